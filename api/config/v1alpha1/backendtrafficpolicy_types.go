@@ -0,0 +1,429 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+const (
+	// KindBackendTrafficPolicy is the name of the BackendTrafficPolicy kind.
+	KindBackendTrafficPolicy = "BackendTrafficPolicy"
+
+	// DefaultShadowHeader is the default header carrying the request ID of
+	// the original requests on the mirrored requests.
+	DefaultShadowHeader = "x-envoy-gateway-shadow-of"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// BackendTrafficPolicy configures the traffic between the proxy and the
+// backends of the targeted resource.
+type BackendTrafficPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BackendTrafficPolicySpec `json:"spec"`
+
+	// Status defines the status of the policy for the resources it applies
+	// to.
+	//
+	// +optional
+	Status PolicyStatus `json:"status,omitempty"`
+}
+
+// BackendTrafficPolicySpec defines the desired state of BackendTrafficPolicy.
+type BackendTrafficPolicySpec struct {
+	// TargetRef identifies the resource the policy applies to. A Gateway or
+	// an HTTPRoute in the namespace of the policy is supported. When several
+	// policies target the same resource, the oldest one takes effect. A policy
+	// targeting a Gateway applies to the HTTPRoutes attached to the Gateway,
+	// and each feature configured by the policy targeting an HTTPRoute
+	// overrides the same feature of the policy targeting its Gateway, while
+	// the other features are inherited. Either TargetRef or TargetSelectors
+	// must be set.
+	//
+	// +optional
+	TargetRef *gwapiv1a2.PolicyTargetReference `json:"targetRef,omitempty"`
+
+	// TargetSelectors select the resources the policy applies to by their
+	// labels, in addition to the TargetRef. The same kinds of resources as
+	// the TargetRef are supported.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	TargetSelectors []TargetSelector `json:"targetSelectors,omitempty"`
+
+	// ConnectTimeout is the timeout for establishing the connections to the
+	// backends, overriding the connect timeout of the proxy.
+	//
+	// +optional
+	ConnectTimeout *metav1.Duration `json:"connectTimeout,omitempty"`
+
+	// TLS configures TLS on the connections to the backends. If unspecified,
+	// the connections to the backends are not encrypted.
+	//
+	// +optional
+	TLS *BackendTLSConfig `json:"tls,omitempty"`
+
+	// LoadBalancer configures the load balancing of the requests across the
+	// backends. If unspecified, the requests are balanced round-robin.
+	//
+	// +optional
+	LoadBalancer *LoadBalancer `json:"loadBalancer,omitempty"`
+
+	// DynamicForwardProxy forwards the requests to the hosts they are
+	// addressed to, resolved with DNS, instead of the backends of the
+	// HTTPRoute, turning the route into a forward proxy. The backendRefs of
+	// the HTTPRoute are ignored. The DNS cache resolving the hosts is
+	// configured by the EnvoyProxy of the GatewayClass.
+	//
+	// +optional
+	DynamicForwardProxy *DynamicForwardProxy `json:"dynamicForwardProxy,omitempty"`
+
+	// Transformation transforms the requests forwarded to the backends and
+	// their responses, adapting legacy clients to new backends. If
+	// unspecified, the requests and responses are forwarded unchanged.
+	//
+	// +optional
+	Transformation *Transformation `json:"transformation,omitempty"`
+
+	// ShadowComparison tags the requests mirrored by the RequestMirror filters
+	// of the HTTPRoute and logs their responses, so that the responses of the
+	// mirror backends can be compared with the ones of the backends offline.
+	// If unspecified, the mirrored requests are neither tagged nor logged.
+	//
+	// +optional
+	ShadowComparison *ShadowComparison `json:"shadowComparison,omitempty"`
+
+	// BandwidthLimit caps the throughput of the requests and responses of the
+	// HTTPRoute, throttling tenants at the byte level rather than at the
+	// request level. If unspecified, the throughput is not limited.
+	//
+	// +optional
+	BandwidthLimit *BandwidthLimit `json:"bandwidthLimit,omitempty"`
+
+	// RuntimeWeights exposes the weights of the backends of the HTTPRoute in
+	// the runtime of the proxies, so that a progressive delivery controller
+	// can adjust them without updating the HTTPRoute. If unspecified, the
+	// backends are only weighted by their backendRefs.
+	//
+	// +optional
+	RuntimeWeights *RuntimeWeights `json:"runtimeWeights,omitempty"`
+
+	// ExtProc configures the processing of the requests and responses of the
+	// HTTPRoute by an external service. If unspecified, the requests and
+	// responses are not processed externally.
+	//
+	// +optional
+	ExtProc *ExtProc `json:"extProc,omitempty"`
+}
+
+// ExtProc defines the external processing service the headers of the
+// requests and responses of a route are sent to, which may mutate them or
+// respond to the requests.
+type ExtProc struct {
+	// BackendRef references the Service of the processing service,
+	// implementing the Envoy gRPC External Processing API. Only a Service in
+	// the namespace of the policy may be referenced.
+	BackendRef gwapiv1b1.BackendObjectReference `json:"backendRef"`
+
+	// FailureMode defines whether requests are forwarded unprocessed when the
+	// processing service is unreachable or fails. Defaults to FailClosed.
+	//
+	// +optional
+	FailureMode FailureMode `json:"failureMode,omitempty"`
+}
+
+// RuntimeWeights defines the runtime keys of the weights of the backends of a
+// route. The requests are split across a cluster per backend, whose weight is
+// read from the runtime key made of the key prefix, a dot and the name of the
+// cluster, and defaults to the weight of the backendRef while the key is
+// unset.
+type RuntimeWeights struct {
+	// KeyPrefix is the prefix of the runtime keys of the weights.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=128
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_-]+(\.[a-zA-Z0-9_-]+)*$`
+	KeyPrefix string `json:"keyPrefix"`
+}
+
+// BandwidthLimitMode selects the bodies whose throughput is limited.
+// +kubebuilder:validation:Enum=Request;Response;RequestAndResponse
+type BandwidthLimitMode string
+
+const (
+	// RequestBandwidthLimitMode limits the throughput of the request bodies.
+	RequestBandwidthLimitMode BandwidthLimitMode = "Request"
+	// ResponseBandwidthLimitMode limits the throughput of the response
+	// bodies.
+	ResponseBandwidthLimitMode BandwidthLimitMode = "Response"
+	// RequestAndResponseBandwidthLimitMode limits the throughput of both the
+	// request and the response bodies, each up to the limit.
+	RequestAndResponseBandwidthLimitMode BandwidthLimitMode = "RequestAndResponse"
+)
+
+// BandwidthLimit defines the throughput cap of the requests and responses of
+// a route. The throughput is shared by all the requests of the route served by
+// a proxy, and the bodies exceeding it are delayed, not rejected.
+type BandwidthLimit struct {
+	// LimitKiBps is the maximum throughput, in KiB per second.
+	//
+	// +kubebuilder:validation:Minimum=1
+	LimitKiBps uint64 `json:"limitKiBps"`
+
+	// Mode selects the bodies whose throughput is limited. If unspecified,
+	// defaults to RequestAndResponse.
+	//
+	// +optional
+	Mode *BandwidthLimitMode `json:"mode,omitempty"`
+
+	// FillInterval is the interval at which the throughput budget is
+	// replenished, between 20ms and 1s. Shorter intervals smooth the
+	// throughput at the expense of CPU. If unspecified, defaults to 50ms.
+	//
+	// +optional
+	FillInterval *metav1.Duration `json:"fillInterval,omitempty"`
+}
+
+// ShadowComparison defines the tagging and the logging of the mirrored
+// requests. The mirrored requests keep the request ID of the original
+// requests, which is logged by the access logs of both, so that each mirrored
+// request is paired with its original request.
+type ShadowComparison struct {
+	// Header is the name of the header added to the mirrored requests, whose
+	// value is the request ID of the original request. Defaults to
+	// "x-envoy-gateway-shadow-of".
+	//
+	// +optional
+	Header *string `json:"header,omitempty"`
+}
+
+// Transformation defines the transformations of the requests and responses of
+// a route. The request headers are copied into query parameters first, then
+// the query parameters into request headers, and the bodies are rendered last.
+//
+// The body templates are rendered with the original body substituted for
+// "{{body}}", the value of a header for "{{header.NAME}}" and, in request body
+// templates only, the value of a query parameter for "{{query.NAME}}". Missing
+// values are substituted with an empty string. Transforming a body buffers it
+// entirely in the proxy, and only the requests and responses with a body are
+// transformed.
+type Transformation struct {
+	// HeadersToQuery copies request headers into query parameters, replacing
+	// the existing query parameters of the same name.
+	//
+	// +optional
+	HeadersToQuery []HeaderQueryMapping `json:"headersToQuery,omitempty"`
+
+	// QueryToHeaders copies query parameters, URL decoded, into request
+	// headers, replacing the existing headers of the same name.
+	//
+	// +optional
+	QueryToHeaders []HeaderQueryMapping `json:"queryToHeaders,omitempty"`
+
+	// RequestBody is the template of the body of the requests forwarded to
+	// the backends.
+	//
+	// +optional
+	RequestBody *string `json:"requestBody,omitempty"`
+
+	// ResponseBody is the template of the body of the responses of the
+	// backends.
+	//
+	// +optional
+	ResponseBody *string `json:"responseBody,omitempty"`
+}
+
+// HeaderQueryMapping defines the copy of a request header into a query
+// parameter, or the other way around. Nothing is copied if the source is
+// missing.
+type HeaderQueryMapping struct {
+	// Header is the name of the request header.
+	Header string `json:"header"`
+
+	// QueryParam is the name of the query parameter.
+	QueryParam string `json:"queryParam"`
+
+	// Remove removes the source of the copy, i.e. the header copied into a
+	// query parameter or the query parameter copied into a header.
+	//
+	// +optional
+	Remove bool `json:"remove,omitempty"`
+}
+
+// DynamicForwardProxy defines how the requests are forwarded to the hosts
+// they are addressed to.
+type DynamicForwardProxy struct {
+	// HostHeader is the name of the request header holding the host, and
+	// optionally the port, the requests are forwarded to. If unspecified, the
+	// requests are forwarded to the host of their Host header.
+	//
+	// +optional
+	HostHeader *string `json:"hostHeader,omitempty"`
+}
+
+// LoadBalancerType is the type of a load balancer.
+// +kubebuilder:validation:Enum=RoundRobin;LeastRequest;Random;RingHash;Maglev
+type LoadBalancerType string
+
+const (
+	// RoundRobinLoadBalancerType selects the backends in turn.
+	RoundRobinLoadBalancerType LoadBalancerType = "RoundRobin"
+	// LeastRequestLoadBalancerType selects the backend with the fewest active
+	// requests out of two random ones.
+	LeastRequestLoadBalancerType LoadBalancerType = "LeastRequest"
+	// RandomLoadBalancerType selects a random backend.
+	RandomLoadBalancerType LoadBalancerType = "Random"
+	// RingHashLoadBalancerType consistently hashes the requests to the
+	// backends with a ring.
+	RingHashLoadBalancerType LoadBalancerType = "RingHash"
+	// MaglevLoadBalancerType consistently hashes the requests to the
+	// backends with a Maglev lookup table.
+	MaglevLoadBalancerType LoadBalancerType = "Maglev"
+)
+
+// LoadBalancer defines the load balancing of the requests across the backends.
+type LoadBalancer struct {
+	// Type is the type of the load balancer.
+	Type LoadBalancerType `json:"type"`
+
+	// ConsistentHash configures the RingHash and Maglev load balancers. If
+	// unspecified, the requests are hashed on their source IP with the Envoy
+	// defaults.
+	//
+	// +optional
+	ConsistentHash *ConsistentHash `json:"consistentHash,omitempty"`
+}
+
+// EndpointHashKeyType is the key the backends are hashed on by the consistent
+// hashing load balancers.
+// +kubebuilder:validation:Enum=Address;BackendRef
+type EndpointHashKeyType string
+
+const (
+	// AddressEndpointHashKeyType hashes the backends on their address.
+	AddressEndpointHashKeyType EndpointHashKeyType = "Address"
+	// BackendRefEndpointHashKeyType hashes the backends on their namespace,
+	// name and port, so that the requests keep being hashed to the same
+	// backends when their addresses change.
+	BackendRefEndpointHashKeyType EndpointHashKeyType = "BackendRef"
+)
+
+// ConsistentHash defines the consistent hashing of the requests to the
+// backends. Larger tables and rings spread the requests more evenly, and
+// reassign fewer requests when the backends change, at the cost of memory.
+type ConsistentHash struct {
+	// Header is the name of the request header hashed. If unspecified, the
+	// source IP of the requests is hashed.
+	//
+	// +optional
+	Header *string `json:"header,omitempty"`
+
+	// EndpointHashKey is the key the backends are hashed on. Defaults to
+	// Address.
+	//
+	// +optional
+	EndpointHashKey *EndpointHashKeyType `json:"endpointHashKey,omitempty"`
+
+	// TableSize is the size of the lookup table of the Maglev load balancer,
+	// which must be a prime number. Defaults to 65537.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=5000011
+	TableSize *uint64 `json:"tableSize,omitempty"`
+
+	// MinimumRingSize is the minimum number of entries of the ring of the
+	// RingHash load balancer. Defaults to 1024.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=8388608
+	MinimumRingSize *uint64 `json:"minimumRingSize,omitempty"`
+
+	// MaximumRingSize is the maximum number of entries of the ring of the
+	// RingHash load balancer. Defaults to 8M.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=8388608
+	MaximumRingSize *uint64 `json:"maximumRingSize,omitempty"`
+}
+
+// BackendTLSConfig defines the TLS configuration of the connections to the
+// backends. The referenced Secrets must be in the namespace of the policy, and
+// changes to them are delivered to the proxies through SDS, without
+// reconfiguring the clusters or draining the existing connections.
+type BackendTLSConfig struct {
+	// ClientCertificateRef references a Secret of type kubernetes.io/tls
+	// holding the certificate and private key the proxy presents to backends
+	// requiring client authentication. If unspecified, no client certificate
+	// is presented.
+	//
+	// +optional
+	ClientCertificateRef *gwapiv1b1.SecretObjectReference `json:"clientCertificateRef,omitempty"`
+
+	// CACertificateRef references a Secret holding, under the ca.crt key, the
+	// PEM encoded CA certificates used to verify the certificates of the
+	// backends. If unspecified, the certificates of the backends are not
+	// verified.
+	//
+	// +optional
+	CACertificateRef *gwapiv1b1.SecretObjectReference `json:"caCertificateRef,omitempty"`
+
+	// SNI is the server name sent to the backends during the TLS handshake.
+	//
+	// +optional
+	SNI *string `json:"sni,omitempty"`
+
+	// SPIFFE presents the X.509 SVID of the proxy to the backends and verifies
+	// their certificates against the SPIFFE trust bundle, both fetched from
+	// the Workload API configured in the Envoy Gateway configuration. It takes
+	// precedence over ClientCertificateRef and CACertificateRef.
+	//
+	// +optional
+	SPIFFE bool `json:"spiffe,omitempty"`
+
+	// ALPNProtocols are the application protocols offered to the backends
+	// during the TLS handshake, in order of preference. When both h2 and
+	// http/1.1 are offered, the requests are forwarded to each backend with
+	// the protocol it negotiates, instead of the protocol of the listener. If
+	// unspecified, no protocol is offered.
+	//
+	// +optional
+	ALPNProtocols []ALPNProtocol `json:"alpnProtocols,omitempty"`
+}
+
+// ALPNProtocol is an application protocol negotiated with ALPN.
+//
+// +kubebuilder:validation:Enum=h2;http/1.1
+type ALPNProtocol string
+
+const (
+	// ALPNProtocolHTTP2 is the application protocol of HTTP/2.
+	ALPNProtocolHTTP2 ALPNProtocol = "h2"
+
+	// ALPNProtocolHTTP11 is the application protocol of HTTP/1.1.
+	ALPNProtocolHTTP11 ALPNProtocol = "http/1.1"
+)
+
+//+kubebuilder:object:root=true
+
+// BackendTrafficPolicyList contains a list of BackendTrafficPolicy.
+type BackendTrafficPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackendTrafficPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackendTrafficPolicy{}, &BackendTrafficPolicyList{})
+}
@@ -0,0 +1,364 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+const (
+	// KindClientTrafficPolicy is the name of the ClientTrafficPolicy kind.
+	KindClientTrafficPolicy = "ClientTrafficPolicy"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ClientTrafficPolicy configures the traffic between the clients and the
+// proxy on the listeners of the targeted resource.
+type ClientTrafficPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClientTrafficPolicySpec `json:"spec"`
+
+	// Status defines the status of the policy for the resources it applies
+	// to.
+	//
+	// +optional
+	Status PolicyStatus `json:"status,omitempty"`
+}
+
+// ClientTrafficPolicySpec defines the desired state of ClientTrafficPolicy.
+type ClientTrafficPolicySpec struct {
+	// TargetRef identifies the resource the policy applies to. Only a Gateway
+	// in the namespace of the policy is supported, and the policy applies to
+	// its HTTP and HTTPS listeners, except for the UDP settings applying to its
+	// UDP listeners. When several policies target the same
+	// resource, the oldest one takes effect. Either TargetRef or
+	// TargetSelectors must be set.
+	//
+	// +optional
+	TargetRef *gwapiv1a2.PolicyTargetReference `json:"targetRef,omitempty"`
+
+	// TargetSelectors select the resources the policy applies to by their
+	// labels, in addition to the TargetRef. The same kinds of resources as
+	// the TargetRef are supported.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	TargetSelectors []TargetSelector `json:"targetSelectors,omitempty"`
+
+	// Headers limits the headers of the requests sent by the clients.
+	//
+	// +optional
+	Headers *HeaderLimits `json:"headers,omitempty"`
+
+	// Draining configures how the connections of the listeners are drained
+	// when the listeners are updated or removed. If unspecified, the Envoy
+	// defaults apply.
+	//
+	// +optional
+	Draining *ConnectionDraining `json:"draining,omitempty"`
+
+	// Maintenance puts the targeted Gateway into maintenance mode.
+	//
+	// +optional
+	Maintenance *Maintenance `json:"maintenance,omitempty"`
+
+	// SocketOptions configures the sockets of the listeners, for
+	// performance-focused deployments. If unspecified, the Envoy defaults
+	// apply.
+	//
+	// +optional
+	SocketOptions *SocketOptions `json:"socketOptions,omitempty"`
+
+	// HTTP1 configures the HTTP/1 connections of the clients to the HTTP and
+	// HTTPS listeners.
+	//
+	// +optional
+	HTTP1 *HTTP1Settings `json:"http1,omitempty"`
+
+	// HTTP2 configures the HTTP/2 connections of the clients to the HTTP
+	// listeners.
+	//
+	// +optional
+	HTTP2 *HTTP2Settings `json:"http2,omitempty"`
+
+	// HeaderHandling configures how the listeners handle the casing, the
+	// duplicates and the hop-by-hop headers, e.g. to comply with strict
+	// security audits. If unspecified, the Envoy defaults apply.
+	//
+	// +optional
+	HeaderHandling *HeaderHandling `json:"headerHandling,omitempty"`
+
+	// UDP configures the sessions of the clients on the UDP listeners. If
+	// unspecified, the Envoy defaults apply.
+	//
+	// +optional
+	UDP *UDPSettings `json:"udp,omitempty"`
+}
+
+// HeaderLimits defines the limits of the request headers. Requests exceeding
+// any of the limits are rejected with a 431 response, without being forwarded
+// to the backends.
+type HeaderLimits struct {
+	// MaxRequestHeadersKiB is the maximum total size of the request headers,
+	// in KiB. If unspecified, defaults to 60 KiB.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=8192
+	// +optional
+	MaxRequestHeadersKiB *uint32 `json:"maxRequestHeadersKiB,omitempty"`
+
+	// MaxRequestHeadersCount is the maximum number of request headers. If
+	// unspecified, defaults to 100.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxRequestHeadersCount *uint32 `json:"maxRequestHeadersCount,omitempty"`
+}
+
+// DrainType selects the events draining the connections of the listeners.
+// +kubebuilder:validation:Enum=Default;ModifyOnly
+type DrainType string
+
+const (
+	// DefaultDrainType drains the connections when the listeners are updated
+	// or removed, and when the proxy is shutting down.
+	DefaultDrainType DrainType = "Default"
+	// ModifyOnlyDrainType drains the connections only when the listeners are
+	// updated or removed.
+	ModifyOnlyDrainType DrainType = "ModifyOnly"
+)
+
+// ConnectionDraining defines how the connections of the listeners are drained.
+// Draining connections are asked to close, with a GOAWAY frame for HTTP/2 or a
+// "Connection: close" header for HTTP/1.1, instead of being reset, so that the
+// in-flight requests of the long-lived connections can complete.
+type ConnectionDraining struct {
+	// Type selects the events draining the connections of the listeners. If
+	// unspecified, defaults to Default.
+	//
+	// +optional
+	Type *DrainType `json:"type,omitempty"`
+
+	// Timeout is the time given to the draining connections to close
+	// gracefully before being closed forcibly. If unspecified, defaults to 5
+	// seconds.
+	//
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// Maintenance defines the maintenance mode of a Gateway. The requests to the
+// HTTP and HTTPS listeners of a Gateway in maintenance mode receive a 503
+// response instead of being routed, without the Gateway or its routes being
+// modified, and the Gateway has a Maintenance condition set to True.
+type Maintenance struct {
+	// Enabled puts the Gateway into maintenance mode.
+	Enabled bool `json:"enabled"`
+
+	// Body is the body of the 503 responses, e.g. a maintenance page. If
+	// unspecified, the responses have no body.
+	//
+	// +kubebuilder:validation:MaxLength=4096
+	// +optional
+	Body *string `json:"body,omitempty"`
+
+	// ContentType is the content type of the body. If unspecified, defaults
+	// to text/plain.
+	//
+	// +optional
+	ContentType *string `json:"contentType,omitempty"`
+}
+
+// SocketOptions defines the options of the sockets of the listeners. The
+// listeners of a Gateway sharing a port share their sockets, so the options
+// apply to all of them.
+type SocketOptions struct {
+	// ReusePort binds a listening socket per worker thread of the proxy with
+	// SO_REUSEPORT, letting the kernel balance the connections across the
+	// worker threads. If unspecified, defaults to true.
+	//
+	// +optional
+	ReusePort *bool `json:"reusePort,omitempty"`
+
+	// TCPFastOpenQueueLength enables TCP Fast Open on the listening sockets,
+	// with the provided maximum number of pending Fast Open connections. If
+	// unspecified or zero, TCP Fast Open is disabled.
+	//
+	// +optional
+	TCPFastOpenQueueLength *uint32 `json:"tcpFastOpenQueueLength,omitempty"`
+
+	// Freebind binds the listening sockets with IP_FREEBIND, allowing the
+	// listeners to bind to addresses not yet assigned to the host.
+	//
+	// +optional
+	Freebind bool `json:"freebind,omitempty"`
+
+	// DSCP is the Differentiated Services Code Point the packets sent to the
+	// clients are marked with. If unspecified, the packets are not marked.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=63
+	// +optional
+	DSCP *uint32 `json:"dscp,omitempty"`
+}
+
+// HTTP1Settings defines the settings of the HTTP/1 connections of the clients.
+type HTTP1Settings struct {
+	// EnableHTTP10 accepts the HTTP/1.0 requests of legacy clients, which
+	// are otherwise rejected with a 426 response.
+	//
+	// +optional
+	EnableHTTP10 bool `json:"enableHTTP10,omitempty"`
+
+	// HTTP10DefaultHost is the host the HTTP/1.0 requests without a Host
+	// header are routed with. If unspecified, these requests are rejected
+	// with a 400 response. Only applies if EnableHTTP10 is set.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	HTTP10DefaultHost *string `json:"http10DefaultHost,omitempty"`
+}
+
+// HTTP2Settings defines the settings of the HTTP/2 connections of the clients.
+type HTTP2Settings struct {
+	// H2C serves the clients opening cleartext HTTP/2 connections with prior
+	// knowledge (h2c) on the HTTP listeners, e.g. the gRPC clients without
+	// TLS, and forwards their requests to the backends over HTTP/2. The
+	// protocol of each connection is inferred, so the HTTP/1 clients are
+	// still served, but their requests are also forwarded over HTTP/2.
+	//
+	// +optional
+	H2C bool `json:"h2c,omitempty"`
+}
+
+// DuplicateHeadersAction selects how the requests with duplicate headers are
+// handled.
+// +kubebuilder:validation:Enum=Preserve;Concatenate;Reject
+type DuplicateHeadersAction string
+
+const (
+	// PreserveDuplicateHeaders forwards the duplicate headers as is.
+	PreserveDuplicateHeaders DuplicateHeadersAction = "Preserve"
+	// ConcatenateDuplicateHeaders concatenates the values of the duplicate
+	// headers into a single comma-separated header.
+	ConcatenateDuplicateHeaders DuplicateHeadersAction = "Concatenate"
+	// RejectDuplicateHeaders rejects the requests with duplicate headers
+	// with a 400 response.
+	RejectDuplicateHeaders DuplicateHeadersAction = "Reject"
+)
+
+// HeaderHandling defines how the listeners handle the headers of the requests
+// and responses.
+type HeaderHandling struct {
+	// ProperCaseHeaders writes the header names of the HTTP/1 responses in
+	// proper case, e.g. Content-Type, for the clients expecting it, instead
+	// of lower case.
+	//
+	// +optional
+	ProperCaseHeaders bool `json:"properCaseHeaders,omitempty"`
+
+	// DuplicateHeaders selects how the requests with several headers of the
+	// same name are handled. The Cookie headers are always accepted, and
+	// concatenated with semicolons. If unspecified, defaults to Preserve.
+	//
+	// +optional
+	DuplicateHeaders *DuplicateHeadersAction `json:"duplicateHeaders,omitempty"`
+
+	// HopByHopHeaders are the names of the headers stripped from the requests
+	// and responses, in addition to the hop-by-hop headers always stripped by
+	// Envoy, e.g. Connection, Keep-Alive or Upgrade. The Host header cannot be
+	// stripped.
+	//
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	HopByHopHeaders []gwapiv1a2.HTTPHeaderName `json:"hopByHopHeaders,omitempty"`
+}
+
+// UDPSessionAffinity selects how the sessions of the clients on the UDP
+// listeners are pinned to the backends.
+// +kubebuilder:validation:Enum=None;SourceIP
+type UDPSessionAffinity string
+
+const (
+	// NoneUDPSessionAffinity balances the sessions across the backends
+	// round-robin.
+	NoneUDPSessionAffinity UDPSessionAffinity = "None"
+	// SourceIPUDPSessionAffinity hashes the source IP of the sessions, so
+	// that the datagrams of a client keep being forwarded to the same backend
+	// across sessions, e.g. for DTLS or game traffic.
+	SourceIPUDPSessionAffinity UDPSessionAffinity = "SourceIP"
+)
+
+// UDPSettings defines the settings of the sessions of the clients on the UDP
+// listeners. A session is made of the datagrams exchanged between a client
+// address and a backend.
+type UDPSettings struct {
+	// SessionAffinity selects how the sessions are pinned to the backends. If
+	// unspecified, defaults to None.
+	//
+	// +optional
+	SessionAffinity *UDPSessionAffinity `json:"sessionAffinity,omitempty"`
+
+	// IdleTimeout is the time after which a session with no datagrams is
+	// closed. If unspecified, defaults to 60 seconds.
+	//
+	// +optional
+	IdleTimeout *metav1.Duration `json:"idleTimeout,omitempty"`
+
+	// DNS makes the UDP listeners answer the DNS queries for the domains of
+	// its records themselves. The queries for the other domains are forwarded
+	// to the backends of the UDPRoutes, which must be DNS resolvers.
+	//
+	// +optional
+	DNS *UDPDNSSettings `json:"dns,omitempty"`
+}
+
+// UDPDNSSettings defines the DNS records answered by the UDP listeners.
+type UDPDNSSettings struct {
+	// Records are the DNS records answered by the listeners.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	Records []UDPDNSRecord `json:"records"`
+
+	// TTL is the TTL of the answers. If unspecified, defaults to 300 seconds.
+	//
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
+
+// UDPDNSRecord defines the addresses a domain resolves to.
+type UDPDNSRecord struct {
+	// Domain is the domain name of the record, e.g. "www.example.com".
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Domain string `json:"domain"`
+
+	// Addresses are the IPv4 or IPv6 addresses the domain resolves to.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Addresses []string `json:"addresses"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClientTrafficPolicyList contains a list of ClientTrafficPolicy.
+type ClientTrafficPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClientTrafficPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClientTrafficPolicy{}, &ClientTrafficPolicyList{})
+}
@@ -6,6 +6,9 @@
 package v1alpha1
 
 import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -14,6 +17,42 @@ const (
 	KindEnvoyGateway = "EnvoyGateway"
 	// GatewayControllerName is the name of the GatewayClass controller.
 	GatewayControllerName = "gateway.envoyproxy.io/gatewayclass-controller"
+	// DefaultXdsServerAddress is the default listening address of the xDS server.
+	DefaultXdsServerAddress = "0.0.0.0"
+	// DefaultXdsServerPort is the default listening port of the xDS server.
+	DefaultXdsServerPort = 18000
+	// DefaultXdsServerCertificatePath is the default path of the xDS server certificate.
+	DefaultXdsServerCertificatePath = "/certs/tls.crt"
+	// DefaultXdsServerPrivateKeyPath is the default path of the xDS server private key.
+	DefaultXdsServerPrivateKeyPath = "/certs/tls.key"
+	// DefaultXdsServerCACertificatePath is the default path of the xDS server trusted CA certificate.
+	DefaultXdsServerCACertificatePath = "/certs/ca.crt"
+	// DefaultXdsSnapshotWarmupPeriod is the default duration the persisted xDS
+	// snapshots are served for after a restart.
+	DefaultXdsSnapshotWarmupPeriod = 10 * time.Second
+	// DefaultXdsAuditGenerations is the default number of generations of the
+	// xDS snapshot of each Gateway kept in the audit trail.
+	DefaultXdsAuditGenerations = 20
+	// DefaultXdsHandoffPeriod is the default period the xDS streams of the
+	// connected Envoy proxies are closed over when the xDS server shuts down.
+	DefaultXdsHandoffPeriod = 5 * time.Second
+	// DefaultTrafficStatsWindow is the default period the traffic statistics
+	// of the proxies are aggregated over.
+	DefaultTrafficStatsWindow = time.Minute
+	// DefaultLogLevel is the default log level of the components of Envoy Gateway.
+	DefaultLogLevel = LogLevelInfo
+	// DefaultSecretRefreshInterval is the default interval the TLS certificates
+	// of the external secret backends are refreshed at.
+	DefaultSecretRefreshInterval = time.Minute
+	// DefaultVaultMount is the default mount path of the Vault KV version 2
+	// secrets engine holding the TLS certificates.
+	DefaultVaultMount = "secret"
+	// DefaultVaultKubernetesAuthMount is the default mount path of the Vault
+	// Kubernetes auth method.
+	DefaultVaultKubernetesAuthMount = "kubernetes"
+	// DefaultGatewayClassName is the default name of the GatewayClass created
+	// by Envoy Gateway.
+	DefaultGatewayClassName = "eg"
 )
 
 //+kubebuilder:object:root=true
@@ -40,6 +79,369 @@ type EnvoyGatewaySpec struct {
 	//
 	// +optional
 	Provider *Provider `json:"provider,omitempty"`
+
+	// XdsServer defines the desired configuration of the xDS server that Envoy
+	// proxies connect to. If unset, default configuration parameters will apply.
+	//
+	// +optional
+	XdsServer *XdsServer `json:"xdsServer,omitempty"`
+
+	// RateLimit defines the configuration of the global rate limit service.
+	// If set, Envoy Gateway deploys the rate limit service and configures the
+	// managed Envoy proxies to use it. If unset, global rate limiting is
+	// disabled.
+	//
+	// +optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+
+	// Timeouts defines the default timeouts of all the managed Envoy proxies.
+	// They can be overridden per GatewayClass by the EnvoyProxy referenced by
+	// its parametersRef. If unset, Envoy defaults apply, except for the
+	// connect timeout which defaults to 5s.
+	//
+	// +optional
+	Timeouts *Timeouts `json:"timeouts,omitempty"`
+
+	// SPIFFE configures the managed Envoy proxies to source their workload
+	// identity from a SPIFFE Workload API, e.g. served by the SPIRE agent,
+	// instead of Kubernetes Secrets. If unset, the proxies use the xDS client
+	// certificate generated by the certgen job.
+	//
+	// +optional
+	SPIFFE *SPIFFE `json:"spiffe,omitempty"`
+
+	// Mesh defines the experimental mesh mode of Envoy Gateway. If unset,
+	// only the routes attached to Gateways are translated.
+	//
+	// +optional
+	Mesh *Mesh `json:"mesh,omitempty"`
+
+	// Limits defines the limits of the resources translated for the managed
+	// Envoy proxies, protecting a control plane shared by several tenants
+	// from a tenant exploding the size of the xDS configuration. If unset,
+	// the translated resources are not limited.
+	//
+	// +optional
+	Limits *TranslationLimits `json:"limits,omitempty"`
+
+	// Tenancy restricts the namespaces allowed to attach routes to the
+	// Gateways of each tenant, on top of the allowedRoutes of their listeners.
+	// If unset, the routes attach as allowed by the listeners.
+	//
+	// +optional
+	Tenancy *Tenancy `json:"tenancy,omitempty"`
+
+	// TrafficStats enables the aggregation of the traffic statistics of the
+	// managed Envoy proxies into a GatewayTrafficStats resource per Gateway,
+	// for the dashboards that can't scrape the proxies. If unset, the
+	// statistics are not aggregated.
+	//
+	// +optional
+	TrafficStats *TrafficStats `json:"trafficStats,omitempty"`
+
+	// Logging defines the log levels of the components of Envoy Gateway.
+	// If unspecified, all the components log at the info level.
+	//
+	// +optional
+	Logging *EnvoyGatewayLogging `json:"logging,omitempty"`
+
+	// SecretBackend defines where the TLS certificates referenced by the
+	// listeners of the Gateways are retrieved from. If unspecified, they are
+	// retrieved from Kubernetes Secrets.
+	//
+	// +optional
+	SecretBackend *SecretBackend `json:"secretBackend,omitempty"`
+}
+
+// SecretBackend defines the backend the TLS certificates referenced by the
+// certificateRefs of the Gateway listeners are retrieved from. The
+// certificateRefs keep referencing Secrets by namespace and name, including
+// the ReferenceGrants required by cross namespace references, and the
+// backends resolve them into the certificate and private key of the listeners.
+type SecretBackend struct {
+	// Type is the type of the secret backend.
+	//
+	// +unionDiscriminator
+	Type SecretBackendType `json:"type"`
+
+	// Vault defines the configuration of the HashiCorp Vault secret backend.
+	//
+	// +optional
+	Vault *VaultSecretBackend `json:"vault,omitempty"`
+
+	// CSI defines the configuration of the Secrets Store CSI secret backend.
+	//
+	// +optional
+	CSI *CSISecretBackend `json:"csi,omitempty"`
+
+	// RefreshInterval is the interval the certificates of the Vault and CSI
+	// backends are retrieved again at, since their changes aren't watched. If
+	// unspecified, defaults to 1 minute.
+	//
+	// +optional
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// SecretBackendType defines the types of secret backends supported by Envoy
+// Gateway.
+// +kubebuilder:validation:Enum=Kubernetes;Vault;CSI
+type SecretBackendType string
+
+const (
+	// SecretBackendTypeKubernetes retrieves the certificates from Kubernetes
+	// Secrets of type kubernetes.io/tls.
+	SecretBackendTypeKubernetes SecretBackendType = "Kubernetes"
+	// SecretBackendTypeVault retrieves the certificates from a HashiCorp
+	// Vault KV version 2 secrets engine.
+	SecretBackendTypeVault SecretBackendType = "Vault"
+	// SecretBackendTypeCSI retrieves the certificates from the files mounted
+	// into the Envoy Gateway pod by the Secrets Store CSI driver.
+	SecretBackendTypeCSI SecretBackendType = "CSI"
+)
+
+// VaultSecretBackend defines how the certificates are retrieved from Vault.
+// The certificate referenced as namespace/name is read from the
+// "<mount>/data/<pathPrefix>/<namespace>/<name>" path, whose "tls.crt" and
+// "tls.key" keys hold the PEM encoded certificate chain and private key.
+// Exactly one of KubernetesAuth and TokenPath must be set.
+type VaultSecretBackend struct {
+	// Address is the URL of the Vault server, e.g.
+	// "https://vault.vault.svc:8200".
+	Address string `json:"address"`
+
+	// Mount is the mount path of the KV version 2 secrets engine. If
+	// unspecified, defaults to "secret".
+	//
+	// +optional
+	Mount string `json:"mount,omitempty"`
+
+	// PathPrefix is the path the certificates are stored under in the
+	// secrets engine, e.g. "envoy-gateway".
+	//
+	// +optional
+	PathPrefix string `json:"pathPrefix,omitempty"`
+
+	// CACertificatePath is the path of the CA certificate bundle trusted to
+	// verify the certificate of the Vault server. If unspecified, the system
+	// roots are trusted.
+	//
+	// +optional
+	CACertificatePath *string `json:"caCertificatePath,omitempty"`
+
+	// KubernetesAuth authenticates Envoy Gateway to Vault with its
+	// Kubernetes service account token.
+	//
+	// +optional
+	KubernetesAuth *VaultKubernetesAuth `json:"kubernetesAuth,omitempty"`
+
+	// TokenPath is the path of a file holding the Vault token of Envoy
+	// Gateway, e.g. written by the Vault agent. The file is read again for
+	// each request, so the token can be renewed on disk.
+	//
+	// +optional
+	TokenPath *string `json:"tokenPath,omitempty"`
+}
+
+// VaultKubernetesAuth defines the Vault Kubernetes auth method.
+type VaultKubernetesAuth struct {
+	// Role is the Vault role bound to the service account of Envoy Gateway.
+	Role string `json:"role"`
+
+	// Mount is the mount path of the Kubernetes auth method. If unspecified,
+	// defaults to "kubernetes".
+	//
+	// +optional
+	Mount string `json:"mount,omitempty"`
+}
+
+// CSISecretBackend defines how the certificates are read from the volume
+// mounted into the Envoy Gateway pod by the Secrets Store CSI driver. The
+// certificate referenced as namespace/name is read from the
+// "<namespace>_<name>.crt" and "<namespace>_<name>.key" files of the volume,
+// which are set as the object aliases of the SecretProviderClass.
+type CSISecretBackend struct {
+	// Path is the mount path of the CSI volume in the Envoy Gateway
+	// container, e.g. "/mnt/secrets-store".
+	Path string `json:"path"`
+}
+
+// EnvoyGatewayLogging defines the structured logging of Envoy Gateway. Each
+// component logs at its own level, which can be adjusted at runtime through
+// the debug endpoint.
+type EnvoyGatewayLogging struct {
+	// Level is the log level of all the components, unless overridden by
+	// Components. If unspecified, defaults to "info".
+	//
+	// +optional
+	Level LogLevel `json:"level,omitempty"`
+
+	// Components are the log levels of specific components of Envoy
+	// Gateway, keyed by component.
+	//
+	// +optional
+	Components map[LogComponent]LogLevel `json:"components,omitempty"`
+
+	// DebugAddress is the address of the debug endpoint serving and
+	// adjusting the log levels at runtime, e.g. "127.0.0.1:19001". If
+	// unspecified, the debug endpoint is disabled.
+	//
+	// +optional
+	DebugAddress *string `json:"debugAddress,omitempty"`
+}
+
+// LogLevel is a log level of Envoy Gateway.
+// +kubebuilder:validation:Enum=debug;info;warn;error
+type LogLevel string
+
+const (
+	// LogLevelDebug is the debug log level.
+	LogLevelDebug LogLevel = "debug"
+	// LogLevelInfo is the info log level.
+	LogLevelInfo LogLevel = "info"
+	// LogLevelWarn is the warn log level.
+	LogLevelWarn LogLevel = "warn"
+	// LogLevelError is the error log level.
+	LogLevelError LogLevel = "error"
+)
+
+// LogComponent is a component of Envoy Gateway logging at its own level.
+// +kubebuilder:validation:Enum=provider;translator;xds;infra
+type LogComponent string
+
+const (
+	// LogComponentProvider is the provider of the resources, e.g. the
+	// Kubernetes controllers and status writers.
+	LogComponentProvider LogComponent = "provider"
+	// LogComponentTranslator is the translator of the Gateway API resources
+	// into the xDS and infrastructure IRs.
+	LogComponentTranslator LogComponent = "translator"
+	// LogComponentXds is the translator of the xDS IR and the xDS server.
+	LogComponentXds LogComponent = "xds"
+	// LogComponentInfra is the manager of the proxy infrastructure.
+	LogComponentInfra LogComponent = "infra"
+)
+
+// TrafficStats defines the aggregation of the traffic statistics of the
+// proxies. The proxies push their statistics to Envoy Gateway over the xDS
+// connection, and the statistics of the proxies of each Gateway are
+// aggregated over a sliding window.
+type TrafficStats struct {
+	// Window is the period the statistics are aggregated over. If
+	// unspecified, defaults to 1 minute.
+	//
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+}
+
+// Tenancy defines the tenants sharing Envoy Gateway. The Gateways in the
+// namespaces of no tenant accept the routes allowed by their listeners. The
+// parentRefs of the routes not allowed by the tenants are reported with an
+// Accepted condition set to False with the "NotAllowedByTenant" reason.
+type Tenancy struct {
+	// Tenants are the tenants of Envoy Gateway.
+	Tenants []Tenant `json:"tenants"`
+}
+
+// Tenant defines the namespaces of a tenant.
+type Tenant struct {
+	// Name is the name of the tenant.
+	Name string `json:"name"`
+
+	// GatewayNamespaces are the namespaces of the Gateways of the tenant.
+	//
+	// +kubebuilder:validation:MinItems=1
+	GatewayNamespaces []string `json:"gatewayNamespaces"`
+
+	// RouteNamespaces are the namespaces allowed to attach routes to the
+	// Gateways of the tenant, in addition to the GatewayNamespaces. If a
+	// namespace holds the Gateways of several tenants, the routes of the
+	// namespaces of all these tenants are allowed.
+	//
+	// +optional
+	RouteNamespaces []string `json:"routeNamespaces,omitempty"`
+}
+
+// TranslationLimits defines the limits of the translated resources. The routes
+// exceeding a limit are not translated, and their parentRefs are reported with
+// an Accepted condition set to False with the "LimitExceeded" reason. The
+// routes are admitted in the order they are translated, the HTTPRoutes first,
+// then the TLSRoutes and the TCPRoutes.
+type TranslationLimits struct {
+	// MaxRoutesPerGateway is the maximum number of routes, of any kind,
+	// attached to a Gateway.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxRoutesPerGateway *int32 `json:"maxRoutesPerGateway,omitempty"`
+
+	// MaxFiltersPerRoute is the maximum number of filters of all the rules of
+	// an HTTPRoute.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxFiltersPerRoute *int32 `json:"maxFiltersPerRoute,omitempty"`
+
+	// MaxClusters is the maximum total number of clusters translated for all
+	// the Gateways. A cluster is translated for each match and hostname of
+	// the HTTPRoute rules forwarding requests to backends, and for each
+	// backend of the TLSRoutes and TCPRoutes.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxClusters *int32 `json:"maxClusters,omitempty"`
+}
+
+// Mesh defines the experimental mesh mode, translating the HTTPRoutes attached
+// to Services (GAMMA) for the east-west traffic to the Services. The mesh
+// proxies are not managed by Envoy Gateway, they fetch their configuration
+// from the xDS server as the "mesh" node cluster.
+type Mesh struct {
+	// Enabled enables the translation of the HTTPRoutes attached to Services.
+	Enabled bool `json:"enabled"`
+}
+
+// SPIFFE defines how the managed Envoy proxies fetch their X.509 SVID and
+// trust bundle from a SPIFFE Workload API. The SVID is presented to the xDS
+// server of Envoy Gateway, and to the backends of the BackendTrafficPolicies
+// enabling SPIFFE.
+//
+// Envoy Gateway itself keeps reading its xDS server certificate and trusted
+// CA from the paths of the xDS server TLS configuration. They are reloaded for
+// each connection, so an SVID kept up to date on disk, e.g. by the SPIFFE
+// helper, can be used.
+type SPIFFE struct {
+	// WorkloadAPISocketPath is the path of the Workload API socket on the
+	// nodes, e.g. "/run/spire/sockets/agent.sock". The directory of the socket
+	// is mounted into the managed Envoy proxies, which fetch their identity
+	// through the Envoy SDS API served on the socket.
+	WorkloadAPISocketPath string `json:"workloadAPISocketPath"`
+
+	// XdsServerID is the SPIFFE ID the certificate of the xDS server of Envoy
+	// Gateway must be issued for, e.g.
+	// "spiffe://example.org/ns/envoy-gateway-system/sa/envoy-gateway".
+	XdsServerID string `json:"xdsServerID"`
+}
+
+// Timeouts defines the default timeouts of the managed Envoy proxies, which
+// apply to all the routes unless they override them.
+type Timeouts struct {
+	// Request is the timeout of a request, from the end of the downstream
+	// request to the end of the upstream response. A zero duration disables
+	// the timeout.
+	//
+	// +optional
+	Request *metav1.Duration `json:"request,omitempty"`
+
+	// Idle is the time after which downstream connections with no active
+	// requests are closed. A zero duration disables the timeout.
+	//
+	// +optional
+	Idle *metav1.Duration `json:"idle,omitempty"`
+
+	// Connect is the timeout of establishing connections to the backends.
+	//
+	// +optional
+	Connect *metav1.Duration `json:"connect,omitempty"`
 }
 
 // Gateway defines the desired Gateway API configuration of Envoy Gateway.
@@ -52,6 +454,403 @@ type Gateway struct {
 	//
 	// +optional
 	ControllerName string `json:"controllerName,omitempty"`
+
+	// RecordAttachedRouteKinds enables recording the number of routes of each
+	// kind attached to each listener of a Gateway in the
+	// "gateway.envoyproxy.io/attached-route-kinds" annotation of the Gateway,
+	// to help debugging Gateways with listeners of multiple protocols.
+	//
+	// +optional
+	RecordAttachedRouteKinds bool `json:"recordAttachedRouteKinds,omitempty"`
+
+	// RecordListenerTLS enables recording the TLS configuration served by
+	// each listener of a Gateway terminating TLS, i.e. the subject alternative
+	// names and expiry of its certificate and its minimum TLS version, in the
+	// "gateway.envoyproxy.io/listener-tls" annotation of the Gateway, so that
+	// operators can audit the served certificates without a config dump of
+	// the proxies.
+	//
+	// +optional
+	RecordListenerTLS bool `json:"recordListenerTLS,omitempty"`
+
+	// ValidationMode defines how the Gateways and routes with an invalid
+	// field or an unresolved reference are programmed. If unspecified,
+	// defaults to "Generous".
+	//
+	// +optional
+	ValidationMode ValidationMode `json:"validationMode,omitempty"`
+
+	// DefaultGatewayClass enables Envoy Gateway to create and own a
+	// GatewayClass with its controller name at startup, so that Gateways can
+	// be created right after installing Envoy Gateway. If unset, the
+	// GatewayClasses are created by the users.
+	//
+	// +optional
+	DefaultGatewayClass *DefaultGatewayClass `json:"defaultGatewayClass,omitempty"`
+
+	// BackendReadinessGating enables the "Programmed" condition of the
+	// HTTPRoutes, which is only true if at least one backend of the route
+	// has a ready endpoint, so that CI pipelines can detect the routes of a
+	// broken deployment. The endpoints are read from the EndpointSlices of
+	// the Services.
+	//
+	// +optional
+	BackendReadinessGating bool `json:"backendReadinessGating,omitempty"`
+
+	// SelfSignedCertificates enables serving a short-lived self-signed
+	// certificate on the HTTPS and TLS listeners whose certificate Secret
+	// does not exist, with a "SelfSignedCertificate" warning condition,
+	// instead of rejecting them. Intended for development clusters only.
+	//
+	// +optional
+	SelfSignedCertificates bool `json:"selfSignedCertificates,omitempty"`
+
+	// EmptyBackendRefs defines how the rules of the HTTPRoutes without
+	// backendRefs, and without a filter responding to the requests, are
+	// programmed. If unspecified, the requests matching these rules receive a
+	// 500 response.
+	//
+	// +optional
+	EmptyBackendRefs *EmptyBackendRefs `json:"emptyBackendRefs,omitempty"`
+}
+
+// EmptyBackendRefs defines how the rules of the HTTPRoutes without backendRefs
+// are programmed. The parents of the HTTPRoutes with such rules have an
+// "EmptyBackendRefs" condition describing the outcome.
+type EmptyBackendRefs struct {
+	// Action defines what happens to the requests matching the rules without
+	// backendRefs. If unspecified, defaults to "DirectResponse".
+	//
+	// +optional
+	Action EmptyBackendRefsAction `json:"action,omitempty"`
+
+	// StatusCode is the status code of the responses of the "DirectResponse"
+	// action. If unspecified, defaults to 500.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=200
+	// +kubebuilder:validation:Maximum=599
+	StatusCode *int32 `json:"statusCode,omitempty"`
+}
+
+// EmptyBackendRefsAction defines what happens to the requests matching the
+// rules of an HTTPRoute without backendRefs.
+// +kubebuilder:validation:Enum=DirectResponse;Skip
+type EmptyBackendRefsAction string
+
+const (
+	// EmptyBackendRefsActionDirectResponse responds to the requests with the
+	// configured status code.
+	EmptyBackendRefsActionDirectResponse EmptyBackendRefsAction = "DirectResponse"
+	// EmptyBackendRefsActionSkip does not program the rules, so that the
+	// requests are served by the other rules and routes matching them, or
+	// receive a 404 response.
+	EmptyBackendRefsActionSkip EmptyBackendRefsAction = "Skip"
+)
+
+// DefaultGatewayClass defines the GatewayClass created and owned by Envoy
+// Gateway. The GatewayClass and its EnvoyProxy are labeled with the
+// "gateway.envoyproxy.io/managed-by" label, and reverted to the configuration
+// of Envoy Gateway at each startup. An existing GatewayClass or EnvoyProxy
+// without the label is left untouched.
+type DefaultGatewayClass struct {
+	// Name is the name of the GatewayClass. If unspecified, defaults to "eg".
+	//
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// EnvoyProxy defines the specification of an EnvoyProxy created in the
+	// namespace of Envoy Gateway, with the name of the GatewayClass, and
+	// referenced by the parametersRef of the GatewayClass. If unset, the
+	// GatewayClass has no parametersRef.
+	//
+	// +optional
+	EnvoyProxy *EnvoyProxySpec `json:"envoyProxy,omitempty"`
+}
+
+// ValidationMode defines how the invalid Gateways and routes are programmed.
+// In both modes, the invalid fields are reported by the conditions of the
+// Gateways and routes.
+// +kubebuilder:validation:Enum=Generous;Strict
+type ValidationMode string
+
+const (
+	// ValidationModeGenerous programs the valid subset of the configuration
+	// of the Gateways and routes: the invalid listeners of a Gateway are not
+	// programmed, and the requests matching the invalid rules of a route
+	// receive an HTTP error response, while their valid listeners and rules
+	// are programmed.
+	ValidationModeGenerous ValidationMode = "Generous"
+	// ValidationModeStrict rejects the Gateways and routes with an invalid
+	// field or an unresolved reference as a whole: none of the listeners of
+	// a Gateway with an invalid listener are programmed, and a route with an
+	// invalid rule, filter, backend reference or policy is not attached to
+	// its parents.
+	ValidationModeStrict ValidationMode = "Strict"
+)
+
+// XdsServer defines the desired configuration of the Envoy Gateway xDS server.
+type XdsServer struct {
+	// Address is the IP address the xDS server listens on. If unspecified,
+	// defaults to "0.0.0.0".
+	//
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// Port is the port the xDS server listens on. If unspecified, defaults to
+	// 18000. Envoy proxies managed by Envoy Gateway connect to the xDS server
+	// through the Envoy Gateway Service, so its target port must match.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty"`
+
+	// TLS defines the TLS material used by the xDS server to authenticate
+	// itself and the connecting Envoy proxies. If unspecified, the certificates
+	// generated by Envoy Gateway are used.
+	//
+	// +optional
+	TLS *XdsServerTLS `json:"tls,omitempty"`
+
+	// SnapshotPersistence persists the last computed xDS snapshots, so that a
+	// restarting xDS server serves the connected Envoy proxies right away. If
+	// unset, the snapshots are not persisted.
+	//
+	// +optional
+	SnapshotPersistence *XdsSnapshotPersistence `json:"snapshotPersistence,omitempty"`
+
+	// BatchWindow is the duration the xDS snapshot updates are batched for
+	// before being pushed to the Envoy proxies. The updates of a key computed
+	// within the window are coalesced, so that churn in the watched resources,
+	// e.g. Services updated during rolling updates and autoscaling, doesn't
+	// cause a push storm. The window starts at the first update following a
+	// push, which bounds the delay of every update to the window. If
+	// unspecified, the updates are pushed right away.
+	//
+	// +optional
+	BatchWindow *metav1.Duration `json:"batchWindow,omitempty"`
+
+	// AuditGenerations is the number of generations of the xDS snapshot of
+	// each Gateway kept in the audit trail, served to egctl. If unspecified,
+	// defaults to 20. Setting it to 0 disables the audit trail.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	AuditGenerations *int32 `json:"auditGenerations,omitempty"`
+
+	// HandoffPeriod is the period the xDS streams of the connected Envoy
+	// proxies are closed over when the xDS server shuts down, e.g. during a
+	// rolling update. The server stops accepting connections, and closes the
+	// streams one by one at regular intervals across the period, so that the
+	// proxies reconnect to the other replicas gradually instead of all at
+	// once. It must be shorter than the termination grace period of the Envoy
+	// Gateway pods. If unspecified, defaults to 5s. Setting it to 0 closes
+	// all the streams at once.
+	//
+	// +optional
+	HandoffPeriod *metav1.Duration `json:"handoffPeriod,omitempty"`
+
+	// Faults are injected into the xDS responses to specific Envoy proxies,
+	// to test the behavior of the data plane under a degraded control plane.
+	// They must not be set in production.
+	//
+	// +optional
+	Faults []XdsFault `json:"faults,omitempty"`
+}
+
+// XdsFault defines a fault injected into the delta xDS responses to specific
+// Envoy proxies. A proxy matching several faults is subject to the first one.
+type XdsFault struct {
+	// NodeIDs are the node IDs of the proxies the fault is injected for. The
+	// node ID of a proxy managed by Envoy Gateway is the name of its pod.
+	//
+	// +kubebuilder:validation:MinItems=1
+	NodeIDs []string `json:"nodeIDs"`
+
+	// Delay delays the responses to the proxies by the duration.
+	//
+	// +optional
+	Delay *metav1.Duration `json:"delay,omitempty"`
+
+	// Drop drops the responses to the proxies, which keep their current
+	// configuration until they reconnect or request resources again.
+	//
+	// +optional
+	Drop bool `json:"drop,omitempty"`
+}
+
+// XdsSnapshotPersistence defines how the xDS snapshots are persisted across
+// restarts of Envoy Gateway.
+type XdsSnapshotPersistence struct {
+	// Path is the directory the snapshots are persisted to, e.g. the mount
+	// path of an emptyDir volume, which survives the restarts of the Envoy
+	// Gateway container.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+
+	// WarmupPeriod is the duration the persisted snapshots are served for
+	// after a restart, while the provider caches are warming up. The
+	// snapshots computed during the warmup period are only served once it
+	// ends, so that the Envoy proxies are never configured from partially
+	// synced resources. If unspecified, defaults to 10s.
+	//
+	// +optional
+	WarmupPeriod *metav1.Duration `json:"warmupPeriod,omitempty"`
+}
+
+// XdsServerTLS defines the TLS material of the xDS server.
+type XdsServerTLS struct {
+	// CertificatePath is the path of the PEM encoded server certificate.
+	// If unspecified, defaults to "/certs/tls.crt".
+	//
+	// +optional
+	CertificatePath string `json:"certificatePath,omitempty"`
+
+	// PrivateKeyPath is the path of the PEM encoded server private key.
+	// If unspecified, defaults to "/certs/tls.key".
+	//
+	// +optional
+	PrivateKeyPath string `json:"privateKeyPath,omitempty"`
+
+	// CACertificatePath is the path of the PEM encoded CA certificate used
+	// to verify the client certificates of Envoy proxies. If unspecified,
+	// defaults to "/certs/ca.crt".
+	//
+	// +optional
+	CACertificatePath string `json:"caCertificatePath,omitempty"`
+}
+
+// RateLimit defines the configuration of the global rate limit service.
+type RateLimit struct {
+	// Backend holds the configuration of the database backend storing
+	// the rate limit counters.
+	Backend RateLimitDatabaseBackend `json:"backend"`
+
+	// FailureMode defines whether requests are allowed when the rate limit
+	// service is unreachable or fails. If unspecified, defaults to "FailOpen".
+	//
+	// +optional
+	FailureMode FailureMode `json:"failureMode,omitempty"`
+}
+
+// FailureMode defines the behavior of Envoy when an external service
+// consulted for a request, e.g. the rate limit service, is unreachable
+// or fails.
+//
+// +kubebuilder:validation:Enum=FailOpen;FailClosed
+type FailureMode string
+
+const (
+	// FailOpen allows requests when the external service fails.
+	FailOpen FailureMode = "FailOpen"
+
+	// FailClosed rejects requests when the external service fails.
+	FailClosed FailureMode = "FailClosed"
+)
+
+// RateLimitDatabaseBackend defines the configuration of the rate limit
+// service database backend.
+// +union
+type RateLimitDatabaseBackend struct {
+	// Type is the type of database backend to use.
+	//
+	// +unionDiscriminator
+	Type RateLimitDatabaseBackendType `json:"type"`
+
+	// Redis defines the settings of the Redis database backend.
+	//
+	// +optional
+	Redis *RateLimitRedisSettings `json:"redis,omitempty"`
+}
+
+// RateLimitDatabaseBackendType defines the types of database backends
+// supported by the rate limit service.
+type RateLimitDatabaseBackendType string
+
+const (
+	// RedisBackendType defines the "Redis" database backend.
+	RedisBackendType RateLimitDatabaseBackendType = "Redis"
+)
+
+// RateLimitRedisSettings defines the settings of the Redis database backend.
+type RateLimitRedisSettings struct {
+	// Topology is the topology of the Redis deployment. If unspecified,
+	// defaults to "Standalone".
+	//
+	// +optional
+	Topology RedisTopology `json:"topology,omitempty"`
+
+	// URL of the standalone Redis database, in the "<host>:<port>" format.
+	// Required for the Standalone topology.
+	//
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// URLs of the Redis Sentinel or Redis Cluster nodes, in the "<host>:<port>"
+	// format. Required for the Sentinel and Cluster topologies.
+	//
+	// +optional
+	URLs []string `json:"urls,omitempty"`
+
+	// MasterName is the name of the master monitored by the Redis Sentinel
+	// nodes. Required for the Sentinel topology.
+	//
+	// +optional
+	MasterName string `json:"masterName,omitempty"`
+
+	// TLS defines the TLS settings of the connections to Redis. If unset,
+	// connections are not encrypted.
+	//
+	// +optional
+	TLS *RedisTLSSettings `json:"tls,omitempty"`
+
+	// Auth defines the credentials used to authenticate to Redis. If unset,
+	// no credentials are sent.
+	//
+	// +optional
+	Auth *RedisAuth `json:"auth,omitempty"`
+}
+
+// RedisTopology defines the Redis topologies supported by the rate limit service.
+type RedisTopology string
+
+const (
+	// RedisTopologyStandalone defines a single Redis database.
+	RedisTopologyStandalone RedisTopology = "Standalone"
+
+	// RedisTopologySentinel defines a Redis database monitored by Redis Sentinel.
+	RedisTopologySentinel RedisTopology = "Sentinel"
+
+	// RedisTopologyCluster defines a Redis Cluster.
+	RedisTopologyCluster RedisTopology = "Cluster"
+)
+
+// RedisTLSSettings defines the TLS settings of the connections to Redis. The
+// referenced Secrets must be in the namespace of Envoy Gateway.
+type RedisTLSSettings struct {
+	// CACertificateRef references the Secret holding, in its "ca.crt" key, the
+	// CA certificate used to verify the Redis server certificates. If unset,
+	// the system trust store is used.
+	//
+	// +optional
+	CACertificateRef *corev1.LocalObjectReference `json:"caCertificateRef,omitempty"`
+
+	// CertificateRef references the Secret of type kubernetes.io/tls holding
+	// the client certificate and private key presented to Redis. If unset, no
+	// client certificate is presented.
+	//
+	// +optional
+	CertificateRef *corev1.LocalObjectReference `json:"certificateRef,omitempty"`
+}
+
+// RedisAuth defines the credentials used to authenticate to Redis. The
+// referenced Secret must be in the namespace of Envoy Gateway.
+type RedisAuth struct {
+	// PasswordRef references the Secret key holding the Redis password, or
+	// the "<username>:<password>" credentials when using Redis ACLs.
+	PasswordRef corev1.SecretKeySelector `json:"passwordRef"`
 }
 
 // Provider defines the desired configuration of a provider.
@@ -87,7 +886,69 @@ const (
 
 // KubernetesProvider defines configuration for the Kubernetes provider.
 type KubernetesProvider struct {
-	// TODO: Add config as use cases are better understood.
+	// LeaderElection enables running several Envoy Gateway replicas, of which
+	// the elected leader is active and the others are standbys. If unset,
+	// leader election is disabled and a single replica must be run.
+	//
+	// +optional
+	LeaderElection *LeaderElection `json:"leaderElection,omitempty"`
+
+	// Watch tunes the watches of the Kubernetes resources, trading the
+	// freshness of Envoy Gateway for a lower load on the API server of very
+	// large clusters. If unset, all the changes of the resources are
+	// reconciled, and the watched resources are resynced every 10 hours.
+	//
+	// +optional
+	Watch *KubernetesWatch `json:"watch,omitempty"`
+}
+
+// KubernetesWatch defines the tuning of the watches of the Kubernetes
+// resources.
+type KubernetesWatch struct {
+	// ResyncPeriod is the minimum interval at which all the watched resources
+	// are reconciled again, even if they did not change. If unspecified,
+	// defaults to 10h.
+	//
+	// +optional
+	ResyncPeriod *metav1.Duration `json:"resyncPeriod,omitempty"`
+
+	// GenerationChangedOnly skips the updates of the GatewayClasses,
+	// Gateways, routes and policies which only change their status, i.e.
+	// which change neither their generation, nor their labels or
+	// annotations. These resources are then not reconciled again on resync
+	// either, and Envoy Gateway no longer reconciles the status it writes.
+	//
+	// +optional
+	GenerationChangedOnly bool `json:"generationChangedOnly,omitempty"`
+}
+
+// LeaderElection defines the leader election of the Envoy Gateway replicas,
+// coordinated through a Lease in the namespace of Envoy Gateway.
+//
+// All the replicas watch the resources, translate them and serve xDS, so that
+// the Envoy proxies connected to a failed replica get their configuration
+// from another replica as soon as they reconnect. Only the leader writes to
+// the Kubernetes API, e.g. the status of the resources and the managed proxy
+// infrastructure. A leader shutting down releases its Lease, so that a
+// standby takes over without waiting for the Lease to expire.
+type LeaderElection struct {
+	// LeaseDuration is the duration the standbys wait before taking over a
+	// Lease that was not renewed. If unspecified, defaults to 15s.
+	//
+	// +optional
+	LeaseDuration *metav1.Duration `json:"leaseDuration,omitempty"`
+
+	// RenewDeadline is the duration the leader retries renewing its Lease
+	// before giving up leadership. If unspecified, defaults to 10s.
+	//
+	// +optional
+	RenewDeadline *metav1.Duration `json:"renewDeadline,omitempty"`
+
+	// RetryPeriod is the duration between attempts to acquire or renew the
+	// Lease. If unspecified, defaults to 2s.
+	//
+	// +optional
+	RetryPeriod *metav1.Duration `json:"retryPeriod,omitempty"`
 }
 
 // FileProvider defines configuration for the File provider.
@@ -6,9 +6,21 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// KindEnvoyProxy is the name of the EnvoyProxy kind.
+	KindEnvoyProxy = "EnvoyProxy"
+	// DefaultProxyHealthPort is the default port of the proxy health listener.
+	DefaultProxyHealthPort = 19001
+	// DefaultProxyHealthPath is the default path of the proxy readiness endpoint.
+	DefaultProxyHealthPath = "/ready"
+	// DefaultProxyLogLevel is the default log level of the proxy.
+	DefaultProxyLogLevel = ProxyLogLevelInfo
+)
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 
@@ -23,8 +35,429 @@ type EnvoyProxy struct {
 
 // EnvoyProxySpec defines the desired state of EnvoyProxy.
 type EnvoyProxySpec struct {
-	// INSERT ADDITIONAL SPEC FIELDS - define desired state of cluster.
-	// Important: Run "make" to regenerate code after modifying this file.
+	// Provider defines the desired resource provider and provider-specific
+	// configuration. If unspecified, the "Kubernetes" resource provider is used
+	// with default configuration parameters.
+	//
+	// +optional
+	Provider *ResourceProvider `json:"provider,omitempty"`
+
+	// FilterOrder defines the order of the HTTP filters of the proxy. HTTP
+	// filters are ordered authentication, authorization, rate limiting and then
+	// custom filters, and the router filter is always last. Each position moves
+	// a filter right before or after another filter, and is applied in order.
+	// Positions referencing filters that are not configured are ignored.
+	//
+	// +optional
+	FilterOrder []FilterPosition `json:"filterOrder,omitempty"`
+
+	// Health defines the health listener of the proxy, which serves the
+	// readiness of the proxy to the readiness and liveness probes of the
+	// proxy Deployment and, optionally, to external load balancers. If
+	// unspecified, default settings are applied.
+	//
+	// +optional
+	Health *ProxyHealth `json:"health,omitempty"`
+
+	// Timeouts defines the default timeouts of the proxy, overriding the ones
+	// defined by the Envoy Gateway configuration field by field.
+	//
+	// +optional
+	Timeouts *Timeouts `json:"timeouts,omitempty"`
+
+	// Concurrency is the number of worker threads of the proxy. If
+	// unspecified, the proxy runs one worker thread per hardware thread of
+	// the node, which oversizes the proxies on large nodes.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Concurrency *uint32 `json:"concurrency,omitempty"`
+
+	// Logging defines the log levels of the proxy. If unspecified, the proxy
+	// logs at the info level.
+	//
+	// +optional
+	Logging *ProxyLogging `json:"logging,omitempty"`
+
+	// RuntimeFlags are the runtime flags of the proxy, keyed by name, e.g.
+	// "envoy.reloadable_features.http2_use_oghttp2": "false". They are set in
+	// the static layer of the runtime of the proxy.
+	//
+	// +optional
+	RuntimeFlags map[string]string `json:"runtimeFlags,omitempty"`
+
+	// DNSCache defines the DNS cache of the proxy, resolving the hosts of the
+	// routes acting as dynamic forward proxies. If unspecified, the Envoy
+	// defaults apply.
+	//
+	// +optional
+	DNSCache *DNSCache `json:"dnsCache,omitempty"`
+
+	// TelemetryBudget bounds the stats and access logs of the proxies of
+	// each Gateway, so that a verbose Gateway does not overwhelm the
+	// observability backends. If unspecified, the telemetry is not bounded.
+	//
+	// +optional
+	TelemetryBudget *TelemetryBudget `json:"telemetryBudget,omitempty"`
+
+	// Telemetry defines the telemetry of the proxies. If unspecified, the
+	// defaults apply.
+	//
+	// +optional
+	Telemetry *ProxyTelemetry `json:"telemetry,omitempty"`
+}
+
+// TelemetryBudget defines the budget of the telemetry of the proxies of a
+// Gateway.
+type TelemetryBudget struct {
+	// MaxStatsClusters is the maximum number of upstream clusters of the
+	// Gateway reporting their own stats, the upstream clusters being the
+	// main source of the cardinality of the stats of the proxies. The
+	// clusters over the budget, in the order of their names, report their
+	// stats under the shared "stats_overflow" name. If unspecified, all the
+	// clusters report their own stats.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxStatsClusters *uint32 `json:"maxStatsClusters,omitempty"`
+
+	// ExcludedStatsPrefixes are the prefixes of the names of the stats the
+	// proxies do not create, e.g. "vhost." or "cluster.stats_overflow.".
+	//
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	ExcludedStatsPrefixes []string `json:"excludedStatsPrefixes,omitempty"`
+
+	// MaxAccessLogsPerSecond is the maximum average number of access log
+	// entries written per second by the proxies of the Gateway for the
+	// requests to its HTTP and HTTPS listeners. The fraction of the requests
+	// logged is halved until the request rate of the Gateway, aggregated over
+	// the window of the traffic stats, fits the budget. It is ignored unless
+	// the traffic stats aggregation of Envoy Gateway is enabled.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxAccessLogsPerSecond *uint32 `json:"maxAccessLogsPerSecond,omitempty"`
+}
+
+// ProxyTelemetry defines the telemetry of the proxies of a Gateway.
+type ProxyTelemetry struct {
+	// AccessLog defines the access logs of the proxies.
+	//
+	// +optional
+	AccessLog *ProxyAccessLog `json:"accessLog,omitempty"`
+}
+
+// ProxyAccessLog defines the access logs of the proxies.
+type ProxyAccessLog struct {
+	// L4Format is the format of the access log entries of the connections
+	// and sessions of the TCP, TLS and UDP listeners. "Text" writes them in
+	// the default format of Envoy. "JSON" writes them as JSON objects, which
+	// carry the ID Envoy assigns to each TCP connection, so that they can be
+	// correlated with the logs of the proxy. If unspecified, defaults to
+	// "Text".
+	//
+	// +optional
+	L4Format AccessLogFormat `json:"l4Format,omitempty"`
+}
+
+// AccessLogFormat is the format of the access log entries of the proxy.
+// +kubebuilder:validation:Enum=Text;JSON
+type AccessLogFormat string
+
+const (
+	// AccessLogFormatText writes the access log entries in the default
+	// format of Envoy.
+	AccessLogFormatText AccessLogFormat = "Text"
+	// AccessLogFormatJSON writes the access log entries as JSON objects.
+	AccessLogFormatJSON AccessLogFormat = "JSON"
+)
+
+// DNSCache defines the DNS cache of the proxy.
+type DNSCache struct {
+	// RefreshRate is the interval at which the cached hosts are resolved
+	// again. If unspecified, defaults to 60 seconds.
+	//
+	// +optional
+	RefreshRate *metav1.Duration `json:"refreshRate,omitempty"`
+
+	// HostTTL is the time after which the hosts that have not been used are
+	// removed from the cache. If unspecified, defaults to 5 minutes.
+	//
+	// +optional
+	HostTTL *metav1.Duration `json:"hostTTL,omitempty"`
+
+	// MaxHosts is the maximum number of hosts in the cache. Requests to
+	// other hosts are rejected with a 503 response once it is reached. If
+	// unspecified, defaults to 1024.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxHosts *uint32 `json:"maxHosts,omitempty"`
+}
+
+// ProxyLogging defines the log levels of the proxy.
+type ProxyLogging struct {
+	// Level is the log level of all the components of the proxy, unless
+	// overridden by Components. If unspecified, defaults to "info".
+	//
+	// +optional
+	Level ProxyLogLevel `json:"level,omitempty"`
+
+	// Components are the log levels of specific components of the proxy,
+	// keyed by component name, e.g. "upstream" or "connection".
+	//
+	// +optional
+	Components map[string]ProxyLogLevel `json:"components,omitempty"`
+}
+
+// ProxyLogLevel is a log level of the proxy.
+// +kubebuilder:validation:Enum=trace;debug;info;warning;error;critical;off
+type ProxyLogLevel string
+
+const (
+	// ProxyLogLevelTrace is the trace log level.
+	ProxyLogLevelTrace ProxyLogLevel = "trace"
+	// ProxyLogLevelDebug is the debug log level.
+	ProxyLogLevelDebug ProxyLogLevel = "debug"
+	// ProxyLogLevelInfo is the info log level.
+	ProxyLogLevelInfo ProxyLogLevel = "info"
+	// ProxyLogLevelWarning is the warning log level.
+	ProxyLogLevelWarning ProxyLogLevel = "warning"
+	// ProxyLogLevelError is the error log level.
+	ProxyLogLevelError ProxyLogLevel = "error"
+	// ProxyLogLevelCritical is the critical log level.
+	ProxyLogLevelCritical ProxyLogLevel = "critical"
+	// ProxyLogLevelOff disables logging.
+	ProxyLogLevelOff ProxyLogLevel = "off"
+)
+
+// ProxyHealth defines the health listener of the proxy. The readiness endpoint
+// returns a 200 status code while the proxy is live, and a 503 status code
+// once it starts draining connections.
+type ProxyHealth struct {
+	// Port is the port of the health listener. It must not be used by any
+	// listener of the Gateway. If unspecified, defaults to 19001.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port,omitempty"`
+
+	// Path is the path of the readiness endpoint. If unspecified, defaults
+	// to "/ready".
+	//
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// ExposeOnService adds the health port to the Envoy service, so that
+	// external load balancers can health check the proxies.
+	//
+	// +optional
+	ExposeOnService bool `json:"exposeOnService,omitempty"`
+}
+
+// FilterPosition defines the position of an HTTP filter relative to another
+// HTTP filter. Exactly one of Before or After must be set.
+type FilterPosition struct {
+	// Name is the name of the HTTP filter to move, e.g. "envoy.filters.http.lua".
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Before is the name of the HTTP filter the filter is placed before.
+	//
+	// +optional
+	Before *string `json:"before,omitempty"`
+
+	// After is the name of the HTTP filter the filter is placed after.
+	//
+	// +optional
+	After *string `json:"after,omitempty"`
+}
+
+// ResourceProvider defines the desired state of a resource provider.
+// +union
+type ResourceProvider struct {
+	// Type is the type of resource provider to use. A resource provider provides
+	// infrastructure resources for running the data plane, e.g. Envoy proxy, and
+	// optional auxiliary control planes. Supported types are "Kubernetes".
+	//
+	// +unionDiscriminator
+	Type ProviderType `json:"type"`
+
+	// Kubernetes defines the desired state of the Kubernetes resource provider.
+	// Kubernetes provides infrastructure resources for running the data plane,
+	// e.g. Envoy proxy. If unspecified and type is "Kubernetes", default settings
+	// for managed Kubernetes resources are applied.
+	//
+	// +optional
+	Kubernetes *KubernetesResourceProvider `json:"kubernetes,omitempty"`
+}
+
+// KubernetesResourceProvider defines configuration for the Kubernetes resource
+// provider.
+type KubernetesResourceProvider struct {
+	// EnvoyDeployment defines the desired state of the Envoy deployment
+	// resource. If unspecified, default settings for the managed Envoy
+	// deployment resource are applied.
+	//
+	// +optional
+	EnvoyDeployment *KubernetesDeploymentSpec `json:"envoyDeployment,omitempty"`
+
+	// EnvoyService defines the desired state of the Envoy service resource.
+	// If unspecified, default settings for the managed Envoy service resource
+	// are applied.
+	//
+	// +optional
+	EnvoyService *KubernetesServiceSpec `json:"envoyService,omitempty"`
+
+	// EnvoyServiceAccount defines the desired state of the service account of
+	// the Envoy pods. Each Gateway gets a dedicated service account, bound to no
+	// role since the proxies don't access the Kubernetes API.
+	//
+	// +optional
+	EnvoyServiceAccount *KubernetesServiceAccountSpec `json:"envoyServiceAccount,omitempty"`
+}
+
+// KubernetesDeploymentSpec defines the desired state of the Kubernetes
+// deployment resource, hardening the pods of the proxy.
+type KubernetesDeploymentSpec struct {
+	// PodSecurityContext holds the pod-level security attributes of the
+	// proxy pods, e.g. the seccomp profile.
+	//
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// ContainerSecurityContext holds the security attributes of the Envoy
+	// container, e.g. runAsNonRoot or readOnlyRootFilesystem. They override
+	// the ones of the PodSecurityContext.
+	//
+	// +optional
+	ContainerSecurityContext *corev1.SecurityContext `json:"containerSecurityContext,omitempty"`
+
+	// AutomountServiceAccountToken indicates whether the token of the service
+	// account of the proxy pods is mounted. If unspecified, defaults to false,
+	// since the proxies don't access the Kubernetes API.
+	//
+	// +optional
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken,omitempty"`
+
+	// Resources are the compute resources of the Envoy container. Unless
+	// the concurrency of the proxy is set, it runs one worker thread per CPU
+	// of its CPU limit, or request if unlimited. The proxy stops accepting
+	// requests before its heap exceeds its memory limit.
+	//
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass of the proxy pods.
+	// If unspecified, the default priority of the cluster applies.
+	//
+	// +optional
+	PriorityClassName *string `json:"priorityClassName,omitempty"`
+
+	// UpgradeStrategy defines how the proxies are upgraded to a new image.
+	// If unspecified, the deployment is upgraded with a rolling update.
+	//
+	// +optional
+	UpgradeStrategy *UpgradeStrategy `json:"upgradeStrategy,omitempty"`
+}
+
+// UpgradeStrategyType defines the types of upgrade strategies of the proxies.
+//
+// +kubebuilder:validation:Enum=RollingUpdate;BlueGreen
+type UpgradeStrategyType string
+
+const (
+	// RollingUpdateUpgradeStrategyType upgrades the proxies in place with a
+	// rolling update of the deployment.
+	RollingUpdateUpgradeStrategyType UpgradeStrategyType = "RollingUpdate"
+
+	// BlueGreenUpgradeStrategyType upgrades the proxies by bringing up a new
+	// deployment with the new image next to the current one, switching the
+	// traffic to it once its proxies are ready, and draining the current one.
+	BlueGreenUpgradeStrategyType UpgradeStrategyType = "BlueGreen"
+)
+
+// UpgradeStrategy defines how the proxies are upgraded to a new image.
+type UpgradeStrategy struct {
+	// Type is the type of upgrade strategy, either "RollingUpdate" or
+	// "BlueGreen".
+	//
+	// +unionDiscriminator
+	Type UpgradeStrategyType `json:"type"`
+}
+
+// KubernetesServiceSpec defines the desired state of the Kubernetes service resource.
+type KubernetesServiceSpec struct {
+	// Annotations that should be appended to the service. Annotations can be
+	// used to attach the service to a pre-provisioned load balancer of the
+	// cloud provider.
+	//
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// LoadBalancerClass is the class of the load balancer implementation the
+	// service belongs to. If unspecified, the default load balancer
+	// implementation of the cluster is used.
+	//
+	// +optional
+	LoadBalancerClass *string `json:"loadBalancerClass,omitempty"`
+
+	// LoadBalancerIP is the static IP address requested for the load balancer.
+	// This field is ignored when the Gateway requests an address through
+	// spec.addresses.
+	//
+	// +optional
+	LoadBalancerIP *string `json:"loadBalancerIP,omitempty"`
+
+	// IPFamilies are the IP families, i.e. "IPv4" and "IPv6", of the addresses
+	// assigned to the service. If unspecified, the default IP family of the
+	// cluster is used. Envoy listens on both IPv4 and IPv6 addresses when the
+	// IPv6 family is requested.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=2
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
+
+	// IPFamilyPolicy is the dual-stack policy of the service, i.e. "SingleStack",
+	// "PreferDualStack" or "RequireDualStack". If unspecified, defaults to
+	// "SingleStack". Envoy listens on both IPv4 and IPv6 addresses unless the
+	// policy is "SingleStack".
+	//
+	// +optional
+	IPFamilyPolicy *corev1.IPFamilyPolicyType `json:"ipFamilyPolicy,omitempty"`
+
+	// ExternalDNS annotates the service for external-dns to create the DNS
+	// records of the listener hostnames of the Gateway, pointing at the
+	// address of the service. If unspecified, the service isn't annotated.
+	//
+	// +optional
+	ExternalDNS *ExternalDNS `json:"externalDNS,omitempty"`
+}
+
+// ExternalDNS defines the DNS records created by external-dns for the
+// hostnames of the listeners of a Gateway. The listeners without a hostname
+// are ignored, and wildcard hostnames create wildcard records.
+type ExternalDNS struct {
+	// TTL is the TTL of the DNS records. If unspecified, the default TTL of
+	// the DNS provider of external-dns applies.
+	//
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
+
+// KubernetesServiceAccountSpec defines the desired state of the Kubernetes
+// service account resource.
+type KubernetesServiceAccountSpec struct {
+	// Annotations that should be appended to the service account. Annotations
+	// can be used to bind the proxies to a role of the cloud provider, e.g.
+	// "eks.amazonaws.com/role-arn" for IAM roles for service accounts or
+	// "iam.gke.io/gcp-service-account" for workload identity.
+	//
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 // EnvoyProxyStatus defines the observed state of EnvoyProxy
@@ -0,0 +1,85 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// KindGatewayTrafficStats is the name of the GatewayTrafficStats kind.
+	KindGatewayTrafficStats = "GatewayTrafficStats"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:path=gatewaytrafficstats,singular=gatewaytrafficstats,shortName=gwstats
+//+kubebuilder:printcolumn:name="RPS",type=string,JSONPath=`.status.requestsPerSecond`
+//+kubebuilder:printcolumn:name="4xx",type=integer,JSONPath=`.status.responses4xx`
+//+kubebuilder:printcolumn:name="5xx",type=integer,JSONPath=`.status.responses5xx`
+//+kubebuilder:printcolumn:name="P95",type=string,JSONPath=`.status.p95Latency`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// GatewayTrafficStats holds the traffic statistics of the proxies of a
+// Gateway, aggregated by Envoy Gateway. It is named after the Gateway, in its
+// namespace, and is deleted along with it.
+type GatewayTrafficStats struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Status holds the statistics of the Gateway.
+	//
+	// +optional
+	Status GatewayTrafficStatsStatus `json:"status,omitempty"`
+}
+
+// GatewayTrafficStatsStatus defines the traffic statistics of the HTTP and
+// HTTPS listeners of a Gateway over a time window.
+type GatewayTrafficStatsStatus struct {
+	// LastUpdateTime is the time the statistics were last aggregated.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+
+	// Window is the period the statistics are aggregated over.
+	Window metav1.Duration `json:"window"`
+
+	// Proxies is the number of proxies having reported statistics over the
+	// window.
+	Proxies int32 `json:"proxies"`
+
+	// Requests is the number of requests received over the window.
+	Requests int64 `json:"requests"`
+
+	// RequestsPerSecond is the average number of requests received per
+	// second over the window, e.g. "12.50".
+	RequestsPerSecond string `json:"requestsPerSecond"`
+
+	// Responses4xx is the number of responses with a 4xx status code sent
+	// over the window.
+	Responses4xx int64 `json:"responses4xx"`
+
+	// Responses5xx is the number of responses with a 5xx status code sent
+	// over the window.
+	Responses5xx int64 `json:"responses5xx"`
+
+	// P95Latency is the highest 95th percentile of the request latency
+	// reported by the proxies over the window. It is unset if no request was
+	// received.
+	//
+	// +optional
+	P95Latency *metav1.Duration `json:"p95Latency,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// GatewayTrafficStatsList contains a list of GatewayTrafficStats.
+type GatewayTrafficStatsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GatewayTrafficStats `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GatewayTrafficStats{}, &GatewayTrafficStatsList{})
+}
@@ -6,6 +6,9 @@
 package v1alpha1
 
 import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -13,14 +16,16 @@ import (
 func DefaultEnvoyGateway() *EnvoyGateway {
 	gw := DefaultGateway()
 	p := DefaultProvider()
+	xds := DefaultXdsServer()
 	return &EnvoyGateway{
 		metav1.TypeMeta{
 			Kind:       KindEnvoyGateway,
 			APIVersion: GroupVersion.String(),
 		},
 		EnvoyGatewaySpec{
-			Gateway:  gw,
-			Provider: p,
+			Gateway:   gw,
+			Provider:  p,
+			XdsServer: xds,
 		},
 	}
 }
@@ -39,6 +44,10 @@ func (e *EnvoyGateway) SetDefaults() {
 	if e.Gateway == nil {
 		e.Gateway = DefaultGateway()
 	}
+	if e.XdsServer == nil {
+		e.XdsServer = DefaultXdsServer()
+	}
+	e.XdsServer.setDefaults()
 }
 
 // DefaultGateway returns a new Gateway with default configuration parameters.
@@ -61,3 +70,205 @@ func (e *EnvoyGateway) GetProvider() *Provider {
 	}
 	return DefaultProvider()
 }
+
+// DefaultXdsServer returns a new XdsServer with default configuration parameters.
+func DefaultXdsServer() *XdsServer {
+	return &XdsServer{
+		Address: DefaultXdsServerAddress,
+		Port:    DefaultXdsServerPort,
+		TLS: &XdsServerTLS{
+			CertificatePath:   DefaultXdsServerCertificatePath,
+			PrivateKeyPath:    DefaultXdsServerPrivateKeyPath,
+			CACertificatePath: DefaultXdsServerCACertificatePath,
+		},
+	}
+}
+
+// setDefaults sets default XdsServer configuration parameters for unset fields.
+func (x *XdsServer) setDefaults() {
+	if x.Address == "" {
+		x.Address = DefaultXdsServerAddress
+	}
+	if x.Port == 0 {
+		x.Port = DefaultXdsServerPort
+	}
+	if x.TLS == nil {
+		x.TLS = &XdsServerTLS{}
+	}
+	if x.TLS.CertificatePath == "" {
+		x.TLS.CertificatePath = DefaultXdsServerCertificatePath
+	}
+	if x.TLS.PrivateKeyPath == "" {
+		x.TLS.PrivateKeyPath = DefaultXdsServerPrivateKeyPath
+	}
+	if x.TLS.CACertificatePath == "" {
+		x.TLS.CACertificatePath = DefaultXdsServerCACertificatePath
+	}
+}
+
+// GetXdsServer returns the XdsServer configuration, with defaults applied
+// to unset fields.
+func (e *EnvoyGateway) GetXdsServer() *XdsServer {
+	xds := DefaultXdsServer()
+	if e.XdsServer != nil {
+		xds = e.XdsServer.DeepCopy()
+		xds.setDefaults()
+	}
+	return xds
+}
+
+// GetAuditGenerations returns the number of generations of the xDS snapshot
+// of each Gateway kept in the audit trail, defaulting to
+// DefaultXdsAuditGenerations.
+func (x *XdsServer) GetAuditGenerations() int {
+	if x == nil || x.AuditGenerations == nil {
+		return DefaultXdsAuditGenerations
+	}
+	return int(*x.AuditGenerations)
+}
+
+// GetHandoffPeriod returns the period the xDS streams are closed over when
+// the xDS server shuts down, defaulting to DefaultXdsHandoffPeriod.
+func (x *XdsServer) GetHandoffPeriod() time.Duration {
+	if x == nil || x.HandoffPeriod == nil || x.HandoffPeriod.Duration < 0 {
+		return DefaultXdsHandoffPeriod
+	}
+	return x.HandoffPeriod.Duration
+}
+
+// GetWindow returns the period the traffic statistics are aggregated over,
+// defaulting to DefaultTrafficStatsWindow.
+func (t *TrafficStats) GetWindow() time.Duration {
+	if t == nil || t.Window == nil || t.Window.Duration <= 0 {
+		return DefaultTrafficStatsWindow
+	}
+	return t.Window.Duration
+}
+
+// GetLevel returns the log level of the provided component, or the default
+// log level if unset.
+func (l *EnvoyGatewayLogging) GetLevel(component LogComponent) LogLevel {
+	if l == nil {
+		return DefaultLogLevel
+	}
+	if level, ok := l.Components[component]; ok {
+		return level
+	}
+	if l.Level != "" {
+		return l.Level
+	}
+	return DefaultLogLevel
+}
+
+// GetRefreshInterval returns the interval the certificates of the external
+// secret backends are refreshed at, defaulting to DefaultSecretRefreshInterval.
+func (s *SecretBackend) GetRefreshInterval() time.Duration {
+	if s == nil || s.RefreshInterval == nil || s.RefreshInterval.Duration <= 0 {
+		return DefaultSecretRefreshInterval
+	}
+	return s.RefreshInterval.Duration
+}
+
+// GetEnvoyDeploymentSpec returns the desired state of the Envoy deployment,
+// or nil if unspecified.
+func (e *EnvoyProxy) GetEnvoyDeploymentSpec() *KubernetesDeploymentSpec {
+	if e == nil || e.Spec.Provider == nil || e.Spec.Provider.Kubernetes == nil {
+		return nil
+	}
+
+	return e.Spec.Provider.Kubernetes.EnvoyDeployment
+}
+
+// GetEnvoyServiceSpec returns the desired state of the Envoy service, or nil
+// if unspecified.
+func (e *EnvoyProxy) GetEnvoyServiceSpec() *KubernetesServiceSpec {
+	if e == nil || e.Spec.Provider == nil || e.Spec.Provider.Kubernetes == nil {
+		return nil
+	}
+
+	return e.Spec.Provider.Kubernetes.EnvoyService
+}
+
+// GetExternalDNS returns the external-dns configuration of the Envoy service,
+// or nil if unspecified.
+func (e *EnvoyProxy) GetExternalDNS() *ExternalDNS {
+	if spec := e.GetEnvoyServiceSpec(); spec != nil {
+		return spec.ExternalDNS
+	}
+	return nil
+}
+
+// GetEnvoyServiceAccountSpec returns the desired state of the Envoy service
+// account, or nil if unspecified.
+func (e *EnvoyProxy) GetEnvoyServiceAccountSpec() *KubernetesServiceAccountSpec {
+	if e == nil || e.Spec.Provider == nil || e.Spec.Provider.Kubernetes == nil {
+		return nil
+	}
+
+	return e.Spec.Provider.Kubernetes.EnvoyServiceAccount
+}
+
+// GetProxyHealth returns the health listener configuration of the proxy, with
+// defaults applied to unset fields.
+func (e *EnvoyProxy) GetProxyHealth() *ProxyHealth {
+	health := new(ProxyHealth)
+	if e != nil && e.Spec.Health != nil {
+		health = e.Spec.Health.DeepCopy()
+	}
+	if health.Port == 0 {
+		health.Port = DefaultProxyHealthPort
+	}
+	if health.Path == "" {
+		health.Path = DefaultProxyHealthPath
+	}
+	return health
+}
+
+// GetProxyLogging returns the log levels of the proxy, with defaults applied
+// to unset fields.
+func (e *EnvoyProxy) GetProxyLogging() *ProxyLogging {
+	logging := new(ProxyLogging)
+	if e != nil && e.Spec.Logging != nil {
+		logging = e.Spec.Logging.DeepCopy()
+	}
+	if logging.Level == "" {
+		logging.Level = DefaultProxyLogLevel
+	}
+	return logging
+}
+
+// GetTelemetryBudget returns the telemetry budget of the proxies of each
+// Gateway, or nil if unspecified.
+func (e *EnvoyProxy) GetTelemetryBudget() *TelemetryBudget {
+	if e == nil {
+		return nil
+	}
+	return e.Spec.TelemetryBudget
+}
+
+// GetL4AccessLogFormat returns the format of the access logs of the TCP, TLS
+// and UDP listeners of the proxies, defaulting to Text.
+func (e *EnvoyProxy) GetL4AccessLogFormat() AccessLogFormat {
+	if e == nil || e.Spec.Telemetry == nil || e.Spec.Telemetry.AccessLog == nil || e.Spec.Telemetry.AccessLog.L4Format == "" {
+		return AccessLogFormatText
+	}
+	return e.Spec.Telemetry.AccessLog.L4Format
+}
+
+// IsIPv6Enabled returns true if the service is requested to be assigned
+// IPv6 addresses, either as a single or dual-stack service.
+func (s *KubernetesServiceSpec) IsIPv6Enabled() bool {
+	if s == nil {
+		return false
+	}
+	if s.IPFamilyPolicy != nil && *s.IPFamilyPolicy != corev1.IPFamilyPolicySingleStack {
+		return true
+	}
+	for _, family := range s.IPFamilies {
+		if family == corev1.IPv6Protocol {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,56 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// TargetSelector selects the resources a policy applies to by their labels.
+// Only the resources in the namespace of the policy are selected.
+type TargetSelector struct {
+	// Group is the group of the selected resources.
+	Group gwapiv1a2.Group `json:"group"`
+
+	// Kind is the kind of the selected resources.
+	Kind gwapiv1a2.Kind `json:"kind"`
+
+	// MatchLabels are the labels of the selected resources. A resource is
+	// selected if it has all the labels.
+	//
+	// +kubebuilder:validation:MinProperties=1
+	MatchLabels map[string]string `json:"matchLabels"`
+}
+
+// PolicyStatus defines the observed state of a policy.
+type PolicyStatus struct {
+	// Ancestors are the statuses of the policy for the resources it applies
+	// to, sorted by kind, namespace and name. Only the first 16 resources are
+	// listed.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	Ancestors []PolicyAncestorStatus `json:"ancestors,omitempty"`
+}
+
+// PolicyAncestorStatus defines the status of a policy for a resource it
+// applies to.
+type PolicyAncestorStatus struct {
+	// AncestorRef references the resource.
+	AncestorRef gwapiv1b1.ParentReference `json:"ancestorRef"`
+
+	// Conditions describe the status of the policy for the resource. The
+	// Accepted condition is set to False with the Conflicted reason if an
+	// older policy of the same kind applies to the resource.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
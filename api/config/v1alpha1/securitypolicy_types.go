@@ -0,0 +1,324 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+const (
+	// KindSecurityPolicy is the name of the SecurityPolicy kind.
+	KindSecurityPolicy = "SecurityPolicy"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// SecurityPolicy configures the authentication and authorization of the
+// requests to the targeted resource.
+type SecurityPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SecurityPolicySpec `json:"spec"`
+
+	// Status defines the status of the policy for the resources it applies
+	// to.
+	//
+	// +optional
+	Status PolicyStatus `json:"status,omitempty"`
+}
+
+// SecurityPolicySpec defines the desired state of SecurityPolicy.
+type SecurityPolicySpec struct {
+	// TargetRef identifies the resource the policy applies to. A Gateway or
+	// an HTTPRoute in the namespace of the policy is supported. When several
+	// policies target the same resource, the oldest one takes effect. A policy
+	// targeting a Gateway applies to the HTTPRoutes attached to the Gateway,
+	// and each feature configured by the policy targeting an HTTPRoute takes
+	// precedence over the same feature of the policy targeting its Gateway.
+	// Either TargetRef or TargetSelectors must be set.
+	//
+	// +optional
+	TargetRef *gwapiv1a2.PolicyTargetReference `json:"targetRef,omitempty"`
+
+	// TargetSelectors select the resources the policy applies to by their
+	// labels, in addition to the TargetRef. The same kinds of resources as
+	// the TargetRef are supported.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	TargetSelectors []TargetSelector `json:"targetSelectors,omitempty"`
+
+	// JWT configures the authentication of the requests with JSON Web Tokens
+	// (JWT), and their authorization based on the claims of the tokens.
+	//
+	// +optional
+	JWT *JWTAuthentication `json:"jwt,omitempty"`
+
+	// ExtAuth configures the authorization of the requests by an external
+	// authorization service. It is consulted after the JWT authentication, if
+	// any, so the payload of the verified JWT can be forwarded to the service.
+	//
+	// +optional
+	ExtAuth *ExtAuth `json:"extAuth,omitempty"`
+
+	// CSRF enables the protection of browser-facing routes against cross-site
+	// request forgery (CSRF). Requests with an unsafe method whose Origin does
+	// not match the destination, nor any of the additional origins, are
+	// rejected with a 403 response.
+	//
+	// +optional
+	CSRF *CSRF `json:"csrf,omitempty"`
+
+	// SecurityHeaders adds standard security headers to the responses,
+	// overriding the same headers set by the backends.
+	//
+	// +optional
+	SecurityHeaders *SecurityHeaders `json:"securityHeaders,omitempty"`
+}
+
+// SecurityHeaders defines the security headers added to the responses. Only
+// the specified headers are added.
+type SecurityHeaders struct {
+	// HSTS sets the Strict-Transport-Security header, requiring browsers to
+	// only connect to the host over HTTPS.
+	//
+	// +optional
+	HSTS *HSTS `json:"hsts,omitempty"`
+
+	// NoSniff sets the X-Content-Type-Options header to "nosniff", preventing
+	// browsers from guessing the content type of the responses.
+	//
+	// +optional
+	NoSniff bool `json:"noSniff,omitempty"`
+
+	// ContentSecurityPolicy is the value of the Content-Security-Policy header,
+	// e.g. "default-src 'self'".
+	//
+	// +optional
+	ContentSecurityPolicy string `json:"contentSecurityPolicy,omitempty"`
+}
+
+// HSTS defines the Strict-Transport-Security header.
+type HSTS struct {
+	// MaxAge is the time browsers remember to only connect to the host over
+	// HTTPS, e.g. "8760h". It is rounded down to the second.
+	MaxAge metav1.Duration `json:"maxAge"`
+
+	// IncludeSubdomains applies the header to the subdomains of the host.
+	//
+	// +optional
+	IncludeSubdomains bool `json:"includeSubdomains,omitempty"`
+
+	// Preload allows the host to be included in the HSTS preload lists of
+	// the browsers.
+	//
+	// +optional
+	Preload bool `json:"preload,omitempty"`
+}
+
+// CSRF defines the origins allowed to send requests with an unsafe method,
+// e.g. POST, besides the destination of the requests.
+type CSRF struct {
+	// AdditionalOrigins are the hosts of the additional origins, including
+	// their port unless it is the default port of their scheme, e.g.
+	// "app.example.com" or "app.example.com:8443". A leading "*." matches any
+	// subdomain, e.g. "*.example.com".
+	//
+	// +optional
+	AdditionalOrigins []string `json:"additionalOrigins,omitempty"`
+}
+
+// ExtAuth defines the external authorization service consulted for the
+// requests, and the context of the requests forwarded to it. Exactly one of
+// GRPC and HTTP must be specified.
+type ExtAuth struct {
+	// GRPC defines an external authorization service implementing the Envoy
+	// gRPC Authorization API. The service receives all the request headers,
+	// and may add or override any header of the request forwarded to the
+	// backends.
+	//
+	// +optional
+	GRPC *GRPCExtAuthService `json:"grpc,omitempty"`
+
+	// HTTP defines an external authorization service receiving a HTTP request
+	// per authorized request. A 2xx response authorizes the request, while any
+	// other response is returned to the client.
+	//
+	// +optional
+	HTTP *HTTPExtAuthService `json:"http,omitempty"`
+
+	// FailureMode defines whether requests are allowed when the authorization
+	// service is unreachable or fails. If unspecified, defaults to "FailClosed".
+	//
+	// +optional
+	FailureMode FailureMode `json:"failureMode,omitempty"`
+
+	// BodyToExtAuth forwards the body of the requests to the authorization
+	// service. If unspecified, the body is not forwarded.
+	//
+	// +optional
+	BodyToExtAuth *BodyToExtAuth `json:"bodyToExtAuth,omitempty"`
+}
+
+// GRPCExtAuthService defines a gRPC external authorization service.
+type GRPCExtAuthService struct {
+	// BackendRef references the Service of the authorization service. Only a
+	// Service in the namespace of the policy is supported, and its port must
+	// be specified.
+	BackendRef gwapiv1b1.BackendObjectReference `json:"backendRef"`
+
+	// MetadataNamespaces are the namespaces of the dynamic metadata of the
+	// request forwarded to the service, e.g. "envoy.filters.http.jwt_authn"
+	// holding the payloads of the verified JWTs. If unspecified, no metadata
+	// is forwarded.
+	//
+	// +optional
+	MetadataNamespaces []string `json:"metadataNamespaces,omitempty"`
+}
+
+// HTTPExtAuthService defines a HTTP external authorization service.
+type HTTPExtAuthService struct {
+	// BackendRef references the Service of the authorization service. Only a
+	// Service in the namespace of the policy is supported, and its port must
+	// be specified.
+	BackendRef gwapiv1b1.BackendObjectReference `json:"backendRef"`
+
+	// Path is the prefix prepended to the path of the requests sent to the
+	// service.
+	//
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// HeadersToExtAuth are the request headers forwarded to the service, in
+	// addition to the Host, Method, Path, Content-Length and Authorization
+	// headers which are always forwarded.
+	//
+	// +optional
+	HeadersToExtAuth []string `json:"headersToExtAuth,omitempty"`
+
+	// HeadersToBackend are the headers of the authorization response added
+	// to, or overriding the headers of, the request forwarded to the backends.
+	// If unspecified, the request is forwarded unmodified.
+	//
+	// +optional
+	HeadersToBackend []string `json:"headersToBackend,omitempty"`
+}
+
+// BodyToExtAuth defines how the body of the requests is forwarded to the
+// external authorization service.
+type BodyToExtAuth struct {
+	// MaxRequestBytes is the maximum size of the buffered body forwarded to
+	// the service.
+	//
+	// +kubebuilder:validation:Minimum=1
+	MaxRequestBytes uint32 `json:"maxRequestBytes"`
+
+	// AllowPartialMessage forwards the first MaxRequestBytes bytes of larger
+	// bodies. If false, requests with a larger body are rejected with a 413
+	// response.
+	//
+	// +optional
+	AllowPartialMessage bool `json:"allowPartialMessage,omitempty"`
+}
+
+// JWTAuthentication defines the JWT providers the requests are authenticated
+// with. Requests without a JWT verified by any of the providers are rejected
+// with a 401 response.
+type JWTAuthentication struct {
+	// Providers are the JWT providers verifying the tokens. The token is
+	// extracted from the Authorization header, using the Bearer scheme.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Providers []JWTProvider `json:"providers"`
+
+	// Authorization defines the scopes and claims the verified JWT must hold
+	// for the request to be authorized. Requests which are not authorized are
+	// rejected with a 403 response. If unspecified, all the authenticated
+	// requests are authorized.
+	//
+	// +optional
+	Authorization *JWTAuthorization `json:"authorization,omitempty"`
+}
+
+// JWTProvider defines how the JWTs issued by an issuer are verified.
+type JWTProvider struct {
+	// Name uniquely identifies the provider within the policy.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Issuer is the expected value of the iss claim of the JWTs. If
+	// unspecified, the iss claim is not verified.
+	//
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+
+	// Audiences are the allowed values of the aud claim of the JWTs. If
+	// unspecified, the aud claim is not verified.
+	//
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+
+	// RemoteJWKS defines where the JSON Web Key Set (JWKS) verifying the
+	// signature of the JWTs is fetched from.
+	RemoteJWKS RemoteJWKS `json:"remoteJWKS"`
+}
+
+// RemoteJWKS defines a JSON Web Key Set served over HTTP.
+type RemoteJWKS struct {
+	// URI is the HTTP or HTTPS URI the JWKS is fetched from, e.g.
+	// "https://example.com/.well-known/jwks.json". HTTPS servers are verified
+	// against the system CA certificates of the proxy.
+	//
+	// +kubebuilder:validation:MinLength=1
+	URI string `json:"uri"`
+}
+
+// JWTAuthorization defines the scopes and claims a verified JWT must hold.
+// All the scopes and claims are required.
+type JWTAuthorization struct {
+	// Scopes are the scopes the space-delimited scope claim of the JWT must
+	// include.
+	//
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+
+	// Claims are the top-level claims the JWT must hold.
+	//
+	// +optional
+	Claims []JWTClaim `json:"claims,omitempty"`
+}
+
+// JWTClaim defines the values a JWT claim may hold.
+type JWTClaim struct {
+	// Name is the name of the claim.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Values are the allowed values of the claim. A string claim must be
+	// equal to one of them, and a list claim must include one of them.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Values []string `json:"values"`
+}
+
+//+kubebuilder:object:root=true
+
+// SecurityPolicyList contains a list of SecurityPolicy.
+type SecurityPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecurityPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SecurityPolicy{}, &SecurityPolicyList{})
+}
@@ -0,0 +1,75 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+const (
+	// KindTrafficShift is the name of the TrafficShift kind.
+	KindTrafficShift = "TrafficShift"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// TrafficShift shifts the requests between the stable and canary backendRefs
+// of the targeted route, so that progressive delivery controllers adjust a
+// single resource at each step of a release.
+type TrafficShift struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TrafficShiftSpec `json:"spec"`
+
+	// Status defines the status of the traffic shift for the route it
+	// applies to.
+	//
+	// +optional
+	Status PolicyStatus `json:"status,omitempty"`
+}
+
+// TrafficShiftSpec defines the desired state of TrafficShift.
+type TrafficShiftSpec struct {
+	// TargetRef identifies the route the requests are shifted on. Only an
+	// HTTPRoute in the namespace of the traffic shift is supported. When
+	// several traffic shifts target the same route, the oldest one takes
+	// effect.
+	TargetRef gwapiv1a2.PolicyTargetReference `json:"targetRef"`
+
+	// StableRef identifies the backendRef receiving the requests not shifted
+	// to the canary. The port of the backendRef is only matched if set.
+	StableRef gwapiv1b1.BackendObjectReference `json:"stableRef"`
+
+	// CanaryRef identifies the backendRef the requests are shifted to. The
+	// port of the backendRef is only matched if set.
+	CanaryRef gwapiv1b1.BackendObjectReference `json:"canaryRef"`
+
+	// CanaryWeight is the percentage of the requests shifted to the canary.
+	// The weights of the stable and canary backendRefs of each rule having
+	// both are replaced by 100 minus the canary weight and the canary weight,
+	// while the other backendRefs of the rule keep their weights.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	CanaryWeight int32 `json:"canaryWeight"`
+}
+
+//+kubebuilder:object:root=true
+
+// TrafficShiftList contains a list of TrafficShift.
+type TrafficShiftList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TrafficShift `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TrafficShift{}, &TrafficShiftList{})
+}
@@ -0,0 +1,199 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+// Package benchmark builds synthetic clusters of Gateways and routes, and
+// measures the time and allocations of their translation to the IR and xDS.
+package benchmark
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/xds/translator"
+)
+
+const (
+	// GatewayClassName is the name of the GatewayClass of the synthetic
+	// Gateways.
+	GatewayClassName = "envoy-gateway-benchmark"
+	// Namespace is the namespace of the synthetic resources.
+	Namespace = "envoy-gateway-benchmark"
+)
+
+// Options defines the size of a synthetic cluster.
+type Options struct {
+	// Gateways is the number of Gateways.
+	Gateways int
+	// RoutesPerGateway is the number of HTTPRoutes attached to each Gateway.
+	RoutesPerGateway int
+}
+
+func (o Options) String() string {
+	return fmt.Sprintf("gateways=%d/routes=%d", o.Gateways, o.RoutesPerGateway)
+}
+
+// Resources returns the resources of a synthetic cluster. Each Gateway has
+// a HTTP listener, to which its routes attach with a distinct hostname. The
+// routes of every Gateway forward to a distinct Service per route index.
+func Resources(opts Options) *gatewayapi.Resources {
+	resources := &gatewayapi.Resources{
+		Namespaces: []*corev1.Namespace{{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{Name: Namespace},
+		}},
+	}
+
+	for j := 0; j < opts.RoutesPerGateway; j++ {
+		resources.Services = append(resources.Services, &corev1.Service{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: Namespace, Name: serviceName(j)},
+			Spec: corev1.ServiceSpec{
+				ClusterIP: fmt.Sprintf("10.96.%d.%d", j/250, j%250+1),
+				Ports:     []corev1.ServicePort{{Port: 8080}},
+			},
+		})
+	}
+
+	pathPrefix := v1beta1.PathMatchPathPrefix
+	namespacesFromSame := v1beta1.NamespacesFromSame
+	for i := 0; i < opts.Gateways; i++ {
+		gatewayName := fmt.Sprintf("gateway-%d", i)
+		hostname := v1beta1.Hostname(fmt.Sprintf("*.%s.example.com", gatewayName))
+		resources.Gateways = append(resources.Gateways, &v1beta1.Gateway{
+			TypeMeta:   metav1.TypeMeta{APIVersion: v1beta1.GroupVersion.String(), Kind: "Gateway"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: Namespace, Name: gatewayName},
+			Spec: v1beta1.GatewaySpec{
+				GatewayClassName: GatewayClassName,
+				Listeners: []v1beta1.Listener{{
+					Name:     "http",
+					Hostname: &hostname,
+					Port:     80,
+					Protocol: v1beta1.HTTPProtocolType,
+					AllowedRoutes: &v1beta1.AllowedRoutes{
+						Namespaces: &v1beta1.RouteNamespaces{From: &namespacesFromSame},
+					},
+				}},
+			},
+		})
+
+		for j := 0; j < opts.RoutesPerGateway; j++ {
+			port := v1beta1.PortNumber(8080)
+			path := fmt.Sprintf("/route-%d", j)
+			resources.HTTPRoutes = append(resources.HTTPRoutes, &v1beta1.HTTPRoute{
+				TypeMeta:   metav1.TypeMeta{APIVersion: v1beta1.GroupVersion.String(), Kind: "HTTPRoute"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: Namespace, Name: fmt.Sprintf("%s-route-%d", gatewayName, j)},
+				Spec: v1beta1.HTTPRouteSpec{
+					CommonRouteSpec: v1beta1.CommonRouteSpec{
+						ParentRefs: []v1beta1.ParentReference{{Name: v1beta1.ObjectName(gatewayName)}},
+					},
+					Hostnames: []v1beta1.Hostname{v1beta1.Hostname(fmt.Sprintf("route-%d.%s.example.com", j, gatewayName))},
+					Rules: []v1beta1.HTTPRouteRule{{
+						Matches: []v1beta1.HTTPRouteMatch{{
+							Path: &v1beta1.HTTPPathMatch{Type: &pathPrefix, Value: &path},
+						}},
+						BackendRefs: []v1beta1.HTTPBackendRef{{
+							BackendRef: v1beta1.BackendRef{
+								BackendObjectReference: v1beta1.BackendObjectReference{
+									Name: v1beta1.ObjectName(serviceName(j)),
+									Port: &port,
+								},
+							},
+						}},
+					}},
+				},
+			})
+		}
+	}
+
+	return resources
+}
+
+func serviceName(index int) string {
+	return fmt.Sprintf("backend-%d", index)
+}
+
+// Measurement is the mean cost of an operation.
+type Measurement struct {
+	Duration time.Duration
+	Allocs   uint64
+	Bytes    uint64
+}
+
+func (m Measurement) String() string {
+	return fmt.Sprintf("%v/op %d allocs/op %d B/op", m.Duration, m.Allocs, m.Bytes)
+}
+
+// Result holds the costs of the translation of a synthetic cluster.
+type Result struct {
+	// GatewayAPITranslation is the cost of translating the Gateway API
+	// resources to the IR.
+	GatewayAPITranslation Measurement
+	// XdsTranslation is the cost of translating the IR of all the Gateways
+	// to xDS resources.
+	XdsTranslation Measurement
+}
+
+// Run translates the synthetic cluster defined by opts the given number of
+// iterations, and returns the mean costs of the translations.
+func Run(opts Options, iterations int) (*Result, error) {
+	if iterations < 1 {
+		return nil, fmt.Errorf("iterations must be positive, got %d", iterations)
+	}
+	resources := Resources(opts)
+	t := &gatewayapi.Translator{GatewayClassName: GatewayClassName}
+
+	var xdsIR gatewayapi.XdsIRMap
+	gatewayAPI := measure(iterations, func() error {
+		xdsIR = t.Translate(resources).XdsIR
+		return nil
+	})
+	xds := measure(iterations, func() error {
+		for key, val := range xdsIR {
+			if _, err := translator.Translate(val); err != nil {
+				return fmt.Errorf("failed to translate xds ir %s: %w", key, err)
+			}
+		}
+		return nil
+	})
+	if xds.err != nil {
+		return nil, xds.err
+	}
+
+	return &Result{GatewayAPITranslation: gatewayAPI.Measurement, XdsTranslation: xds.Measurement}, nil
+}
+
+type measurement struct {
+	Measurement
+	err error
+}
+
+// measure runs fn the given number of iterations, stopping at the first
+// error, and returns its mean cost.
+func measure(iterations int, fn func() error) measurement {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for i := 0; i < iterations; i++ {
+		if err := fn(); err != nil {
+			return measurement{err: err}
+		}
+	}
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	n := uint64(iterations)
+	return measurement{Measurement: Measurement{
+		Duration: elapsed / time.Duration(iterations),
+		Allocs:   (after.Mallocs - before.Mallocs) / n,
+		Bytes:    (after.TotalAlloc - before.TotalAlloc) / n,
+	}}
+}
@@ -0,0 +1,78 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package benchmark
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/xds/translator"
+)
+
+var sizes = []Options{
+	{Gateways: 1, RoutesPerGateway: 10},
+	{Gateways: 10, RoutesPerGateway: 10},
+	{Gateways: 10, RoutesPerGateway: 100},
+	{Gateways: 100, RoutesPerGateway: 10},
+}
+
+func TestResources(t *testing.T) {
+	opts := Options{Gateways: 2, RoutesPerGateway: 3}
+	resources := Resources(opts)
+	require.Len(t, resources.Gateways, 2)
+	require.Len(t, resources.HTTPRoutes, 6)
+	require.Len(t, resources.Services, 3)
+
+	// All the routes are accepted by their Gateway.
+	result := (&gatewayapi.Translator{GatewayClassName: GatewayClassName}).Translate(resources)
+	require.Len(t, result.XdsIR, 2)
+	for _, route := range result.HTTPRoutes {
+		require.Len(t, route.Status.Parents, 1)
+		require.True(t, meta.IsStatusConditionTrue(route.Status.Parents[0].Conditions, string(v1beta1.RouteConditionAccepted)), route.Name)
+	}
+	for _, xdsIR := range result.XdsIR {
+		require.Len(t, xdsIR.HTTP, 1)
+		require.Len(t, xdsIR.HTTP[0].Routes, 3)
+	}
+
+	_, err := Run(opts, 1)
+	require.NoError(t, err)
+}
+
+func BenchmarkGatewayAPITranslate(b *testing.B) {
+	for _, opts := range sizes {
+		b.Run(opts.String(), func(b *testing.B) {
+			resources := Resources(opts)
+			t := &gatewayapi.Translator{GatewayClassName: GatewayClassName}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				t.Translate(resources)
+			}
+		})
+	}
+}
+
+func BenchmarkXdsTranslate(b *testing.B) {
+	for _, opts := range sizes {
+		b.Run(opts.String(), func(b *testing.B) {
+			result := (&gatewayapi.Translator{GatewayClassName: GatewayClassName}).Translate(Resources(opts))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, xdsIR := range result.XdsIR {
+					if _, err := translator.Translate(xdsIR); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/benchmark"
+)
+
+// benchmarkOptions holds the options of the benchmark command.
+type benchmarkOptions struct {
+	benchmark.Options
+	iterations int
+	manifests  bool
+}
+
+// getBenchmarkCommand returns the benchmark cobra command to be executed.
+func getBenchmarkCommand() *cobra.Command {
+	opts := &benchmarkOptions{}
+	cmd := &cobra.Command{
+		Use:   "benchmark",
+		Short: "Measure the translation of a synthetic cluster",
+		Long: "Build a synthetic cluster of Gateways and HTTPRoutes, and measure the time and allocations of its " +
+			"translation to the IR and xDS. With --manifests, print the manifests of the synthetic cluster instead, " +
+			"to generate load on a real cluster.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.manifests {
+				return printBenchmarkManifests(cmd.OutOrStdout(), opts.Options)
+			}
+			return runBenchmark(cmd.OutOrStdout(), opts)
+		},
+	}
+	cmd.Flags().IntVar(&opts.Gateways, "gateways", 10, "The number of Gateways.")
+	cmd.Flags().IntVar(&opts.RoutesPerGateway, "routes", 10, "The number of HTTPRoutes attached to each Gateway.")
+	cmd.Flags().IntVar(&opts.iterations, "iterations", 10, "The number of translations to average the measurements over.")
+	cmd.Flags().BoolVar(&opts.manifests, "manifests", false, "Print the manifests of the synthetic cluster.")
+
+	return cmd
+}
+
+// runBenchmark measures the translation of the synthetic cluster.
+func runBenchmark(w io.Writer, opts *benchmarkOptions) error {
+	result, err := benchmark.Run(opts.Options, opts.iterations)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%s\n", opts.Options)
+	fmt.Fprintf(w, "gateway-api translation: %s\n", result.GatewayAPITranslation)
+	fmt.Fprintf(w, "xds translation: %s\n", result.XdsTranslation)
+	return nil
+}
+
+// printBenchmarkManifests prints the manifests of the synthetic cluster,
+// along with the GatewayClass of its Gateways.
+func printBenchmarkManifests(w io.Writer, opts benchmark.Options) error {
+	resources := benchmark.Resources(opts)
+	objs := []interface{}{&v1beta1.GatewayClass{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1beta1.GroupVersion.String(), Kind: "GatewayClass"},
+		ObjectMeta: metav1.ObjectMeta{Name: benchmark.GatewayClassName},
+		Spec:       v1beta1.GatewayClassSpec{ControllerName: v1alpha1.GatewayControllerName},
+	}}
+	for _, ns := range resources.Namespaces {
+		objs = append(objs, ns)
+	}
+	for _, svc := range resources.Services {
+		// The cluster IPs are allocated by the cluster.
+		svc.Spec.ClusterIP = ""
+		objs = append(objs, svc)
+	}
+	for _, gw := range resources.Gateways {
+		objs = append(objs, gw)
+	}
+	for _, route := range resources.HTTPRoutes {
+		objs = append(objs, route)
+	}
+
+	for _, obj := range objs {
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "---\n%s", out)
+	}
+	return nil
+}
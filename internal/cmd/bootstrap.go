@@ -0,0 +1,146 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clicfg "sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	"github.com/envoyproxy/gateway/internal/crypto"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
+	xdsrunner "github.com/envoyproxy/gateway/internal/xds/server/runner"
+)
+
+// bootstrapOptions holds the options of the bootstrap command.
+type bootstrapOptions struct {
+	gatewayNamespace string
+	gatewayName      string
+	mesh             bool
+	nodeID           string
+	xdsAddress       string
+	xdsPort          int32
+	sdsDir           string
+	outputDir        string
+}
+
+// getBootstrapCommand returns the bootstrap cobra command to be executed.
+func getBootstrapCommand() *cobra.Command {
+	opts := &bootstrapOptions{}
+	cmd := &cobra.Command{
+		Use:   "bootstrap",
+		Short: "Generate the bootstrap configuration of an externally managed Envoy",
+		Long: "Generate the bootstrap configuration of an Envoy instance that is not managed by Envoy Gateway, " +
+			"e.g. running on a VM, binding the node to the provided Gateway, or to the mesh in mesh mode. If an output directory is provided, " +
+			"a client certificate is issued for the node and written along with the SDS and bootstrap files.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.mesh == (opts.gatewayName != "") {
+				return fmt.Errorf("exactly one of --gateway-name and --mesh must be set")
+			}
+			return generateBootstrap(ctrl.SetupSignalHandler(), opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.gatewayNamespace, "gateway-namespace", "default", "The namespace of the Gateway the node serves.")
+	cmd.Flags().StringVar(&opts.gatewayName, "gateway-name", "", "The name of the Gateway the node serves.")
+	cmd.Flags().BoolVar(&opts.mesh, "mesh", false, "Bind the node to the mesh instead of a Gateway, serving the routes attached to Services.")
+	cmd.Flags().StringVar(&opts.nodeID, "node-id", "", "The unique ID of the node.")
+	cmd.Flags().StringVar(&opts.xdsAddress, "xds-address", "", "The address of the xDS server reachable from the node.")
+	cmd.Flags().Int32Var(&opts.xdsPort, "xds-port", xdsrunner.XdsServerPort, "The port of the xDS server reachable from the node.")
+	cmd.Flags().StringVar(&opts.sdsDir, "sds-dir", "/etc/envoy", "The directory the certificate and SDS files are installed into on the node.")
+	cmd.Flags().StringVar(&opts.outputDir, "output-dir", "", "The directory to write the bootstrap, certificate and SDS files to.")
+	_ = cmd.MarkFlagRequired("node-id")
+	_ = cmd.MarkFlagRequired("xds-address")
+
+	return cmd
+}
+
+// generateBootstrap renders the bootstrap configuration and, if requested,
+// issues the xDS client certificate of the node.
+func generateBootstrap(ctx context.Context, opts *bootstrapOptions) error {
+	cluster := gatewayapi.IRKey(opts.gatewayNamespace, opts.gatewayName)
+	if opts.mesh {
+		cluster = gatewayapi.MeshIRKey
+	}
+	bootstrapYAML, err := bootstrap.GetRenderedExternalBootstrapConfig(&bootstrap.ExternalOptions{
+		Cluster:    cluster,
+		NodeID:     opts.nodeID,
+		XdsAddress: opts.xdsAddress,
+		XdsPort:    opts.xdsPort,
+		SdsDir:     opts.sdsDir,
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.outputDir == "" {
+		fmt.Print(bootstrapYAML)
+		return nil
+	}
+
+	caCert, caKey, err := getCA(ctx)
+	if err != nil {
+		return err
+	}
+	cert, key, err := crypto.GenerateProxyCert(caCert, caKey, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to generate node certificate: %v", err)
+	}
+
+	caPath := filepath.Join(opts.sdsDir, "ca.crt")
+	certPath := filepath.Join(opts.sdsDir, corev1.TLSCertKey)
+	keyPath := filepath.Join(opts.sdsDir, corev1.TLSPrivateKeyKey)
+	files := map[string][]byte{
+		"bootstrap.yaml":          []byte(bootstrapYAML),
+		"ca.crt":                  caCert,
+		corev1.TLSCertKey:         cert,
+		corev1.TLSPrivateKeyKey:   key,
+		bootstrap.SdsCAFilename:   []byte(bootstrap.SdsCAConfig(caPath)),
+		bootstrap.SdsCertFilename: []byte(bootstrap.SdsCertificateConfig(certPath, keyPath)),
+	}
+
+	if err := os.MkdirAll(opts.outputDir, 0o750); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(opts.outputDir, name), data, 0o600); err != nil {
+			return fmt.Errorf("failed to write %s: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+// getCA returns the CA used to issue the xDS client certificates.
+func getCA(ctx context.Context) ([]byte, []byte, error) {
+	cli, err := client.New(clicfg.GetConfigOrDie(), client.Options{Scheme: envoygateway.GetScheme()})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create controller-runtime client: %v", err)
+	}
+
+	secret := new(corev1.Secret)
+	key := types.NamespacedName{Namespace: config.EnvoyGatewayNamespace, Name: config.EnvoyGatewayServiceName}
+	if err := cli.Get(ctx, key, secret); err != nil {
+		return nil, nil, fmt.Errorf("failed to get secret %s: %v", key, err)
+	}
+
+	caCert, caKey := secret.Data["ca.crt"], secret.Data["ca.key"]
+	if len(caCert) == 0 || len(caKey) == 0 {
+		return nil, nil, fmt.Errorf("secret %s is missing the CA certificate or key", key)
+	}
+
+	return caCert, caKey, nil
+}
@@ -0,0 +1,117 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/xds/audit"
+)
+
+// auditOptions holds the options of the audit command.
+type auditOptions struct {
+	namespace string
+	since     time.Duration
+}
+
+// newAuditCommand returns the audit cobra command.
+func newAuditCommand() *cobra.Command {
+	opts := &auditOptions{}
+	cmd := &cobra.Command{
+		Use:   "audit GATEWAY",
+		Short: "Show the history of the xDS configuration applied to a Gateway",
+		Long: "Show the last generations of the xDS snapshot of the proxies of a Gateway applied by each replica " +
+			"of Envoy Gateway, with the time they were applied, their hash and the resources they changed. " +
+			"The history is read from the metrics endpoint of the Envoy Gateway pods through the API server pod proxy, " +
+			"and is lost when a replica restarts.",
+		Example: "  egctl x audit eg --namespace default --since 1h",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clients, err := newKubeClients()
+			if err != nil {
+				return err
+			}
+			return printAudit(cmd.Context(), cmd.OutOrStdout(), clients, args[0], opts, time.Now())
+		},
+	}
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "default", "The namespace of the Gateway.")
+	cmd.Flags().DurationVar(&opts.since, "since", 0,
+		"Only show the generations applied within this duration, e.g. 1h. Shows all the generations if unset.")
+
+	return cmd
+}
+
+// printAudit writes the audit trail of the Gateway recorded by each Envoy
+// Gateway pod to out.
+func printAudit(ctx context.Context, out io.Writer, clients *kubeClients, gateway string, opts *auditOptions, now time.Time) error {
+	pods, err := envoyGatewayPods(ctx, clients)
+	if err != nil {
+		return err
+	}
+
+	key := gatewayapi.IRKey(opts.namespace, gateway)
+	tw := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "POD\tGENERATION\tTIME\tHASH\tCHANGES")
+	for i := range pods {
+		body, err := clients.clientset.CoreV1().Pods(pods[i].Namespace).
+			ProxyGet("http", pods[i].Name, strconv.Itoa(envoyGatewayMetricsPort),
+				strings.TrimPrefix(audit.HandlerPath, "/"), map[string]string{"key": key}).
+			DoRaw(ctx)
+		if err != nil {
+			fmt.Fprintf(tw, "%s\t-\t-\t-\tunknown (%v)\n", pods[i].Name, err)
+			continue
+		}
+		entries, err := parseAuditEntries(body, key)
+		if err != nil {
+			return fmt.Errorf("failed to parse audit trail of pod %s: %w", pods[i].Name, err)
+		}
+		for _, entry := range filterAuditEntries(entries, opts.since, now) {
+			hash := entry.Hash
+			if hash == "" {
+				hash = "-"
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\n", pods[i].Name, entry.Generation,
+				entry.Time.Format(time.RFC3339), hash, entry.Summary())
+		}
+	}
+
+	return tw.Flush()
+}
+
+// parseAuditEntries returns the entries of the key in the audit trail served
+// by an Envoy Gateway pod.
+func parseAuditEntries(body []byte, key string) ([]audit.Entry, error) {
+	entries := make(map[string][]audit.Entry)
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return nil, err
+	}
+	return entries[key], nil
+}
+
+// filterAuditEntries returns the entries applied within since of now, or all
+// the entries if since is zero.
+func filterAuditEntries(entries []audit.Entry, since time.Duration, now time.Time) []audit.Entry {
+	if since <= 0 {
+		return entries
+	}
+	var filtered []audit.Entry
+	for _, entry := range entries {
+		if !entry.Time.Before(now.Add(-since)) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
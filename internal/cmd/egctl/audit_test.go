@@ -0,0 +1,37 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAuditEntries(t *testing.T) {
+	body := []byte(`{"default-eg":[` +
+		`{"generation":1,"hash":"0123","time":"2022-10-01T14:00:00Z","added":["Listener/http"]},` +
+		`{"generation":2,"hash":"4567","time":"2022-10-01T14:32:00Z","modified":["Cluster/backend"]}]}`)
+	now := time.Date(2022, 10, 1, 14, 40, 0, 0, time.UTC)
+
+	entries, err := parseAuditEntries(body, "default-eg")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Len(t, filterAuditEntries(entries, 0, now), 2)
+
+	filtered := filterAuditEntries(entries, 10*time.Minute, now)
+	require.Len(t, filtered, 1)
+	require.Equal(t, int64(2), filtered[0].Generation)
+	require.Equal(t, "modified Cluster/backend", filtered[0].Summary())
+
+	entries, err = parseAuditEntries(body, "default-other")
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	_, err = parseAuditEntries([]byte("not json"), "default-eg")
+	require.Error(t, err)
+}
@@ -0,0 +1,108 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+)
+
+// drainOptions holds the options of the drain command.
+type drainOptions struct {
+	namespace string
+	undo      bool
+}
+
+// newDrainCommand returns the drain cobra command.
+func newDrainCommand() *cobra.Command {
+	opts := &drainOptions{}
+	cmd := &cobra.Command{
+		Use:   "drain (httproute|service) NAME",
+		Short: "Drain the traffic of a route or a backend",
+		Long: "Drain an HTTPRoute, or a Service in all the HTTPRoutes forwarding requests to it, by setting the " +
+			gatewayapi.DrainAnnotation + " annotation. The backends drained receive no new requests, " +
+			"while the requests in flight complete. The specification of the HTTPRoutes is not modified.",
+		Example: "  egctl x drain service backend-v1 --namespace default\n" +
+			"  egctl x drain service backend-v1 --namespace default --undo",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clients, err := newKubeClients()
+			if err != nil {
+				return err
+			}
+			return drain(cmd.Context(), cmd.OutOrStdout(), clients.client, args[0], args[1], opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "default", "The namespace of the route or Service.")
+	cmd.Flags().BoolVar(&opts.undo, "undo", false, "Undrain the route or Service, restoring its traffic.")
+
+	return cmd
+}
+
+// drain sets, or removes if undoing, the drain annotation of the route or
+// Service of the provided kind and name.
+func drain(ctx context.Context, out io.Writer, cli client.Client, kind, name string, opts *drainOptions) error {
+	obj, err := drainableObject(kind)
+	if err != nil {
+		return err
+	}
+	obj.SetNamespace(opts.namespace)
+	obj.SetName(name)
+
+	patch, err := drainPatch(opts.undo)
+	if err != nil {
+		return err
+	}
+	key := types.NamespacedName{Namespace: opts.namespace, Name: name}
+	if err := cli.Patch(ctx, obj, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return fmt.Errorf("failed to patch %s %s: %w", kind, key, err)
+	}
+
+	if opts.undo {
+		fmt.Fprintf(out, "%s %s undrained\n", kind, key)
+	} else {
+		fmt.Fprintf(out, "%s %s drained\n", kind, key)
+	}
+	return nil
+}
+
+// drainableObject returns an empty object of the provided kind, which must
+// be a kind the drain annotation is supported on.
+func drainableObject(kind string) (client.Object, error) {
+	switch strings.ToLower(kind) {
+	case "httproute", "httproutes":
+		return new(gwapiv1b1.HTTPRoute), nil
+	case "service", "services", "svc":
+		return new(corev1.Service), nil
+	default:
+		return nil, fmt.Errorf("unsupported kind %q, only httproute and service can be drained", kind)
+	}
+}
+
+// drainPatch returns the merge patch setting the drain annotation, or
+// removing it if undoing.
+func drainPatch(undo bool) ([]byte, error) {
+	var value interface{} = "true"
+	if undo {
+		value = nil
+	}
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{gatewayapi.DrainAnnotation: value},
+		},
+	})
+}
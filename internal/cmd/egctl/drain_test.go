@@ -0,0 +1,51 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+)
+
+func TestDrain(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "backend",
+			Annotations: map[string]string{"app": "backend"},
+		},
+	}
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects(svc).Build()
+	ctx := context.Background()
+	key := types.NamespacedName{Namespace: "default", Name: "backend"}
+
+	var out bytes.Buffer
+	require.NoError(t, drain(ctx, &out, cli, "svc", "backend", &drainOptions{namespace: "default"}))
+	require.Equal(t, "svc default/backend drained\n", out.String())
+	got := new(corev1.Service)
+	require.NoError(t, cli.Get(ctx, key, got))
+	require.Equal(t, map[string]string{"app": "backend", gatewayapi.DrainAnnotation: "true"}, got.Annotations)
+
+	out.Reset()
+	require.NoError(t, drain(ctx, &out, cli, "svc", "backend", &drainOptions{namespace: "default", undo: true}))
+	require.Equal(t, "svc default/backend undrained\n", out.String())
+	require.NoError(t, cli.Get(ctx, key, got))
+	require.Equal(t, map[string]string{"app": "backend"}, got.Annotations)
+
+	require.Error(t, drain(ctx, &out, cli, "httproute", "missing", &drainOptions{namespace: "default"}))
+	require.EqualError(t, drain(ctx, &out, cli, "gateway", "eg", &drainOptions{namespace: "default"}),
+		`unsupported kind "gateway", only httproute and service can be drained`)
+}
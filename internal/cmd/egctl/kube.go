@@ -0,0 +1,99 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clicfg "sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+)
+
+const (
+	// envoyGatewayDeploymentName is the name of the Envoy Gateway Deployment.
+	envoyGatewayDeploymentName = "envoy-gateway"
+	// envoyGatewayContainerName is the name of the Envoy Gateway container.
+	envoyGatewayContainerName = "envoy-gateway"
+	// envoyContainerName is the name of the container of the managed Envoy pods.
+	envoyContainerName = "envoy"
+	// envoyAppLabel is the label set on the managed Envoy pods.
+	envoyAppLabel = "app.gateway.envoyproxy.io/name"
+)
+
+// kubeClients holds the clients used to query the Kubernetes API.
+type kubeClients struct {
+	client    client.Client
+	clientset kubernetes.Interface
+}
+
+// newKubeClients returns the Kubernetes clients of the current kubeconfig context.
+func newKubeClients() (*kubeClients, error) {
+	cfg, err := clicfg.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+	cli, err := client.New(cfg, client.Options{Scheme: envoygateway.GetScheme()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create controller-runtime client: %w", err)
+	}
+	cs, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clientset: %w", err)
+	}
+
+	return &kubeClients{client: cli, clientset: cs}, nil
+}
+
+// envoyGatewayPods returns the pods of the Envoy Gateway Deployment.
+func envoyGatewayPods(ctx context.Context, clients *kubeClients) ([]corev1.Pod, error) {
+	deploy := new(appsv1.Deployment)
+	key := types.NamespacedName{Namespace: config.EnvoyGatewayNamespace, Name: envoyGatewayDeploymentName}
+	if err := clients.client.Get(ctx, key, deploy); err != nil {
+		return nil, fmt.Errorf("failed to get deployment %s: %w", key, err)
+	}
+	if deploy.Spec.Selector == nil {
+		return nil, errors.New("envoy gateway deployment has no selector")
+	}
+	pods := new(corev1.PodList)
+	if err := clients.client.List(ctx, pods, client.InNamespace(key.Namespace),
+		client.MatchingLabels(deploy.Spec.Selector.MatchLabels)); err != nil {
+		return nil, fmt.Errorf("failed to list envoy gateway pods: %w", err)
+	}
+	return pods.Items, nil
+}
+
+// containerImageVersion returns the tag of the image of the named container
+// of the pod spec, or an empty string if not found.
+func containerImageVersion(spec *corev1.PodSpec, name string) string {
+	for _, c := range spec.Containers {
+		if c.Name == name {
+			return imageTag(c.Image)
+		}
+	}
+	return ""
+}
+
+// imageTag returns the tag or digest of the provided image reference.
+func imageTag(image string) string {
+	if i := strings.LastIndex(image, "@"); i >= 0 {
+		return image[i+1:]
+	}
+	// Ignore the colon of a registry port.
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[i+1:]
+	}
+	return "latest"
+}
@@ -0,0 +1,42 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// GetRootCommand returns the root cobra command to be executed
+// by main.
+func GetRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "egctl",
+		Short: "A command line utility for operating Envoy Gateway",
+		Long:  "A command line utility for operating and debugging Envoy Gateway",
+	}
+
+	cmd.AddCommand(newVersionCommand())
+	cmd.AddCommand(newExperimentalCommand())
+
+	return cmd
+}
+
+// newExperimentalCommand returns the experimental cobra command, grouping
+// the commands that may change or be removed in future releases.
+func newExperimentalCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "experimental",
+		Aliases: []string{"x"},
+		Short:   "Experimental features",
+	}
+
+	cmd.AddCommand(newTranslateCommand())
+	cmd.AddCommand(newStatusCommand())
+	cmd.AddCommand(newAuditCommand())
+	cmd.AddCommand(newDrainCommand())
+
+	return cmd
+}
@@ -0,0 +1,254 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+)
+
+const (
+	// defaultEnvoyAdminPort is the port of the Envoy admin endpoint.
+	defaultEnvoyAdminPort = 19000
+	// envoyGatewayMetricsPort is the port of the metrics endpoint of Envoy Gateway.
+	envoyGatewayMetricsPort = 8080
+	// nodeSyncedMetric is the metric reporting whether a proxy accepted the
+	// latest xDS snapshot of its cluster.
+	nodeSyncedMetric = "envoy_gateway_xds_node_synced"
+	// xdsStatsFilter selects the Envoy stats used to compute the xDS sync state.
+	xdsStatsFilter = `^control_plane\.connected_state$|\.update_rejected$`
+
+	syncStateConnected    = "CONNECTED"
+	syncStateDisconnected = "DISCONNECTED"
+	syncStateUnknown      = "UNKNOWN"
+)
+
+// statusOptions holds the options of the status command.
+type statusOptions struct {
+	controllerName string
+	adminPort      int
+}
+
+// newStatusCommand returns the status cobra command.
+func newStatusCommand() *cobra.Command {
+	opts := &statusOptions{}
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the status of the Envoy Gateway fleet",
+		Long: "Show the version of the Envoy Gateway control plane, the GatewayClasses it owns and, " +
+			"for each Gateway of these GatewayClasses, the version and xDS sync state of its Envoy pods. " +
+			"The xDS sync state is read from the Envoy admin endpoint through the API server pod proxy, " +
+			"and is reported as UNKNOWN if the admin endpoint is not reachable from the API server. " +
+			"Whether each Envoy pod accepted the latest configuration is read from the metrics of Envoy Gateway, " +
+			"and is reported as - if unknown.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clients, err := newKubeClients()
+			if err != nil {
+				return err
+			}
+			return printStatus(cmd.Context(), cmd.OutOrStdout(), clients, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.controllerName, "controller-name", v1alpha1.GatewayControllerName,
+		"The controller name of the GatewayClasses managed by Envoy Gateway.")
+	cmd.Flags().IntVar(&opts.adminPort, "admin-port", defaultEnvoyAdminPort, "The port of the Envoy admin endpoint.")
+
+	return cmd
+}
+
+// printStatus writes the status of the Envoy Gateway fleet to out.
+func printStatus(ctx context.Context, out io.Writer, clients *kubeClients, opts *statusOptions) error {
+	serverVersion, err := controlPlaneVersion(ctx, clients)
+	if err != nil {
+		serverVersion = fmt.Sprintf("unknown (%v)", err)
+	}
+	fmt.Fprintf(out, "CONTROL PLANE VERSION: %s\n\n", serverVersion)
+
+	gcs := new(gwapiv1b1.GatewayClassList)
+	if err := clients.client.List(ctx, gcs); err != nil {
+		return fmt.Errorf("failed to list gatewayclasses: %w", err)
+	}
+	owned := make(map[string]bool)
+	tw := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "GATEWAYCLASS\tACCEPTED")
+	for _, gc := range gcs.Items {
+		if string(gc.Spec.ControllerName) != opts.controllerName {
+			continue
+		}
+		owned[gc.Name] = true
+		fmt.Fprintf(tw, "%s\t%s\n", gc.Name, conditionStatus(gc.Status.Conditions, string(gwapiv1b1.GatewayClassConditionStatusAccepted)))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(out)
+
+	gws := new(gwapiv1b1.GatewayList)
+	if err := clients.client.List(ctx, gws); err != nil {
+		return fmt.Errorf("failed to list gateways: %w", err)
+	}
+	synced := proxySyncStatus(ctx, clients)
+	fmt.Fprintln(tw, "GATEWAY\tPOD\tVERSION\tREADY\tXDS\tSYNCED")
+	for _, gw := range gws.Items {
+		if !owned[string(gw.Spec.GatewayClassName)] {
+			continue
+		}
+		pods := new(corev1.PodList)
+		if err := clients.client.List(ctx, pods, client.InNamespace(config.EnvoyGatewayNamespace), client.MatchingLabels{
+			envoyAppLabel:                          envoyContainerName,
+			gatewayapi.OwningGatewayNamespaceLabel: gw.Namespace,
+			gatewayapi.OwningGatewayNameLabel:      gw.Name,
+		}); err != nil {
+			return fmt.Errorf("failed to list pods of gateway %s/%s: %w", gw.Namespace, gw.Name, err)
+		}
+		if len(pods.Items) == 0 {
+			fmt.Fprintf(tw, "%s/%s\t-\t-\t-\t-\t-\n", gw.Namespace, gw.Name)
+			continue
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			podSynced := "-"
+			if v, ok := synced[pod.Name]; ok {
+				podSynced = strconv.FormatBool(v)
+			}
+			fmt.Fprintf(tw, "%s/%s\t%s\t%s\t%t\t%s\t%s\n", gw.Namespace, gw.Name, pod.Name,
+				containerImageVersion(&pod.Spec, envoyContainerName), isPodReady(pod),
+				xdsSyncState(ctx, clients, pod, opts.adminPort), podSynced)
+		}
+	}
+
+	return tw.Flush()
+}
+
+// xdsSyncState returns the xDS sync state of the Envoy pod, read from its
+// admin endpoint.
+func xdsSyncState(ctx context.Context, clients *kubeClients, pod *corev1.Pod, adminPort int) string {
+	stats, err := clients.clientset.CoreV1().Pods(pod.Namespace).
+		ProxyGet("http", pod.Name, strconv.Itoa(adminPort), "stats", map[string]string{"filter": xdsStatsFilter}).
+		DoRaw(ctx)
+	if err != nil {
+		return syncStateUnknown
+	}
+	return parseSyncState(string(stats))
+}
+
+// parseSyncState returns the xDS sync state from the Envoy stats, in the
+// "name: value" admin text format.
+func parseSyncState(stats string) string {
+	state := syncStateUnknown
+	var rejected uint64
+	scanner := bufio.NewScanner(strings.NewReader(stats))
+	for scanner.Scan() {
+		name, value, found := strings.Cut(scanner.Text(), ": ")
+		if !found {
+			continue
+		}
+		switch {
+		case name == "control_plane.connected_state":
+			if value == "1" {
+				state = syncStateConnected
+			} else {
+				state = syncStateDisconnected
+			}
+		case strings.HasSuffix(name, ".update_rejected"):
+			if n, err := strconv.ParseUint(value, 10, 64); err == nil {
+				rejected += n
+			}
+		}
+	}
+
+	if rejected > 0 && state != syncStateUnknown {
+		return fmt.Sprintf("%s (%d rejected updates)", state, rejected)
+	}
+	return state
+}
+
+// proxySyncStatus returns whether each Envoy pod, by node ID, accepted the
+// latest xDS snapshot of its Gateway, read from the metrics of the Envoy
+// Gateway pods through the API server pod proxy. The pods missing from the
+// metrics, or the metrics not reachable, are left out.
+func proxySyncStatus(ctx context.Context, clients *kubeClients) map[string]bool {
+	synced := make(map[string]bool)
+	pods, err := envoyGatewayPods(ctx, clients)
+	if err != nil {
+		return synced
+	}
+
+	// Each proxy is connected to a single replica of Envoy Gateway.
+	for i := range pods {
+		metrics, err := clients.clientset.CoreV1().Pods(pods[i].Namespace).
+			ProxyGet("http", pods[i].Name, strconv.Itoa(envoyGatewayMetricsPort), "metrics", nil).
+			DoRaw(ctx)
+		if err != nil {
+			continue
+		}
+		for node, v := range parseNodeSynced(string(metrics)) {
+			synced[node] = synced[node] || v
+		}
+	}
+
+	return synced
+}
+
+// parseNodeSynced returns the sync status of each node from the metrics, in
+// the Prometheus text format.
+func parseNodeSynced(metrics string) map[string]bool {
+	synced := make(map[string]bool)
+	scanner := bufio.NewScanner(strings.NewReader(metrics))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, nodeSyncedMetric+"{") {
+			continue
+		}
+		labels, value, found := strings.Cut(strings.TrimPrefix(line, nodeSyncedMetric+"{"), "} ")
+		if !found {
+			continue
+		}
+		for _, label := range strings.Split(labels, ",") {
+			name, node, _ := strings.Cut(label, "=")
+			if name == "node" {
+				synced[strings.Trim(node, `"`)] = value == "1"
+			}
+		}
+	}
+	return synced
+}
+
+// conditionStatus returns the status of the condition of the provided type,
+// or Unknown if not found.
+func conditionStatus(conditions []metav1.Condition, conditionType string) metav1.ConditionStatus {
+	for _, cond := range conditions {
+		if cond.Type == conditionType {
+			return cond.Status
+		}
+	}
+	return metav1.ConditionUnknown
+}
+
+// isPodReady returns true if the Ready condition of the pod is true.
+func isPodReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSyncState(t *testing.T) {
+	testCases := []struct {
+		name  string
+		stats string
+		want  string
+	}{
+		{
+			name:  "connected",
+			stats: "control_plane.connected_state: 1\ncluster_manager.cds.update_rejected: 0\nlistener_manager.lds.update_rejected: 0\n",
+			want:  syncStateConnected,
+		},
+		{
+			name:  "disconnected",
+			stats: "control_plane.connected_state: 0\n",
+			want:  syncStateDisconnected,
+		},
+		{
+			name:  "rejected updates",
+			stats: "control_plane.connected_state: 1\ncluster_manager.cds.update_rejected: 2\nlistener_manager.lds.update_rejected: 1\n",
+			want:  "CONNECTED (3 rejected updates)",
+		},
+		{
+			name:  "no connected state",
+			stats: "listener_manager.lds.update_rejected: 1\n",
+			want:  syncStateUnknown,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, parseSyncState(tc.stats))
+		})
+	}
+}
+
+func TestImageTag(t *testing.T) {
+	testCases := map[string]string{
+		"envoyproxy/envoy:v1.23.0":                 "v1.23.0",
+		"localhost:5000/envoyproxy/gateway:v0.2.0": "v0.2.0",
+		"envoyproxy/envoy@sha256:0123":             "sha256:0123",
+		"localhost:5000/envoyproxy/envoy":          "latest",
+	}
+	for image, want := range testCases {
+		require.Equal(t, want, imageTag(image), image)
+	}
+}
+
+func TestParseNodeSynced(t *testing.T) {
+	metrics := `# HELP envoy_gateway_xds_node_synced Whether a proxy accepted the latest snapshot of its cluster for all resource types (1) or not (0).
+# TYPE envoy_gateway_xds_node_synced gauge
+envoy_gateway_xds_node_synced{cluster="default-eg",node="envoy-default-eg-1"} 1
+envoy_gateway_xds_node_synced{cluster="default-eg",node="envoy-default-eg-2"} 0
+envoy_gateway_xds_snapshot_rejected{cluster="default-eg"} 0
+`
+	require.Equal(t, map[string]bool{
+		"envoy-default-eg-1": true,
+		"envoy-default-eg-2": false,
+	}, parseNodeSynced(metrics))
+}
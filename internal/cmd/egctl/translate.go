@@ -0,0 +1,207 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/translator"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+const (
+	// translateFromXdsIR is the xDS IR input type.
+	translateFromXdsIR = "xds-ir"
+	// translateToXds is the xDS output type.
+	translateToXds = "xds"
+
+	outputYAML = "yaml"
+	outputJSON = "json"
+)
+
+// xdsResourceTypes holds the xDS resource types to output, and their
+// output field names.
+var xdsResourceTypes = []struct {
+	typeURL string
+	name    string
+}{
+	{resource.ListenerType, "listeners"},
+	{resource.RouteType, "routes"},
+	{resource.ClusterType, "clusters"},
+	{resource.SecretType, "secrets"},
+}
+
+// translateOptions holds the options of the translate command.
+type translateOptions struct {
+	file   string
+	from   string
+	to     string
+	output string
+}
+
+// newTranslateCommand returns the translate cobra command.
+func newTranslateCommand() *cobra.Command {
+	opts := &translateOptions{}
+	cmd := &cobra.Command{
+		Use:   "translate",
+		Short: "Translate configuration from an input type to an output type",
+		Long: "Translate a dumped xDS IR into the xDS resources Envoy Gateway generates for it, " +
+			"so that IR level issues can be reproduced and bisected independently of the provider. " +
+			"The input is either a single xDS IR, or xDS IRs keyed by name as dumped by the gateway-api runner.",
+		Example: "  egctl x translate --from xds-ir --to xds --file xds-ir.yaml",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return translate(cmd.InOrStdin(), cmd.OutOrStdout(), opts)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "The file to translate, or - to read from standard input.")
+	cmd.Flags().StringVar(&opts.from, "from", translateFromXdsIR, "The input type. Supported types are: xds-ir.")
+	cmd.Flags().StringVar(&opts.to, "to", translateToXds, "The output type. Supported types are: xds.")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", outputYAML, "The output format, yaml or json.")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// translate reads the input from the file, or in if the file is -, and
+// writes the translated output to out.
+func translate(in io.Reader, out io.Writer, opts *translateOptions) error {
+	if opts.from != translateFromXdsIR {
+		return fmt.Errorf("unsupported input type %q, must be %s", opts.from, translateFromXdsIR)
+	}
+	if opts.to != translateToXds {
+		return fmt.Errorf("unsupported output type %q, must be %s", opts.to, translateToXds)
+	}
+	if opts.output != outputYAML && opts.output != outputJSON {
+		return fmt.Errorf("unsupported output format %q, must be %s or %s", opts.output, outputYAML, outputJSON)
+	}
+
+	var data []byte
+	var err error
+	if opts.file == "-" {
+		data, err = io.ReadAll(in)
+	} else {
+		data, err = os.ReadFile(opts.file)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read input: %w", err)
+	}
+
+	xdsIRs, single, err := parseXdsIR(data)
+	if err != nil {
+		return err
+	}
+
+	outputs := make(map[string]map[string]json.RawMessage, len(xdsIRs))
+	for name, xdsIR := range xdsIRs {
+		if err := xdsIR.Validate(); err != nil {
+			return fmt.Errorf("invalid xds ir %s: %w", name, err)
+		}
+		tCtx, err := translator.Translate(xdsIR)
+		if err != nil {
+			return fmt.Errorf("failed to translate xds ir %s: %w", name, err)
+		}
+		resources, err := marshalXdsResources(tCtx)
+		if err != nil {
+			return err
+		}
+		outputs[name] = resources
+	}
+
+	var result []byte
+	if single {
+		for _, resources := range outputs {
+			result, err = json.Marshal(resources)
+		}
+	} else {
+		result, err = json.Marshal(outputs)
+	}
+	if err != nil {
+		return err
+	}
+
+	if opts.output == outputYAML {
+		if result, err = yaml.JSONToYAML(result); err != nil {
+			return err
+		}
+	} else {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, result, "", "  "); err != nil {
+			return err
+		}
+		buf.WriteByte('\n')
+		result = buf.Bytes()
+	}
+
+	_, err = out.Write(result)
+	return err
+}
+
+// parseXdsIR parses either a single xDS IR, or xDS IRs keyed by name. The
+// returned bool is true if a single xDS IR was parsed.
+func parseXdsIR(data []byte) (map[string]*ir.Xds, bool, error) {
+	var fields map[string]interface{}
+	if err := yaml.Unmarshal(data, &fields); err != nil {
+		return nil, false, fmt.Errorf("failed to parse xds ir: %w", err)
+	}
+
+	single := true
+	for field := range fields {
+		switch strings.ToLower(field) {
+		case "http", "tcp", "udp":
+		default:
+			single = false
+		}
+	}
+
+	if single {
+		xdsIR := new(ir.Xds)
+		if err := yaml.Unmarshal(data, xdsIR); err != nil {
+			return nil, false, fmt.Errorf("failed to parse xds ir: %w", err)
+		}
+		return map[string]*ir.Xds{"": xdsIR}, true, nil
+	}
+
+	xdsIRs := make(map[string]*ir.Xds)
+	if err := yaml.Unmarshal(data, &xdsIRs); err != nil {
+		return nil, false, fmt.Errorf("failed to parse xds ir: %w", err)
+	}
+	return xdsIRs, false, nil
+}
+
+// marshalXdsResources returns the JSON encoded xDS resources of the provided
+// table, keyed by resource type name.
+func marshalXdsResources(tCtx *types.ResourceVersionTable) (map[string]json.RawMessage, error) {
+	resources := make(map[string]json.RawMessage)
+	for _, rType := range xdsResourceTypes {
+		var buf bytes.Buffer
+		buf.WriteByte('[')
+		for i, r := range tCtx.GetXdsResources()[rType.typeURL] {
+			if i != 0 {
+				buf.WriteByte(',')
+			}
+			b, err := protojson.Marshal(r.(proto.Message))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal %s: %w", rType.name, err)
+			}
+			buf.Write(b)
+		}
+		buf.WriteByte(']')
+		resources[rType.name] = buf.Bytes()
+	}
+	return resources, nil
+}
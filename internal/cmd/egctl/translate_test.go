@@ -0,0 +1,123 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		file      string
+		output    string
+		wantNames []string
+	}{
+		{
+			name:   "single xds ir",
+			file:   "xds-ir.yaml",
+			output: outputYAML,
+		},
+		{
+			name:      "xds ir map",
+			file:      "xds-ir-map.yaml",
+			output:    outputJSON,
+			wantNames: []string{"envoy-gateway-gateway-1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			opts := &translateOptions{
+				file:   filepath.Join("testdata", "translate", tc.file),
+				from:   translateFromXdsIR,
+				to:     translateToXds,
+				output: tc.output,
+			}
+			require.NoError(t, translate(nil, out, opts))
+
+			data := out.Bytes()
+			if tc.output == outputYAML {
+				var err error
+				data, err = yaml.YAMLToJSON(data)
+				require.NoError(t, err)
+			}
+
+			var resources map[string][]interface{}
+			if tc.wantNames == nil {
+				require.NoError(t, json.Unmarshal(data, &resources))
+				requireXdsResources(t, resources)
+				return
+			}
+
+			var outputs map[string]map[string][]interface{}
+			require.NoError(t, json.Unmarshal(data, &outputs))
+			require.Len(t, outputs, len(tc.wantNames))
+			for _, name := range tc.wantNames {
+				requireXdsResources(t, outputs[name])
+			}
+		})
+	}
+}
+
+func TestTranslateStdin(t *testing.T) {
+	out := new(bytes.Buffer)
+	in := strings.NewReader("http:\n- name: listener\n  address: 0.0.0.0\n  port: 10080\n  hostnames:\n  - \"*\"\n")
+	opts := &translateOptions{file: "-", from: translateFromXdsIR, to: translateToXds, output: outputYAML}
+	require.NoError(t, translate(in, out, opts))
+	require.Contains(t, out.String(), "listeners:")
+}
+
+func TestTranslateErrors(t *testing.T) {
+	file := filepath.Join("testdata", "translate", "xds-ir.yaml")
+	testCases := []struct {
+		name string
+		opts *translateOptions
+	}{
+		{
+			name: "unsupported input type",
+			opts: &translateOptions{file: file, from: "gateway-api", to: translateToXds, output: outputYAML},
+		},
+		{
+			name: "unsupported output type",
+			opts: &translateOptions{file: file, from: translateFromXdsIR, to: "envoy", output: outputYAML},
+		},
+		{
+			name: "unsupported output format",
+			opts: &translateOptions{file: file, from: translateFromXdsIR, to: translateToXds, output: "xml"},
+		},
+		{
+			name: "missing file",
+			opts: &translateOptions{file: "missing.yaml", from: translateFromXdsIR, to: translateToXds, output: outputYAML},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.Error(t, translate(nil, new(bytes.Buffer), tc.opts))
+		})
+	}
+}
+
+func requireXdsResources(t *testing.T, resources map[string][]interface{}) {
+	t.Helper()
+	require.Len(t, resources["listeners"], 1)
+	require.NotEmpty(t, resources["clusters"])
+	for _, name := range []string{"routes", "secrets"} {
+		_, ok := resources[name]
+		require.True(t, ok, "missing %s", name)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/envoyproxy/gateway/internal/cmd/version"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+)
+
+// newVersionCommand returns the version cobra command.
+func newVersionCommand() *cobra.Command {
+	var remote bool
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Show the versions of egctl and of the Envoy Gateway control plane",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printVersions(cmd.Context(), cmd.OutOrStdout(), remote)
+		},
+	}
+	cmd.Flags().BoolVar(&remote, "remote", true, "If set, also show the version of the Envoy Gateway control plane of the current cluster.")
+
+	return cmd
+}
+
+// printVersions writes the versions of egctl and, if remote is set, of the
+// Envoy Gateway control plane to out.
+func printVersions(ctx context.Context, out io.Writer, remote bool) error {
+	fmt.Fprintf(out, "CLIENT_VERSION: %s\n", version.EnvoyGatewayVersion)
+	fmt.Fprintf(out, "GIT_COMMIT_ID: %s\n", version.GitCommitID)
+	if !remote {
+		return nil
+	}
+
+	clients, err := newKubeClients()
+	if err != nil {
+		return err
+	}
+	serverVersion, err := controlPlaneVersion(ctx, clients)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "SERVER_VERSION: %s\n", serverVersion)
+
+	return nil
+}
+
+// controlPlaneVersion returns the version of the Envoy Gateway control plane,
+// i.e. the image tag of its Deployment.
+func controlPlaneVersion(ctx context.Context, clients *kubeClients) (string, error) {
+	deploy := new(appsv1.Deployment)
+	key := types.NamespacedName{Namespace: config.EnvoyGatewayNamespace, Name: envoyGatewayDeploymentName}
+	if err := clients.client.Get(ctx, key, deploy); err != nil {
+		return "", fmt.Errorf("failed to get deployment %s: %w", key, err)
+	}
+	v := containerImageVersion(&deploy.Spec.Template.Spec, envoyGatewayContainerName)
+	if v == "" {
+		return "", fmt.Errorf("deployment %s has no %s container", key, envoyGatewayContainerName)
+	}
+	return v, nil
+}
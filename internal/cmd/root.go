@@ -22,6 +22,8 @@ func GetRootCommand() *cobra.Command {
 	cmd.AddCommand(getVersionsCommand())
 	cmd.AddCommand(getxDSTestCommand())
 	cmd.AddCommand(getCertGenCommand())
+	cmd.AddCommand(getBootstrapCommand())
+	cmd.AddCommand(getBenchmarkCommand())
 
 	return cmd
 }
@@ -6,14 +6,23 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"net/http"
+
 	"github.com/spf13/cobra"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	gatewayapirunner "github.com/envoyproxy/gateway/internal/gatewayapi/runner"
 	infrarunner "github.com/envoyproxy/gateway/internal/infrastructure/runner"
+	eglog "github.com/envoyproxy/gateway/internal/log"
 	"github.com/envoyproxy/gateway/internal/message"
 	providerrunner "github.com/envoyproxy/gateway/internal/provider/runner"
+	"github.com/envoyproxy/gateway/internal/trafficstats"
+	trafficstatsrunner "github.com/envoyproxy/gateway/internal/trafficstats/runner"
+	"github.com/envoyproxy/gateway/internal/xds/audit"
 	xdsserverrunner "github.com/envoyproxy/gateway/internal/xds/server/runner"
 	xdstranslatorrunner "github.com/envoyproxy/gateway/internal/xds/translator/runner"
 )
@@ -77,6 +86,15 @@ func getConfig() (*config.Server, error) {
 		eg.SetDefaults()
 		cfg.EnvoyGateway = eg
 	}
+
+	// Switch to the structured loggers of the components.
+	loggers, err := eglog.NewLoggers(cfg.EnvoyGateway.Logging)
+	if err != nil {
+		cfg.Logger.Error(err, "failed to create loggers")
+		return nil, err
+	}
+	cfg.Loggers = loggers
+	cfg.Logger = loggers.Logger("")
 	return cfg, nil
 }
 
@@ -87,6 +105,22 @@ func setupRunners(cfg *config.Server) error {
 	// https://github.com/envoyproxy/gateway/issues/43
 	ctx := ctrl.SetupSignalHandler()
 
+	// Serve the debug endpoint of the log levels, if enabled.
+	if logging := cfg.EnvoyGateway.Logging; logging != nil && logging.DebugAddress != nil {
+		go serveDebug(ctx, cfg, *logging.DebugAddress)
+	}
+
+	// The audit trail of the xDS snapshots is recorded by the xDS Server
+	// and served by the Provider, next to the metrics.
+	var auditTrail *audit.Trail
+	if generations := cfg.EnvoyGateway.GetXdsServer().GetAuditGenerations(); generations > 0 {
+		auditTrail = audit.NewTrail(generations)
+	}
+
+	// The reference graph of the translated resources is built by the
+	// Gateway API Translator and served by the Provider, next to the metrics.
+	referenceGraphs := new(gatewayapi.ReferenceGraphs)
+
 	pResources := new(message.ProviderResources)
 	// Start the Provider Service
 	// It fetches the resources from the configured provider type
@@ -94,6 +128,8 @@ func setupRunners(cfg *config.Server) error {
 	providerRunner := providerrunner.New(&providerrunner.Config{
 		Server:            *cfg,
 		ProviderResources: pResources,
+		AuditTrail:        auditTrail,
+		ReferenceGraphs:   referenceGraphs,
 	})
 	if err := providerRunner.Start(ctx); err != nil {
 		return err
@@ -101,6 +137,10 @@ func setupRunners(cfg *config.Server) error {
 
 	xdsIR := new(message.XdsIR)
 	infraIR := new(message.InfraIR)
+	// The xDS Server publishes the rejections of the snapshots of the IR
+	// keys by their proxies, whose updates are then handled first and which
+	// are reported by the status of their Gateways.
+	rejectedSnapshots := new(message.RejectedSnapshots)
 	// Start the GatewayAPI Translator Runner
 	// It subscribes to the provider resources, translates it to xDS IR
 	// and infra IR resources and publishes them.
@@ -109,6 +149,8 @@ func setupRunners(cfg *config.Server) error {
 		ProviderResources: pResources,
 		XdsIR:             xdsIR,
 		InfraIR:           infraIR,
+		ReferenceGraphs:   referenceGraphs,
+		RejectedSnapshots: rejectedSnapshots,
 	})
 	if err := gwRunner.Start(ctx); err != nil {
 		return err
@@ -118,9 +160,10 @@ func setupRunners(cfg *config.Server) error {
 	// Start the Xds Translator Service
 	// It subscribes to the xdsIR, translates it into xds Resources and publishes it.
 	xdsTranslatorRunner := xdstranslatorrunner.New(&xdstranslatorrunner.Config{
-		Server: *cfg,
-		XdsIR:  xdsIR,
-		Xds:    xds,
+		Server:            *cfg,
+		XdsIR:             xdsIR,
+		Xds:               xds,
+		RejectedSnapshots: rejectedSnapshots,
 	})
 	if err := xdsTranslatorRunner.Start(ctx); err != nil {
 		return err
@@ -137,24 +180,48 @@ func setupRunners(cfg *config.Server) error {
 		return err
 	}
 
+	// Start the Traffic Stats Runner, if enabled
+	// It aggregates the stats pushed by the Envoy Proxies to the xDS Server
+	// and publishes the traffic statistics of the Gateways.
+	var aggregator *trafficstats.Aggregator
+	if cfg.EnvoyGateway.TrafficStats != nil {
+		aggregator = trafficstats.NewAggregator()
+		trafficStatsRunner := trafficstatsrunner.New(&trafficstatsrunner.Config{
+			Server:            *cfg,
+			ProviderResources: pResources,
+			Aggregator:        aggregator,
+		})
+		if err := trafficStatsRunner.Start(ctx); err != nil {
+			return err
+		}
+	}
+
 	// Start the xDS Server
 	// It subscribes to the xds Resources and configures the remote Envoy Proxy
 	// via the xDS Protocol
 	xdsServerRunner := xdsserverrunner.New(&xdsserverrunner.Config{
-		Server: *cfg,
-		Xds:    xds,
+		Server:            *cfg,
+		Xds:               xds,
+		AuditTrail:        auditTrail,
+		RejectedSnapshots: rejectedSnapshots,
 	})
+	if aggregator != nil {
+		xdsServerRunner.MetricsService = aggregator
+	}
 	if err := xdsServerRunner.Start(ctx); err != nil {
 		return err
 	}
 
-	// Wait until done
+	// Wait until done, and until the xDS streams are handed off to the other
+	// replicas.
 	<-ctx.Done()
+	<-xdsServerRunner.Stopped()
 	// Close messages
 	pResources.GatewayClasses.Close()
 	pResources.Gateways.Close()
 	pResources.HTTPRoutes.Close()
 	pResources.Services.Close()
+	pResources.EndpointSlices.Close()
 	pResources.Secrets.Close()
 	pResources.ReferenceGrants.Close()
 	pResources.Namespaces.Close()
@@ -162,11 +229,40 @@ func setupRunners(cfg *config.Server) error {
 	pResources.HTTPRouteStatuses.Close()
 	pResources.TLSRoutes.Close()
 	pResources.TLSRouteStatuses.Close()
+	pResources.TCPRoutes.Close()
+	pResources.TCPRouteStatuses.Close()
+	pResources.UDPRoutes.Close()
+	pResources.UDPRouteStatuses.Close()
+	pResources.BackendTrafficPolicies.Close()
+	pResources.ClientTrafficPolicies.Close()
+	pResources.SecurityPolicies.Close()
+	pResources.TrafficShifts.Close()
+	pResources.BackendTrafficPolicyStatuses.Close()
+	pResources.ClientTrafficPolicyStatuses.Close()
+	pResources.SecurityPolicyStatuses.Close()
+	pResources.TrafficShiftStatuses.Close()
+	pResources.GatewayTrafficStats.Close()
 	xdsIR.Close()
 	infraIR.Close()
 	xds.Close()
+	rejectedSnapshots.Close()
 
 	cfg.Logger.Info("shutting down")
 
 	return nil
 }
+
+// serveDebug serves the debug endpoint of the log levels of the components on
+// the provided address until ctx is done.
+func serveDebug(ctx context.Context, cfg *config.Server, addr string) {
+	srv := &http.Server{Addr: addr, Handler: cfg.Loggers.Handler()}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+	}()
+
+	cfg.Logger.Info("serving debug endpoint", "address", addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		cfg.Logger.Error(err, "failed to serve debug endpoint", "address", addr)
+	}
+}
@@ -162,7 +162,7 @@ func xDSTest() error {
 	// Set up the gRPC server and register the xDS handler.
 	g := grpc.NewServer()
 
-	snapCache := cache.NewSnapshotCache(false, logger)
+	snapCache := cache.NewSnapshotCache(false, false, logger)
 	RegisterServer(controlplane_server_v3.NewServer(ctx, snapCache, snapCache), g)
 
 	addr := net.JoinHostPort("0.0.0.0", "8001")
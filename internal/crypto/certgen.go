@@ -15,6 +15,8 @@ import (
 	"encoding/pem"
 	"fmt"
 	"math/big"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/envoyproxy/gateway/api/config/v1alpha1"
@@ -34,6 +36,10 @@ const (
 	// DefaultCertificateLifetime holds the default certificate lifetime (in days).
 	DefaultCertificateLifetime = 365
 
+	// DefaultRotationThreshold is the time before expiry at which control plane
+	// certificates are rotated.
+	DefaultRotationThreshold = 30 * 24 * time.Hour
+
 	// DefaultDNSSuffix is the default DNS suffix name.
 	DefaultDNSSuffix = "cluster.local"
 
@@ -77,6 +83,7 @@ const (
 // the CA Cert along with Envoy Gateway & Envoy certificates.
 type Certificates struct {
 	CACertificate           []byte
+	CAPrivateKey            []byte
 	EnvoyGatewayCertificate []byte
 	EnvoyGatewayPrivateKey  []byte
 	EnvoyCertificate        []byte
@@ -90,11 +97,32 @@ type certificateRequest struct {
 	expiry     time.Time
 	commonName string
 	altNames   []string
+	uris       []*url.URL
 }
 
 // GenerateCerts generates a CA Certificate along with certificates for Envoy Gateway
 // and Envoy returning them as a *Certificates struct or error if encountered.
 func GenerateCerts(egCfg *v1alpha1.EnvoyGateway) (*Certificates, error) {
+	caCertPEM, caKeyPEM, err := GenerateCA()
+	if err != nil {
+		return nil, err
+	}
+
+	return GenerateCertsWithCA(egCfg, caCertPEM, caKeyPEM)
+}
+
+// GenerateCA generates a new CA used to issue the Envoy Gateway and Envoy
+// certificates. The return values are cert, key, err.
+func GenerateCA() ([]byte, []byte, error) {
+	expiry := time.Now().Add(24 * time.Duration(DefaultCertificateLifetime) * time.Hour)
+	return newCA(DefaultEnvoyGatewayDNSPrefix, expiry)
+}
+
+// GenerateCertsWithCA generates certificates for Envoy Gateway and Envoy issued by
+// the first CA of the caBundlePEM bundle, whose private key is caKeyPEM. The other
+// CAs of the bundle stay trusted, so that the certificates they issued are accepted
+// while a new CA is rolled out. The bundle is returned as the CA certificate.
+func GenerateCertsWithCA(egCfg *v1alpha1.EnvoyGateway, caBundlePEM, caKeyPEM []byte) (*Certificates, error) {
 	certCfg := new(Configuration)
 
 	// Check if the EG config is not provided, then default.
@@ -107,10 +135,6 @@ func GenerateCerts(egCfg *v1alpha1.EnvoyGateway) (*Certificates, error) {
 	case ProviderTypeEnvoyGateway:
 		now := time.Now()
 		expiry := now.Add(24 * time.Duration(DefaultCertificateLifetime) * time.Hour)
-		caCertPEM, caKeyPEM, err := newCA(DefaultEnvoyGatewayDNSPrefix, expiry)
-		if err != nil {
-			return nil, err
-		}
 
 		var egDNSNames, envoyDNSNames []string
 		egProvider := egCfg.GetProvider().Type
@@ -124,7 +148,7 @@ func GenerateCerts(egCfg *v1alpha1.EnvoyGateway) (*Certificates, error) {
 		}
 
 		egCertReq := &certificateRequest{
-			caCertPEM:  caCertPEM,
+			caCertPEM:  caBundlePEM,
 			caKeyPEM:   caKeyPEM,
 			expiry:     expiry,
 			commonName: DefaultEnvoyGatewayDNSPrefix,
@@ -137,7 +161,7 @@ func GenerateCerts(egCfg *v1alpha1.EnvoyGateway) (*Certificates, error) {
 		}
 
 		envoyCertReq := &certificateRequest{
-			caCertPEM:  caCertPEM,
+			caCertPEM:  caBundlePEM,
 			caKeyPEM:   caKeyPEM,
 			expiry:     expiry,
 			commonName: DefaultEnvoyDNSPrefix,
@@ -150,7 +174,8 @@ func GenerateCerts(egCfg *v1alpha1.EnvoyGateway) (*Certificates, error) {
 		}
 
 		return &Certificates{
-			CACertificate:           caCertPEM,
+			CACertificate:           caBundlePEM,
+			CAPrivateKey:            caKeyPEM,
 			EnvoyGatewayCertificate: egCert,
 			EnvoyGatewayPrivateKey:  egKey,
 			EnvoyCertificate:        envoyCert,
@@ -162,6 +187,161 @@ func GenerateCerts(egCfg *v1alpha1.EnvoyGateway) (*Certificates, error) {
 	}
 }
 
+// GenerateProxyCert generates a certificate for the Envoy proxy fleet identified
+// by proxyName, signed by the provided CA. The proxy name is encoded in the
+// URI subject alternative name returned by ProxyIdentityURI, which the xDS
+// server uses to authorize the node. The return values are cert, key, err.
+func GenerateProxyCert(caCertPEM, caKeyPEM []byte, proxyName string) ([]byte, []byte, error) {
+	if len(proxyName) == 0 {
+		return nil, nil, fmt.Errorf("proxy name is empty")
+	}
+
+	req := &certificateRequest{
+		caCertPEM:  caCertPEM,
+		caKeyPEM:   caKeyPEM,
+		expiry:     time.Now().Add(24 * time.Duration(DefaultCertificateLifetime) * time.Hour),
+		commonName: proxyName,
+		altNames:   []string{fmt.Sprintf("*.%s", DefaultNamespace)},
+		uris:       []*url.URL{ProxyIdentityURI(proxyName)},
+	}
+
+	return newCert(req)
+}
+
+// GenerateSelfSignedCert generates a self-signed server certificate valid
+// for dnsNames until expiry, for the listeners serving traffic without a
+// certificate of their own. The return values are cert, key, err.
+func GenerateSelfSignedCert(commonName string, dnsNames []string, expiry time.Time) ([]byte, []byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, keySize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot generate key: %v", err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: newSerial(now),
+		Subject: pkix.Name{
+			CommonName: commonName,
+		},
+		NotBefore:             now.UTC().Add(-time.Hour),
+		NotAfter:              expiry.UTC(),
+		SubjectKeyId:          bigIntHash(key.N),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              dnsNames,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: certDER,
+	})
+	keyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+	return certPEM, keyPEM, nil
+}
+
+// proxyIdentityPrefix is the prefix of the URI subject alternative names
+// identifying the proxy fleets in their client certificates.
+const proxyIdentityPrefix = "envoy-gateway:proxy:"
+
+// ProxyIdentityURI returns the URI subject alternative name identifying the
+// proxy fleet proxyName in its client certificate.
+func ProxyIdentityURI(proxyName string) *url.URL {
+	return &url.URL{Scheme: "urn", Opaque: proxyIdentityPrefix + proxyName}
+}
+
+// ProxyIdentity returns the name of the proxy fleet identified by the URI
+// subject alternative name of cert, or an empty string if it has none.
+func ProxyIdentity(cert *x509.Certificate) string {
+	for _, uri := range cert.URIs {
+		if uri.Scheme == "urn" && strings.HasPrefix(uri.Opaque, proxyIdentityPrefix) {
+			return strings.TrimPrefix(uri.Opaque, proxyIdentityPrefix)
+		}
+	}
+	return ""
+}
+
+// ProxyName returns the name of the proxy fleet identified by the PEM encoded
+// certificate in certPEM, or an empty string if it identifies none.
+func ProxyName(certPEM []byte) (string, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return "", fmt.Errorf("failed to decode PEM certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse certificate: %v", err)
+	}
+
+	return ProxyIdentity(cert), nil
+}
+
+// NeedsRotation returns true if the PEM encoded certificate in certPEM expires
+// within threshold of now, or is not yet valid.
+func NeedsRotation(certPEM []byte, now time.Time, threshold time.Duration) (bool, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return false, fmt.Errorf("failed to decode PEM certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse certificate: %v", err)
+	}
+	if now.Before(cert.NotBefore) {
+		return true, nil
+	}
+
+	return !now.Add(threshold).Before(cert.NotAfter), nil
+}
+
+// ParseCertificates returns the certificates of the PEM encoded bundle in
+// certsPEM, in order.
+func ParseCertificates(certsPEM []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, certsPEM = pem.Decode(certsPEM)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %v", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("failed to decode PEM certificate")
+	}
+
+	return certs, nil
+}
+
+// IssuedBy returns true if the first PEM encoded certificate in certPEM is
+// signed by the first CA of the PEM encoded bundle in caBundlePEM.
+func IssuedBy(certPEM, caBundlePEM []byte) bool {
+	certs, err := ParseCertificates(certPEM)
+	if err != nil {
+		return false
+	}
+	cas, err := ParseCertificates(caBundlePEM)
+	if err != nil {
+		return false
+	}
+
+	return certs[0].CheckSignatureFrom(cas[0]) == nil
+}
+
 // newCert generates a new keypair based on the given the request.
 // The return values are cert, key, err.
 func newCert(request *certificateRequest) ([]byte, []byte, error) {
@@ -198,6 +378,7 @@ func newCert(request *certificateRequest) ([]byte, []byte, error) {
 			x509.KeyUsageKeyEncipherment |
 			x509.KeyUsageContentCommitment,
 		DNSNames: request.altNames,
+		URIs:     request.uris,
 	}
 	newCert, err := x509.CreateCertificate(rand.Reader, template, caCert, &newKey.PublicKey, caKey)
 	if err != nil {
@@ -114,6 +114,124 @@ func TestGeneratedValidKubeCerts(t *testing.T) {
 
 }
 
+func TestGenerateProxyCert(t *testing.T) {
+	certs, err := GenerateCerts(nil)
+	require.NoError(t, err)
+
+	cert, key, err := GenerateProxyCert(certs.CACertificate, certs.CAPrivateKey, "default-eg")
+	require.NoError(t, err)
+	require.NotEmpty(t, key)
+
+	roots := x509.NewCertPool()
+	ok := roots.AppendCertsFromPEM(certs.CACertificate)
+	require.Truef(t, ok, "Failed to set up CA cert for testing, maybe it's an invalid PEM")
+
+	err = verifyCert(cert, roots, fmt.Sprintf("envoy.%s", DefaultNamespace), time.Now())
+	assert.NoError(t, err)
+
+	proxyName, err := ProxyName(cert)
+	require.NoError(t, err)
+	assert.Equal(t, "default-eg", proxyName)
+
+	_, _, err = GenerateProxyCert(certs.CACertificate, certs.CAPrivateKey, "")
+	require.Error(t, err)
+}
+
+func TestGenerateCertsWithCA(t *testing.T) {
+	oldCACert, _, err := GenerateCA()
+	require.NoError(t, err)
+	caCert, caKey, err := GenerateCA()
+	require.NoError(t, err)
+	bundle := append(append([]byte{}, caCert...), oldCACert...)
+
+	certs, err := GenerateCertsWithCA(nil, bundle, caKey)
+	require.NoError(t, err)
+	require.Equal(t, bundle, certs.CACertificate)
+
+	cas, err := ParseCertificates(certs.CACertificate)
+	require.NoError(t, err)
+	require.Len(t, cas, 2)
+
+	// The certificates are issued by the first CA of the bundle.
+	assert.True(t, IssuedBy(certs.EnvoyGatewayCertificate, bundle))
+	assert.True(t, IssuedBy(certs.EnvoyCertificate, caCert))
+	assert.False(t, IssuedBy(certs.EnvoyCertificate, oldCACert))
+
+	_, err = ParseCertificates([]byte("invalid"))
+	require.Error(t, err)
+}
+
+func TestGenerateSelfSignedCert(t *testing.T) {
+	expiry := time.Now().Add(24 * time.Hour)
+
+	cert, key, err := GenerateSelfSignedCert("www.example.com", []string{"www.example.com"}, expiry)
+	require.NoError(t, err)
+	require.NotEmpty(t, key)
+
+	roots := x509.NewCertPool()
+	ok := roots.AppendCertsFromPEM(cert)
+	require.Truef(t, ok, "Failed to set up self-signed cert for testing, maybe it's an invalid PEM")
+
+	err = verifyCert(cert, roots, "www.example.com", time.Now())
+	assert.NoError(t, err)
+
+	rotate, err := NeedsRotation(cert, time.Now(), 23*time.Hour)
+	require.NoError(t, err)
+	assert.False(t, rotate)
+}
+
+func TestNeedsRotation(t *testing.T) {
+	now := time.Now()
+	expiry := now.Add(24 * 90 * time.Hour)
+
+	caCert, _, err := newCA("envoy-gateway", expiry)
+	require.NoErrorf(t, err, "Failed to generate CA cert")
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		threshold time.Duration
+		want      bool
+	}{
+		{
+			name:      "valid cert outside threshold",
+			now:       now,
+			threshold: DefaultRotationThreshold,
+			want:      false,
+		},
+		{
+			name:      "valid cert within threshold",
+			now:       now.Add(24 * 70 * time.Hour),
+			threshold: DefaultRotationThreshold,
+			want:      true,
+		},
+		{
+			name:      "expired cert",
+			now:       expiry.Add(time.Hour),
+			threshold: 0,
+			want:      true,
+		},
+		{
+			name:      "cert not yet valid",
+			now:       now.AddDate(0, 0, -2),
+			threshold: DefaultRotationThreshold,
+			want:      true,
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NeedsRotation(caCert, tc.now, tc.threshold)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err = NeedsRotation([]byte("invalid"), now, DefaultRotationThreshold)
+	require.Error(t, err)
+}
+
 func verifyCert(certPEM []byte, roots *x509.CertPool, dnsname string, currentTime time.Time) error {
 	block, _ := pem.Decode(certPEM)
 	if block == nil {
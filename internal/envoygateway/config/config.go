@@ -19,6 +19,12 @@ const (
 	EnvoyGatewayServiceName = "envoy-gateway"
 	// EnvoyPrefix is the prefix applied to the Envoy ConfigMap, Service, Deployment, and ServiceAccount.
 	EnvoyPrefix = "envoy"
+	// RateLimitInfraName is the name of the rate limit service ConfigMap, Service and Deployment.
+	RateLimitInfraName = "envoy-ratelimit"
+	// RateLimitGRPCPort is the port of the rate limit service gRPC server.
+	RateLimitGRPCPort = 8081
+	// RateLimitDomain is the domain of the rate limit descriptors sent by the managed Envoy proxies.
+	RateLimitDomain = "envoy-gateway"
 )
 
 // Server wraps the EnvoyGateway configuration and additional parameters
@@ -28,6 +34,14 @@ type Server struct {
 	EnvoyGateway *v1alpha1.EnvoyGateway
 	// Logger is the logr implementation used by Envoy Gateway.
 	Logger logr.Logger
+	// Loggers are the loggers of the components of Envoy Gateway, each
+	// logging at its own level. If unset, the components use Logger.
+	Loggers *log.Loggers
+	// Elected is closed by the provider once this replica is elected leader,
+	// or once the provider starts if leader election is disabled. Runners
+	// writing to the provider wait for it, so that standby replicas only keep
+	// their caches and xDS snapshots warm.
+	Elected chan struct{}
 }
 
 // NewDefaultServer returns a Server with default parameters.
@@ -39,5 +53,15 @@ func NewDefaultServer() (*Server, error) {
 	return &Server{
 		EnvoyGateway: v1alpha1.DefaultEnvoyGateway(),
 		Logger:       logger,
+		Elected:      make(chan struct{}),
 	}, nil
 }
+
+// ComponentLogger returns the logger of the provided component of Envoy
+// Gateway.
+func (s *Server) ComponentLogger(component v1alpha1.LogComponent) logr.Logger {
+	if s.Loggers == nil {
+		return s.Logger
+	}
+	return s.Loggers.Logger(component)
+}
@@ -8,6 +8,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -119,6 +120,68 @@ func TestDecode(t *testing.T) {
 			},
 			expect: false,
 		},
+		{
+			in: inPath + "xds-server.yaml",
+			out: &v1alpha1.EnvoyGateway{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       v1alpha1.KindEnvoyGateway,
+					APIVersion: v1alpha1.GroupVersion.String(),
+				},
+				EnvoyGatewaySpec: v1alpha1.EnvoyGatewaySpec{
+					XdsServer: &v1alpha1.XdsServer{
+						Address: "10.0.0.1",
+						Port:    18001,
+						TLS: &v1alpha1.XdsServerTLS{
+							CertificatePath:   "/etc/xds/tls.crt",
+							PrivateKeyPath:    "/etc/xds/tls.key",
+							CACertificatePath: "/etc/xds/ca.crt",
+						},
+						BatchWindow: &metav1.Duration{Duration: 250 * time.Millisecond},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			in: inPath + "leader-election.yaml",
+			out: &v1alpha1.EnvoyGateway{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       v1alpha1.KindEnvoyGateway,
+					APIVersion: v1alpha1.GroupVersion.String(),
+				},
+				EnvoyGatewaySpec: v1alpha1.EnvoyGatewaySpec{
+					Provider: &v1alpha1.Provider{
+						Type: v1alpha1.ProviderTypeKubernetes,
+						Kubernetes: &v1alpha1.KubernetesProvider{
+							LeaderElection: &v1alpha1.LeaderElection{
+								LeaseDuration: &metav1.Duration{Duration: 8 * time.Second},
+								RenewDeadline: &metav1.Duration{Duration: 6 * time.Second},
+								RetryPeriod:   &metav1.Duration{Duration: time.Second},
+							},
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			in: inPath + "xds-snapshot-persistence.yaml",
+			out: &v1alpha1.EnvoyGateway{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       v1alpha1.KindEnvoyGateway,
+					APIVersion: v1alpha1.GroupVersion.String(),
+				},
+				EnvoyGatewaySpec: v1alpha1.EnvoyGatewaySpec{
+					XdsServer: &v1alpha1.XdsServer{
+						SnapshotPersistence: &v1alpha1.XdsSnapshotPersistence{
+							Path:         "/var/lib/envoy-gateway/snapshots",
+							WarmupPeriod: &metav1.Duration{Duration: 30 * time.Second},
+						},
+					},
+				},
+			},
+			expect: true,
+		},
 		{
 			in:     inPath + "no-api-version.yaml",
 			expect: false,
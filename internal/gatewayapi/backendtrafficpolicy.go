@@ -0,0 +1,65 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+// RouteReasonPolicyConflict is the reason of the Accepted condition of the
+// routes whose policy can't be combined with the policy of the same kind
+// targeting their Gateway.
+const RouteReasonPolicyConflict v1beta1.RouteConditionReason = "PolicyConflict"
+
+// backendFeaturePolicy returns the route policy if it configures the feature,
+// and the Gateway policy otherwise, so that each feature configured by the
+// policy targeting a route overrides the same feature of the policy targeting
+// its Gateway, and the other features are inherited.
+func backendFeaturePolicy(routePolicy, gatewayPolicy *v1alpha1.BackendTrafficPolicy, configures func(*v1alpha1.BackendTrafficPolicySpec) bool) *v1alpha1.BackendTrafficPolicy {
+	if routePolicy != nil && configures(&routePolicy.Spec) {
+		return routePolicy
+	}
+	if gatewayPolicy != nil && configures(&gatewayPolicy.Spec) {
+		return gatewayPolicy
+	}
+	return nil
+}
+
+// backendTrafficPolicyConflict returns an error if the features of the
+// policies targeting a route and its Gateway can't be combined. A dynamic
+// forward proxy ignores the backendRefs of the route, so it can't be combined
+// with the TLS configuration or the load balancer of the backends defined by
+// the policy of the other level.
+func backendTrafficPolicyConflict(routePolicy, gatewayPolicy *v1alpha1.BackendTrafficPolicy) error {
+	if routePolicy == nil || gatewayPolicy == nil {
+		return nil
+	}
+
+	dynamicForwardProxyPolicy := backendFeaturePolicy(routePolicy, gatewayPolicy, func(spec *v1alpha1.BackendTrafficPolicySpec) bool { return spec.DynamicForwardProxy != nil })
+	if dynamicForwardProxyPolicy == nil {
+		return nil
+	}
+
+	backendFeatures := []struct {
+		name       string
+		configures func(*v1alpha1.BackendTrafficPolicySpec) bool
+	}{
+		{name: "tls", configures: func(spec *v1alpha1.BackendTrafficPolicySpec) bool { return spec.TLS != nil }},
+		{name: "loadBalancer", configures: func(spec *v1alpha1.BackendTrafficPolicySpec) bool { return spec.LoadBalancer != nil }},
+	}
+	for _, feature := range backendFeatures {
+		policy := backendFeaturePolicy(routePolicy, gatewayPolicy, feature.configures)
+		if policy != nil && policy != dynamicForwardProxyPolicy {
+			return fmt.Errorf("the dynamicForwardProxy of BackendTrafficPolicy %s/%s can't be combined with the %s of BackendTrafficPolicy %s/%s",
+				dynamicForwardProxyPolicy.Namespace, dynamicForwardProxyPolicy.Name, feature.name, policy.Namespace, policy.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func TestBackendTrafficPolicyMerge(t *testing.T) {
+	gatewayPolicy := &v1alpha1.BackendTrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "envoy-gateway", Name: "gateway"},
+		Spec: v1alpha1.BackendTrafficPolicySpec{
+			TargetRef: &v1alpha2.PolicyTargetReference{
+				Group: v1beta1.GroupName,
+				Kind:  KindGateway,
+				Name:  "gateway-1",
+			},
+			ConnectTimeout: &metav1.Duration{Duration: time.Second},
+			LoadBalancer:   &v1alpha1.LoadBalancer{Type: v1alpha1.RandomLoadBalancerType},
+		},
+	}
+	routePolicy := func(spec v1alpha1.BackendTrafficPolicySpec) *v1alpha1.BackendTrafficPolicy {
+		spec.TargetRef = &v1alpha2.PolicyTargetReference{
+			Group: v1beta1.GroupName,
+			Kind:  KindHTTPRoute,
+			Name:  "httproute-1",
+		}
+		return &v1alpha1.BackendTrafficPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "route"},
+			Spec:       spec,
+		}
+	}
+
+	testCases := []struct {
+		name                   string
+		routePolicy            *v1alpha1.BackendTrafficPolicy
+		expectedConnectTimeout time.Duration
+		expectedLoadBalancer   *ir.LoadBalancer
+		expectedProxy          bool
+		expectedReason         v1beta1.RouteConditionReason
+	}{
+		{
+			name:                   "route inherits the gateway policy",
+			expectedConnectTimeout: time.Second,
+			expectedLoadBalancer:   &ir.LoadBalancer{Type: ir.RandomLoadBalancer},
+			expectedReason:         v1beta1.RouteReasonAccepted,
+		},
+		{
+			name: "route overrides the connect timeout and inherits the load balancer",
+			routePolicy: routePolicy(v1alpha1.BackendTrafficPolicySpec{
+				ConnectTimeout: &metav1.Duration{Duration: 3 * time.Second},
+			}),
+			expectedConnectTimeout: 3 * time.Second,
+			expectedLoadBalancer:   &ir.LoadBalancer{Type: ir.RandomLoadBalancer},
+			expectedReason:         v1beta1.RouteReasonAccepted,
+		},
+		{
+			name: "dynamic forward proxy conflicts with the load balancer of the gateway",
+			routePolicy: routePolicy(v1alpha1.BackendTrafficPolicySpec{
+				DynamicForwardProxy: &v1alpha1.DynamicForwardProxy{},
+			}),
+			expectedProxy:  true,
+			expectedReason: RouteReasonPolicyConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			resources := attachedRoutesResources(t)
+			resources.BackendTrafficPolicies = []*v1alpha1.BackendTrafficPolicy{gatewayPolicy}
+			if tc.routePolicy != nil {
+				resources.BackendTrafficPolicies = append(resources.BackendTrafficPolicies, tc.routePolicy)
+			}
+
+			translator := &Translator{GatewayClassName: "envoy-gateway-class"}
+			result := translator.Translate(resources)
+
+			listener := result.XdsIR[IRKey("envoy-gateway", "gateway-1")].GetHTTPListener("envoy-gateway-gateway-1-http")
+			require.NotNil(t, listener)
+			var route *ir.HTTPRoute
+			for _, r := range listener.Routes {
+				if r.Name == "default-httproute-1-rule-0-match-0-*" {
+					route = r
+				}
+			}
+			require.NotNil(t, route)
+			if tc.expectedConnectTimeout == 0 {
+				require.Nil(t, route.ConnectTimeout)
+			} else {
+				require.NotNil(t, route.ConnectTimeout)
+				require.Equal(t, tc.expectedConnectTimeout, route.ConnectTimeout.Duration)
+			}
+			require.Equal(t, tc.expectedLoadBalancer, route.LoadBalancer)
+			require.Equal(t, tc.expectedProxy, route.DynamicForwardProxy != nil)
+
+			for _, httpRoute := range result.HTTPRoutes {
+				if httpRoute.Name != "httproute-1" {
+					continue
+				}
+				require.Len(t, httpRoute.Status.Parents, 1)
+				cond := meta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(v1beta1.RouteConditionAccepted))
+				require.NotNil(t, cond)
+				require.Equal(t, string(tc.expectedReason), cond.Reason)
+			}
+		})
+	}
+}
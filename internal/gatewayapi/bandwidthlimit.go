@@ -0,0 +1,61 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// minBandwidthLimitFillInterval and maxBandwidthLimitFillInterval are
+	// the bounds of the fill interval accepted by Envoy.
+	minBandwidthLimitFillInterval = 20 * time.Millisecond
+	maxBandwidthLimitFillInterval = time.Second
+)
+
+// buildBandwidthLimit returns the bandwidth limit of the BackendTrafficPolicy,
+// or nil if the throughput is not limited. An error is returned if the limit
+// or its fill interval is invalid.
+func buildBandwidthLimit(policy *v1alpha1.BackendTrafficPolicy) (*ir.BandwidthLimit, error) {
+	if policy == nil || policy.Spec.BandwidthLimit == nil {
+		return nil, nil
+	}
+
+	limit := policy.Spec.BandwidthLimit
+	if limit.LimitKiBps == 0 {
+		return nil, fmt.Errorf("the limit must be at least 1 KiB per second")
+	}
+	bl := &ir.BandwidthLimit{LimitKiBps: limit.LimitKiBps}
+
+	mode := v1alpha1.RequestAndResponseBandwidthLimitMode
+	if limit.Mode != nil {
+		mode = *limit.Mode
+	}
+	switch mode {
+	case v1alpha1.RequestBandwidthLimitMode:
+		bl.Request = true
+	case v1alpha1.ResponseBandwidthLimitMode:
+		bl.Response = true
+	case v1alpha1.RequestAndResponseBandwidthLimitMode:
+		bl.Request, bl.Response = true, true
+	default:
+		return nil, fmt.Errorf("unsupported mode %s", mode)
+	}
+
+	if limit.FillInterval != nil {
+		d := limit.FillInterval.Duration
+		if d < minBandwidthLimitFillInterval || d > maxBandwidthLimitFillInterval {
+			return nil, fmt.Errorf("the fill interval %s is not between %s and %s",
+				d, minBandwidthLimitFillInterval, maxBandwidthLimitFillInterval)
+		}
+		bl.FillInterval = limit.FillInterval
+	}
+	return bl, nil
+}
@@ -6,12 +6,10 @@
 package gatewayapi
 
 import (
-	"reflect"
-	"time"
-
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
@@ -25,6 +23,29 @@ type GatewayContext struct {
 	*v1beta1.Gateway
 
 	listeners []*ListenerContext
+
+	// tenantRouteNamespaces are the namespaces allowed to attach routes
+	// to the Gateway by its tenants, or nil if unrestricted.
+	tenantRouteNamespaces sets.String
+
+	// conditionTime is the transition time of the conditions set during
+	// the translation, shared by its listeners.
+	conditionTime metav1.Time
+}
+
+// conditionsCapacity is the initial capacity of the condition lists reset
+// by the translation, which usually sets an Accepted or Ready condition and
+// a ResolvedRefs condition, so that they don't grow while being set.
+const conditionsCapacity = 2
+
+// conditionTime returns the provided transition time of the conditions set
+// during a translation, or the current time if unset, e.g. for the contexts
+// created outside of a translation.
+func conditionTime(t metav1.Time) metav1.Time {
+	if t.IsZero() {
+		return metav1.Now()
+	}
+	return t
 }
 
 // GetListenerContext returns the ListenerContext with listenerName.
@@ -66,9 +87,11 @@ func (g *GatewayContext) GetListenerContext(listenerName v1beta1.SectionName) *L
 	}
 
 	ctx := &ListenerContext{
-		Listener:          listener,
-		gateway:           g.Gateway,
-		listenerStatusIdx: listenerStatusIdx,
+		Listener:              listener,
+		gateway:               g.Gateway,
+		listenerStatusIdx:     listenerStatusIdx,
+		tenantRouteNamespaces: g.tenantRouteNamespaces,
+		conditionTime:         g.conditionTime,
 	}
 	g.listeners = append(g.listeners, ctx)
 	return ctx
@@ -84,6 +107,17 @@ type ListenerContext struct {
 	listenerStatusIdx int
 	namespaceSelector labels.Selector
 	tlsSecret         *v1.Secret
+	// selfSignedCertificate is set if tlsSecret holds a self-signed
+	// certificate because the referenced Secret does not exist.
+	selfSignedCertificate bool
+	// attachedRouteKinds holds the number of attached routes per route kind.
+	attachedRouteKinds map[string]int32
+	// tenantRouteNamespaces are the namespaces allowed to attach routes
+	// to the Gateway by its tenants, or nil if unrestricted.
+	tenantRouteNamespaces sets.String
+	// conditionTime is the transition time of the conditions set during
+	// the translation.
+	conditionTime metav1.Time
 }
 
 func (l *ListenerContext) SetCondition(conditionType v1beta1.ListenerConditionType, status metav1.ConditionStatus, reason v1beta1.ListenerConditionReason, message string) {
@@ -93,12 +127,13 @@ func (l *ListenerContext) SetCondition(conditionType v1beta1.ListenerConditionTy
 		Reason:             string(reason),
 		Message:            message,
 		ObservedGeneration: l.gateway.Generation,
-		LastTransitionTime: metav1.NewTime(time.Now()),
+		LastTransitionTime: conditionTime(l.conditionTime),
 	}
 
 	idx := -1
-	for i, existing := range l.gateway.Status.Listeners[l.listenerStatusIdx].Conditions {
-		if existing.Type == cond.Type {
+	conditions := l.gateway.Status.Listeners[l.listenerStatusIdx].Conditions
+	for i := range conditions {
+		if existing := &conditions[i]; existing.Type == cond.Type {
 			// return early if the condition is unchanged
 			if existing.Status == cond.Status &&
 				existing.Reason == cond.Reason &&
@@ -111,14 +146,14 @@ func (l *ListenerContext) SetCondition(conditionType v1beta1.ListenerConditionTy
 	}
 
 	if idx > -1 {
-		l.gateway.Status.Listeners[l.listenerStatusIdx].Conditions[idx] = cond
+		conditions[idx] = cond
 	} else {
-		l.gateway.Status.Listeners[l.listenerStatusIdx].Conditions = append(l.gateway.Status.Listeners[l.listenerStatusIdx].Conditions, cond)
+		l.gateway.Status.Listeners[l.listenerStatusIdx].Conditions = append(conditions, cond)
 	}
 }
 
 func (l *ListenerContext) ResetConditions() {
-	l.gateway.Status.Listeners[l.listenerStatusIdx].Conditions = make([]metav1.Condition, 0)
+	l.gateway.Status.Listeners[l.listenerStatusIdx].Conditions = make([]metav1.Condition, 0, conditionsCapacity)
 }
 
 func (l *ListenerContext) SetSupportedKinds(kinds ...v1beta1.RouteGroupKind) {
@@ -128,10 +163,22 @@ func (l *ListenerContext) SetSupportedKinds(kinds ...v1beta1.RouteGroupKind) {
 func (l *ListenerContext) ResetAttachedRoutes() {
 	// Reset attached route count since it will be recomputed during translation.
 	l.gateway.Status.Listeners[l.listenerStatusIdx].AttachedRoutes = 0
+	l.attachedRouteKinds = nil
 }
 
-func (l *ListenerContext) IncrementAttachedRoutes() {
+// IncrementAttachedRoutes increments the attached route count of the listener,
+// recording the kind of the attached route.
+func (l *ListenerContext) IncrementAttachedRoutes(routeKind string) {
 	l.gateway.Status.Listeners[l.listenerStatusIdx].AttachedRoutes++
+	if l.attachedRouteKinds == nil {
+		l.attachedRouteKinds = make(map[string]int32)
+	}
+	l.attachedRouteKinds[routeKind]++
+}
+
+// AttachedRouteKinds returns the number of attached routes per route kind.
+func (l *ListenerContext) AttachedRouteKinds() map[string]int32 {
+	return l.attachedRouteKinds
 }
 
 func (l *ListenerContext) AllowsKind(kind v1beta1.RouteGroupKind) bool {
@@ -148,7 +195,14 @@ func (l *ListenerContext) AllowsNamespace(namespace *v1.Namespace) bool {
 	if namespace == nil {
 		return false
 	}
-	switch *l.AllowedRoutes.Namespaces.From {
+	// The API server defaults the allowed namespaces, but the
+	// listeners of the Gateways created otherwise may leave them unset.
+	from := v1beta1.NamespacesFromSame
+	if l.AllowedRoutes != nil && l.AllowedRoutes.Namespaces != nil && l.AllowedRoutes.Namespaces.From != nil {
+		from = *l.AllowedRoutes.Namespaces.From
+	}
+
+	switch from {
 	case v1beta1.NamespacesFromAll:
 		return true
 	case v1beta1.NamespacesFromSelector:
@@ -191,12 +245,14 @@ type RouteContext interface {
 
 	// TODO: [v1alpha2-v1beta1] This should not be required once all Route
 	// objects being implemented are of type v1beta1.
-	// GetHostnames returns the hosts targeted by the Route object.
+	// GetHostnames returns the hosts targeted by the Route object. The
+	// returned slice is cached and must not be modified.
 	GetHostnames() []string
 
 	// TODO: [v1alpha2-v1beta1] This should not be required once all Route
 	// objects being implemented are of type v1beta1.
 	// GetParentReferences returns the ParentReference of the Route object.
+	// The returned slice is cached and must not be modified.
 	GetParentReferences() []v1beta1.ParentReference
 
 	// GetRouteParentContext returns RouteParentContext by using the Route
@@ -204,12 +260,28 @@ type RouteContext interface {
 	GetRouteParentContext(forParentRef v1beta1.ParentReference) *RouteParentContext
 }
 
+// routeControllerName returns the provided controller name of the route parent
+// statuses, or the Envoy Gateway one if it is empty.
+func routeControllerName(controllerName string) string {
+	if controllerName == "" {
+		return egv1alpha1.GatewayControllerName
+	}
+	return controllerName
+}
+
 // HTTPRouteContext wraps an HTTPRoute and provides helper methods for
 // accessing the route's parents.
 type HTTPRouteContext struct {
 	*v1beta1.HTTPRoute
 
-	parentRefs map[v1beta1.ParentReference]*RouteParentContext
+	parentRefs map[parentRefKey]*RouteParentContext
+	hostnames  []string
+	// conditionTime is the transition time of the conditions set during
+	// the translation, shared by its parentRefs.
+	conditionTime metav1.Time
+	// controllerName is the name of the controller written in the parent
+	// statuses of the route, defaulting to the Envoy Gateway one.
+	controllerName string
 }
 
 func (h *HTTPRouteContext) GetRouteType() string {
@@ -217,11 +289,13 @@ func (h *HTTPRouteContext) GetRouteType() string {
 }
 
 func (h *HTTPRouteContext) GetHostnames() []string {
-	hostnames := make([]string, len(h.Spec.Hostnames))
-	for idx, s := range h.Spec.Hostnames {
-		hostnames[idx] = string(s)
+	if h.hostnames == nil {
+		h.hostnames = make([]string, len(h.Spec.Hostnames))
+		for idx, s := range h.Spec.Hostnames {
+			h.hostnames[idx] = string(s)
+		}
 	}
-	return hostnames
+	return h.hostnames
 }
 
 func (h *HTTPRouteContext) GetParentReferences() []v1beta1.ParentReference {
@@ -230,16 +304,17 @@ func (h *HTTPRouteContext) GetParentReferences() []v1beta1.ParentReference {
 
 func (h *HTTPRouteContext) GetRouteParentContext(forParentRef v1beta1.ParentReference) *RouteParentContext {
 	if h.parentRefs == nil {
-		h.parentRefs = make(map[v1beta1.ParentReference]*RouteParentContext)
+		h.parentRefs = make(map[parentRefKey]*RouteParentContext)
 	}
 
-	if ctx := h.parentRefs[forParentRef]; ctx != nil {
+	key := newParentRefKey(forParentRef, h.Namespace)
+	if ctx := h.parentRefs[key]; ctx != nil {
 		return ctx
 	}
 
 	var parentRef *v1beta1.ParentReference
-	for i, p := range h.Spec.ParentRefs {
-		if reflect.DeepEqual(p, forParentRef) {
+	for i := range h.Spec.ParentRefs {
+		if newParentRefKey(h.Spec.ParentRefs[i], h.Namespace) == key {
 			parentRef = &h.Spec.ParentRefs[i]
 			break
 		}
@@ -249,16 +324,17 @@ func (h *HTTPRouteContext) GetRouteParentContext(forParentRef v1beta1.ParentRefe
 	}
 
 	routeParentStatusIdx := -1
+	controllerName := v1beta1.GatewayController(routeControllerName(h.controllerName))
 	for i := range h.Status.Parents {
-		if reflect.DeepEqual(h.Status.Parents[i].ParentRef, forParentRef) {
+		if h.Status.Parents[i].ControllerName == controllerName &&
+			newParentRefKey(h.Status.Parents[i].ParentRef, h.Namespace) == key {
 			routeParentStatusIdx = i
 			break
 		}
 	}
 	if routeParentStatusIdx == -1 {
 		rParentStatus := v1beta1.RouteParentStatus{
-			// TODO: get this value from the config
-			ControllerName: v1beta1.GatewayController(egv1alpha1.GatewayControllerName),
+			ControllerName: controllerName,
 			ParentRef:      forParentRef,
 		}
 		h.Status.Parents = append(h.Status.Parents, rParentStatus)
@@ -270,8 +346,9 @@ func (h *HTTPRouteContext) GetRouteParentContext(forParentRef v1beta1.ParentRefe
 
 		httpRoute:            h.HTTPRoute,
 		routeParentStatusIdx: routeParentStatusIdx,
+		conditionTime:        h.conditionTime,
 	}
-	h.parentRefs[forParentRef] = ctx
+	h.parentRefs[key] = ctx
 	return ctx
 }
 
@@ -280,7 +357,17 @@ func (h *HTTPRouteContext) GetRouteParentContext(forParentRef v1beta1.ParentRefe
 type TLSRouteContext struct {
 	*v1alpha2.TLSRoute
 
-	parentRefs map[v1beta1.ParentReference]*RouteParentContext
+	parentRefs map[parentRefKey]*RouteParentContext
+	// parentReferences holds the upgraded parentRefs of the route, so that
+	// they are only converted once.
+	parentReferences []v1beta1.ParentReference
+	hostnames        []string
+	// conditionTime is the transition time of the conditions set during
+	// the translation, shared by its parentRefs.
+	conditionTime metav1.Time
+	// controllerName is the name of the controller written in the parent
+	// statuses of the route, defaulting to the Envoy Gateway one.
+	controllerName string
 }
 
 func (t *TLSRouteContext) GetRouteType() string {
@@ -288,36 +375,37 @@ func (t *TLSRouteContext) GetRouteType() string {
 }
 
 func (t *TLSRouteContext) GetHostnames() []string {
-	hostnames := make([]string, len(t.Spec.Hostnames))
-	for idx, s := range t.Spec.Hostnames {
-		hostnames[idx] = string(s)
+	if t.hostnames == nil {
+		t.hostnames = make([]string, len(t.Spec.Hostnames))
+		for idx, s := range t.Spec.Hostnames {
+			t.hostnames[idx] = string(s)
+		}
 	}
-	return hostnames
+	return t.hostnames
 }
 
 func (t *TLSRouteContext) GetParentReferences() []v1beta1.ParentReference {
-	parentReferences := make([]v1beta1.ParentReference, len(t.Spec.ParentRefs))
-	for idx, p := range t.Spec.ParentRefs {
-		parentReferences[idx] = UpgradeParentReference(p)
+	if t.parentReferences == nil {
+		t.parentReferences = UpgradeParentReferences(t.Spec.ParentRefs)
 	}
-	return parentReferences
+	return t.parentReferences
 }
 
 func (t *TLSRouteContext) GetRouteParentContext(forParentRef v1beta1.ParentReference) *RouteParentContext {
 	if t.parentRefs == nil {
-		t.parentRefs = make(map[v1beta1.ParentReference]*RouteParentContext)
+		t.parentRefs = make(map[parentRefKey]*RouteParentContext)
 	}
 
-	if ctx := t.parentRefs[forParentRef]; ctx != nil {
+	key := newParentRefKey(forParentRef, t.Namespace)
+	if ctx := t.parentRefs[key]; ctx != nil {
 		return ctx
 	}
 
 	var parentRef *v1beta1.ParentReference
-	for i, p := range t.Spec.ParentRefs {
-		p := UpgradeParentReference(p)
-		if reflect.DeepEqual(p, forParentRef) {
-			upgraded := UpgradeParentReference(t.Spec.ParentRefs[i])
-			parentRef = &upgraded
+	parentReferences := t.GetParentReferences()
+	for i := range parentReferences {
+		if newParentRefKey(parentReferences[i], t.Namespace) == key {
+			parentRef = &parentReferences[i]
 			break
 		}
 	}
@@ -326,24 +414,101 @@ func (t *TLSRouteContext) GetRouteParentContext(forParentRef v1beta1.ParentRefer
 	}
 
 	routeParentStatusIdx := -1
+	controllerName := v1alpha2.GatewayController(routeControllerName(t.controllerName))
 	for i := range t.Status.Parents {
-		p := UpgradeParentReference(t.Status.Parents[i].ParentRef)
-		defaultNamespace := v1beta1.Namespace(metav1.NamespaceDefault)
-		if forParentRef.Namespace == nil {
-			forParentRef.Namespace = &defaultNamespace
+		if t.Status.Parents[i].ControllerName == controllerName &&
+			newParentRefKeyV1Alpha2(t.Status.Parents[i].ParentRef, t.Namespace) == key {
+			routeParentStatusIdx = i
+			break
 		}
-		if p.Namespace == nil {
-			p.Namespace = &defaultNamespace
+	}
+	if routeParentStatusIdx == -1 {
+		rParentStatus := v1alpha2.RouteParentStatus{
+			ControllerName: controllerName,
+			ParentRef:      DowngradeParentReference(forParentRef),
+		}
+		t.Status.Parents = append(t.Status.Parents, rParentStatus)
+		routeParentStatusIdx = len(t.Status.Parents) - 1
+	}
+
+	ctx := &RouteParentContext{
+		ParentReference: parentRef,
+
+		tlsRoute:             t.TLSRoute,
+		routeParentStatusIdx: routeParentStatusIdx,
+		conditionTime:        t.conditionTime,
+	}
+	t.parentRefs[key] = ctx
+	return ctx
+}
+
+// TCPRouteContext wraps a TCPRoute and provides helper methods for
+// accessing the route's parents.
+type TCPRouteContext struct {
+	*v1alpha2.TCPRoute
+
+	parentRefs map[parentRefKey]*RouteParentContext
+	// parentReferences holds the upgraded parentRefs of the route, so that
+	// they are only converted once.
+	parentReferences []v1beta1.ParentReference
+	// conditionTime is the transition time of the conditions set during
+	// the translation, shared by its parentRefs.
+	conditionTime metav1.Time
+	// controllerName is the name of the controller written in the parent
+	// statuses of the route, defaulting to the Envoy Gateway one.
+	controllerName string
+}
+
+func (t *TCPRouteContext) GetRouteType() string {
+	return KindTCPRoute
+}
+
+// GetHostnames returns nil since TCPRoutes do not match on hostnames.
+func (t *TCPRouteContext) GetHostnames() []string {
+	return nil
+}
+
+func (t *TCPRouteContext) GetParentReferences() []v1beta1.ParentReference {
+	if t.parentReferences == nil {
+		t.parentReferences = UpgradeParentReferences(t.Spec.ParentRefs)
+	}
+	return t.parentReferences
+}
+
+func (t *TCPRouteContext) GetRouteParentContext(forParentRef v1beta1.ParentReference) *RouteParentContext {
+	if t.parentRefs == nil {
+		t.parentRefs = make(map[parentRefKey]*RouteParentContext)
+	}
+
+	key := newParentRefKey(forParentRef, t.Namespace)
+	if ctx := t.parentRefs[key]; ctx != nil {
+		return ctx
+	}
+
+	var parentRef *v1beta1.ParentReference
+	parentReferences := t.GetParentReferences()
+	for i := range parentReferences {
+		if newParentRefKey(parentReferences[i], t.Namespace) == key {
+			parentRef = &parentReferences[i]
+			break
 		}
-		if reflect.DeepEqual(p, forParentRef) {
+	}
+	if parentRef == nil {
+		panic("parentRef not found")
+	}
+
+	routeParentStatusIdx := -1
+	controllerName := v1alpha2.GatewayController(routeControllerName(t.controllerName))
+	for i := range t.Status.Parents {
+		if t.Status.Parents[i].ControllerName == controllerName &&
+			newParentRefKeyV1Alpha2(t.Status.Parents[i].ParentRef, t.Namespace) == key {
 			routeParentStatusIdx = i
 			break
 		}
 	}
 	if routeParentStatusIdx == -1 {
 		rParentStatus := v1alpha2.RouteParentStatus{
-			// TODO: get this value from the config
-			ControllerName: v1alpha2.GatewayController(egv1alpha1.GatewayControllerName),
+			ControllerName: controllerName,
 			ParentRef:      DowngradeParentReference(forParentRef),
 		}
 		t.Status.Parents = append(t.Status.Parents, rParentStatus)
@@ -353,16 +518,167 @@ func (t *TLSRouteContext) GetRouteParentContext(forParentRef v1beta1.ParentRefer
 	ctx := &RouteParentContext{
 		ParentReference: parentRef,
 
-		tlsRoute:             t.TLSRoute,
+		tcpRoute:             t.TCPRoute,
 		routeParentStatusIdx: routeParentStatusIdx,
+		conditionTime:        t.conditionTime,
 	}
-	t.parentRefs[forParentRef] = ctx
+	t.parentRefs[key] = ctx
 	return ctx
 }
 
+// UDPRouteContext wraps a UDPRoute and provides helper methods for
+// accessing the route's parents.
+type UDPRouteContext struct {
+	*v1alpha2.UDPRoute
+
+	parentRefs map[parentRefKey]*RouteParentContext
+	// parentReferences holds the upgraded parentRefs of the route, so that
+	// they are only converted once.
+	parentReferences []v1beta1.ParentReference
+	// conditionTime is the transition time of the conditions set during
+	// the translation, shared by its parentRefs.
+	conditionTime metav1.Time
+	// controllerName is the name of the controller written in the parent
+	// statuses of the route, defaulting to the Envoy Gateway one.
+	controllerName string
+}
+
+func (t *UDPRouteContext) GetRouteType() string {
+	return KindUDPRoute
+}
+
+// GetHostnames returns nil since UDPRoutes do not match on hostnames.
+func (t *UDPRouteContext) GetHostnames() []string {
+	return nil
+}
+
+func (t *UDPRouteContext) GetParentReferences() []v1beta1.ParentReference {
+	if t.parentReferences == nil {
+		t.parentReferences = UpgradeParentReferences(t.Spec.ParentRefs)
+	}
+	return t.parentReferences
+}
+
+func (t *UDPRouteContext) GetRouteParentContext(forParentRef v1beta1.ParentReference) *RouteParentContext {
+	if t.parentRefs == nil {
+		t.parentRefs = make(map[parentRefKey]*RouteParentContext)
+	}
+
+	key := newParentRefKey(forParentRef, t.Namespace)
+	if ctx := t.parentRefs[key]; ctx != nil {
+		return ctx
+	}
+
+	var parentRef *v1beta1.ParentReference
+	parentReferences := t.GetParentReferences()
+	for i := range parentReferences {
+		if newParentRefKey(parentReferences[i], t.Namespace) == key {
+			parentRef = &parentReferences[i]
+			break
+		}
+	}
+	if parentRef == nil {
+		panic("parentRef not found")
+	}
+
+	routeParentStatusIdx := -1
+	controllerName := v1alpha2.GatewayController(routeControllerName(t.controllerName))
+	for i := range t.Status.Parents {
+		if t.Status.Parents[i].ControllerName == controllerName &&
+			newParentRefKeyV1Alpha2(t.Status.Parents[i].ParentRef, t.Namespace) == key {
+			routeParentStatusIdx = i
+			break
+		}
+	}
+	if routeParentStatusIdx == -1 {
+		rParentStatus := v1alpha2.RouteParentStatus{
+			ControllerName: controllerName,
+			ParentRef:      DowngradeParentReference(forParentRef),
+		}
+		t.Status.Parents = append(t.Status.Parents, rParentStatus)
+		routeParentStatusIdx = len(t.Status.Parents) - 1
+	}
+
+	ctx := &RouteParentContext{
+		ParentReference: parentRef,
+
+		udpRoute:             t.UDPRoute,
+		routeParentStatusIdx: routeParentStatusIdx,
+		conditionTime:        t.conditionTime,
+	}
+	t.parentRefs[key] = ctx
+	return ctx
+}
+
+// parentRefKey is a comparable key of a ParentReference, with the defaults of
+// its optional fields applied, so that references to the same parent match
+// whether or not the defaults are set explicitly.
+type parentRefKey struct {
+	group       string
+	kind        string
+	namespace   string
+	name        string
+	sectionName string
+	port        int32
+}
+
+// newParentRefKey returns the key of a ParentReference of a route in the
+// routeNamespace.
+func newParentRefKey(ref v1beta1.ParentReference, routeNamespace string) parentRefKey {
+	key := parentRefKey{
+		group:     v1beta1.GroupName,
+		kind:      KindGateway,
+		namespace: routeNamespace,
+		name:      string(ref.Name),
+	}
+	if ref.Group != nil {
+		key.group = string(*ref.Group)
+	}
+	if ref.Kind != nil {
+		key.kind = string(*ref.Kind)
+	}
+	if ref.Namespace != nil {
+		key.namespace = string(*ref.Namespace)
+	}
+	if ref.SectionName != nil {
+		key.sectionName = string(*ref.SectionName)
+	}
+	if ref.Port != nil {
+		key.port = int32(*ref.Port)
+	}
+	return key
+}
+
+// newParentRefKeyV1Alpha2 returns the key of a v1alpha2 ParentReference of a
+// route in the routeNamespace, without upgrading the reference.
+func newParentRefKeyV1Alpha2(ref v1alpha2.ParentReference, routeNamespace string) parentRefKey {
+	key := parentRefKey{
+		group:     v1beta1.GroupName,
+		kind:      KindGateway,
+		namespace: routeNamespace,
+		name:      string(ref.Name),
+	}
+	if ref.Group != nil {
+		key.group = string(*ref.Group)
+	}
+	if ref.Kind != nil {
+		key.kind = string(*ref.Kind)
+	}
+	if ref.Namespace != nil {
+		key.namespace = string(*ref.Namespace)
+	}
+	if ref.SectionName != nil {
+		key.sectionName = string(*ref.SectionName)
+	}
+	if ref.Port != nil {
+		key.port = int32(*ref.Port)
+	}
+	return key
+}
+
 // RouteParentContext wraps a ParentReference and provides helper methods for
 // setting conditions and other status information on the associated
-// HTTPRoute, TLSRoute etc.
+// HTTPRoute, TLSRoute, TCPRoute etc.
 type RouteParentContext struct {
 	*v1beta1.ParentReference
 
@@ -370,9 +686,14 @@ type RouteParentContext struct {
 	// a single field pointing to *v1beta1.RouteStatus.
 	httpRoute *v1beta1.HTTPRoute
 	tlsRoute  *v1alpha2.TLSRoute
+	tcpRoute  *v1alpha2.TCPRoute
+	udpRoute  *v1alpha2.UDPRoute
 
 	routeParentStatusIdx int
 	listeners            []*ListenerContext
+	// conditionTime is the transition time of the conditions set during
+	// the translation.
+	conditionTime metav1.Time
 }
 
 func (r *RouteParentContext) SetListeners(listeners ...*ListenerContext) {
@@ -380,76 +701,71 @@ func (r *RouteParentContext) SetListeners(listeners ...*ListenerContext) {
 }
 
 func (r *RouteParentContext) SetCondition(route RouteContext, conditionType v1beta1.RouteConditionType, status metav1.ConditionStatus, reason v1beta1.RouteConditionReason, message string) {
-	cond := metav1.Condition{
+	conditions := r.conditions(route)
+	if conditions == nil {
+		return
+	}
+
+	for i := range *conditions {
+		existing := &(*conditions)[i]
+		if existing.Type != string(conditionType) {
+			continue
+		}
+		// return early if the condition is unchanged
+		if existing.Status == status &&
+			existing.Reason == string(reason) &&
+			existing.Message == message {
+			return
+		}
+		existing.Status = status
+		existing.Reason = string(reason)
+		existing.Message = message
+		existing.ObservedGeneration = route.GetGeneration()
+		existing.LastTransitionTime = conditionTime(r.conditionTime)
+		return
+	}
+
+	*conditions = append(*conditions, metav1.Condition{
 		Type:               string(conditionType),
 		Status:             status,
 		Reason:             string(reason),
 		Message:            message,
 		ObservedGeneration: route.GetGeneration(),
-		LastTransitionTime: metav1.NewTime(time.Now()),
-	}
-
-	idx := -1
-	switch route.GetRouteType() {
-	case KindHTTPRoute:
-		for i, existing := range r.httpRoute.Status.Parents[r.routeParentStatusIdx].Conditions {
-			if existing.Type == cond.Type {
-				// return early if the condition is unchanged
-				if existing.Status == cond.Status &&
-					existing.Reason == cond.Reason &&
-					existing.Message == cond.Message {
-					return
-				}
-				idx = i
-				break
-			}
-		}
+		LastTransitionTime: conditionTime(r.conditionTime),
+	})
+}
 
-		if idx > -1 {
-			r.httpRoute.Status.Parents[r.routeParentStatusIdx].Conditions[idx] = cond
-		} else {
-			r.httpRoute.Status.Parents[r.routeParentStatusIdx].Conditions = append(r.httpRoute.Status.Parents[r.routeParentStatusIdx].Conditions, cond)
-		}
-	case KindTLSRoute:
-		for i, existing := range r.tlsRoute.Status.Parents[r.routeParentStatusIdx].Conditions {
-			if existing.Type == cond.Type {
-				// return early if the condition is unchanged
-				if existing.Status == cond.Status &&
-					existing.Reason == cond.Reason &&
-					existing.Message == cond.Message {
-					return
-				}
-				idx = i
-				break
-			}
-		}
+func (r *RouteParentContext) ResetConditions(route RouteContext) {
+	if conditions := r.conditions(route); conditions != nil {
+		*conditions = make([]metav1.Condition, 0, conditionsCapacity)
+	}
+}
 
-		if idx > -1 {
-			r.tlsRoute.Status.Parents[r.routeParentStatusIdx].Conditions[idx] = cond
-		} else {
-			r.tlsRoute.Status.Parents[r.routeParentStatusIdx].Conditions = append(r.tlsRoute.Status.Parents[r.routeParentStatusIdx].Conditions, cond)
-		}
+func (r *RouteParentContext) GetConditions(route RouteContext) []metav1.Condition {
+	if conditions := r.conditions(route); conditions != nil {
+		return *conditions
 	}
+	return nil
 }
 
-func (r *RouteParentContext) ResetConditions(route RouteContext) {
+// conditions returns the conditions of the status of the parent in the
+// status of the route, or nil for an unknown route type.
+func (r *RouteParentContext) conditions(route RouteContext) *[]metav1.Condition {
 	switch route.GetRouteType() {
 	case KindHTTPRoute:
-		r.httpRoute.Status.Parents[r.routeParentStatusIdx].Conditions = make([]metav1.Condition, 0)
+		return &r.httpRoute.Status.Parents[r.routeParentStatusIdx].Conditions
 	case KindTLSRoute:
-		r.tlsRoute.Status.Parents[r.routeParentStatusIdx].Conditions = make([]metav1.Condition, 0)
+		return &r.tlsRoute.Status.Parents[r.routeParentStatusIdx].Conditions
+	case KindTCPRoute:
+		return &r.tcpRoute.Status.Parents[r.routeParentStatusIdx].Conditions
+	case KindUDPRoute:
+		return &r.udpRoute.Status.Parents[r.routeParentStatusIdx].Conditions
 	}
+	return nil
 }
 
 func (r *RouteParentContext) IsAccepted(route RouteContext) bool {
-	var conditions []metav1.Condition
-	switch route.GetRouteType() {
-	case KindHTTPRoute:
-		conditions = r.httpRoute.Status.Parents[r.routeParentStatusIdx].Conditions
-	case KindTLSRoute:
-		conditions = r.tlsRoute.Status.Parents[r.routeParentStatusIdx].Conditions
-	}
-	for _, cond := range conditions {
+	for _, cond := range r.GetConditions(route) {
 		if cond.Type == string(v1beta1.RouteConditionAccepted) && cond.Status == metav1.ConditionTrue {
 			return true
 		}
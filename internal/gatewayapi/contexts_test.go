@@ -6,11 +6,17 @@
 package gatewayapi
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	egv1alpha1 "github.com/envoyproxy/gateway/api/config/v1alpha1"
 )
 
 func TestContexts(t *testing.T) {
@@ -54,3 +60,199 @@ func TestContexts(t *testing.T) {
 	lctx.ResetConditions()
 	require.Len(t, gateway.Status.Listeners[0].Conditions, 0)
 }
+
+func TestTLSRouteContextCache(t *testing.T) {
+	route := &v1alpha2.TLSRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "tlsroute-1",
+		},
+		Spec: v1alpha2.TLSRouteSpec{
+			CommonRouteSpec: v1alpha2.CommonRouteSpec{
+				ParentRefs: []v1alpha2.ParentReference{
+					{
+						Namespace: NamespacePtrV1Alpha2("envoy-gateway"),
+						Name:      "gateway-1",
+					},
+				},
+			},
+			Hostnames: []v1alpha2.Hostname{"foo.com"},
+		},
+	}
+
+	rctx := &TLSRouteContext{
+		TLSRoute: route,
+	}
+
+	hostnames := rctx.GetHostnames()
+	require.Equal(t, []string{"foo.com"}, hostnames)
+	require.Same(t, &hostnames[0], &rctx.GetHostnames()[0])
+
+	parentRefs := rctx.GetParentReferences()
+	require.Len(t, parentRefs, 1)
+	require.Same(t, &parentRefs[0], &rctx.GetParentReferences()[0])
+
+	pctx := rctx.GetRouteParentContext(parentRefs[0])
+	require.Same(t, &parentRefs[0], pctx.ParentReference)
+	require.Same(t, pctx, rctx.GetRouteParentContext(rctx.GetParentReferences()[0]))
+	require.Len(t, route.Status.Parents, 1)
+}
+
+func TestGetRouteParentContextDefaults(t *testing.T) {
+	route := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "httproute-1",
+		},
+		Spec: v1beta1.HTTPRouteSpec{
+			CommonRouteSpec: v1beta1.CommonRouteSpec{
+				ParentRefs: []v1beta1.ParentReference{
+					{
+						Name: "gateway-1",
+					},
+				},
+			},
+		},
+		Status: v1beta1.HTTPRouteStatus{
+			RouteStatus: v1beta1.RouteStatus{
+				Parents: []v1beta1.RouteParentStatus{
+					{
+						ControllerName: v1beta1.GatewayController(egv1alpha1.GatewayControllerName),
+						ParentRef: v1beta1.ParentReference{
+							Group:     GroupPtr(v1beta1.GroupName),
+							Kind:      KindPtr(KindGateway),
+							Namespace: NamespacePtr("default"),
+							Name:      "gateway-1",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	rctx := &HTTPRouteContext{
+		HTTPRoute: route,
+	}
+
+	// A copy of the parentRef with defaults set explicitly resolves to the
+	// same parent and status.
+	pctx := rctx.GetRouteParentContext(v1beta1.ParentReference{
+		Kind:      KindPtr(KindGateway),
+		Namespace: NamespacePtr("default"),
+		Name:      "gateway-1",
+	})
+	require.Same(t, &route.Spec.ParentRefs[0], pctx.ParentReference)
+	require.Same(t, pctx, rctx.GetRouteParentContext(route.Spec.ParentRefs[0]))
+	require.Len(t, route.Status.Parents, 1)
+
+	require.NotEqual(t,
+		newParentRefKey(v1beta1.ParentReference{Name: "gateway-1"}, "default"),
+		newParentRefKey(v1beta1.ParentReference{Name: "gateway-1", SectionName: SectionNamePtr("http")}, "default"))
+	require.NotEqual(t,
+		newParentRefKey(v1beta1.ParentReference{Name: "gateway-1"}, "default"),
+		newParentRefKey(v1beta1.ParentReference{Name: "gateway-1"}, "other"))
+}
+
+func TestGetRouteParentContextControllerName(t *testing.T) {
+	route := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "httproute-1"},
+		Spec: v1beta1.HTTPRouteSpec{
+			CommonRouteSpec: v1beta1.CommonRouteSpec{
+				ParentRefs: []v1beta1.ParentReference{{Name: "gateway-1"}},
+			},
+		},
+		Status: v1beta1.HTTPRouteStatus{
+			RouteStatus: v1beta1.RouteStatus{
+				Parents: []v1beta1.RouteParentStatus{
+					{
+						ControllerName: v1beta1.GatewayController(egv1alpha1.GatewayControllerName),
+						ParentRef:      v1beta1.ParentReference{Name: "gateway-1"},
+					},
+				},
+			},
+		},
+	}
+	rctx := &HTTPRouteContext{HTTPRoute: route, controllerName: "example.com/gateway-controller"}
+
+	// The parent status of another controller for the same parentRef is kept
+	// as is, and a parent status of the configured controller is added.
+	pctx := rctx.GetRouteParentContext(route.Spec.ParentRefs[0])
+	pctx.SetCondition(rctx, v1beta1.RouteConditionAccepted, metav1.ConditionTrue, v1beta1.RouteReasonAccepted, "Route is accepted")
+
+	require.Len(t, route.Status.Parents, 2)
+	require.Empty(t, route.Status.Parents[0].Conditions)
+	require.Equal(t, v1beta1.GatewayController("example.com/gateway-controller"), route.Status.Parents[1].ControllerName)
+	require.Len(t, route.Status.Parents[1].Conditions, 1)
+}
+
+func TestRouteParentContextConditionTime(t *testing.T) {
+	conditionTime := metav1.NewTime(time.Unix(100, 0))
+	route := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "httproute-1"},
+		Spec: v1beta1.HTTPRouteSpec{
+			CommonRouteSpec: v1beta1.CommonRouteSpec{
+				ParentRefs: []v1beta1.ParentReference{{Name: "gateway-1"}},
+			},
+		},
+	}
+	rctx := &HTTPRouteContext{HTTPRoute: route, conditionTime: conditionTime}
+	pctx := rctx.GetRouteParentContext(route.Spec.ParentRefs[0])
+
+	// All the conditions set during a translation share its transition time,
+	// and the conditions of the existing types are updated in place.
+	pctx.ResetConditions(rctx)
+	pctx.SetCondition(rctx, v1beta1.RouteConditionAccepted, metav1.ConditionTrue, v1beta1.RouteReasonAccepted, "Route is accepted")
+	pctx.SetCondition(rctx, v1beta1.RouteConditionResolvedRefs, metav1.ConditionTrue, v1beta1.RouteReasonResolvedRefs, "Resolved all the Object references for the Route")
+	pctx.SetCondition(rctx, v1beta1.RouteConditionAccepted, metav1.ConditionFalse, RouteReasonDisabled, "Route is disabled")
+
+	conditions := route.Status.Parents[0].Conditions
+	require.Len(t, conditions, 2)
+	require.Equal(t, string(RouteReasonDisabled), conditions[0].Reason)
+	for _, cond := range conditions {
+		require.Equal(t, conditionTime, cond.LastTransitionTime)
+	}
+}
+
+func BenchmarkRouteParentContextSetCondition(b *testing.B) {
+	routes := make([]*HTTPRouteContext, 1000)
+	for i := range routes {
+		route := &v1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("httproute-%d", i)},
+			Spec: v1beta1.HTTPRouteSpec{
+				CommonRouteSpec: v1beta1.CommonRouteSpec{
+					ParentRefs: []v1beta1.ParentReference{{Name: "gateway-1"}},
+				},
+			},
+		}
+		routes[i] = &HTTPRouteContext{HTTPRoute: route, conditionTime: metav1.Now()}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, rctx := range routes {
+			pctx := rctx.GetRouteParentContext(rctx.Spec.ParentRefs[0])
+			pctx.ResetConditions(rctx)
+			pctx.SetCondition(rctx, v1beta1.RouteConditionAccepted, metav1.ConditionTrue, v1beta1.RouteReasonAccepted, "Route is accepted")
+			pctx.SetCondition(rctx, v1beta1.RouteConditionResolvedRefs, metav1.ConditionTrue, v1beta1.RouteReasonResolvedRefs, "Resolved all the Object references for the Route")
+		}
+	}
+}
+
+func TestListenerContextAllowsNamespaceDefault(t *testing.T) {
+	gateway := &v1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "envoy-gateway",
+			Name:      "gateway-1",
+		},
+		Spec: v1beta1.GatewaySpec{
+			Listeners: []v1beta1.Listener{{Name: "http"}},
+		},
+	}
+	lctx := (&GatewayContext{Gateway: gateway}).GetListenerContext("http")
+
+	// The routes of the Gateway namespace only are allowed if the allowed
+	// namespaces of the listener are unset.
+	require.True(t, lctx.AllowsNamespace(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "envoy-gateway"}}))
+	require.False(t, lctx.AllowsNamespace(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}))
+}
@@ -0,0 +1,22 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DrainAnnotation is the annotation of the routes and Services which, if set
+// to "true", drains them: the backends of a drained route, and a drained
+// Service in all the routes, receive no new requests while the requests in
+// flight complete. It lets operators shift the traffic away from a route or a
+// backend without editing the specification of the routes.
+const DrainAnnotation = "gateway.envoyproxy.io/drain"
+
+// isDrained returns whether the route or Service is drained.
+func isDrained(obj client.Object) bool {
+	return obj.GetAnnotations()[DrainAnnotation] == "true"
+}
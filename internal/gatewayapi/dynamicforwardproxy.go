@@ -0,0 +1,42 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// buildDynamicForwardProxy returns the dynamic forward proxy of the
+// BackendTrafficPolicy, resolving the hosts with the DNS cache of the
+// EnvoyProxy, or nil if the requests are forwarded to the backends. An error
+// is returned if the host header is invalid.
+func buildDynamicForwardProxy(policy *v1alpha1.BackendTrafficPolicy, envoyProxy *v1alpha1.EnvoyProxy) (*ir.DynamicForwardProxy, error) {
+	if policy == nil || policy.Spec.DynamicForwardProxy == nil {
+		return nil, nil
+	}
+
+	dfp := &ir.DynamicForwardProxy{}
+	if header := policy.Spec.DynamicForwardProxy.HostHeader; header != nil {
+		if !isValidHeaderName(*header) {
+			return nil, fmt.Errorf("the host header %q is not a valid header name", *header)
+		}
+		dfp.HostHeader = *header
+	}
+
+	if envoyProxy == nil || envoyProxy.Spec.DNSCache == nil {
+		return dfp, nil
+	}
+	dnsCache := envoyProxy.Spec.DNSCache
+	dfp.DNSRefreshRate = dnsCache.RefreshRate
+	dfp.DNSHostTTL = dnsCache.HostTTL
+	if dnsCache.MaxHosts != nil {
+		dfp.DNSMaxHosts = *dnsCache.MaxHosts
+	}
+	return dfp, nil
+}
@@ -0,0 +1,80 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// RouteConditionEmptyBackendRefs is the type of the condition of the
+	// parents of the HTTPRoutes with rules without backendRefs, describing
+	// how these rules are programmed.
+	RouteConditionEmptyBackendRefs v1beta1.RouteConditionType = "EmptyBackendRefs"
+	// RouteReasonDirectResponse is the reason of the EmptyBackendRefs
+	// condition when the requests matching the rules receive a direct
+	// response.
+	RouteReasonDirectResponse v1beta1.RouteConditionReason = "DirectResponse"
+	// RouteReasonSkipped is the reason of the EmptyBackendRefs condition when
+	// the rules are not programmed.
+	RouteReasonSkipped v1beta1.RouteConditionReason = "Skipped"
+
+	// defaultEmptyBackendRefsStatusCode is the status code of the direct
+	// responses to the requests matching the rules without backendRefs,
+	// unless configured otherwise.
+	defaultEmptyBackendRefsStatusCode = 500
+)
+
+// emptyBackendRefsDirectResponse returns the direct response to the requests
+// matching the rules without backendRefs, or nil if the rules are skipped.
+func emptyBackendRefsDirectResponse(config *v1alpha1.EmptyBackendRefs) *ir.DirectResponse {
+	if config == nil {
+		return &ir.DirectResponse{StatusCode: defaultEmptyBackendRefsStatusCode}
+	}
+	if config.Action == v1alpha1.EmptyBackendRefsActionSkip {
+		return nil
+	}
+	statusCode := uint32(defaultEmptyBackendRefsStatusCode)
+	if config.StatusCode != nil {
+		statusCode = uint32(*config.StatusCode)
+	}
+	return &ir.DirectResponse{StatusCode: statusCode}
+}
+
+// setEmptyBackendRefsCondition sets the EmptyBackendRefs condition of the
+// parentRef, describing how the rules of the route at the provided indices,
+// which have no backendRefs, are programmed.
+func setEmptyBackendRefsCondition(parentRef *RouteParentContext, httpRoute *HTTPRouteContext, ruleIndices []int, directResponse *ir.DirectResponse) {
+	indices := make([]string, 0, len(ruleIndices))
+	for _, idx := range ruleIndices {
+		indices = append(indices, strconv.Itoa(idx))
+	}
+	rules := strings.Join(indices, ", ")
+
+	if directResponse == nil {
+		parentRef.SetCondition(httpRoute,
+			RouteConditionEmptyBackendRefs,
+			metav1.ConditionTrue,
+			RouteReasonSkipped,
+			fmt.Sprintf("The rules without backendRefs (%s) are not programmed.", rules),
+		)
+		return
+	}
+	parentRef.SetCondition(httpRoute,
+		RouteConditionEmptyBackendRefs,
+		metav1.ConditionTrue,
+		RouteReasonDirectResponse,
+		fmt.Sprintf("The requests matching the rules without backendRefs (%s) receive a %d response.", rules, directResponse.StatusCode),
+	)
+}
@@ -0,0 +1,95 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func TestEmptyBackendRefs(t *testing.T) {
+	statusCode := int32(503)
+	testCases := []struct {
+		name                   string
+		config                 *v1alpha1.EmptyBackendRefs
+		expectedDirectResponse *ir.DirectResponse
+		expectedReason         v1beta1.RouteConditionReason
+		expectedMessage        string
+	}{
+		{
+			name:                   "default direct response",
+			expectedDirectResponse: &ir.DirectResponse{StatusCode: 500},
+			expectedReason:         RouteReasonDirectResponse,
+			expectedMessage:        "The requests matching the rules without backendRefs (0) receive a 500 response.",
+		},
+		{
+			name: "configured status code",
+			config: &v1alpha1.EmptyBackendRefs{
+				Action:     v1alpha1.EmptyBackendRefsActionDirectResponse,
+				StatusCode: &statusCode,
+			},
+			expectedDirectResponse: &ir.DirectResponse{StatusCode: 503},
+			expectedReason:         RouteReasonDirectResponse,
+			expectedMessage:        "The requests matching the rules without backendRefs (0) receive a 503 response.",
+		},
+		{
+			name:            "skipped rules",
+			config:          &v1alpha1.EmptyBackendRefs{Action: v1alpha1.EmptyBackendRefsActionSkip},
+			expectedReason:  RouteReasonSkipped,
+			expectedMessage: "The rules without backendRefs (0) are not programmed.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resources := attachedRoutesResources(t)
+			resources.HTTPRoutes[0].Spec.Rules[0].BackendRefs = nil
+
+			translator := &Translator{
+				GatewayClassName: "envoy-gateway-class",
+				EmptyBackendRefs: tc.config,
+			}
+			result := translator.Translate(resources)
+
+			listener := result.XdsIR[IRKey("envoy-gateway", "gateway-1")].GetHTTPListener("envoy-gateway-gateway-1-http")
+			require.NotNil(t, listener)
+			var route *ir.HTTPRoute
+			for _, r := range listener.Routes {
+				if r.Name == "default-httproute-1-rule-0-match-0-*" {
+					route = r
+				}
+			}
+			if tc.expectedDirectResponse == nil {
+				require.Nil(t, route)
+			} else {
+				require.NotNil(t, route)
+				require.Equal(t, tc.expectedDirectResponse, route.DirectResponse)
+				require.Empty(t, route.Destinations)
+			}
+
+			parents := result.HTTPRoutes[0].Status.Parents
+			require.Len(t, parents, 1)
+			accepted := meta.FindStatusCondition(parents[0].Conditions, string(v1beta1.RouteConditionAccepted))
+			require.NotNil(t, accepted)
+			require.Equal(t, metav1.ConditionTrue, accepted.Status)
+			cond := meta.FindStatusCondition(parents[0].Conditions, string(RouteConditionEmptyBackendRefs))
+			require.NotNil(t, cond)
+			require.Equal(t, metav1.ConditionTrue, cond.Status)
+			require.Equal(t, string(tc.expectedReason), cond.Reason)
+			require.Equal(t, tc.expectedMessage, cond.Message)
+
+			// The routes whose rules all have backendRefs have no EmptyBackendRefs condition.
+			require.Nil(t, meta.FindStatusCondition(result.HTTPRoutes[1].Status.Parents[0].Conditions, string(RouteConditionEmptyBackendRefs)))
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+const (
+	// RouteEnabledAnnotation is the annotation of the routes which, if set to
+	// "false", disables them, e.g. to switch the traffic from a route to
+	// another one without deleting it.
+	RouteEnabledAnnotation = "gateway.envoyproxy.io/enabled"
+	// RouteEnabledFromAnnotation is the annotation of the routes holding the
+	// RFC 3339 time from which they are enabled.
+	RouteEnabledFromAnnotation = "gateway.envoyproxy.io/enabled-from"
+	// RouteEnabledUntilAnnotation is the annotation of the routes holding the
+	// RFC 3339 time from which they are disabled.
+	RouteEnabledUntilAnnotation = "gateway.envoyproxy.io/enabled-until"
+)
+
+// RouteReasonDisabled is the reason of the Accepted condition of the
+// parentRefs of the disabled routes.
+const RouteReasonDisabled v1beta1.RouteConditionReason = "Disabled"
+
+// IsScheduledRoute returns whether the route is only enabled within a time
+// window, so that it must be translated again once the window opens or closes.
+func IsScheduledRoute(route client.Object) bool {
+	annotations := route.GetAnnotations()
+	_, from := annotations[RouteEnabledFromAnnotation]
+	_, until := annotations[RouteEnabledUntilAnnotation]
+	return from || until
+}
+
+// routeDisabledError returns an error describing why the route is disabled at
+// the provided time, or nil if it is enabled. Routes with an invalid time
+// window are disabled.
+func routeDisabledError(route client.Object, now time.Time) error {
+	annotations := route.GetAnnotations()
+	if annotations[RouteEnabledAnnotation] == "false" {
+		return fmt.Errorf("the route is disabled by the %s annotation", RouteEnabledAnnotation)
+	}
+
+	from, err := parseRouteEnablementTime(annotations, RouteEnabledFromAnnotation)
+	if err != nil {
+		return err
+	}
+	until, err := parseRouteEnablementTime(annotations, RouteEnabledUntilAnnotation)
+	if err != nil {
+		return err
+	}
+
+	if from != nil && now.Before(*from) {
+		return fmt.Errorf("the route is disabled until %s", from.Format(time.RFC3339))
+	}
+	if until != nil && !now.Before(*until) {
+		return fmt.Errorf("the route is disabled since %s", until.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// parseRouteEnablementTime parses the time of the provided annotation, or
+// returns nil if the annotation is not set.
+func parseRouteEnablementTime(annotations map[string]string, annotation string) (*time.Time, error) {
+	value, ok := annotations[annotation]
+	if !ok {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("the route is disabled, the %s annotation is not a valid RFC 3339 time: %v", annotation, err)
+	}
+	return &t, nil
+}
@@ -0,0 +1,90 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestRouteDisabledError(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		scheduled   bool
+		err         string
+	}{
+		{
+			name: "enabled by default",
+		},
+		{
+			name:        "enabled",
+			annotations: map[string]string{RouteEnabledAnnotation: "true"},
+		},
+		{
+			name:        "disabled",
+			annotations: map[string]string{RouteEnabledAnnotation: "false"},
+			err:         "the route is disabled by the gateway.envoyproxy.io/enabled annotation",
+		},
+		{
+			name: "within the time window",
+			annotations: map[string]string{
+				RouteEnabledFromAnnotation:  "2022-12-31T00:00:00Z",
+				RouteEnabledUntilAnnotation: "2023-01-02T00:00:00Z",
+			},
+			scheduled: true,
+		},
+		{
+			name:        "before the time window",
+			annotations: map[string]string{RouteEnabledFromAnnotation: "2023-01-01T00:00:01Z"},
+			scheduled:   true,
+			err:         "the route is disabled until 2023-01-01T00:00:01Z",
+		},
+		{
+			name:        "after the time window",
+			annotations: map[string]string{RouteEnabledUntilAnnotation: "2023-01-01T00:00:00Z"},
+			scheduled:   true,
+			err:         "the route is disabled since 2023-01-01T00:00:00Z",
+		},
+		{
+			name: "disabled within the time window",
+			annotations: map[string]string{
+				RouteEnabledAnnotation:     "false",
+				RouteEnabledFromAnnotation: "2022-12-31T00:00:00Z",
+			},
+			scheduled: true,
+			err:       "the route is disabled by the gateway.envoyproxy.io/enabled annotation",
+		},
+		{
+			name:        "invalid time",
+			annotations: map[string]string{RouteEnabledFromAnnotation: "tomorrow"},
+			scheduled:   true,
+			err:         "the route is disabled, the gateway.envoyproxy.io/enabled-from annotation is not a valid RFC 3339 time",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			route := &v1beta1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "httproute-1", Annotations: tc.annotations},
+			}
+			require.Equal(t, tc.scheduled, IsScheduledRoute(route))
+
+			err := routeDisabledError(route, now)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
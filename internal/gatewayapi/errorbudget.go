@@ -0,0 +1,203 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+const (
+	// GatewayClassConditionConfigurationValid is the condition of the
+	// GatewayClass summarizing the objects rejected by the translation of
+	// its Gateways, routes and policies.
+	GatewayClassConditionConfigurationValid v1beta1.GatewayClassConditionType = "ConfigurationValid"
+	// GatewayClassReasonAllObjectsAccepted is the reason of the
+	// ConfigurationValid condition when no object is rejected.
+	GatewayClassReasonAllObjectsAccepted v1beta1.GatewayClassConditionReason = "AllObjectsAccepted"
+	// GatewayClassReasonObjectsRejected is the reason of the
+	// ConfigurationValid condition when some objects are rejected.
+	GatewayClassReasonObjectsRejected v1beta1.GatewayClassConditionReason = "ObjectsRejected"
+)
+
+// TranslatedKinds are the kinds of the objects whose status is computed by
+// the translation.
+var TranslatedKinds = []string{
+	KindGateway,
+	KindHTTPRoute,
+	KindTLSRoute,
+	KindTCPRoute,
+	KindUDPRoute,
+	v1alpha1.KindBackendTrafficPolicy,
+	v1alpha1.KindClientTrafficPolicy,
+	v1alpha1.KindSecurityPolicy,
+	v1alpha1.KindTrafficShift,
+}
+
+// ObjectCounts holds the number of objects of each kind of a translation.
+type ObjectCounts map[string]int
+
+// Total returns the number of objects of all the kinds.
+func (c ObjectCounts) Total() int {
+	var total int
+	for _, count := range c {
+		total += count
+	}
+	return total
+}
+
+// TranslatedObjects returns the number of objects of each kind whose status is
+// computed by the translation.
+func (t *TranslateResult) TranslatedObjects() ObjectCounts {
+	return ObjectCounts{
+		KindGateway:                       len(t.Gateways),
+		KindHTTPRoute:                     len(t.HTTPRoutes),
+		KindTLSRoute:                      len(t.TLSRoutes),
+		KindTCPRoute:                      len(t.TCPRoutes),
+		KindUDPRoute:                      len(t.UDPRoutes),
+		v1alpha1.KindBackendTrafficPolicy: len(t.BackendTrafficPolicies),
+		v1alpha1.KindClientTrafficPolicy:  len(t.ClientTrafficPolicies),
+		v1alpha1.KindSecurityPolicy:       len(t.SecurityPolicies),
+		v1alpha1.KindTrafficShift:         len(t.TrafficShifts),
+	}
+}
+
+// RejectedObjects returns the number of objects of each kind rejected by the
+// translation, i.e. with an Accepted, ResolvedRefs or Ready condition set to
+// False for any of their listeners, parents or ancestors. Only the route parent
+// statuses of the provided controller, defaulting to the Envoy Gateway one, are
+// considered.
+func (t *TranslateResult) RejectedObjects(controllerName string) ObjectCounts {
+	controllerName = routeControllerName(controllerName)
+	rejected := make(ObjectCounts, len(TranslatedKinds))
+	for _, gateway := range t.Gateways {
+		// The conditions of the Gateway itself are computed from its data
+		// plane, only the conditions of its listeners are translated.
+		conditions := make([][]metav1.Condition, 0, len(gateway.Status.Listeners))
+		for _, listener := range gateway.Status.Listeners {
+			conditions = append(conditions, listener.Conditions)
+		}
+		countRejected(rejected, KindGateway, conditions...)
+	}
+	for _, route := range t.HTTPRoutes {
+		countRejected(rejected, KindHTTPRoute, parentConditions(route.Status.Parents, controllerName)...)
+	}
+	for _, route := range t.TLSRoutes {
+		countRejected(rejected, KindTLSRoute, parentConditionsV1Alpha2(route.Status.Parents, controllerName)...)
+	}
+	for _, route := range t.TCPRoutes {
+		countRejected(rejected, KindTCPRoute, parentConditionsV1Alpha2(route.Status.Parents, controllerName)...)
+	}
+	for _, route := range t.UDPRoutes {
+		countRejected(rejected, KindUDPRoute, parentConditionsV1Alpha2(route.Status.Parents, controllerName)...)
+	}
+	for _, policy := range t.BackendTrafficPolicies {
+		countRejected(rejected, v1alpha1.KindBackendTrafficPolicy, ancestorConditions(policy.Status.Ancestors)...)
+	}
+	for _, policy := range t.ClientTrafficPolicies {
+		countRejected(rejected, v1alpha1.KindClientTrafficPolicy, ancestorConditions(policy.Status.Ancestors)...)
+	}
+	for _, policy := range t.SecurityPolicies {
+		countRejected(rejected, v1alpha1.KindSecurityPolicy, ancestorConditions(policy.Status.Ancestors)...)
+	}
+	for _, shift := range t.TrafficShifts {
+		countRejected(rejected, v1alpha1.KindTrafficShift, ancestorConditions(shift.Status.Ancestors)...)
+	}
+	return rejected
+}
+
+// countRejected increments the number of rejected objects of the provided kind
+// if any of the provided conditions rejects the object.
+func countRejected(rejected ObjectCounts, kind string, conditions ...[]metav1.Condition) {
+	for _, conds := range conditions {
+		for _, cond := range conds {
+			switch cond.Type {
+			case string(v1beta1.RouteConditionAccepted), string(v1beta1.RouteConditionResolvedRefs),
+				string(v1beta1.ListenerConditionReady):
+				if cond.Status == metav1.ConditionFalse {
+					rejected[kind]++
+					return
+				}
+			}
+		}
+	}
+}
+
+// parentConditions returns the conditions of the provided route parents
+// written by the provided controller, ignoring the parents of other
+// controllers.
+func parentConditions(parents []v1beta1.RouteParentStatus, controllerName string) [][]metav1.Condition {
+	conditions := make([][]metav1.Condition, 0, len(parents))
+	for _, parent := range parents {
+		if string(parent.ControllerName) == controllerName {
+			conditions = append(conditions, parent.Conditions)
+		}
+	}
+	return conditions
+}
+
+// parentConditionsV1Alpha2 is the v1alpha2 version of parentConditions.
+func parentConditionsV1Alpha2(parents []v1alpha2.RouteParentStatus, controllerName string) [][]metav1.Condition {
+	conditions := make([][]metav1.Condition, 0, len(parents))
+	for _, parent := range parents {
+		if string(parent.ControllerName) == controllerName {
+			conditions = append(conditions, parent.Conditions)
+		}
+	}
+	return conditions
+}
+
+// ancestorConditions returns the conditions of the provided policy ancestors.
+func ancestorConditions(ancestors []v1alpha1.PolicyAncestorStatus) [][]metav1.Condition {
+	conditions := make([][]metav1.Condition, 0, len(ancestors))
+	for _, ancestor := range ancestors {
+		conditions = append(conditions, ancestor.Conditions)
+	}
+	return conditions
+}
+
+// ComputeGatewayClassConfigurationCondition returns the ConfigurationValid
+// condition of the provided GatewayClass, summarizing the objects rejected by
+// the translation of its Gateways, routes and policies.
+func ComputeGatewayClassConfigurationCondition(gatewayClass *v1beta1.GatewayClass, translated, rejected ObjectCounts) metav1.Condition {
+	cond := metav1.Condition{
+		Type:               string(GatewayClassConditionConfigurationValid),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(GatewayClassReasonAllObjectsAccepted),
+		Message:            fmt.Sprintf("All the %d objects are accepted.", translated.Total()),
+		ObservedGeneration: gatewayClass.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	}
+	if rejected.Total() == 0 {
+		return cond
+	}
+
+	kinds := make([]string, 0, len(rejected))
+	for kind, count := range rejected {
+		if count > 0 {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+	details := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		details = append(details, fmt.Sprintf("%d %s", rejected[kind], kind))
+	}
+
+	cond.Status = metav1.ConditionFalse
+	cond.Reason = string(GatewayClassReasonObjectsRejected)
+	cond.Message = fmt.Sprintf("%d of the %d objects are rejected: %s.",
+		rejected.Total(), translated.Total(), strings.Join(details, ", "))
+	return cond
+}
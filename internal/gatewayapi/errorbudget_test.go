@@ -0,0 +1,65 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+func TestGatewayClassConfigurationCondition(t *testing.T) {
+	testCases := []struct {
+		name     string
+		limits   *v1alpha1.TranslationLimits
+		rejected int
+		status   metav1.ConditionStatus
+		reason   v1beta1.GatewayClassConditionReason
+		message  string
+	}{
+		{
+			name:    "all objects accepted",
+			status:  metav1.ConditionTrue,
+			reason:  GatewayClassReasonAllObjectsAccepted,
+			message: "All the 3 objects are accepted.",
+		},
+		{
+			name:     "route rejected",
+			limits:   &v1alpha1.TranslationLimits{MaxRoutesPerGateway: pointer.Int32(1)},
+			rejected: 1,
+			status:   metav1.ConditionFalse,
+			reason:   GatewayClassReasonObjectsRejected,
+			message:  "1 of the 3 objects are rejected: 1 HTTPRoute.",
+		},
+	}
+
+	gatewayClass := &v1beta1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "envoy-gateway-class", Generation: 2}}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			translator := &Translator{GatewayClassName: "envoy-gateway-class", Limits: tc.limits}
+			result := translator.Translate(attachedRoutesResources(t))
+
+			translated, rejected := result.TranslatedObjects(), result.RejectedObjects("")
+			require.Equal(t, 1, translated[KindGateway])
+			require.Equal(t, 2, translated[KindHTTPRoute])
+			require.Equal(t, tc.rejected, rejected[KindHTTPRoute])
+			require.Equal(t, tc.rejected, rejected.Total())
+
+			cond := ComputeGatewayClassConfigurationCondition(gatewayClass, translated, rejected)
+			require.Equal(t, string(GatewayClassConditionConfigurationValid), cond.Type)
+			require.Equal(t, tc.status, cond.Status)
+			require.Equal(t, string(tc.reason), cond.Reason)
+			require.Equal(t, tc.message, cond.Message)
+			require.Equal(t, int64(2), cond.ObservedGeneration)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// buildExtProc resolves the external processing service of the provided
+// BackendTrafficPolicy. It returns nil if the policy does not configure
+// external processing, and an error if the Service of the processing service
+// cannot be resolved.
+func buildExtProc(policy *v1alpha1.BackendTrafficPolicy, resources *Resources) (*ir.ExtProc, error) {
+	if policy == nil || policy.Spec.ExtProc == nil {
+		return nil, nil
+	}
+	extProc := policy.Spec.ExtProc
+
+	destination, err := resolveExternalServiceDestination("processing service", extProc.BackendRef, policy.Namespace, resources)
+	if err != nil {
+		return nil, err
+	}
+	return &ir.ExtProc{
+		Destination: destination,
+		FailOpen:    extProc.FailureMode == v1alpha1.FailOpen,
+	}, nil
+}
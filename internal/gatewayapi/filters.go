@@ -0,0 +1,218 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// httpFilterKey identifies the translator of an HTTPRoute filter.
+type httpFilterKey struct {
+	Type v1beta1.HTTPRouteFilterType
+	// Group and Kind are the group and kind of the object referenced by an
+	// ExtensionRef filter, and are empty for the other filter types.
+	Group v1beta1.Group
+	Kind  v1beta1.Kind
+}
+
+// httpFilterKeyFor returns the key of the translator of the provided filter.
+func httpFilterKeyFor(filter *v1beta1.HTTPRouteFilter) httpFilterKey {
+	key := httpFilterKey{Type: filter.Type}
+	if filter.Type == v1beta1.HTTPRouteFilterExtensionRef && filter.ExtensionRef != nil {
+		key.Group = filter.ExtensionRef.Group
+		key.Kind = filter.ExtensionRef.Kind
+	}
+	return key
+}
+
+// httpFiltersContext holds the state shared by the filters of an HTTPRoute
+// rule, and the IR they translate into.
+type httpFiltersContext struct {
+	parentRef    *RouteParentContext
+	httpRoute    *HTTPRouteContext
+	resources    *Resources
+	shadowHeader string
+
+	directResponse       *ir.DirectResponse
+	redirectResponse     *ir.Redirect
+	addRequestHeaders    []ir.AddHeader
+	removeRequestHeaders []string
+	mirrors              []*ir.Mirror
+}
+
+// httpFilterTranslator translates an HTTPRoute filter into the IR of the
+// provided context, and sets error statuses on its parentRef if the filter is
+// invalid.
+type httpFilterTranslator func(filter *v1beta1.HTTPRouteFilter, ctx *httpFiltersContext)
+
+// httpFilterTranslators are the translators of the supported HTTPRoute
+// filters. The built-in filters are keyed by their type only, the extension
+// filters by the group and kind of the object they reference.
+var httpFilterTranslators = map[httpFilterKey]httpFilterTranslator{
+	{Type: v1beta1.HTTPRouteFilterRequestRedirect}:       translateRequestRedirectFilter,
+	{Type: v1beta1.HTTPRouteFilterRequestHeaderModifier}: translateRequestHeaderModifierFilter,
+	{Type: v1beta1.HTTPRouteFilterRequestMirror}:         translateRequestMirrorFilter,
+}
+
+// processHTTPFilters translates the filters of an HTTPRoute rule with their
+// registered translators. The requests processed by a filter without
+// translator receive an error response, as required by the Gateway API.
+func processHTTPFilters(filters []v1beta1.HTTPRouteFilter, ctx *httpFiltersContext) {
+	for i := range filters {
+		if ctx.directResponse != nil {
+			break // If an invalid filter type has been configured then skip processing any more filters
+		}
+		filter := &filters[i]
+		if translate, ok := httpFilterTranslators[httpFilterKeyFor(filter)]; ok {
+			translate(filter, ctx)
+			continue
+		}
+
+		var errMsg string
+		if filter.Type == v1beta1.HTTPRouteFilterExtensionRef {
+			// "If a reference to a custom filter type cannot be resolved, the filter MUST NOT be skipped.
+			// Instead, requests that would have been processed by that filter MUST receive a HTTP error response."
+			errMsg = fmt.Sprintf("Unknown custom filter type: %s", filter.Type)
+		} else {
+			// Unsupported filters.
+			errMsg = fmt.Sprintf("Unsupported filter type: %s", filter.Type)
+		}
+		ctx.parentRef.SetCondition(ctx.httpRoute,
+			v1beta1.RouteConditionAccepted,
+			metav1.ConditionFalse,
+			v1beta1.RouteReasonUnsupportedValue,
+			errMsg,
+		)
+		ctx.directResponse = &ir.DirectResponse{
+			Body:       &errMsg,
+			StatusCode: 500,
+		}
+	}
+}
+
+// translateRequestHeaderModifierFilter translates a RequestHeaderModifier
+// filter into the request headers to add and remove.
+func translateRequestHeaderModifierFilter(filter *v1beta1.HTTPRouteFilter, ctx *httpFiltersContext) {
+	ctx.addRequestHeaders, ctx.removeRequestHeaders = processRequestHeaderModifierFilter(filter.RequestHeaderModifier,
+		ctx.parentRef, ctx.httpRoute, ctx.addRequestHeaders, ctx.removeRequestHeaders)
+}
+
+// translateRequestMirrorFilter translates a RequestMirror filter into a mirror
+// of the requests.
+func translateRequestMirrorFilter(filter *v1beta1.HTTPRouteFilter, ctx *httpFiltersContext) {
+	if mirror := buildMirror(filter.RequestMirror, ctx.shadowHeader, ctx.parentRef, ctx.httpRoute, ctx.resources); mirror != nil {
+		ctx.mirrors = append(ctx.mirrors, mirror)
+	}
+}
+
+// translateRequestRedirectFilter translates a RequestRedirect filter into the
+// redirect response of the route.
+func translateRequestRedirectFilter(filter *v1beta1.HTTPRouteFilter, ctx *httpFiltersContext) {
+	parentRef, httpRoute := ctx.parentRef, ctx.httpRoute
+
+	// Can't have two redirects for the same route
+	if ctx.redirectResponse != nil {
+		parentRef.SetCondition(httpRoute,
+			v1beta1.RouteConditionAccepted,
+			metav1.ConditionFalse,
+			v1beta1.RouteReasonUnsupportedValue,
+			"Cannot configure multiple requestRedirect filters for a single HTTPRouteRule",
+		)
+		return
+	}
+
+	redirect := filter.RequestRedirect
+	if redirect == nil {
+		return
+	}
+
+	redir := &ir.Redirect{}
+	if redirect.Scheme != nil {
+		// Note that gateway API may support additional schemes in the future, but unknown values
+		// must result in an UnsupportedValue status
+		if *redirect.Scheme == "http" || *redirect.Scheme == "https" {
+			redir.Scheme = redirect.Scheme
+		} else {
+			errMsg := fmt.Sprintf("Scheme: %s is unsupported, only 'https' and 'http' are supported", *redirect.Scheme)
+			parentRef.SetCondition(httpRoute,
+				v1beta1.RouteConditionAccepted,
+				metav1.ConditionFalse,
+				v1beta1.RouteReasonUnsupportedValue,
+				errMsg,
+			)
+			return
+		}
+	}
+
+	if redirect.Hostname != nil {
+		if err := isValidHostname(string(*redirect.Hostname)); err != nil {
+			parentRef.SetCondition(httpRoute,
+				v1beta1.RouteConditionAccepted,
+				metav1.ConditionFalse,
+				v1beta1.RouteReasonUnsupportedValue,
+				err.Error(),
+			)
+			return
+		}
+		redirectHost := string(*redirect.Hostname)
+		redir.Hostname = &redirectHost
+	}
+
+	if redirect.Path != nil {
+		switch redirect.Path.Type {
+		case v1beta1.FullPathHTTPPathModifier:
+			if redirect.Path.ReplaceFullPath != nil {
+				redir.Path = &ir.HTTPPathModifier{
+					FullReplace: redirect.Path.ReplaceFullPath,
+				}
+			}
+		case v1beta1.PrefixMatchHTTPPathModifier:
+			if redirect.Path.ReplacePrefixMatch != nil {
+				redir.Path = &ir.HTTPPathModifier{
+					PrefixMatchReplace: redirect.Path.ReplacePrefixMatch,
+				}
+			}
+		default:
+			errMsg := fmt.Sprintf("Redirect path type: %s is invalid, only \"ReplaceFullPath\" and \"ReplacePrefixMatch\" are supported", redirect.Path.Type)
+			parentRef.SetCondition(httpRoute,
+				v1beta1.RouteConditionAccepted,
+				metav1.ConditionFalse,
+				v1beta1.RouteReasonUnsupportedValue,
+				errMsg,
+			)
+			return
+		}
+	}
+
+	if redirect.StatusCode != nil {
+		redirectCode := int32(*redirect.StatusCode)
+		// Envoy supports 302, 303, 307, and 308, but gateway API only includes 301 and 302
+		if redirectCode == 301 || redirectCode == 302 {
+			redir.StatusCode = &redirectCode
+		} else {
+			errMsg := fmt.Sprintf("Status code %d is invalid, only 302 and 301 are supported", redirectCode)
+			parentRef.SetCondition(httpRoute,
+				v1beta1.RouteConditionAccepted,
+				metav1.ConditionFalse,
+				v1beta1.RouteReasonUnsupportedValue,
+				errMsg,
+			)
+			return
+		}
+	}
+
+	if redirect.Port != nil {
+		redirectPort := uint32(*redirect.Port)
+		redir.Port = &redirectPort
+	}
+
+	ctx.redirectResponse = redir
+}
@@ -0,0 +1,89 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func TestProcessHTTPFilters(t *testing.T) {
+	extensionKey := httpFilterKey{Type: v1beta1.HTTPRouteFilterExtensionRef, Group: "example.io", Kind: "Foo"}
+	httpFilterTranslators[extensionKey] = func(filter *v1beta1.HTTPRouteFilter, ctx *httpFiltersContext) {
+		ctx.removeRequestHeaders = append(ctx.removeRequestHeaders, string(filter.ExtensionRef.Name))
+	}
+	t.Cleanup(func() { delete(httpFilterTranslators, extensionKey) })
+
+	testCases := []struct {
+		name                 string
+		filters              []v1beta1.HTTPRouteFilter
+		removeRequestHeaders []string
+		directResponse       string
+	}{
+		{
+			name: "built-in and extension filters",
+			filters: []v1beta1.HTTPRouteFilter{
+				{
+					Type: v1beta1.HTTPRouteFilterRequestHeaderModifier,
+					RequestHeaderModifier: &v1beta1.HTTPRequestHeaderFilter{
+						Remove: []string{"x-debug"},
+					},
+				},
+				{
+					Type:         v1beta1.HTTPRouteFilterExtensionRef,
+					ExtensionRef: &v1beta1.LocalObjectReference{Group: "example.io", Kind: "Foo", Name: "x-foo"},
+				},
+			},
+			removeRequestHeaders: []string{"x-debug", "x-foo"},
+		},
+		{
+			name: "unknown extension filter",
+			filters: []v1beta1.HTTPRouteFilter{
+				{
+					Type:         v1beta1.HTTPRouteFilterExtensionRef,
+					ExtensionRef: &v1beta1.LocalObjectReference{Group: "example.io", Kind: "Bar", Name: "bar"},
+				},
+				{
+					Type:         v1beta1.HTTPRouteFilterExtensionRef,
+					ExtensionRef: &v1beta1.LocalObjectReference{Group: "example.io", Kind: "Foo", Name: "x-foo"},
+				},
+			},
+			directResponse: "Unknown custom filter type: ExtensionRef",
+		},
+		{
+			name:           "unsupported filter",
+			filters:        []v1beta1.HTTPRouteFilter{{Type: "Unknown"}},
+			directResponse: "Unsupported filter type: Unknown",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			httpRoute := &v1beta1.HTTPRoute{
+				Status: v1beta1.HTTPRouteStatus{
+					RouteStatus: v1beta1.RouteStatus{Parents: []v1beta1.RouteParentStatus{{}}},
+				},
+			}
+			ctx := &httpFiltersContext{
+				parentRef: &RouteParentContext{ParentReference: &v1beta1.ParentReference{}, httpRoute: httpRoute},
+				httpRoute: &HTTPRouteContext{HTTPRoute: httpRoute},
+			}
+			processHTTPFilters(tc.filters, ctx)
+
+			require.Equal(t, tc.removeRequestHeaders, ctx.removeRequestHeaders)
+			if tc.directResponse == "" {
+				require.Nil(t, ctx.directResponse)
+				return
+			}
+			require.Equal(t, &ir.DirectResponse{Body: &tc.directResponse, StatusCode: 500}, ctx.directResponse)
+		})
+	}
+}
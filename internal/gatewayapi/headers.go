@@ -0,0 +1,100 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// supportedHeaderCommandOperators holds the Envoy command operators that can be
+// used in the values of the headers added to requests. They are limited to the
+// ones that cannot fail and do not expose sensitive information.
+var supportedHeaderCommandOperators = map[string]bool{
+	"DOWNSTREAM_DIRECT_REMOTE_ADDRESS":              true,
+	"DOWNSTREAM_DIRECT_REMOTE_ADDRESS_WITHOUT_PORT": true,
+	"DOWNSTREAM_LOCAL_ADDRESS":                      true,
+	"DOWNSTREAM_LOCAL_ADDRESS_WITHOUT_PORT":         true,
+	"DOWNSTREAM_LOCAL_PORT":                         true,
+	"DOWNSTREAM_REMOTE_ADDRESS":                     true,
+	"DOWNSTREAM_REMOTE_ADDRESS_WITHOUT_PORT":        true,
+	"DOWNSTREAM_REMOTE_PORT":                        true,
+	"HOSTNAME":                                      true,
+	"PROTOCOL":                                      true,
+	"REQUESTED_SERVER_NAME":                         true,
+	"START_TIME":                                    true,
+	"UPSTREAM_REMOTE_ADDRESS":                       true,
+}
+
+// reqCommandOperatorRegex matches the %REQ(X?Y):Z% command operator, without
+// its percent signs, which is replaced by the value of the X request header,
+// or of the Y request header if X is not present, truncated to Z characters.
+var reqCommandOperatorRegex = regexp.MustCompile(`^REQ\(([^?)]+)(\?[^)]+)?\)(:[0-9]+)?$`)
+
+// isValidHeaderName returns true if the name is a valid HTTP header name, i.e.
+// a token as defined by RFC 7230.
+func isValidHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if !isTokenChar(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// isTokenChar returns true if c is a token character as defined by RFC 7230.
+func isTokenChar(c rune) bool {
+	if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' {
+		return true
+	}
+	return strings.ContainsRune("!#$%&'*+-.^_`|~", c)
+}
+
+// validateHeaderValue returns an error if the value contains characters that
+// are not allowed in HTTP header values, or command operators that are not
+// supported. Percent signs must either be escaped as %% or delimit a supported
+// command operator, since Envoy rejects the configuration otherwise.
+func validateHeaderValue(value string) error {
+	for _, c := range value {
+		if c < ' ' && c != '\t' || c == 0x7f {
+			return fmt.Errorf("value contains the invalid character %q", c)
+		}
+	}
+
+	for rest := value; ; {
+		start := strings.IndexByte(rest, '%')
+		if start < 0 {
+			return nil
+		}
+		rest = rest[start+1:]
+		if strings.HasPrefix(rest, "%") {
+			rest = rest[1:]
+			continue
+		}
+		end := strings.IndexByte(rest, '%')
+		if end < 0 {
+			return errors.New("value contains an unterminated command operator, a literal % must be doubled")
+		}
+		if operator := rest[:end]; !isSupportedHeaderCommandOperator(operator) {
+			return fmt.Errorf("value contains the unsupported command operator %%%s%%", operator)
+		}
+		rest = rest[end+1:]
+	}
+}
+
+// isSupportedHeaderCommandOperator returns true if the command operator, without
+// its percent signs, can be used in header values.
+func isSupportedHeaderCommandOperator(operator string) bool {
+	if supportedHeaderCommandOperators[operator] {
+		return true
+	}
+	return reqCommandOperatorRegex.MatchString(operator)
+}
@@ -0,0 +1,100 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidHeaderName(t *testing.T) {
+	testCases := []struct {
+		name  string
+		valid bool
+	}{
+		{name: "x-example-header", valid: true},
+		{name: "X_Example.Header~1", valid: true},
+		{name: "", valid: false},
+		{name: "example header", valid: false},
+		{name: "example\"header", valid: false},
+		{name: "example(header)", valid: false},
+		{name: "exämple", valid: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.valid, isValidHeaderName(tc.name))
+		})
+	}
+}
+
+func TestValidateHeaderValue(t *testing.T) {
+	testCases := []struct {
+		name    string
+		value   string
+		wantErr string
+	}{
+		{
+			name:  "plain value",
+			value: "some value\twith a tab",
+		},
+		{
+			name:  "empty value",
+			value: "",
+		},
+		{
+			name:  "escaped percent signs",
+			value: "100%% and %%%%",
+		},
+		{
+			name:  "supported command operators",
+			value: "%DOWNSTREAM_REMOTE_ADDRESS_WITHOUT_PORT%,%HOSTNAME%",
+		},
+		{
+			name:  "request header command operator",
+			value: "forwarded-for=%REQ(x-forwarded-for)%;host=%REQ(:authority?host):32%",
+		},
+		{
+			name:    "new line",
+			value:   "some\nvalue",
+			wantErr: `value contains the invalid character '\n'`,
+		},
+		{
+			name:    "null character",
+			value:   "some\x00value",
+			wantErr: `value contains the invalid character '\x00'`,
+		},
+		{
+			name:    "unterminated command operator",
+			value:   "100%",
+			wantErr: "value contains an unterminated command operator, a literal % must be doubled",
+		},
+		{
+			name:    "unsupported command operator",
+			value:   "%DYNAMIC_METADATA(foo)%",
+			wantErr: "value contains the unsupported command operator %DYNAMIC_METADATA(foo)%",
+		},
+		{
+			name:    "invalid request header command operator",
+			value:   "%REQ()%",
+			wantErr: "value contains the unsupported command operator %REQ()%",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateHeaderValue(tc.value)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+}
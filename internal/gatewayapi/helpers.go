@@ -6,11 +6,22 @@
 package gatewayapi
 
 import (
+	"fmt"
+	"net"
+	"sort"
 	"strings"
 
+	"golang.org/x/exp/slices"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/crypto"
+	"github.com/envoyproxy/gateway/internal/ir"
 )
 
 func GroupPtr(name string) *v1beta1.Group {
@@ -49,6 +60,10 @@ func Int32Ptr(val int32) *int32 {
 	return &val
 }
 
+func Uint64Ptr(val uint64) *uint64 {
+	return &val
+}
+
 func PortNumPtr(val int32) *v1beta1.PortNumber {
 	portNum := v1beta1.PortNumber(val)
 	return &portNum
@@ -103,6 +118,13 @@ func GroupDerefOr(group *v1beta1.Group, defaultGroup string) string {
 	return defaultGroup
 }
 
+func KindDerefOr(kind *v1beta1.Kind, defaultKind string) string {
+	if kind != nil && *kind != "" {
+		return string(*kind)
+	}
+	return defaultKind
+}
+
 // IsRefToGateway returns whether the provided parent ref is a reference
 // to a Gateway with the given namespace/name, irrespective of whether a
 // section/listener name has been specified (i.e. a parent ref to a listener
@@ -125,22 +147,31 @@ func IsRefToGateway(parentRef v1beta1.ParentReference, gateway types.NamespacedN
 
 // GetReferencedListeners returns whether a given parent ref references a Gateway
 // in the given list, and if so, a list of the Listeners within that Gateway that
-// are included by the parent ref (either one specific Listener, or all Listeners
-// in the Gateway, depending on whether section name is specified or not).
+// are included by the parent ref (either one specific Listener, the Listeners on
+// a specific port, or all Listeners in the Gateway, depending on whether section
+// name and port are specified or not).
 func GetReferencedListeners(parentRef v1beta1.ParentReference, gateways []*GatewayContext) (bool, []*ListenerContext) {
 	var selectsGateway bool
 	var referencedListeners []*ListenerContext
 
 	for _, gateway := range gateways {
-		if !IsRefToGateway(parentRef, types.NamespacedName{Namespace: gateway.Namespace, Name: gateway.Name}) {
+		if gateway == nil || gateway.Gateway == nil {
+			continue
+		}
+		gatewayNN := types.NamespacedName{Namespace: gateway.Namespace, Name: gateway.Name}
+		if !IsRefToGateway(parentRef, gatewayNN) {
 			continue
 		}
 
 		selectsGateway = true
 
-		// The parentRef may be to the entire Gateway, or to a specific listener.
+		// The parentRef may be to the entire Gateway, to the listeners on a
+		// specific port, or to a specific listener.
 		for _, listener := range gateway.listeners {
-			if parentRef.SectionName == nil || *parentRef.SectionName == listener.Name {
+			if listener == nil || listener.Listener == nil {
+				continue
+			}
+			if ParentRefMatchesListener(parentRef, gatewayNN, listener.Name, listener.Port) {
 				referencedListeners = append(referencedListeners, listener)
 			}
 		}
@@ -160,6 +191,410 @@ func HasReadyListener(listeners []*ListenerContext) bool {
 	return false
 }
 
+// requestedIPAddresses returns the IP addresses requested through the
+// spec.addresses field of the provided Gateway. Addresses of other types
+// are not supported and are surfaced through the Gateway status.
+func requestedIPAddresses(gateway *v1beta1.Gateway) []string {
+	var addrs []string
+	for _, addr := range gateway.Spec.Addresses {
+		if addr.Type == nil || *addr.Type == v1beta1.IPAddressType {
+			addrs = append(addrs, addr.Value)
+		}
+	}
+	return addrs
+}
+
+// listenerHostnames returns the sorted, unique hostnames of the listeners of
+// the provided gateway.
+func listenerHostnames(gateway *v1beta1.Gateway) []string {
+	var hostnames []string
+	for _, listener := range gateway.Spec.Listeners {
+		if listener.Hostname != nil && !slices.Contains(hostnames, string(*listener.Hostname)) {
+			hostnames = append(hostnames, string(*listener.Hostname))
+		}
+	}
+	sort.Strings(hostnames)
+	return hostnames
+}
+
+// irListenerAddress returns the address the proxy listeners bind to, based on
+// the IP families requested for the Envoy service.
+func irListenerAddress(envoyProxy *v1alpha1.EnvoyProxy) string {
+	if envoyProxy.GetEnvoyServiceSpec().IsIPv6Enabled() {
+		return IPv6ListenerAddress
+	}
+	return IPv4ListenerAddress
+}
+
+// irFilterOrder returns the HTTP filter positions of the provided EnvoyProxy.
+func irFilterOrder(envoyProxy *v1alpha1.EnvoyProxy) []*ir.FilterPosition {
+	if envoyProxy == nil {
+		return nil
+	}
+	var order []*ir.FilterPosition
+	for _, position := range envoyProxy.Spec.FilterOrder {
+		order = append(order, &ir.FilterPosition{
+			Name:   position.Name,
+			Before: position.Before,
+			After:  position.After,
+		})
+	}
+	return order
+}
+
+// irTimeouts returns the default timeouts of the routes, i.e. the ones of the
+// provided EnvoyProxy, falling back to the provided defaults field by field.
+func irTimeouts(defaults *v1alpha1.Timeouts, envoyProxy *v1alpha1.EnvoyProxy) *v1alpha1.Timeouts {
+	timeouts := new(v1alpha1.Timeouts)
+	if defaults != nil {
+		timeouts = defaults.DeepCopy()
+	}
+	if envoyProxy == nil || envoyProxy.Spec.Timeouts == nil {
+		return timeouts
+	}
+	if envoyProxy.Spec.Timeouts.Request != nil {
+		timeouts.Request = envoyProxy.Spec.Timeouts.Request.DeepCopy()
+	}
+	if envoyProxy.Spec.Timeouts.Idle != nil {
+		timeouts.Idle = envoyProxy.Spec.Timeouts.Idle.DeepCopy()
+	}
+	if envoyProxy.Spec.Timeouts.Connect != nil {
+		timeouts.Connect = envoyProxy.Spec.Timeouts.Connect.DeepCopy()
+	}
+	return timeouts
+}
+
+// backendTrafficPolicyForRoute returns the BackendTrafficPolicy targeting the
+// provided HTTPRoute, or nil if there is none. Policies only target routes in
+// their own namespace, and the oldest policy wins if several target the route.
+func backendTrafficPolicyForRoute(policies []*v1alpha1.BackendTrafficPolicy, route *v1beta1.HTTPRoute) *v1alpha1.BackendTrafficPolicy {
+	var selected *v1alpha1.BackendTrafficPolicy
+	for _, policy := range policies {
+		if !policyTargetsRoute(policy.Namespace, policy.Spec.TargetRef, policy.Spec.TargetSelectors, route) {
+			continue
+		}
+		if selected == nil || isOlderPolicy(&policy.ObjectMeta, &selected.ObjectMeta) {
+			selected = policy
+		}
+	}
+	return selected
+}
+
+// backendTrafficPolicyForGateway returns the BackendTrafficPolicy targeting
+// the provided Gateway, or nil if there is none, following the same rules as
+// backendTrafficPolicyForRoute.
+func backendTrafficPolicyForGateway(policies []*v1alpha1.BackendTrafficPolicy, gateway *v1beta1.Gateway) *v1alpha1.BackendTrafficPolicy {
+	var selected *v1alpha1.BackendTrafficPolicy
+	for _, policy := range policies {
+		if !policyTargetsGateway(policy.Namespace, policy.Spec.TargetRef, policy.Spec.TargetSelectors, gateway) {
+			continue
+		}
+		if selected == nil || isOlderPolicy(&policy.ObjectMeta, &selected.ObjectMeta) {
+			selected = policy
+		}
+	}
+	return selected
+}
+
+// securityPolicyForRoute returns the SecurityPolicy targeting the provided
+// HTTPRoute, or nil if there is none, following the same rules as
+// backendTrafficPolicyForRoute.
+func securityPolicyForRoute(policies []*v1alpha1.SecurityPolicy, route *v1beta1.HTTPRoute) *v1alpha1.SecurityPolicy {
+	var selected *v1alpha1.SecurityPolicy
+	for _, policy := range policies {
+		if !policyTargetsRoute(policy.Namespace, policy.Spec.TargetRef, policy.Spec.TargetSelectors, route) {
+			continue
+		}
+		if selected == nil || isOlderPolicy(&policy.ObjectMeta, &selected.ObjectMeta) {
+			selected = policy
+		}
+	}
+	return selected
+}
+
+// clientTrafficPolicyForGateway returns the ClientTrafficPolicy targeting the
+// provided Gateway, or nil if there is none, following the same rules as
+// backendTrafficPolicyForRoute.
+func clientTrafficPolicyForGateway(policies []*v1alpha1.ClientTrafficPolicy, gateway *v1beta1.Gateway) *v1alpha1.ClientTrafficPolicy {
+	var selected *v1alpha1.ClientTrafficPolicy
+	for _, policy := range policies {
+		if !policyTargetsGateway(policy.Namespace, policy.Spec.TargetRef, policy.Spec.TargetSelectors, gateway) {
+			continue
+		}
+		if selected == nil || isOlderPolicy(&policy.ObjectMeta, &selected.ObjectMeta) {
+			selected = policy
+		}
+	}
+	return selected
+}
+
+// irHeaderLimits returns the request header limits of the listeners of the
+// Gateway targeted by the provided policy, or nil if the policy does not limit
+// the request headers.
+func irHeaderLimits(policy *v1alpha1.ClientTrafficPolicy) *ir.HeaderLimits {
+	if policy == nil || policy.Spec.Headers == nil {
+		return nil
+	}
+	limits := new(ir.HeaderLimits)
+	if policy.Spec.Headers.MaxRequestHeadersKiB != nil {
+		limits.MaxRequestHeadersKiB = *policy.Spec.Headers.MaxRequestHeadersKiB
+	}
+	if policy.Spec.Headers.MaxRequestHeadersCount != nil {
+		limits.MaxRequestHeadersCount = *policy.Spec.Headers.MaxRequestHeadersCount
+	}
+	return limits
+}
+
+// irDraining returns the connection draining configuration of the listeners
+// of the Gateway targeted by the provided policy, or nil if the policy does not
+// configure it.
+func irDraining(policy *v1alpha1.ClientTrafficPolicy) *ir.Draining {
+	if policy == nil || policy.Spec.Draining == nil {
+		return nil
+	}
+	draining := &ir.Draining{Timeout: policy.Spec.Draining.Timeout}
+	if policy.Spec.Draining.Type != nil && *policy.Spec.Draining.Type == v1alpha1.ModifyOnlyDrainType {
+		draining.ModifyOnly = true
+	}
+	return draining
+}
+
+// irSocketOptions returns the socket options of the listeners of the Gateway
+// targeted by the provided policy, or nil if the policy does not configure
+// them.
+func irSocketOptions(policy *v1alpha1.ClientTrafficPolicy) *ir.SocketOptions {
+	if policy == nil || policy.Spec.SocketOptions == nil {
+		return nil
+	}
+	options := &ir.SocketOptions{
+		ReusePort: policy.Spec.SocketOptions.ReusePort,
+		Freebind:  policy.Spec.SocketOptions.Freebind,
+		DSCP:      policy.Spec.SocketOptions.DSCP,
+	}
+	if policy.Spec.SocketOptions.TCPFastOpenQueueLength != nil {
+		options.TCPFastOpenQueueLength = *policy.Spec.SocketOptions.TCPFastOpenQueueLength
+	}
+	return options
+}
+
+// irHTTP1Settings returns the settings of the HTTP/1 connections of the
+// listeners of the Gateway targeted by the provided policy, or nil if the
+// policy does not accept the HTTP/1.0 requests.
+func irHTTP1Settings(policy *v1alpha1.ClientTrafficPolicy) *ir.HTTP1Settings {
+	if policy == nil || policy.Spec.HTTP1 == nil || !policy.Spec.HTTP1.EnableHTTP10 {
+		return nil
+	}
+	settings := &ir.HTTP1Settings{EnableHTTP10: true}
+	if policy.Spec.HTTP1.HTTP10DefaultHost != nil {
+		settings.HTTP10DefaultHost = *policy.Spec.HTTP1.HTTP10DefaultHost
+	}
+	return settings
+}
+
+// irHeaderHandling returns how the listeners of the Gateway targeted by the
+// provided policy handle the headers, or nil if the policy does not configure
+// it. The Host header is never stripped, since Envoy rejects the
+// configurations removing it.
+func irHeaderHandling(policy *v1alpha1.ClientTrafficPolicy) *ir.HeaderHandling {
+	if policy == nil || policy.Spec.HeaderHandling == nil {
+		return nil
+	}
+	handling := &ir.HeaderHandling{ProperCaseHeaders: policy.Spec.HeaderHandling.ProperCaseHeaders}
+	if action := policy.Spec.HeaderHandling.DuplicateHeaders; action != nil {
+		switch *action {
+		case v1alpha1.ConcatenateDuplicateHeaders:
+			handling.DuplicateHeaders = ir.ConcatenateDuplicateHeaders
+		case v1alpha1.RejectDuplicateHeaders:
+			handling.DuplicateHeaders = ir.RejectDuplicateHeaders
+		}
+	}
+	for _, header := range policy.Spec.HeaderHandling.HopByHopHeaders {
+		name := strings.ToLower(string(header))
+		if name != "host" {
+			handling.HopByHopHeaders = append(handling.HopByHopHeaders, name)
+		}
+	}
+	return handling
+}
+
+// irUDPLoadBalancer returns the load balancer of the sessions of the UDP
+// listeners of the Gateway targeted by the provided policy, or nil if the
+// sessions are balanced round-robin. The Maglev load balancer hashes the source
+// IP of the sessions, so that the sessions of a client are pinned to a backend.
+func irUDPLoadBalancer(policy *v1alpha1.ClientTrafficPolicy) *ir.LoadBalancer {
+	if policy == nil || policy.Spec.UDP == nil || policy.Spec.UDP.SessionAffinity == nil ||
+		*policy.Spec.UDP.SessionAffinity != v1alpha1.SourceIPUDPSessionAffinity {
+		return nil
+	}
+	return &ir.LoadBalancer{Type: ir.MaglevLoadBalancer}
+}
+
+// irUDPIdleTimeout returns the idle timeout of the sessions of the UDP
+// listeners of the Gateway targeted by the provided policy, or nil if the
+// policy does not configure it.
+func irUDPIdleTimeout(policy *v1alpha1.ClientTrafficPolicy) *metav1.Duration {
+	if policy == nil || policy.Spec.UDP == nil {
+		return nil
+	}
+	return policy.Spec.UDP.IdleTimeout
+}
+
+// irUDPDNS returns the DNS records answered by the UDP listeners of the
+// Gateway targeted by the provided policy, or nil if the policy does not
+// configure any. It returns an error if a record has no domain or addresses, or
+// if an address is not a valid IP address.
+func irUDPDNS(policy *v1alpha1.ClientTrafficPolicy) (*ir.DNSConfig, error) {
+	if policy == nil || policy.Spec.UDP == nil || policy.Spec.UDP.DNS == nil {
+		return nil, nil
+	}
+	dns := &ir.DNSConfig{TTL: policy.Spec.UDP.DNS.TTL}
+	for _, record := range policy.Spec.UDP.DNS.Records {
+		if record.Domain == "" || len(record.Addresses) == 0 {
+			return nil, fmt.Errorf("records must have a domain and addresses")
+		}
+		for _, address := range record.Addresses {
+			if net.ParseIP(address) == nil {
+				return nil, fmt.Errorf("address %q of domain %s is not a valid IP address", address, record.Domain)
+			}
+		}
+		dns.Records = append(dns.Records, ir.DNSRecord{
+			Domain:    record.Domain,
+			Addresses: record.Addresses,
+		})
+	}
+	return dns, nil
+}
+
+// isH2CEnabled returns true if the provided policy serves the cleartext
+// HTTP/2 connections with prior knowledge on the HTTP listeners of the
+// Gateway it targets.
+func isH2CEnabled(policy *v1alpha1.ClientTrafficPolicy) bool {
+	return policy != nil && policy.Spec.HTTP2 != nil && policy.Spec.HTTP2.H2C
+}
+
+// securityPolicyForGateway returns the SecurityPolicy targeting the provided
+// Gateway, or nil if there is none, following the same rules as
+// backendTrafficPolicyForRoute.
+func securityPolicyForGateway(policies []*v1alpha1.SecurityPolicy, gateway *v1beta1.Gateway) *v1alpha1.SecurityPolicy {
+	var selected *v1alpha1.SecurityPolicy
+	for _, policy := range policies {
+		if !policyTargetsGateway(policy.Namespace, policy.Spec.TargetRef, policy.Spec.TargetSelectors, gateway) {
+			continue
+		}
+		if selected == nil || isOlderPolicy(&policy.ObjectMeta, &selected.ObjectMeta) {
+			selected = policy
+		}
+	}
+	return selected
+}
+
+// policyTargetsGateway returns true if the target reference or one of the
+// target selectors of a policy in the provided namespace selects the Gateway.
+func policyTargetsGateway(namespace string, ref *v1alpha2.PolicyTargetReference, selectors []v1alpha1.TargetSelector, gateway *v1beta1.Gateway) bool {
+	return policyTargets(namespace, ref, selectors, KindGateway, gateway)
+}
+
+// policyTargetsRoute returns true if the target reference or one of the
+// target selectors of a policy in the provided namespace selects the
+// HTTPRoute.
+func policyTargetsRoute(namespace string, ref *v1alpha2.PolicyTargetReference, selectors []v1alpha1.TargetSelector, route *v1beta1.HTTPRoute) bool {
+	return policyTargets(namespace, ref, selectors, KindHTTPRoute, route)
+}
+
+// policyTargets returns true if the target reference or one of the target
+// selectors of a policy in the provided namespace selects the object of the
+// provided Gateway API kind. Policies only apply to the objects in their own
+// namespace.
+func policyTargets(namespace string, ref *v1alpha2.PolicyTargetReference, selectors []v1alpha1.TargetSelector, kind string, obj metav1.Object) bool {
+	if namespace != obj.GetNamespace() {
+		return false
+	}
+	if ref != nil && string(ref.Group) == v1beta1.GroupName && string(ref.Kind) == kind && string(ref.Name) == obj.GetName() &&
+		(ref.Namespace == nil || string(*ref.Namespace) == namespace) {
+		return true
+	}
+	for _, selector := range selectors {
+		if string(selector.Group) != v1beta1.GroupName || string(selector.Kind) != kind || len(selector.MatchLabels) == 0 {
+			continue
+		}
+		if labels.SelectorFromSet(selector.MatchLabels).Matches(labels.Set(obj.GetLabels())) {
+			return true
+		}
+	}
+	return false
+}
+
+// isOlderPolicy returns true if the policy a was created before the policy b,
+// using their names as a tie-breaker.
+func isOlderPolicy(a, b *metav1.ObjectMeta) bool {
+	if a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.Name < b.Name
+	}
+	return a.CreationTimestamp.Before(&b.CreationTimestamp)
+}
+
+// isOlderGateway returns true if Gateway a was created before Gateway b,
+// ordering the Gateways created at the same time by namespace and name.
+func isOlderGateway(a, b *v1beta1.Gateway) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// setCrossGatewayHostnameConflicts sets the Conflicted condition on the
+// listeners claiming the hostname and port of a listener of another Gateway.
+// The oldest Gateway keeps the hostname, so that the conflict is resolved
+// deterministically rather than by whichever proxy the clients resolve.
+func setCrossGatewayHostnameConflicts(gateways []*GatewayContext) {
+	type hostnamePort struct {
+		hostname string
+		port     v1beta1.PortNumber
+	}
+
+	sorted := make([]*GatewayContext, len(gateways))
+	copy(sorted, gateways)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return isOlderGateway(sorted[i].Gateway, sorted[j].Gateway)
+	})
+
+	owners := make(map[hostnamePort]*GatewayContext)
+	for _, gateway := range sorted {
+		for _, listener := range gateway.listeners {
+			// Listeners without hostname match all the hostnames, but
+			// are expected to be shared across Gateways with distinct
+			// addresses.
+			if listener.Hostname == nil {
+				continue
+			}
+			key := hostnamePort{hostname: string(*listener.Hostname), port: listener.Port}
+			owner, ok := owners[key]
+			if !ok {
+				owners[key] = gateway
+				continue
+			}
+			if owner == gateway {
+				continue
+			}
+			listener.SetCondition(
+				v1beta1.ListenerConditionConflicted,
+				metav1.ConditionTrue,
+				v1beta1.ListenerReasonHostnameConflict,
+				fmt.Sprintf("Hostname %s on port %d is already claimed by Gateway %s/%s.",
+					key.hostname, key.port, owner.Namespace, owner.Name),
+			)
+		}
+	}
+}
+
+// serviceFQDN returns the fully qualified domain name of the Service.
+func serviceFQDN(service *v1.Service) string {
+	return fmt.Sprintf("%s.%s.svc.%s", service.Name, service.Namespace, crypto.DefaultDNSSuffix)
+}
+
 // computeHosts returns a list of the intersecting hostnames between the route
 // and the listener.
 func computeHosts(routeHostnames []string, listenerHostname *v1beta1.Hostname) []string {
@@ -212,6 +647,63 @@ func computeHosts(routeHostnames []string, listenerHostname *v1beta1.Hostname) [
 	return hostnames
 }
 
+// hostnameCovers returns true if hostname matches all the hosts matched by the
+// more specific hostname other, e.g. "*.example.com" covers "foo.example.com"
+// and "*.foo.example.com", and "*" covers any other hostname.
+func hostnameCovers(hostname, other string) bool {
+	switch {
+	case hostname == other:
+		return false
+	case hostname == "*":
+		return true
+	case strings.HasPrefix(hostname, "*"):
+		return hostnameMatchesWildcardHostname(other, hostname)
+	}
+	return false
+}
+
+// setIsolatedHostnames isolates the HTTP listeners sharing a port, per the
+// listener isolation of GEP-1713: the requests for the hostname of a listener
+// are not served by the routes of the less specific listeners matching it.
+func setIsolatedHostnames(listeners []*ir.HTTPListener) {
+	for _, listener := range listeners {
+		for _, other := range listeners {
+			if other == listener || other.Port != listener.Port {
+				continue
+			}
+			for _, hostname := range listener.Hostnames {
+				for _, otherHostname := range other.Hostnames {
+					if hostnameCovers(hostname, otherHostname) && !slices.Contains(listener.IsolatedHostnames, otherHostname) {
+						listener.IsolatedHostnames = append(listener.IsolatedHostnames, otherHostname)
+					}
+				}
+			}
+		}
+	}
+}
+
+// excludeIsolatedHostnames returns the hosts which are not matched by any of
+// the isolated hostnames.
+func excludeIsolatedHostnames(hosts, isolatedHostnames []string) []string {
+	if len(isolatedHostnames) == 0 {
+		return hosts
+	}
+	var ret []string
+	for _, host := range hosts {
+		isolated := false
+		for _, hostname := range isolatedHostnames {
+			if host == hostname || hostnameCovers(hostname, host) {
+				isolated = true
+				break
+			}
+		}
+		if !isolated {
+			ret = append(ret, host)
+		}
+	}
+	return ret
+}
+
 // hostnameMatchesWildcardHostname returns true if hostname has the non-wildcard
 // portion of wildcardHostname as a suffix, plus at least one DNS label matching the
 // wildcard.
@@ -0,0 +1,95 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// IsGatewayBackendRef returns whether the backendRef of a TCPRoute references
+// a Gateway, whose HTTP listener on the port of the backendRef processes the
+// connections as HTTP requests.
+func IsGatewayBackendRef(backendRef v1alpha2.BackendRef) bool {
+	return backendRef.Group != nil && string(*backendRef.Group) == v1beta1.GroupName &&
+		backendRef.Kind != nil && string(*backendRef.Kind) == KindGateway
+}
+
+// buildInternalListenerDest translates a backendRef referencing the Gateway of
+// the parentRef into a destination forwarding the connections to its HTTP
+// listener on the port of the backendRef through an internal listener, e.g. to
+// route the requests of the TLS connections terminated by a TCP listener. It
+// sets error statuses and returns nil if the backendRef is invalid.
+func buildInternalListenerDest(backendRef v1alpha2.BackendRef,
+	parentRef *RouteParentContext,
+	route RouteContext,
+	xdsIR XdsIRMap) *ir.RouteDestination {
+	if len(parentRef.listeners) == 0 {
+		return nil
+	}
+
+	gateway := parentRef.listeners[0].gateway
+	namespace := NamespaceDerefOrAlpha(backendRef.Namespace, route.GetNamespace())
+	if namespace != gateway.Namespace || string(backendRef.Name) != gateway.Name {
+		parentRef.SetCondition(route,
+			v1beta1.RouteConditionResolvedRefs,
+			metav1.ConditionFalse,
+			v1beta1.RouteReasonRefNotPermitted,
+			fmt.Sprintf("Backend ref to gateway %s/%s not permitted, only the gateway %s/%s of the parent ref can be referenced",
+				namespace, backendRef.Name, gateway.Namespace, gateway.Name),
+		)
+		return nil
+	}
+
+	if backendRef.Port == nil {
+		parentRef.SetCondition(route,
+			v1beta1.RouteConditionResolvedRefs,
+			metav1.ConditionFalse,
+			"PortNotSpecified",
+			"A valid port number corresponding to an HTTP listener of the Gateway must be specified",
+		)
+		return nil
+	}
+
+	// Connections can only be forwarded to the plain text HTTP listeners.
+	var httpListener *ir.HTTPListener
+	containerPort := uint32(servicePortToContainerPort(int32(*backendRef.Port)))
+	for _, listener := range xdsIR[irStringKey(gateway)].HTTP {
+		if listener.Port == containerPort && listener.TLS == nil {
+			httpListener = listener
+			break
+		}
+	}
+	if httpListener == nil {
+		parentRef.SetCondition(route,
+			v1beta1.RouteConditionResolvedRefs,
+			metav1.ConditionFalse,
+			"PortNotFound",
+			fmt.Sprintf("HTTP listener on port %d not found on gateway %s/%s", *backendRef.Port, gateway.Namespace, gateway.Name),
+		)
+		return nil
+	}
+
+	weight := uint32(1)
+	if backendRef.Weight != nil {
+		weight = uint32(*backendRef.Weight)
+	}
+
+	// A backend with a weight of 0 must not receive any connection.
+	if weight == 0 {
+		return nil
+	}
+
+	return &ir.RouteDestination{
+		InternalListener: httpListener.Name,
+		Weight:           weight,
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// RouteReasonLimitExceeded is the reason of the Accepted condition of the
+// parentRefs of the routes exceeding the translation limits.
+const RouteReasonLimitExceeded v1beta1.RouteConditionReason = "LimitExceeded"
+
+// translationLimits tracks the resources translated against the limits of the
+// translator. A nil translationLimits doesn't limit anything.
+type translationLimits struct {
+	limits *v1alpha1.TranslationLimits
+	// routes holds the routes attached to each Gateway, by IR key.
+	routes map[string]sets.String
+	// clusters is the number of clusters translated so far.
+	clusters int32
+}
+
+// newTranslationLimits returns the tracker of the provided limits, or nil if
+// unset.
+func newTranslationLimits(limits *v1alpha1.TranslationLimits) *translationLimits {
+	if limits == nil {
+		return nil
+	}
+	return &translationLimits{
+		limits: limits,
+		routes: make(map[string]sets.String),
+	}
+}
+
+// admitHTTPRoute returns an error if the HTTPRoute has more filters than
+// allowed, or if attaching it to the Gateways of the parentRef exceeds the
+// limits.
+func (l *translationLimits) admitHTTPRoute(httpRoute *HTTPRouteContext, parentRef *RouteParentContext) error {
+	if l == nil {
+		return nil
+	}
+
+	if maxFilters := l.limits.MaxFiltersPerRoute; maxFilters != nil {
+		var filters int
+		for _, rule := range httpRoute.Spec.Rules {
+			filters += len(rule.Filters)
+		}
+		if filters > int(*maxFilters) {
+			return fmt.Errorf("the route has %d filters, more than the maximum of %d filters per route", filters, *maxFilters)
+		}
+	}
+
+	return l.admitRoute(httpRoute, parentRef)
+}
+
+// admitRoute returns an error if attaching the route to the Gateways of the
+// parentRef exceeds the maximum number of routes per Gateway, or records the
+// route otherwise.
+func (l *translationLimits) admitRoute(route RouteContext, parentRef *RouteParentContext) error {
+	if l == nil || l.limits.MaxRoutesPerGateway == nil {
+		return nil
+	}
+
+	maxRoutes := int(*l.limits.MaxRoutesPerGateway)
+	routeKey := fmt.Sprintf("%s/%s/%s", route.GetRouteType(), route.GetNamespace(), route.GetName())
+	for _, listener := range parentRef.listeners {
+		irKey := irStringKey(listener.gateway)
+		if routes := l.routes[irKey]; !routes.Has(routeKey) && routes.Len() >= maxRoutes {
+			return fmt.Errorf("gateway %s/%s has reached the maximum of %d routes",
+				listener.gateway.Namespace, listener.gateway.Name, maxRoutes)
+		}
+	}
+
+	for _, listener := range parentRef.listeners {
+		irKey := irStringKey(listener.gateway)
+		if l.routes[irKey] == nil {
+			l.routes[irKey] = sets.NewString()
+		}
+		l.routes[irKey].Insert(routeKey)
+	}
+	return nil
+}
+
+// admitClusters returns an error if translating the provided number of
+// clusters exceeds the maximum number of clusters, or records them otherwise.
+func (l *translationLimits) admitClusters(clusters int) error {
+	if l == nil || l.limits.MaxClusters == nil {
+		return nil
+	}
+
+	if l.clusters+int32(clusters) > *l.limits.MaxClusters {
+		return fmt.Errorf("the maximum of %d clusters has been reached", *l.limits.MaxClusters)
+	}
+	l.clusters += int32(clusters)
+	return nil
+}
+
+// httpRouteClusters returns the number of clusters translated for the
+// provided IR routes, one per route forwarding requests to backends or to the
+// hosts they are addressed to, plus the ones of the mirrors of the requests.
+func httpRouteClusters(routes []*ir.HTTPRoute) int {
+	var clusters int
+	for _, route := range routes {
+		if len(route.Destinations) > 0 || route.DynamicForwardProxy != nil {
+			clusters++
+			for _, mirror := range route.Mirrors {
+				clusters++
+				// The tagged mirrored requests go through a cluster of
+				// their own, forwarding them to the mirror cluster.
+				if mirror.ShadowHeader != "" {
+					clusters++
+				}
+			}
+		}
+	}
+	return clusters
+}
+
+// tcpRouteClusters returns the number of clusters translated for the
+// provided destinations of a TCP listener, one per destination, and at least
+// one.
+func tcpRouteClusters(destinations []*ir.RouteDestination) int {
+	if len(destinations) <= 1 {
+		return 1
+	}
+	return len(destinations)
+}
@@ -0,0 +1,152 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+// attachedRoutesResources returns the resources of gateway-1, allowing the
+// routes of all namespaces, and of the two HTTPRoutes attached to it.
+func attachedRoutesResources(t *testing.T) *Resources {
+	resources := &Resources{}
+	mustUnmarshal(t, attachedRoutesYAML, resources)
+	resources.Namespaces = append(resources.Namespaces, &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+	})
+	resources.Services = append(resources.Services, &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "service-1"},
+		Spec: v1.ServiceSpec{
+			ClusterIP: "7.7.7.7",
+			Ports:     []v1.ServicePort{{Port: 8080}},
+		},
+	})
+	return resources
+}
+
+const attachedRoutesYAML = `
+gateways:
+  - apiVersion: gateway.networking.k8s.io/v1beta1
+    kind: Gateway
+    metadata:
+      namespace: envoy-gateway
+      name: gateway-1
+    spec:
+      gatewayClassName: envoy-gateway-class
+      listeners:
+        - name: http
+          protocol: HTTP
+          port: 80
+          allowedRoutes:
+            namespaces:
+              from: All
+httpRoutes:
+  - apiVersion: gateway.networking.k8s.io/v1beta1
+    kind: HTTPRoute
+    metadata:
+      namespace: default
+      name: httproute-1
+    spec:
+      parentRefs:
+        - namespace: envoy-gateway
+          name: gateway-1
+      rules:
+        - matches:
+            - path:
+                value: "/1"
+          backendRefs:
+            - name: service-1
+              port: 8080
+  - apiVersion: gateway.networking.k8s.io/v1beta1
+    kind: HTTPRoute
+    metadata:
+      namespace: default
+      name: httproute-2
+    spec:
+      parentRefs:
+        - namespace: envoy-gateway
+          name: gateway-1
+      rules:
+        - matches:
+            - path:
+                value: "/2"
+          filters:
+            - type: RequestHeaderModifier
+              requestHeaderModifier:
+                remove:
+                  - x-debug
+          backendRefs:
+            - name: service-1
+              port: 8080
+`
+
+func TestTranslationLimits(t *testing.T) {
+	testCases := []struct {
+		name   string
+		limits *v1alpha1.TranslationLimits
+		// reason is the reason of the Accepted condition of httproute-2.
+		reason v1beta1.RouteConditionReason
+	}{
+		{
+			name:   "no limits",
+			reason: v1beta1.RouteReasonAccepted,
+		},
+		{
+			name:   "max routes per gateway",
+			limits: &v1alpha1.TranslationLimits{MaxRoutesPerGateway: pointer.Int32(1)},
+			reason: RouteReasonLimitExceeded,
+		},
+		{
+			name:   "max filters per route",
+			limits: &v1alpha1.TranslationLimits{MaxFiltersPerRoute: pointer.Int32(0)},
+			reason: RouteReasonLimitExceeded,
+		},
+		{
+			name:   "max clusters",
+			limits: &v1alpha1.TranslationLimits{MaxClusters: pointer.Int32(1)},
+			reason: RouteReasonLimitExceeded,
+		},
+		{
+			name: "limits not reached",
+			limits: &v1alpha1.TranslationLimits{
+				MaxRoutesPerGateway: pointer.Int32(2),
+				MaxFiltersPerRoute:  pointer.Int32(1),
+				MaxClusters:         pointer.Int32(2),
+			},
+			reason: v1beta1.RouteReasonAccepted,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			translator := &Translator{GatewayClassName: "envoy-gateway-class", Limits: tc.limits}
+			result := translator.Translate(attachedRoutesResources(t))
+
+			require.Len(t, result.HTTPRoutes, 2)
+			for i, want := range []v1beta1.RouteConditionReason{v1beta1.RouteReasonAccepted, tc.reason} {
+				conditions := result.HTTPRoutes[i].Status.Parents[0].Conditions
+				require.Len(t, conditions, 1)
+				require.Equal(t, string(want), conditions[0].Reason, result.HTTPRoutes[i].Name)
+			}
+
+			routes := result.XdsIR["envoy-gateway-gateway-1"].HTTP[0].Routes
+			if tc.reason == RouteReasonLimitExceeded {
+				require.Len(t, routes, 1)
+			} else {
+				require.Len(t, routes, 2)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"encoding/json"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+const (
+	// ListenerTLSAnnotation is the Gateway annotation recording the TLS
+	// configuration served by each listener terminating TLS, as a JSON object
+	// of ListenerTLS keyed by listener name.
+	ListenerTLSAnnotation = "gateway.envoyproxy.io/listener-tls"
+
+	// listenerMinTLSVersion is the minimum TLS version accepted by the
+	// listeners. The listeners don't configure one, so the Envoy default for
+	// downstream connections applies.
+	listenerMinTLSVersion = "1.2"
+)
+
+// ListenerTLS describes the TLS configuration served by a listener.
+type ListenerTLS struct {
+	// CertificateRef is the namespace and name of the Secret holding the
+	// served certificate, unset for a self-signed certificate.
+	CertificateRef string `json:"certificateRef,omitempty"`
+	// SubjectAltNames are the DNS names, IP addresses, email addresses and
+	// URIs of the served leaf certificate.
+	SubjectAltNames []string `json:"subjectAltNames,omitempty"`
+	// NotAfter is the RFC 3339 time the served leaf certificate expires at.
+	NotAfter string `json:"notAfter"`
+	// MinVersion is the minimum TLS version accepted by the listener.
+	MinVersion string `json:"minVersion"`
+	// SelfSigned is set if the listener serves a self-signed certificate
+	// because the referenced Secret does not exist.
+	SelfSigned bool `json:"selfSigned,omitempty"`
+}
+
+// recordListenerTLS sets the ListenerTLSAnnotation of the provided Gateways
+// based on the certificates served by their listeners.
+func recordListenerTLS(gateways []*GatewayContext) {
+	for _, gateway := range gateways {
+		listeners := make(map[v1beta1.SectionName]ListenerTLS, len(gateway.listeners))
+		for _, listener := range gateway.listeners {
+			if listener.tlsSecret == nil {
+				continue
+			}
+			if listenerTLS, ok := listenerTLSFor(listener.tlsSecret, listener.selfSignedCertificate); ok {
+				listeners[listener.Name] = listenerTLS
+			}
+		}
+
+		// Marshaling maps of strings and structs of strings can't fail, and
+		// keys are sorted so that the annotation is stable.
+		value, _ := json.Marshal(listeners)
+		if gateway.Annotations == nil {
+			gateway.Annotations = make(map[string]string)
+		}
+		gateway.Annotations[ListenerTLSAnnotation] = string(value)
+	}
+}
+
+// listenerTLSFor returns the TLS configuration served with the certificate of
+// the provided Secret, or false if the certificate can't be parsed.
+func listenerTLSFor(secret *v1.Secret, selfSigned bool) (ListenerTLS, bool) {
+	certs, err := parseCertificateChain(secret.Data[v1.TLSCertKey])
+	if err != nil {
+		return ListenerTLS{}, false
+	}
+	leaf := certs[0]
+
+	listenerTLS := ListenerTLS{
+		NotAfter:   leaf.NotAfter.UTC().Format(time.RFC3339),
+		MinVersion: listenerMinTLSVersion,
+		SelfSigned: selfSigned,
+	}
+	if !selfSigned {
+		listenerTLS.CertificateRef = secret.Namespace + "/" + secret.Name
+	}
+	listenerTLS.SubjectAltNames = append(listenerTLS.SubjectAltNames, leaf.DNSNames...)
+	for _, ip := range leaf.IPAddresses {
+		listenerTLS.SubjectAltNames = append(listenerTLS.SubjectAltNames, ip.String())
+	}
+	listenerTLS.SubjectAltNames = append(listenerTLS.SubjectAltNames, leaf.EmailAddresses...)
+	for _, uri := range leaf.URIs {
+		listenerTLS.SubjectAltNames = append(listenerTLS.SubjectAltNames, uri.String())
+	}
+	return listenerTLS, true
+}
@@ -0,0 +1,101 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/crypto"
+)
+
+func TestRecordListenerTLS(t *testing.T) {
+	notAfter := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	cert, key, err := crypto.GenerateSelfSignedCert("www.example.com", []string{"www.example.com"}, notAfter)
+	require.NoError(t, err)
+
+	tlsResources := func(t *testing.T, secretName string) *Resources {
+		resources := attachedRoutesResources(t)
+		hostname := v1beta1.Hostname("www.example.com")
+		listener := &resources.Gateways[0].Spec.Listeners[0]
+		listener.Name = "https"
+		listener.Protocol = v1beta1.HTTPSProtocolType
+		listener.Port = 443
+		listener.Hostname = &hostname
+		listener.TLS = &v1beta1.GatewayTLSConfig{
+			CertificateRefs: []v1beta1.SecretObjectReference{{Name: v1beta1.ObjectName(secretName)}},
+		}
+		resources.Secrets = append(resources.Secrets, &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "envoy-gateway", Name: "tls-secret"},
+			Type:       v1.SecretTypeTLS,
+			Data: map[string][]byte{
+				v1.TLSCertKey:       cert,
+				v1.TLSPrivateKeyKey: key,
+			},
+		})
+		return resources
+	}
+	listenerTLS := func(t *testing.T, gateway *v1beta1.Gateway) map[string]ListenerTLS {
+		value, ok := gateway.Annotations[ListenerTLSAnnotation]
+		require.True(t, ok)
+		listeners := make(map[string]ListenerTLS)
+		require.NoError(t, json.Unmarshal([]byte(value), &listeners))
+		return listeners
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		translator := &Translator{GatewayClassName: "envoy-gateway-class"}
+		result := translator.Translate(tlsResources(t, "tls-secret"))
+
+		require.Len(t, result.Gateways, 1)
+		require.NotContains(t, result.Gateways[0].Annotations, ListenerTLSAnnotation)
+	})
+
+	t.Run("certificate secret", func(t *testing.T) {
+		translator := &Translator{GatewayClassName: "envoy-gateway-class", RecordListenerTLS: true}
+		result := translator.Translate(tlsResources(t, "tls-secret"))
+
+		require.Len(t, result.Gateways, 1)
+		require.Equal(t, map[string]ListenerTLS{
+			"https": {
+				CertificateRef:  "envoy-gateway/tls-secret",
+				SubjectAltNames: []string{"www.example.com"},
+				NotAfter:        notAfter.Format(time.RFC3339),
+				MinVersion:      "1.2",
+			},
+		}, listenerTLS(t, result.Gateways[0]))
+	})
+
+	t.Run("self-signed certificate", func(t *testing.T) {
+		translator := &Translator{
+			GatewayClassName:       "envoy-gateway-class",
+			RecordListenerTLS:      true,
+			SelfSignedCertificates: NewSelfSignedCertificates(),
+		}
+		result := translator.Translate(tlsResources(t, "missing-secret"))
+
+		require.Len(t, result.Gateways, 1)
+		listeners := listenerTLS(t, result.Gateways[0])
+		require.Contains(t, listeners, "https")
+		require.True(t, listeners["https"].SelfSigned)
+		require.Empty(t, listeners["https"].CertificateRef)
+		require.Equal(t, []string{"www.example.com"}, listeners["https"].SubjectAltNames)
+	})
+
+	t.Run("invalid certificate secret", func(t *testing.T) {
+		translator := &Translator{GatewayClassName: "envoy-gateway-class", RecordListenerTLS: true}
+		result := translator.Translate(tlsResources(t, "missing-secret"))
+
+		require.Len(t, result.Gateways, 1)
+		require.Empty(t, listenerTLS(t, result.Gateways[0]))
+	})
+}
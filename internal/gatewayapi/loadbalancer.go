@@ -0,0 +1,91 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+	"math/big"
+
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// buildLoadBalancer returns the load balancer of the BackendTrafficPolicy, or
+// nil if the requests are balanced round-robin. An error is returned if the
+// consistent hashing parameters are invalid.
+func buildLoadBalancer(policy *v1alpha1.BackendTrafficPolicy) (*ir.LoadBalancer, error) {
+	if policy == nil || policy.Spec.LoadBalancer == nil {
+		return nil, nil
+	}
+
+	lb := &ir.LoadBalancer{}
+	switch policy.Spec.LoadBalancer.Type {
+	case v1alpha1.RoundRobinLoadBalancerType:
+		return nil, nil
+	case v1alpha1.LeastRequestLoadBalancerType:
+		lb.Type = ir.LeastRequestLoadBalancer
+	case v1alpha1.RandomLoadBalancerType:
+		lb.Type = ir.RandomLoadBalancer
+	case v1alpha1.RingHashLoadBalancerType:
+		lb.Type = ir.RingHashLoadBalancer
+	case v1alpha1.MaglevLoadBalancerType:
+		lb.Type = ir.MaglevLoadBalancer
+	default:
+		return nil, fmt.Errorf("unsupported load balancer type %s", policy.Spec.LoadBalancer.Type)
+	}
+
+	hash := policy.Spec.LoadBalancer.ConsistentHash
+	if hash == nil || !lb.IsConsistentHash() {
+		return lb, nil
+	}
+	if hash.Header != nil {
+		if !isValidHeaderName(*hash.Header) {
+			return nil, fmt.Errorf("the hash header %q is not a valid header name", *hash.Header)
+		}
+		lb.HashHeader = *hash.Header
+	}
+
+	switch lb.Type {
+	case ir.MaglevLoadBalancer:
+		// Envoy rejects the Maglev tables whose size isn't prime.
+		if hash.TableSize != nil && !new(big.Int).SetUint64(*hash.TableSize).ProbablyPrime(0) {
+			return nil, fmt.Errorf("the table size %d is not a prime number", *hash.TableSize)
+		}
+		lb.TableSize = hash.TableSize
+	case ir.RingHashLoadBalancer:
+		if hash.MinimumRingSize != nil && hash.MaximumRingSize != nil && *hash.MinimumRingSize > *hash.MaximumRingSize {
+			return nil, fmt.Errorf("the minimum ring size %d is greater than the maximum ring size %d",
+				*hash.MinimumRingSize, *hash.MaximumRingSize)
+		}
+		lb.MinimumRingSize = hash.MinimumRingSize
+		lb.MaximumRingSize = hash.MaximumRingSize
+	}
+
+	return lb, nil
+}
+
+// hashesOnBackendRef returns whether the BackendTrafficPolicy consistently
+// hashes the requests to the backends on their backendRefs rather than their
+// addresses.
+func hashesOnBackendRef(policy *v1alpha1.BackendTrafficPolicy) bool {
+	if policy == nil || policy.Spec.LoadBalancer == nil || policy.Spec.LoadBalancer.ConsistentHash == nil {
+		return false
+	}
+	key := policy.Spec.LoadBalancer.ConsistentHash.EndpointHashKey
+	return key != nil && *key == v1alpha1.BackendRefEndpointHashKeyType
+}
+
+// backendRefHashKey returns the hash key of the backend referenced by the
+// backendRef of the route, made of its namespace, name and port.
+func backendRefHashKey(backendRef v1beta1.BackendRef, routeNamespace string) string {
+	var port v1beta1.PortNumber
+	if backendRef.Port != nil {
+		port = *backendRef.Port
+	}
+	return fmt.Sprintf("%s/%s:%d", NamespaceDerefOr(backendRef.Namespace, routeNamespace), backendRef.Name, port)
+}
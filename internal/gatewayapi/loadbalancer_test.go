@@ -0,0 +1,114 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func TestBuildLoadBalancer(t *testing.T) {
+	testCases := []struct {
+		name         string
+		loadBalancer *v1alpha1.LoadBalancer
+		want         *ir.LoadBalancer
+		wantErr      string
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name:         "round robin",
+			loadBalancer: &v1alpha1.LoadBalancer{Type: v1alpha1.RoundRobinLoadBalancerType},
+		},
+		{
+			name:         "least request",
+			loadBalancer: &v1alpha1.LoadBalancer{Type: v1alpha1.LeastRequestLoadBalancerType},
+			want:         &ir.LoadBalancer{Type: ir.LeastRequestLoadBalancer},
+		},
+		{
+			name: "consistent hash ignored",
+			loadBalancer: &v1alpha1.LoadBalancer{
+				Type:           v1alpha1.RandomLoadBalancerType,
+				ConsistentHash: &v1alpha1.ConsistentHash{Header: StringPtr("x-user-id")},
+			},
+			want: &ir.LoadBalancer{Type: ir.RandomLoadBalancer},
+		},
+		{
+			name: "maglev",
+			loadBalancer: &v1alpha1.LoadBalancer{
+				Type: v1alpha1.MaglevLoadBalancerType,
+				ConsistentHash: &v1alpha1.ConsistentHash{
+					Header:    StringPtr("x-user-id"),
+					TableSize: Uint64Ptr(65537),
+				},
+			},
+			want: &ir.LoadBalancer{Type: ir.MaglevLoadBalancer, HashHeader: "x-user-id", TableSize: Uint64Ptr(65537)},
+		},
+		{
+			name: "maglev table size not prime",
+			loadBalancer: &v1alpha1.LoadBalancer{
+				Type:           v1alpha1.MaglevLoadBalancerType,
+				ConsistentHash: &v1alpha1.ConsistentHash{TableSize: Uint64Ptr(65536)},
+			},
+			wantErr: "the table size 65536 is not a prime number",
+		},
+		{
+			name: "invalid hash header",
+			loadBalancer: &v1alpha1.LoadBalancer{
+				Type:           v1alpha1.MaglevLoadBalancerType,
+				ConsistentHash: &v1alpha1.ConsistentHash{Header: StringPtr("x user")},
+			},
+			wantErr: `the hash header "x user" is not a valid header name`,
+		},
+		{
+			name: "ring hash",
+			loadBalancer: &v1alpha1.LoadBalancer{
+				Type: v1alpha1.RingHashLoadBalancerType,
+				ConsistentHash: &v1alpha1.ConsistentHash{
+					MinimumRingSize: Uint64Ptr(1024),
+					MaximumRingSize: Uint64Ptr(8192),
+				},
+			},
+			want: &ir.LoadBalancer{
+				Type:            ir.RingHashLoadBalancer,
+				MinimumRingSize: Uint64Ptr(1024),
+				MaximumRingSize: Uint64Ptr(8192),
+			},
+		},
+		{
+			name: "ring hash minimum above maximum",
+			loadBalancer: &v1alpha1.LoadBalancer{
+				Type: v1alpha1.RingHashLoadBalancerType,
+				ConsistentHash: &v1alpha1.ConsistentHash{
+					MinimumRingSize: Uint64Ptr(8192),
+					MaximumRingSize: Uint64Ptr(1024),
+				},
+			},
+			wantErr: "the minimum ring size 8192 is greater than the maximum ring size 1024",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			policy := &v1alpha1.BackendTrafficPolicy{
+				Spec: v1alpha1.BackendTrafficPolicySpec{LoadBalancer: tc.loadBalancer},
+			}
+			lb, err := buildLoadBalancer(policy)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, lb)
+		})
+	}
+}
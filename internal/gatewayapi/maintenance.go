@@ -0,0 +1,54 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// GatewayConditionMaintenance is the condition of the Gateways put into
+	// maintenance mode by their ClientTrafficPolicy.
+	GatewayConditionMaintenance v1beta1.GatewayConditionType = "Maintenance"
+	// GatewayReasonMaintenanceEnabled is the reason of the Maintenance
+	// condition of the Gateways in maintenance mode.
+	GatewayReasonMaintenanceEnabled v1beta1.GatewayConditionReason = "MaintenanceEnabled"
+)
+
+// irMaintenance returns the maintenance mode of the listeners of the Gateway
+// targeted by the provided policy, or nil if the policy does not enable it.
+func irMaintenance(policy *v1alpha1.ClientTrafficPolicy) *ir.Maintenance {
+	if policy == nil || policy.Spec.Maintenance == nil || !policy.Spec.Maintenance.Enabled {
+		return nil
+	}
+	maintenance := &ir.Maintenance{Body: policy.Spec.Maintenance.Body}
+	if policy.Spec.Maintenance.ContentType != nil {
+		maintenance.ContentType = *policy.Spec.Maintenance.ContentType
+	}
+	return maintenance
+}
+
+// setMaintenanceCondition sets the Maintenance condition of the Gateway if
+// the provided policy puts it into maintenance mode, and removes it otherwise.
+func (g *GatewayContext) setMaintenanceCondition(policy *v1alpha1.ClientTrafficPolicy) {
+	if irMaintenance(policy) == nil {
+		meta.RemoveStatusCondition(&g.Status.Conditions, string(GatewayConditionMaintenance))
+		return
+	}
+	meta.SetStatusCondition(&g.Status.Conditions, metav1.Condition{
+		Type:               string(GatewayConditionMaintenance),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(GatewayReasonMaintenanceEnabled),
+		Message:            "The Gateway is in maintenance mode, the requests to its HTTP and HTTPS listeners receive a 503 response",
+		ObservedGeneration: g.Generation,
+		LastTransitionTime: conditionTime(g.conditionTime),
+	})
+}
@@ -0,0 +1,74 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// The functions of this file implement the matching rules of the Gateway API
+// the translator relies on. They are deterministic, don't depend on the state
+// of the translator, and accept any input, so that they can be fuzzed.
+
+// IntersectHostnames returns the hostnames matched by both the hostnames of a
+// route and the hostname of a listener, an empty listener hostname matching
+// any hostname. A route without hostnames matches the listener hostname, or
+// any hostname ("*") if the listener hostname is empty.
+func IntersectHostnames(routeHostnames []string, listenerHostname string) []string {
+	if listenerHostname == "" {
+		return computeHosts(routeHostnames, nil)
+	}
+	hostname := v1beta1.Hostname(listenerHostname)
+	return computeHosts(routeHostnames, &hostname)
+}
+
+// ParentRefMatchesListener returns whether parentRef references the listener
+// with the given name and port of the gateway, i.e. whether it references the
+// gateway, and its section name and port, if specified, are the ones of the
+// listener.
+func ParentRefMatchesListener(parentRef v1beta1.ParentReference, gateway types.NamespacedName, listenerName v1beta1.SectionName, listenerPort v1beta1.PortNumber) bool {
+	if !IsRefToGateway(parentRef, gateway) {
+		return false
+	}
+	if parentRef.SectionName != nil && *parentRef.SectionName != listenerName {
+		return false
+	}
+	if parentRef.Port != nil && *parentRef.Port != listenerPort {
+		return false
+	}
+	return true
+}
+
+// RoutePrecedenceLess returns whether route a has a lower precedence than
+// route b, as defined in the Gateway API spec: the route with the longest path
+// match, and then with the most header matches, and then with the most query
+// param matches takes precedence. A nil route has the lowest precedence.
+// https://gateway-api.sigs.k8s.io/references/spec/#gateway.networking.k8s.io/v1beta1.HTTPRouteRule
+func RoutePrecedenceLess(a, b *ir.HTTPRoute) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+
+	// 1. Sort based on characters in a matching path.
+	pCountA := pathMatchCount(a.PathMatch)
+	pCountB := pathMatchCount(b.PathMatch)
+	if pCountA != pCountB {
+		return pCountA < pCountB
+	}
+
+	// 2. Sort based on the number of Header matches.
+	hCountA := len(a.HeaderMatches)
+	hCountB := len(b.HeaderMatches)
+	if hCountA != hCountB {
+		return hCountA < hCountB
+	}
+
+	// 3. Sort based on the number of Query param matches.
+	return len(a.QueryParamMatches) < len(b.QueryParamMatches)
+}
@@ -0,0 +1,120 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func FuzzIntersectHostnames(f *testing.F) {
+	f.Add("foo.example.com", "*.example.com", "")
+	f.Add("foo.example.com", "*.example.com", "*.example.com")
+	f.Add("*.example.com", "", "bar.example.com")
+	f.Add("*", "example.com", "*")
+	f.Add("", "", "*.")
+	f.Add("*.", ".", "*")
+	f.Fuzz(func(t *testing.T, routeHostname1, routeHostname2, listenerHostname string) {
+		routeHostnames := []string{routeHostname1, routeHostname2}
+		hostnames := IntersectHostnames(routeHostnames, listenerHostname)
+
+		require.Equal(t, hostnames, IntersectHostnames(routeHostnames, listenerHostname))
+		require.LessOrEqual(t, len(hostnames), len(routeHostnames))
+		for _, hostname := range hostnames {
+			require.True(t, hostname == listenerHostname || slices.Contains(routeHostnames, hostname),
+				"hostname %q is neither the listener hostname nor a route hostname", hostname)
+		}
+
+		hostnames = IntersectHostnames(nil, listenerHostname)
+		require.Len(t, hostnames, 1)
+		if listenerHostname == "" {
+			require.Equal(t, "*", hostnames[0])
+		} else {
+			require.Equal(t, listenerHostname, hostnames[0])
+		}
+	})
+}
+
+func FuzzParentRefMatchesListener(f *testing.F) {
+	f.Add(v1beta1.GroupName, KindGateway, "envoy-gateway", "gateway-1", "http", int32(80), "envoy-gateway", "gateway-1", "http", int32(80))
+	f.Add("", "", "", "gateway-1", "", int32(0), "envoy-gateway", "gateway-1", "https", int32(443))
+	f.Add("example.com", "Service", "default", "gateway-1", "http", int32(-1), "default", "gateway-1", "http", int32(80))
+	f.Fuzz(func(t *testing.T, group, kind, namespace, name, sectionName string, port int32,
+		gatewayNamespace, gatewayName, listenerName string, listenerPort int32) {
+		parentRef := v1beta1.ParentReference{Name: v1beta1.ObjectName(name)}
+		if group != "" {
+			parentRef.Group = GroupPtr(group)
+		}
+		if kind != "" {
+			parentRef.Kind = KindPtr(kind)
+		}
+		if namespace != "" {
+			parentRef.Namespace = NamespacePtr(namespace)
+		}
+		if sectionName != "" {
+			parentRef.SectionName = SectionNamePtr(sectionName)
+		}
+		if port != 0 {
+			parentRef.Port = PortNumPtr(port)
+		}
+		gateway := types.NamespacedName{Namespace: gatewayNamespace, Name: gatewayName}
+
+		matches := ParentRefMatchesListener(parentRef, gateway, v1beta1.SectionName(listenerName), v1beta1.PortNumber(listenerPort))
+		if matches {
+			require.True(t, IsRefToGateway(parentRef, gateway))
+			require.Equal(t, gatewayName, name)
+		}
+		if IsRefToGateway(parentRef, gateway) && parentRef.SectionName == nil && parentRef.Port == nil {
+			require.True(t, matches)
+		}
+	})
+}
+
+func FuzzRoutePrecedenceLess(f *testing.F) {
+	f.Add("/foo", uint8(1), uint8(0), "/", uint8(0), uint8(2), false)
+	f.Add("/foo", uint8(0), uint8(0), "/foo", uint8(0), uint8(0), false)
+	f.Add("", uint8(3), uint8(3), "/bar", uint8(3), uint8(3), true)
+	f.Fuzz(func(t *testing.T, pathA string, headersA, queryParamsA uint8,
+		pathB string, headersB, queryParamsB uint8, nilRoute bool) {
+		newRoute := func(path string, headers, queryParams uint8) *ir.HTTPRoute {
+			route := &ir.HTTPRoute{
+				HeaderMatches:     make([]*ir.StringMatch, headers%8),
+				QueryParamMatches: make([]*ir.StringMatch, queryParams%8),
+			}
+			switch {
+			case path == "":
+			case strings.HasPrefix(path, "^"):
+				route.PathMatch = &ir.StringMatch{SafeRegex: &path}
+			case strings.HasSuffix(path, "/"):
+				route.PathMatch = &ir.StringMatch{Prefix: &path}
+			default:
+				route.PathMatch = &ir.StringMatch{Exact: &path}
+			}
+			return route
+		}
+		a := newRoute(pathA, headersA, queryParamsA)
+		b := newRoute(pathB, headersB, queryParamsB)
+		if nilRoute {
+			b = nil
+		}
+
+		require.False(t, RoutePrecedenceLess(a, a))
+		require.False(t, RoutePrecedenceLess(a, b) && RoutePrecedenceLess(b, a))
+
+		routes := XdsIRRoutes{a, b, nil}
+		sort.Sort(sort.Reverse(routes))
+		require.True(t, sort.IsSorted(sort.Reverse(routes)))
+		require.Nil(t, routes[len(routes)-1])
+	})
+}
@@ -0,0 +1,161 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// MeshIRKey is the key of the IR translated from the HTTPRoutes attached to
+// Services in mesh mode. It is also used as the xDS node cluster of the mesh
+// proxies.
+const MeshIRKey = "mesh"
+
+// meshContext holds the Gateway synthesized for the Services the HTTPRoutes
+// attach to in mesh mode, with one listener per Service port. The synthesized
+// Gateway has no namespace, so that its IR key can't collide with the one of a
+// Gateway.
+type meshContext struct {
+	*GatewayContext
+}
+
+func newMeshContext() *meshContext {
+	return &meshContext{
+		GatewayContext: &GatewayContext{
+			Gateway: &v1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: MeshIRKey},
+			},
+		},
+	}
+}
+
+// IsRefToService returns whether the provided parent ref is a reference to a
+// Service, as used by the routes of the mesh (GAMMA).
+func IsRefToService(parentRef v1beta1.ParentReference) bool {
+	if parentRef.Group == nil || (*parentRef.Group != "" && *parentRef.Group != "core") {
+		return false
+	}
+	return parentRef.Kind != nil && *parentRef.Kind == KindService
+}
+
+// processParentRefs selects the listeners of the Services referenced by the
+// parent refs of the route, and sets the conditions of the parent refs. It
+// returns whether the route references at least one Service.
+func (m *meshContext) processParentRefs(routeContext RouteContext, resources *Resources, xdsIR XdsIRMap) bool {
+	var relevantRoute bool
+
+	for _, parentRef := range routeContext.GetParentReferences() {
+		if !IsRefToService(parentRef) {
+			continue
+		}
+		relevantRoute = true
+
+		parentRefCtx := routeContext.GetRouteParentContext(parentRef)
+		// Reset conditions since they will be recomputed during translation
+		parentRefCtx.ResetConditions(routeContext)
+
+		service := resources.GetService(NamespaceDerefOr(parentRef.Namespace, routeContext.GetNamespace()), string(parentRef.Name))
+		if service == nil {
+			parentRefCtx.SetCondition(routeContext,
+				v1beta1.RouteConditionAccepted,
+				metav1.ConditionFalse,
+				"NoMatchingParent",
+				fmt.Sprintf("Service %s not found", parentRef.Name),
+			)
+			continue
+		}
+
+		var listeners []*ListenerContext
+		for _, port := range service.Spec.Ports {
+			if port.Protocol != "" && port.Protocol != v1.ProtocolTCP {
+				continue
+			}
+			if parentRef.Port != nil && int32(*parentRef.Port) != port.Port {
+				continue
+			}
+			listeners = append(listeners, m.serviceListener(service, port.Port, xdsIR))
+		}
+
+		if len(listeners) == 0 {
+			parentRefCtx.SetCondition(routeContext,
+				v1beta1.RouteConditionAccepted,
+				metav1.ConditionFalse,
+				"NoMatchingParent",
+				"There are no TCP ports of the Service matching the port of this parent ref",
+			)
+			continue
+		}
+
+		parentRefCtx.SetListeners(listeners...)
+
+		parentRefCtx.SetCondition(routeContext,
+			v1beta1.RouteConditionAccepted,
+			metav1.ConditionTrue,
+			v1beta1.RouteReasonAccepted,
+			"Route is accepted",
+		)
+	}
+
+	return relevantRoute
+}
+
+// serviceListener returns the listener of the synthesized Gateway for the
+// provided Service port, adding it along with its IR listener if needed.
+func (m *meshContext) serviceListener(service *v1.Service, port int32, xdsIR XdsIRMap) *ListenerContext {
+	name := v1beta1.SectionName(fmt.Sprintf("%s-%s-%d", service.Namespace, service.Name, port))
+	for _, listener := range m.listeners {
+		if listener.Name == name {
+			return listener
+		}
+	}
+
+	m.Spec.Listeners = append(m.Spec.Listeners, v1beta1.Listener{
+		Name:     name,
+		Port:     v1beta1.PortNumber(port),
+		Protocol: v1beta1.HTTPProtocolType,
+	})
+	listener := m.GetListenerContext(name)
+
+	irKey := irStringKey(m.Gateway)
+	if xdsIR[irKey] == nil {
+		xdsIR[irKey] = &ir.Xds{}
+	}
+	xdsIR[irKey].HTTP = append(xdsIR[irKey].HTTP, &ir.HTTPListener{
+		Name:      irHTTPListenerName(listener),
+		Address:   "0.0.0.0",
+		Port:      uint32(servicePortToContainerPort(port)),
+		Hostnames: serviceHostnames(service, port),
+	})
+
+	return listener
+}
+
+// serviceHostnames returns the authorities the clients of the mesh may use to
+// reach the provided Service port: its DNS names and cluster IP, with and
+// without the port.
+func serviceHostnames(service *v1.Service, port int32) []string {
+	names := []string{
+		service.Name,
+		fmt.Sprintf("%s.%s", service.Name, service.Namespace),
+		fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace),
+		serviceFQDN(service),
+	}
+	if service.Spec.ClusterIP != "" && service.Spec.ClusterIP != v1.ClusterIPNone {
+		names = append(names, service.Spec.ClusterIP)
+	}
+
+	hostnames := make([]string, 0, 2*len(names))
+	for _, name := range names {
+		hostnames = append(hostnames, name, fmt.Sprintf("%s:%d", name, port))
+	}
+	return hostnames
+}
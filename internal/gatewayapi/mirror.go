@@ -0,0 +1,57 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// buildMirror translates a RequestMirror filter into the mirror of the
+// requests to its backendRef, tagging the mirrored requests with the shadow
+// header unless it is empty. It sets error statuses and returns nil if the
+// backendRef is invalid, in which case the requests are not mirrored.
+func buildMirror(filter *v1beta1.HTTPRequestMirrorFilter,
+	shadowHeader string,
+	parentRef *RouteParentContext,
+	httpRoute *HTTPRouteContext,
+	resources *Resources) *ir.Mirror {
+	if filter == nil {
+		return nil
+	}
+
+	backendRef := v1beta1.HTTPBackendRef{
+		BackendRef: v1beta1.BackendRef{BackendObjectReference: filter.BackendRef},
+	}
+	destinations, _ := buildRuleRouteDest(backendRef, parentRef, httpRoute, resources)
+	if len(destinations) == 0 {
+		return nil
+	}
+	return &ir.Mirror{Destinations: destinations, ShadowHeader: shadowHeader}
+}
+
+// buildShadowHeader returns the header tagging the requests mirrored by the
+// routes targeted by the BackendTrafficPolicy, or an empty string if they are
+// not tagged. An error is returned if the header name is invalid.
+func buildShadowHeader(policy *v1alpha1.BackendTrafficPolicy) (string, error) {
+	if policy == nil || policy.Spec.ShadowComparison == nil {
+		return "", nil
+	}
+
+	header := v1alpha1.DefaultShadowHeader
+	if policy.Spec.ShadowComparison.Header != nil {
+		header = *policy.Spec.ShadowComparison.Header
+	}
+	if !isValidHeaderName(header) {
+		return "", fmt.Errorf("the header %q is not a valid header name", header)
+	}
+	return strings.ToLower(header), nil
+}
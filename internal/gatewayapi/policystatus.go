@@ -0,0 +1,198 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+const (
+	// PolicyConditionAccepted is the type of the condition of a policy
+	// describing whether it applies to a resource.
+	PolicyConditionAccepted = "Accepted"
+	// PolicyReasonAccepted is the reason of the Accepted condition of a
+	// policy applying to a resource.
+	PolicyReasonAccepted = "Accepted"
+	// PolicyReasonConflicted is the reason of the Accepted condition of a
+	// policy superseded by an older policy of the same kind on a resource.
+	PolicyReasonConflicted = "Conflicted"
+
+	// maxPolicyAncestors is the maximum number of ancestors listed in the
+	// status of a policy.
+	maxPolicyAncestors = 16
+)
+
+// policyStatus is a policy whose status is computed, along with its target
+// reference and selectors.
+type policyStatus struct {
+	kind      string
+	meta      *metav1.ObjectMeta
+	targetRef *v1alpha2.PolicyTargetReference
+	selectors []v1alpha1.TargetSelector
+	status    *v1alpha1.PolicyStatus
+}
+
+// policyTarget is a resource of the provided Gateway API kind a policy may
+// apply to.
+type policyTarget struct {
+	kind string
+	obj  metav1.Object
+}
+
+// setPolicyStatuses sets the translation result to copies of the policies and
+// traffic shifts, whose status lists the relevant Gateways and HTTPRoutes they
+// apply to.
+func (t *Translator) setPolicyStatuses(result *TranslateResult, resources *Resources, gateways []*GatewayContext, httpRoutes []*HTTPRouteContext) {
+	gatewayTargets := make([]policyTarget, 0, len(gateways))
+	for _, gateway := range gateways {
+		gatewayTargets = append(gatewayTargets, policyTarget{kind: KindGateway, obj: gateway.Gateway})
+	}
+	routeTargets := make([]policyTarget, 0, len(httpRoutes))
+	for _, httpRoute := range httpRoutes {
+		routeTargets = append(routeTargets, policyTarget{kind: KindHTTPRoute, obj: httpRoute.HTTPRoute})
+	}
+
+	var backendTrafficPolicies []policyStatus
+	for _, policy := range resources.BackendTrafficPolicies {
+		policy = policy.DeepCopy()
+		policy.Status = v1alpha1.PolicyStatus{}
+		result.BackendTrafficPolicies = append(result.BackendTrafficPolicies, policy)
+		backendTrafficPolicies = append(backendTrafficPolicies, policyStatus{
+			kind:      v1alpha1.KindBackendTrafficPolicy,
+			meta:      &policy.ObjectMeta,
+			targetRef: policy.Spec.TargetRef,
+			selectors: policy.Spec.TargetSelectors,
+			status:    &policy.Status,
+		})
+	}
+	setPolicyAncestors(backendTrafficPolicies, append(gatewayTargets, routeTargets...), t.conditionTime)
+
+	var clientTrafficPolicies []policyStatus
+	for _, policy := range resources.ClientTrafficPolicies {
+		policy = policy.DeepCopy()
+		policy.Status = v1alpha1.PolicyStatus{}
+		result.ClientTrafficPolicies = append(result.ClientTrafficPolicies, policy)
+		clientTrafficPolicies = append(clientTrafficPolicies, policyStatus{
+			kind:      v1alpha1.KindClientTrafficPolicy,
+			meta:      &policy.ObjectMeta,
+			targetRef: policy.Spec.TargetRef,
+			selectors: policy.Spec.TargetSelectors,
+			status:    &policy.Status,
+		})
+	}
+	setPolicyAncestors(clientTrafficPolicies, gatewayTargets, t.conditionTime)
+
+	var securityPolicies []policyStatus
+	for _, policy := range resources.SecurityPolicies {
+		policy = policy.DeepCopy()
+		policy.Status = v1alpha1.PolicyStatus{}
+		result.SecurityPolicies = append(result.SecurityPolicies, policy)
+		securityPolicies = append(securityPolicies, policyStatus{
+			kind:      v1alpha1.KindSecurityPolicy,
+			meta:      &policy.ObjectMeta,
+			targetRef: policy.Spec.TargetRef,
+			selectors: policy.Spec.TargetSelectors,
+			status:    &policy.Status,
+		})
+	}
+	setPolicyAncestors(securityPolicies, append(gatewayTargets, routeTargets...), t.conditionTime)
+
+	var trafficShifts []policyStatus
+	for _, shift := range resources.TrafficShifts {
+		shift = shift.DeepCopy()
+		shift.Status = v1alpha1.PolicyStatus{}
+		result.TrafficShifts = append(result.TrafficShifts, shift)
+		trafficShifts = append(trafficShifts, policyStatus{
+			kind:      v1alpha1.KindTrafficShift,
+			meta:      &shift.ObjectMeta,
+			targetRef: &shift.Spec.TargetRef,
+			status:    &shift.Status,
+		})
+	}
+	setPolicyAncestors(trafficShifts, routeTargets, t.conditionTime)
+	setTrafficShiftBackendRefsConditions(result.TrafficShifts, httpRoutes)
+}
+
+// setPolicyAncestors adds the targets to the ancestors of the policies of a
+// kind applying to them. The oldest policy applying to a target is accepted,
+// and the other ones are conflicted, following the selection of the policies
+// by the translation.
+func setPolicyAncestors(policies []policyStatus, targets []policyTarget, transitionTime metav1.Time) {
+	if len(policies) == 0 {
+		return
+	}
+
+	var applying []*policyStatus
+	for _, target := range targets {
+		applying = applying[:0]
+		var selected *policyStatus
+		for i := range policies {
+			policy := &policies[i]
+			if !policyTargets(policy.meta.Namespace, policy.targetRef, policy.selectors, target.kind, target.obj) {
+				continue
+			}
+			applying = append(applying, policy)
+			if selected == nil || isOlderPolicy(policy.meta, selected.meta) {
+				selected = policy
+			}
+		}
+
+		for _, policy := range applying {
+			cond := metav1.Condition{
+				Type:               PolicyConditionAccepted,
+				Status:             metav1.ConditionTrue,
+				Reason:             PolicyReasonAccepted,
+				Message:            "Policy has been accepted.",
+				ObservedGeneration: policy.meta.Generation,
+				LastTransitionTime: transitionTime,
+			}
+			if policy != selected {
+				cond.Status = metav1.ConditionFalse
+				cond.Reason = PolicyReasonConflicted
+				cond.Message = fmt.Sprintf("The older %s %s/%s applies to the %s.",
+					selected.kind, selected.meta.Namespace, selected.meta.Name, target.kind)
+			}
+			policy.status.Ancestors = append(policy.status.Ancestors, v1alpha1.PolicyAncestorStatus{
+				AncestorRef: v1beta1.ParentReference{
+					Group:     GroupPtr(v1beta1.GroupName),
+					Kind:      KindPtr(target.kind),
+					Namespace: NamespacePtr(target.obj.GetNamespace()),
+					Name:      v1beta1.ObjectName(target.obj.GetName()),
+				},
+				Conditions: []metav1.Condition{cond},
+			})
+		}
+	}
+
+	for _, policy := range policies {
+		sortPolicyAncestors(policy.status.Ancestors)
+		if len(policy.status.Ancestors) > maxPolicyAncestors {
+			policy.status.Ancestors = policy.status.Ancestors[:maxPolicyAncestors]
+		}
+	}
+}
+
+// sortPolicyAncestors sorts the ancestors of a policy by kind, namespace and
+// name.
+func sortPolicyAncestors(ancestors []v1alpha1.PolicyAncestorStatus) {
+	sort.Slice(ancestors, func(i, j int) bool {
+		a, b := ancestors[i].AncestorRef, ancestors[j].AncestorRef
+		if *a.Kind != *b.Kind {
+			return *a.Kind < *b.Kind
+		}
+		if *a.Namespace != *b.Namespace {
+			return *a.Namespace < *b.Namespace
+		}
+		return a.Name < b.Name
+	})
+}
@@ -0,0 +1,110 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+func TestPolicyTargetSelectors(t *testing.T) {
+	resources := attachedRoutesResources(t)
+	for _, route := range resources.HTTPRoutes {
+		route.Labels = map[string]string{"team": "checkout"}
+	}
+
+	created := metav1.NewTime(time.Unix(100, 0))
+	resources.BackendTrafficPolicies = []*v1alpha1.BackendTrafficPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "selected", CreationTimestamp: created},
+			Spec: v1alpha1.BackendTrafficPolicySpec{
+				TargetSelectors: []v1alpha1.TargetSelector{{
+					Group:       v1beta1.GroupName,
+					Kind:        KindHTTPRoute,
+					MatchLabels: map[string]string{"team": "checkout"},
+				}},
+				ConnectTimeout: &metav1.Duration{Duration: time.Second},
+			},
+		},
+		{
+			// The older policy targeting httproute-1 by name takes precedence.
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "named", CreationTimestamp: metav1.NewTime(time.Unix(10, 0))},
+			Spec: v1alpha1.BackendTrafficPolicySpec{
+				TargetRef: &v1alpha2.PolicyTargetReference{
+					Group: v1beta1.GroupName,
+					Kind:  KindHTTPRoute,
+					Name:  "httproute-1",
+				},
+				ConnectTimeout: &metav1.Duration{Duration: 2 * time.Second},
+			},
+		},
+		{
+			// Policies don't select the resources of other namespaces.
+			ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "other", CreationTimestamp: created},
+			Spec: v1alpha1.BackendTrafficPolicySpec{
+				TargetSelectors: []v1alpha1.TargetSelector{{
+					Group:       v1beta1.GroupName,
+					Kind:        KindHTTPRoute,
+					MatchLabels: map[string]string{"team": "checkout"},
+				}},
+			},
+		},
+	}
+
+	translator := &Translator{GatewayClassName: "envoy-gateway-class"}
+	result := translator.Translate(resources)
+
+	listener := result.XdsIR[IRKey("envoy-gateway", "gateway-1")].GetHTTPListener("envoy-gateway-gateway-1-http")
+	require.NotNil(t, listener)
+	connectTimeouts := map[string]time.Duration{}
+	for _, route := range listener.Routes {
+		require.NotNil(t, route.ConnectTimeout, route.Name)
+		connectTimeouts[route.Name] = route.ConnectTimeout.Duration
+	}
+	require.Equal(t, map[string]time.Duration{
+		"default-httproute-1-rule-0-match-0-*": 2 * time.Second,
+		"default-httproute-2-rule-0-match-0-*": time.Second,
+	}, connectTimeouts)
+
+	type ancestor struct {
+		name   string
+		reason string
+	}
+	ancestors := map[string][]ancestor{}
+	for _, policy := range result.BackendTrafficPolicies {
+		ancestors[policy.Name] = []ancestor{}
+		for _, status := range policy.Status.Ancestors {
+			require.Equal(t, KindHTTPRoute, string(*status.AncestorRef.Kind))
+			require.Len(t, status.Conditions, 1)
+			ancestors[policy.Name] = append(ancestors[policy.Name], ancestor{
+				name:   string(status.AncestorRef.Name),
+				reason: status.Conditions[0].Reason,
+			})
+		}
+	}
+	require.Equal(t, map[string][]ancestor{
+		"selected": {
+			{name: "httproute-1", reason: PolicyReasonConflicted},
+			{name: "httproute-2", reason: PolicyReasonAccepted},
+		},
+		"named": {
+			{name: "httproute-1", reason: PolicyReasonAccepted},
+		},
+		"other": {},
+	}, ancestors)
+
+	// The status is set on copies of the policies.
+	for _, policy := range resources.BackendTrafficPolicies {
+		require.Empty(t, policy.Status.Ancestors)
+	}
+}
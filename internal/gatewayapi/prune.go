@@ -0,0 +1,66 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// pruneListenerStatuses drops the statuses of the listeners that are no longer
+// part of the spec of the provided Gateway, and the repeated statuses of the
+// same listener, so that the translated status only holds the listeners that
+// are translated.
+func pruneListenerStatuses(gateway *v1beta1.Gateway) {
+	names := make(map[v1beta1.SectionName]bool, len(gateway.Spec.Listeners))
+	for _, listener := range gateway.Spec.Listeners {
+		names[listener.Name] = true
+	}
+
+	listeners := make([]v1beta1.ListenerStatus, 0, len(gateway.Status.Listeners))
+	for _, listener := range gateway.Status.Listeners {
+		if !names[listener.Name] {
+			continue
+		}
+		delete(names, listener.Name)
+		listeners = append(listeners, listener)
+	}
+	gateway.Status.Listeners = listeners
+}
+
+// pruneRouteParentStatuses drops the parent statuses written by the provided
+// controller, defaulting to the Envoy Gateway one, for the parentRefs that are
+// no longer part of the spec of a route in the routeNamespace. The parent
+// statuses written by other controllers are kept as is.
+func pruneRouteParentStatuses(parents []v1beta1.RouteParentStatus, parentRefs []v1beta1.ParentReference,
+	routeNamespace, controllerName string) []v1beta1.RouteParentStatus {
+	keys := make(map[parentRefKey]bool, len(parentRefs))
+	for _, parentRef := range parentRefs {
+		keys[newParentRefKey(parentRef, routeNamespace)] = true
+	}
+
+	controller := v1beta1.GatewayController(routeControllerName(controllerName))
+	pruned := make([]v1beta1.RouteParentStatus, 0, len(parents))
+	for _, parent := range parents {
+		if parent.ControllerName == controller {
+			key := newParentRefKey(parent.ParentRef, routeNamespace)
+			if !keys[key] {
+				continue
+			}
+			delete(keys, key)
+		}
+		pruned = append(pruned, parent)
+	}
+	return pruned
+}
+
+// pruneRouteParentStatusesV1Alpha2 is the v1alpha2 version of
+// pruneRouteParentStatuses.
+func pruneRouteParentStatusesV1Alpha2(parents []v1alpha2.RouteParentStatus, parentRefs []v1alpha2.ParentReference,
+	routeNamespace, controllerName string) []v1alpha2.RouteParentStatus {
+	return DowngradeRouteParentStatuses(pruneRouteParentStatuses(UpgradeRouteParentStatuses(parents),
+		UpgradeParentReferences(parentRefs), routeNamespace, controllerName))
+}
@@ -0,0 +1,103 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	egv1alpha1 "github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+func TestPruneListenerStatuses(t *testing.T) {
+	gateway := &v1beta1.Gateway{
+		Spec: v1beta1.GatewaySpec{
+			Listeners: []v1beta1.Listener{{Name: "http"}, {Name: "https"}},
+		},
+		Status: v1beta1.GatewayStatus{
+			Listeners: []v1beta1.ListenerStatus{
+				{Name: "removed", AttachedRoutes: 1},
+				{Name: "https", AttachedRoutes: 2},
+				{Name: "https", AttachedRoutes: 3},
+			},
+		},
+	}
+
+	pruneListenerStatuses(gateway)
+
+	require.Equal(t, []v1beta1.ListenerStatus{{Name: "https", AttachedRoutes: 2}}, gateway.Status.Listeners)
+}
+
+func TestPruneRouteParentStatuses(t *testing.T) {
+	other := v1beta1.GatewayController("example.com/gateway-controller")
+	parents := []v1beta1.RouteParentStatus{
+		{
+			ControllerName: egv1alpha1.GatewayControllerName,
+			ParentRef:      v1beta1.ParentReference{Name: "gateway-1", Namespace: NamespacePtr("default")},
+		},
+		{
+			ControllerName: egv1alpha1.GatewayControllerName,
+			ParentRef:      v1beta1.ParentReference{Name: "gateway-1", SectionName: SectionNamePtr("removed")},
+		},
+		{
+			ControllerName: egv1alpha1.GatewayControllerName,
+			ParentRef:      v1beta1.ParentReference{Name: "removed"},
+		},
+		{
+			ControllerName: other,
+			ParentRef:      v1beta1.ParentReference{Name: "removed"},
+		},
+	}
+	parentRefs := []v1beta1.ParentReference{{Name: "gateway-1"}}
+
+	pruned := pruneRouteParentStatuses(parents, parentRefs, "default", "")
+	require.Equal(t, []v1beta1.RouteParentStatus{parents[0], parents[3]}, pruned)
+
+	// Only the parent statuses of the configured controller are pruned, so
+	// the ones of the Envoy Gateway controller are left alone when another
+	// controller name is configured.
+	pruned = pruneRouteParentStatuses(parents, parentRefs, "default", string(other))
+	require.Equal(t, parents[:3], pruned)
+}
+
+func TestPruneRouteParentStatusesV1Alpha2(t *testing.T) {
+	parents := []v1alpha2.RouteParentStatus{
+		{
+			ControllerName: egv1alpha1.GatewayControllerName,
+			ParentRef:      v1alpha2.ParentReference{Name: "gateway-1", SectionName: SectionNamePtrV1Alpha2("tls")},
+		},
+		{
+			ControllerName: egv1alpha1.GatewayControllerName,
+			ParentRef:      v1alpha2.ParentReference{Name: "gateway-1", SectionName: SectionNamePtrV1Alpha2("tcp")},
+		},
+	}
+	parentRefs := []v1alpha2.ParentReference{{Name: "gateway-1", SectionName: SectionNamePtrV1Alpha2("tls")}}
+
+	pruned := pruneRouteParentStatusesV1Alpha2(parents, parentRefs, "default", "")
+	require.Equal(t, parents[:1], pruned)
+}
+
+func TestTranslatePrunesStaleStatuses(t *testing.T) {
+	resources := attachedRoutesResources(t)
+	resources.Gateways[0].Status.Listeners = []v1beta1.ListenerStatus{{Name: "removed"}}
+	resources.HTTPRoutes[0].Status.Parents = []v1beta1.RouteParentStatus{{
+		ControllerName: egv1alpha1.GatewayControllerName,
+		ParentRef:      v1beta1.ParentReference{Namespace: NamespacePtr("envoy-gateway"), Name: "removed"},
+	}}
+
+	translator := &Translator{GatewayClassName: "envoy-gateway-class"}
+	result := translator.Translate(resources)
+
+	require.Len(t, result.Gateways, 1)
+	require.Len(t, result.Gateways[0].Status.Listeners, 1)
+	require.EqualValues(t, "http", result.Gateways[0].Status.Listeners[0].Name)
+	require.Len(t, result.HTTPRoutes, 2)
+	require.Len(t, result.HTTPRoutes[0].Status.Parents, 1)
+	require.EqualValues(t, "gateway-1", result.HTTPRoutes[0].Status.Parents[0].ParentRef.Name)
+}
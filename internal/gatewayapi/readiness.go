@@ -0,0 +1,108 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+const (
+	// RouteConditionProgrammed is the type of the condition of the
+	// HTTPRoutes reporting whether one of their backends has a ready
+	// endpoint, set if the backend readiness gating is enabled.
+	RouteConditionProgrammed v1beta1.RouteConditionType = "Programmed"
+	// RouteReasonProgrammed is the reason of the Programmed condition of a
+	// route with a ready backend, or without backends.
+	RouteReasonProgrammed v1beta1.RouteConditionReason = "Programmed"
+	// RouteReasonNoReadyBackends is the reason of the Programmed condition
+	// of a route none of whose backends has a ready endpoint.
+	RouteReasonNoReadyBackends v1beta1.RouteConditionReason = "NoReadyBackends"
+)
+
+// setBackendReadinessConditions sets the Programmed condition of the accepted
+// parents of the HTTPRoutes, which is true if at least one of the Services
+// referenced by the backendRefs of the route has a ready endpoint.
+func setBackendReadinessConditions(httpRoutes []*HTTPRouteContext, resources *Resources) {
+	ready := readyServices(resources.EndpointSlices)
+
+	for _, httpRoute := range httpRoutes {
+		var hasBackends, hasReadyBackend bool
+		for _, rule := range httpRoute.Spec.Rules {
+			for _, backendRef := range rule.BackendRefs {
+				if KindDerefOr(backendRef.Kind, KindService) != KindService {
+					continue
+				}
+				hasBackends = true
+				key := types.NamespacedName{
+					Namespace: NamespaceDerefOr(backendRef.Namespace, httpRoute.Namespace),
+					Name:      string(backendRef.Name),
+				}
+				if ready[key] || isExternalNameService(resources.GetService(key.Namespace, key.Name)) {
+					hasReadyBackend = true
+				}
+			}
+		}
+
+		for _, parentRef := range httpRoute.parentRefs {
+			if !parentRef.IsAccepted(httpRoute) {
+				continue
+			}
+			switch {
+			case !hasBackends:
+				parentRef.SetCondition(httpRoute,
+					RouteConditionProgrammed,
+					metav1.ConditionTrue,
+					RouteReasonProgrammed,
+					"Route has no backends.",
+				)
+			case hasReadyBackend:
+				parentRef.SetCondition(httpRoute,
+					RouteConditionProgrammed,
+					metav1.ConditionTrue,
+					RouteReasonProgrammed,
+					"Route has a backend with a ready endpoint.",
+				)
+			default:
+				parentRef.SetCondition(httpRoute,
+					RouteConditionProgrammed,
+					metav1.ConditionFalse,
+					RouteReasonNoReadyBackends,
+					"None of the backends of the route has a ready endpoint.",
+				)
+			}
+		}
+	}
+}
+
+// readyServices returns the Services with a ready endpoint in their
+// EndpointSlices. An endpoint whose readiness is unknown is considered ready,
+// as recommended by the EndpointSlice API.
+func readyServices(slices []*discoveryv1.EndpointSlice) map[types.NamespacedName]bool {
+	ready := make(map[types.NamespacedName]bool)
+	for _, slice := range slices {
+		name := slice.Labels[discoveryv1.LabelServiceName]
+		if name == "" {
+			continue
+		}
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				ready[types.NamespacedName{Namespace: slice.Namespace, Name: name}] = true
+				break
+			}
+		}
+	}
+	return ready
+}
+
+// isExternalNameService returns whether the Service is an ExternalName
+// Service, which has no endpoints and is always considered ready.
+func isExternalNameService(service *v1.Service) bool {
+	return service != nil && service.Spec.Type == v1.ServiceTypeExternalName
+}
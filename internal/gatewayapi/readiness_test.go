@@ -0,0 +1,93 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestBackendReadinessGating(t *testing.T) {
+	endpointSlice := func(ready *bool) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "service-1-abcde",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "service-1"},
+			},
+			Endpoints: []discoveryv1.Endpoint{{
+				Addresses:  []string{"10.0.0.1"},
+				Conditions: discoveryv1.EndpointConditions{Ready: ready},
+			}},
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		gating         bool
+		endpointSlices []*discoveryv1.EndpointSlice
+		// expectedReason is the reason of the Programmed condition of the
+		// routes, or empty if the condition is not set.
+		expectedReason v1beta1.RouteConditionReason
+	}{
+		{
+			name:           "gating disabled",
+			endpointSlices: []*discoveryv1.EndpointSlice{endpointSlice(pointer.Bool(false))},
+		},
+		{
+			name:           "ready endpoint",
+			gating:         true,
+			endpointSlices: []*discoveryv1.EndpointSlice{endpointSlice(pointer.Bool(true))},
+			expectedReason: RouteReasonProgrammed,
+		},
+		{
+			name:           "endpoint with unknown readiness",
+			gating:         true,
+			endpointSlices: []*discoveryv1.EndpointSlice{endpointSlice(nil)},
+			expectedReason: RouteReasonProgrammed,
+		},
+		{
+			name:           "endpoint not ready",
+			gating:         true,
+			endpointSlices: []*discoveryv1.EndpointSlice{endpointSlice(pointer.Bool(false))},
+			expectedReason: RouteReasonNoReadyBackends,
+		},
+		{
+			name:           "no endpoint slices",
+			gating:         true,
+			expectedReason: RouteReasonNoReadyBackends,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			resources := attachedRoutesResources(t)
+			resources.EndpointSlices = tc.endpointSlices
+
+			translator := &Translator{GatewayClassName: "envoy-gateway-class", BackendReadinessGating: tc.gating}
+			result := translator.Translate(resources)
+
+			require.Len(t, result.HTTPRoutes, 2)
+			for _, httpRoute := range result.HTTPRoutes {
+				require.Len(t, httpRoute.Status.Parents, 1)
+				cond := meta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(RouteConditionProgrammed))
+				if tc.expectedReason == "" {
+					require.Nil(t, cond)
+					continue
+				}
+				require.NotNil(t, cond)
+				require.Equal(t, string(tc.expectedReason), cond.Reason)
+			}
+		})
+	}
+}
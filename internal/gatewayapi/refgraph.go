@@ -0,0 +1,332 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+// ReferenceGraphHandlerPath is the path the reference graph is served on, next
+// to the metrics of Envoy Gateway.
+const ReferenceGraphHandlerPath = "/debug/gatewayapi/references"
+
+// ObjectRef identifies an object of the reference graph. The ObjectRef of a
+// ReferenceGrant without name stands for all the ReferenceGrants of its
+// namespace, which may permit the references from other namespaces.
+type ObjectRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
+func (o ObjectRef) String() string {
+	name := o.Name
+	if name == "" {
+		name = "*"
+	}
+	return fmt.Sprintf("%s/%s/%s", o.Kind, o.Namespace, name)
+}
+
+// Reference is an edge of the reference graph.
+type Reference struct {
+	From ObjectRef `json:"from"`
+	To   ObjectRef `json:"to"`
+}
+
+// ReferenceGraph is the graph of the references between the Gateways, the
+// routes, the policies, and the Services, Secrets and ReferenceGrants they
+// depend on. The references are recorded whether the referenced objects exist
+// or not, since creating a missing object changes the translation too.
+type ReferenceGraph struct {
+	// references are the objects referenced by each object.
+	references map[ObjectRef]map[ObjectRef]struct{}
+	// referrers are the objects referencing each object.
+	referrers map[ObjectRef]map[ObjectRef]struct{}
+}
+
+// NewReferenceGraph returns the reference graph of the provided resources.
+func NewReferenceGraph(resources *Resources) *ReferenceGraph {
+	g := &ReferenceGraph{
+		references: make(map[ObjectRef]map[ObjectRef]struct{}),
+		referrers:  make(map[ObjectRef]map[ObjectRef]struct{}),
+	}
+
+	for _, gateway := range resources.Gateways {
+		from := ObjectRef{Kind: KindGateway, Namespace: gateway.Namespace, Name: gateway.Name}
+		for _, listener := range gateway.Spec.Listeners {
+			if listener.TLS == nil {
+				continue
+			}
+			for _, ref := range listener.TLS.CertificateRefs {
+				if GroupDerefOr(ref.Group, "") == "" && KindDerefOr(ref.Kind, KindSecret) == KindSecret {
+					g.addCrossNamespace(from, ObjectRef{Kind: KindSecret, Namespace: NamespaceDerefOr(ref.Namespace, gateway.Namespace), Name: string(ref.Name)})
+				}
+			}
+		}
+	}
+
+	for _, route := range resources.HTTPRoutes {
+		from := ObjectRef{Kind: KindHTTPRoute, Namespace: route.Namespace, Name: route.Name}
+		g.addParentRefs(from, route.Spec.ParentRefs)
+		for _, rule := range route.Spec.Rules {
+			for _, backendRef := range rule.BackendRefs {
+				g.addBackendRef(from, backendRef.BackendObjectReference)
+				for _, filter := range backendRef.Filters {
+					if filter.RequestMirror != nil {
+						g.addBackendRef(from, filter.RequestMirror.BackendRef)
+					}
+				}
+			}
+			for _, filter := range rule.Filters {
+				if filter.RequestMirror != nil {
+					g.addBackendRef(from, filter.RequestMirror.BackendRef)
+				}
+			}
+		}
+	}
+	for _, route := range resources.TLSRoutes {
+		from := ObjectRef{Kind: KindTLSRoute, Namespace: route.Namespace, Name: route.Name}
+		g.addParentRefs(from, UpgradeParentReferences(route.Spec.ParentRefs))
+		for _, rule := range route.Spec.Rules {
+			g.addBackendRefsV1Alpha2(from, rule.BackendRefs)
+		}
+	}
+	for _, route := range resources.TCPRoutes {
+		from := ObjectRef{Kind: KindTCPRoute, Namespace: route.Namespace, Name: route.Name}
+		g.addParentRefs(from, UpgradeParentReferences(route.Spec.ParentRefs))
+		for _, rule := range route.Spec.Rules {
+			g.addBackendRefsV1Alpha2(from, rule.BackendRefs)
+		}
+	}
+	for _, route := range resources.UDPRoutes {
+		from := ObjectRef{Kind: KindUDPRoute, Namespace: route.Namespace, Name: route.Name}
+		g.addParentRefs(from, UpgradeParentReferences(route.Spec.ParentRefs))
+		for _, rule := range route.Spec.Rules {
+			g.addBackendRefsV1Alpha2(from, rule.BackendRefs)
+		}
+	}
+
+	for _, policy := range resources.BackendTrafficPolicies {
+		from := ObjectRef{Kind: v1alpha1.KindBackendTrafficPolicy, Namespace: policy.Namespace, Name: policy.Name}
+		g.addTargetRef(from, policy.Spec.TargetRef)
+		if tls := policy.Spec.TLS; tls != nil {
+			for _, ref := range []*v1beta1.SecretObjectReference{tls.ClientCertificateRef, tls.CACertificateRef} {
+				if ref != nil {
+					g.add(from, ObjectRef{Kind: KindSecret, Namespace: policy.Namespace, Name: string(ref.Name)})
+				}
+			}
+		}
+		if extProc := policy.Spec.ExtProc; extProc != nil {
+			g.addBackendRef(from, extProc.BackendRef)
+		}
+	}
+	for _, policy := range resources.ClientTrafficPolicies {
+		from := ObjectRef{Kind: v1alpha1.KindClientTrafficPolicy, Namespace: policy.Namespace, Name: policy.Name}
+		g.addTargetRef(from, policy.Spec.TargetRef)
+	}
+	for _, policy := range resources.SecurityPolicies {
+		from := ObjectRef{Kind: v1alpha1.KindSecurityPolicy, Namespace: policy.Namespace, Name: policy.Name}
+		g.addTargetRef(from, policy.Spec.TargetRef)
+		if extAuth := policy.Spec.ExtAuth; extAuth != nil {
+			if extAuth.GRPC != nil {
+				g.addBackendRef(from, extAuth.GRPC.BackendRef)
+			}
+			if extAuth.HTTP != nil {
+				g.addBackendRef(from, extAuth.HTTP.BackendRef)
+			}
+		}
+	}
+	for _, shift := range resources.TrafficShifts {
+		from := ObjectRef{Kind: v1alpha1.KindTrafficShift, Namespace: shift.Namespace, Name: shift.Name}
+		g.addTargetRef(from, &shift.Spec.TargetRef)
+		g.addBackendRef(from, shift.Spec.StableRef)
+		g.addBackendRef(from, shift.Spec.CanaryRef)
+	}
+
+	return g
+}
+
+// add records the reference of from to to.
+func (g *ReferenceGraph) add(from, to ObjectRef) {
+	if g.references[from] == nil {
+		g.references[from] = make(map[ObjectRef]struct{})
+	}
+	g.references[from][to] = struct{}{}
+	if g.referrers[to] == nil {
+		g.referrers[to] = make(map[ObjectRef]struct{})
+	}
+	g.referrers[to][from] = struct{}{}
+}
+
+// addCrossNamespace records the reference of from to to, and to the
+// ReferenceGrants of the namespace of to if it is in another namespace.
+func (g *ReferenceGraph) addCrossNamespace(from, to ObjectRef) {
+	g.add(from, to)
+	if to.Namespace != from.Namespace {
+		g.add(from, ObjectRef{Kind: KindReferenceGrant, Namespace: to.Namespace})
+	}
+}
+
+// addParentRefs records the references of a route to its parent Gateways, or
+// to its parent Services in mesh mode.
+func (g *ReferenceGraph) addParentRefs(from ObjectRef, parentRefs []v1beta1.ParentReference) {
+	for _, parentRef := range parentRefs {
+		to := ObjectRef{Namespace: NamespaceDerefOr(parentRef.Namespace, from.Namespace), Name: string(parentRef.Name)}
+		switch KindDerefOr(parentRef.Kind, KindGateway) {
+		case KindGateway:
+			to.Kind = KindGateway
+		case KindService:
+			to.Kind = KindService
+		default:
+			continue
+		}
+		g.add(from, to)
+	}
+}
+
+// addBackendRef records the reference of from to the Service of a backendRef.
+func (g *ReferenceGraph) addBackendRef(from ObjectRef, backendRef v1beta1.BackendObjectReference) {
+	if GroupDerefOr(backendRef.Group, "") != "" || KindDerefOr(backendRef.Kind, KindService) != KindService {
+		return
+	}
+	g.addCrossNamespace(from, ObjectRef{Kind: KindService, Namespace: NamespaceDerefOr(backendRef.Namespace, from.Namespace), Name: string(backendRef.Name)})
+}
+
+// addBackendRefsV1Alpha2 is the v1alpha2 version of addBackendRef.
+func (g *ReferenceGraph) addBackendRefsV1Alpha2(from ObjectRef, backendRefs []v1alpha2.BackendRef) {
+	for _, backendRef := range backendRefs {
+		if (backendRef.Group != nil && *backendRef.Group != "") || (backendRef.Kind != nil && *backendRef.Kind != KindService) {
+			continue
+		}
+		g.addCrossNamespace(from, ObjectRef{Kind: KindService, Namespace: NamespaceDerefOrAlpha(backendRef.Namespace, from.Namespace), Name: string(backendRef.Name)})
+	}
+}
+
+// addTargetRef records the reference of a policy to its target, if set.
+func (g *ReferenceGraph) addTargetRef(from ObjectRef, targetRef *v1alpha2.PolicyTargetReference) {
+	if targetRef == nil {
+		return
+	}
+	namespace := from.Namespace
+	if targetRef.Namespace != nil {
+		namespace = string(*targetRef.Namespace)
+	}
+	g.add(from, ObjectRef{Kind: string(targetRef.Kind), Namespace: namespace, Name: string(targetRef.Name)})
+}
+
+// References returns the objects referenced by the provided object, sorted.
+func (g *ReferenceGraph) References(obj ObjectRef) []ObjectRef {
+	return sortedObjectRefs(g.references[obj])
+}
+
+// Referrers returns the objects referencing the provided object, sorted.
+func (g *ReferenceGraph) Referrers(obj ObjectRef) []ObjectRef {
+	return sortedObjectRefs(g.referrers[obj])
+}
+
+// IsReferenced returns true if any object references the provided object.
+func (g *ReferenceGraph) IsReferenced(obj ObjectRef) bool {
+	return len(g.referrers[obj]) > 0
+}
+
+// IsReferencedGrant returns true if any object references an object of the
+// namespace of the provided ReferenceGrant from another namespace, i.e. if the
+// ReferenceGrant may permit a reference.
+func (g *ReferenceGraph) IsReferencedGrant(namespace string) bool {
+	return g.IsReferenced(ObjectRef{Kind: KindReferenceGrant, Namespace: namespace})
+}
+
+// Edges returns all the references of the graph, sorted.
+func (g *ReferenceGraph) Edges() []Reference {
+	var edges []Reference
+	for from, tos := range g.references {
+		for to := range tos {
+			edges = append(edges, Reference{From: from, To: to})
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From.String() < edges[j].From.String()
+		}
+		return edges[i].To.String() < edges[j].To.String()
+	})
+	return edges
+}
+
+// sortedObjectRefs returns the provided objects, sorted.
+func sortedObjectRefs(objs map[ObjectRef]struct{}) []ObjectRef {
+	sorted := make([]ObjectRef, 0, len(objs))
+	for obj := range objs {
+		sorted = append(sorted, obj)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].String() < sorted[j].String()
+	})
+	return sorted
+}
+
+// ReferenceGraphs holds the reference graph of the last translated resources,
+// and serves it as JSON over HTTP.
+type ReferenceGraphs struct {
+	mu    sync.RWMutex
+	graph *ReferenceGraph
+}
+
+// Store stores the reference graph of the last translated resources.
+func (r *ReferenceGraphs) Store(graph *ReferenceGraph) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.graph = graph
+}
+
+// Load returns the reference graph of the last translated resources, or nil if
+// no resources were translated yet.
+func (r *ReferenceGraphs) Load() *ReferenceGraph {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.graph
+}
+
+// ServeHTTP serves the references of the last translated resources as JSON,
+// or the references and referrers of the object of the "kind", "namespace" and
+// "name" query parameters if set.
+func (r *ReferenceGraphs) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	graph := r.Load()
+	if graph == nil {
+		graph = &ReferenceGraph{}
+	}
+	var body any
+	query := req.URL.Query()
+	if kind := query.Get("kind"); kind != "" {
+		obj := ObjectRef{Kind: kind, Namespace: query.Get("namespace"), Name: query.Get("name")}
+		body = struct {
+			References []ObjectRef `json:"references"`
+			Referrers  []ObjectRef `json:"referrers"`
+		}{graph.References(obj), graph.Referrers(obj)}
+	} else {
+		body = struct {
+			References []Reference `json:"references"`
+		}{graph.Edges()}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
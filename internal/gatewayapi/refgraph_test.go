@@ -0,0 +1,94 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestReferenceGraph(t *testing.T) {
+	resources := attachedRoutesResources(t)
+	resources.Gateways[0].Spec.Listeners = append(resources.Gateways[0].Spec.Listeners, v1beta1.Listener{
+		Name:     "https",
+		Protocol: v1beta1.HTTPSProtocolType,
+		Port:     443,
+		TLS: &v1beta1.GatewayTLSConfig{
+			CertificateRefs: []v1beta1.SecretObjectReference{
+				{Namespace: NamespacePtr("certs"), Name: "tls-secret"},
+			},
+		},
+	})
+	resources.HTTPRoutes = append(resources.HTTPRoutes, &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "httproute-3"},
+		Spec: v1beta1.HTTPRouteSpec{
+			CommonRouteSpec: v1beta1.CommonRouteSpec{
+				ParentRefs: []v1beta1.ParentReference{{Namespace: NamespacePtr("envoy-gateway"), Name: "gateway-1"}},
+			},
+			Rules: []v1beta1.HTTPRouteRule{
+				{
+					BackendRefs: []v1beta1.HTTPBackendRef{
+						{BackendRef: v1beta1.BackendRef{BackendObjectReference: v1beta1.BackendObjectReference{
+							Namespace: NamespacePtr("backends"),
+							Name:      "service-2",
+						}}},
+					},
+				},
+			},
+		},
+	})
+
+	graph := NewReferenceGraph(resources)
+
+	gateway := ObjectRef{Kind: KindGateway, Namespace: "envoy-gateway", Name: "gateway-1"}
+	require.Equal(t, []ObjectRef{
+		{Kind: KindReferenceGrant, Namespace: "certs"},
+		{Kind: KindSecret, Namespace: "certs", Name: "tls-secret"},
+	}, graph.References(gateway))
+	require.Equal(t, []ObjectRef{
+		{Kind: KindHTTPRoute, Namespace: "default", Name: "httproute-1"},
+		{Kind: KindHTTPRoute, Namespace: "default", Name: "httproute-2"},
+		{Kind: KindHTTPRoute, Namespace: "default", Name: "httproute-3"},
+	}, graph.Referrers(gateway))
+
+	require.True(t, graph.IsReferenced(ObjectRef{Kind: KindService, Namespace: "default", Name: "service-1"}))
+	require.True(t, graph.IsReferenced(ObjectRef{Kind: KindService, Namespace: "backends", Name: "service-2"}))
+	require.False(t, graph.IsReferenced(ObjectRef{Kind: KindService, Namespace: "default", Name: "service-2"}))
+	require.True(t, graph.IsReferencedGrant("backends"))
+	require.False(t, graph.IsReferencedGrant("default"))
+}
+
+func TestReferenceGraphsServeHTTP(t *testing.T) {
+	graphs := new(ReferenceGraphs)
+	graphs.Store(NewReferenceGraph(attachedRoutesResources(t)))
+
+	rec := httptest.NewRecorder()
+	graphs.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ReferenceGraphHandlerPath, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var all struct {
+		References []Reference `json:"references"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &all))
+	require.Len(t, all.References, 4)
+
+	rec = httptest.NewRecorder()
+	graphs.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		ReferenceGraphHandlerPath+"?kind=Service&namespace=default&name=service-1", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var object struct {
+		References []ObjectRef `json:"references"`
+		Referrers  []ObjectRef `json:"referrers"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &object))
+	require.Empty(t, object.References)
+	require.Len(t, object.Referrers, 2)
+}
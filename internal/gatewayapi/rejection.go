@@ -0,0 +1,39 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+const (
+	// GatewayConditionConfigurationRejected is the condition of the Gateways
+	// whose current configuration was rejected by one of their proxies.
+	GatewayConditionConfigurationRejected v1beta1.GatewayConditionType = "ConfigurationRejected"
+	// GatewayReasonProxyRejected is the reason of the ConfigurationRejected
+	// condition of the Gateways.
+	GatewayReasonProxyRejected v1beta1.GatewayConditionReason = "ProxyRejected"
+)
+
+// SetConfigurationRejectedCondition sets the ConfigurationRejected condition
+// of the provided Gateway with the provided message describing the rejection,
+// or removes it if the message is empty.
+func SetConfigurationRejectedCondition(gw *v1beta1.Gateway, message string) {
+	if message == "" {
+		meta.RemoveStatusCondition(&gw.Status.Conditions, string(GatewayConditionConfigurationRejected))
+		return
+	}
+	meta.SetStatusCondition(&gw.Status.Conditions, metav1.Condition{
+		Type:               string(GatewayConditionConfigurationRejected),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(GatewayReasonProxyRejected),
+		Message:            message,
+		ObservedGeneration: gw.Generation,
+		LastTransitionTime: metav1.Now(),
+	})
+}
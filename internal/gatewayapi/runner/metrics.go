@@ -0,0 +1,33 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// gatewayClassTranslatedObjects is the number of objects of each kind
+	// translated for the GatewayClass.
+	gatewayClassTranslatedObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "envoy_gateway_gatewayclass_translated_objects",
+		Help: "Number of objects translated for the GatewayClass.",
+	}, []string{"gateway_class", "kind"})
+
+	// gatewayClassRejectedObjects is the number of objects of each kind
+	// rejected by the translation of the GatewayClass.
+	gatewayClassRejectedObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "envoy_gateway_gatewayclass_rejected_objects",
+		Help: "Number of objects rejected by the translation of the GatewayClass.",
+	}, []string{"gateway_class", "kind"})
+)
+
+func init() {
+	// Register with the controller-runtime registry so the gauges are served
+	// by the manager metrics endpoint.
+	metrics.Registry.MustRegister(gatewayClassTranslatedObjects, gatewayClassRejectedObjects)
+}
@@ -0,0 +1,84 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/provider/utils"
+)
+
+// gatewayIRKeys returns the Gateway owning the infra IR of each key of the
+// provided infra IRs, e.g. the keys of the shards of a Gateway.
+func gatewayIRKeys(infraIR gatewayapi.InfraIRMap) map[string]types.NamespacedName {
+	keys := make(map[string]types.NamespacedName, len(infraIR))
+	for key, infra := range infraIR {
+		if infra == nil || infra.Proxy == nil {
+			continue
+		}
+		labels := infra.Proxy.GetProxyMetadata().Labels
+		keys[key] = types.NamespacedName{
+			Namespace: labels[gatewayapi.OwningGatewayNamespaceLabel],
+			Name:      labels[gatewayapi.OwningGatewayNameLabel],
+		}
+	}
+	return keys
+}
+
+// rejectionMessage returns the message of the ConfigurationRejected condition
+// of a Gateway whose snapshot was rejected by one of its proxies.
+func rejectionMessage(rejection message.SnapshotRejection) string {
+	kind := rejection.TypeURL[strings.LastIndex(rejection.TypeURL, ".")+1:]
+	if rejection.Resource == "" {
+		return fmt.Sprintf("Proxy %s rejected the %s resources: %s", rejection.Node, kind, rejection.Message)
+	}
+	return fmt.Sprintf("Proxy %s rejected the %s %s: %s", rejection.Node, kind, rejection.Resource, rejection.Message)
+}
+
+// setConfigurationRejectedCondition sets the ConfigurationRejected condition
+// of the provided Gateway if the current snapshot of one of its IRs was
+// rejected by one of their proxies, and removes it otherwise.
+func (r *Runner) setConfigurationRejectedCondition(gw *v1beta1.Gateway) {
+	var rejections map[string]message.SnapshotRejection
+	if r.RejectedSnapshots != nil {
+		rejections = r.RejectedSnapshots.LoadAll()
+	}
+
+	gwKey := utils.NamespacedName(gw)
+	var irKeys []string
+	for irKey, owner := range r.gatewayIRKeys {
+		if _, ok := rejections[irKey]; ok && owner == gwKey {
+			irKeys = append(irKeys, irKey)
+		}
+	}
+	if len(irKeys) == 0 {
+		gatewayapi.SetConfigurationRejectedCondition(gw, "")
+		return
+	}
+	sort.Strings(irKeys)
+	gatewayapi.SetConfigurationRejectedCondition(gw, rejectionMessage(rejections[irKeys[0]]))
+}
+
+// updateConfigurationRejectedConditions updates the ConfigurationRejected
+// condition of the statuses of the translated Gateways, once the rejections
+// of their snapshots changed.
+func (r *Runner) updateConfigurationRejectedConditions() {
+	for key, gw := range r.ProviderResources.GatewayStatuses.LoadAll() {
+		updated := gw.DeepCopy()
+		r.setConfigurationRejectedCondition(updated)
+		if !equality.Semantic.DeepEqual(gw.Status.Conditions, updated.Status.Conditions) {
+			r.ProviderResources.GatewayStatuses.Store(key, updated)
+		}
+	}
+}
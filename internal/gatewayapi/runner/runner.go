@@ -7,29 +7,64 @@ package runner
 
 import (
 	"context"
+	"strconv"
+	"time"
 
+	"github.com/telepresenceio/watchable"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 	"sigs.k8s.io/yaml"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/message"
 	"github.com/envoyproxy/gateway/internal/provider/utils"
 )
 
+// resyncInterval is the interval at which the resources are translated again
+// if they depend on the SRV records of the Services or on the time.
+const resyncInterval = 30 * time.Second
+
 type Config struct {
 	config.Server
 	ProviderResources *message.ProviderResources
 	XdsIR             *message.XdsIR
 	InfraIR           *message.InfraIR
+	// ReferenceGraphs holds the reference graph of the last translated
+	// resources, used to skip the translation of the updates of the
+	// Services, Secrets and ReferenceGrants no resource depends on. It is
+	// served next to the metrics of Envoy Gateway, if set.
+	ReferenceGraphs *gatewayapi.ReferenceGraphs
+	// RejectedSnapshots holds the rejections of the current snapshots by
+	// their proxies, reported by the ConfigurationRejected condition of
+	// their Gateways, if set.
+	RejectedSnapshots *message.RejectedSnapshots
 }
 
 type Runner struct {
 	Config
+	// resolver resolves the SRV records of the Services. If nil, the default
+	// resolver is used.
+	resolver srvResolver
+	// selfSigned holds the self-signed certificates served by the listeners
+	// missing their certificate Secret, kept across the translations.
+	selfSigned *gatewayapi.SelfSignedCertificates
+	// gatewayIRKeys holds the Gateway of each IR key of the last translation.
+	gatewayIRKeys map[string]types.NamespacedName
 }
 
 func New(cfg *Config) *Runner {
-	return &Runner{Config: *cfg}
+	r := &Runner{Config: *cfg}
+	if r.ReferenceGraphs == nil {
+		r.ReferenceGraphs = new(gatewayapi.ReferenceGraphs)
+	}
+	if cfg.EnvoyGateway != nil && cfg.EnvoyGateway.Gateway != nil && cfg.EnvoyGateway.Gateway.SelfSignedCertificates {
+		r.selfSigned = gatewayapi.NewSelfSignedCertificates()
+	}
+	return r
 }
 
 func (r *Runner) Name() string {
@@ -38,7 +73,7 @@ func (r *Runner) Name() string {
 
 // Start starts the gateway-api translator runner
 func (r *Runner) Start(ctx context.Context) error {
-	r.Logger = r.Logger.WithValues("runner", r.Name())
+	r.Logger = r.ComponentLogger(v1alpha1.LogComponentTranslator).WithValues("runner", r.Name())
 	go r.subscribeAndTranslate(ctx)
 	r.Logger.Info("started")
 	return nil
@@ -52,8 +87,26 @@ func (r *Runner) subscribeAndTranslate(ctx context.Context) {
 	refGrantsCh := r.ProviderResources.ReferenceGrants.Subscribe(ctx)
 	httpRoutesCh := r.ProviderResources.HTTPRoutes.Subscribe(ctx)
 	tlsRoutesCh := r.ProviderResources.TLSRoutes.Subscribe(ctx)
+	tcpRoutesCh := r.ProviderResources.TCPRoutes.Subscribe(ctx)
+	udpRoutesCh := r.ProviderResources.UDPRoutes.Subscribe(ctx)
 	servicesCh := r.ProviderResources.Services.Subscribe(ctx)
+	endpointSlicesCh := r.ProviderResources.EndpointSlices.Subscribe(ctx)
 	namespacesCh := r.ProviderResources.Namespaces.Subscribe(ctx)
+	envoyProxiesCh := r.ProviderResources.EnvoyProxies.Subscribe(ctx)
+	backendTrafficPoliciesCh := r.ProviderResources.BackendTrafficPolicies.Subscribe(ctx)
+	clientTrafficPoliciesCh := r.ProviderResources.ClientTrafficPolicies.Subscribe(ctx)
+	securityPoliciesCh := r.ProviderResources.SecurityPolicies.Subscribe(ctx)
+	trafficShiftsCh := r.ProviderResources.TrafficShifts.Subscribe(ctx)
+	var rejectedSnapshotsCh <-chan watchable.Snapshot[string, message.SnapshotRejection]
+	if r.RejectedSnapshots != nil {
+		rejectedSnapshotsCh = r.RejectedSnapshots.Subscribe(ctx)
+	}
+
+	// The resources are translated again periodically to pick up the changes
+	// of the SRV records of the Services and the opening and closing of the
+	// time windows of the scheduled routes.
+	resyncTicker := time.NewTicker(resyncInterval)
+	defer resyncTicker.Stop()
 
 	for ctx.Err() == nil {
 		var in gatewayapi.Resources
@@ -61,12 +114,37 @@ func (r *Runner) subscribeAndTranslate(ctx context.Context) {
 		select {
 		case <-gatewayClassesCh:
 		case <-gatewaysCh:
-		case <-secretsCh:
-		case <-refGrantsCh:
+		case snapshot := <-secretsCh:
+			if !referencesUpdates(r.ReferenceGraphs.Load(), gatewayapi.KindSecret, snapshot.Updates) {
+				continue
+			}
+		case snapshot := <-refGrantsCh:
+			if !referencesUpdates(r.ReferenceGraphs.Load(), gatewayapi.KindReferenceGrant, snapshot.Updates) {
+				continue
+			}
 		case <-httpRoutesCh:
 		case <-tlsRoutesCh:
-		case <-servicesCh:
+		case <-tcpRoutesCh:
+		case <-udpRoutesCh:
+		case snapshot := <-servicesCh:
+			if !referencesUpdates(r.ReferenceGraphs.Load(), gatewayapi.KindService, snapshot.Updates) {
+				continue
+			}
+		case <-endpointSlicesCh:
 		case <-namespacesCh:
+		case <-envoyProxiesCh:
+		case <-backendTrafficPoliciesCh:
+		case <-clientTrafficPoliciesCh:
+		case <-securityPoliciesCh:
+		case <-trafficShiftsCh:
+		case <-rejectedSnapshotsCh:
+			// The rejections only change the status of the Gateways.
+			r.updateConfigurationRejectedConditions()
+			continue
+		case <-resyncTicker.C:
+			if !r.requiresResync() {
+				continue
+			}
 		}
 		r.Logger.Info("received a notification")
 		// Load all resources required for translation
@@ -75,8 +153,17 @@ func (r *Runner) subscribeAndTranslate(ctx context.Context) {
 		in.ReferenceGrants = r.ProviderResources.GetReferenceGrants()
 		in.HTTPRoutes = r.ProviderResources.GetHTTPRoutes()
 		in.TLSRoutes = r.ProviderResources.GetTLSRoutes()
+		in.TCPRoutes = r.ProviderResources.GetTCPRoutes()
+		in.UDPRoutes = r.ProviderResources.GetUDPRoutes()
 		in.Services = r.ProviderResources.GetServices()
+		in.EndpointSlices = r.ProviderResources.GetEndpointSlices()
 		in.Namespaces = r.ProviderResources.GetNamespaces()
+		in.BackendTrafficPolicies = r.ProviderResources.GetBackendTrafficPolicies()
+		in.ClientTrafficPolicies = r.ProviderResources.GetClientTrafficPolicies()
+		in.SecurityPolicies = r.ProviderResources.GetSecurityPolicies()
+		in.TrafficShifts = r.ProviderResources.GetTrafficShifts()
+		in.SRVRecords = r.resolveSRVRecords(ctx, gatewayapi.ServiceSRVNames(in.Services))
+		in.RequestRates = r.requestRates()
 		gatewayClasses := r.ProviderResources.GetGatewayClasses()
 		// Fetch the first gateway class since there should be only 1
 		// gateway class linked to this controller
@@ -85,12 +172,28 @@ func (r *Runner) subscribeAndTranslate(ctx context.Context) {
 			// Envoy Gateway startup.
 			continue
 		default:
+			in.EnvoyProxy = r.ProviderResources.GetEnvoyProxy(gatewayClasses[0].GetName())
 			// Translate and publish IRs.
 			t := &gatewayapi.Translator{
-				GatewayClassName: v1beta1.ObjectName(gatewayClasses[0].GetName()),
+				GatewayClassName:         v1beta1.ObjectName(gatewayClasses[0].GetName()),
+				RecordAttachedRouteKinds: r.EnvoyGateway.Gateway != nil && r.EnvoyGateway.Gateway.RecordAttachedRouteKinds,
+				RecordListenerTLS:        r.EnvoyGateway.Gateway != nil && r.EnvoyGateway.Gateway.RecordListenerTLS,
+				RateLimitService:         r.rateLimitService(),
+				Timeouts:                 r.EnvoyGateway.Timeouts,
+				SPIFFE:                   r.EnvoyGateway.SPIFFE,
+				MeshMode:                 r.EnvoyGateway.Mesh != nil && r.EnvoyGateway.Mesh.Enabled,
+				Limits:                   r.EnvoyGateway.Limits,
+				Tenancy:                  r.EnvoyGateway.Tenancy,
+				TrafficStats:             r.EnvoyGateway.TrafficStats != nil,
+				StrictValidation:         r.EnvoyGateway.Gateway != nil && r.EnvoyGateway.Gateway.ValidationMode == v1alpha1.ValidationModeStrict,
+				BackendReadinessGating:   r.EnvoyGateway.Gateway != nil && r.EnvoyGateway.Gateway.BackendReadinessGating,
+				SelfSignedCertificates:   r.selfSigned,
+				ControllerName:           r.controllerName(),
+				EmptyBackendRefs:         r.emptyBackendRefs(),
 			}
 			// Translate to IR
 			result := t.Translate(&in)
+			r.ReferenceGraphs.Store(gatewayapi.NewReferenceGraph(&in))
 
 			yamlXdsIR, _ := yaml.Marshal(&result.XdsIR)
 			r.Logger.WithValues("output", "xds-ir").Info(string(yamlXdsIR))
@@ -129,10 +232,17 @@ func (r *Runner) subscribeAndTranslate(ctx context.Context) {
 				r.InfraIR.Delete(key)
 				r.XdsIR.Delete(key)
 			}
+			// The mesh IR has no infra IR, delete it once no route
+			// attaches to a Service anymore.
+			if _, ok := r.XdsIR.Load(gatewayapi.MeshIRKey); ok && result.XdsIR[gatewayapi.MeshIRKey] == nil {
+				r.XdsIR.Delete(gatewayapi.MeshIRKey)
+			}
 
 			// Update Status
+			r.gatewayIRKeys = gatewayIRKeys(result.InfraIR)
 			for _, gateway := range result.Gateways {
 				key := utils.NamespacedName(gateway)
+				r.setConfigurationRejectedCondition(gateway)
 				r.ProviderResources.GatewayStatuses.Store(key, gateway)
 			}
 			for _, httpRoute := range result.HTTPRoutes {
@@ -143,11 +253,94 @@ func (r *Runner) subscribeAndTranslate(ctx context.Context) {
 				key := utils.NamespacedName(tlsRoute)
 				r.ProviderResources.TLSRouteStatuses.Store(key, tlsRoute)
 			}
+			for _, tcpRoute := range result.TCPRoutes {
+				key := utils.NamespacedName(tcpRoute)
+				r.ProviderResources.TCPRouteStatuses.Store(key, tcpRoute)
+			}
+			for _, udpRoute := range result.UDPRoutes {
+				key := utils.NamespacedName(udpRoute)
+				r.ProviderResources.UDPRouteStatuses.Store(key, udpRoute)
+			}
+			for _, policy := range result.BackendTrafficPolicies {
+				key := utils.NamespacedName(policy)
+				r.ProviderResources.BackendTrafficPolicyStatuses.Store(key, policy)
+			}
+			for _, policy := range result.ClientTrafficPolicies {
+				key := utils.NamespacedName(policy)
+				r.ProviderResources.ClientTrafficPolicyStatuses.Store(key, policy)
+			}
+			for _, policy := range result.SecurityPolicies {
+				key := utils.NamespacedName(policy)
+				r.ProviderResources.SecurityPolicyStatuses.Store(key, policy)
+			}
+			for _, shift := range result.TrafficShifts {
+				key := utils.NamespacedName(shift)
+				r.ProviderResources.TrafficShiftStatuses.Store(key, shift)
+			}
+			r.updateGatewayClassStatus(gatewayClasses[0], result)
 		}
 	}
 	r.Logger.Info("shutting down")
 }
 
+// referencesUpdates returns true if the provided reference graph of the last
+// translated resources references any of the updated objects of the provided
+// kind, i.e. if the updates may change the translation, or if no resources
+// were translated yet.
+func referencesUpdates[V any](graph *gatewayapi.ReferenceGraph, kind string, updates []watchable.Update[types.NamespacedName, V]) bool {
+	if graph == nil || len(updates) == 0 {
+		return true
+	}
+	for _, update := range updates {
+		if kind == gatewayapi.KindReferenceGrant {
+			if graph.IsReferencedGrant(update.Key.Namespace) {
+				return true
+			}
+			continue
+		}
+		if graph.IsReferenced(gatewayapi.ObjectRef{Kind: kind, Namespace: update.Key.Namespace, Name: update.Key.Name}) {
+			return true
+		}
+	}
+	return false
+}
+
+// controllerName returns the name of the controller of the GatewayClass, or an
+// empty string to default to the Envoy Gateway one.
+func (r *Runner) controllerName() string {
+	if r.EnvoyGateway.Gateway == nil {
+		return ""
+	}
+	return r.EnvoyGateway.Gateway.ControllerName
+}
+
+// emptyBackendRefs returns the configuration of the rules without backendRefs,
+// or nil to default to a direct response.
+func (r *Runner) emptyBackendRefs() *v1alpha1.EmptyBackendRefs {
+	if r.EnvoyGateway.Gateway == nil {
+		return nil
+	}
+	return r.EnvoyGateway.Gateway.EmptyBackendRefs
+}
+
+// updateGatewayClassStatus publishes the ConfigurationValid condition of the
+// provided GatewayClass and the number of objects translated and rejected for
+// it, summarizing the provided translation result.
+func (r *Runner) updateGatewayClassStatus(gatewayClass *v1beta1.GatewayClass, result *gatewayapi.TranslateResult) {
+	translated := result.TranslatedObjects()
+	rejected := result.RejectedObjects(r.controllerName())
+	for _, kind := range gatewayapi.TranslatedKinds {
+		gatewayClassTranslatedObjects.WithLabelValues(gatewayClass.Name, kind).Set(float64(translated[kind]))
+		gatewayClassRejectedObjects.WithLabelValues(gatewayClass.Name, kind).Set(float64(rejected[kind]))
+	}
+
+	gc := gatewayClass.DeepCopy()
+	gc.Status.Conditions = []metav1.Condition{
+		gatewayapi.ComputeGatewayClassConfigurationCondition(gc, translated, rejected),
+	}
+	r.ProviderResources.GatewayClassStatuses.Store(gc.Name, gc)
+}
+
 // getIRKeysToDelete returns the list of IR keys to delete
 // based on the difference between the current keys and the
 // new keys parameters passed to the function.
@@ -172,3 +365,79 @@ func getIRKeysToDelete(curKeys, newKeys []string) []string {
 
 	return delKeys
 }
+
+// rateLimitService returns the global rate limit service managed by the
+// infrastructure runner, or nil if global rate limiting is disabled. The
+// service runs in the namespace of the managed Envoy proxies, so it is
+// addressed by name.
+func (r *Runner) rateLimitService() *ir.RateLimitService {
+	if r.EnvoyGateway.RateLimit == nil {
+		return nil
+	}
+	return &ir.RateLimitService{
+		Host:       config.RateLimitInfraName,
+		Port:       config.RateLimitGRPCPort,
+		Domain:     config.RateLimitDomain,
+		FailClosed: r.EnvoyGateway.RateLimit.FailureMode == v1alpha1.FailClosed,
+	}
+}
+
+// requestRates returns the requests per second received by the Gateways, as
+// aggregated by the traffic stats runner, or nil if the traffic stats
+// aggregation is disabled.
+func (r *Runner) requestRates() map[types.NamespacedName]float64 {
+	if r.EnvoyGateway.TrafficStats == nil {
+		return nil
+	}
+	rates := make(map[types.NamespacedName]float64)
+	for key, stats := range r.ProviderResources.GatewayTrafficStats.LoadAll() {
+		rate, err := strconv.ParseFloat(stats.RequestsPerSecond, 64)
+		if err != nil {
+			continue
+		}
+		rates[key] = rate
+	}
+	return rates
+}
+
+// requiresResync returns whether the resources must be translated again
+// periodically, i.e. if Services are resolved through their SRV records or
+// routes are only enabled within a time window, self-signed certificates
+// must be generated again before they expire, or the access logs are
+// sampled according to the request rates of the Gateways.
+func (r *Runner) requiresResync() bool {
+	if r.selfSigned != nil {
+		return true
+	}
+	if r.EnvoyGateway.TrafficStats != nil {
+		for _, envoyProxy := range r.ProviderResources.EnvoyProxies.LoadAll() {
+			if budget := envoyProxy.GetTelemetryBudget(); budget != nil && budget.MaxAccessLogsPerSecond != nil {
+				return true
+			}
+		}
+	}
+	if len(gatewayapi.ServiceSRVNames(r.ProviderResources.GetServices())) > 0 {
+		return true
+	}
+	for _, route := range r.ProviderResources.GetHTTPRoutes() {
+		if gatewayapi.IsScheduledRoute(route) {
+			return true
+		}
+	}
+	for _, route := range r.ProviderResources.GetTLSRoutes() {
+		if gatewayapi.IsScheduledRoute(route) {
+			return true
+		}
+	}
+	for _, route := range r.ProviderResources.GetTCPRoutes() {
+		if gatewayapi.IsScheduledRoute(route) {
+			return true
+		}
+	}
+	for _, route := range r.ProviderResources.GetUDPRoutes() {
+		if gatewayapi.IsScheduledRoute(route) {
+			return true
+		}
+	}
+	return false
+}
@@ -13,9 +13,14 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/telepresenceio/watchable"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/message"
 )
@@ -103,3 +108,91 @@ func TestGetIRKeysToDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestReferencesUpdates(t *testing.T) {
+	graph := gatewayapi.NewReferenceGraph(&gatewayapi.Resources{
+		HTTPRoutes: []*v1beta1.HTTPRoute{
+			{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "httproute-1"},
+				Spec: v1beta1.HTTPRouteSpec{
+					Rules: []v1beta1.HTTPRouteRule{
+						{
+							BackendRefs: []v1beta1.HTTPBackendRef{
+								{BackendRef: v1beta1.BackendRef{BackendObjectReference: v1beta1.BackendObjectReference{
+									Namespace: gatewayapi.NamespacePtr("backends"),
+									Name:      "service-1",
+								}}},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	update := func(namespace, name string) []watchable.Update[types.NamespacedName, *corev1.Service] {
+		return []watchable.Update[types.NamespacedName, *corev1.Service]{
+			{Key: types.NamespacedName{Namespace: namespace, Name: name}},
+		}
+	}
+
+	// The resources are translated until a reference graph is built, and
+	// for the initial snapshots.
+	require.True(t, referencesUpdates(nil, gatewayapi.KindService, update("default", "service-2")))
+	require.True(t, referencesUpdates(graph, gatewayapi.KindService, []watchable.Update[types.NamespacedName, *corev1.Service]{}))
+
+	require.True(t, referencesUpdates(graph, gatewayapi.KindService, update("backends", "service-1")))
+	require.False(t, referencesUpdates(graph, gatewayapi.KindService, update("default", "service-2")))
+	require.True(t, referencesUpdates(graph, gatewayapi.KindReferenceGrant, update("backends", "grant-1")))
+	require.False(t, referencesUpdates(graph, gatewayapi.KindReferenceGrant, update("default", "grant-1")))
+}
+
+func TestConfigurationRejectedCondition(t *testing.T) {
+	pResources := new(message.ProviderResources)
+	rejected := new(message.RejectedSnapshots)
+	r := New(&Config{ProviderResources: pResources, RejectedSnapshots: rejected})
+
+	gwKey := types.NamespacedName{Namespace: "default", Name: "eg"}
+	infra := func() *ir.Infra {
+		infra := ir.NewInfra()
+		infra.Proxy.GetProxyMetadata().Labels = map[string]string{
+			gatewayapi.OwningGatewayNamespaceLabel: gwKey.Namespace,
+			gatewayapi.OwningGatewayNameLabel:      gwKey.Name,
+		}
+		return infra
+	}
+	r.gatewayIRKeys = gatewayIRKeys(gatewayapi.InfraIRMap{
+		"default-eg":         infra(),
+		"default-eg-shard-1": infra(),
+	})
+	pResources.GatewayStatuses.Store(gwKey, &v1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: gwKey.Namespace, Name: gwKey.Name},
+	})
+	condition := func() *metav1.Condition {
+		gw, ok := pResources.GatewayStatuses.Load(gwKey)
+		require.True(t, ok)
+		for i := range gw.Status.Conditions {
+			if gw.Status.Conditions[i].Type == string(gatewayapi.GatewayConditionConfigurationRejected) {
+				return &gw.Status.Conditions[i]
+			}
+		}
+		return nil
+	}
+
+	// The rejection of the snapshot of a shard is reported by its Gateway.
+	rejected.Store("default-eg-shard-1", message.SnapshotRejection{
+		Node:     "envoy-pod",
+		TypeURL:  "type.googleapis.com/envoy.config.listener.v3.Listener",
+		Resource: "default/eg/http",
+		Message:  "invalid filter",
+	})
+	r.updateConfigurationRejectedConditions()
+	cond := condition()
+	require.NotNil(t, cond)
+	require.Equal(t, string(gatewayapi.GatewayReasonProxyRejected), cond.Reason)
+	require.Equal(t, "Proxy envoy-pod rejected the Listener default/eg/http: invalid filter", cond.Message)
+
+	// The condition is removed once a new snapshot is generated.
+	rejected.Delete("default-eg-shard-1")
+	r.updateConfigurationRejectedConditions()
+	require.Nil(t, condition())
+}
@@ -0,0 +1,73 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+)
+
+// srvLookupTimeout is the timeout of resolving the SRV records and their
+// targets.
+const srvLookupTimeout = 5 * time.Second
+
+// srvResolver resolves DNS SRV records and their targets. It is implemented
+// by net.Resolver.
+type srvResolver interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// resolveSRVRecords resolves the SRV records of the provided names to the IPv4
+// addresses of their targets. The records that cannot be resolved are
+// omitted.
+func (r *Runner) resolveSRVRecords(ctx context.Context, names []string) map[string][]gatewayapi.SRVRecord {
+	if len(names) == 0 {
+		return nil
+	}
+
+	resolver := r.resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	ctx, cancel := context.WithTimeout(ctx, srvLookupTimeout)
+	defer cancel()
+
+	records := make(map[string][]gatewayapi.SRVRecord, len(names))
+	for _, name := range names {
+		if _, ok := records[name]; ok {
+			continue
+		}
+		_, srvs, err := resolver.LookupSRV(ctx, "", "", name)
+		if err != nil {
+			r.Logger.Error(err, "unable to resolve srv record", "name", name)
+			continue
+		}
+		for _, srv := range srvs {
+			addrs, err := resolver.LookupIPAddr(ctx, strings.TrimSuffix(srv.Target, "."))
+			if err != nil {
+				r.Logger.Error(err, "unable to resolve srv target", "name", name, "target", srv.Target)
+				continue
+			}
+			for _, addr := range addrs {
+				if addr.IP.To4() == nil {
+					continue
+				}
+				records[name] = append(records[name], gatewayapi.SRVRecord{
+					Address:  addr.IP.String(),
+					Port:     srv.Port,
+					Priority: srv.Priority,
+					Weight:   srv.Weight,
+				})
+			}
+		}
+	}
+	return records
+}
@@ -0,0 +1,71 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+)
+
+type fakeSRVResolver struct {
+	srvs  map[string][]*net.SRV
+	addrs map[string][]net.IPAddr
+}
+
+func (f *fakeSRVResolver) LookupSRV(_ context.Context, _, _, name string) (string, []*net.SRV, error) {
+	srvs, ok := f.srvs[name]
+	if !ok {
+		return "", nil, errors.New("no such host")
+	}
+	return name, srvs, nil
+}
+
+func (f *fakeSRVResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	addrs, ok := f.addrs[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return addrs, nil
+}
+
+func TestResolveSRVRecords(t *testing.T) {
+	cfg, err := config.NewDefaultServer()
+	require.NoError(t, err)
+	r := New(&Config{Server: *cfg})
+	r.resolver = &fakeSRVResolver{
+		srvs: map[string][]*net.SRV{
+			"_http._tcp.legacy.example.com": {
+				{Target: "a.legacy.example.com.", Port: 8080, Priority: 0, Weight: 10},
+				{Target: "b.legacy.example.com.", Port: 8081, Priority: 1, Weight: 5},
+				{Target: "c.legacy.example.com.", Port: 8082, Priority: 1, Weight: 5},
+			},
+		},
+		addrs: map[string][]net.IPAddr{
+			"a.legacy.example.com": {{IP: net.ParseIP("10.0.0.1")}, {IP: net.ParseIP("::1")}},
+			"b.legacy.example.com": {{IP: net.ParseIP("10.0.0.2")}},
+		},
+	}
+
+	got := r.resolveSRVRecords(context.Background(), []string{
+		"_http._tcp.legacy.example.com",
+		"_http._tcp.missing.example.com",
+	})
+	require.Equal(t, map[string][]gatewayapi.SRVRecord{
+		"_http._tcp.legacy.example.com": {
+			{Address: "10.0.0.1", Port: 8080, Priority: 0, Weight: 10},
+			{Address: "10.0.0.2", Port: 8081, Priority: 1, Weight: 5},
+		},
+	}, got)
+
+	require.Nil(t, r.resolveSRVRecords(context.Background(), nil))
+}
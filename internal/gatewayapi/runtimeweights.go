@@ -0,0 +1,33 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+// runtimeKeyPrefixRegexp matches the valid prefixes of the runtime keys of the
+// weights: dot-separated segments of letters, digits, underscores and dashes.
+var runtimeKeyPrefixRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+(\.[a-zA-Z0-9_-]+)*$`)
+
+// buildRuntimeWeightsKeyPrefix returns the prefix of the runtime keys the
+// weights of the backends are read from, or an empty string if the weights
+// are not exposed in the runtime. An error is returned if the prefix is
+// invalid.
+func buildRuntimeWeightsKeyPrefix(policy *v1alpha1.BackendTrafficPolicy) (string, error) {
+	if policy == nil || policy.Spec.RuntimeWeights == nil {
+		return "", nil
+	}
+
+	prefix := policy.Spec.RuntimeWeights.KeyPrefix
+	if !runtimeKeyPrefixRegexp.MatchString(prefix) {
+		return "", fmt.Errorf("the key prefix %q is not a valid runtime key prefix", prefix)
+	}
+	return prefix, nil
+}
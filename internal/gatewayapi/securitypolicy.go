@@ -0,0 +1,260 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// routeSecurity holds the security features of a route, resolved from the
+// SecurityPolicies targeting the route and its Gateway.
+type routeSecurity struct {
+	jwt             *ir.JWT
+	extAuth         *ir.ExtAuth
+	csrf            *ir.CSRF
+	responseHeaders []ir.AddHeader
+}
+
+// buildRouteSecurity resolves the security features of a route. Each feature
+// configured by the policy targeting the route takes precedence over the same
+// feature of the policy targeting its Gateway. If a feature is invalid, it
+// returns empty features along with the policy configuring the feature and
+// the error.
+func buildRouteSecurity(routePolicy, gatewayPolicy *v1alpha1.SecurityPolicy, resources *Resources) (*routeSecurity, *v1alpha1.SecurityPolicy, error) {
+	security := new(routeSecurity)
+	var err error
+
+	policy := securityFeaturePolicy(routePolicy, gatewayPolicy, func(spec *v1alpha1.SecurityPolicySpec) bool { return spec.JWT != nil })
+	if security.jwt, err = buildJWT(policy); err != nil {
+		return new(routeSecurity), policy, err
+	}
+	policy = securityFeaturePolicy(routePolicy, gatewayPolicy, func(spec *v1alpha1.SecurityPolicySpec) bool { return spec.ExtAuth != nil })
+	if security.extAuth, err = buildExtAuth(policy, resources); err != nil {
+		return new(routeSecurity), policy, err
+	}
+	policy = securityFeaturePolicy(routePolicy, gatewayPolicy, func(spec *v1alpha1.SecurityPolicySpec) bool { return spec.CSRF != nil })
+	if security.csrf, err = buildCSRF(policy); err != nil {
+		return new(routeSecurity), policy, err
+	}
+	policy = securityFeaturePolicy(routePolicy, gatewayPolicy, func(spec *v1alpha1.SecurityPolicySpec) bool { return spec.SecurityHeaders != nil })
+	if security.responseHeaders, err = buildSecurityHeaders(policy); err != nil {
+		return new(routeSecurity), policy, err
+	}
+
+	return security, nil, nil
+}
+
+// securityFeaturePolicy returns the route policy if it configures the feature,
+// and the Gateway policy otherwise.
+func securityFeaturePolicy(routePolicy, gatewayPolicy *v1alpha1.SecurityPolicy, configures func(*v1alpha1.SecurityPolicySpec) bool) *v1alpha1.SecurityPolicy {
+	if routePolicy != nil && configures(&routePolicy.Spec) {
+		return routePolicy
+	}
+	return gatewayPolicy
+}
+
+// buildJWT resolves the JWT authentication and authorization defined by the
+// provided SecurityPolicy. It returns nil if the policy does not configure JWT
+// authentication, and an error if the configuration is invalid.
+func buildJWT(policy *v1alpha1.SecurityPolicy) (*ir.JWT, error) {
+	if policy == nil || policy.Spec.JWT == nil {
+		return nil, nil
+	}
+	jwt := policy.Spec.JWT
+
+	if len(jwt.Providers) == 0 {
+		return nil, errors.New("at least one JWT provider must be specified")
+	}
+
+	irJWT := new(ir.JWT)
+	names := map[string]bool{}
+	for _, provider := range jwt.Providers {
+		if provider.Name == "" {
+			return nil, errors.New("the name of a JWT provider must be specified")
+		}
+		if names[provider.Name] {
+			return nil, fmt.Errorf("the name of JWT provider %s must be unique", provider.Name)
+		}
+		names[provider.Name] = true
+
+		u, err := url.Parse(provider.RemoteJWKS.URI)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+			return nil, fmt.Errorf("the remote JWKS URI %q of JWT provider %s must be an absolute HTTP or HTTPS URI", provider.RemoteJWKS.URI, provider.Name)
+		}
+
+		irJWT.Providers = append(irJWT.Providers, ir.JWTProvider{
+			Name:      provider.Name,
+			Issuer:    provider.Issuer,
+			Audiences: provider.Audiences,
+			JWKSURI:   provider.RemoteJWKS.URI,
+		})
+	}
+
+	if authz := jwt.Authorization; authz != nil && (len(authz.Scopes) > 0 || len(authz.Claims) > 0) {
+		irJWT.Authorization = &ir.JWTAuthorization{
+			Scopes: authz.Scopes,
+		}
+		for _, claim := range authz.Claims {
+			if claim.Name == "" || len(claim.Values) == 0 {
+				return nil, errors.New("the name and values of a JWT claim must be specified")
+			}
+			irJWT.Authorization.Claims = append(irJWT.Authorization.Claims, ir.JWTClaim{
+				Name:   claim.Name,
+				Values: claim.Values,
+			})
+		}
+	}
+
+	return irJWT, nil
+}
+
+// buildExtAuth resolves the external authorization service of the provided
+// SecurityPolicy. It returns nil if the policy does not configure external
+// authorization, and an error if the configuration is invalid or the Service
+// of the authorization service cannot be resolved.
+func buildExtAuth(policy *v1alpha1.SecurityPolicy, resources *Resources) (*ir.ExtAuth, error) {
+	if policy == nil || policy.Spec.ExtAuth == nil {
+		return nil, nil
+	}
+	extAuth := policy.Spec.ExtAuth
+
+	irExtAuth := &ir.ExtAuth{
+		Name:     fmt.Sprintf("%s/%s", policy.Namespace, policy.Name),
+		FailOpen: extAuth.FailureMode == v1alpha1.FailOpen,
+	}
+	var backendRef v1beta1.BackendObjectReference
+	switch {
+	case extAuth.GRPC != nil && extAuth.HTTP != nil:
+		return nil, errors.New("only one of the gRPC and HTTP authorization services may be specified")
+	case extAuth.GRPC != nil:
+		backendRef = extAuth.GRPC.BackendRef
+		irExtAuth.GRPC = &ir.GRPCExtAuth{
+			MetadataNamespaces: extAuth.GRPC.MetadataNamespaces,
+		}
+	case extAuth.HTTP != nil:
+		backendRef = extAuth.HTTP.BackendRef
+		irExtAuth.HTTP = &ir.HTTPExtAuth{
+			Path:             extAuth.HTTP.Path,
+			HeadersToExtAuth: extAuth.HTTP.HeadersToExtAuth,
+			HeadersToBackend: extAuth.HTTP.HeadersToBackend,
+		}
+	default:
+		return nil, errors.New("a gRPC or HTTP authorization service must be specified")
+	}
+
+	destination, err := resolveExternalServiceDestination("authorization service", backendRef, policy.Namespace, resources)
+	if err != nil {
+		return nil, err
+	}
+	irExtAuth.Destination = destination
+
+	if body := extAuth.BodyToExtAuth; body != nil {
+		if body.MaxRequestBytes == 0 {
+			return nil, errors.New("the maximum size of the body forwarded to the authorization service must be greater than zero")
+		}
+		irExtAuth.Body = &ir.ExtAuthBody{
+			MaxRequestBytes:     body.MaxRequestBytes,
+			AllowPartialMessage: body.AllowPartialMessage,
+		}
+	}
+
+	return irExtAuth, nil
+}
+
+// resolveExternalServiceDestination returns the destination of the Service
+// referenced by the backendRef of an external service, e.g. an authorization
+// service, which must be in the namespace of the policy. The description of
+// the service is used in the returned errors.
+func resolveExternalServiceDestination(description string, backendRef v1beta1.BackendObjectReference, namespace string, resources *Resources) (*ir.RouteDestination, error) {
+	if (backendRef.Group != nil && *backendRef.Group != "") || (backendRef.Kind != nil && *backendRef.Kind != KindService) {
+		return nil, fmt.Errorf("the %s must reference a Service", description)
+	}
+	if NamespaceDerefOr(backendRef.Namespace, namespace) != namespace {
+		return nil, fmt.Errorf("the Service of the %s must be in the namespace of the policy", description)
+	}
+	if backendRef.Port == nil {
+		return nil, fmt.Errorf("the port of service %s/%s must be specified", namespace, backendRef.Name)
+	}
+
+	service := resources.GetService(namespace, string(backendRef.Name))
+	if service == nil {
+		return nil, fmt.Errorf("service %s/%s not found", namespace, backendRef.Name)
+	}
+	if findServicePort(service, backendRef.Port, "") == nil {
+		return nil, fmt.Errorf("port %d not found on service %s/%s", *backendRef.Port, namespace, backendRef.Name)
+	}
+	return &ir.RouteDestination{
+		Host: service.Spec.ClusterIP,
+		Port: uint32(*backendRef.Port),
+	}, nil
+}
+
+// buildCSRF resolves the CSRF protection of the provided SecurityPolicy. It
+// returns nil if the policy does not enable the protection, and an error if an
+// additional origin is invalid.
+func buildCSRF(policy *v1alpha1.SecurityPolicy) (*ir.CSRF, error) {
+	if policy == nil || policy.Spec.CSRF == nil {
+		return nil, nil
+	}
+
+	csrf := new(ir.CSRF)
+	for _, origin := range policy.Spec.CSRF.AdditionalOrigins {
+		if origin == "" || strings.Contains(strings.TrimPrefix(origin, "*."), "*") {
+			return nil, fmt.Errorf("the additional origin %q must be a host, optionally prefixed with a *. wildcard", origin)
+		}
+		if strings.HasPrefix(origin, "*.") {
+			csrf.AdditionalOrigins = append(csrf.AdditionalOrigins, &ir.StringMatch{
+				SafeRegex: StringPtr(".*" + regexp.QuoteMeta(origin[1:])),
+			})
+		} else {
+			csrf.AdditionalOrigins = append(csrf.AdditionalOrigins, &ir.StringMatch{
+				Exact: StringPtr(origin),
+			})
+		}
+	}
+	return csrf, nil
+}
+
+// buildSecurityHeaders returns the security headers added to the responses
+// by the provided SecurityPolicy. It returns nil if the policy does not
+// configure security headers, and an error if the configuration is invalid.
+func buildSecurityHeaders(policy *v1alpha1.SecurityPolicy) ([]ir.AddHeader, error) {
+	if policy == nil || policy.Spec.SecurityHeaders == nil {
+		return nil, nil
+	}
+	securityHeaders := policy.Spec.SecurityHeaders
+
+	var headers []ir.AddHeader
+	if hsts := securityHeaders.HSTS; hsts != nil {
+		if hsts.MaxAge.Duration < 0 {
+			return nil, errors.New("the max age of the HSTS header must not be negative")
+		}
+		value := fmt.Sprintf("max-age=%d", int64(hsts.MaxAge.Seconds()))
+		if hsts.IncludeSubdomains {
+			value += "; includeSubDomains"
+		}
+		if hsts.Preload {
+			value += "; preload"
+		}
+		headers = append(headers, ir.AddHeader{Name: "Strict-Transport-Security", Value: value})
+	}
+	if securityHeaders.NoSniff {
+		headers = append(headers, ir.AddHeader{Name: "X-Content-Type-Options", Value: "nosniff"})
+	}
+	if securityHeaders.ContentSecurityPolicy != "" {
+		headers = append(headers, ir.AddHeader{Name: "Content-Security-Policy", Value: securityHeaders.ContentSecurityPolicy})
+	}
+	return headers, nil
+}
@@ -0,0 +1,128 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/crypto"
+)
+
+const (
+	// ListenerConditionSelfSignedCertificate is the condition of the
+	// listeners serving a self-signed certificate because their certificate
+	// Secret does not exist.
+	ListenerConditionSelfSignedCertificate v1beta1.ListenerConditionType = "SelfSignedCertificate"
+	// ListenerReasonSelfSignedCertificate is the reason of the
+	// SelfSignedCertificate condition of the listeners.
+	ListenerReasonSelfSignedCertificate v1beta1.ListenerConditionReason = "SelfSignedCertificate"
+
+	// selfSignedCertificateLifetime is the lifetime of the self-signed
+	// certificates.
+	selfSignedCertificateLifetime = 24 * time.Hour
+	// selfSignedCertificateRotationThreshold is the time before expiry at
+	// which the self-signed certificates are generated again.
+	selfSignedCertificateRotationThreshold = time.Hour
+)
+
+// SelfSignedCertificates generates the short-lived self-signed certificates
+// served by the listeners whose certificate Secret does not exist. The
+// certificates are kept across the translations, and only generated again
+// when they are close to expiry or the hostname of the listener changes.
+type SelfSignedCertificates struct {
+	mu      sync.Mutex
+	entries map[string]selfSignedCertificate
+}
+
+type selfSignedCertificate struct {
+	hostname string
+	secret   *v1.Secret
+}
+
+// NewSelfSignedCertificates returns an empty set of self-signed certificates.
+func NewSelfSignedCertificates() *SelfSignedCertificates {
+	return &SelfSignedCertificates{entries: make(map[string]selfSignedCertificate)}
+}
+
+// secretFor returns a TLS Secret holding the self-signed certificate of the
+// listener, generating it if it does not exist yet or expires within the
+// rotation threshold.
+func (s *SelfSignedCertificates) secretFor(listener *ListenerContext, now time.Time) (*v1.Secret, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hostname := "*"
+	if listener.Hostname != nil {
+		hostname = string(*listener.Hostname)
+	}
+
+	key := selfSignedCertificateKey(listener)
+	if entry, ok := s.entries[key]; ok && entry.hostname == hostname {
+		rotate, err := crypto.NeedsRotation(entry.secret.Data[v1.TLSCertKey], now, selfSignedCertificateRotationThreshold)
+		if err == nil && !rotate {
+			return entry.secret, nil
+		}
+	}
+
+	// A listener without hostname matches all the hostnames, so its
+	// certificate is issued for the name of the Gateway without DNS names.
+	commonName := hostname
+	var dnsNames []string
+	if hostname == "*" {
+		commonName = listener.gateway.Name
+	} else {
+		dnsNames = append(dnsNames, hostname)
+	}
+
+	cert, privateKey, err := crypto.GenerateSelfSignedCert(commonName, dnsNames, now.Add(selfSignedCertificateLifetime))
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: listener.gateway.Namespace,
+			Name:      fmt.Sprintf("%s-%s-self-signed", listener.gateway.Name, listener.Name),
+		},
+		Type: v1.SecretTypeTLS,
+		Data: map[string][]byte{
+			v1.TLSCertKey:       cert,
+			v1.TLSPrivateKeyKey: privateKey,
+		},
+	}
+	s.entries[key] = selfSignedCertificate{hostname: hostname, secret: secret}
+
+	return secret, nil
+}
+
+// retain removes the self-signed certificates of the listeners which are no
+// longer part of the provided Gateways.
+func (s *SelfSignedCertificates) retain(gateways []*GatewayContext) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make(map[string]bool)
+	for _, gateway := range gateways {
+		for _, listener := range gateway.listeners {
+			keys[selfSignedCertificateKey(listener)] = true
+		}
+	}
+	for key := range s.entries {
+		if !keys[key] {
+			delete(s.entries, key)
+		}
+	}
+}
+
+func selfSignedCertificateKey(listener *ListenerContext) string {
+	return fmt.Sprintf("%s/%s/%s", listener.gateway.Namespace, listener.gateway.Name, listener.Name)
+}
@@ -0,0 +1,69 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestSelfSignedCertificates(t *testing.T) {
+	selfSignedResources := func(t *testing.T) *Resources {
+		resources := attachedRoutesResources(t)
+		hostname := v1beta1.Hostname("www.example.com")
+		listener := &resources.Gateways[0].Spec.Listeners[0]
+		listener.Name = "https"
+		listener.Protocol = v1beta1.HTTPSProtocolType
+		listener.Port = 443
+		listener.Hostname = &hostname
+		listener.TLS = &v1beta1.GatewayTLSConfig{
+			CertificateRefs: []v1beta1.SecretObjectReference{{Name: "missing-secret"}},
+		}
+		return resources
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		translator := &Translator{GatewayClassName: "envoy-gateway-class"}
+		result := translator.Translate(selfSignedResources(t))
+
+		require.Len(t, result.Gateways, 1)
+		conditions := result.Gateways[0].Status.Listeners[0].Conditions
+		require.True(t, meta.IsStatusConditionFalse(conditions, string(v1beta1.ListenerConditionResolvedRefs)))
+		require.Nil(t, meta.FindStatusCondition(conditions, string(ListenerConditionSelfSignedCertificate)))
+		require.Empty(t, result.XdsIR["envoy-gateway-gateway-1"].HTTP)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		selfSigned := NewSelfSignedCertificates()
+		translator := &Translator{GatewayClassName: "envoy-gateway-class", SelfSignedCertificates: selfSigned}
+		result := translator.Translate(selfSignedResources(t))
+
+		require.Len(t, result.Gateways, 1)
+		conditions := result.Gateways[0].Status.Listeners[0].Conditions
+		require.True(t, meta.IsStatusConditionTrue(conditions, string(v1beta1.ListenerConditionReady)))
+		cond := meta.FindStatusCondition(conditions, string(ListenerConditionSelfSignedCertificate))
+		require.NotNil(t, cond)
+		require.Equal(t, string(ListenerReasonSelfSignedCertificate), cond.Reason)
+
+		httpListeners := result.XdsIR["envoy-gateway-gateway-1"].HTTP
+		require.Len(t, httpListeners, 1)
+		require.NotNil(t, httpListeners[0].TLS)
+		require.NotEmpty(t, httpListeners[0].TLS.ServerCertificate)
+
+		// The certificate is kept across the translations.
+		translator = &Translator{GatewayClassName: "envoy-gateway-class", SelfSignedCertificates: selfSigned}
+		result = translator.Translate(selfSignedResources(t))
+		require.Equal(t, httpListeners[0].TLS.ServerCertificate, result.XdsIR["envoy-gateway-gateway-1"].HTTP[0].TLS.ServerCertificate)
+
+		// The certificate of a removed listener is dropped.
+		translator = &Translator{GatewayClassName: "envoy-gateway-class", SelfSignedCertificates: selfSigned}
+		translator.Translate(attachedRoutesResources(t))
+		require.Empty(t, selfSigned.entries)
+	})
+}
@@ -0,0 +1,63 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// BackendPortNamesAnnotation is the annotation of the routes resolving the
+// ports of their backendRefs without a port by name. Its value is a
+// comma-separated list of <service name>=<port name> pairs, e.g.
+// "backend=http,admin=metrics", naming the port of the Service used by the
+// backendRefs of the route referencing it without a port.
+const BackendPortNamesAnnotation = "gateway.envoyproxy.io/backend-port-names"
+
+// backendPortName returns the name of the port of the Service with the
+// provided name given by the BackendPortNamesAnnotation of the route, or an
+// empty string if none.
+func backendPortName(route client.Object, serviceName string) string {
+	value := route.GetAnnotations()[BackendPortNamesAnnotation]
+	for _, pair := range strings.Split(value, ",") {
+		name, portName, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if found && strings.TrimSpace(name) == serviceName {
+			return strings.TrimSpace(portName)
+		}
+	}
+	return ""
+}
+
+// findServicePort returns the TCP port of the Service with the provided
+// number, or with the provided name if the number is nil, or nil if not
+// found. The ports of the other protocols are skipped, so that a Service may
+// expose the same port number for TCP and UDP, e.g. for DNS.
+func findServicePort(service *v1.Service, port *v1beta1.PortNumber, name string) *v1.ServicePort {
+	for i, servicePort := range service.Spec.Ports {
+		if servicePort.Protocol != "" && servicePort.Protocol != v1.ProtocolTCP {
+			continue
+		}
+		if port != nil && servicePort.Port == int32(*port) {
+			return &service.Spec.Ports[i]
+		}
+		if port == nil && name != "" && servicePort.Name == name {
+			return &service.Spec.Ports[i]
+		}
+	}
+	return nil
+}
+
+// servicePortRef returns the number of the port if set, or its name.
+func servicePortRef(port *v1beta1.PortNumber, name string) string {
+	if port != nil {
+		return strconv.Itoa(int(*port))
+	}
+	return name
+}
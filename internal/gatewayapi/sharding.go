@@ -0,0 +1,98 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// listenerShards returns the number of shards requested by the
+// ListenerShardsAnnotation of the Gateway, or 1 if unset or invalid.
+func listenerShards(gateway *GatewayContext) int {
+	shards, err := strconv.Atoi(gateway.Annotations[ListenerShardsAnnotation])
+	if err != nil || shards < 1 {
+		return 1
+	}
+	return shards
+}
+
+// shardIRKey returns the IR key of the provided shard of a Gateway. The first
+// shard keeps the IR key of the Gateway, so that its Service, and hence the
+// addresses of the Gateway, are preserved when sharding is enabled.
+func shardIRKey(irKey string, shard int) string {
+	if shard == 0 {
+		return irKey
+	}
+	return fmt.Sprintf("%s-shard-%d", irKey, shard)
+}
+
+// shardListeners splits the IRs of the Gateways annotated with the
+// ListenerShardsAnnotation into one IR per shard, each served by its own
+// managed Deployment and Service. The ports of the Gateway are assigned to the
+// shards round-robin in ascending order, so that the listeners sharing a port
+// are served by the same proxy. A Gateway is not sharded beyond its number of
+// ports.
+func shardListeners(gateways []*GatewayContext, xdsIR XdsIRMap, infraIR InfraIRMap) {
+	for _, gateway := range gateways {
+		irKey := irStringKey(gateway.Gateway)
+		gwXdsIR, gwInfraIR := xdsIR[irKey], infraIR[irKey]
+		if gwXdsIR == nil || gwInfraIR == nil || len(gwInfraIR.Proxy.Listeners) == 0 {
+			continue
+		}
+
+		shards := listenerShards(gateway)
+		if shards > len(gwInfraIR.Proxy.Listeners[0].Ports) {
+			shards = len(gwInfraIR.Proxy.Listeners[0].Ports)
+		}
+		if shards < 2 {
+			continue
+		}
+
+		ports := make([]ir.ListenerPort, len(gwInfraIR.Proxy.Listeners[0].Ports))
+		copy(ports, gwInfraIR.Proxy.Listeners[0].Ports)
+		sort.Slice(ports, func(i, j int) bool { return ports[i].ServicePort < ports[j].ServicePort })
+
+		// The shard of each container port.
+		portShards := make(map[uint32]int, len(ports))
+		shardXdsIRs := make([]*ir.Xds, shards)
+		shardInfraIRs := make([]*ir.Infra, shards)
+		for shard := 0; shard < shards; shard++ {
+			shardXdsIRs[shard] = &ir.Xds{}
+			shardInfraIRs[shard] = gwInfraIR.DeepCopy()
+			shardInfraIRs[shard].Proxy.Name = shardIRKey(irKey, shard)
+			shardInfraIRs[shard].Proxy.GetProxyMetadata().Labels[ListenerShardLabel] = strconv.Itoa(shard)
+			shardInfraIRs[shard].Proxy.Listeners[0].Ports = nil
+		}
+		for i, port := range ports {
+			shard := i % shards
+			portShards[uint32(port.ContainerPort)] = shard
+			shardInfraIRs[shard].Proxy.Listeners[0].Ports = append(shardInfraIRs[shard].Proxy.Listeners[0].Ports, port)
+		}
+
+		for _, listener := range gwXdsIR.HTTP {
+			shard := shardXdsIRs[portShards[listener.Port]]
+			shard.HTTP = append(shard.HTTP, listener)
+		}
+		for _, listener := range gwXdsIR.TCP {
+			shard := shardXdsIRs[portShards[listener.Port]]
+			shard.TCP = append(shard.TCP, listener)
+		}
+		for _, listener := range gwXdsIR.UDP {
+			shard := shardXdsIRs[portShards[listener.Port]]
+			shard.UDP = append(shard.UDP, listener)
+		}
+
+		for shard := 0; shard < shards; shard++ {
+			key := shardIRKey(irKey, shard)
+			xdsIR[key] = shardXdsIRs[shard]
+			infraIR[key] = shardInfraIRs[shard]
+		}
+	}
+}
@@ -8,6 +8,8 @@ package gatewayapi
 import (
 	"sort"
 
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+
 	"github.com/envoyproxy/gateway/internal/ir"
 )
 
@@ -16,32 +18,7 @@ type XdsIRRoutes []*ir.HTTPRoute
 func (x XdsIRRoutes) Len() int      { return len(x) }
 func (x XdsIRRoutes) Swap(i, j int) { x[i], x[j] = x[j], x[i] }
 func (x XdsIRRoutes) Less(i, j int) bool {
-	// 1. Sort based on characters in a matching path.
-	pCountI := pathMatchCount(x[i].PathMatch)
-	pCountJ := pathMatchCount(x[j].PathMatch)
-	if pCountI < pCountJ {
-		return true
-	}
-	if pCountI > pCountJ {
-		return false
-	}
-	// Equal case
-
-	// 2. Sort based on the number of Header matches.
-	hCountI := len(x[i].HeaderMatches)
-	hCountJ := len(x[j].HeaderMatches)
-	if hCountI < hCountJ {
-		return true
-	}
-	if hCountI > hCountJ {
-		return false
-	}
-	// Equal case
-
-	// 3. Sort based on the number of Query param matches.
-	qCountI := len(x[i].QueryParamMatches)
-	qCountJ := len(x[j].QueryParamMatches)
-	return qCountI < qCountJ
+	return RoutePrecedenceLess(x[i], x[j])
 }
 
 // sortXdsIR sorts the xdsIR based on the match precedence
@@ -70,3 +47,22 @@ func pathMatchCount(pathMatch *ir.StringMatch) int {
 	}
 	return 0
 }
+
+// sortTLSRoutes returns a copy of tlsRoutes sorted by precedence, as defined
+// in the Gateway API spec for conflicting routes: the oldest route first, and
+// then alphabetically by namespace and name.
+func sortTLSRoutes(tlsRoutes []*v1alpha2.TLSRoute) []*v1alpha2.TLSRoute {
+	sorted := make([]*v1alpha2.TLSRoute, len(tlsRoutes))
+	copy(sorted, tlsRoutes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].CreationTimestamp.Equal(&sorted[j].CreationTimestamp) {
+			return sorted[i].CreationTimestamp.Before(&sorted[j].CreationTimestamp)
+		}
+		if sorted[i].Namespace != sorted[j].Namespace {
+			return sorted[i].Namespace < sorted[j].Namespace
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	return sorted
+}
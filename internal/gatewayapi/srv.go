@@ -0,0 +1,84 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// DNSSRVAnnotation is the annotation of the ExternalName Services which, if
+// set to "true", forwards the requests to the targets of the DNS SRV records
+// of their ports instead of their external name. The SRV record of a port is
+// named after the port name and protocol, and the external name of the
+// Service, e.g. "_http._tcp.legacy.example.com".
+const DNSSRVAnnotation = "gateway.envoyproxy.io/dns-srv"
+
+// SRVRecord is a target of a DNS SRV record, resolved to an IP address.
+type SRVRecord struct {
+	// Address is the IP address of the target.
+	Address string
+	// Port is the port of the target.
+	Port uint16
+	// Priority is the priority of the target. Lower values take precedence.
+	Priority uint16
+	// Weight is the relative weight of the target among the targets of the
+	// same priority.
+	Weight uint16
+}
+
+// isSRVService returns whether the requests to the Service are forwarded to
+// the targets of its SRV records.
+func isSRVService(service *v1.Service) bool {
+	return service.Spec.Type == v1.ServiceTypeExternalName && service.Annotations[DNSSRVAnnotation] == "true"
+}
+
+// serviceSRVName returns the name of the SRV record of the port of the
+// Service.
+func serviceSRVName(service *v1.Service, port v1.ServicePort) string {
+	protocol := port.Protocol
+	if protocol == "" {
+		protocol = v1.ProtocolTCP
+	}
+	return fmt.Sprintf("_%s._%s.%s", port.Name, strings.ToLower(string(protocol)), service.Spec.ExternalName)
+}
+
+// ServiceSRVNames returns the names of the SRV records of the ports of the
+// Services forwarding their requests to the targets of their SRV records.
+func ServiceSRVNames(services []*v1.Service) []string {
+	var names []string
+	for _, service := range services {
+		if !isSRVService(service) {
+			continue
+		}
+		for _, port := range service.Spec.Ports {
+			names = append(names, serviceSRVName(service, port))
+		}
+	}
+	return names
+}
+
+// srvDestinations returns a copy of the destination per SRV record, forwarding
+// the requests to the target of the record. The weight of the destination is
+// scaled by the weight of each record.
+func srvDestinations(destination *ir.RouteDestination, records []SRVRecord) []*ir.RouteDestination {
+	destinations := make([]*ir.RouteDestination, 0, len(records))
+	for _, record := range records {
+		srvDestination := destination.DeepCopy()
+		srvDestination.Host = record.Address
+		srvDestination.Port = uint32(record.Port)
+		srvDestination.Priority = uint32(record.Priority)
+		if record.Weight > 1 {
+			srvDestination.Weight *= uint32(record.Weight)
+		}
+		destinations = append(destinations, srvDestination)
+	}
+	return destinations
+}
@@ -0,0 +1,44 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+// accessLogSampleRateDenominator is the denominator of the fraction of the
+// requests written to the access log.
+const accessLogSampleRateDenominator = 1000000
+
+// irMaxStatsClusters returns the maximum number of clusters of a Gateway
+// reporting their own stats, or zero if it is not bounded.
+func irMaxStatsClusters(budget *v1alpha1.TelemetryBudget) uint32 {
+	if budget == nil || budget.MaxStatsClusters == nil {
+		return 0
+	}
+	return *budget.MaxStatsClusters
+}
+
+// irAccessLogSampleRate returns the fraction of the requests to the listeners
+// of a Gateway receiving the provided number of requests per second written
+// to the access log, in parts per million, or zero if all the requests are
+// logged. The fraction is halved until the sampled requests fit the budget,
+// so that it only changes when the request rate doubles or halves, rather
+// than updating the listeners on every fluctuation of the request rate.
+func irAccessLogSampleRate(budget *v1alpha1.TelemetryBudget, requestsPerSecond float64) uint32 {
+	if budget == nil || budget.MaxAccessLogsPerSecond == nil {
+		return 0
+	}
+	max := float64(*budget.MaxAccessLogsPerSecond)
+	if requestsPerSecond <= max {
+		return 0
+	}
+	rate := uint32(accessLogSampleRateDenominator)
+	for rate > 1 && requestsPerSecond*float64(rate)/accessLogSampleRateDenominator > max {
+		rate /= 2
+	}
+	return rate
+}
@@ -0,0 +1,86 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+func TestIRAccessLogSampleRate(t *testing.T) {
+	max := uint32(100)
+	budget := &v1alpha1.TelemetryBudget{MaxAccessLogsPerSecond: &max}
+
+	testCases := []struct {
+		name              string
+		budget            *v1alpha1.TelemetryBudget
+		requestsPerSecond float64
+		want              uint32
+	}{
+		{
+			name:              "no budget",
+			requestsPerSecond: 1000,
+		},
+		{
+			name:              "within budget",
+			budget:            budget,
+			requestsPerSecond: 100,
+		},
+		{
+			name:              "twice the budget",
+			budget:            budget,
+			requestsPerSecond: 200,
+			want:              500000,
+		},
+		{
+			name:              "slightly over twice the budget",
+			budget:            budget,
+			requestsPerSecond: 201,
+			want:              250000,
+		},
+		{
+			name:              "far over budget",
+			budget:            budget,
+			requestsPerSecond: 1e12,
+			want:              1,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, irAccessLogSampleRate(tc.budget, tc.requestsPerSecond))
+		})
+	}
+}
+
+func TestTelemetryBudget(t *testing.T) {
+	maxClusters, maxLogs := uint32(10), uint32(100)
+	resources := attachedRoutesResources(t)
+	resources.EnvoyProxy = &v1alpha1.EnvoyProxy{
+		Spec: v1alpha1.EnvoyProxySpec{
+			TelemetryBudget: &v1alpha1.TelemetryBudget{
+				MaxStatsClusters:       &maxClusters,
+				MaxAccessLogsPerSecond: &maxLogs,
+			},
+		},
+	}
+	resources.RequestRates = map[types.NamespacedName]float64{
+		{Namespace: "envoy-gateway", Name: "gateway-1"}: 400,
+	}
+
+	translator := &Translator{GatewayClassName: "envoy-gateway-class"}
+	result := translator.Translate(resources)
+
+	xdsIR := result.XdsIR["envoy-gateway-gateway-1"]
+	require.Equal(t, maxClusters, xdsIR.MaxStatsClusters)
+	require.Len(t, xdsIR.HTTP, 1)
+	require.Equal(t, uint32(250000), xdsIR.HTTP[0].AccessLogSampleRate)
+}
@@ -0,0 +1,45 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+// RouteReasonNotAllowedByTenant is the reason of the Accepted condition of the
+// parentRefs of the routes not allowed to attach to a Gateway by its tenants.
+const RouteReasonNotAllowedByTenant v1beta1.RouteConditionReason = "NotAllowedByTenant"
+
+// tenantRouteNamespaces returns the namespaces allowed to attach routes to the
+// Gateways in the provided namespace, or nil if the namespace belongs to no
+// tenant.
+func tenantRouteNamespaces(tenancy *v1alpha1.Tenancy, gatewayNamespace string) sets.String {
+	if tenancy == nil {
+		return nil
+	}
+
+	var allowed sets.String
+	for _, tenant := range tenancy.Tenants {
+		if !sets.NewString(tenant.GatewayNamespaces...).Has(gatewayNamespace) {
+			continue
+		}
+		if allowed == nil {
+			allowed = sets.NewString()
+		}
+		allowed.Insert(tenant.GatewayNamespaces...)
+		allowed.Insert(tenant.RouteNamespaces...)
+	}
+	return allowed
+}
+
+// allowsTenantNamespace returns whether the tenants of the Gateway of the
+// listener allow the routes of the provided namespace to attach to it.
+func (l *ListenerContext) allowsTenantNamespace(namespace string) bool {
+	return l.tenantRouteNamespaces == nil || l.tenantRouteNamespaces.Has(namespace)
+}
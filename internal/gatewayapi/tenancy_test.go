@@ -0,0 +1,68 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+func TestTenancy(t *testing.T) {
+	testCases := []struct {
+		name    string
+		tenancy *v1alpha1.Tenancy
+		reason  v1beta1.RouteConditionReason
+	}{
+		{
+			name:   "no tenancy",
+			reason: v1beta1.RouteReasonAccepted,
+		},
+		{
+			name: "route namespace allowed by the tenant",
+			tenancy: &v1alpha1.Tenancy{Tenants: []v1alpha1.Tenant{{
+				Name:              "tenant-1",
+				GatewayNamespaces: []string{"envoy-gateway"},
+				RouteNamespaces:   []string{"default"},
+			}}},
+			reason: v1beta1.RouteReasonAccepted,
+		},
+		{
+			name: "route namespace not allowed by the tenant",
+			tenancy: &v1alpha1.Tenancy{Tenants: []v1alpha1.Tenant{{
+				Name:              "tenant-1",
+				GatewayNamespaces: []string{"envoy-gateway"},
+			}}},
+			reason: RouteReasonNotAllowedByTenant,
+		},
+		{
+			name: "gateway namespace of no tenant",
+			tenancy: &v1alpha1.Tenancy{Tenants: []v1alpha1.Tenant{{
+				Name:              "tenant-1",
+				GatewayNamespaces: []string{"tenant-1"},
+			}}},
+			reason: v1beta1.RouteReasonAccepted,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			translator := &Translator{GatewayClassName: "envoy-gateway-class", Tenancy: tc.tenancy}
+			result := translator.Translate(attachedRoutesResources(t))
+
+			require.Len(t, result.HTTPRoutes, 2)
+			for _, route := range result.HTTPRoutes {
+				conditions := route.Status.Parents[0].Conditions
+				require.Len(t, conditions, 1)
+				require.Equal(t, string(tc.reason), conditions[0].Reason, route.Name)
+			}
+		})
+	}
+}
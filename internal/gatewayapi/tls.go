@@ -0,0 +1,223 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// minRSAKeySize is the minimum size in bits of the RSA keys supported by Envoy.
+	minRSAKeySize = 2048
+	// caCertKey is the key of the CA certificates in a Secret.
+	caCertKey = "ca.crt"
+	// ocspStapleKey is the key of the DER encoded OCSP response stapled to
+	// the certificate of a TLS Secret.
+	ocspStapleKey = "tls.ocsp-staple"
+)
+
+// validateTLSSecretData validates the certificate chain and private key of a
+// TLS Secret, so that invalid TLS material is reported in the listener status
+// rather than rejected by Envoy. The certificate chain must be PEM encoded,
+// ordered from the leaf certificate, with each certificate issued by the next
+// one, and the leaf certificate must not be expired at now. The private key
+// must be an RSA key of at least 2048 bits or an ECDSA P-256 or P-384 key,
+// and must match the leaf certificate.
+func validateTLSSecretData(data map[string][]byte, now time.Time) error {
+	certs, err := parseCertificateChain(data[v1.TLSCertKey])
+	if err != nil {
+		return err
+	}
+	leaf := certs[0]
+
+	if now.After(leaf.NotAfter) {
+		return fmt.Errorf("certificate expired on %s", leaf.NotAfter.UTC().Format(time.RFC3339))
+	}
+	for i := 0; i < len(certs)-1; i++ {
+		if err := certs[i].CheckSignatureFrom(certs[i+1]); err != nil {
+			return fmt.Errorf("certificate %d of the chain is not issued by the next certificate: %w", i, err)
+		}
+	}
+
+	key, err := parsePrivateKey(data[v1.TLSPrivateKeyKey])
+	if err != nil {
+		return err
+	}
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSAKeySize {
+			return fmt.Errorf("RSA private key size %d is lower than %d bits", k.N.BitLen(), minRSAKeySize)
+		}
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() && k.Curve != elliptic.P384() {
+			return fmt.Errorf("ECDSA private key curve %s is not supported, must be P-256 or P-384", k.Curve.Params().Name)
+		}
+	default:
+		return fmt.Errorf("private key type %T is not supported, must be RSA or ECDSA", key)
+	}
+
+	pub, ok := key.(interface{ Public() crypto.PublicKey })
+	if !ok {
+		return fmt.Errorf("private key type %T is not supported, must be RSA or ECDSA", key)
+	}
+	if equal, ok := pub.Public().(interface{ Equal(crypto.PublicKey) bool }); !ok || !equal.Equal(leaf.PublicKey) {
+		return errors.New("private key does not match the certificate")
+	}
+
+	return nil
+}
+
+// parseCertificateChain parses the PEM encoded certificates of data.
+func parseCertificateChain(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+	return certs, nil
+}
+
+// parsePrivateKey parses the PEM encoded private key of data, in either the
+// PKCS #1, SEC 1 or PKCS #8 format.
+func parsePrivateKey(data []byte) (crypto.PrivateKey, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("no PEM encoded private key found")
+		}
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			return x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			return x509.ParseECPrivateKey(block.Bytes)
+		case "PRIVATE KEY":
+			return x509.ParsePKCS8PrivateKey(block.Bytes)
+		}
+	}
+}
+
+// buildBackendTLSConfig resolves the TLS configuration of the connections to
+// the backends defined by the provided BackendTrafficPolicy. It returns nil if
+// the policy does not configure TLS, and an error if a referenced Secret is not
+// found or does not hold valid TLS material, or if the policy enables SPIFFE
+// while spiffe, the SPIFFE configuration of Envoy Gateway, is nil.
+func buildBackendTLSConfig(policy *v1alpha1.BackendTrafficPolicy, resources *Resources, spiffe *v1alpha1.SPIFFE, now time.Time) (*ir.BackendTLSConfig, error) {
+	if policy == nil || policy.Spec.TLS == nil {
+		return nil, nil
+	}
+	tlsConfig := policy.Spec.TLS
+
+	backendTLS := new(ir.BackendTLSConfig)
+	if tlsConfig.SNI != nil {
+		backendTLS.SNI = *tlsConfig.SNI
+	}
+	for _, protocol := range tlsConfig.ALPNProtocols {
+		backendTLS.ALPNProtocols = append(backendTLS.ALPNProtocols, string(protocol))
+	}
+
+	// The SVID and trust bundle are fetched by the proxies from the Workload
+	// API, so the referenced Secrets are ignored.
+	if tlsConfig.SPIFFE {
+		if spiffe == nil {
+			return nil, errors.New("SPIFFE is not enabled in the Envoy Gateway configuration")
+		}
+		backendTLS.SPIFFE = true
+		return backendTLS, nil
+	}
+
+	if ref := tlsConfig.ClientCertificateRef; ref != nil {
+		secret, err := getPolicySecret(policy, ref, resources)
+		if err != nil {
+			return nil, err
+		}
+		if secret.Type != v1.SecretTypeTLS {
+			return nil, fmt.Errorf("secret %s/%s must be of type %s", secret.Namespace, secret.Name, v1.SecretTypeTLS)
+		}
+		if err := validateTLSSecretData(secret.Data, now); err != nil {
+			return nil, fmt.Errorf("secret %s/%s must contain valid TLS material: %w", secret.Namespace, secret.Name, err)
+		}
+		backendTLS.ClientCertificate = secret.Data[v1.TLSCertKey]
+		backendTLS.PrivateKey = secret.Data[v1.TLSPrivateKeyKey]
+	}
+
+	if ref := tlsConfig.CACertificateRef; ref != nil {
+		secret, err := getPolicySecret(policy, ref, resources)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := parseCertificateChain(secret.Data[caCertKey]); err != nil {
+			return nil, fmt.Errorf("secret %s/%s must contain valid CA certificates under the %s key: %w", secret.Namespace, secret.Name, caCertKey, err)
+		}
+		backendTLS.CACertificate = secret.Data[caCertKey]
+	}
+
+	return backendTLS, nil
+}
+
+// getPolicySecret returns the Secret referenced by the provided policy. The
+// Secret must be in the namespace of the policy.
+func getPolicySecret(policy *v1alpha1.BackendTrafficPolicy, ref *v1beta1.SecretObjectReference, resources *Resources) (*v1.Secret, error) {
+	if (ref.Group != nil && *ref.Group != "") || (ref.Kind != nil && *ref.Kind != KindSecret) {
+		return nil, fmt.Errorf("reference to %s is not supported, only Secrets are supported", ref.Name)
+	}
+	if ref.Namespace != nil && string(*ref.Namespace) != policy.Namespace {
+		return nil, fmt.Errorf("secret %s/%s must be in the namespace of the policy", *ref.Namespace, ref.Name)
+	}
+	secret := resources.GetSecret(policy.Namespace, string(ref.Name))
+	if secret == nil {
+		return nil, fmt.Errorf("secret %s/%s does not exist", policy.Namespace, ref.Name)
+	}
+	return secret, nil
+}
+
+// ocspStaplePolicy returns the OCSP staple policy set by the
+// OCSPStaplePolicyOption of the provided listener TLS configuration, or an
+// empty policy if unset.
+func ocspStaplePolicy(tlsConfig *v1beta1.GatewayTLSConfig) (ir.OCSPStaplePolicy, error) {
+	if tlsConfig == nil {
+		return "", nil
+	}
+	value, ok := tlsConfig.Options[OCSPStaplePolicyOption]
+	if !ok {
+		return "", nil
+	}
+	switch policy := ir.OCSPStaplePolicy(value); policy {
+	case ir.OCSPStaplePolicyLenient, ir.OCSPStaplePolicyStrict, ir.OCSPStaplePolicyMustStaple:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("policy %q is not supported, must be %s, %s or %s", value,
+			ir.OCSPStaplePolicyLenient, ir.OCSPStaplePolicyStrict, ir.OCSPStaplePolicyMustStaple)
+	}
+}
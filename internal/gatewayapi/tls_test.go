@@ -0,0 +1,169 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+)
+
+// testCertificate issues a certificate for the public key of key, signed by
+// the parent certificate and its key, or self-signed if parent is nil.
+func testCertificate(t *testing.T, key crypto.Signer, parent *x509.Certificate, parentKey crypto.Signer, notAfter time.Time, ca bool) *x509.Certificate {
+	t.Helper()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "foo.bar.com"},
+		NotBefore:             notAfter.Add(-24 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  ca,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func encodeCertificates(certs ...*x509.Certificate) []byte {
+	var data []byte
+	for _, cert := range certs {
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	return data
+}
+
+func encodePrivateKey(t *testing.T, key crypto.Signer) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func TestValidateTLSSecretData(t *testing.T) {
+	now := time.Now()
+	notAfter := now.Add(24 * time.Hour)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherECKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	p521Key, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	weakRSAKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	caCert := testCertificate(t, otherECKey, nil, nil, notAfter, true)
+	leafCert := testCertificate(t, ecKey, caCert, otherECKey, notAfter, false)
+
+	testCases := []struct {
+		name    string
+		cert    []byte
+		key     []byte
+		wantErr string
+	}{
+		{
+			name: "valid ECDSA certificate",
+			cert: encodeCertificates(testCertificate(t, ecKey, nil, nil, notAfter, false)),
+			key:  encodePrivateKey(t, ecKey),
+		},
+		{
+			name: "valid RSA certificate with PKCS #1 key",
+			cert: encodeCertificates(testCertificate(t, rsaKey, nil, nil, notAfter, false)),
+			key:  pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}),
+		},
+		{
+			name: "valid certificate chain",
+			cert: encodeCertificates(leafCert, caCert),
+			key:  encodePrivateKey(t, ecKey),
+		},
+		{
+			name:    "certificate chain in the wrong order",
+			cert:    encodeCertificates(caCert, leafCert),
+			key:     encodePrivateKey(t, ecKey),
+			wantErr: "certificate 0 of the chain is not issued by the next certificate",
+		},
+		{
+			name:    "no certificate",
+			cert:    []byte("foo"),
+			key:     encodePrivateKey(t, ecKey),
+			wantErr: "no PEM encoded certificate found",
+		},
+		{
+			name:    "expired certificate",
+			cert:    encodeCertificates(testCertificate(t, ecKey, nil, nil, now.Add(-time.Hour), false)),
+			key:     encodePrivateKey(t, ecKey),
+			wantErr: "certificate expired on",
+		},
+		{
+			name:    "no private key",
+			cert:    encodeCertificates(testCertificate(t, ecKey, nil, nil, notAfter, false)),
+			key:     []byte("bar"),
+			wantErr: "no PEM encoded private key found",
+		},
+		{
+			name:    "mismatched private key",
+			cert:    encodeCertificates(testCertificate(t, ecKey, nil, nil, notAfter, false)),
+			key:     encodePrivateKey(t, otherECKey),
+			wantErr: "private key does not match the certificate",
+		},
+		{
+			name:    "weak RSA private key",
+			cert:    encodeCertificates(testCertificate(t, weakRSAKey, nil, nil, notAfter, false)),
+			key:     encodePrivateKey(t, weakRSAKey),
+			wantErr: "RSA private key size 1024 is lower than 2048 bits",
+		},
+		{
+			name:    "unsupported ECDSA curve",
+			cert:    encodeCertificates(testCertificate(t, p521Key, nil, nil, notAfter, false)),
+			key:     encodePrivateKey(t, p521Key),
+			wantErr: "ECDSA private key curve P-521 is not supported",
+		},
+		{
+			name:    "unsupported private key type",
+			cert:    encodeCertificates(testCertificate(t, edKey, nil, nil, notAfter, false)),
+			key:     encodePrivateKey(t, edKey),
+			wantErr: "private key type ed25519.PrivateKey is not supported",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateTLSSecretData(map[string][]byte{
+				v1.TLSCertKey:       tc.cert,
+				v1.TLSPrivateKeyKey: tc.key,
+			}, now)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+// TrafficShiftReasonBackendRefsNotFound is the reason of the Accepted
+// condition of a traffic shift whose route has no rule with both the stable
+// and the canary backendRefs.
+const TrafficShiftReasonBackendRefsNotFound = "BackendRefsNotFound"
+
+// trafficShiftForRoute returns the TrafficShift targeting the provided
+// HTTPRoute, or nil if there is none, following the same rules as
+// backendTrafficPolicyForRoute.
+func trafficShiftForRoute(shifts []*v1alpha1.TrafficShift, route *v1beta1.HTTPRoute) *v1alpha1.TrafficShift {
+	var selected *v1alpha1.TrafficShift
+	for _, shift := range shifts {
+		if !policyTargetsRoute(shift.Namespace, &shift.Spec.TargetRef, nil, route) {
+			continue
+		}
+		if selected == nil || isOlderPolicy(&shift.ObjectMeta, &selected.ObjectMeta) {
+			selected = shift
+		}
+	}
+	return selected
+}
+
+// shiftBackendRefs returns the backendRefs of a rule of a route in the provided
+// namespace, with the weights of the stable and canary backendRefs replaced by
+// the traffic shift if the rule has both. The backendRef whose weight is
+// shifted to 0 is left out, so that it receives no requests at all. The
+// backendRefs are copied rather than modified.
+func shiftBackendRefs(backendRefs []v1beta1.HTTPBackendRef, shift *v1alpha1.TrafficShift, namespace string) []v1beta1.HTTPBackendRef {
+	stable, canary := shiftedBackendRefs(backendRefs, shift, namespace)
+	if stable < 0 || canary < 0 {
+		return backendRefs
+	}
+
+	weights := map[int]int32{
+		stable: 100 - shift.Spec.CanaryWeight,
+		canary: shift.Spec.CanaryWeight,
+	}
+	shifted := make([]v1beta1.HTTPBackendRef, 0, len(backendRefs))
+	for i, backendRef := range backendRefs {
+		if weight, ok := weights[i]; ok {
+			if weight == 0 {
+				continue
+			}
+			backendRef.Weight = &weight
+		}
+		shifted = append(shifted, backendRef)
+	}
+	return shifted
+}
+
+// shiftedBackendRefs returns the indexes of the stable and canary backendRefs
+// of the traffic shift among the provided backendRefs, or -1 if not found.
+func shiftedBackendRefs(backendRefs []v1beta1.HTTPBackendRef, shift *v1alpha1.TrafficShift, namespace string) (stable, canary int) {
+	stable, canary = -1, -1
+	if shift == nil {
+		return stable, canary
+	}
+	for i, backendRef := range backendRefs {
+		switch {
+		case stable < 0 && backendRefMatches(shift.Spec.StableRef, backendRef.BackendObjectReference, namespace):
+			stable = i
+		case canary < 0 && backendRefMatches(shift.Spec.CanaryRef, backendRef.BackendObjectReference, namespace):
+			canary = i
+		}
+	}
+	return stable, canary
+}
+
+// backendRefMatches returns true if the backendRef of a route in the provided
+// namespace references the backend identified by ref. The ports are only
+// compared if the port of ref is set.
+func backendRefMatches(ref, backendRef v1beta1.BackendObjectReference, namespace string) bool {
+	if GroupDerefOr(ref.Group, "") != GroupDerefOr(backendRef.Group, "") {
+		return false
+	}
+	if KindDerefOr(ref.Kind, KindService) != KindDerefOr(backendRef.Kind, KindService) {
+		return false
+	}
+	if NamespaceDerefOr(ref.Namespace, namespace) != NamespaceDerefOr(backendRef.Namespace, namespace) {
+		return false
+	}
+	if ref.Name != backendRef.Name {
+		return false
+	}
+	return ref.Port == nil || (backendRef.Port != nil && *ref.Port == *backendRef.Port)
+}
+
+// setTrafficShiftBackendRefsConditions sets the Accepted condition of the
+// traffic shifts applying to a route without a rule having both the stable
+// and the canary backendRefs to False.
+func setTrafficShiftBackendRefsConditions(shifts []*v1alpha1.TrafficShift, httpRoutes []*HTTPRouteContext) {
+	routes := make(map[string]*HTTPRouteContext, len(httpRoutes))
+	for _, route := range httpRoutes {
+		routes[route.Namespace+"/"+route.Name] = route
+	}
+
+	for _, shift := range shifts {
+		for i := range shift.Status.Ancestors {
+			ancestor := &shift.Status.Ancestors[i]
+			cond := &ancestor.Conditions[0]
+			if cond.Status != metav1.ConditionTrue {
+				continue
+			}
+			route := routes[NamespaceDerefOr(ancestor.AncestorRef.Namespace, shift.Namespace)+"/"+string(ancestor.AncestorRef.Name)]
+			if route == nil || routeHasShiftedBackendRefs(route, shift) {
+				continue
+			}
+			cond.Status = metav1.ConditionFalse
+			cond.Reason = TrafficShiftReasonBackendRefsNotFound
+			cond.Message = "The HTTPRoute has no rule with both the stable and the canary backendRefs."
+		}
+	}
+}
+
+// routeHasShiftedBackendRefs returns true if a rule of the route has both the
+// stable and the canary backendRefs of the traffic shift.
+func routeHasShiftedBackendRefs(route *HTTPRouteContext, shift *v1alpha1.TrafficShift) bool {
+	for _, rule := range route.Spec.Rules {
+		if stable, canary := shiftedBackendRefs(rule.BackendRefs, shift, route.Namespace); stable >= 0 && canary >= 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func TestTrafficShift(t *testing.T) {
+	testCases := []struct {
+		name         string
+		canaryRef    v1beta1.ObjectName
+		canaryWeight int32
+		// expectedWeights are the weights of the destinations of the route by
+		// host.
+		expectedWeights map[string]uint32
+		expectedReason  string
+	}{
+		{
+			name:            "canary weight shifted",
+			canaryRef:       "service-2",
+			canaryWeight:    10,
+			expectedWeights: map[string]uint32{"7.7.7.7": 90, "8.8.8.8": 10},
+			expectedReason:  PolicyReasonAccepted,
+		},
+		{
+			name:            "stable backend left out",
+			canaryRef:       "service-2",
+			canaryWeight:    100,
+			expectedWeights: map[string]uint32{"8.8.8.8": 100},
+			expectedReason:  PolicyReasonAccepted,
+		},
+		{
+			name:            "canary backendRef not found",
+			canaryRef:       "service-3",
+			canaryWeight:    10,
+			expectedWeights: map[string]uint32{"7.7.7.7": 1, "8.8.8.8": 1},
+			expectedReason:  TrafficShiftReasonBackendRefsNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			resources := attachedRoutesResources(t)
+			resources.Services = append(resources.Services, &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "service-2"},
+				Spec: v1.ServiceSpec{
+					ClusterIP: "8.8.8.8",
+					Ports:     []v1.ServicePort{{Port: 8080}},
+				},
+			})
+			port := v1beta1.PortNumber(8080)
+			rule := &resources.HTTPRoutes[0].Spec.Rules[0]
+			rule.BackendRefs = append(rule.BackendRefs, v1beta1.HTTPBackendRef{
+				BackendRef: v1beta1.BackendRef{
+					BackendObjectReference: v1beta1.BackendObjectReference{Name: "service-2", Port: &port},
+				},
+			})
+			resources.TrafficShifts = []*v1alpha1.TrafficShift{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "shift"},
+				Spec: v1alpha1.TrafficShiftSpec{
+					TargetRef: v1alpha2.PolicyTargetReference{
+						Group: v1beta1.GroupName,
+						Kind:  KindHTTPRoute,
+						Name:  "httproute-1",
+					},
+					StableRef:    v1beta1.BackendObjectReference{Name: "service-1"},
+					CanaryRef:    v1beta1.BackendObjectReference{Name: tc.canaryRef},
+					CanaryWeight: tc.canaryWeight,
+				},
+			}}
+
+			translator := &Translator{GatewayClassName: "envoy-gateway-class"}
+			result := translator.Translate(resources)
+
+			listener := result.XdsIR[IRKey("envoy-gateway", "gateway-1")].GetHTTPListener("envoy-gateway-gateway-1-http")
+			require.NotNil(t, listener)
+			var route *ir.HTTPRoute
+			for _, r := range listener.Routes {
+				if r.Name == "default-httproute-1-rule-0-match-0-*" {
+					route = r
+				}
+			}
+			require.NotNil(t, route)
+			weights := make(map[string]uint32, len(route.Destinations))
+			for _, dest := range route.Destinations {
+				weights[dest.Host] = dest.Weight
+			}
+			require.Equal(t, tc.expectedWeights, weights)
+
+			require.Len(t, result.TrafficShifts, 1)
+			require.Len(t, result.TrafficShifts[0].Status.Ancestors, 1)
+			require.Equal(t, tc.expectedReason, result.TrafficShifts[0].Status.Ancestors[0].Conditions[0].Reason)
+		})
+	}
+}
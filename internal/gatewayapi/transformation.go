@@ -0,0 +1,115 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// templateBody is the template placeholder of the original body.
+	templateBody = "body"
+	// templateHeaderPrefix is the prefix of the template placeholders of the
+	// header values.
+	templateHeaderPrefix = "header."
+	// templateQueryPrefix is the prefix of the template placeholders of the
+	// query parameter values.
+	templateQueryPrefix = "query."
+)
+
+// buildTransformation returns the transformation of the BackendTrafficPolicy,
+// or nil if the requests and responses are forwarded unchanged. An error is
+// returned if a header name or a body template is invalid.
+func buildTransformation(policy *v1alpha1.BackendTrafficPolicy) (*ir.Transformation, error) {
+	if policy == nil || policy.Spec.Transformation == nil {
+		return nil, nil
+	}
+	spec := policy.Spec.Transformation
+
+	transformation := &ir.Transformation{}
+	var err error
+	if transformation.HeadersToQuery, err = buildHeaderQueryMappings(spec.HeadersToQuery); err != nil {
+		return nil, err
+	}
+	if transformation.QueryToHeaders, err = buildHeaderQueryMappings(spec.QueryToHeaders); err != nil {
+		return nil, err
+	}
+	if spec.RequestBody != nil {
+		if transformation.RequestBody, err = parseBodyTemplate(*spec.RequestBody, true); err != nil {
+			return nil, fmt.Errorf("invalid request body template: %w", err)
+		}
+	}
+	if spec.ResponseBody != nil {
+		if transformation.ResponseBody, err = parseBodyTemplate(*spec.ResponseBody, false); err != nil {
+			return nil, fmt.Errorf("invalid response body template: %w", err)
+		}
+	}
+	return transformation, nil
+}
+
+func buildHeaderQueryMappings(mappings []v1alpha1.HeaderQueryMapping) ([]ir.HeaderQueryMapping, error) {
+	var ret []ir.HeaderQueryMapping
+	for _, mapping := range mappings {
+		if !isValidHeaderName(mapping.Header) {
+			return nil, fmt.Errorf("the header %q is not a valid header name", mapping.Header)
+		}
+		if mapping.QueryParam == "" {
+			return nil, fmt.Errorf("the query parameter of the header %q is empty", mapping.Header)
+		}
+		ret = append(ret, ir.HeaderQueryMapping{
+			Header:     strings.ToLower(mapping.Header),
+			QueryParam: mapping.QueryParam,
+			Remove:     mapping.Remove,
+		})
+	}
+	return ret, nil
+}
+
+// parseBodyTemplate parses the provided body template into its parts. The
+// query parameter placeholders are only allowed in request body templates.
+func parseBodyTemplate(tmpl string, allowQuery bool) (*ir.BodyTemplate, error) {
+	body := &ir.BodyTemplate{}
+	for tmpl != "" {
+		start := strings.Index(tmpl, "{{")
+		if start < 0 {
+			body.Parts = append(body.Parts, ir.TemplatePart{Literal: tmpl})
+			break
+		}
+		if start > 0 {
+			body.Parts = append(body.Parts, ir.TemplatePart{Literal: tmpl[:start]})
+		}
+		end := strings.Index(tmpl[start:], "}}")
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated placeholder %q", tmpl[start:])
+		}
+
+		placeholder := strings.TrimSpace(tmpl[start+2 : start+end])
+		switch {
+		case placeholder == templateBody:
+			body.Parts = append(body.Parts, ir.TemplatePart{Body: true})
+		case strings.HasPrefix(placeholder, templateHeaderPrefix):
+			name := strings.TrimPrefix(placeholder, templateHeaderPrefix)
+			if !isValidHeaderName(name) {
+				return nil, fmt.Errorf("the header %q is not a valid header name", name)
+			}
+			body.Parts = append(body.Parts, ir.TemplatePart{Header: strings.ToLower(name)})
+		case strings.HasPrefix(placeholder, templateQueryPrefix) && allowQuery:
+			name := strings.TrimPrefix(placeholder, templateQueryPrefix)
+			if name == "" {
+				return nil, fmt.Errorf("the query parameter of the placeholder %q is empty", placeholder)
+			}
+			body.Parts = append(body.Parts, ir.TemplatePart{QueryParam: name})
+		default:
+			return nil, fmt.Errorf("unsupported placeholder %q", placeholder)
+		}
+		tmpl = tmpl[start+end+2:]
+	}
+	return body, nil
+}
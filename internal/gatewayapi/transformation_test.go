@@ -0,0 +1,102 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func TestBuildTransformation(t *testing.T) {
+	testCases := []struct {
+		name           string
+		transformation *v1alpha1.Transformation
+		want           *ir.Transformation
+		wantErr        string
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name: "headers and query parameters",
+			transformation: &v1alpha1.Transformation{
+				HeadersToQuery: []v1alpha1.HeaderQueryMapping{{Header: "X-Api-Key", QueryParam: "api_key", Remove: true}},
+				QueryToHeaders: []v1alpha1.HeaderQueryMapping{{Header: "x-version", QueryParam: "v"}},
+			},
+			want: &ir.Transformation{
+				HeadersToQuery: []ir.HeaderQueryMapping{{Header: "x-api-key", QueryParam: "api_key", Remove: true}},
+				QueryToHeaders: []ir.HeaderQueryMapping{{Header: "x-version", QueryParam: "v"}},
+			},
+		},
+		{
+			name: "body templates",
+			transformation: &v1alpha1.Transformation{
+				RequestBody:  StringPtr(`{"user": "{{ header.X-User }}", "page": "{{query.page}}", "data": {{body}}}`),
+				ResponseBody: StringPtr(""),
+			},
+			want: &ir.Transformation{
+				RequestBody: &ir.BodyTemplate{Parts: []ir.TemplatePart{
+					{Literal: `{"user": "`},
+					{Header: "x-user"},
+					{Literal: `", "page": "`},
+					{QueryParam: "page"},
+					{Literal: `", "data": `},
+					{Body: true},
+					{Literal: "}"},
+				}},
+				ResponseBody: &ir.BodyTemplate{},
+			},
+		},
+		{
+			name: "invalid header",
+			transformation: &v1alpha1.Transformation{
+				HeadersToQuery: []v1alpha1.HeaderQueryMapping{{Header: "x api key", QueryParam: "api_key"}},
+			},
+			wantErr: `the header "x api key" is not a valid header name`,
+		},
+		{
+			name: "empty query parameter",
+			transformation: &v1alpha1.Transformation{
+				QueryToHeaders: []v1alpha1.HeaderQueryMapping{{Header: "x-version"}},
+			},
+			wantErr: `the query parameter of the header "x-version" is empty`,
+		},
+		{
+			name: "unterminated placeholder",
+			transformation: &v1alpha1.Transformation{
+				RequestBody: StringPtr("{{body"),
+			},
+			wantErr: `invalid request body template: unterminated placeholder "{{body"`,
+		},
+		{
+			name: "query placeholder in response body",
+			transformation: &v1alpha1.Transformation{
+				ResponseBody: StringPtr("{{query.page}}"),
+			},
+			wantErr: `invalid response body template: unsupported placeholder "query.page"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			policy := &v1alpha1.BackendTrafficPolicy{
+				Spec: v1alpha1.BackendTrafficPolicySpec{Transformation: tc.transformation},
+			}
+			transformation, err := buildTransformation(policy)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, transformation)
+		})
+	}
+}
@@ -6,18 +6,23 @@
 package gatewayapi
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/netip"
 	"strings"
+	"time"
 
 	"golang.org/x/exp/slices"
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
 	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/ir"
 )
 
@@ -25,9 +30,13 @@ const (
 	KindGateway   = "Gateway"
 	KindHTTPRoute = "HTTPRoute"
 	KindTLSRoute  = "TLSRoute"
+	KindTCPRoute  = "TCPRoute"
+	KindUDPRoute  = "UDPRoute"
 	KindService   = "Service"
 	KindSecret    = "Secret"
 
+	KindReferenceGrant = "ReferenceGrant"
+
 	// OwningGatewayNamespaceLabel is the owner reference label used for managed infra.
 	// The value should be the namespace of the accepted Envoy Gateway.
 	OwningGatewayNamespaceLabel = "gateway.envoyproxy.io/owning-gateway-namespace"
@@ -36,11 +45,58 @@ const (
 	// The value should be the name of the accepted Envoy Gateway.
 	OwningGatewayNameLabel = "gateway.envoyproxy.io/owning-gateway-name"
 
+	// AttachedRouteKindsAnnotation is the Gateway annotation recording the number
+	// of attached routes per route kind of each listener, as a JSON object keyed
+	// by listener name.
+	AttachedRouteKindsAnnotation = "gateway.envoyproxy.io/attached-route-kinds"
+
+	// BackendHostRewriteAnnotation is the HTTPRoute annotation which, if set to
+	// "true", rewrites the Host header of the requests to the FQDN of the backend
+	// Service they are forwarded to.
+	BackendHostRewriteAnnotation = "gateway.envoyproxy.io/backend-host-rewrite"
+
+	// HTTPSRedirectAnnotation is the Gateway annotation which, if set to "true",
+	// generates an HTTP listener on port 80 redirecting the requests to the
+	// HTTPS listeners of the Gateway with the same hostname.
+	HTTPSRedirectAnnotation = "gateway.envoyproxy.io/https-redirect"
+
+	// ListenerShardsAnnotation is the Gateway annotation setting the number of
+	// managed Deployments and Services the listeners of the Gateway are sharded
+	// across, by port.
+	ListenerShardsAnnotation = "gateway.envoyproxy.io/listener-shards"
+
+	// ListenerShardLabel is the label of the managed infra of a sharded Gateway.
+	// The value is the index of the shard.
+	ListenerShardLabel = "gateway.envoyproxy.io/listener-shard"
+
+	// RevisionLabel is the label of the managed Envoy Deployments upgraded with
+	// the BlueGreen upgrade strategy. The value identifies the image of the
+	// Deployment, and is set on the selector of the Service to the revision
+	// serving the traffic.
+	RevisionLabel = "gateway.envoyproxy.io/revision"
+
+	// OCSPStaplePolicyOption is the listener TLS option defining how the OCSP
+	// staple held by the certificate Secret under the tls.ocsp-staple key is
+	// used: LenientStapling (default), StrictStapling or MustStaple.
+	OCSPStaplePolicyOption = "gateway.envoyproxy.io/ocsp-staple-policy"
+
+	// httpRedirectPort is the port of the listeners generated by the
+	// HTTPSRedirectAnnotation.
+	httpRedirectPort = 80
+
 	// minEphemeralPort is the first port in the ephemeral port range.
 	minEphemeralPort = 1024
 	// wellKnownPortShift is the constant added to the well known port (1-1023)
 	// to convert it into an ephemeral port.
 	wellKnownPortShift = 10000
+
+	// IPv4ListenerAddress is the address proxy listeners bind to when
+	// the Envoy service is assigned IPv4 addresses only.
+	IPv4ListenerAddress = "0.0.0.0"
+	// IPv6ListenerAddress is the address proxy listeners bind to when the
+	// Envoy service is assigned IPv6 addresses. It also accepts IPv4
+	// connections, so dual-stack services are supported.
+	IPv6ListenerAddress = "::"
 )
 
 type XdsIRMap map[string]*ir.Xds
@@ -52,10 +108,36 @@ type Resources struct {
 	Gateways        []*v1beta1.Gateway
 	HTTPRoutes      []*v1beta1.HTTPRoute
 	TLSRoutes       []*v1alpha2.TLSRoute
+	TCPRoutes       []*v1alpha2.TCPRoute
+	UDPRoutes       []*v1alpha2.UDPRoute
 	ReferenceGrants []*v1alpha2.ReferenceGrant
 	Namespaces      []*v1.Namespace
 	Services        []*v1.Service
 	Secrets         []*v1.Secret
+	// EndpointSlices are the EndpointSlices of the Services, only
+	// set if the backend readiness gating is enabled.
+	EndpointSlices []*discoveryv1.EndpointSlice
+	// EnvoyProxy is the optional EnvoyProxy referenced by
+	// the parametersRef of the managed GatewayClass.
+	EnvoyProxy *v1alpha1.EnvoyProxy
+	// BackendTrafficPolicies are the policies configuring the traffic
+	// between the proxy and the backends of the targeted routes.
+	BackendTrafficPolicies []*v1alpha1.BackendTrafficPolicy
+	// ClientTrafficPolicies are the policies configuring the traffic
+	// between the clients and the proxy on the targeted Gateways.
+	ClientTrafficPolicies []*v1alpha1.ClientTrafficPolicy
+	// SecurityPolicies are the policies configuring the authentication
+	// and authorization of the requests to the targeted routes.
+	SecurityPolicies []*v1alpha1.SecurityPolicy
+	// TrafficShifts shift the requests between the stable and canary
+	// backendRefs of the targeted routes.
+	TrafficShifts []*v1alpha1.TrafficShift
+	// SRVRecords are the resolved targets of the SRV records of the
+	// Services annotated with DNSSRVAnnotation, by SRV record name.
+	SRVRecords map[string][]SRVRecord
+	// RequestRates are the requests per second received by the Gateways,
+	// by Gateway, only set if the traffic stats aggregation is enabled.
+	RequestRates map[types.NamespacedName]float64
 }
 
 func (r *Resources) GetNamespace(name string) *v1.Namespace {
@@ -99,19 +181,102 @@ type Translator struct {
 	// the Infra IR. If unspecified, the default proxy
 	// image will be used.
 	ProxyImage string
+
+	// RecordAttachedRouteKinds enables recording the number of
+	// attached routes per route kind of each listener in the
+	// AttachedRouteKindsAnnotation of the Gateways.
+	RecordAttachedRouteKinds bool
+
+	// RecordListenerTLS enables recording the TLS configuration
+	// served by each listener in the ListenerTLSAnnotation of
+	// the Gateways.
+	RecordListenerTLS bool
+
+	// RateLimitService is the optional global rate limit
+	// service configured on the HTTP listeners.
+	RateLimitService *ir.RateLimitService
+
+	// Timeouts are the optional default timeouts of the
+	// HTTP listeners and routes, overridden by the ones
+	// of the EnvoyProxy.
+	Timeouts *v1alpha1.Timeouts
+
+	// SPIFFE is the optional configuration of the SPIFFE
+	// Workload API the proxies source their identity from.
+	SPIFFE *v1alpha1.SPIFFE
+
+	// MeshMode enables the translation of the HTTPRoutes attached
+	// to Services into the IR with the MeshIRKey.
+	MeshMode bool
+
+	// Limits are the optional limits of the translated resources.
+	Limits *v1alpha1.TranslationLimits
+
+	// Tenancy optionally restricts the namespaces allowed to
+	// attach routes to the Gateways of each tenant.
+	Tenancy *v1alpha1.Tenancy
+
+	// TrafficStats enables the proxies to push their stats
+	// to Envoy Gateway to aggregate their traffic statistics.
+	TrafficStats bool
+
+	// StrictValidation rejects the Gateways and routes with an
+	// invalid field or an unresolved reference as a whole,
+	// instead of programming their valid subset.
+	StrictValidation bool
+
+	// BackendReadinessGating enables the Programmed condition of
+	// the HTTPRoutes, reporting whether one of their backends has
+	// a ready endpoint.
+	BackendReadinessGating bool
+
+	// SelfSignedCertificates optionally serves a self-signed
+	// certificate on the listeners terminating TLS whose
+	// certificate Secret does not exist, instead of rejecting them.
+	SelfSignedCertificates *SelfSignedCertificates
+
+	// ControllerName is the name of the controller written in the
+	// parent statuses of the routes, defaulting to the Envoy Gateway
+	// one.
+	ControllerName string
+
+	// EmptyBackendRefs optionally defines how the rules of the
+	// HTTPRoutes without backendRefs are programmed, defaulting
+	// to a 500 direct response.
+	EmptyBackendRefs *v1alpha1.EmptyBackendRefs
+
+	// Clock optionally returns the current time the certificates
+	// are validated against, defaulting to time.Now.
+	Clock func() time.Time
+
+	// limits tracks the resources translated against the Limits
+	// during a translation.
+	limits *translationLimits
+
+	// conditionTime is the transition time of the conditions set
+	// during a translation.
+	conditionTime metav1.Time
 }
 
 type TranslateResult struct {
 	Gateways   []*v1beta1.Gateway
 	HTTPRoutes []*v1beta1.HTTPRoute
 	TLSRoutes  []*v1alpha2.TLSRoute
-	XdsIR      XdsIRMap
-	InfraIR    InfraIRMap
+	TCPRoutes  []*v1alpha2.TCPRoute
+	UDPRoutes  []*v1alpha2.UDPRoute
+	// The policies and traffic shifts are copies of the inputs with their
+	// status.
+	BackendTrafficPolicies []*v1alpha1.BackendTrafficPolicy
+	ClientTrafficPolicies  []*v1alpha1.ClientTrafficPolicy
+	SecurityPolicies       []*v1alpha1.SecurityPolicy
+	TrafficShifts          []*v1alpha1.TrafficShift
+	XdsIR                  XdsIRMap
+	InfraIR                InfraIRMap
 }
 
 func newTranslateResult(gateways []*GatewayContext,
-	httpRoutes []*HTTPRouteContext, tlsRoutes []*TLSRouteContext,
-	xdsIR XdsIRMap, infraIR InfraIRMap) *TranslateResult {
+	httpRoutes []*HTTPRouteContext, tlsRoutes []*TLSRouteContext, tcpRoutes []*TCPRouteContext,
+	udpRoutes []*UDPRouteContext, xdsIR XdsIRMap, infraIR InfraIRMap) *TranslateResult {
 	translateResult := &TranslateResult{
 		XdsIR:   xdsIR,
 		InfraIR: infraIR,
@@ -126,13 +291,52 @@ func newTranslateResult(gateways []*GatewayContext,
 	for _, tlsRoute := range tlsRoutes {
 		translateResult.TLSRoutes = append(translateResult.TLSRoutes, tlsRoute.TLSRoute)
 	}
+	for _, tcpRoute := range tcpRoutes {
+		translateResult.TCPRoutes = append(translateResult.TCPRoutes, tcpRoute.TCPRoute)
+	}
+	for _, udpRoute := range udpRoutes {
+		translateResult.UDPRoutes = append(translateResult.UDPRoutes, udpRoute.UDPRoute)
+	}
 
 	return translateResult
 }
 
+// recordAttachedRouteKinds sets the AttachedRouteKindsAnnotation of the provided
+// Gateways based on the routes attached to their listeners.
+func recordAttachedRouteKinds(gateways []*GatewayContext) {
+	for _, gateway := range gateways {
+		kinds := make(map[v1beta1.SectionName]map[string]int32, len(gateway.listeners))
+		for _, listener := range gateway.listeners {
+			listenerKinds := listener.AttachedRouteKinds()
+			if listenerKinds == nil {
+				listenerKinds = map[string]int32{}
+			}
+			kinds[listener.Name] = listenerKinds
+		}
+
+		// Marshaling maps of strings and integers can't fail, and keys are
+		// sorted so that the annotation is stable.
+		value, _ := json.Marshal(kinds)
+		if gateway.Annotations == nil {
+			gateway.Annotations = make(map[string]string)
+		}
+		gateway.Annotations[AttachedRouteKindsAnnotation] = string(value)
+	}
+}
+
+// now returns the current time of the Clock of the translator.
+func (t *Translator) now() time.Time {
+	if t.Clock != nil {
+		return t.Clock()
+	}
+	return time.Now()
+}
+
 func (t *Translator) Translate(resources *Resources) *TranslateResult {
 	xdsIR := make(XdsIRMap)
 	infraIR := make(InfraIRMap)
+	t.limits = newTranslationLimits(t.Limits)
+	t.conditionTime = metav1.NewTime(t.now())
 
 	// Get Gateways belonging to our GatewayClass.
 	gateways := t.GetRelevantGateways(resources.Gateways)
@@ -140,16 +344,49 @@ func (t *Translator) Translate(resources *Resources) *TranslateResult {
 	// Process all Listeners for all relevant Gateways.
 	t.ProcessListeners(gateways, xdsIR, infraIR, resources)
 
+	// Drop the self-signed certificates of the removed listeners.
+	if t.SelfSignedCertificates != nil {
+		t.SelfSignedCertificates.retain(gateways)
+	}
+
 	// Process all relevant HTTPRoutes.
 	httpRoutes := t.ProcessHTTPRoutes(resources.HTTPRoutes, gateways, resources, xdsIR)
 
+	// Report whether the backends of the HTTPRoutes have ready endpoints,
+	// if requested.
+	if t.BackendReadinessGating {
+		setBackendReadinessConditions(httpRoutes, resources)
+	}
+
 	// Process all relevant TLSRoutes.
 	tlsRoutes := t.ProcessTLSRoutes(resources.TLSRoutes, gateways, resources, xdsIR)
 
+	// Process all relevant TCPRoutes.
+	tcpRoutes := t.ProcessTCPRoutes(resources.TCPRoutes, gateways, resources, xdsIR)
+
+	// Process all relevant UDPRoutes.
+	udpRoutes := t.ProcessUDPRoutes(resources.UDPRoutes, gateways, resources, xdsIR)
+
+	if t.RecordAttachedRouteKinds {
+		recordAttachedRouteKinds(gateways)
+	}
+
+	if t.RecordListenerTLS {
+		recordListenerTLS(gateways)
+	}
+
+	// Shard the listeners of the Gateways across several proxies, if requested.
+	shardListeners(gateways, xdsIR, infraIR)
+
 	// Sort xdsIR based on the Gateway API spec
 	sortXdsIRMap(xdsIR)
 
-	return newTranslateResult(gateways, httpRoutes, tlsRoutes, xdsIR, infraIR)
+	result := newTranslateResult(gateways, httpRoutes, tlsRoutes, tcpRoutes, udpRoutes, xdsIR, infraIR)
+
+	// Compute the status of the policies for the resources they apply to.
+	t.setPolicyStatuses(result, resources, gateways, httpRoutes)
+
+	return result
 }
 
 func (t *Translator) GetRelevantGateways(gateways []*v1beta1.Gateway) []*GatewayContext {
@@ -162,9 +399,14 @@ func (t *Translator) GetRelevantGateways(gateways []*v1beta1.Gateway) []*Gateway
 
 		if gateway.Spec.GatewayClassName == t.GatewayClassName {
 			gc := &GatewayContext{
-				Gateway: gateway.DeepCopy(),
+				Gateway:               gateway.DeepCopy(),
+				tenantRouteNamespaces: tenantRouteNamespaces(t.Tenancy, gateway.Namespace),
+				conditionTime:         t.conditionTime,
 			}
 
+			// Drop the statuses of the listeners removed from the spec.
+			pruneListenerStatuses(gc.Gateway)
+
 			for _, listener := range gateway.Spec.Listeners {
 				l := gc.GetListenerContext(listener.Name)
 				// Reset conditions and attached route count
@@ -249,19 +491,28 @@ func (t *Translator) ProcessListeners(gateways []*GatewayContext, xdsIR XdsIRMap
 		}
 	}
 
+	setCrossGatewayHostnameConflicts(gateways)
+
 	// Iterate through all listeners to validate spec
 	// and compute status for each, and add valid ones
 	// to the Xds IR.
 	for _, gateway := range gateways {
 		// init IR per gateway
 		irKey := irStringKey(gateway.Gateway)
-		gwXdsIR := &ir.Xds{}
+		gwXdsIR := &ir.Xds{MaxStatsClusters: irMaxStatsClusters(resources.EnvoyProxy.GetTelemetryBudget())}
 		gwInfraIR := ir.NewInfra()
 		gwInfraIR.Proxy.Name = irKey
 		gwInfraIR.Proxy.GetProxyMetadata().Labels = GatewayOwnerLabels(gateway.Namespace, gateway.Name)
 		if len(t.ProxyImage) > 0 {
 			gwInfraIR.Proxy.Image = t.ProxyImage
 		}
+		gwInfraIR.Proxy.Config = resources.EnvoyProxy
+		gwInfraIR.Proxy.SPIFFE = t.SPIFFE
+		gwInfraIR.Proxy.TrafficStats = t.TrafficStats
+		gwInfraIR.Proxy.Addresses = requestedIPAddresses(gateway.Gateway)
+		if resources.EnvoyProxy.GetExternalDNS() != nil {
+			gwInfraIR.Proxy.Hostnames = listenerHostnames(gateway.Gateway)
+		}
 
 		// save the IR references in the map before the translation starts
 		xdsIR[irKey] = gwXdsIR
@@ -270,69 +521,34 @@ func (t *Translator) ProcessListeners(gateways []*GatewayContext, xdsIR XdsIRMap
 		// Infra IR proxy ports must be unique.
 		var foundPorts []int32
 
+		clientTrafficPolicy := clientTrafficPolicyForGateway(resources.ClientTrafficPolicies, gateway.Gateway)
+		accessLogSampleRate := irAccessLogSampleRate(resources.EnvoyProxy.GetTelemetryBudget(),
+			resources.RequestRates[types.NamespacedName{Namespace: gateway.Namespace, Name: gateway.Name}])
+		gateway.setMaintenanceCondition(clientTrafficPolicy)
+
 		for _, listener := range gateway.listeners {
 			// Process protocol & supported kinds
 			switch listener.Protocol {
 			case v1beta1.TLSProtocolType:
-				if listener.AllowedRoutes == nil || len(listener.AllowedRoutes.Kinds) == 0 {
-					listener.SetSupportedKinds(v1beta1.RouteGroupKind{Group: GroupPtr(v1beta1.GroupName), Kind: KindTLSRoute})
+				if isTLSTerminate(listener) {
+					validateAllowedRoutes(listener, KindTCPRoute)
 				} else {
-					for _, kind := range listener.AllowedRoutes.Kinds {
-						if kind.Group != nil && string(*kind.Group) != v1beta1.GroupName {
-							listener.SetCondition(
-								v1beta1.ListenerConditionResolvedRefs,
-								metav1.ConditionFalse,
-								v1beta1.ListenerReasonInvalidRouteKinds,
-								fmt.Sprintf("Group is not supported, group must be %s", v1beta1.GroupName),
-							)
-							continue
-						}
-
-						if kind.Kind != KindTLSRoute {
-							listener.SetCondition(
-								v1beta1.ListenerConditionResolvedRefs,
-								metav1.ConditionFalse,
-								v1beta1.ListenerReasonInvalidRouteKinds,
-								fmt.Sprintf("Kind is not supported, kind must be %s", KindTLSRoute),
-							)
-							continue
-						}
-						listener.SetSupportedKinds(kind)
-					}
+					validateAllowedRoutes(listener, KindTLSRoute)
 				}
+			case v1beta1.TCPProtocolType:
+				validateAllowedRoutes(listener, KindTCPRoute)
+			case v1beta1.UDPProtocolType:
+				validateAllowedRoutes(listener, KindUDPRoute)
 			case v1beta1.HTTPProtocolType, v1beta1.HTTPSProtocolType:
-				if listener.AllowedRoutes == nil || len(listener.AllowedRoutes.Kinds) == 0 {
-					listener.SetSupportedKinds(v1beta1.RouteGroupKind{Group: GroupPtr(v1beta1.GroupName), Kind: KindHTTPRoute})
-				} else {
-					for _, kind := range listener.AllowedRoutes.Kinds {
-						if kind.Group != nil && string(*kind.Group) != v1beta1.GroupName {
-							listener.SetCondition(
-								v1beta1.ListenerConditionResolvedRefs,
-								metav1.ConditionFalse,
-								v1beta1.ListenerReasonInvalidRouteKinds,
-								fmt.Sprintf("Group is not supported, group must be %s", v1beta1.GroupName),
-							)
-							continue
-						}
-
-						if kind.Kind != KindHTTPRoute {
-							listener.SetCondition(
-								v1beta1.ListenerConditionResolvedRefs,
-								metav1.ConditionFalse,
-								v1beta1.ListenerReasonInvalidRouteKinds,
-								fmt.Sprintf("Kind is not supported, kind must be %s", KindHTTPRoute),
-							)
-							continue
-						}
-						listener.SetSupportedKinds(kind)
-					}
-				}
+				validateAllowedRoutes(listener, KindHTTPRoute)
 			default:
 				listener.SetCondition(
 					v1beta1.ListenerConditionDetached,
 					metav1.ConditionTrue,
 					v1beta1.ListenerReasonUnsupportedProtocol,
-					fmt.Sprintf("Protocol %s is unsupported, must be %s or %s.", listener.Protocol, v1beta1.HTTPProtocolType, v1beta1.HTTPSProtocolType),
+					fmt.Sprintf("Protocol %s is unsupported, must be %s, %s, %s, %s or %s.", listener.Protocol,
+						v1beta1.HTTPProtocolType, v1beta1.HTTPSProtocolType, v1beta1.TLSProtocolType, v1beta1.TCPProtocolType,
+						v1beta1.UDPProtocolType),
 				)
 			}
 
@@ -395,135 +611,60 @@ func (t *Translator) ProcessListeners(gateways []*GatewayContext, xdsIR XdsIRMap
 					break
 				}
 
-				if len(listener.TLS.CertificateRefs) != 1 {
+				validateTLSCertificateRef(listener, resources, t.SelfSignedCertificates, t.now())
+			case v1beta1.TLSProtocolType:
+				if listener.TLS == nil {
 					listener.SetCondition(
 						v1beta1.ListenerConditionReady,
 						metav1.ConditionFalse,
 						v1beta1.ListenerReasonInvalid,
-						"Listener must have exactly 1 TLS certificate ref",
-					)
-					break
-				}
-
-				certificateRef := listener.TLS.CertificateRefs[0]
-
-				if certificateRef.Group != nil && string(*certificateRef.Group) != "" {
-					listener.SetCondition(
-						v1beta1.ListenerConditionResolvedRefs,
-						metav1.ConditionFalse,
-						v1beta1.ListenerReasonInvalidCertificateRef,
-						"Listener's TLS certificate ref group must be unspecified/empty.",
-					)
-					break
-				}
-
-				if certificateRef.Kind != nil && string(*certificateRef.Kind) != KindSecret {
-					listener.SetCondition(
-						v1beta1.ListenerConditionResolvedRefs,
-						metav1.ConditionFalse,
-						v1beta1.ListenerReasonInvalidCertificateRef,
-						fmt.Sprintf("Listener's TLS certificate ref kind must be %s.", KindSecret),
-					)
-					break
-				}
-
-				secretNamespace := listener.gateway.Namespace
-
-				if certificateRef.Namespace != nil && string(*certificateRef.Namespace) != "" && string(*certificateRef.Namespace) != listener.gateway.Namespace {
-					if !isValidCrossNamespaceRef(
-						crossNamespaceFrom{
-							group:     string(v1beta1.GroupName),
-							kind:      KindGateway,
-							namespace: listener.gateway.Namespace,
-						},
-						crossNamespaceTo{
-							group:     "",
-							kind:      KindSecret,
-							namespace: string(*certificateRef.Namespace),
-							name:      string(certificateRef.Name),
-						},
-						resources.ReferenceGrants,
-					) {
-						listener.SetCondition(
-							v1beta1.ListenerConditionResolvedRefs,
-							metav1.ConditionFalse,
-							v1beta1.ListenerReasonRefNotPermitted,
-							fmt.Sprintf("Certificate ref to secret %s/%s not permitted by any ReferenceGrant", *certificateRef.Namespace, certificateRef.Name),
-						)
-						break
-					}
-
-					secretNamespace = string(*certificateRef.Namespace)
-				}
-
-				secret := resources.GetSecret(secretNamespace, string(certificateRef.Name))
-
-				if secret == nil {
-					listener.SetCondition(
-						v1beta1.ListenerConditionResolvedRefs,
-						metav1.ConditionFalse,
-						v1beta1.ListenerReasonInvalidCertificateRef,
-						fmt.Sprintf("Secret %s/%s does not exist.", listener.gateway.Namespace, certificateRef.Name),
-					)
-					break
-				}
-
-				if secret.Type != v1.SecretTypeTLS {
-					listener.SetCondition(
-						v1beta1.ListenerConditionResolvedRefs,
-						metav1.ConditionFalse,
-						v1beta1.ListenerReasonInvalidCertificateRef,
-						fmt.Sprintf("Secret %s/%s must be of type %s.", listener.gateway.Namespace, certificateRef.Name, v1.SecretTypeTLS),
+						fmt.Sprintf("Listener must have TLS set when protocol is %s.", listener.Protocol),
 					)
 					break
 				}
 
-				if len(secret.Data[v1.TLSCertKey]) == 0 || len(secret.Data[v1.TLSPrivateKeyKey]) == 0 {
-					listener.SetCondition(
-						v1beta1.ListenerConditionResolvedRefs,
-						metav1.ConditionFalse,
-						v1beta1.ListenerReasonInvalidCertificateRef,
-						fmt.Sprintf("Secret %s/%s must contain %s and %s.", listener.gateway.Namespace, certificateRef.Name, v1.TLSCertKey, v1.TLSPrivateKeyKey),
-					)
+				// TLS connections are terminated and forwarded to the backends
+				// of a TCPRoute when the mode is Terminate, and passed through
+				// to the backends of a TLSRoute otherwise.
+				if isTLSTerminate(listener) {
+					validateTLSCertificateRef(listener, resources, t.SelfSignedCertificates, t.now())
 					break
 				}
 
-				listener.SetTLSSecret(secret)
-			case v1beta1.TLSProtocolType:
-				if listener.TLS == nil {
+				if len(listener.TLS.CertificateRefs) > 0 {
 					listener.SetCondition(
 						v1beta1.ListenerConditionReady,
 						metav1.ConditionFalse,
 						v1beta1.ListenerReasonInvalid,
-						fmt.Sprintf("Listener must have TLS set when protocol is %s.", listener.Protocol),
-					)
-					break
-				}
-
-				if listener.TLS.Mode != nil && *listener.TLS.Mode != v1beta1.TLSModePassthrough {
-					listener.SetCondition(
-						v1beta1.ListenerConditionReady,
-						metav1.ConditionFalse,
-						"UnsupportedTLSMode",
-						fmt.Sprintf("TLS %s mode is not supported, TLS mode must be Passthrough.", *listener.TLS.Mode),
+						"Listener must not have TLS certificate refs set for TLS mode Passthrough",
 					)
 					break
 				}
-
-				if len(listener.TLS.CertificateRefs) > 0 {
+			case v1beta1.TCPProtocolType, v1beta1.UDPProtocolType:
+				if listener.TLS != nil {
 					listener.SetCondition(
 						v1beta1.ListenerConditionReady,
 						metav1.ConditionFalse,
 						v1beta1.ListenerReasonInvalid,
-						"Listener must not have TLS certificate refs set for TLS mode Passthrough",
+						fmt.Sprintf("Listener must not have TLS set when protocol is %s.", listener.Protocol),
 					)
-					break
 				}
 			}
 
 			lConditions := listener.GetConditions()
 			if len(lConditions) == 0 {
 				listener.SetCondition(v1beta1.ListenerConditionReady, metav1.ConditionTrue, v1beta1.ListenerReasonReady, "Listener is ready")
+				// The self-signed certificate is only a warning, set once the
+				// listener is known to be ready.
+				if listener.selfSignedCertificate {
+					listener.SetCondition(
+						ListenerConditionSelfSignedCertificate,
+						metav1.ConditionTrue,
+						ListenerReasonSelfSignedCertificate,
+						fmt.Sprintf("Secret %s/%s does not exist, serving a short-lived self-signed certificate.",
+							NamespaceDerefOr(listener.TLS.CertificateRefs[0].Namespace, listener.gateway.Namespace), listener.TLS.CertificateRefs[0].Name),
+					)
+				}
 				// Any condition on the listener apart from Ready=true indicates an error.
 			} else if !(lConditions[0].Type == string(v1beta1.ListenerConditionReady) && lConditions[0].Status == metav1.ConditionTrue) {
 				// set "Ready: false" if it's not set already.
@@ -552,10 +693,25 @@ func (t *Translator) ProcessListeners(gateways []*GatewayContext, xdsIR XdsIRMap
 			switch listener.Protocol {
 			case v1beta1.HTTPProtocolType, v1beta1.HTTPSProtocolType:
 				irListener := &ir.HTTPListener{
-					Name:    irHTTPListenerName(listener),
-					Address: "0.0.0.0",
-					Port:    uint32(containerPort),
-					TLS:     irTLSConfig(listener.tlsSecret),
+					Name:             irHTTPListenerName(listener),
+					Address:          irListenerAddress(resources.EnvoyProxy),
+					Port:             uint32(containerPort),
+					TLS:              irTLSConfig(listener),
+					FilterOrder:      irFilterOrder(resources.EnvoyProxy),
+					RateLimitService: t.RateLimitService.DeepCopy(),
+					IdleTimeout:      irTimeouts(t.Timeouts, resources.EnvoyProxy).Idle,
+					HeaderLimits:     irHeaderLimits(clientTrafficPolicy),
+					Draining:         irDraining(clientTrafficPolicy),
+					Maintenance:      irMaintenance(clientTrafficPolicy),
+					SocketOptions:    irSocketOptions(clientTrafficPolicy),
+					HTTP1:            irHTTP1Settings(clientTrafficPolicy),
+					HeaderHandling:   irHeaderHandling(clientTrafficPolicy),
+					// The access logs of the Gateway are sampled to fit its
+					// telemetry budget.
+					AccessLogSampleRate: accessLogSampleRate,
+					// The requests of the h2c clients are forwarded to the
+					// backends over HTTP/2.
+					IsHTTP2: listener.Protocol == v1beta1.HTTPProtocolType && isH2CEnabled(clientTrafficPolicy),
 				}
 				if listener.Hostname != nil {
 					irListener.Hostnames = append(irListener.Hostnames, string(*listener.Hostname))
@@ -579,6 +735,10 @@ func (t *Translator) ProcessListeners(gateways []*GatewayContext, xdsIR XdsIRMap
 					proto = ir.HTTPSProtocolType
 				case v1beta1.TLSProtocolType:
 					proto = ir.TLSProtocolType
+				case v1beta1.TCPProtocolType:
+					proto = ir.TCPProtocolType
+				case v1beta1.UDPProtocolType:
+					proto = ir.UDPProtocolType
 				}
 				infraPort := ir.ListenerPort{
 					Name:          string(listener.Name),
@@ -590,7 +750,273 @@ func (t *Translator) ProcessListeners(gateways []*GatewayContext, xdsIR XdsIRMap
 				gwInfraIR.Proxy.Listeners[0].Ports = append(gwInfraIR.Proxy.Listeners[0].Ports, infraPort)
 			}
 		}
+
+		// In the strict validation mode, a Gateway with an invalid listener
+		// is rejected as a whole.
+		if t.StrictValidation && rejectInvalidGateway(gateway) {
+			resetGatewayIR(gwXdsIR, gwInfraIR)
+			continue
+		}
+
+		if gateway.Annotations[HTTPSRedirectAnnotation] == "true" {
+			foundPorts = addHTTPSRedirectListeners(gateway, gwXdsIR, gwInfraIR, foundPorts, resources)
+		}
+
+		setIsolatedHostnames(gwXdsIR.HTTP)
+	}
+}
+
+// addHTTPSRedirectListeners adds an HTTP listener on port 80 per hostname of
+// the ready HTTPS listeners of the Gateway, redirecting the requests with a 301
+// to the HTTPS listener. The hostnames already served by an HTTP listener on
+// port 80 are skipped, as are all of them if the port is used by another
+// protocol. It returns the updated ports of the Infra IR.
+func addHTTPSRedirectListeners(gateway *GatewayContext, xdsIR *ir.Xds, infraIR *ir.Infra, foundPorts []int32, resources *Resources) []int32 {
+	servedHostnames := sets.NewString()
+	for _, listener := range gateway.listeners {
+		if listener.Port != httpRedirectPort {
+			continue
+		}
+		if listener.Protocol != v1beta1.HTTPProtocolType {
+			return foundPorts
+		}
+		servedHostnames.Insert(hostnameOrWildcard(listener.Hostname))
+	}
+
+	for _, listener := range gateway.listeners {
+		if listener.Protocol != v1beta1.HTTPSProtocolType || !listener.IsReady() {
+			continue
+		}
+		hostname := hostnameOrWildcard(listener.Hostname)
+		if servedHostnames.Has(hostname) {
+			continue
+		}
+		servedHostnames.Insert(hostname)
+
+		redirect := &ir.Redirect{
+			Scheme:     StringPtr("https"),
+			StatusCode: Int32Ptr(301),
+		}
+		// The port of the scheme is implied.
+		if listener.Port != 443 {
+			port := uint32(listener.Port)
+			redirect.Port = &port
+		}
+		name := irHTTPListenerName(listener) + "-https-redirect"
+		xdsIR.HTTP = append(xdsIR.HTTP, &ir.HTTPListener{
+			Name:      name,
+			Address:   irListenerAddress(resources.EnvoyProxy),
+			Port:      uint32(servicePortToContainerPort(httpRedirectPort)),
+			Hostnames: []string{hostname},
+			Routes: []*ir.HTTPRoute{{
+				Name:      name,
+				PathMatch: &ir.StringMatch{Prefix: StringPtr("/")},
+				Redirect:  redirect,
+			}},
+		})
+
+		if !slices.Contains(foundPorts, httpRedirectPort) {
+			foundPorts = append(foundPorts, httpRedirectPort)
+			infraIR.Proxy.Listeners[0].Ports = append(infraIR.Proxy.Listeners[0].Ports, ir.ListenerPort{
+				Name:          "https-redirect",
+				Protocol:      ir.HTTPProtocolType,
+				ServicePort:   httpRedirectPort,
+				ContainerPort: servicePortToContainerPort(httpRedirectPort),
+			})
+		}
+	}
+
+	return foundPorts
+}
+
+// hostnameOrWildcard returns the provided listener hostname, or "*" if unset.
+func hostnameOrWildcard(hostname *v1beta1.Hostname) string {
+	if hostname == nil {
+		return "*"
+	}
+	return string(*hostname)
+}
+
+// validateAllowedRoutes sets the supported kinds of the listener, which
+// default to routeKind if the listener does not restrict the allowed kinds.
+func validateAllowedRoutes(listener *ListenerContext, routeKind string) {
+	if listener.AllowedRoutes == nil || len(listener.AllowedRoutes.Kinds) == 0 {
+		listener.SetSupportedKinds(v1beta1.RouteGroupKind{Group: GroupPtr(v1beta1.GroupName), Kind: v1beta1.Kind(routeKind)})
+		return
+	}
+
+	for _, kind := range listener.AllowedRoutes.Kinds {
+		if kind.Group != nil && string(*kind.Group) != v1beta1.GroupName {
+			listener.SetCondition(
+				v1beta1.ListenerConditionResolvedRefs,
+				metav1.ConditionFalse,
+				v1beta1.ListenerReasonInvalidRouteKinds,
+				fmt.Sprintf("Group is not supported, group must be %s", v1beta1.GroupName),
+			)
+			continue
+		}
+
+		if string(kind.Kind) != routeKind {
+			listener.SetCondition(
+				v1beta1.ListenerConditionResolvedRefs,
+				metav1.ConditionFalse,
+				v1beta1.ListenerReasonInvalidRouteKinds,
+				fmt.Sprintf("Kind is not supported, kind must be %s", routeKind),
+			)
+			continue
+		}
+		listener.SetSupportedKinds(kind)
+	}
+}
+
+// isTLSTerminate returns true if the listener terminates TLS connections
+// and forwards the decrypted stream to the backends of TCPRoutes.
+func isTLSTerminate(listener *ListenerContext) bool {
+	return listener.Protocol == v1beta1.TLSProtocolType &&
+		listener.TLS != nil &&
+		listener.TLS.Mode != nil &&
+		*listener.TLS.Mode == v1beta1.TLSModeTerminate
+}
+
+// validateTLSCertificateRef resolves the certificate ref of a listener
+// terminating TLS connections, and sets the referenced Secret on the listener
+// if it is valid. If the Secret does not exist and selfSigned is not nil, a
+// self-signed certificate is set on the listener instead. The certificate
+// must be valid at now.
+func validateTLSCertificateRef(listener *ListenerContext, resources *Resources, selfSigned *SelfSignedCertificates, now time.Time) {
+	if len(listener.TLS.CertificateRefs) != 1 {
+		listener.SetCondition(
+			v1beta1.ListenerConditionReady,
+			metav1.ConditionFalse,
+			v1beta1.ListenerReasonInvalid,
+			"Listener must have exactly 1 TLS certificate ref",
+		)
+		return
+	}
+
+	certificateRef := listener.TLS.CertificateRefs[0]
+
+	if certificateRef.Group != nil && string(*certificateRef.Group) != "" {
+		listener.SetCondition(
+			v1beta1.ListenerConditionResolvedRefs,
+			metav1.ConditionFalse,
+			v1beta1.ListenerReasonInvalidCertificateRef,
+			"Listener's TLS certificate ref group must be unspecified/empty.",
+		)
+		return
+	}
+
+	if certificateRef.Kind != nil && string(*certificateRef.Kind) != KindSecret {
+		listener.SetCondition(
+			v1beta1.ListenerConditionResolvedRefs,
+			metav1.ConditionFalse,
+			v1beta1.ListenerReasonInvalidCertificateRef,
+			fmt.Sprintf("Listener's TLS certificate ref kind must be %s.", KindSecret),
+		)
+		return
+	}
+
+	secretNamespace := listener.gateway.Namespace
+
+	if certificateRef.Namespace != nil && string(*certificateRef.Namespace) != "" && string(*certificateRef.Namespace) != listener.gateway.Namespace {
+		if !isValidCrossNamespaceRef(
+			crossNamespaceFrom{
+				group:     string(v1beta1.GroupName),
+				kind:      KindGateway,
+				namespace: listener.gateway.Namespace,
+			},
+			crossNamespaceTo{
+				group:     "",
+				kind:      KindSecret,
+				namespace: string(*certificateRef.Namespace),
+				name:      string(certificateRef.Name),
+			},
+			resources.ReferenceGrants,
+		) {
+			listener.SetCondition(
+				v1beta1.ListenerConditionResolvedRefs,
+				metav1.ConditionFalse,
+				v1beta1.ListenerReasonRefNotPermitted,
+				fmt.Sprintf("Certificate ref to secret %s/%s not permitted by any ReferenceGrant", *certificateRef.Namespace, certificateRef.Name),
+			)
+			return
+		}
+
+		secretNamespace = string(*certificateRef.Namespace)
+	}
+
+	secret := resources.GetSecret(secretNamespace, string(certificateRef.Name))
+
+	if secret == nil && selfSigned != nil {
+		selfSignedSecret, err := selfSigned.secretFor(listener, now)
+		if err == nil {
+			listener.SetTLSSecret(selfSignedSecret)
+			listener.selfSignedCertificate = true
+			return
+		}
+	}
+
+	if secret == nil {
+		listener.SetCondition(
+			v1beta1.ListenerConditionResolvedRefs,
+			metav1.ConditionFalse,
+			v1beta1.ListenerReasonInvalidCertificateRef,
+			fmt.Sprintf("Secret %s/%s does not exist.", listener.gateway.Namespace, certificateRef.Name),
+		)
+		return
 	}
+
+	if secret.Type != v1.SecretTypeTLS {
+		listener.SetCondition(
+			v1beta1.ListenerConditionResolvedRefs,
+			metav1.ConditionFalse,
+			v1beta1.ListenerReasonInvalidCertificateRef,
+			fmt.Sprintf("Secret %s/%s must be of type %s.", listener.gateway.Namespace, certificateRef.Name, v1.SecretTypeTLS),
+		)
+		return
+	}
+
+	if len(secret.Data[v1.TLSCertKey]) == 0 || len(secret.Data[v1.TLSPrivateKeyKey]) == 0 {
+		listener.SetCondition(
+			v1beta1.ListenerConditionResolvedRefs,
+			metav1.ConditionFalse,
+			v1beta1.ListenerReasonInvalidCertificateRef,
+			fmt.Sprintf("Secret %s/%s must contain %s and %s.", listener.gateway.Namespace, certificateRef.Name, v1.TLSCertKey, v1.TLSPrivateKeyKey),
+		)
+		return
+	}
+
+	if err := validateTLSSecretData(secret.Data, now); err != nil {
+		listener.SetCondition(
+			v1beta1.ListenerConditionResolvedRefs,
+			metav1.ConditionFalse,
+			v1beta1.ListenerReasonInvalidCertificateRef,
+			fmt.Sprintf("Secret %s/%s must contain valid TLS material: %v.", secret.Namespace, secret.Name, err),
+		)
+		return
+	}
+
+	staplePolicy, err := ocspStaplePolicy(listener.TLS)
+	if err != nil {
+		listener.SetCondition(
+			v1beta1.ListenerConditionReady,
+			metav1.ConditionFalse,
+			v1beta1.ListenerReasonInvalid,
+			fmt.Sprintf("Invalid TLS option %s: %v.", OCSPStaplePolicyOption, err),
+		)
+		return
+	}
+	if staplePolicy == ir.OCSPStaplePolicyMustStaple && len(secret.Data[ocspStapleKey]) == 0 {
+		listener.SetCondition(
+			v1beta1.ListenerConditionResolvedRefs,
+			metav1.ConditionFalse,
+			v1beta1.ListenerReasonInvalidCertificateRef,
+			fmt.Sprintf("Secret %s/%s must contain %s when the OCSP staple policy is %s.", secret.Namespace, secret.Name, ocspStapleKey, staplePolicy),
+		)
+		return
+	}
+
+	listener.SetTLSSecret(secret)
 }
 
 // servicePortToContainerPort translates a service port into an ephemeral
@@ -612,7 +1038,7 @@ func servicePortToContainerPort(servicePort int32) int32 {
 func buildRuleRouteDest(backendRef v1beta1.HTTPBackendRef,
 	parentRef *RouteParentContext,
 	httpRoute *HTTPRouteContext,
-	resources *Resources) (destination *ir.RouteDestination, backendWeight uint32) {
+	resources *Resources) (destinations []*ir.RouteDestination, backendWeight uint32) {
 
 	weight := uint32(1)
 	if backendRef.Weight != nil {
@@ -664,7 +1090,10 @@ func buildRuleRouteDest(backendRef v1beta1.HTTPBackendRef,
 		}
 	}
 
-	if backendRef.Port == nil {
+	// The backendRefs without a port may name the port of the Service with
+	// an annotation of the route.
+	portName := backendPortName(httpRoute, string(backendRef.Name))
+	if backendRef.Port == nil && portName == "" {
 		parentRef.SetCondition(httpRoute,
 			v1beta1.RouteConditionResolvedRefs,
 			metav1.ConditionFalse,
@@ -685,357 +1114,193 @@ func buildRuleRouteDest(backendRef v1beta1.HTTPBackendRef,
 		return nil, weight
 	}
 
-	var portFound bool
-	for _, port := range service.Spec.Ports {
-		if port.Port == int32(*backendRef.Port) {
-			portFound = true
-			break
-		}
-	}
-
-	if !portFound {
+	servicePort := findServicePort(service, backendRef.Port, portName)
+	if servicePort == nil {
 		parentRef.SetCondition(httpRoute,
 			v1beta1.RouteConditionResolvedRefs,
 			metav1.ConditionFalse,
 			"PortNotFound",
-			fmt.Sprintf("Port %d not found on service %s/%s", *backendRef.Port, NamespaceDerefOr(backendRef.Namespace, httpRoute.Namespace), string(backendRef.Name)),
+			fmt.Sprintf("Port %s not found on service %s/%s", servicePortRef(backendRef.Port, portName), NamespaceDerefOr(backendRef.Namespace, httpRoute.Namespace), string(backendRef.Name)),
 		)
 		return nil, weight
 	}
 
-	return &ir.RouteDestination{
-		Host:   service.Spec.ClusterIP,
-		Port:   uint32(*backendRef.Port),
-		Weight: weight,
-	}, weight
+	// The requests to the Services resolving SRV records are forwarded to the
+	// targets of the record of the port.
+	var srvRecords []SRVRecord
+	if isSRVService(service) {
+		srvName := serviceSRVName(service, *servicePort)
+		srvRecords = resources.SRVRecords[srvName]
+		if len(srvRecords) == 0 {
+			parentRef.SetCondition(httpRoute,
+				v1beta1.RouteConditionResolvedRefs,
+				metav1.ConditionFalse,
+				"SRVRecordNotResolved",
+				fmt.Sprintf("SRV record %s of service %s/%s could not be resolved", srvName, service.Namespace, service.Name),
+			)
+			return nil, weight
+		}
+	}
+
+	destination := &ir.RouteDestination{
+		Host:     service.Spec.ClusterIP,
+		Port:     uint32(servicePort.Port),
+		Weight:   weight,
+		Draining: isDrained(service) || isDrained(httpRoute),
+	}
+	if httpRoute.Annotations[BackendHostRewriteAnnotation] == "true" {
+		destination.HostRewrite = serviceFQDN(service)
+	}
+
+	// Process the filters applied to requests forwarded to this backend only.
+	for _, filter := range backendRef.Filters {
+		switch filter.Type {
+		case v1beta1.HTTPRouteFilterRequestHeaderModifier:
+			destination.AddRequestHeaders, destination.RemoveRequestHeaders = processRequestHeaderModifierFilter(filter.RequestHeaderModifier,
+				parentRef, httpRoute, destination.AddRequestHeaders, destination.RemoveRequestHeaders)
+		default:
+			// Filters must not be skipped, so the backend is considered invalid
+			// and the requests that would have been forwarded to it receive a
+			// HTTP error response instead.
+			parentRef.SetCondition(httpRoute,
+				v1beta1.RouteConditionAccepted,
+				metav1.ConditionFalse,
+				v1beta1.RouteReasonUnsupportedValue,
+				fmt.Sprintf("Unsupported backendRef filter type: %s", filter.Type),
+			)
+			return nil, weight
+		}
+	}
 
+	if srvRecords != nil {
+		return srvDestinations(destination, srvRecords), weight
+	}
+	return []*ir.RouteDestination{destination}, weight
 }
 
 func (t *Translator) ProcessHTTPRoutes(httpRoutes []*v1beta1.HTTPRoute, gateways []*GatewayContext, resources *Resources, xdsIR XdsIRMap) []*HTTPRouteContext {
 	var relevantHTTPRoutes []*HTTPRouteContext
+	timeouts := irTimeouts(t.Timeouts, resources.EnvoyProxy)
+	emptyBackendRefsResponse := emptyBackendRefsDirectResponse(t.EmptyBackendRefs)
+	now := t.now()
+
+	var mesh *meshContext
+	if t.MeshMode {
+		mesh = newMeshContext()
+	}
 
 	for _, h := range httpRoutes {
 		if h == nil {
 			panic("received nil httproute")
 		}
-		httpRoute := &HTTPRouteContext{HTTPRoute: h}
+		httpRoute := &HTTPRouteContext{HTTPRoute: h, conditionTime: t.conditionTime, controllerName: t.ControllerName}
+		// Drop the parent statuses of the parentRefs removed from the spec.
+		h.Status.Parents = pruneRouteParentStatuses(h.Status.Parents, h.Spec.ParentRefs, h.Namespace, t.ControllerName)
 
 		// Find out if this route attaches to one of our Gateway's listeners,
 		// and if so, get the list of listeners that allow it to attach for each
 		// parentRef.
 		relevantRoute := processAllowedListenersForParentRefs(httpRoute, gateways, resources)
+		// In mesh mode, the route may also attach to the listeners of
+		// the Services it references.
+		if mesh != nil && mesh.processParentRefs(httpRoute, resources, xdsIR) {
+			relevantRoute = true
+		}
 		if !relevantRoute {
 			continue
 		}
 
 		relevantHTTPRoutes = append(relevantHTTPRoutes, httpRoute)
 
+		routeBackendPolicy := backendTrafficPolicyForRoute(resources.BackendTrafficPolicies, h)
+		routeSecurityPolicy := securityPolicyForRoute(resources.SecurityPolicies, h)
+		routeTrafficShift := trafficShiftForRoute(resources.TrafficShifts, h)
+
 		for _, parentRef := range httpRoute.parentRefs {
 			// Skip parent refs that did not accept the route
 			if !parentRef.IsAccepted(httpRoute) {
 				continue
 			}
 
-			// Need to compute Route rules within the parentRef loop because
-			// any conditions that come out of it have to go on each RouteParentStatus,
-			// not on the Route as a whole.
-			var routeRoutes []*ir.HTTPRoute
-
-			// compute matches, filters, backends
-			for ruleIdx, rule := range httpRoute.Spec.Rules {
-				var ruleRoutes []*ir.HTTPRoute
+			// Skip the route if it is disabled.
+			if err := routeDisabledError(httpRoute, now); err != nil {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					RouteReasonDisabled,
+					err.Error(),
+				)
+				continue
+			}
 
-				// First see if there are any filters in the rules. Then apply those filters to any irRoutes.
-				var directResponse *ir.DirectResponse
-				var redirectResponse *ir.Redirect
-				addRequestHeaders := []ir.AddHeader{}
-				removeRequestHeaders := []string{}
-
-				// Process the filters for this route rule
-				for _, filter := range rule.Filters {
-					if directResponse != nil {
-						break // If an invalid filter type has been configured then skip processing any more filters
-					}
-					switch filter.Type {
-					case v1beta1.HTTPRouteFilterRequestRedirect:
-						// Can't have two redirects for the same route
-						if redirectResponse != nil {
-							parentRef.SetCondition(httpRoute,
-								v1beta1.RouteConditionAccepted,
-								metav1.ConditionFalse,
-								v1beta1.RouteReasonUnsupportedValue,
-								"Cannot configure multiple requestRedirect filters for a single HTTPRouteRule",
-							)
-							continue
-						}
-
-						redirect := filter.RequestRedirect
-						if redirect == nil {
-							break
-						}
-
-						redir := &ir.Redirect{}
-						if redirect.Scheme != nil {
-							// Note that gateway API may support additional schemes in the future, but unknown values
-							// must result in an UnsupportedValue status
-							if *redirect.Scheme == "http" || *redirect.Scheme == "https" {
-								redir.Scheme = redirect.Scheme
-							} else {
-								errMsg := fmt.Sprintf("Scheme: %s is unsupported, only 'https' and 'http' are supported", *redirect.Scheme)
-								parentRef.SetCondition(httpRoute,
-									v1beta1.RouteConditionAccepted,
-									metav1.ConditionFalse,
-									v1beta1.RouteReasonUnsupportedValue,
-									errMsg,
-								)
-								continue
-							}
-						}
-
-						if redirect.Hostname != nil {
-							if err := isValidHostname(string(*redirect.Hostname)); err != nil {
-								parentRef.SetCondition(httpRoute,
-									v1beta1.RouteConditionAccepted,
-									metav1.ConditionFalse,
-									v1beta1.RouteReasonUnsupportedValue,
-									err.Error(),
-								)
-								continue
-							} else {
-								redirectHost := string(*redirect.Hostname)
-								redir.Hostname = &redirectHost
-							}
-						}
-
-						if redirect.Path != nil {
-							switch redirect.Path.Type {
-							case v1beta1.FullPathHTTPPathModifier:
-								if redirect.Path.ReplaceFullPath != nil {
-									redir.Path = &ir.HTTPPathModifier{
-										FullReplace: redirect.Path.ReplaceFullPath,
-									}
-								}
-							case v1beta1.PrefixMatchHTTPPathModifier:
-								if redirect.Path.ReplacePrefixMatch != nil {
-									redir.Path = &ir.HTTPPathModifier{
-										PrefixMatchReplace: redirect.Path.ReplacePrefixMatch,
-									}
-								}
-							default:
-								errMsg := fmt.Sprintf("Redirect path type: %s is invalid, only \"ReplaceFullPath\" and \"ReplacePrefixMatch\" are supported", redirect.Path.Type)
-								parentRef.SetCondition(httpRoute,
-									v1beta1.RouteConditionAccepted,
-									metav1.ConditionFalse,
-									v1beta1.RouteReasonUnsupportedValue,
-									errMsg,
-								)
-								continue
-							}
-						}
-
-						if redirect.StatusCode != nil {
-							redirectCode := int32(*redirect.StatusCode)
-							// Envoy supports 302, 303, 307, and 308, but gateway API only includes 301 and 302
-							if redirectCode == 301 || redirectCode == 302 {
-								redir.StatusCode = &redirectCode
-							} else {
-								errMsg := fmt.Sprintf("Status code %d is invalid, only 302 and 301 are supported", redirectCode)
-								parentRef.SetCondition(httpRoute,
-									v1beta1.RouteConditionAccepted,
-									metav1.ConditionFalse,
-									v1beta1.RouteReasonUnsupportedValue,
-									errMsg,
-								)
-								continue
-							}
-						}
-
-						if redirect.Port != nil {
-							redirectPort := uint32(*redirect.Port)
-							redir.Port = &redirectPort
-						}
-
-						redirectResponse = redir
-					case v1beta1.HTTPRouteFilterRequestHeaderModifier:
-						// Make sure the header modifier config actually exists
-						headerModifier := filter.RequestHeaderModifier
-						if headerModifier == nil {
-							break
-						}
-						emptyFilterConfig := true // keep track of whether the provided config is empty or not
-
-						// Add request headers
-						if headersToAdd := headerModifier.Add; headersToAdd != nil {
-							if len(headersToAdd) > 0 {
-								emptyFilterConfig = false
-							}
-							for _, addHeader := range headersToAdd {
-								emptyFilterConfig = false
-								if addHeader.Name == "" {
-									parentRef.SetCondition(httpRoute,
-										v1beta1.RouteConditionAccepted,
-										metav1.ConditionFalse,
-										v1beta1.RouteReasonUnsupportedValue,
-										"RequestHeaderModifier Filter cannot add a header with an empty name",
-									)
-									// try to process the rest of the headers and produce a valid config.
-									continue
-								}
-								// Per Gateway API specification on HTTPHeaderName, : and / are invalid characters in header names
-								if strings.Contains(string(addHeader.Name), "/") || strings.Contains(string(addHeader.Name), ":") {
-									parentRef.SetCondition(httpRoute,
-										v1beta1.RouteConditionAccepted,
-										metav1.ConditionFalse,
-										v1beta1.RouteReasonUnsupportedValue,
-										fmt.Sprintf("RequestHeaderModifier Filter cannot set headers with a '/' or ':' character in them. Header: %q", string(addHeader.Name)),
-									)
-									continue
-								}
-								// Check if the header is a duplicate
-								headerKey := string(addHeader.Name)
-								canAddHeader := true
-								for _, h := range addRequestHeaders {
-									if strings.EqualFold(h.Name, headerKey) {
-										canAddHeader = false
-										break
-									}
-								}
-
-								if !canAddHeader {
-									continue
-								}
-
-								newHeader := ir.AddHeader{
-									Name:   headerKey,
-									Append: true,
-									Value:  addHeader.Value,
-								}
-
-								addRequestHeaders = append(addRequestHeaders, newHeader)
-							}
-						}
+			// Reject the route if it exceeds the translation limits.
+			if err := t.limits.admitHTTPRoute(httpRoute, parentRef); err != nil {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					RouteReasonLimitExceeded,
+					err.Error(),
+				)
+				continue
+			}
 
-						// Set headers
-						if headersToSet := headerModifier.Set; headersToSet != nil {
-							if len(headersToSet) > 0 {
-								emptyFilterConfig = false
-							}
-							for _, setHeader := range headersToSet {
-
-								if setHeader.Name == "" {
-									parentRef.SetCondition(httpRoute,
-										v1beta1.RouteConditionAccepted,
-										metav1.ConditionFalse,
-										v1beta1.RouteReasonUnsupportedValue,
-										"RequestHeaderModifier Filter cannot set a header with an empty name",
-									)
-									continue
-								}
-								// Per Gateway API specification on HTTPHeaderName, : and / are invalid characters in header names
-								if strings.Contains(string(setHeader.Name), "/") || strings.Contains(string(setHeader.Name), ":") {
-									parentRef.SetCondition(httpRoute,
-										v1beta1.RouteConditionAccepted,
-										metav1.ConditionFalse,
-										v1beta1.RouteReasonUnsupportedValue,
-										fmt.Sprintf("RequestHeaderModifier Filter cannot set headers with a '/' or ':' character in them. Header: '%s'", string(setHeader.Name)),
-									)
-									continue
-								}
-
-								// Check if the header to be set has already been configured
-								headerKey := string(setHeader.Name)
-								canAddHeader := true
-								for _, h := range addRequestHeaders {
-									if strings.EqualFold(h.Name, headerKey) {
-										canAddHeader = false
-										break
-									}
-								}
-								if !canAddHeader {
-									continue
-								}
-								newHeader := ir.AddHeader{
-									Name:   string(setHeader.Name),
-									Append: false,
-									Value:  setHeader.Value,
-								}
-
-								addRequestHeaders = append(addRequestHeaders, newHeader)
-							}
-						}
+			// The backend traffic features of the route fall back to the ones
+			// of the policy targeting the Gateway of the parent ref, unless the
+			// features of the two policies can't be combined.
+			var gatewayBackendPolicy *v1alpha1.BackendTrafficPolicy
+			if len(parentRef.listeners) > 0 {
+				gatewayBackendPolicy = backendTrafficPolicyForGateway(resources.BackendTrafficPolicies, parentRef.listeners[0].gateway)
+			}
+			if err := backendTrafficPolicyConflict(routeBackendPolicy, gatewayBackendPolicy); err != nil {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					RouteReasonPolicyConflict,
+					fmt.Sprintf("%v, only the policy targeting the route applies.", err),
+				)
+				gatewayBackendPolicy = nil
+			}
+			timeoutPolicy := backendFeaturePolicy(routeBackendPolicy, gatewayBackendPolicy, func(spec *v1alpha1.BackendTrafficPolicySpec) bool { return spec.ConnectTimeout != nil })
+			connectTimeout := timeouts.Connect
+			if timeoutPolicy != nil {
+				connectTimeout = timeoutPolicy.Spec.ConnectTimeout
+			}
+			tlsPolicy := backendFeaturePolicy(routeBackendPolicy, gatewayBackendPolicy, func(spec *v1alpha1.BackendTrafficPolicySpec) bool { return spec.TLS != nil })
+			backendTLS, backendTLSErr := buildBackendTLSConfig(tlsPolicy, resources, t.SPIFFE, t.now())
+			loadBalancerPolicy := backendFeaturePolicy(routeBackendPolicy, gatewayBackendPolicy, func(spec *v1alpha1.BackendTrafficPolicySpec) bool { return spec.LoadBalancer != nil })
+			loadBalancer, loadBalancerErr := buildLoadBalancer(loadBalancerPolicy)
+			dynamicForwardProxyPolicy := backendFeaturePolicy(routeBackendPolicy, gatewayBackendPolicy, func(spec *v1alpha1.BackendTrafficPolicySpec) bool { return spec.DynamicForwardProxy != nil })
+			dynamicForwardProxy, dynamicForwardProxyErr := buildDynamicForwardProxy(dynamicForwardProxyPolicy, resources.EnvoyProxy)
+			transformationPolicy := backendFeaturePolicy(routeBackendPolicy, gatewayBackendPolicy, func(spec *v1alpha1.BackendTrafficPolicySpec) bool { return spec.Transformation != nil })
+			transformation, transformationErr := buildTransformation(transformationPolicy)
+			shadowComparisonPolicy := backendFeaturePolicy(routeBackendPolicy, gatewayBackendPolicy, func(spec *v1alpha1.BackendTrafficPolicySpec) bool { return spec.ShadowComparison != nil })
+			shadowHeader, shadowHeaderErr := buildShadowHeader(shadowComparisonPolicy)
+			bandwidthLimitPolicy := backendFeaturePolicy(routeBackendPolicy, gatewayBackendPolicy, func(spec *v1alpha1.BackendTrafficPolicySpec) bool { return spec.BandwidthLimit != nil })
+			bandwidthLimit, bandwidthLimitErr := buildBandwidthLimit(bandwidthLimitPolicy)
+			runtimeWeightsPolicy := backendFeaturePolicy(routeBackendPolicy, gatewayBackendPolicy, func(spec *v1alpha1.BackendTrafficPolicySpec) bool { return spec.RuntimeWeights != nil })
+			runtimeWeightsKeyPrefix, runtimeWeightsErr := buildRuntimeWeightsKeyPrefix(runtimeWeightsPolicy)
+			extProcPolicy := backendFeaturePolicy(routeBackendPolicy, gatewayBackendPolicy, func(spec *v1alpha1.BackendTrafficPolicySpec) bool { return spec.ExtProc != nil })
+			extProc, extProcErr := buildExtProc(extProcPolicy, resources)
 
-						// Remove request headers
-						// As far as Envoy is concerned, it is ok to configure a header to be added/set and also in the list of
-						// headers to remove. It will remove the original header if present and then add/set the header after.
-						if headersToRemove := headerModifier.Remove; headersToRemove != nil {
-							if len(headersToRemove) > 0 {
-								emptyFilterConfig = false
-							}
-							for _, removedHeader := range headersToRemove {
-								if removedHeader == "" {
-									parentRef.SetCondition(httpRoute,
-										v1beta1.RouteConditionAccepted,
-										metav1.ConditionFalse,
-										v1beta1.RouteReasonUnsupportedValue,
-										"RequestHeaderModifier Filter cannot remove a header with an empty name",
-									)
-									continue
-								}
-
-								canRemHeader := true
-								for _, h := range removeRequestHeaders {
-									if strings.EqualFold(h, removedHeader) {
-										canRemHeader = false
-										break
-									}
-								}
-								if !canRemHeader {
-									continue
-								}
-
-								removeRequestHeaders = append(removeRequestHeaders, removedHeader)
+			// Need to compute Route rules within the parentRef loop because
+			// any conditions that come out of it have to go on each RouteParentStatus,
+			// not on the Route as a whole.
+			var routeRoutes []*ir.HTTPRoute
+			// The indices of the rules without backendRefs.
+			var emptyRules []int
 
-							}
-						}
+			// compute matches, filters, backends
+			for ruleIdx, rule := range httpRoute.Spec.Rules {
+				var ruleRoutes []*ir.HTTPRoute
 
-						// Update the status if the filter failed to configure any valid headers to add/remove
-						if len(addRequestHeaders) == 0 && len(removeRequestHeaders) == 0 && !emptyFilterConfig {
-							parentRef.SetCondition(httpRoute,
-								v1beta1.RouteConditionAccepted,
-								metav1.ConditionFalse,
-								v1beta1.RouteReasonUnsupportedValue,
-								"RequestHeaderModifier Filter did not provide valid configuration to add/set/remove any headers",
-							)
-						}
-					case v1beta1.HTTPRouteFilterExtensionRef:
-						// "If a reference to a custom filter type cannot be resolved, the filter MUST NOT be skipped.
-						// Instead, requests that would have been processed by that filter MUST receive a HTTP error response."
-						errMsg := fmt.Sprintf("Unknown custom filter type: %s", filter.Type)
-						parentRef.SetCondition(httpRoute,
-							v1beta1.RouteConditionAccepted,
-							metav1.ConditionFalse,
-							v1beta1.RouteReasonUnsupportedValue,
-							errMsg,
-						)
-						directResponse = &ir.DirectResponse{
-							Body:       &errMsg,
-							StatusCode: 500,
-						}
-					default:
-						// Unsupported filters.
-						errMsg := fmt.Sprintf("Unsupported filter type: %s", filter.Type)
-						parentRef.SetCondition(httpRoute,
-							v1beta1.RouteConditionAccepted,
-							metav1.ConditionFalse,
-							v1beta1.RouteReasonUnsupportedValue,
-							errMsg,
-						)
-						directResponse = &ir.DirectResponse{
-							Body:       &errMsg,
-							StatusCode: 500,
-						}
-					}
+				// First see if there are any filters in the rules. Then apply those filters to any irRoutes.
+				filters := &httpFiltersContext{
+					parentRef:    parentRef,
+					httpRoute:    httpRoute,
+					resources:    resources,
+					shadowHeader: shadowHeader,
 				}
+				processHTTPFilters(rule.Filters, filters)
 
 				// A rule is matched if any one of its matches
 				// is satisfied (i.e. a logical "OR"), so generate
@@ -1098,29 +1363,43 @@ func (t *Translator) ProcessHTTPRoutes(httpRoutes []*v1beta1.HTTPRoute, gateways
 					}
 
 					// Add the redirect filter or direct response that were created earlier to all the irRoutes
-					if redirectResponse != nil {
-						irRoute.Redirect = redirectResponse
+					if filters.redirectResponse != nil {
+						irRoute.Redirect = filters.redirectResponse
 					}
-					if directResponse != nil {
-						irRoute.DirectResponse = directResponse
+					if filters.directResponse != nil {
+						irRoute.DirectResponse = filters.directResponse
 					}
-					if len(addRequestHeaders) > 0 {
-						irRoute.AddRequestHeaders = addRequestHeaders
+					if len(filters.addRequestHeaders) > 0 {
+						irRoute.AddRequestHeaders = filters.addRequestHeaders
 					}
-					if len(removeRequestHeaders) > 0 {
-						irRoute.RemoveRequestHeaders = removeRequestHeaders
+					if len(filters.removeRequestHeaders) > 0 {
+						irRoute.RemoveRequestHeaders = filters.removeRequestHeaders
+					}
+					if len(filters.mirrors) > 0 {
+						irRoute.Mirrors = filters.mirrors
 					}
 					ruleRoutes = append(ruleRoutes, irRoute)
 				}
 
-				for _, backendRef := range rule.BackendRefs {
-					destination, backendWeight := buildRuleRouteDest(backendRef, parentRef, httpRoute, resources)
+				// The backendRefs are ignored when the requests are forwarded to
+				// the hosts they are addressed to.
+				var backendRefs []v1beta1.HTTPBackendRef
+				if dynamicForwardProxy == nil {
+					backendRefs = shiftBackendRefs(rule.BackendRefs, routeTrafficShift, httpRoute.Namespace)
+				}
+				for _, backendRef := range backendRefs {
+					destinations, backendWeight := buildRuleRouteDest(backendRef, parentRef, httpRoute, resources)
+					if loadBalancer.IsConsistentHash() && hashesOnBackendRef(loadBalancerPolicy) {
+						for _, destination := range destinations {
+							destination.HashKey = backendRefHashKey(backendRef.BackendRef, httpRoute.Namespace)
+						}
+					}
 					for _, route := range ruleRoutes {
 						// If the route already has a direct response or redirect configured, then it was from a filter so skip
 						// processing any destinations for this route.
 						if route.DirectResponse == nil && route.Redirect == nil {
-							if destination != nil {
-								route.Destinations = append(route.Destinations, destination)
+							if len(destinations) > 0 {
+								route.Destinations = append(route.Destinations, destinations...)
 								route.BackendWeights.Valid += backendWeight
 
 							} else {
@@ -1130,6 +1409,19 @@ func (t *Translator) ProcessHTTPRoutes(httpRoutes []*v1beta1.HTTPRoute, gateways
 					}
 				}
 
+				// The rules without backendRefs, and without a filter responding
+				// to the requests, either get a direct response or are skipped.
+				if len(backendRefs) == 0 && dynamicForwardProxy == nil &&
+					filters.redirectResponse == nil && filters.directResponse == nil {
+					emptyRules = append(emptyRules, ruleIdx)
+					if emptyBackendRefsResponse == nil {
+						continue
+					}
+					for _, ruleRoute := range ruleRoutes {
+						ruleRoute.DirectResponse = emptyBackendRefsResponse
+					}
+				}
+
 				// If the route has no valid backends then just use a direct response and don't fuss with weighted responses
 				for _, ruleRoute := range ruleRoutes {
 					if ruleRoute.BackendWeights.Invalid > 0 && len(ruleRoute.Destinations) == 0 {
@@ -1146,9 +1438,191 @@ func (t *Translator) ProcessHTTPRoutes(httpRoutes []*v1beta1.HTTPRoute, gateways
 				routeRoutes = append(routeRoutes, ruleRoutes...)
 			}
 
+			// Requests must not be forwarded unencrypted to backends whose TLS
+			// configuration is invalid, so they receive a HTTP error response
+			// instead.
+			if backendTLSErr != nil {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionResolvedRefs,
+					metav1.ConditionFalse,
+					"InvalidBackendTLS",
+					fmt.Sprintf("Invalid TLS configuration of BackendTrafficPolicy %s/%s: %v.", tlsPolicy.Namespace, tlsPolicy.Name, backendTLSErr),
+				)
+				for _, routeRoute := range routeRoutes {
+					if len(routeRoute.Destinations) == 0 {
+						continue
+					}
+					routeRoute.BackendWeights.Invalid += routeRoute.BackendWeights.Valid
+					routeRoute.BackendWeights.Valid = 0
+					routeRoute.Destinations = nil
+					routeRoute.DirectResponse = &ir.DirectResponse{
+						StatusCode: 500,
+					}
+				}
+			}
+
+			// Requests must not be forwarded to the backends of a route meant
+			// to forward them to other hosts, so they receive a HTTP error
+			// response instead if the dynamic forward proxy is invalid.
+			if dynamicForwardProxyErr != nil {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					"InvalidDynamicForwardProxy",
+					fmt.Sprintf("Invalid dynamic forward proxy of BackendTrafficPolicy %s/%s: %v.", dynamicForwardProxyPolicy.Namespace, dynamicForwardProxyPolicy.Name, dynamicForwardProxyErr),
+				)
+				for _, routeRoute := range routeRoutes {
+					if routeRoute.DirectResponse != nil || routeRoute.Redirect != nil {
+						continue
+					}
+					routeRoute.BackendWeights.Invalid += routeRoute.BackendWeights.Valid
+					routeRoute.BackendWeights.Valid = 0
+					routeRoute.Destinations = nil
+					routeRoute.DirectResponse = &ir.DirectResponse{
+						StatusCode: 500,
+					}
+				}
+			}
+
+			// Untransformed requests must not be forwarded to backends expecting
+			// them transformed, so they receive a HTTP error response instead if
+			// the transformation is invalid.
+			if transformationErr != nil {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					"InvalidTransformation",
+					fmt.Sprintf("Invalid transformation of BackendTrafficPolicy %s/%s: %v.", transformationPolicy.Namespace, transformationPolicy.Name, transformationErr),
+				)
+				for _, routeRoute := range routeRoutes {
+					if routeRoute.DirectResponse != nil || routeRoute.Redirect != nil {
+						continue
+					}
+					routeRoute.BackendWeights.Invalid += routeRoute.BackendWeights.Valid
+					routeRoute.BackendWeights.Valid = 0
+					routeRoute.Destinations = nil
+					routeRoute.DirectResponse = &ir.DirectResponse{
+						StatusCode: 500,
+					}
+				}
+			}
+
+			// The mirrored requests are neither tagged nor logged if the shadow
+			// comparison of the policy is invalid.
+			if shadowHeaderErr != nil {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					"InvalidShadowComparison",
+					fmt.Sprintf("Invalid shadow comparison of BackendTrafficPolicy %s/%s: %v.", shadowComparisonPolicy.Namespace, shadowComparisonPolicy.Name, shadowHeaderErr),
+				)
+			}
+
+			// The throughput is not limited if the bandwidth limit of the policy
+			// is invalid.
+			if bandwidthLimitErr != nil {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					"InvalidBandwidthLimit",
+					fmt.Sprintf("Invalid bandwidth limit of BackendTrafficPolicy %s/%s: %v.", bandwidthLimitPolicy.Namespace, bandwidthLimitPolicy.Name, bandwidthLimitErr),
+				)
+			}
+
+			// The weights are not read from the runtime if the runtime weights
+			// of the policy are invalid.
+			if runtimeWeightsErr != nil {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					"InvalidRuntimeWeights",
+					fmt.Sprintf("Invalid runtime weights of BackendTrafficPolicy %s/%s: %v.", runtimeWeightsPolicy.Namespace, runtimeWeightsPolicy.Name, runtimeWeightsErr),
+				)
+			}
+
+			// Unprocessed requests must not be forwarded to backends expecting
+			// them processed, so they receive a HTTP error response instead if
+			// the processing service can't be resolved, unless the policy
+			// allows forwarding them unprocessed when the service fails.
+			if extProcErr != nil {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					"InvalidExtProc",
+					fmt.Sprintf("Invalid external processing of BackendTrafficPolicy %s/%s: %v.", extProcPolicy.Namespace, extProcPolicy.Name, extProcErr),
+				)
+				if extProcPolicy.Spec.ExtProc.FailureMode != v1alpha1.FailOpen {
+					for _, routeRoute := range routeRoutes {
+						if routeRoute.DirectResponse != nil || routeRoute.Redirect != nil {
+							continue
+						}
+						routeRoute.BackendWeights.Invalid += routeRoute.BackendWeights.Valid
+						routeRoute.BackendWeights.Valid = 0
+						routeRoute.Destinations = nil
+						routeRoute.DirectResponse = &ir.DirectResponse{
+							StatusCode: 500,
+						}
+					}
+				}
+			}
+
+			// The requests are balanced round-robin if the load balancer of the
+			// policy is invalid.
+			if loadBalancerErr != nil {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					"InvalidLoadBalancer",
+					fmt.Sprintf("Invalid load balancer of BackendTrafficPolicy %s/%s: %v.", loadBalancerPolicy.Namespace, loadBalancerPolicy.Name, loadBalancerErr),
+				)
+			}
+
+			// The security features of the route fall back to the ones of the
+			// policy targeting the Gateway of the parent ref.
+			var gatewaySecurityPolicy *v1alpha1.SecurityPolicy
+			if len(parentRef.listeners) > 0 {
+				gatewaySecurityPolicy = securityPolicyForGateway(resources.SecurityPolicies, parentRef.listeners[0].gateway)
+			}
+			security, invalidSecurityPolicy, securityPolicyErr := buildRouteSecurity(routeSecurityPolicy, gatewaySecurityPolicy, resources)
+
+			// Requests must not be forwarded unauthenticated to backends whose
+			// security policy is invalid, so they receive a HTTP error response
+			// instead.
+			if securityPolicyErr != nil {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					"InvalidSecurityPolicy",
+					fmt.Sprintf("Invalid SecurityPolicy %s/%s: %v.", invalidSecurityPolicy.Namespace, invalidSecurityPolicy.Name, securityPolicyErr),
+				)
+				for _, routeRoute := range routeRoutes {
+					routeRoute.BackendWeights.Invalid += routeRoute.BackendWeights.Valid
+					routeRoute.BackendWeights.Valid = 0
+					routeRoute.Destinations = nil
+					routeRoute.Redirect = nil
+					routeRoute.DirectResponse = &ir.DirectResponse{
+						StatusCode: 500,
+					}
+				}
+			}
+
+			// In the strict validation mode, a route with an invalid field or
+			// an unresolved reference is rejected as a whole.
+			if t.StrictValidation && rejectInvalidRoute(httpRoute, parentRef) {
+				continue
+			}
+
 			var hasHostnameIntersection bool
 			for _, listener := range parentRef.listeners {
+				irKey := irStringKey(listener.gateway)
+				irListener := xdsIR[irKey].GetHTTPListener(irHTTPListenerName(listener))
+
 				hosts := computeHosts(httpRoute.GetHostnames(), listener.Hostname)
+				// The hosts served by a more specific listener are isolated
+				// from the routes of this listener.
+				if irListener != nil {
+					hosts = excludeIsolatedHostnames(hosts, irListener.IsolatedHostnames)
+				}
 				if len(hosts) == 0 {
 					continue
 				}
@@ -1169,15 +1643,29 @@ func (t *Translator) ProcessHTTPRoutes(httpRoutes []*v1beta1.HTTPRoute, gateways
 
 					for _, routeRoute := range routeRoutes {
 						hostRoute := &ir.HTTPRoute{
-							Name:                 fmt.Sprintf("%s-%s", routeRoute.Name, host),
-							PathMatch:            routeRoute.PathMatch,
-							HeaderMatches:        append(headerMatches, routeRoute.HeaderMatches...),
-							QueryParamMatches:    routeRoute.QueryParamMatches,
-							AddRequestHeaders:    routeRoute.AddRequestHeaders,
-							RemoveRequestHeaders: routeRoute.RemoveRequestHeaders,
-							Destinations:         routeRoute.Destinations,
-							Redirect:             routeRoute.Redirect,
-							DirectResponse:       routeRoute.DirectResponse,
+							Name:                    fmt.Sprintf("%s-%s", routeRoute.Name, host),
+							PathMatch:               routeRoute.PathMatch,
+							HeaderMatches:           append(headerMatches, routeRoute.HeaderMatches...),
+							QueryParamMatches:       routeRoute.QueryParamMatches,
+							AddRequestHeaders:       routeRoute.AddRequestHeaders,
+							RemoveRequestHeaders:    routeRoute.RemoveRequestHeaders,
+							Destinations:            routeRoute.Destinations,
+							Redirect:                routeRoute.Redirect,
+							DirectResponse:          routeRoute.DirectResponse,
+							Timeout:                 timeouts.Request,
+							ConnectTimeout:          connectTimeout,
+							BackendTLS:              backendTLS,
+							LoadBalancer:            loadBalancer,
+							DynamicForwardProxy:     dynamicForwardProxy,
+							AddResponseHeaders:      security.responseHeaders,
+							JWT:                     security.jwt,
+							ExtAuth:                 security.extAuth,
+							CSRF:                    security.csrf,
+							Transformation:          transformation,
+							Mirrors:                 routeRoute.Mirrors,
+							BandwidthLimit:          bandwidthLimit,
+							RuntimeWeightsKeyPrefix: runtimeWeightsKeyPrefix,
+							ExtProc:                 extProc,
 						}
 						// Don't bother copying over the weights unless the route has invalid backends.
 						if routeRoute.BackendWeights.Invalid > 0 {
@@ -1187,16 +1675,23 @@ func (t *Translator) ProcessHTTPRoutes(httpRoutes []*v1beta1.HTTPRoute, gateways
 					}
 				}
 
-				irKey := irStringKey(listener.gateway)
-				irListener := xdsIR[irKey].GetHTTPListener(irHTTPListenerName(listener))
 				if irListener != nil {
+					if err := t.limits.admitClusters(httpRouteClusters(perHostRoutes)); err != nil {
+						parentRef.SetCondition(httpRoute,
+							v1beta1.RouteConditionAccepted,
+							metav1.ConditionFalse,
+							RouteReasonLimitExceeded,
+							err.Error(),
+						)
+						continue
+					}
 					irListener.Routes = append(irListener.Routes, perHostRoutes...)
 				}
 				// Theoretically there should only be one parent ref per
 				// Route that attaches to a given Listener, so fine to just increment here, but we
 				// might want to check to ensure we're not double-counting.
 				if len(routeRoutes) > 0 {
-					listener.IncrementAttachedRoutes()
+					listener.IncrementAttachedRoutes(KindHTTPRoute)
 				}
 			}
 
@@ -1219,34 +1714,454 @@ func (t *Translator) ProcessHTTPRoutes(httpRoutes []*v1beta1.HTTPRoute, gateways
 					"Route is accepted",
 				)
 			}
+
+			if len(emptyRules) > 0 {
+				setEmptyBackendRefsCondition(parentRef, httpRoute, emptyRules, emptyBackendRefsResponse)
+			}
+		}
+	}
+
+	return relevantHTTPRoutes
+}
+
+// processRequestHeaderModifierFilter appends the headers to add or set and the
+// headers to remove of the RequestHeaderModifier filter to the provided ones,
+// and sets error statuses on the parentRef for the invalid headers.
+func processRequestHeaderModifierFilter(headerModifier *v1beta1.HTTPRequestHeaderFilter,
+	parentRef *RouteParentContext,
+	httpRoute *HTTPRouteContext,
+	addRequestHeaders []ir.AddHeader,
+	removeRequestHeaders []string) ([]ir.AddHeader, []string) {
+	// Make sure the header modifier config actually exists
+	if headerModifier == nil {
+		return addRequestHeaders, removeRequestHeaders
+	}
+	emptyFilterConfig := true // keep track of whether the provided config is empty or not
+
+	// Add request headers
+	if headersToAdd := headerModifier.Add; headersToAdd != nil {
+		if len(headersToAdd) > 0 {
+			emptyFilterConfig = false
+		}
+		for _, addHeader := range headersToAdd {
+			emptyFilterConfig = false
+			if addHeader.Name == "" {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					v1beta1.RouteReasonUnsupportedValue,
+					"RequestHeaderModifier Filter cannot add a header with an empty name",
+				)
+				// try to process the rest of the headers and produce a valid config.
+				continue
+			}
+			// Per Gateway API specification on HTTPHeaderName, : and / are invalid characters in header names
+			if strings.Contains(string(addHeader.Name), "/") || strings.Contains(string(addHeader.Name), ":") {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					v1beta1.RouteReasonUnsupportedValue,
+					fmt.Sprintf("RequestHeaderModifier Filter cannot set headers with a '/' or ':' character in them. Header: %q", string(addHeader.Name)),
+				)
+				continue
+			}
+			if !isValidHeaderName(string(addHeader.Name)) {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					v1beta1.RouteReasonUnsupportedValue,
+					fmt.Sprintf("RequestHeaderModifier Filter cannot set headers with invalid characters in their name. Header: %q", string(addHeader.Name)),
+				)
+				continue
+			}
+			if err := validateHeaderValue(addHeader.Value); err != nil {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					v1beta1.RouteReasonUnsupportedValue,
+					fmt.Sprintf("RequestHeaderModifier Filter cannot set header %q: %v", string(addHeader.Name), err),
+				)
+				continue
+			}
+			// Check if the header is a duplicate
+			headerKey := string(addHeader.Name)
+			canAddHeader := true
+			for _, h := range addRequestHeaders {
+				if strings.EqualFold(h.Name, headerKey) {
+					canAddHeader = false
+					break
+				}
+			}
+
+			if !canAddHeader {
+				continue
+			}
+
+			newHeader := ir.AddHeader{
+				Name:   headerKey,
+				Append: true,
+				Value:  addHeader.Value,
+			}
+
+			addRequestHeaders = append(addRequestHeaders, newHeader)
+		}
+	}
+
+	// Set headers
+	if headersToSet := headerModifier.Set; headersToSet != nil {
+		if len(headersToSet) > 0 {
+			emptyFilterConfig = false
+		}
+		for _, setHeader := range headersToSet {
+
+			if setHeader.Name == "" {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					v1beta1.RouteReasonUnsupportedValue,
+					"RequestHeaderModifier Filter cannot set a header with an empty name",
+				)
+				continue
+			}
+			// Per Gateway API specification on HTTPHeaderName, : and / are invalid characters in header names
+			if strings.Contains(string(setHeader.Name), "/") || strings.Contains(string(setHeader.Name), ":") {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					v1beta1.RouteReasonUnsupportedValue,
+					fmt.Sprintf("RequestHeaderModifier Filter cannot set headers with a '/' or ':' character in them. Header: '%s'", string(setHeader.Name)),
+				)
+				continue
+			}
+			if !isValidHeaderName(string(setHeader.Name)) {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					v1beta1.RouteReasonUnsupportedValue,
+					fmt.Sprintf("RequestHeaderModifier Filter cannot set headers with invalid characters in their name. Header: %q", string(setHeader.Name)),
+				)
+				continue
+			}
+			if err := validateHeaderValue(setHeader.Value); err != nil {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					v1beta1.RouteReasonUnsupportedValue,
+					fmt.Sprintf("RequestHeaderModifier Filter cannot set header %q: %v", string(setHeader.Name), err),
+				)
+				continue
+			}
+
+			// Check if the header to be set has already been configured
+			headerKey := string(setHeader.Name)
+			canAddHeader := true
+			for _, h := range addRequestHeaders {
+				if strings.EqualFold(h.Name, headerKey) {
+					canAddHeader = false
+					break
+				}
+			}
+			if !canAddHeader {
+				continue
+			}
+			newHeader := ir.AddHeader{
+				Name:   string(setHeader.Name),
+				Append: false,
+				Value:  setHeader.Value,
+			}
+
+			addRequestHeaders = append(addRequestHeaders, newHeader)
+		}
+	}
+
+	// Remove request headers
+	// As far as Envoy is concerned, it is ok to configure a header to be added/set and also in the list of
+	// headers to remove. It will remove the original header if present and then add/set the header after.
+	if headersToRemove := headerModifier.Remove; headersToRemove != nil {
+		if len(headersToRemove) > 0 {
+			emptyFilterConfig = false
+		}
+		for _, removedHeader := range headersToRemove {
+			if removedHeader == "" {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					v1beta1.RouteReasonUnsupportedValue,
+					"RequestHeaderModifier Filter cannot remove a header with an empty name",
+				)
+				continue
+			}
+			if !isValidHeaderName(removedHeader) {
+				parentRef.SetCondition(httpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					v1beta1.RouteReasonUnsupportedValue,
+					fmt.Sprintf("RequestHeaderModifier Filter cannot remove headers with invalid characters in their name. Header: %q", removedHeader),
+				)
+				continue
+			}
+
+			canRemHeader := true
+			for _, h := range removeRequestHeaders {
+				if strings.EqualFold(h, removedHeader) {
+					canRemHeader = false
+					break
+				}
+			}
+			if !canRemHeader {
+				continue
+			}
+
+			removeRequestHeaders = append(removeRequestHeaders, removedHeader)
+
+		}
+	}
+
+	// Update the status if the filter failed to configure any valid headers to add/remove
+	if len(addRequestHeaders) == 0 && len(removeRequestHeaders) == 0 && !emptyFilterConfig {
+		parentRef.SetCondition(httpRoute,
+			v1beta1.RouteConditionAccepted,
+			metav1.ConditionFalse,
+			v1beta1.RouteReasonUnsupportedValue,
+			"RequestHeaderModifier Filter did not provide valid configuration to add/set/remove any headers",
+		)
+	}
+
+	return addRequestHeaders, removeRequestHeaders
+}
+
+func (t *Translator) ProcessTLSRoutes(tlsRoutes []*v1alpha2.TLSRoute, gateways []*GatewayContext, resources *Resources, xdsIR XdsIRMap) []*TLSRouteContext {
+	var relevantTLSRoutes []*TLSRouteContext
+	limits := t.limits
+	strictValidation := t.StrictValidation
+	transitionTime := t.conditionTime
+	controllerName := t.ControllerName
+	now := t.now()
+
+	for _, t := range tlsRoutes {
+		if t == nil {
+			panic("received nil tlsroute")
+		}
+	}
+
+	// Server names claimed by TLSRoutes, per Gateway and port. Routes are
+	// processed by precedence, so that a server name is routed according
+	// to the oldest route matching it.
+	claimedSNIs := map[string]sets.String{}
+
+	for _, t := range sortTLSRoutes(tlsRoutes) {
+		tlsRoute := &TLSRouteContext{TLSRoute: t, conditionTime: transitionTime, controllerName: controllerName}
+		// Drop the parent statuses of the parentRefs removed from the spec.
+		t.Status.Parents = pruneRouteParentStatusesV1Alpha2(t.Status.Parents, t.Spec.ParentRefs, t.Namespace, controllerName)
+
+		// Find out if this route attaches to one of our Gateway's listeners,
+		// and if so, get the list of listeners that allow it to attach for each
+		// parentRef.
+		relevantRoute := processAllowedListenersForParentRefs(tlsRoute, gateways, resources)
+		if !relevantRoute {
+			continue
+		}
+
+		relevantTLSRoutes = append(relevantTLSRoutes, tlsRoute)
+
+		for _, parentRef := range tlsRoute.parentRefs {
+			// Skip parent refs that did not accept the route
+			if !parentRef.IsAccepted(tlsRoute) {
+				continue
+			}
+
+			// Skip the route if it is disabled.
+			if err := routeDisabledError(tlsRoute, now); err != nil {
+				parentRef.SetCondition(tlsRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					RouteReasonDisabled,
+					err.Error(),
+				)
+				continue
+			}
+
+			// Reject the route if it exceeds the translation limits.
+			if err := limits.admitRoute(tlsRoute, parentRef); err != nil {
+				parentRef.SetCondition(tlsRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					RouteReasonLimitExceeded,
+					err.Error(),
+				)
+				continue
+			}
+
+			// Need to compute Route rules within the parentRef loop because
+			// any conditions that come out of it have to go on each RouteParentStatus,
+			// not on the Route as a whole.
+			var routeDestinations []*ir.RouteDestination
+
+			// compute backends
+			for _, rule := range tlsRoute.Spec.Rules {
+				for _, backendRef := range rule.BackendRefs {
+					if routeDestination := buildL4RouteDest(backendRef, parentRef, tlsRoute, resources); routeDestination != nil {
+						routeDestinations = append(routeDestinations, routeDestination)
+					}
+				}
+
+				// TODO handle:
+				//	- no valid backend refs
+				//	- sum of weights for valid backend refs is 0
+				//	- returning 500's for invalid backend refs
+				//	- etc.
+			}
+
+			// In the strict validation mode, a route with an unresolved
+			// reference is rejected as a whole.
+			if strictValidation && rejectInvalidRoute(tlsRoute, parentRef) {
+				continue
+			}
+
+			var hasHostnameIntersection, hasUnclaimedHostname bool
+			for _, listener := range parentRef.listeners {
+				hosts := computeHosts(tlsRoute.GetHostnames(), listener.Hostname)
+				if len(hosts) == 0 {
+					continue
+				}
+
+				hasHostnameIntersection = true
+
+				irKey := irStringKey(listener.gateway)
+				containerPort := servicePortToContainerPort(int32(listener.Port))
+
+				// Skip the server names already claimed by routes with a higher
+				// precedence, Envoy rejects filter chains with identical matches.
+				portKey := fmt.Sprintf("%s/%d", irKey, containerPort)
+				if claimedSNIs[portKey] == nil {
+					claimedSNIs[portKey] = sets.NewString()
+				}
+				var unclaimedHosts []string
+				for _, host := range hosts {
+					if !claimedSNIs[portKey].Has(host) {
+						unclaimedHosts = append(unclaimedHosts, host)
+					}
+				}
+				if len(unclaimedHosts) == 0 {
+					continue
+				}
+				hasUnclaimedHostname = true
+				if err := limits.admitClusters(tcpRouteClusters(routeDestinations)); err != nil {
+					parentRef.SetCondition(tlsRoute,
+						v1beta1.RouteConditionAccepted,
+						metav1.ConditionFalse,
+						RouteReasonLimitExceeded,
+						err.Error(),
+					)
+					continue
+				}
+				claimedSNIs[portKey].Insert(unclaimedHosts...)
+				// Create the TCP Listener while parsing the TLSRoute since
+				// the listener directly links to a routeDestination.
+				irListener := &ir.TCPListener{
+					Name:    irTCPListenerName(listener, tlsRoute),
+					Address: irListenerAddress(resources.EnvoyProxy),
+					Port:    uint32(containerPort),
+					TLS: &ir.TLSInspectorConfig{
+						SNIs: unclaimedHosts,
+					},
+					Destinations:  routeDestinations,
+					JSONAccessLog: resources.EnvoyProxy.GetL4AccessLogFormat() == v1alpha1.AccessLogFormatJSON,
+				}
+				gwXdsIR := xdsIR[irKey]
+				gwXdsIR.TCP = append(gwXdsIR.TCP, irListener)
+
+				// Theoretically there should only be one parent ref per
+				// Route that attaches to a given Listener, so fine to just increment here, but we
+				// might want to check to ensure we're not double-counting.
+				if len(routeDestinations) > 0 {
+					listener.IncrementAttachedRoutes(KindTLSRoute)
+				}
+			}
+
+			if !hasHostnameIntersection {
+				parentRef.SetCondition(tlsRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					v1beta1.RouteReasonNoMatchingListenerHostname,
+					"There were no hostname intersections between the HTTPRoute and this parent ref's Listener(s).",
+				)
+			} else if !hasUnclaimedHostname {
+				parentRef.SetCondition(tlsRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					"HostnameConflict",
+					"All hostnames of the route are already matched by TLSRoutes with a higher precedence on this parent ref's Listener(s).",
+				)
+			}
+
+			// If no negative conditions have been set, the route is considered "Accepted=True".
+			if parentRef.tlsRoute != nil &&
+				len(parentRef.tlsRoute.Status.Parents[parentRef.routeParentStatusIdx].Conditions) == 0 {
+				parentRef.SetCondition(tlsRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionTrue,
+					v1beta1.RouteReasonAccepted,
+					"Route is accepted",
+				)
+			}
 		}
 	}
 
-	return relevantHTTPRoutes
+	return relevantTLSRoutes
 }
 
-func (t *Translator) ProcessTLSRoutes(tlsRoutes []*v1alpha2.TLSRoute, gateways []*GatewayContext, resources *Resources, xdsIR XdsIRMap) []*TLSRouteContext {
-	var relevantTLSRoutes []*TLSRouteContext
+func (t *Translator) ProcessTCPRoutes(tcpRoutes []*v1alpha2.TCPRoute, gateways []*GatewayContext, resources *Resources, xdsIR XdsIRMap) []*TCPRouteContext {
+	var relevantTCPRoutes []*TCPRouteContext
+	limits := t.limits
+	strictValidation := t.StrictValidation
+	transitionTime := t.conditionTime
+	controllerName := t.ControllerName
+	now := t.now()
 
-	for _, t := range tlsRoutes {
+	for _, t := range tcpRoutes {
 		if t == nil {
-			panic("received nil tlsroute")
+			panic("received nil tcproute")
 		}
-		tlsRoute := &TLSRouteContext{TLSRoute: t}
+		tcpRoute := &TCPRouteContext{TCPRoute: t, conditionTime: transitionTime, controllerName: controllerName}
+		// Drop the parent statuses of the parentRefs removed from the spec.
+		t.Status.Parents = pruneRouteParentStatusesV1Alpha2(t.Status.Parents, t.Spec.ParentRefs, t.Namespace, controllerName)
 
 		// Find out if this route attaches to one of our Gateway's listeners,
 		// and if so, get the list of listeners that allow it to attach for each
 		// parentRef.
-		relevantRoute := processAllowedListenersForParentRefs(tlsRoute, gateways, resources)
+		relevantRoute := processAllowedListenersForParentRefs(tcpRoute, gateways, resources)
 		if !relevantRoute {
 			continue
 		}
 
-		relevantTLSRoutes = append(relevantTLSRoutes, tlsRoute)
+		relevantTCPRoutes = append(relevantTCPRoutes, tcpRoute)
 
-		for _, parentRef := range tlsRoute.parentRefs {
+		for _, parentRef := range tcpRoute.parentRefs {
 			// Skip parent refs that did not accept the route
-			if !parentRef.IsAccepted(tlsRoute) {
+			if !parentRef.IsAccepted(tcpRoute) {
+				continue
+			}
+
+			// Skip the route if it is disabled.
+			if err := routeDisabledError(tcpRoute, now); err != nil {
+				parentRef.SetCondition(tcpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					RouteReasonDisabled,
+					err.Error(),
+				)
+				continue
+			}
+
+			// Reject the route if it exceeds the translation limits.
+			if err := limits.admitRoute(tcpRoute, parentRef); err != nil {
+				parentRef.SetCondition(tcpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					RouteReasonLimitExceeded,
+					err.Error(),
+				)
 				continue
 			}
 
@@ -1256,134 +2171,57 @@ func (t *Translator) ProcessTLSRoutes(tlsRoutes []*v1alpha2.TLSRoute, gateways [
 			var routeDestinations []*ir.RouteDestination
 
 			// compute backends
-			for _, rule := range tlsRoute.Spec.Rules {
+			for _, rule := range tcpRoute.Spec.Rules {
 				for _, backendRef := range rule.BackendRefs {
-					if backendRef.Group != nil && *backendRef.Group != "" {
-						parentRef.SetCondition(tlsRoute,
-							v1beta1.RouteConditionResolvedRefs,
-							metav1.ConditionFalse,
-							v1beta1.RouteReasonInvalidKind,
-							"Group is invalid, only the core API group (specified by omitting the group field or setting it to an empty string) is supported",
-						)
-						continue
-					}
-
-					if backendRef.Kind != nil && *backendRef.Kind != KindService {
-						parentRef.SetCondition(tlsRoute,
-							v1beta1.RouteConditionResolvedRefs,
-							metav1.ConditionFalse,
-							v1beta1.RouteReasonInvalidKind,
-							"Kind is invalid, only Service is supported",
-						)
-						continue
-					}
-
-					if backendRef.Namespace != nil && string(*backendRef.Namespace) != "" && string(*backendRef.Namespace) != tlsRoute.Namespace {
-						if !isValidCrossNamespaceRef(
-							crossNamespaceFrom{
-								group:     v1beta1.GroupName,
-								kind:      KindTLSRoute,
-								namespace: tlsRoute.Namespace,
-							},
-							crossNamespaceTo{
-								group:     "",
-								kind:      KindService,
-								namespace: string(*backendRef.Namespace),
-								name:      string(backendRef.Name),
-							},
-							resources.ReferenceGrants,
-						) {
-							parentRef.SetCondition(tlsRoute,
-								v1beta1.RouteConditionResolvedRefs,
-								metav1.ConditionFalse,
-								v1beta1.RouteReasonRefNotPermitted,
-								fmt.Sprintf("Backend ref to service %s/%s not permitted by any ReferenceGrant", *backendRef.Namespace, backendRef.Name),
-							)
-							continue
-						}
-					}
-
-					if backendRef.Port == nil {
-						parentRef.SetCondition(tlsRoute,
-							v1beta1.RouteConditionResolvedRefs,
-							metav1.ConditionFalse,
-							"PortNotSpecified",
-							"A valid port number corresponding to a port on the Service must be specified",
-						)
-						continue
-					}
-
-					// TODO: [v1alpha2-v1beta1] Replace with NamespaceDerefOr when TLSRoute graduates to v1beta1.
-					serviceNamespace := NamespaceDerefOrAlpha(backendRef.Namespace, tlsRoute.Namespace)
-					service := resources.GetService(serviceNamespace, string(backendRef.Name))
-					if service == nil {
-						parentRef.SetCondition(tlsRoute,
-							v1beta1.RouteConditionResolvedRefs,
-							metav1.ConditionFalse,
-							v1beta1.RouteReasonBackendNotFound,
-							fmt.Sprintf("Service %s/%s not found", serviceNamespace, string(backendRef.Name)),
-						)
-						continue
-					}
-
-					var portFound bool
-					for _, port := range service.Spec.Ports {
-						if port.Port == int32(*backendRef.Port) {
-							portFound = true
-							break
-						}
+					var routeDestination *ir.RouteDestination
+					if IsGatewayBackendRef(backendRef) {
+						routeDestination = buildInternalListenerDest(backendRef, parentRef, tcpRoute, xdsIR)
+					} else {
+						routeDestination = buildL4RouteDest(backendRef, parentRef, tcpRoute, resources)
 					}
-
-					if !portFound {
-						parentRef.SetCondition(tlsRoute,
-							v1beta1.RouteConditionResolvedRefs,
-							metav1.ConditionFalse,
-							"PortNotFound",
-							fmt.Sprintf("Port %d not found on service %s/%s", *backendRef.Port, serviceNamespace, string(backendRef.Name)),
-						)
-						continue
+					if routeDestination != nil {
+						routeDestinations = append(routeDestinations, routeDestination)
 					}
-
-					weight := uint32(1)
-					if backendRef.Weight != nil {
-						weight = uint32(*backendRef.Weight)
-					}
-
-					routeDestinations = append(routeDestinations, &ir.RouteDestination{
-						Host:   service.Spec.ClusterIP,
-						Port:   uint32(*backendRef.Port),
-						Weight: weight,
-					})
 				}
+			}
 
-				// TODO handle:
-				//	- no valid backend refs
-				//	- sum of weights for valid backend refs is 0
-				//	- returning 500's for invalid backend refs
-				//	- etc.
+			// In the strict validation mode, a route with an unresolved
+			// reference is rejected as a whole.
+			if strictValidation && rejectInvalidRoute(tcpRoute, parentRef) {
+				continue
 			}
 
-			var hasHostnameIntersection bool
 			for _, listener := range parentRef.listeners {
-				hosts := computeHosts(tlsRoute.GetHostnames(), listener.Hostname)
-				if len(hosts) == 0 {
-					continue
-				}
-
-				hasHostnameIntersection = true
-
 				irKey := irStringKey(listener.gateway)
 				containerPort := servicePortToContainerPort(int32(listener.Port))
-				// Create the TCP Listener while parsing the TLSRoute since
+				// Create the TCP Listener while parsing the TCPRoute since
 				// the listener directly links to a routeDestination.
 				irListener := &ir.TCPListener{
-					Name:    irTCPListenerName(listener, tlsRoute),
-					Address: "0.0.0.0",
-					Port:    uint32(containerPort),
-					TLS: &ir.TLSInspectorConfig{
-						SNIs: hosts,
-					},
-					Destinations: routeDestinations,
+					Name:          irTCPListenerName(listener, tcpRoute),
+					Address:       irListenerAddress(resources.EnvoyProxy),
+					Port:          uint32(containerPort),
+					Destinations:  routeDestinations,
+					JSONAccessLog: resources.EnvoyProxy.GetL4AccessLogFormat() == v1alpha1.AccessLogFormatJSON,
+				}
+				// TLS connections are terminated by the listener before being
+				// forwarded to the backends. If the listener has a hostname, only
+				// connections with a matching server name are accepted.
+				if isTLSTerminate(listener) {
+					irListener.TerminateTLS = irTLSConfig(listener)
+					if listener.Hostname != nil {
+						irListener.TLS = &ir.TLSInspectorConfig{
+							SNIs: []string{string(*listener.Hostname)},
+						}
+					}
+				}
+				if err := limits.admitClusters(tcpRouteClusters(routeDestinations)); err != nil {
+					parentRef.SetCondition(tcpRoute,
+						v1beta1.RouteConditionAccepted,
+						metav1.ConditionFalse,
+						RouteReasonLimitExceeded,
+						err.Error(),
+					)
+					continue
 				}
 				gwXdsIR := xdsIR[irKey]
 				gwXdsIR.TCP = append(gwXdsIR.TCP, irListener)
@@ -1392,23 +2230,158 @@ func (t *Translator) ProcessTLSRoutes(tlsRoutes []*v1alpha2.TLSRoute, gateways [
 				// Route that attaches to a given Listener, so fine to just increment here, but we
 				// might want to check to ensure we're not double-counting.
 				if len(routeDestinations) > 0 {
-					listener.IncrementAttachedRoutes()
+					listener.IncrementAttachedRoutes(KindTCPRoute)
 				}
 			}
 
-			if !hasHostnameIntersection {
-				parentRef.SetCondition(tlsRoute,
+			// If no negative conditions have been set, the route is considered "Accepted=True".
+			if parentRef.tcpRoute != nil &&
+				len(parentRef.tcpRoute.Status.Parents[parentRef.routeParentStatusIdx].Conditions) == 0 {
+				parentRef.SetCondition(tcpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionTrue,
+					v1beta1.RouteReasonAccepted,
+					"Route is accepted",
+				)
+			}
+		}
+	}
+
+	return relevantTCPRoutes
+}
+
+// RouteReasonListenerInUse is the reason of the Accepted condition of the
+// parentRefs of the UDPRoutes attaching to a UDP listener already serving
+// another UDPRoute, since the datagrams of a listener are all forwarded to the
+// same backends.
+const RouteReasonListenerInUse v1beta1.RouteConditionReason = "ListenerInUse"
+
+func (t *Translator) ProcessUDPRoutes(udpRoutes []*v1alpha2.UDPRoute, gateways []*GatewayContext, resources *Resources, xdsIR XdsIRMap) []*UDPRouteContext {
+	var relevantUDPRoutes []*UDPRouteContext
+	limits := t.limits
+	strictValidation := t.StrictValidation
+	transitionTime := t.conditionTime
+	controllerName := t.ControllerName
+	now := t.now()
+
+	for _, u := range udpRoutes {
+		if u == nil {
+			panic("received nil udproute")
+		}
+		udpRoute := &UDPRouteContext{UDPRoute: u, conditionTime: transitionTime, controllerName: controllerName}
+		// Drop the parent statuses of the parentRefs removed from the spec.
+		u.Status.Parents = pruneRouteParentStatusesV1Alpha2(u.Status.Parents, u.Spec.ParentRefs, u.Namespace, controllerName)
+
+		// Find out if this route attaches to one of our Gateway's listeners,
+		// and if so, get the list of listeners that allow it to attach for each
+		// parentRef.
+		relevantRoute := processAllowedListenersForParentRefs(udpRoute, gateways, resources)
+		if !relevantRoute {
+			continue
+		}
+
+		relevantUDPRoutes = append(relevantUDPRoutes, udpRoute)
+
+		for _, parentRef := range udpRoute.parentRefs {
+			// Skip parent refs that did not accept the route
+			if !parentRef.IsAccepted(udpRoute) {
+				continue
+			}
+
+			// Skip the route if it is disabled.
+			if err := routeDisabledError(udpRoute, now); err != nil {
+				parentRef.SetCondition(udpRoute,
 					v1beta1.RouteConditionAccepted,
 					metav1.ConditionFalse,
-					v1beta1.RouteReasonNoMatchingListenerHostname,
-					"There were no hostname intersections between the HTTPRoute and this parent ref's Listener(s).",
+					RouteReasonDisabled,
+					err.Error(),
 				)
+				continue
+			}
+
+			// Reject the route if it exceeds the translation limits.
+			if err := limits.admitRoute(udpRoute, parentRef); err != nil {
+				parentRef.SetCondition(udpRoute,
+					v1beta1.RouteConditionAccepted,
+					metav1.ConditionFalse,
+					RouteReasonLimitExceeded,
+					err.Error(),
+				)
+				continue
+			}
+
+			var routeDestinations []*ir.RouteDestination
+			for _, rule := range udpRoute.Spec.Rules {
+				for _, backendRef := range rule.BackendRefs {
+					if routeDestination := buildL4RouteDest(backendRef, parentRef, udpRoute, resources); routeDestination != nil {
+						routeDestinations = append(routeDestinations, routeDestination)
+					}
+				}
+			}
+
+			// In the strict validation mode, a route with an unresolved
+			// reference is rejected as a whole.
+			if strictValidation && rejectInvalidRoute(udpRoute, parentRef) {
+				continue
+			}
+
+			for _, listener := range parentRef.listeners {
+				gwXdsIR := xdsIR[irStringKey(listener.gateway)]
+				containerPort := servicePortToContainerPort(int32(listener.Port))
+				if udpListenerOnPort(gwXdsIR, uint32(containerPort)) {
+					parentRef.SetCondition(udpRoute,
+						v1beta1.RouteConditionAccepted,
+						metav1.ConditionFalse,
+						RouteReasonListenerInUse,
+						fmt.Sprintf("Listener %s already serves another UDPRoute", listener.Name),
+					)
+					continue
+				}
+
+				// Create the UDP Listener while parsing the UDPRoute since
+				// the listener directly links to a routeDestination.
+				clientTrafficPolicy := clientTrafficPolicyForGateway(resources.ClientTrafficPolicies, listener.gateway)
+				irListener := &ir.UDPListener{
+					Name:          irUDPListenerName(listener, udpRoute),
+					Address:       irListenerAddress(resources.EnvoyProxy),
+					Port:          uint32(containerPort),
+					Destinations:  routeDestinations,
+					LoadBalancer:  irUDPLoadBalancer(clientTrafficPolicy),
+					IdleTimeout:   irUDPIdleTimeout(clientTrafficPolicy),
+					JSONAccessLog: resources.EnvoyProxy.GetL4AccessLogFormat() == v1alpha1.AccessLogFormatJSON,
+				}
+				// The datagrams keep being proxied to the backends if the DNS
+				// records are invalid.
+				dns, err := irUDPDNS(clientTrafficPolicy)
+				if err != nil {
+					parentRef.SetCondition(udpRoute,
+						v1beta1.RouteConditionAccepted,
+						metav1.ConditionFalse,
+						"InvalidDNS",
+						fmt.Sprintf("Invalid DNS records of ClientTrafficPolicy %s/%s: %v.", clientTrafficPolicy.Namespace, clientTrafficPolicy.Name, err),
+					)
+				}
+				irListener.DNS = dns
+				if err := limits.admitClusters(1); err != nil {
+					parentRef.SetCondition(udpRoute,
+						v1beta1.RouteConditionAccepted,
+						metav1.ConditionFalse,
+						RouteReasonLimitExceeded,
+						err.Error(),
+					)
+					continue
+				}
+				gwXdsIR.UDP = append(gwXdsIR.UDP, irListener)
+
+				if len(routeDestinations) > 0 {
+					listener.IncrementAttachedRoutes(KindUDPRoute)
+				}
 			}
 
 			// If no negative conditions have been set, the route is considered "Accepted=True".
-			if parentRef.tlsRoute != nil &&
-				len(parentRef.tlsRoute.Status.Parents[parentRef.routeParentStatusIdx].Conditions) == 0 {
-				parentRef.SetCondition(tlsRoute,
+			if parentRef.udpRoute != nil &&
+				len(parentRef.udpRoute.Status.Parents[parentRef.routeParentStatusIdx].Conditions) == 0 {
+				parentRef.SetCondition(udpRoute,
 					v1beta1.RouteConditionAccepted,
 					metav1.ConditionTrue,
 					v1beta1.RouteReasonAccepted,
@@ -1418,7 +2391,129 @@ func (t *Translator) ProcessTLSRoutes(tlsRoutes []*v1alpha2.TLSRoute, gateways [
 		}
 	}
 
-	return relevantTLSRoutes
+	return relevantUDPRoutes
+}
+
+// udpListenerOnPort returns whether the xDS IR already has a UDP listener on
+// the provided port.
+func udpListenerOnPort(xdsIR *ir.Xds, port uint32) bool {
+	for _, udpListener := range xdsIR.UDP {
+		if udpListener.Port == port {
+			return true
+		}
+	}
+	return false
+}
+
+// buildL4RouteDest takes a backendRef of a TLSRoute, TCPRoute or UDPRoute and
+// translates it into a destination, or sets error statuses and returns nil if
+// the backendRef is invalid. Connections are split across the destinations
+// according to their weights.
+func buildL4RouteDest(backendRef v1alpha2.BackendRef,
+	parentRef *RouteParentContext,
+	route RouteContext,
+	resources *Resources) *ir.RouteDestination {
+	if backendRef.Group != nil && *backendRef.Group != "" {
+		parentRef.SetCondition(route,
+			v1beta1.RouteConditionResolvedRefs,
+			metav1.ConditionFalse,
+			v1beta1.RouteReasonInvalidKind,
+			"Group is invalid, only the core API group (specified by omitting the group field or setting it to an empty string) is supported",
+		)
+		return nil
+	}
+
+	if backendRef.Kind != nil && *backendRef.Kind != KindService {
+		parentRef.SetCondition(route,
+			v1beta1.RouteConditionResolvedRefs,
+			metav1.ConditionFalse,
+			v1beta1.RouteReasonInvalidKind,
+			"Kind is invalid, only Service is supported",
+		)
+		return nil
+	}
+
+	if backendRef.Namespace != nil && string(*backendRef.Namespace) != "" && string(*backendRef.Namespace) != route.GetNamespace() {
+		if !isValidCrossNamespaceRef(
+			crossNamespaceFrom{
+				group:     v1beta1.GroupName,
+				kind:      route.GetRouteType(),
+				namespace: route.GetNamespace(),
+			},
+			crossNamespaceTo{
+				group:     "",
+				kind:      KindService,
+				namespace: string(*backendRef.Namespace),
+				name:      string(backendRef.Name),
+			},
+			resources.ReferenceGrants,
+		) {
+			parentRef.SetCondition(route,
+				v1beta1.RouteConditionResolvedRefs,
+				metav1.ConditionFalse,
+				v1beta1.RouteReasonRefNotPermitted,
+				fmt.Sprintf("Backend ref to service %s/%s not permitted by any ReferenceGrant", *backendRef.Namespace, backendRef.Name),
+			)
+			return nil
+		}
+	}
+
+	// The backendRefs without a port may name the port of the Service with
+	// an annotation of the route.
+	portName := backendPortName(route, string(backendRef.Name))
+	if backendRef.Port == nil && portName == "" {
+		parentRef.SetCondition(route,
+			v1beta1.RouteConditionResolvedRefs,
+			metav1.ConditionFalse,
+			"PortNotSpecified",
+			"A valid port number corresponding to a port on the Service must be specified",
+		)
+		return nil
+	}
+
+	// TODO: [v1alpha2-v1beta1] Replace with NamespaceDerefOr when TLSRoute and TCPRoute graduate to v1beta1.
+	serviceNamespace := NamespaceDerefOrAlpha(backendRef.Namespace, route.GetNamespace())
+	service := resources.GetService(serviceNamespace, string(backendRef.Name))
+	if service == nil {
+		parentRef.SetCondition(route,
+			v1beta1.RouteConditionResolvedRefs,
+			metav1.ConditionFalse,
+			v1beta1.RouteReasonBackendNotFound,
+			fmt.Sprintf("Service %s/%s not found", serviceNamespace, string(backendRef.Name)),
+		)
+		return nil
+	}
+
+	var port *v1beta1.PortNumber
+	if backendRef.Port != nil {
+		port = PortNumPtr(int32(*backendRef.Port))
+	}
+	servicePort := findServicePort(service, port, portName)
+	if servicePort == nil {
+		parentRef.SetCondition(route,
+			v1beta1.RouteConditionResolvedRefs,
+			metav1.ConditionFalse,
+			"PortNotFound",
+			fmt.Sprintf("Port %s not found on service %s/%s", servicePortRef(port, portName), serviceNamespace, string(backendRef.Name)),
+		)
+		return nil
+	}
+
+	weight := uint32(1)
+	if backendRef.Weight != nil {
+		weight = uint32(*backendRef.Weight)
+	}
+
+	// A backend with a weight of 0 must not receive any connection.
+	if weight == 0 {
+		return nil
+	}
+
+	return &ir.RouteDestination{
+		Host:   service.Spec.ClusterIP,
+		Port:   uint32(servicePort.Port),
+		Weight: weight,
+	}
 }
 
 // processAllowedListenersForParentRefs finds out if the route attaches to one of our
@@ -1440,6 +2535,16 @@ func processAllowedListenersForParentRefs(routeContext RouteContext, gateways []
 		// Reset conditions since they will be recomputed during translation
 		parentRefCtx.ResetConditions(routeContext)
 
+		if len(selectedListeners) == 0 {
+			parentRefCtx.SetCondition(routeContext,
+				v1beta1.RouteConditionAccepted,
+				metav1.ConditionFalse,
+				"NoMatchingParent",
+				"There are no listeners matching the sectionName and port of this parent ref",
+			)
+			continue
+		}
+
 		if !HasReadyListener(selectedListeners) {
 			parentRefCtx.SetCondition(routeContext,
 				v1beta1.RouteConditionAccepted,
@@ -1451,14 +2556,31 @@ func processAllowedListenersForParentRefs(routeContext RouteContext, gateways []
 		}
 
 		var allowedListeners []*ListenerContext
+		var notAllowedByTenant bool
 		for _, listener := range selectedListeners {
 			acceptedKind := routeContext.GetRouteType()
 			if listener.AllowsKind(v1beta1.RouteGroupKind{Group: GroupPtr(v1beta1.GroupName), Kind: v1beta1.Kind(acceptedKind)}) &&
 				listener.AllowsNamespace(resources.GetNamespace(routeContext.GetNamespace())) {
+				// The tenants of the Gateway may restrict the namespaces
+				// allowed by the listener.
+				if !listener.allowsTenantNamespace(routeContext.GetNamespace()) {
+					notAllowedByTenant = true
+					continue
+				}
 				allowedListeners = append(allowedListeners, listener)
 			}
 		}
 
+		if len(allowedListeners) == 0 && notAllowedByTenant {
+			parentRefCtx.SetCondition(routeContext,
+				v1beta1.RouteConditionAccepted,
+				metav1.ConditionFalse,
+				RouteReasonNotAllowedByTenant,
+				fmt.Sprintf("Namespace %s is not allowed to attach routes to the Gateways of this parent ref by their tenants.", routeContext.GetNamespace()),
+			)
+			continue
+		}
+
 		if len(allowedListeners) == 0 {
 			parentRefCtx.SetCondition(routeContext,
 				v1beta1.RouteConditionAccepted,
@@ -1565,29 +2687,49 @@ func isValidHostname(hostname string) error {
 }
 
 func irStringKey(gateway *v1beta1.Gateway) string {
-	return fmt.Sprintf("%s-%s", gateway.Namespace, gateway.Name)
+	// The Gateway synthesized for the mesh has no namespace.
+	if gateway.Namespace == "" {
+		return gateway.Name
+	}
+	return IRKey(gateway.Namespace, gateway.Name)
+}
+
+// IRKey returns the key of the IR translated from the Gateway with the provided
+// namespace and name. It is also used as the xDS node cluster of the proxies
+// serving the Gateway.
+func IRKey(namespace, name string) string {
+	return fmt.Sprintf("%s-%s", namespace, name)
 }
 
 func irHTTPListenerName(listener *ListenerContext) string {
-	return fmt.Sprintf("%s-%s-%s", listener.gateway.Namespace, listener.gateway.Name, listener.Name)
+	return fmt.Sprintf("%s-%s", irStringKey(listener.gateway), listener.Name)
+}
+
+func irTCPListenerName(listener *ListenerContext, route RouteContext) string {
+	return fmt.Sprintf("%s-%s-%s-%s", listener.gateway.Namespace, listener.gateway.Name, listener.Name, route.GetName())
 }
 
-func irTCPListenerName(listener *ListenerContext, tlsRoute *TLSRouteContext) string {
-	return fmt.Sprintf("%s-%s-%s-%s", listener.gateway.Namespace, listener.gateway.Name, listener.Name, tlsRoute.Name)
+func irUDPListenerName(listener *ListenerContext, route RouteContext) string {
+	return fmt.Sprintf("%s-%s-%s-%s", listener.gateway.Namespace, listener.gateway.Name, listener.Name, route.GetName())
 }
 
 func routeName(route RouteContext, ruleIdx, matchIdx int) string {
 	return fmt.Sprintf("%s-%s-rule-%d-match-%d", route.GetNamespace(), route.GetName(), ruleIdx, matchIdx)
 }
 
-func irTLSConfig(tlsSecret *v1.Secret) *ir.TLSListenerConfig {
+func irTLSConfig(listener *ListenerContext) *ir.TLSListenerConfig {
+	tlsSecret := listener.tlsSecret
 	if tlsSecret == nil {
 		return nil
 	}
 
+	// The OCSP staple policy is validated along with the certificate ref.
+	staplePolicy, _ := ocspStaplePolicy(listener.TLS)
 	return &ir.TLSListenerConfig{
 		ServerCertificate: tlsSecret.Data[v1.TLSCertKey],
 		PrivateKey:        tlsSecret.Data[v1.TLSPrivateKeyKey],
+		OCSPStaple:        tlsSecret.Data[ocspStapleKey],
+		OCSPStaplePolicy:  staplePolicy,
 	}
 }
 
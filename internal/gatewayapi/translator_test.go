@@ -7,20 +7,22 @@ package gatewayapi
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
-	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
 	"sigs.k8s.io/yaml"
+
+	"github.com/envoyproxy/gateway/internal/golden"
 )
 
 func mustUnmarshal(t *testing.T, val string, out interface{}) {
@@ -28,69 +30,55 @@ func mustUnmarshal(t *testing.T, val string, out interface{}) {
 }
 
 func TestTranslate(t *testing.T) {
-	inputFiles, err := filepath.Glob(filepath.Join("testdata", "*.in.yaml"))
-	require.NoError(t, err)
-
-	for _, inputFile := range inputFiles {
-		inputFile := inputFile
-		t.Run(testName(inputFile), func(t *testing.T) {
-			input, err := os.ReadFile(inputFile)
-			require.NoError(t, err)
-
-			resources := &Resources{}
-			mustUnmarshal(t, string(input), resources)
-
-			output, err := os.ReadFile(strings.ReplaceAll(inputFile, ".in.yaml", ".out.yaml"))
-			require.NoError(t, err)
-
-			want := &TranslateResult{}
-			mustUnmarshal(t, string(output), want)
-
-			translator := &Translator{
-				GatewayClassName: "envoy-gateway-class",
-				ProxyImage:       "envoyproxy/envoy:translator-tests",
-			}
+	golden.Run(t, filepath.Join("testdata", "*.in.yaml"), ".in.yaml", func(t *testing.T, inputFile string) {
+		resources := &Resources{}
+		golden.ReadYAML(t, inputFile, resources)
+
+		translator := &Translator{
+			GatewayClassName: "envoy-gateway-class",
+			ProxyImage:       "envoyproxy/envoy:translator-tests",
+			MeshMode:         true,
+			// Validate the certificates of the test fixtures at a fixed
+			// time, so that the golden files don't depend on their expiry.
+			Clock: func() time.Time {
+				return time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)
+			},
+		}
 
-			// Add common test fixtures
-			for i := 1; i <= 3; i++ {
-				resources.Services = append(resources.Services,
-					&v1.Service{
-						ObjectMeta: metav1.ObjectMeta{
-							Namespace: "default",
-							Name:      "service-" + strconv.Itoa(i),
-						},
-						Spec: v1.ServiceSpec{
-							ClusterIP: "7.7.7.7",
-							Ports: []v1.ServicePort{
-								{Port: 8080},
-								{Port: 8443},
-							},
+		// Add common test fixtures
+		for i := 1; i <= 3; i++ {
+			resources.Services = append(resources.Services,
+				&v1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "service-" + strconv.Itoa(i),
+					},
+					Spec: v1.ServiceSpec{
+						ClusterIP: "7.7.7.7",
+						Ports: []v1.ServicePort{
+							{Port: 8080},
+							{Port: 8443},
 						},
 					},
-				)
-			}
-
-			resources.Namespaces = append(resources.Namespaces, &v1.Namespace{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "envoy-gateway",
-				},
-			}, &v1.Namespace{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "default",
 				},
-			})
-
-			got := translator.Translate(resources)
+			)
+		}
 
-			opts := cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")
-			require.Empty(t, cmp.Diff(want, got, opts))
+		resources.Namespaces = append(resources.Namespaces, &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "envoy-gateway",
+			},
+		}, &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "default",
+			},
 		})
-	}
-}
 
-func testName(inputFile string) string {
-	_, fileName := filepath.Split(inputFile)
-	return strings.TrimSuffix(fileName, ".in.yaml")
+		got := translator.Translate(resources)
+
+		opts := cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")
+		golden.AssertYAML(t, strings.ReplaceAll(inputFile, ".in.yaml", ".out.yaml"), got, opts)
+	})
 }
 
 func TestIsValidHostname(t *testing.T) {
@@ -336,3 +324,34 @@ func TestServicePortToContainerPort(t *testing.T) {
 		assert.Equal(t, tc.containerPort, got)
 	}
 }
+
+func TestRecordAttachedRouteKinds(t *testing.T) {
+	gateway := &v1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "envoy-gateway",
+			Name:      "gateway-1",
+		},
+		Spec: v1beta1.GatewaySpec{
+			Listeners: []v1beta1.Listener{
+				{Name: "http"},
+				{Name: "tls"},
+				{Name: "tcp"},
+			},
+		},
+	}
+	gctx := &GatewayContext{Gateway: gateway}
+
+	http := gctx.GetListenerContext("http")
+	http.IncrementAttachedRoutes(KindHTTPRoute)
+	http.IncrementAttachedRoutes(KindHTTPRoute)
+	tls := gctx.GetListenerContext("tls")
+	tls.IncrementAttachedRoutes(KindTLSRoute)
+	tls.IncrementAttachedRoutes(KindTCPRoute)
+	gctx.GetListenerContext("tcp")
+
+	recordAttachedRouteKinds([]*GatewayContext{gctx})
+
+	require.Equal(t, `{"http":{"HTTPRoute":2},"tcp":{},"tls":{"TCPRoute":1,"TLSRoute":1}}`,
+		gateway.Annotations[AttachedRouteKindsAnnotation])
+	require.EqualValues(t, 2, gateway.Status.Listeners[1].AttachedRoutes)
+}
@@ -0,0 +1,91 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// RouteReasonRejected is the reason of the Accepted condition of the
+	// parentRefs of the routes rejected in the strict validation mode.
+	RouteReasonRejected v1beta1.RouteConditionReason = "Rejected"
+	// ListenerReasonRejected is the reason of the Ready condition of the
+	// valid listeners of the Gateways rejected in the strict validation mode.
+	ListenerReasonRejected v1beta1.ListenerConditionReason = "Rejected"
+)
+
+// rejectInvalidRoute returns whether the route has an invalid field or an
+// unresolved reference on the parentRef, i.e. any False condition, in which
+// case it is rejected in the strict validation mode, and sets its Accepted
+// condition to False if it isn't already.
+func rejectInvalidRoute(route RouteContext, parentRef *RouteParentContext) bool {
+	var invalid *metav1.Condition
+	for _, cond := range parentRef.GetConditions(route) {
+		if cond.Status != metav1.ConditionFalse {
+			continue
+		}
+		if cond.Type == string(v1beta1.RouteConditionAccepted) {
+			return true
+		}
+		if invalid == nil {
+			invalid = cond.DeepCopy()
+		}
+	}
+	if invalid == nil {
+		return false
+	}
+
+	parentRef.SetCondition(route,
+		v1beta1.RouteConditionAccepted,
+		metav1.ConditionFalse,
+		RouteReasonRejected,
+		fmt.Sprintf("The route is rejected by the strict validation mode: %s", invalid.Message),
+	)
+	return true
+}
+
+// rejectInvalidGateway returns whether the Gateway has an invalid listener,
+// in which case it is rejected in the strict validation mode, and sets the
+// Ready condition of its valid listeners to False.
+func rejectInvalidGateway(gateway *GatewayContext) bool {
+	var invalid []string
+	for _, listener := range gateway.listeners {
+		if !listener.IsReady() {
+			invalid = append(invalid, string(listener.Name))
+		}
+	}
+	if len(invalid) == 0 {
+		return false
+	}
+
+	for _, listener := range gateway.listeners {
+		if listener.IsReady() {
+			listener.SetCondition(
+				v1beta1.ListenerConditionReady,
+				metav1.ConditionFalse,
+				ListenerReasonRejected,
+				fmt.Sprintf("The Gateway is rejected by the strict validation mode, due to its invalid listeners %v.", invalid),
+			)
+		}
+	}
+	return true
+}
+
+// resetGatewayIR removes the listeners of a rejected Gateway from its IRs.
+func resetGatewayIR(xdsIR *ir.Xds, infraIR *ir.Infra) {
+	xdsIR.HTTP = nil
+	xdsIR.TCP = nil
+	xdsIR.UDP = nil
+	for i := range infraIR.Proxy.Listeners {
+		infraIR.Proxy.Listeners[i].Ports = nil
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+const invalidResourcesYAML = `
+gateways:
+  - apiVersion: gateway.networking.k8s.io/v1beta1
+    kind: Gateway
+    metadata:
+      namespace: envoy-gateway
+      name: gateway-1
+    spec:
+      gatewayClassName: envoy-gateway-class
+      listeners:
+        - name: http
+          protocol: HTTP
+          port: 80
+          allowedRoutes:
+            namespaces:
+              from: All
+  - apiVersion: gateway.networking.k8s.io/v1beta1
+    kind: Gateway
+    metadata:
+      namespace: envoy-gateway
+      name: gateway-2
+    spec:
+      gatewayClassName: envoy-gateway-class
+      listeners:
+        - name: http
+          protocol: HTTP
+          port: 80
+          allowedRoutes:
+            namespaces:
+              from: All
+        - name: sctp
+          protocol: SCTP
+          port: 53
+          allowedRoutes:
+            namespaces:
+              from: All
+httpRoutes:
+  - apiVersion: gateway.networking.k8s.io/v1beta1
+    kind: HTTPRoute
+    metadata:
+      namespace: default
+      name: httproute-1
+    spec:
+      parentRefs:
+        - namespace: envoy-gateway
+          name: gateway-1
+      rules:
+        - matches:
+            - path:
+                value: "/1"
+          backendRefs:
+            - name: service-1
+              port: 8080
+  - apiVersion: gateway.networking.k8s.io/v1beta1
+    kind: HTTPRoute
+    metadata:
+      namespace: default
+      name: httproute-2
+    spec:
+      parentRefs:
+        - namespace: envoy-gateway
+          name: gateway-1
+      rules:
+        - matches:
+            - path:
+                value: "/2"
+          backendRefs:
+            - name: service-1
+              port: 8080
+        - matches:
+            - path:
+                value: "/missing"
+          backendRefs:
+            - name: missing
+              port: 8080
+`
+
+func TestValidationMode(t *testing.T) {
+	testCases := []struct {
+		name   string
+		strict bool
+		// routes are the names of the routes of the HTTP listener of gateway-1.
+		routes []string
+		// gateway2Listeners is the number of HTTP listeners of gateway-2.
+		gateway2Listeners int
+		// reason is the reason of the Accepted condition of httproute-2.
+		reason v1beta1.RouteConditionReason
+	}{
+		{
+			name: "generous",
+			routes: []string{
+				"default-httproute-1-rule-0-match-0-*",
+				"default-httproute-2-rule-0-match-0-*",
+				"default-httproute-2-rule-1-match-0-*",
+			},
+			gateway2Listeners: 1,
+			reason:            v1beta1.RouteReasonAccepted,
+		},
+		{
+			name:   "strict",
+			strict: true,
+			routes: []string{
+				"default-httproute-1-rule-0-match-0-*",
+			},
+			reason: RouteReasonRejected,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			resources := &Resources{}
+			mustUnmarshal(t, invalidResourcesYAML, resources)
+			resources.Namespaces = append(resources.Namespaces, &v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			})
+			resources.Services = append(resources.Services, &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "service-1"},
+				Spec: v1.ServiceSpec{
+					ClusterIP: "7.7.7.7",
+					Ports:     []v1.ServicePort{{Port: 8080}},
+				},
+			})
+
+			translator := &Translator{GatewayClassName: "envoy-gateway-class", StrictValidation: tc.strict}
+			result := translator.Translate(resources)
+
+			listener := result.XdsIR[IRKey("envoy-gateway", "gateway-1")].GetHTTPListener("envoy-gateway-gateway-1-http")
+			require.NotNil(t, listener)
+			var routes []string
+			for _, route := range listener.Routes {
+				routes = append(routes, route.Name)
+			}
+			require.ElementsMatch(t, tc.routes, routes)
+
+			require.Len(t, result.XdsIR[IRKey("envoy-gateway", "gateway-2")].HTTP, tc.gateway2Listeners)
+			require.Len(t, result.InfraIR[IRKey("envoy-gateway", "gateway-2")].Proxy.Listeners[0].Ports, tc.gateway2Listeners)
+
+			require.Len(t, result.HTTPRoutes, 2)
+			for _, route := range result.HTTPRoutes {
+				var accepted *metav1.Condition
+				for i, cond := range route.Status.Parents[0].Conditions {
+					if cond.Type == string(v1beta1.RouteConditionAccepted) {
+						accepted = &route.Status.Parents[0].Conditions[i]
+					}
+				}
+				require.NotNil(t, accepted, route.Name)
+				if route.Name == "httproute-2" {
+					require.Equal(t, string(tc.reason), accepted.Reason)
+				} else {
+					require.Equal(t, string(v1beta1.RouteReasonAccepted), accepted.Reason)
+				}
+			}
+		})
+	}
+}
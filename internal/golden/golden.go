@@ -0,0 +1,121 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+// Package golden runs testdata-driven tests comparing the outputs of the
+// translators with golden files, so that downstream forks and extensions can
+// validate their behavior the same way as Envoy Gateway. Running the tests
+// with the -update flag writes the actual outputs to the golden files instead
+// of comparing them, e.g.:
+//
+//	go test ./internal/gatewayapi/... -run TestTranslate -update
+package golden
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+)
+
+var update = flag.Bool("update", false, "Write the actual outputs to the golden files instead of comparing them.")
+
+// Updating returns whether the golden files are written instead of compared.
+func Updating() bool {
+	return *update
+}
+
+// Run runs the test as a subtest for each input file matching the pattern,
+// named after the input file without the suffix. The test fails if no input
+// file matches.
+func Run(t *testing.T, pattern, suffix string, test func(t *testing.T, inputFile string)) {
+	t.Helper()
+	inputFiles, err := filepath.Glob(pattern)
+	require.NoError(t, err)
+	require.NotEmpty(t, inputFiles, "no input file matches %s", pattern)
+
+	for _, inputFile := range inputFiles {
+		inputFile := inputFile
+		t.Run(strings.TrimSuffix(filepath.Base(inputFile), suffix), func(t *testing.T) {
+			test(t, inputFile)
+		})
+	}
+}
+
+// ReadYAML decodes the YAML file into out, failing the test if the file
+// holds unknown fields.
+func ReadYAML(t testing.TB, path string, out interface{}) {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, yaml.UnmarshalStrict(content, out, yaml.DisallowUnknownFields))
+}
+
+// AssertFile compares got with the content of the golden file, or writes got
+// to the golden file if updating.
+func AssertFile(t testing.TB, path string, got []byte) {
+	t.Helper()
+	if Updating() {
+		require.NoError(t, os.WriteFile(path, got, 0o600))
+		return
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(want), string(got), "golden file %s", path)
+}
+
+// AssertYAML compares got with the value decoded from the golden YAML file,
+// or writes got marshalled to YAML to the golden file if updating. The values
+// are compared with the provided options, so that the golden file may be
+// formatted freely and omit the fields ignored by the options.
+func AssertYAML[T any](t testing.TB, path string, got T, opts ...cmp.Option) {
+	t.Helper()
+	if Updating() {
+		content, err := yaml.Marshal(got)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, content, 0o600))
+		return
+	}
+	var want T
+	ReadYAML(t, path, &want)
+	require.Empty(t, cmp.Diff(want, got, opts...), "golden file %s", path)
+}
+
+// AssertXdsResources compares the xDS resources, marshalled to YAML, with the
+// content of the golden file, or writes them to the golden file if updating.
+func AssertXdsResources(t testing.TB, path string, resources []types.Resource) {
+	t.Helper()
+	jsonBytes, err := marshalResourcesToJSON(resources)
+	require.NoError(t, err)
+	data, err := yaml.JSONToYAML(jsonBytes)
+	require.NoError(t, err)
+	AssertFile(t, path, data)
+}
+
+// marshalResourcesToJSON marshals the xDS resources to a JSON array.
+func marshalResourcesToJSON(resources []types.Resource) ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('[')
+	for idx, resource := range resources {
+		if idx != 0 {
+			buffer.WriteByte(',')
+		}
+		b, err := protojson.Marshal(resource.(proto.Message))
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(b)
+	}
+	buffer.WriteByte(']')
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,152 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+// Package harness runs the translation of the resources to the xDS IR and
+// the xDS resources in memory, so that contributors can write end to end
+// translation tests without a Kubernetes API server.
+//
+// A test starts the harness, applies resources to its in-memory provider and
+// asserts on the resulting IR, xDS resources and statuses:
+//
+//	h := harness.Start(t, nil)
+//	require.NoError(t, h.Apply(gatewayClass, gateway, service, httpRoute))
+//	h.RequireHTTPRouteCondition(t, key, "Accepted", metav1.ConditionTrue)
+package harness
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	gatewayapirunner "github.com/envoyproxy/gateway/internal/gatewayapi/runner"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/provider/memory"
+	xdstranslatorrunner "github.com/envoyproxy/gateway/internal/xds/translator/runner"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+const (
+	// waitTimeout is the time the assertions wait for the translation to
+	// satisfy them.
+	waitTimeout = 5 * time.Second
+	// waitTick is the interval at which the assertions are checked.
+	waitTick = 20 * time.Millisecond
+)
+
+// Harness runs the Gateway API and xDS translators on the resources applied
+// to its in-memory provider.
+type Harness struct {
+	*memory.Provider
+
+	// ProviderResources are the resources published by the provider, and the
+	// statuses published by the Gateway API translator.
+	ProviderResources *message.ProviderResources
+	// XdsIR and InfraIR are the IRs published by the Gateway API translator.
+	XdsIR   *message.XdsIR
+	InfraIR *message.InfraIR
+	// Xds are the xDS resources published by the xDS translator.
+	Xds *message.Xds
+}
+
+// Start starts the translators of the harness with the provided
+// configuration, or the default configuration if nil. The translators are
+// stopped when the test completes.
+func Start(t testing.TB, cfg *config.Server) *Harness {
+	t.Helper()
+	if cfg == nil {
+		var err error
+		cfg, err = config.NewDefaultServer()
+		require.NoError(t, err)
+	}
+
+	h := &Harness{
+		ProviderResources: new(message.ProviderResources),
+		XdsIR:             new(message.XdsIR),
+		InfraIR:           new(message.InfraIR),
+		Xds:               new(message.Xds),
+	}
+	h.Provider = memory.New(h.ProviderResources)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	require.NoError(t, gatewayapirunner.New(&gatewayapirunner.Config{
+		Server:            *cfg,
+		ProviderResources: h.ProviderResources,
+		XdsIR:             h.XdsIR,
+		InfraIR:           h.InfraIR,
+	}).Start(ctx))
+	require.NoError(t, xdstranslatorrunner.New(&xdstranslatorrunner.Config{
+		Server: *cfg,
+		XdsIR:  h.XdsIR,
+		Xds:    h.Xds,
+	}).Start(ctx))
+
+	return h
+}
+
+// RequireXdsIR waits until the xDS IR of the provided key, see
+// gatewayapi.IRKey, satisfies the condition, and fails the test otherwise.
+func (h *Harness) RequireXdsIR(t testing.TB, key string, condition func(*ir.Xds) bool) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		xdsIR, ok := h.XdsIR.Load(key)
+		return ok && condition(xdsIR)
+	}, waitTimeout, waitTick, "xds ir %s does not satisfy the condition", key)
+}
+
+// RequireNoXdsIR waits until there is no xDS IR of the provided key, and
+// fails the test otherwise.
+func (h *Harness) RequireNoXdsIR(t testing.TB, key string) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		_, ok := h.XdsIR.Load(key)
+		return !ok
+	}, waitTimeout, waitTick, "xds ir %s is not deleted", key)
+}
+
+// RequireXds waits until the xDS resources of the provided key satisfy the
+// condition, and fails the test otherwise.
+func (h *Harness) RequireXds(t testing.TB, key string, condition func(xdstypes.XdsResources) bool) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		xds, ok := h.Xds.Load(key)
+		return ok && condition(xds.GetXdsResources())
+	}, waitTimeout, waitTick, "xds resources %s do not satisfy the condition", key)
+}
+
+// RequireGatewayCondition waits until the Gateway has the condition of the
+// provided type and status, and fails the test otherwise.
+func (h *Harness) RequireGatewayCondition(t testing.TB, key types.NamespacedName, conditionType string, status metav1.ConditionStatus) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		gateway, ok := h.ProviderResources.GatewayStatuses.Load(key)
+		return ok && meta.IsStatusConditionPresentAndEqual(gateway.Status.Conditions, conditionType, status)
+	}, waitTimeout, waitTick, "gateway %s has no %s condition with status %s", key, conditionType, status)
+}
+
+// RequireHTTPRouteCondition waits until any parent of the HTTPRoute has the
+// condition of the provided type and status, and fails the test otherwise.
+func (h *Harness) RequireHTTPRouteCondition(t testing.TB, key types.NamespacedName, conditionType string, status metav1.ConditionStatus) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		route, ok := h.ProviderResources.HTTPRouteStatuses.Load(key)
+		if !ok {
+			return false
+		}
+		for _, parent := range route.Status.Parents {
+			if meta.IsStatusConditionPresentAndEqual(parent.Conditions, conditionType, status) {
+				return true
+			}
+		}
+		return false
+	}, waitTimeout, waitTick, "httproute %s has no %s condition with status %s", key, conditionType, status)
+}
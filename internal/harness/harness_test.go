@@ -0,0 +1,101 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package harness
+
+import (
+	"testing"
+
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/ir"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestHarness(t *testing.T) {
+	h := Start(t, nil)
+
+	gatewayClass := &gwapiv1b1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "eg"},
+		Spec:       gwapiv1b1.GatewayClassSpec{ControllerName: v1alpha1.GatewayControllerName},
+	}
+	from := gwapiv1b1.NamespacesFromSame
+	gateway := &gwapiv1b1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "eg"},
+		Spec: gwapiv1b1.GatewaySpec{
+			GatewayClassName: "eg",
+			Listeners: []gwapiv1b1.Listener{
+				{
+					Name:          "http",
+					Protocol:      gwapiv1b1.HTTPProtocolType,
+					Port:          80,
+					AllowedRoutes: &gwapiv1b1.AllowedRoutes{Namespaces: &gwapiv1b1.RouteNamespaces{From: &from}},
+				},
+			},
+		},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "backend"},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: "10.0.0.1",
+			Ports:     []corev1.ServicePort{{Port: 3000}},
+		},
+	}
+	port := gwapiv1b1.PortNumber(3000)
+	// The in-memory provider doesn't default the resources like the API
+	// server, so the rule sets the path match it would default.
+	pathPrefix := gwapiv1b1.PathMatchPathPrefix
+	path := "/"
+	httpRoute := &gwapiv1b1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "backend"},
+		Spec: gwapiv1b1.HTTPRouteSpec{
+			CommonRouteSpec: gwapiv1b1.CommonRouteSpec{
+				ParentRefs: []gwapiv1b1.ParentReference{{Name: "eg"}},
+			},
+			Rules: []gwapiv1b1.HTTPRouteRule{
+				{
+					Matches: []gwapiv1b1.HTTPRouteMatch{
+						{Path: &gwapiv1b1.HTTPPathMatch{Type: &pathPrefix, Value: &path}},
+					},
+					BackendRefs: []gwapiv1b1.HTTPBackendRef{
+						{BackendRef: gwapiv1b1.BackendRef{BackendObjectReference: gwapiv1b1.BackendObjectReference{
+							Name: "backend",
+							Port: &port,
+						}}},
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, h.Apply(gatewayClass, gateway, namespace, service, httpRoute))
+
+	routeKey := types.NamespacedName{Namespace: "default", Name: "backend"}
+	irKey := gatewayapi.IRKey("default", "eg")
+	h.RequireHTTPRouteCondition(t, routeKey, string(gwapiv1b1.RouteConditionAccepted), metav1.ConditionTrue)
+	h.RequireXdsIR(t, irKey, func(xdsIR *ir.Xds) bool {
+		return len(xdsIR.HTTP) == 1 && len(xdsIR.HTTP[0].Routes) == 1 &&
+			xdsIR.HTTP[0].Routes[0].Destinations[0].Host == "10.0.0.1"
+	})
+	h.RequireXds(t, irKey, func(resources xdstypes.XdsResources) bool {
+		return len(resources[resourcev3.ListenerType]) == 1 && len(resources[resourcev3.ClusterType]) == 1
+	})
+
+	// The backend of the route can't be resolved once it is deleted.
+	require.NoError(t, h.Delete(service))
+	h.RequireHTTPRouteCondition(t, routeKey, string(gwapiv1b1.RouteConditionResolvedRefs), metav1.ConditionFalse)
+
+	require.NoError(t, h.Delete(gateway))
+	h.RequireNoXdsIR(t, irKey)
+
+	require.Error(t, h.Apply(&corev1.ConfigMap{}))
+}
@@ -1,75 +0,0 @@
-admin:
-  access_log_path: {{ .AdminServer.AccessLogPath }}
-  address:
-    socket_address:
-      address: {{ .AdminServer.Address }}
-      port_value: {{ .AdminServer.Port }}
-dynamic_resources:
-  cds_config:
-    resource_api_version: V3
-    api_config_source:
-      api_type: DELTA_GRPC
-      transport_api_version: V3
-      grpc_services:
-      - envoy_grpc:
-          cluster_name: xds_cluster
-      set_node_on_first_message_only: true
-  lds_config:
-    resource_api_version: V3
-    api_config_source:
-      api_type: DELTA_GRPC
-      transport_api_version: V3
-      grpc_services:
-      - envoy_grpc:
-          cluster_name: xds_cluster
-      set_node_on_first_message_only: true
-node:
-  cluster: envoy-gateway-system
-  id: envoy-default
-static_resources:
-  clusters:
-  - connect_timeout: 1s
-    load_assignment:
-      cluster_name: xds_cluster
-      endpoints:
-      - lb_endpoints:
-        - endpoint:
-            address:
-              socket_address:
-                address: {{ .XdsServer.Address }}
-                port_value: {{ .XdsServer.Port }}
-    http2_protocol_options: {}
-    name: xds_cluster
-    type: STRICT_DNS
-    transport_socket:
-      name: envoy.transport_sockets.tls
-      typed_config:
-        "@type": type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.UpstreamTlsContext
-        common_tls_context:
-          tls_params:
-            tls_maximum_protocol_version: TLSv1_3
-          tls_certificate_sds_secret_configs:
-          - name: xds_certificate
-            sds_config:
-              path_config_source:
-                path: "/sds/xds-certificate.json"
-              resource_api_version: V3
-          validation_context_sds_secret_config:
-            name: xds_trusted_ca
-            sds_config:
-              path_config_source:
-                path: "/sds/xds-trusted-ca.json"
-              resource_api_version: V3
-layered_runtime:
-  layers:
-    - name: runtime-0
-      rtds_layer:
-        rtds_config:
-          resource_api_version: V3
-          api_config_source:
-            transport_api_version: V3
-            api_type: DELTA_GRPC
-            grpc_services:
-              envoy_grpc:
-                cluster_name: xds_cluster
-        name: runtime-0
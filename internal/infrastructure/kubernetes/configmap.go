@@ -19,11 +19,10 @@ import (
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/provider/utils"
+	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
 )
 
 const (
-	sdsCAFilename   = "xds-trusted-ca.json"
-	sdsCertFilename = "xds-certificate.json"
 	// xdsTLSCertFilename is the fully qualified path of the file containing Envoy's
 	// xDS server TLS certificate.
 	xdsTLSCertFilename = "/certs/tls.crt"
@@ -37,12 +36,8 @@ const (
 
 var (
 	// xDS certificate rotation is supported by using SDS path-based resource files.
-	sdsCAConfigMapData = fmt.Sprintf(`{"resources":[{"@type":"type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.Secret",`+
-		`"name":"xds_trusted_ca","validation_context":{"trusted_ca":{"filename":"%s"},`+
-		`"match_typed_subject_alt_names":[{"san_type":"DNS","matcher":{"exact":"envoy-gateway"}}]}}]}`, xdsTLSCaFilename)
-	sdsCertConfigMapData = fmt.Sprintf(`{"resources":[{"@type":"type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.Secret",`+
-		`"name":"xds_certificate","tls_certificate":{"certificate_chain":{"filename":"%s"},`+
-		`"private_key":{"filename":"%s"}}}]}`, xdsTLSCertFilename, xdsTLSKeyFilename)
+	sdsCAConfigMapData   = bootstrap.SdsCAConfig(xdsTLSCaFilename)
+	sdsCertConfigMapData = bootstrap.SdsCertificateConfig(xdsTLSCertFilename, xdsTLSKeyFilename)
 )
 
 // expectedConfigMap returns the expected ConfigMap based on the provided infra.
@@ -59,13 +54,19 @@ func (i *Infra) expectedConfigMap(infra *ir.Infra) (*corev1.ConfigMap, error) {
 			Name:      expectedConfigMapName(infra.Proxy.Name),
 			Labels:    labels,
 		},
-		Data: map[string]string{
-			sdsCAFilename:   sdsCAConfigMapData,
-			sdsCertFilename: sdsCertConfigMapData,
-		},
+		Data: expectedConfigMapData(),
 	}, nil
 }
 
+// expectedConfigMapData returns the static config files of the proxies held
+// by the ConfigMap.
+func expectedConfigMapData() map[string]string {
+	return map[string]string{
+		bootstrap.SdsCAFilename:   sdsCAConfigMapData,
+		bootstrap.SdsCertFilename: sdsCertConfigMapData,
+	}
+}
+
 // createOrUpdateConfigMap creates a ConfigMap in the Kube api server based on the provided
 // infra, if it doesn't exist and updates it if it does.
 func (i *Infra) createOrUpdateConfigMap(ctx context.Context, infra *ir.Infra) (*corev1.ConfigMap, error) {
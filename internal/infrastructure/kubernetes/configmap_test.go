@@ -20,6 +20,7 @@ import (
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
 )
 
 func TestExpectedConfigMap(t *testing.T) {
@@ -43,10 +44,10 @@ func TestExpectedConfigMap(t *testing.T) {
 
 	require.Equal(t, "envoy-test-74657374", cm.Name)
 	require.Equal(t, "envoy-gateway-system", cm.Namespace)
-	require.Contains(t, cm.Data, sdsCAFilename)
-	assert.Equal(t, sdsCAConfigMapData, cm.Data[sdsCAFilename])
-	require.Contains(t, cm.Data, sdsCertFilename)
-	assert.Equal(t, sdsCertConfigMapData, cm.Data[sdsCertFilename])
+	require.Contains(t, cm.Data, bootstrap.SdsCAFilename)
+	assert.Equal(t, sdsCAConfigMapData, cm.Data[bootstrap.SdsCAFilename])
+	require.Contains(t, cm.Data, bootstrap.SdsCertFilename)
+	assert.Equal(t, sdsCertConfigMapData, cm.Data[bootstrap.SdsCertFilename])
 
 	wantLabels := envoyAppLabel()
 	wantLabels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
@@ -78,7 +79,7 @@ func TestCreateOrUpdateConfigMap(t *testing.T) {
 						gatewayapi.OwningGatewayNameLabel:      "test",
 					},
 				},
-				Data: map[string]string{sdsCAFilename: sdsCAConfigMapData, sdsCertFilename: sdsCertConfigMapData},
+				Data: map[string]string{bootstrap.SdsCAFilename: sdsCAConfigMapData, bootstrap.SdsCertFilename: sdsCertConfigMapData},
 			},
 		},
 		{
@@ -105,7 +106,7 @@ func TestCreateOrUpdateConfigMap(t *testing.T) {
 						gatewayapi.OwningGatewayNameLabel:      "test",
 					},
 				},
-				Data: map[string]string{sdsCAFilename: sdsCAConfigMapData, sdsCertFilename: sdsCertConfigMapData},
+				Data: map[string]string{bootstrap.SdsCAFilename: sdsCAConfigMapData, bootstrap.SdsCertFilename: sdsCertConfigMapData},
 			},
 		},
 	}
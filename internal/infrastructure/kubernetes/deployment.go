@@ -7,24 +7,27 @@ package kubernetes
 
 import (
 	"context"
-	_ "embed"
+	"crypto/sha256"
 	"fmt"
+	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
-	"text/template"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/provider/utils"
-	xdsrunner "github.com/envoyproxy/gateway/internal/xds/server/runner"
+	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
 )
 
 const (
@@ -34,71 +37,23 @@ const (
 	envoyNsEnvVar = "ENVOY_GATEWAY_NAMESPACE"
 	// envoyPodEnvVar is the name of the Envoy pod name environment variable.
 	envoyPodEnvVar = "ENVOY_POD_NAME"
-	// envoyCfgFileName is the name of the Envoy configuration file.
-	envoyCfgFileName = "bootstrap.yaml"
 	// envoyHTTPPort is the container port number of Envoy's HTTP endpoint.
 	envoyHTTPPort = int32(8080)
 	// envoyHTTPSPort is the container port number of Envoy's HTTPS endpoint.
 	envoyHTTPSPort = int32(8443)
-	// envoyGatewayXdsServerHost is the DNS name of the Xds Server within Envoy Gateway.
-	// It defaults to the Envoy Gateway Kubernetes service.
-	envoyGatewayXdsServerHost = "envoy-gateway"
-	// envoyAdminAddress is the listening address of the envoy admin interface.
-	envoyAdminAddress = "127.0.0.1"
-	// envoyAdminPort is the port used to expose admin interface.
-	envoyAdminPort = 19000
-	// envoyAdminAccessLogPath is the path used to expose admin access log.
-	envoyAdminAccessLogPath = "/dev/null"
+	// envoyHealthPortName is the name of the container and service port of
+	// Envoy's health listener.
+	envoyHealthPortName = "health"
+	// spiffeVolumeName is the name of the volume of the SPIFFE Workload API
+	// socket directory.
+	spiffeVolumeName = "spiffe-workload-api"
+	// configChecksumAnnotation is the annotation of the pod template of the
+	// Envoy Deployment holding the checksum of the bootstrap config and of the
+	// static config files of the ConfigMap, so that the pods are rolled when
+	// either changes.
+	configChecksumAnnotation = "gateway.envoyproxy.io/config-checksum"
 )
 
-//go:embed bootstrap.yaml.tpl
-var bootstrapTmplStr string
-
-var bootstrapTmpl = template.Must(template.New(envoyCfgFileName).Parse(bootstrapTmplStr))
-
-// envoyBootstrap defines the envoy Bootstrap configuration.
-type bootstrapConfig struct {
-	// parameters defines configurable bootstrap configuration parameters.
-	parameters bootstrapParameters
-	// rendered is the rendered bootstrap configuration.
-	rendered string
-}
-
-// envoyBootstrap defines the envoy Bootstrap configuration.
-type bootstrapParameters struct {
-	// XdsServer defines the configuration of the XDS server.
-	XdsServer xdsServerParameters
-	// AdminServer defines the configuration of the Envoy admin interface.
-	AdminServer adminServerParameters
-}
-
-type xdsServerParameters struct {
-	// Address is the address of the XDS Server that Envoy is managed by.
-	Address string
-	// Port is the port of the XDS Server that Envoy is managed by.
-	Port int32
-}
-
-type adminServerParameters struct {
-	// Address is the address of the Envoy admin interface.
-	Address string
-	// Port is the port of the Envoy admin interface.
-	Port int32
-	// AccessLogPath is the path of the Envoy admin access log.
-	AccessLogPath string
-}
-
-// render the stringified bootstrap config in yaml format.
-func (b *bootstrapConfig) render() error {
-	buf := new(strings.Builder)
-	if err := bootstrapTmpl.Execute(buf, b.parameters); err != nil {
-		return fmt.Errorf("failed to render bootstrap config: %v", err)
-	}
-	b.rendered = buf.String()
-
-	return nil
-}
-
 func expectedDeploymentName(proxyName string) string {
 	deploymentName := utils.GetHashedName(proxyName)
 	return fmt.Sprintf("%s-%s", config.EnvoyPrefix, deploymentName)
@@ -133,6 +88,9 @@ func (i *Infra) expectedDeployment(infra *ir.Infra) (*appsv1.Deployment, error)
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: envoySelector(infra.GetProxyInfra().GetProxyMetadata().Labels).MatchLabels,
+					Annotations: map[string]string{
+						configChecksumAnnotation: configChecksum(containers, expectedConfigMapData()),
+					},
 				},
 				Spec: corev1.PodSpec{
 					Containers:                    containers,
@@ -147,7 +105,7 @@ func (i *Infra) expectedDeployment(infra *ir.Infra) (*appsv1.Deployment, error)
 							Name: "certs",
 							VolumeSource: corev1.VolumeSource{
 								Secret: &corev1.SecretVolumeSource{
-									SecretName: "envoy",
+									SecretName: expectedSecretName(infra.Proxy.Name),
 								},
 							},
 						},
@@ -160,12 +118,12 @@ func (i *Infra) expectedDeployment(infra *ir.Infra) (*appsv1.Deployment, error)
 									},
 									Items: []corev1.KeyToPath{
 										{
-											Key:  sdsCAFilename,
-											Path: sdsCAFilename,
+											Key:  bootstrap.SdsCAFilename,
+											Path: bootstrap.SdsCAFilename,
 										},
 										{
-											Key:  sdsCertFilename,
-											Path: sdsCertFilename,
+											Key:  bootstrap.SdsCertFilename,
+											Path: bootstrap.SdsCertFilename,
 										},
 									},
 									DefaultMode: pointer.Int32Ptr(int32(420)),
@@ -179,9 +137,82 @@ func (i *Infra) expectedDeployment(infra *ir.Infra) (*appsv1.Deployment, error)
 		},
 	}
 
+	if spec := infra.Proxy.Config.GetEnvoyDeploymentSpec(); spec != nil {
+		podSpec := &deployment.Spec.Template.Spec
+		podSpec.SecurityContext = spec.PodSecurityContext
+		if spec.AutomountServiceAccountToken != nil {
+			podSpec.AutomountServiceAccountToken = spec.AutomountServiceAccountToken
+		}
+		if spec.PriorityClassName != nil {
+			podSpec.PriorityClassName = *spec.PriorityClassName
+		}
+		for i := range podSpec.Containers {
+			if podSpec.Containers[i].Name == envoyContainerName {
+				podSpec.Containers[i].SecurityContext = spec.ContainerSecurityContext
+				if spec.Resources != nil {
+					podSpec.Containers[i].Resources = *spec.Resources
+				}
+			}
+		}
+	}
+
+	// The deployments upgraded with the BlueGreen strategy are named and
+	// selected by revision, so that the revisions run side by side.
+	if revision := expectedRevision(infra); revision != "" {
+		deployment.Name = expectedRevisionDeploymentName(infra.Proxy.Name, revision)
+		deployment.Labels[gatewayapi.RevisionLabel] = revision
+		deployment.Spec.Selector.MatchLabels[gatewayapi.RevisionLabel] = revision
+		deployment.Spec.Template.Labels[gatewayapi.RevisionLabel] = revision
+	}
+
+	// Mount the directory of the Workload API socket from the node, so
+	// that the proxy fetches its identity from the local SPIFFE agent.
+	if spiffe := infra.Proxy.SPIFFE; spiffe != nil {
+		podSpec := &deployment.Spec.Template.Spec
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: spiffeVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				HostPath: &corev1.HostPathVolumeSource{
+					Path: filepath.Dir(spiffe.WorkloadAPISocketPath),
+					Type: hostPathTypePtr(corev1.HostPathDirectory),
+				},
+			},
+		})
+	}
+
 	return deployment, nil
 }
 
+// configChecksum returns the checksum of the args of the containers, which
+// hold the bootstrap config, and of the static config files, sorted by name.
+// The files are mounted from the ConfigMap and their changes are otherwise
+// only picked up by the pods started afterwards.
+func configChecksum(containers []corev1.Container, files map[string]string) string {
+	h := sha256.New()
+	for _, container := range containers {
+		for _, arg := range container.Args {
+			h.Write([]byte(arg))
+			h.Write([]byte{0})
+		}
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+		h.Write([]byte(files[name]))
+		h.Write([]byte{0})
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+func hostPathTypePtr(t corev1.HostPathType) *corev1.HostPathType {
+	return &t
+}
+
 func expectedContainers(infra *ir.Infra) ([]corev1.Container, error) {
 	ports := []corev1.ContainerPort{
 		{
@@ -196,23 +227,65 @@ func expectedContainers(infra *ir.Infra) ([]corev1.Container, error) {
 		},
 	}
 
-	cfg := bootstrapConfig{
-		parameters: bootstrapParameters{
-			XdsServer: xdsServerParameters{
-				Address: envoyGatewayXdsServerHost,
-				Port:    xdsrunner.XdsServerPort,
-			},
-			AdminServer: adminServerParameters{
-				Address:       envoyAdminAddress,
-				Port:          envoyAdminPort,
-				AccessLogPath: envoyAdminAccessLogPath,
-			},
-		},
+	health := infra.Proxy.Config.GetProxyHealth()
+	ports = append(ports, corev1.ContainerPort{
+		Name:          envoyHealthPortName,
+		ContainerPort: health.Port,
+		Protocol:      corev1.ProtocolTCP,
+	})
+
+	var runtimeFlags map[string]string
+	if infra.Proxy.Config != nil {
+		runtimeFlags = infra.Proxy.Config.Spec.RuntimeFlags
 	}
-	if err := cfg.render(); err != nil {
+	var excludedStatsPrefixes []string
+	if budget := infra.Proxy.Config.GetTelemetryBudget(); budget != nil {
+		excludedStatsPrefixes = budget.ExcludedStatsPrefixes
+	}
+	var resources *corev1.ResourceRequirements
+	if spec := infra.Proxy.Config.GetEnvoyDeploymentSpec(); spec != nil {
+		resources = spec.Resources
+	}
+	bootstrapYAML, err := bootstrap.GetRenderedBootstrapConfig(health, infra.Proxy.SPIFFE, runtimeFlags, proxyMaxHeapSize(resources), infra.Proxy.TrafficStats, excludedStatsPrefixes)
+	if err != nil {
 		return nil, err
 	}
 
+	logging := infra.Proxy.Config.GetProxyLogging()
+	args := []string{
+		fmt.Sprintf("--service-cluster %s", infra.Proxy.Name),
+		fmt.Sprintf("--service-node $(%s)", envoyPodEnvVar),
+		fmt.Sprintf("--config-yaml %s", bootstrapYAML),
+		fmt.Sprintf("--log-level %s", logging.Level),
+	}
+	if len(logging.Components) > 0 {
+		args = append(args, fmt.Sprintf("--component-log-level %s", componentLogLevels(logging.Components)))
+	}
+	if infra.Proxy.Config != nil && infra.Proxy.Config.Spec.Concurrency != nil {
+		args = append(args, fmt.Sprintf("--concurrency %d", *infra.Proxy.Config.Spec.Concurrency))
+	} else if concurrency := proxyConcurrency(resources); concurrency > 0 {
+		args = append(args, fmt.Sprintf("--concurrency %d", concurrency))
+	}
+
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      "certs",
+			MountPath: "/certs",
+			ReadOnly:  true,
+		},
+		{
+			Name:      "sds",
+			MountPath: bootstrap.DefaultSdsDir,
+		},
+	}
+	if spiffe := infra.Proxy.SPIFFE; spiffe != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      spiffeVolumeName,
+			MountPath: filepath.Dir(spiffe.WorkloadAPISocketPath),
+			ReadOnly:  true,
+		})
+	}
+
 	containers := []corev1.Container{
 		{
 			Name:            envoyContainerName,
@@ -221,12 +294,7 @@ func expectedContainers(infra *ir.Infra) ([]corev1.Container, error) {
 			Command: []string{
 				"envoy",
 			},
-			Args: []string{
-				fmt.Sprintf("--service-cluster %s", infra.Proxy.Name),
-				fmt.Sprintf("--service-node $(%s)", envoyPodEnvVar),
-				fmt.Sprintf("--config-yaml %s", cfg.rendered),
-				"--log-level info",
-			},
+			Args: args,
 			Env: []corev1.EnvVar{
 				{
 					Name: envoyNsEnvVar,
@@ -248,17 +316,11 @@ func expectedContainers(infra *ir.Infra) ([]corev1.Container, error) {
 				},
 			},
 			Ports: ports,
-			VolumeMounts: []corev1.VolumeMount{
-				{
-					Name:      "certs",
-					MountPath: "/certs",
-					ReadOnly:  true,
-				},
-				{
-					Name:      "sds",
-					MountPath: "/sds",
-				},
-			},
+			// The proxy is ready as soon as it serves the health listener,
+			// and is considered unready once it starts draining.
+			ReadinessProbe:           expectedHealthProbe(health, 5, 1),
+			LivenessProbe:            expectedHealthProbe(health, 10, 3),
+			VolumeMounts:             volumeMounts,
 			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
 			TerminationMessagePath:   "/dev/termination-log",
 		},
@@ -267,6 +329,67 @@ func expectedContainers(infra *ir.Infra) ([]corev1.Container, error) {
 	return containers, nil
 }
 
+// componentLogLevels returns the value of the --component-log-level flag of
+// Envoy for the provided log levels keyed by component, sorted by component.
+func componentLogLevels(components map[string]v1alpha1.ProxyLogLevel) string {
+	levels := make([]string, 0, len(components))
+	for component, level := range components {
+		levels = append(levels, fmt.Sprintf("%s:%s", component, level))
+	}
+	sort.Strings(levels)
+	return strings.Join(levels, ",")
+}
+
+// proxyConcurrency returns the number of worker threads of the proxy sized to
+// the CPU limit of the provided resources, or request if unlimited, rounded
+// up. Zero is returned if neither is set, leaving Envoy to run one worker
+// thread per hardware thread of the node.
+func proxyConcurrency(resources *corev1.ResourceRequirements) int64 {
+	if resources == nil {
+		return 0
+	}
+	cpu, ok := resources.Limits[corev1.ResourceCPU]
+	if !ok {
+		cpu, ok = resources.Requests[corev1.ResourceCPU]
+	}
+	if !ok || cpu.IsZero() {
+		return 0
+	}
+	return (cpu.MilliValue() + 999) / 1000
+}
+
+// proxyMaxHeapSize returns the maximum heap size of the proxy, leaving 20% of
+// the memory limit of the provided resources to the memory of the proxy not
+// accounted in its heap. Zero is returned if the memory is unlimited.
+func proxyMaxHeapSize(resources *corev1.ResourceRequirements) uint64 {
+	if resources == nil {
+		return 0
+	}
+	memory, ok := resources.Limits[corev1.ResourceMemory]
+	if !ok || memory.Sign() <= 0 {
+		return 0
+	}
+	return uint64(memory.Value()) / 5 * 4
+}
+
+// expectedHealthProbe returns a probe of the readiness endpoint of Envoy's
+// health listener.
+func expectedHealthProbe(health *v1alpha1.ProxyHealth, periodSeconds, failureThreshold int32) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path:   health.Path,
+				Port:   intstr.FromInt(int(health.Port)),
+				Scheme: corev1.URISchemeHTTP,
+			},
+		},
+		TimeoutSeconds:   1,
+		PeriodSeconds:    periodSeconds,
+		SuccessThreshold: 1,
+		FailureThreshold: failureThreshold,
+	}
+}
+
 // createDeployment creates a Deployment in the kube api server based on the provided
 // infra, if it doesn't exist and updates it if it does.
 func (i *Infra) createOrUpdateDeployment(ctx context.Context, infra *ir.Infra) error {
@@ -278,7 +401,7 @@ func (i *Infra) createOrUpdateDeployment(ctx context.Context, infra *ir.Infra) e
 	current := &appsv1.Deployment{}
 	key := types.NamespacedName{
 		Namespace: i.Namespace,
-		Name:      expectedDeploymentName(infra.Proxy.Name),
+		Name:      deploy.Name,
 	}
 
 	if err := i.Client.Get(ctx, key, current); err != nil {
@@ -290,6 +413,20 @@ func (i *Infra) createOrUpdateDeployment(ctx context.Context, infra *ir.Infra) e
 			}
 		}
 	} else {
+		// The selector of a deployment is immutable, recreate the deployment
+		// if it changed, e.g. when the listeners of the Gateway are sharded.
+		if !reflect.DeepEqual(deploy.Spec.Selector, current.Spec.Selector) {
+			if err := i.Client.Delete(ctx, current); err != nil && !kerrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete deployment %s/%s: %w",
+					current.Namespace, current.Name, err)
+			}
+			if err := i.Client.Create(ctx, deploy); err != nil {
+				return fmt.Errorf("failed to create deployment %s/%s: %w",
+					deploy.Namespace, deploy.Name, err)
+			}
+			return nil
+		}
+
 		// Update if current value is different.
 		if !reflect.DeepEqual(deploy.Spec, current.Spec) {
 			if err := i.Client.Update(ctx, deploy); err != nil {
@@ -311,12 +448,11 @@ func (i *Infra) deleteDeployment(ctx context.Context, infra *ir.Infra) error {
 		},
 	}
 
-	if err := i.Client.Delete(ctx, deploy); err != nil {
-		if kerrors.IsNotFound(err) {
-			return nil
-		}
+	if err := i.Client.Delete(ctx, deploy); err != nil && !kerrors.IsNotFound(err) {
 		return fmt.Errorf("failed to delete deployment %s/%s: %w", deploy.Namespace, deploy.Name, err)
 	}
 
-	return nil
+	// Delete the deployments of the revisions upgraded with the BlueGreen
+	// strategy, if any.
+	return i.deleteStaleDeployments(ctx, infra, "")
 }
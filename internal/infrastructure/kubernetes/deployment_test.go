@@ -15,14 +15,17 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/ir"
-	xdsrunner "github.com/envoyproxy/gateway/internal/xds/server/runner"
+	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
 )
 
 func checkEnvVar(t *testing.T, deploy *appsv1.Deployment, container, name string) {
@@ -127,29 +130,260 @@ func TestExpectedDeployment(t *testing.T) {
 	checkEnvVar(t, deploy, envoyContainerName, envoyPodEnvVar)
 	checkLabels(t, deploy, deploy.Labels)
 
-	// Create a bootstrap config, render it into an arg, and ensure it's as expected.
-	cfg := &bootstrapConfig{
-		parameters: bootstrapParameters{
-			XdsServer: xdsServerParameters{
-				Address: envoyGatewayXdsServerHost,
-				Port:    xdsrunner.XdsServerPort,
+	// Render the bootstrap config into an arg, and ensure it's as expected.
+	bootstrapYAML, err := bootstrap.GetRenderedBootstrapConfig(nil, nil, nil, 0, false, nil)
+	require.NoError(t, err)
+	checkContainerHasArg(t, container, fmt.Sprintf("--config-yaml %s", bootstrapYAML))
+
+	// Check container ports for the deployment are as expected.
+	ports := []int32{envoyHTTPPort, envoyHTTPSPort, v1alpha1.DefaultProxyHealthPort}
+	for _, port := range ports {
+		checkContainerHasPort(t, deploy, port)
+	}
+}
+
+func TestExpectedDeploymentSPIFFE(t *testing.T) {
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects().Build()
+	kube := NewInfra(cli)
+	infra := ir.NewInfra()
+
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+	infra.Proxy.SPIFFE = &v1alpha1.SPIFFE{
+		WorkloadAPISocketPath: "/run/spire/sockets/agent.sock",
+		XdsServerID:           "spiffe://example.org/ns/envoy-gateway-system/sa/envoy-gateway",
+	}
+
+	deploy, err := kube.expectedDeployment(infra)
+	require.NoError(t, err)
+
+	// The bootstrap config fetches the xDS client certificate from the Workload API.
+	container := checkContainer(t, deploy, envoyContainerName, true)
+	bootstrapYAML, err := bootstrap.GetRenderedBootstrapConfig(nil, infra.Proxy.SPIFFE, nil, 0, false, nil)
+	require.NoError(t, err)
+	checkContainerHasArg(t, container, fmt.Sprintf("--config-yaml %s", bootstrapYAML))
+
+	// The directory of the Workload API socket is mounted from the node.
+	var volume *corev1.Volume
+	for i := range deploy.Spec.Template.Spec.Volumes {
+		if deploy.Spec.Template.Spec.Volumes[i].Name == spiffeVolumeName {
+			volume = &deploy.Spec.Template.Spec.Volumes[i]
+		}
+	}
+	require.NotNil(t, volume)
+	require.NotNil(t, volume.HostPath)
+	assert.Equal(t, "/run/spire/sockets", volume.HostPath.Path)
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+		Name:      spiffeVolumeName,
+		MountPath: "/run/spire/sockets",
+		ReadOnly:  true,
+	})
+}
+
+func TestExpectedDeploymentProxyFlags(t *testing.T) {
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects().Build()
+	kube := NewInfra(cli)
+	infra := ir.NewInfra()
+
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+	concurrency := uint32(2)
+	infra.Proxy.Config = &v1alpha1.EnvoyProxy{
+		Spec: v1alpha1.EnvoyProxySpec{
+			Concurrency: &concurrency,
+			Logging: &v1alpha1.ProxyLogging{
+				Level: v1alpha1.ProxyLogLevelWarning,
+				Components: map[string]v1alpha1.ProxyLogLevel{
+					"upstream":   v1alpha1.ProxyLogLevelDebug,
+					"connection": v1alpha1.ProxyLogLevelTrace,
+				},
 			},
-			AdminServer: adminServerParameters{
-				Address:       envoyAdminAddress,
-				Port:          envoyAdminPort,
-				AccessLogPath: envoyAdminAccessLogPath,
+			RuntimeFlags: map[string]string{
+				"envoy.reloadable_features.http2_use_oghttp2": "false",
 			},
 		},
 	}
-	err = cfg.render()
+
+	deploy, err := kube.expectedDeployment(infra)
 	require.NoError(t, err)
-	checkContainerHasArg(t, container, fmt.Sprintf("--config-yaml %s", cfg.rendered))
 
-	// Check container ports for the deployment are as expected.
-	ports := []int32{envoyHTTPPort, envoyHTTPSPort}
-	for _, port := range ports {
-		checkContainerHasPort(t, deploy, port)
+	container := checkContainer(t, deploy, envoyContainerName, true)
+	checkContainerHasArg(t, container, "--log-level warning")
+	checkContainerHasArg(t, container, "--component-log-level connection:trace,upstream:debug")
+	checkContainerHasArg(t, container, "--concurrency 2")
+
+	// The runtime flags are rendered into the bootstrap config.
+	bootstrapYAML, err := bootstrap.GetRenderedBootstrapConfig(nil, nil, infra.Proxy.Config.Spec.RuntimeFlags, 0, false, nil)
+	require.NoError(t, err)
+	checkContainerHasArg(t, container, fmt.Sprintf("--config-yaml %s", bootstrapYAML))
+}
+
+func TestExpectedDeploymentResources(t *testing.T) {
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects().Build()
+	kube := NewInfra(cli)
+	infra := ir.NewInfra()
+
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+	resources := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("500m"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("2500m"),
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		},
+	}
+	infra.Proxy.Config = &v1alpha1.EnvoyProxy{
+		Spec: v1alpha1.EnvoyProxySpec{
+			Provider: &v1alpha1.ResourceProvider{
+				Type: v1alpha1.ProviderTypeKubernetes,
+				Kubernetes: &v1alpha1.KubernetesResourceProvider{
+					EnvoyDeployment: &v1alpha1.KubernetesDeploymentSpec{Resources: resources},
+				},
+			},
+		},
 	}
+
+	deploy, err := kube.expectedDeployment(infra)
+	require.NoError(t, err)
+
+	container := checkContainer(t, deploy, envoyContainerName, true)
+	assert.Equal(t, *resources, container.Resources)
+	// The worker threads are sized to the CPU limit, rounded up.
+	checkContainerHasArg(t, container, "--concurrency 3")
+	// The heap of the proxy is bounded to 80% of the memory limit.
+	bootstrapYAML, err := bootstrap.GetRenderedBootstrapConfig(nil, nil, nil, 858993456, false, nil)
+	require.NoError(t, err)
+	checkContainerHasArg(t, container, fmt.Sprintf("--config-yaml %s", bootstrapYAML))
+
+	// The concurrency of the EnvoyProxy takes precedence over the resources.
+	concurrency := uint32(1)
+	infra.Proxy.Config.Spec.Concurrency = &concurrency
+	deploy, err = kube.expectedDeployment(infra)
+	require.NoError(t, err)
+
+	container = checkContainer(t, deploy, envoyContainerName, true)
+	checkContainerHasArg(t, container, "--concurrency 1")
+}
+
+func TestExpectedDeploymentConfigChecksum(t *testing.T) {
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects().Build()
+	kube := NewInfra(cli)
+	infra := ir.NewInfra()
+
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+
+	deploy, err := kube.expectedDeployment(infra)
+	require.NoError(t, err)
+	checksum := deploy.Spec.Template.Annotations[configChecksumAnnotation]
+	require.NotEmpty(t, checksum)
+
+	// The checksum is stable across reconciliations.
+	deploy, err = kube.expectedDeployment(infra)
+	require.NoError(t, err)
+	require.Equal(t, checksum, deploy.Spec.Template.Annotations[configChecksumAnnotation])
+
+	// A change of the bootstrap config changes the checksum.
+	infra.Proxy.Config = &v1alpha1.EnvoyProxy{
+		Spec: v1alpha1.EnvoyProxySpec{
+			RuntimeFlags: map[string]string{
+				"envoy.reloadable_features.http2_use_oghttp2": "false",
+			},
+		},
+	}
+	deploy, err = kube.expectedDeployment(infra)
+	require.NoError(t, err)
+	require.NotEqual(t, checksum, deploy.Spec.Template.Annotations[configChecksumAnnotation])
+
+	// A change of the static config files changes the checksum.
+	files := expectedConfigMapData()
+	before := configChecksum(deploy.Spec.Template.Spec.Containers, files)
+	files[bootstrap.SdsCAFilename] += "\n"
+	require.NotEqual(t, before, configChecksum(deploy.Spec.Template.Spec.Containers, files))
+}
+
+func TestProxyConcurrency(t *testing.T) {
+	testCases := []struct {
+		name      string
+		resources *corev1.ResourceRequirements
+		expected  int64
+	}{
+		{
+			name: "unspecified",
+		},
+		{
+			name: "cpu request",
+			resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			},
+			expected: 2,
+		},
+		{
+			name: "fractional cpu limit",
+			resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			},
+			expected: 1,
+		},
+		{
+			name: "memory only",
+			resources: &corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, proxyConcurrency(tc.resources))
+		})
+	}
+}
+
+func TestExpectedDeploymentHardening(t *testing.T) {
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects().Build()
+	kube := NewInfra(cli)
+	infra := ir.NewInfra()
+
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+	infra.Proxy.Config = &v1alpha1.EnvoyProxy{
+		Spec: v1alpha1.EnvoyProxySpec{
+			Provider: &v1alpha1.ResourceProvider{
+				Type: v1alpha1.ProviderTypeKubernetes,
+				Kubernetes: &v1alpha1.KubernetesResourceProvider{
+					EnvoyDeployment: &v1alpha1.KubernetesDeploymentSpec{
+						PodSecurityContext: &corev1.PodSecurityContext{
+							SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+						},
+						ContainerSecurityContext: &corev1.SecurityContext{
+							RunAsNonRoot:           pointer.Bool(true),
+							ReadOnlyRootFilesystem: pointer.Bool(true),
+						},
+						AutomountServiceAccountToken: pointer.Bool(true),
+						PriorityClassName:            pointer.String("system-cluster-critical"),
+					},
+				},
+			},
+		},
+	}
+
+	deploy, err := kube.expectedDeployment(infra)
+	require.NoError(t, err)
+
+	podSpec := deploy.Spec.Template.Spec
+	assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, podSpec.SecurityContext.SeccompProfile.Type)
+	assert.Equal(t, pointer.Bool(true), podSpec.AutomountServiceAccountToken)
+	assert.Equal(t, "system-cluster-critical", podSpec.PriorityClassName)
+
+	container := checkContainer(t, deploy, envoyContainerName, true)
+	require.NotNil(t, container.SecurityContext)
+	assert.Equal(t, pointer.Bool(true), container.SecurityContext.RunAsNonRoot)
+	assert.Equal(t, pointer.Bool(true), container.SecurityContext.ReadOnlyRootFilesystem)
 }
 
 func deploymentWithImage(deploy *appsv1.Deployment, image string) *appsv1.Deployment {
@@ -172,6 +406,11 @@ func TestCreateOrUpdateDeployment(t *testing.T) {
 	deploy, err := kube.expectedDeployment(infra)
 	require.NoError(t, err)
 
+	shardInfra := infra.DeepCopy()
+	shardInfra.Proxy.GetProxyMetadata().Labels[gatewayapi.ListenerShardLabel] = "0"
+	shardDeploy, err := kube.expectedDeployment(shardInfra)
+	require.NoError(t, err)
+
 	testCases := []struct {
 		name    string
 		in      *ir.Infra
@@ -207,6 +446,12 @@ func TestCreateOrUpdateDeployment(t *testing.T) {
 			current: deploy,
 			want:    deploymentWithImage(deploy, "envoyproxy/gateway-dev:v1.2.3"),
 		},
+		{
+			name:    "recreate deployment on selector change",
+			in:      shardInfra,
+			current: deploy,
+			want:    shardDeploy,
+		},
 	}
 
 	for _, tc := range testCases {
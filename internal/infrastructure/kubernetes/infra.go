@@ -8,6 +8,7 @@ package kubernetes
 import (
 	"context"
 	"errors"
+	"sync"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -23,6 +24,13 @@ type Infra struct {
 
 	// Namespace is the Namespace used for managed infra.
 	Namespace string
+
+	// mu guards upgrades.
+	mu sync.Mutex
+	// upgrades holds the latest infra of the proxies upgraded with the
+	// BlueGreen strategy, keyed by proxy name, while the traffic switch to
+	// their new revision is awaited.
+	upgrades map[string]*ir.Infra
 }
 
 // NewInfra returns a new Infra.
@@ -51,6 +59,10 @@ func (i *Infra) CreateOrUpdateInfra(ctx context.Context, infra *ir.Infra) error
 		return err
 	}
 
+	if err := i.createOrUpdateSecret(ctx, infra); err != nil {
+		return err
+	}
+
 	if err := i.createOrUpdateDeployment(ctx, infra); err != nil {
 		return err
 	}
@@ -80,6 +92,10 @@ func (i *Infra) DeleteInfra(ctx context.Context, infra *ir.Infra) error {
 		return err
 	}
 
+	if err := i.deleteSecret(ctx, infra); err != nil {
+		return err
+	}
+
 	if err := i.deleteServiceAccount(ctx, infra); err != nil {
 		return err
 	}
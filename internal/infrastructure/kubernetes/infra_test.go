@@ -62,7 +62,7 @@ func TestCreateInfra(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 			kube := &Infra{
-				Client:    fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build(),
+				Client:    fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects(newTestCASecret(t, "default")).Build(),
 				Namespace: "default",
 			}
 			// Create or update the proxy infra.
@@ -89,6 +89,14 @@ func TestCreateInfra(t *testing.T) {
 				}
 				require.NoError(t, kube.Client.Get(context.Background(), client.ObjectKeyFromObject(cm), cm))
 
+				secret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: kube.Namespace,
+						Name:      expectedSecretName(tc.in.Proxy.Name),
+					},
+				}
+				require.NoError(t, kube.Client.Get(context.Background(), client.ObjectKeyFromObject(secret), secret))
+
 				deploy := &appsv1.Deployment{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: kube.Namespace,
@@ -0,0 +1,372 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+)
+
+const (
+	// rateLimitContainerName is the name of the rate limit service container.
+	rateLimitContainerName = "envoy-ratelimit"
+	// rateLimitImage is the image of the rate limit service.
+	rateLimitImage = "envoyproxy/ratelimit:f28024e3"
+	// rateLimitRuntimeRoot is the directory the rate limit service loads its
+	// runtime from.
+	rateLimitRuntimeRoot = "/data"
+	// rateLimitRuntimeSubdirectory is the runtime subdirectory holding the
+	// rate limit configuration.
+	rateLimitRuntimeSubdirectory = "ratelimit"
+	// rateLimitConfigKey is the key of the rate limit configuration in the
+	// rate limit ConfigMap.
+	rateLimitConfigKey = "config.yaml"
+	// redisTLSCADir is the directory the Redis CA certificate is mounted in.
+	redisTLSCADir = "/redis-certs/ca"
+	// redisTLSCertDir is the directory the Redis client certificate is mounted in.
+	redisTLSCertDir = "/redis-certs/client"
+	// redisClusterPipelineWindow is the pipelining window of the Redis client,
+	// required by the Cluster topology.
+	redisClusterPipelineWindow = "150us"
+)
+
+// rateLimitLabels returns the labels used for all the rate limit service resources.
+func rateLimitLabels() map[string]string {
+	return map[string]string{
+		"app.gateway.envoyproxy.io/name": config.RateLimitInfraName,
+	}
+}
+
+// validateRateLimit returns an error if the rate limit configuration
+// is not supported.
+func validateRateLimit(rateLimit *v1alpha1.RateLimit) error {
+	if rateLimit == nil {
+		return errors.New("rate limit configuration is nil")
+	}
+	if rateLimit.Backend.Type != v1alpha1.RedisBackendType {
+		return fmt.Errorf("unsupported rate limit backend type %q", rateLimit.Backend.Type)
+	}
+	redis := rateLimit.Backend.Redis
+	if redis == nil {
+		return errors.New("rate limit redis settings are required")
+	}
+	switch redis.Topology {
+	case "", v1alpha1.RedisTopologyStandalone:
+		if redis.URL == "" {
+			return errors.New("rate limit redis url is required for the standalone topology")
+		}
+	case v1alpha1.RedisTopologySentinel:
+		if redis.MasterName == "" || len(redis.URLs) == 0 {
+			return errors.New("rate limit redis master name and urls are required for the sentinel topology")
+		}
+	case v1alpha1.RedisTopologyCluster:
+		if len(redis.URLs) == 0 {
+			return errors.New("rate limit redis urls are required for the cluster topology")
+		}
+	default:
+		return fmt.Errorf("unsupported rate limit redis topology %q", redis.Topology)
+	}
+	if redis.Auth != nil && (redis.Auth.PasswordRef.Name == "" || redis.Auth.PasswordRef.Key == "") {
+		return errors.New("rate limit redis password secret name and key are required")
+	}
+	return nil
+}
+
+// expectedRedisEnv returns the environment variables configuring the
+// connection of the rate limit service to Redis.
+func expectedRedisEnv(redis *v1alpha1.RateLimitRedisSettings) []corev1.EnvVar {
+	var env []corev1.EnvVar
+	switch redis.Topology {
+	case v1alpha1.RedisTopologySentinel:
+		// The master name is the first element of the sentinel URL.
+		env = append(env,
+			corev1.EnvVar{Name: "REDIS_TYPE", Value: "SENTINEL"},
+			corev1.EnvVar{Name: "REDIS_URL", Value: strings.Join(append([]string{redis.MasterName}, redis.URLs...), ",")},
+		)
+	case v1alpha1.RedisTopologyCluster:
+		env = append(env,
+			corev1.EnvVar{Name: "REDIS_TYPE", Value: "CLUSTER"},
+			corev1.EnvVar{Name: "REDIS_URL", Value: strings.Join(redis.URLs, ",")},
+			corev1.EnvVar{Name: "REDIS_PIPELINE_WINDOW", Value: redisClusterPipelineWindow},
+		)
+	default:
+		env = append(env,
+			corev1.EnvVar{Name: "REDIS_TYPE", Value: "SINGLE"},
+			corev1.EnvVar{Name: "REDIS_URL", Value: redis.URL},
+		)
+	}
+
+	if redis.TLS != nil {
+		env = append(env, corev1.EnvVar{Name: "REDIS_TLS", Value: "true"})
+		if redis.TLS.CACertificateRef != nil {
+			env = append(env, corev1.EnvVar{Name: "REDIS_TLS_CACERT", Value: redisTLSCADir + "/ca.crt"})
+		}
+		if redis.TLS.CertificateRef != nil {
+			env = append(env,
+				corev1.EnvVar{Name: "REDIS_TLS_CLIENT_CERT", Value: redisTLSCertDir + "/" + corev1.TLSCertKey},
+				corev1.EnvVar{Name: "REDIS_TLS_CLIENT_KEY", Value: redisTLSCertDir + "/" + corev1.TLSPrivateKeyKey},
+			)
+		}
+	}
+
+	if redis.Auth != nil {
+		env = append(env, corev1.EnvVar{
+			Name: "REDIS_AUTH",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: redis.Auth.PasswordRef.DeepCopy(),
+			},
+		})
+	}
+
+	return env
+}
+
+// expectedRedisVolumes returns the volumes and volume mounts of the Redis TLS
+// certificates of the rate limit service.
+func expectedRedisVolumes(redis *v1alpha1.RateLimitRedisSettings) ([]corev1.Volume, []corev1.VolumeMount) {
+	if redis.TLS == nil {
+		return nil, nil
+	}
+
+	var volumes []corev1.Volume
+	var mounts []corev1.VolumeMount
+	add := func(name, secretName, dir string) {
+		volumes = append(volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  secretName,
+					DefaultMode: pointer.Int32Ptr(int32(420)),
+				},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{Name: name, MountPath: dir, ReadOnly: true})
+	}
+	if redis.TLS.CACertificateRef != nil {
+		add("redis-ca", redis.TLS.CACertificateRef.Name, redisTLSCADir)
+	}
+	if redis.TLS.CertificateRef != nil {
+		add("redis-cert", redis.TLS.CertificateRef.Name, redisTLSCertDir)
+	}
+
+	return volumes, mounts
+}
+
+// expectedRateLimitConfigMap returns the expected ConfigMap holding the
+// configuration of the rate limit service.
+func (i *Infra) expectedRateLimitConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: i.Namespace,
+			Name:      config.RateLimitInfraName,
+			Labels:    rateLimitLabels(),
+		},
+		Data: map[string]string{
+			rateLimitConfigKey: fmt.Sprintf("domain: %s\n", config.RateLimitDomain),
+		},
+	}
+}
+
+// expectedRateLimitDeployment returns the expected Deployment of the rate
+// limit service based on the provided rate limit configuration.
+func (i *Infra) expectedRateLimitDeployment(rateLimit *v1alpha1.RateLimit) *appsv1.Deployment {
+	env := []corev1.EnvVar{
+		{Name: "RUNTIME_ROOT", Value: rateLimitRuntimeRoot},
+		{Name: "RUNTIME_SUBDIRECTORY", Value: rateLimitRuntimeSubdirectory},
+		{Name: "RUNTIME_IGNOREDOTFILES", Value: "true"},
+		// ConfigMap updates swap a symlink, which isn't detected when watching the root.
+		{Name: "RUNTIME_WATCH_ROOT", Value: "false"},
+		{Name: "LOG_LEVEL", Value: "info"},
+		{Name: "USE_STATSD", Value: "false"},
+		{Name: "GRPC_PORT", Value: strconv.Itoa(config.RateLimitGRPCPort)},
+		{Name: "REDIS_SOCKET_TYPE", Value: "tcp"},
+	}
+	env = append(env, expectedRedisEnv(rateLimit.Backend.Redis)...)
+
+	volumes := []corev1.Volume{
+		{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: config.RateLimitInfraName,
+					},
+					DefaultMode: pointer.Int32Ptr(int32(420)),
+					Optional:    pointer.BoolPtr(false),
+				},
+			},
+		},
+	}
+	mounts := []corev1.VolumeMount{
+		{
+			Name:      "config",
+			MountPath: fmt.Sprintf("%s/%s/config", rateLimitRuntimeRoot, rateLimitRuntimeSubdirectory),
+			ReadOnly:  true,
+		},
+	}
+	redisVolumes, redisMounts := expectedRedisVolumes(rateLimit.Backend.Redis)
+	volumes = append(volumes, redisVolumes...)
+	mounts = append(mounts, redisMounts...)
+
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: i.Namespace,
+			Name:      config.RateLimitInfraName,
+			Labels:    rateLimitLabels(),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32(1),
+			Selector: &metav1.LabelSelector{MatchLabels: rateLimitLabels()},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: rateLimitLabels(),
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:            rateLimitContainerName,
+							Image:           rateLimitImage,
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Command:         []string{"/bin/ratelimit"},
+							Env:             env,
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "grpc",
+									ContainerPort: config.RateLimitGRPCPort,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							VolumeMounts:             mounts,
+							TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+							TerminationMessagePath:   "/dev/termination-log",
+						},
+					},
+					AutomountServiceAccountToken: pointer.BoolPtr(false),
+					DNSPolicy:                    corev1.DNSClusterFirst,
+					RestartPolicy:                corev1.RestartPolicyAlways,
+					SchedulerName:                "default-scheduler",
+					Volumes:                      volumes,
+				},
+			},
+		},
+	}
+}
+
+// expectedRateLimitService returns the expected Service of the rate limit
+// service, addressed by the managed Envoy proxies.
+func (i *Infra) expectedRateLimitService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: i.Namespace,
+			Name:      config.RateLimitInfraName,
+			Labels:    rateLimitLabels(),
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "grpc",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       config.RateLimitGRPCPort,
+					TargetPort: intstr.IntOrString{IntVal: config.RateLimitGRPCPort},
+				},
+			},
+			Selector:        rateLimitLabels(),
+			SessionAffinity: corev1.ServiceAffinityNone,
+		},
+	}
+}
+
+// CreateOrUpdateRateLimitInfra creates the managed kube infra of the global rate
+// limit service, if it doesn't exist, and updates it if it does.
+func (i *Infra) CreateOrUpdateRateLimitInfra(ctx context.Context, rateLimit *v1alpha1.RateLimit) error {
+	if err := validateRateLimit(rateLimit); err != nil {
+		return err
+	}
+
+	cm := i.expectedRateLimitConfigMap()
+	if err := i.createOrUpdateRateLimitObject(ctx, cm, &corev1.ConfigMap{}, func(current client.Object) bool {
+		return reflect.DeepEqual(cm.Data, current.(*corev1.ConfigMap).Data)
+	}); err != nil {
+		return err
+	}
+
+	deploy := i.expectedRateLimitDeployment(rateLimit)
+	if err := i.createOrUpdateRateLimitObject(ctx, deploy, &appsv1.Deployment{}, func(current client.Object) bool {
+		return reflect.DeepEqual(deploy.Spec, current.(*appsv1.Deployment).Spec)
+	}); err != nil {
+		return err
+	}
+
+	svc := i.expectedRateLimitService()
+	return i.createOrUpdateRateLimitObject(ctx, svc, &corev1.Service{}, func(current client.Object) bool {
+		return reflect.DeepEqual(svc.Spec, current.(*corev1.Service).Spec)
+	})
+}
+
+// createOrUpdateRateLimitObject creates the provided object in the kube api
+// server if it doesn't exist, and updates it if it isn't equal to the current
+// object.
+func (i *Infra) createOrUpdateRateLimitObject(ctx context.Context, obj, current client.Object, equal func(client.Object) bool) error {
+	key := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	if err := i.Client.Get(ctx, key, current); err != nil {
+		// Create if not found.
+		if kerrors.IsNotFound(err) {
+			if err := i.Client.Create(ctx, obj); err != nil {
+				return fmt.Errorf("failed to create %T %s: %w", obj, key, err)
+			}
+			return nil
+		}
+		return fmt.Errorf("failed to get %T %s: %w", obj, key, err)
+	}
+
+	// Update if current value is different.
+	if !equal(current) {
+		if err := i.Client.Update(ctx, obj); err != nil {
+			return fmt.Errorf("failed to update %T %s: %w", obj, key, err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteRateLimitInfra removes the managed kube infra of the global rate limit
+// service, if it exists.
+func (i *Infra) DeleteRateLimitInfra(ctx context.Context) error {
+	meta := metav1.ObjectMeta{Namespace: i.Namespace, Name: config.RateLimitInfraName}
+	objs := []client.Object{
+		&corev1.Service{ObjectMeta: meta},
+		&appsv1.Deployment{ObjectMeta: meta},
+		&corev1.ConfigMap{ObjectMeta: meta},
+	}
+	for _, obj := range objs {
+		if err := i.Client.Delete(ctx, obj); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %T %s/%s: %w", obj, obj.GetNamespace(), obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
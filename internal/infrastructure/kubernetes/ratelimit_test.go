@@ -0,0 +1,150 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+)
+
+func redisRateLimit(url string) *v1alpha1.RateLimit {
+	return &v1alpha1.RateLimit{
+		Backend: v1alpha1.RateLimitDatabaseBackend{
+			Type:  v1alpha1.RedisBackendType,
+			Redis: &v1alpha1.RateLimitRedisSettings{URL: url},
+		},
+	}
+}
+
+func TestCreateOrUpdateRateLimitInfra(t *testing.T) {
+	ctx := context.Background()
+	kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+	key := types.NamespacedName{Namespace: kube.Namespace, Name: config.RateLimitInfraName}
+
+	require.NoError(t, kube.CreateOrUpdateRateLimitInfra(ctx, redisRateLimit("redis.redis.svc:6379")))
+
+	cm := new(corev1.ConfigMap)
+	require.NoError(t, kube.Client.Get(ctx, key, cm))
+	require.Equal(t, "domain: envoy-gateway\n", cm.Data[rateLimitConfigKey])
+
+	svc := new(corev1.Service)
+	require.NoError(t, kube.Client.Get(ctx, key, svc))
+	require.Equal(t, int32(config.RateLimitGRPCPort), svc.Spec.Ports[0].Port)
+
+	deploy := new(appsv1.Deployment)
+	require.NoError(t, kube.Client.Get(ctx, key, deploy))
+	require.Contains(t, deploy.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "REDIS_URL", Value: "redis.redis.svc:6379"})
+
+	// Changing the backend updates the deployment.
+	require.NoError(t, kube.CreateOrUpdateRateLimitInfra(ctx, redisRateLimit("redis.other.svc:6379")))
+	require.NoError(t, kube.Client.Get(ctx, key, deploy))
+	require.Contains(t, deploy.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "REDIS_URL", Value: "redis.other.svc:6379"})
+
+	require.NoError(t, kube.DeleteRateLimitInfra(ctx))
+	require.True(t, kerrors.IsNotFound(kube.Client.Get(ctx, key, new(appsv1.Deployment))))
+	require.True(t, kerrors.IsNotFound(kube.Client.Get(ctx, key, new(corev1.Service))))
+	require.True(t, kerrors.IsNotFound(kube.Client.Get(ctx, key, new(corev1.ConfigMap))))
+
+	// Deleting missing infra is a no-op.
+	require.NoError(t, kube.DeleteRateLimitInfra(ctx))
+}
+
+func TestValidateRateLimit(t *testing.T) {
+	require.NoError(t, validateRateLimit(redisRateLimit("redis:6379")))
+	require.Error(t, validateRateLimit(nil))
+	require.Error(t, validateRateLimit(redisRateLimit("")))
+	require.Error(t, validateRateLimit(&v1alpha1.RateLimit{Backend: v1alpha1.RateLimitDatabaseBackend{Type: "Memcached"}}))
+	require.Error(t, validateRateLimit(&v1alpha1.RateLimit{Backend: v1alpha1.RateLimitDatabaseBackend{
+		Type:  v1alpha1.RedisBackendType,
+		Redis: &v1alpha1.RateLimitRedisSettings{Topology: v1alpha1.RedisTopologySentinel, URLs: []string{"sentinel:26379"}},
+	}}))
+	require.Error(t, validateRateLimit(&v1alpha1.RateLimit{Backend: v1alpha1.RateLimitDatabaseBackend{
+		Type:  v1alpha1.RedisBackendType,
+		Redis: &v1alpha1.RateLimitRedisSettings{Topology: v1alpha1.RedisTopologyCluster},
+	}}))
+}
+
+func TestExpectedRedisEnv(t *testing.T) {
+	testCases := []struct {
+		name  string
+		redis *v1alpha1.RateLimitRedisSettings
+		want  []corev1.EnvVar
+	}{
+		{
+			name:  "standalone",
+			redis: &v1alpha1.RateLimitRedisSettings{URL: "redis:6379"},
+			want: []corev1.EnvVar{
+				{Name: "REDIS_TYPE", Value: "SINGLE"},
+				{Name: "REDIS_URL", Value: "redis:6379"},
+			},
+		},
+		{
+			name: "sentinel",
+			redis: &v1alpha1.RateLimitRedisSettings{
+				Topology:   v1alpha1.RedisTopologySentinel,
+				MasterName: "mymaster",
+				URLs:       []string{"sentinel-0:26379", "sentinel-1:26379"},
+			},
+			want: []corev1.EnvVar{
+				{Name: "REDIS_TYPE", Value: "SENTINEL"},
+				{Name: "REDIS_URL", Value: "mymaster,sentinel-0:26379,sentinel-1:26379"},
+			},
+		},
+		{
+			name: "cluster with tls and auth",
+			redis: &v1alpha1.RateLimitRedisSettings{
+				Topology: v1alpha1.RedisTopologyCluster,
+				URLs:     []string{"redis-0:6379", "redis-1:6379"},
+				TLS: &v1alpha1.RedisTLSSettings{
+					CACertificateRef: &corev1.LocalObjectReference{Name: "redis-ca"},
+					CertificateRef:   &corev1.LocalObjectReference{Name: "redis-client"},
+				},
+				Auth: &v1alpha1.RedisAuth{
+					PasswordRef: corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "redis-auth"},
+						Key:                  "password",
+					},
+				},
+			},
+			want: []corev1.EnvVar{
+				{Name: "REDIS_TYPE", Value: "CLUSTER"},
+				{Name: "REDIS_URL", Value: "redis-0:6379,redis-1:6379"},
+				{Name: "REDIS_PIPELINE_WINDOW", Value: redisClusterPipelineWindow},
+				{Name: "REDIS_TLS", Value: "true"},
+				{Name: "REDIS_TLS_CACERT", Value: "/redis-certs/ca/ca.crt"},
+				{Name: "REDIS_TLS_CLIENT_CERT", Value: "/redis-certs/client/tls.crt"},
+				{Name: "REDIS_TLS_CLIENT_KEY", Value: "/redis-certs/client/tls.key"},
+				{Name: "REDIS_AUTH", ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "redis-auth"},
+						Key:                  "password",
+					},
+				}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, validateRateLimit(&v1alpha1.RateLimit{
+				Backend: v1alpha1.RateLimitDatabaseBackend{Type: v1alpha1.RedisBackendType, Redis: tc.redis},
+			}))
+			require.Equal(t, tc.want, expectedRedisEnv(tc.redis))
+		})
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/envoyproxy/gateway/internal/crypto"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/provider/utils"
+)
+
+const (
+	// caSecretName is the name of the Secret holding the CA used to issue
+	// per-proxy xDS client certificates.
+	caSecretName = "envoy-gateway"
+	// caCertificateKey is the key name for accessing the CA certificate.
+	caCertificateKey = "ca.crt"
+	// caPrivateKeyKey is the key name for accessing the CA private key.
+	caPrivateKeyKey = "ca.key"
+)
+
+func expectedSecretName(proxyName string) string {
+	secretName := utils.GetHashedName(proxyName)
+	return fmt.Sprintf("%s-%s", config.EnvoyPrefix, secretName)
+}
+
+// expectedSecret returns the expected Secret holding the xDS client certificate
+// of the proxy fleet, issued by the provided CA. The proxy name is used as the
+// certificate identity, so the xDS server only serves the fleet the configuration
+// of its owning Gateway.
+func (i *Infra) expectedSecret(infra *ir.Infra, caCert, caKey []byte) (*corev1.Secret, error) {
+	// Set the labels based on the owning gateway name.
+	labels := envoyLabels(infra.GetProxyInfra().GetProxyMetadata().Labels)
+	if len(labels[gatewayapi.OwningGatewayNamespaceLabel]) == 0 || len(labels[gatewayapi.OwningGatewayNameLabel]) == 0 {
+		return nil, fmt.Errorf("missing owning gateway labels")
+	}
+
+	cert, key, err := crypto.GenerateProxyCert(caCert, caKey, infra.Proxy.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate proxy certificate: %w", err)
+	}
+
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: i.Namespace,
+			Name:      expectedSecretName(infra.Proxy.Name),
+			Labels:    labels,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			caCertificateKey:        caCert,
+			corev1.TLSCertKey:       cert,
+			corev1.TLSPrivateKeyKey: key,
+		},
+	}, nil
+}
+
+// getCA returns the PEM encoded CA certificate and private key used to issue
+// proxy certificates.
+func (i *Infra) getCA(ctx context.Context) ([]byte, []byte, error) {
+	secret := new(corev1.Secret)
+	key := types.NamespacedName{Namespace: i.Namespace, Name: caSecretName}
+	if err := i.Client.Get(ctx, key, secret); err != nil {
+		return nil, nil, fmt.Errorf("failed to get secret %s/%s: %w", key.Namespace, key.Name, err)
+	}
+
+	caCert, caKey := secret.Data[caCertificateKey], secret.Data[caPrivateKeyKey]
+	if len(caCert) == 0 || len(caKey) == 0 {
+		return nil, nil, fmt.Errorf("secret %s/%s is missing the CA certificate or key", key.Namespace, key.Name)
+	}
+
+	return caCert, caKey, nil
+}
+
+// createOrUpdateSecret creates the Envoy xDS client certificate Secret in the kube
+// api server, if it doesn't exist. The certificate is reissued if it was signed by
+// a different CA or is close to expiry.
+func (i *Infra) createOrUpdateSecret(ctx context.Context, infra *ir.Infra) error {
+	caCert, caKey, err := i.getCA(ctx)
+	if err != nil {
+		return err
+	}
+
+	current := &corev1.Secret{}
+	key := types.NamespacedName{
+		Namespace: i.Namespace,
+		Name:      expectedSecretName(infra.Proxy.Name),
+	}
+
+	if err := i.Client.Get(ctx, key, current); err != nil {
+		// Create if not found.
+		if kerrors.IsNotFound(err) {
+			secret, err := i.expectedSecret(infra, caCert, caKey)
+			if err != nil {
+				return err
+			}
+			if err := i.Client.Create(ctx, secret); err != nil {
+				return fmt.Errorf("failed to create secret %s/%s: %w", secret.Namespace, secret.Name, err)
+			}
+		}
+	} else {
+		// Generating a certificate is not idempotent, so only reissue it when the
+		// current one can no longer be used.
+		if !secretNeedsUpdate(current, infra.Proxy.Name, caCert) {
+			return nil
+		}
+		secret, err := i.expectedSecret(infra, caCert, caKey)
+		if err != nil {
+			return err
+		}
+		if err := i.Client.Update(ctx, secret); err != nil {
+			return fmt.Errorf("failed to update secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// secretNeedsUpdate returns true if the proxy certificate in secret was not issued
+// for proxyName by caCert, or needs to be rotated.
+func secretNeedsUpdate(secret *corev1.Secret, proxyName string, caCert []byte) bool {
+	if !bytes.Equal(secret.Data[caCertificateKey], caCert) {
+		return true
+	}
+	cert := secret.Data[corev1.TLSCertKey]
+	if name, err := crypto.ProxyName(cert); err != nil || name != proxyName {
+		return true
+	}
+	rotate, err := crypto.NeedsRotation(cert, time.Now(), crypto.DefaultRotationThreshold)
+	if err != nil {
+		return true
+	}
+
+	return rotate
+}
+
+// deleteSecret deletes the Envoy xDS client certificate Secret in the kube api
+// server, if it exists.
+func (i *Infra) deleteSecret(ctx context.Context, infra *ir.Infra) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: i.Namespace,
+			Name:      expectedSecretName(infra.Proxy.Name),
+		},
+	}
+
+	if err := i.Client.Delete(ctx, secret); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/envoyproxy/gateway/internal/crypto"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// newTestCASecret returns a Secret holding a freshly generated CA in namespace.
+func newTestCASecret(t *testing.T, namespace string) *corev1.Secret {
+	t.Helper()
+	certs, err := crypto.GenerateCerts(nil)
+	require.NoError(t, err)
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      caSecretName,
+		},
+		Data: map[string][]byte{
+			caCertificateKey: certs.CACertificate,
+			caPrivateKeyKey:  certs.CAPrivateKey,
+		},
+	}
+}
+
+func TestCreateOrUpdateSecret(t *testing.T) {
+	infra := ir.NewInfra()
+	infra.Proxy.Name = "test"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+
+	ca := newTestCASecret(t, config.EnvoyGatewayNamespace)
+	kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects(ca).Build())
+
+	require.NoError(t, kube.createOrUpdateSecret(context.Background(), infra))
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: config.EnvoyGatewayNamespace,
+			Name:      "envoy-test-74657374",
+		},
+	}
+	require.NoError(t, kube.Client.Get(context.Background(), client.ObjectKeyFromObject(secret), secret))
+	assert.Equal(t, ca.Data[caCertificateKey], secret.Data[caCertificateKey])
+	assert.NotContains(t, secret.Data, caPrivateKeyKey)
+	proxyName, err := crypto.ProxyName(secret.Data[corev1.TLSCertKey])
+	require.NoError(t, err)
+	assert.Equal(t, infra.Proxy.Name, proxyName)
+
+	// A valid certificate must not be reissued.
+	require.NoError(t, kube.createOrUpdateSecret(context.Background(), infra))
+	current := &corev1.Secret{}
+	require.NoError(t, kube.Client.Get(context.Background(), client.ObjectKeyFromObject(secret), current))
+	assert.Equal(t, secret.Data, current.Data)
+
+	// A new CA must trigger a reissue.
+	newCA := newTestCASecret(t, config.EnvoyGatewayNamespace)
+	require.NoError(t, kube.Client.Update(context.Background(), newCA))
+	require.NoError(t, kube.createOrUpdateSecret(context.Background(), infra))
+	require.NoError(t, kube.Client.Get(context.Background(), client.ObjectKeyFromObject(secret), current))
+	assert.Equal(t, newCA.Data[caCertificateKey], current.Data[caCertificateKey])
+}
+
+func TestCreateOrUpdateSecretMissingCA(t *testing.T) {
+	infra := ir.NewInfra()
+	infra.Proxy.Name = "test"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+
+	kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+	require.Error(t, kube.createOrUpdateSecret(context.Background(), infra))
+}
+
+func TestDeleteSecret(t *testing.T) {
+	infra := ir.NewInfra()
+	infra.Proxy.Name = "test"
+
+	kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+	// Deleting a non-existent secret must not fail.
+	require.NoError(t, kube.deleteSecret(context.Background(), infra))
+}
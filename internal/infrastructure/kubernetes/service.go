@@ -9,6 +9,8 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -22,6 +24,15 @@ import (
 	"github.com/envoyproxy/gateway/internal/provider/utils"
 )
 
+const (
+	// externalDNSHostnameAnnotation is the annotation of the hostnames of the
+	// DNS records created by external-dns for a Service.
+	externalDNSHostnameAnnotation = "external-dns.alpha.kubernetes.io/hostname"
+	// externalDNSTTLAnnotation is the annotation of the TTL, in seconds, of the
+	// DNS records created by external-dns for a Service.
+	externalDNSTTLAnnotation = "external-dns.alpha.kubernetes.io/ttl"
+)
+
 func expectedServiceName(proxyName string) string {
 	svcName := utils.GetHashedName(proxyName)
 	return fmt.Sprintf("%s-%s", config.EnvoyPrefix, svcName)
@@ -33,9 +44,13 @@ func (i *Infra) expectedService(infra *ir.Infra) (*corev1.Service, error) {
 	for _, listener := range infra.Proxy.Listeners {
 		for _, port := range listener.Ports {
 			target := intstr.IntOrString{IntVal: port.ContainerPort}
+			protocol := corev1.ProtocolTCP
+			if port.Protocol == ir.UDPProtocolType {
+				protocol = corev1.ProtocolUDP
+			}
 			p := corev1.ServicePort{
 				Name:       port.Name,
-				Protocol:   corev1.ProtocolTCP,
+				Protocol:   protocol,
 				Port:       port.ServicePort,
 				TargetPort: target,
 			}
@@ -43,6 +58,16 @@ func (i *Infra) expectedService(infra *ir.Infra) (*corev1.Service, error) {
 		}
 	}
 
+	// Expose the health listener to external load balancers, if requested.
+	if health := infra.Proxy.Config.GetProxyHealth(); health.ExposeOnService {
+		ports = append(ports, corev1.ServicePort{
+			Name:       envoyHealthPortName,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       health.Port,
+			TargetPort: intstr.FromInt(int(health.Port)),
+		})
+	}
+
 	// Set the labels based on the owning gatewayclass name.
 	labels := envoyLabels(infra.GetProxyInfra().GetProxyMetadata().Labels)
 	if len(labels[gatewayapi.OwningGatewayNamespaceLabel]) == 0 || len(labels[gatewayapi.OwningGatewayNameLabel]) == 0 {
@@ -65,6 +90,41 @@ func (i *Infra) expectedService(infra *ir.Infra) (*corev1.Service, error) {
 		},
 	}
 
+	// Apply the user-facing service configuration of the EnvoyProxy, if any.
+	if spec := infra.Proxy.Config.GetEnvoyServiceSpec(); spec != nil {
+		if len(spec.Annotations) > 0 {
+			svc.Annotations = make(map[string]string, len(spec.Annotations))
+			for k, v := range spec.Annotations {
+				svc.Annotations[k] = v
+			}
+		}
+		svc.Spec.LoadBalancerClass = spec.LoadBalancerClass
+		if spec.LoadBalancerIP != nil {
+			svc.Spec.LoadBalancerIP = *spec.LoadBalancerIP
+		}
+		svc.Spec.IPFamilies = spec.IPFamilies
+		svc.Spec.IPFamilyPolicy = spec.IPFamilyPolicy
+	}
+
+	// Annotate the service for external-dns to create the DNS records of the
+	// listener hostnames, taking precedence over the user-facing annotations.
+	if externalDNS := infra.Proxy.Config.GetExternalDNS(); externalDNS != nil && len(infra.Proxy.Hostnames) > 0 {
+		if svc.Annotations == nil {
+			svc.Annotations = make(map[string]string)
+		}
+		svc.Annotations[externalDNSHostnameAnnotation] = strings.Join(infra.Proxy.Hostnames, ",")
+		if externalDNS.TTL != nil {
+			svc.Annotations[externalDNSTTLAnnotation] = strconv.FormatInt(int64(externalDNS.TTL.Seconds()), 10)
+		}
+	}
+
+	// An address requested by the Gateway takes precedence. Only a single
+	// address can be requested from the load balancer, additional addresses
+	// are surfaced as unassigned through the Gateway status.
+	if len(infra.Proxy.Addresses) > 0 {
+		svc.Spec.LoadBalancerIP = infra.Proxy.Addresses[0]
+	}
+
 	return svc, nil
 }
 
@@ -82,17 +142,28 @@ func (i *Infra) createOrUpdateService(ctx context.Context, infra *ir.Infra) erro
 		Name:      expectedServiceName(infra.Proxy.Name),
 	}
 
+	revision := expectedRevision(infra)
+	selected := revision
 	if err := i.Client.Get(ctx, key, current); err != nil {
 		// Create if not found.
 		if kerrors.IsNotFound(err) {
+			setRevisionSelector(svc, selected)
 			if err := i.Client.Create(ctx, svc); err != nil {
 				return fmt.Errorf("failed to create service %s/%s: %w",
 					svc.Namespace, svc.Name, err)
 			}
 		}
 	} else {
+		// Keep the traffic on the current revision of the proxy until the
+		// expected one is available.
+		var err error
+		if selected, err = i.selectedRevision(ctx, infra, current); err != nil {
+			return err
+		}
+		setRevisionSelector(svc, selected)
+
 		// Update if current value is different.
-		if !reflect.DeepEqual(svc.Spec, current.Spec) {
+		if !reflect.DeepEqual(svc.Spec, current.Spec) || !reflect.DeepEqual(svc.Annotations, current.Annotations) {
 			if err := i.Client.Update(ctx, svc); err != nil {
 				return fmt.Errorf("failed to update service %s/%s: %w",
 					svc.Namespace, svc.Name, err)
@@ -100,9 +171,22 @@ func (i *Infra) createOrUpdateService(ctx context.Context, infra *ir.Infra) erro
 		}
 	}
 
+	// Drain the other revisions once the traffic is switched.
+	if selected == revision {
+		return i.deleteStaleDeployments(ctx, infra, expectedRevisionDeploymentName(infra.Proxy.Name, revision))
+	}
+
 	return nil
 }
 
+// setRevisionSelector restricts the selector of the Service to the pods of the
+// provided revision, if any.
+func setRevisionSelector(svc *corev1.Service, revision string) {
+	if revision != "" {
+		svc.Spec.Selector[gatewayapi.RevisionLabel] = revision
+	}
+}
+
 // deleteService deletes the Envoy Service in the kube api server, if it exists.
 func (i *Infra) deleteService(ctx context.Context, infra *ir.Infra) error {
 	svc := &corev1.Service{
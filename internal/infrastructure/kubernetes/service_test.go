@@ -8,14 +8,17 @@ package kubernetes
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/ir"
@@ -84,6 +87,12 @@ func TestDesiredService(t *testing.T) {
 			ServicePort:   443,
 			ContainerPort: 2443,
 		},
+		{
+			Name:          "gateway-system-gateway-1",
+			Protocol:      ir.UDPProtocolType,
+			ServicePort:   53,
+			ContainerPort: 10053,
+		},
 	}
 	svc, err := kube.expectedService(infra)
 	require.NoError(t, err)
@@ -96,6 +105,15 @@ func TestDesiredService(t *testing.T) {
 	checkServiceHasTargetPort(t, svc, 2080)
 	checkServiceHasTargetPort(t, svc, 2443)
 
+	// The UDP listeners are exposed on UDP ports.
+	for _, port := range svc.Spec.Ports {
+		if port.Port == 53 {
+			assert.Equal(t, corev1.ProtocolUDP, port.Protocol)
+		} else {
+			assert.Equal(t, corev1.ProtocolTCP, port.Protocol)
+		}
+	}
+
 	// Ensure the Envoy service has the expected labels.
 	lbls := envoyAppLabel()
 	lbls[gatewayapi.OwningGatewayNamespaceLabel] = "default"
@@ -107,6 +125,161 @@ func TestDesiredService(t *testing.T) {
 	}
 }
 
+func TestDesiredServiceHealth(t *testing.T) {
+	kube := NewInfra(nil)
+	infra := ir.NewInfra()
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+
+	// The health listener isn't exposed by default.
+	svc, err := kube.expectedService(infra)
+	require.NoError(t, err)
+	for _, port := range svc.Spec.Ports {
+		assert.NotEqual(t, envoyHealthPortName, port.Name)
+	}
+
+	infra.Proxy.Config = &v1alpha1.EnvoyProxy{
+		Spec: v1alpha1.EnvoyProxySpec{
+			Health: &v1alpha1.ProxyHealth{Port: 8002, ExposeOnService: true},
+		},
+	}
+	svc, err = kube.expectedService(infra)
+	require.NoError(t, err)
+	checkServiceHasPortName(t, svc, envoyHealthPortName)
+	checkServiceHasPort(t, svc, 8002)
+	checkServiceHasTargetPort(t, svc, 8002)
+}
+
+func TestDesiredServiceLoadBalancer(t *testing.T) {
+	lbClass := "example.com/static"
+	lbIP := "10.0.0.10"
+	dualStack := corev1.IPFamilyPolicyRequireDualStack
+
+	testCases := []struct {
+		name        string
+		config      *v1alpha1.EnvoyProxy
+		addresses   []string
+		hostnames   []string
+		expectClass *string
+		expectIP    string
+		expectAnns  map[string]string
+		expectIPFam []corev1.IPFamily
+	}{
+		{
+			name: "default",
+		},
+		{
+			name: "envoyproxy service config",
+			config: &v1alpha1.EnvoyProxy{
+				Spec: v1alpha1.EnvoyProxySpec{
+					Provider: &v1alpha1.ResourceProvider{
+						Type: v1alpha1.ProviderTypeKubernetes,
+						Kubernetes: &v1alpha1.KubernetesResourceProvider{
+							EnvoyService: &v1alpha1.KubernetesServiceSpec{
+								Annotations:       map[string]string{"lb.example.com/id": "lb-1234"},
+								LoadBalancerClass: &lbClass,
+								LoadBalancerIP:    &lbIP,
+							},
+						},
+					},
+				},
+			},
+			expectClass: &lbClass,
+			expectIP:    lbIP,
+			expectAnns:  map[string]string{"lb.example.com/id": "lb-1234"},
+		},
+		{
+			name: "dual-stack",
+			config: &v1alpha1.EnvoyProxy{
+				Spec: v1alpha1.EnvoyProxySpec{
+					Provider: &v1alpha1.ResourceProvider{
+						Type: v1alpha1.ProviderTypeKubernetes,
+						Kubernetes: &v1alpha1.KubernetesResourceProvider{
+							EnvoyService: &v1alpha1.KubernetesServiceSpec{
+								IPFamilies:     []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+								IPFamilyPolicy: &dualStack,
+							},
+						},
+					},
+				},
+			},
+			expectIPFam: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+		},
+		{
+			name:      "gateway addresses",
+			addresses: []string{"10.0.0.20", "10.0.0.21"},
+			expectIP:  "10.0.0.20",
+		},
+		{
+			name: "gateway addresses take precedence",
+			config: &v1alpha1.EnvoyProxy{
+				Spec: v1alpha1.EnvoyProxySpec{
+					Provider: &v1alpha1.ResourceProvider{
+						Type: v1alpha1.ProviderTypeKubernetes,
+						Kubernetes: &v1alpha1.KubernetesResourceProvider{
+							EnvoyService: &v1alpha1.KubernetesServiceSpec{
+								LoadBalancerIP: &lbIP,
+							},
+						},
+					},
+				},
+			},
+			addresses: []string{"10.0.0.20"},
+			expectIP:  "10.0.0.20",
+		},
+		{
+			name: "external dns",
+			config: &v1alpha1.EnvoyProxy{
+				Spec: v1alpha1.EnvoyProxySpec{
+					Provider: &v1alpha1.ResourceProvider{
+						Type: v1alpha1.ProviderTypeKubernetes,
+						Kubernetes: &v1alpha1.KubernetesResourceProvider{
+							EnvoyService: &v1alpha1.KubernetesServiceSpec{
+								Annotations: map[string]string{"lb.example.com/id": "lb-1234"},
+								ExternalDNS: &v1alpha1.ExternalDNS{TTL: &metav1.Duration{Duration: 5 * time.Minute}},
+							},
+						},
+					},
+				},
+			},
+			hostnames: []string{"*.example.com", "www.example.org"},
+			expectAnns: map[string]string{
+				"lb.example.com/id":                         "lb-1234",
+				"external-dns.alpha.kubernetes.io/hostname": "*.example.com,www.example.org",
+				"external-dns.alpha.kubernetes.io/ttl":      "300",
+			},
+		},
+		{
+			name:      "external dns disabled",
+			hostnames: []string{"www.example.org"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects().Build()
+			kube := NewInfra(cli)
+			infra := ir.NewInfra()
+			infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+			infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+			infra.Proxy.Config = tc.config
+			infra.Proxy.Addresses = tc.addresses
+			infra.Proxy.Hostnames = tc.hostnames
+
+			svc, err := kube.expectedService(infra)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectClass, svc.Spec.LoadBalancerClass)
+			assert.Equal(t, tc.expectIP, svc.Spec.LoadBalancerIP)
+			assert.Equal(t, tc.expectAnns, svc.Annotations)
+			assert.Equal(t, tc.expectIPFam, svc.Spec.IPFamilies)
+			if tc.expectIPFam != nil {
+				assert.Equal(t, &dualStack, svc.Spec.IPFamilyPolicy)
+			}
+		})
+	}
+}
+
 func TestDeleteService(t *testing.T) {
 	testCases := []struct {
 		name string
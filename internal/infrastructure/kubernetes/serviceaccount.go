@@ -33,7 +33,7 @@ func (i *Infra) expectedServiceAccount(infra *ir.Infra) (*corev1.ServiceAccount,
 		return nil, fmt.Errorf("missing owning gateway labels")
 	}
 
-	return &corev1.ServiceAccount{
+	sa := &corev1.ServiceAccount{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "ServiceAccount",
 			APIVersion: "v1",
@@ -43,7 +43,18 @@ func (i *Infra) expectedServiceAccount(infra *ir.Infra) (*corev1.ServiceAccount,
 			Name:      expectedServiceAccountName(infra.Proxy.Name),
 			Labels:    labels,
 		},
-	}, nil
+	}
+
+	// Apply the annotations of the EnvoyProxy, if any, e.g. to bind the proxies
+	// to a role of the cloud provider.
+	if spec := infra.Proxy.Config.GetEnvoyServiceAccountSpec(); spec != nil && len(spec.Annotations) > 0 {
+		sa.Annotations = make(map[string]string, len(spec.Annotations))
+		for k, v := range spec.Annotations {
+			sa.Annotations[k] = v
+		}
+	}
+
+	return sa, nil
 }
 
 // createOrUpdateServiceAccount creates the Envoy ServiceAccount in the kube api server,
@@ -19,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/ir"
@@ -47,6 +48,27 @@ func TestExpectedServiceAccount(t *testing.T) {
 	wantLabels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
 	wantLabels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
 	assert.True(t, apiequality.Semantic.DeepEqual(wantLabels, sa.Labels))
+	assert.Nil(t, sa.Annotations)
+
+	// Annotations of the EnvoyProxy are appended to the serviceaccount.
+	infra.Proxy.Config = &v1alpha1.EnvoyProxy{
+		Spec: v1alpha1.EnvoyProxySpec{
+			Provider: &v1alpha1.ResourceProvider{
+				Type: v1alpha1.ProviderTypeKubernetes,
+				Kubernetes: &v1alpha1.KubernetesResourceProvider{
+					EnvoyServiceAccount: &v1alpha1.KubernetesServiceAccountSpec{
+						Annotations: map[string]string{
+							"eks.amazonaws.com/role-arn": "arn:aws:iam::111122223333:role/envoy",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	sa, err = kube.expectedServiceAccount(infra)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::111122223333:role/envoy"}, sa.Annotations)
 }
 
 func TestCreateOrUpdateServiceAccount(t *testing.T) {
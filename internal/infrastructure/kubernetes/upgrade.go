@@ -0,0 +1,191 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// revisionPollInterval is the interval at which the readiness of the
+	// deployment of a new revision is checked.
+	revisionPollInterval = 5 * time.Second
+	// revisionReadyTimeout is the time the proxies of a new revision have to
+	// become ready before the traffic switch is abandoned.
+	revisionReadyTimeout = 10 * time.Minute
+)
+
+// expectedRevision returns the revision of the proxy deployment if it is
+// upgraded with the BlueGreen strategy, or an empty string otherwise. The
+// revision is derived from the image of the proxy.
+func expectedRevision(infra *ir.Infra) string {
+	spec := infra.Proxy.Config.GetEnvoyDeploymentSpec()
+	if spec == nil || spec.UpgradeStrategy == nil || spec.UpgradeStrategy.Type != v1alpha1.BlueGreenUpgradeStrategyType {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(infra.Proxy.Image))
+	return fmt.Sprintf("%x", sum)[:10]
+}
+
+// expectedRevisionDeploymentName returns the name of the deployment of the
+// provided revision of the proxy.
+func expectedRevisionDeploymentName(proxyName, revision string) string {
+	if revision == "" {
+		return expectedDeploymentName(proxyName)
+	}
+	return fmt.Sprintf("%s-%s", expectedDeploymentName(proxyName), revision)
+}
+
+// deploymentAvailable returns whether all the replicas of the deployment are
+// up to date and available. The readiness probe of Envoy only succeeds once
+// the proxy has fetched its initial configuration from the xDS server.
+func deploymentAvailable(deploy *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if deploy.Spec.Replicas != nil {
+		replicas = *deploy.Spec.Replicas
+	}
+
+	return deploy.Status.ObservedGeneration >= deploy.Generation &&
+		deploy.Status.UpdatedReplicas >= replicas &&
+		deploy.Status.AvailableReplicas >= replicas
+}
+
+// selectedRevision returns the revision the Service of the proxy should
+// select. The Service keeps selecting its current revision until the
+// deployment of the expected revision is available, which is then awaited in
+// the background.
+func (i *Infra) selectedRevision(ctx context.Context, infra *ir.Infra, current *corev1.Service) (string, error) {
+	revision := expectedRevision(infra)
+	active := current.Spec.Selector[gatewayapi.RevisionLabel]
+	if active == revision {
+		return revision, nil
+	}
+
+	deploy := &appsv1.Deployment{}
+	key := types.NamespacedName{
+		Namespace: i.Namespace,
+		Name:      expectedRevisionDeploymentName(infra.Proxy.Name, revision),
+	}
+	if err := i.Client.Get(ctx, key, deploy); err != nil {
+		if kerrors.IsNotFound(err) {
+			return active, nil
+		}
+		return "", fmt.Errorf("failed to get deployment %s/%s: %w", key.Namespace, key.Name, err)
+	}
+	if deploymentAvailable(deploy) {
+		return revision, nil
+	}
+
+	i.awaitRevision(ctx, infra, revision)
+	return active, nil
+}
+
+// awaitRevision switches the Service of the proxy to the provided revision
+// once its deployment is available, unless a switch to the revision is
+// already awaited. The latest infra is recorded, so that the switch applies
+// the current state of the Service.
+func (i *Infra) awaitRevision(ctx context.Context, infra *ir.Infra, revision string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.upgrades == nil {
+		i.upgrades = make(map[string]*ir.Infra)
+	}
+	pending, ok := i.upgrades[infra.Proxy.Name]
+	i.upgrades[infra.Proxy.Name] = infra
+	if ok && expectedRevision(pending) == revision {
+		return
+	}
+
+	go i.switchRevision(ctx, infra.Proxy.Name, revision)
+}
+
+// pendingUpgrade returns the latest infra of the proxy if its switch to the
+// provided revision is still awaited, or nil if it was superseded.
+func (i *Infra) pendingUpgrade(proxyName, revision string) *ir.Infra {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	pending := i.upgrades[proxyName]
+	if pending == nil || expectedRevision(pending) != revision {
+		return nil
+	}
+	return pending
+}
+
+// switchRevision polls the deployment of the provided revision of the proxy
+// and switches the Service to it once available. The switch is abandoned if
+// it is superseded, the deployment is deleted, or the revision isn't
+// available within revisionReadyTimeout.
+func (i *Infra) switchRevision(ctx context.Context, proxyName, revision string) {
+	ctx, cancel := context.WithTimeout(ctx, revisionReadyTimeout)
+	defer cancel()
+
+	_ = wait.PollImmediateUntil(revisionPollInterval, func() (bool, error) {
+		infra := i.pendingUpgrade(proxyName, revision)
+		if infra == nil {
+			return true, nil
+		}
+
+		deploy := &appsv1.Deployment{}
+		key := types.NamespacedName{
+			Namespace: i.Namespace,
+			Name:      expectedRevisionDeploymentName(proxyName, revision),
+		}
+		if err := i.Client.Get(ctx, key, deploy); err != nil {
+			return kerrors.IsNotFound(err), nil
+		}
+		if !deploymentAvailable(deploy) {
+			return false, nil
+		}
+
+		// Retry until the Service is switched.
+		return i.createOrUpdateService(ctx, infra) == nil, nil
+	}, ctx.Done())
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if pending := i.upgrades[proxyName]; pending != nil && expectedRevision(pending) == revision {
+		delete(i.upgrades, proxyName)
+	}
+}
+
+// deleteStaleDeployments deletes the deployments of the revisions of the
+// proxy other than the one of the provided name, draining their proxies.
+func (i *Infra) deleteStaleDeployments(ctx context.Context, infra *ir.Infra, keep string) error {
+	deploys := &appsv1.DeploymentList{}
+	if err := i.Client.List(ctx, deploys, client.InNamespace(i.Namespace), client.MatchingLabels(envoyAppLabel())); err != nil {
+		return fmt.Errorf("failed to list deployments in namespace %s: %w", i.Namespace, err)
+	}
+
+	for j := range deploys.Items {
+		deploy := &deploys.Items[j]
+		revision := deploy.Labels[gatewayapi.RevisionLabel]
+		if deploy.Name == keep || deploy.Name != expectedRevisionDeploymentName(infra.Proxy.Name, revision) {
+			continue
+		}
+		if err := i.Client.Delete(ctx, deploy); err != nil && !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete deployment %s/%s: %w", deploy.Namespace, deploy.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func blueGreenInfra(image string) *ir.Infra {
+	infra := ir.NewInfra()
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNamespaceLabel] = "default"
+	infra.Proxy.GetProxyMetadata().Labels[gatewayapi.OwningGatewayNameLabel] = infra.Proxy.Name
+	infra.Proxy.Image = image
+	infra.Proxy.Config = &v1alpha1.EnvoyProxy{
+		Spec: v1alpha1.EnvoyProxySpec{
+			Provider: &v1alpha1.ResourceProvider{
+				Type: v1alpha1.ProviderTypeKubernetes,
+				Kubernetes: &v1alpha1.KubernetesResourceProvider{
+					EnvoyDeployment: &v1alpha1.KubernetesDeploymentSpec{
+						UpgradeStrategy: &v1alpha1.UpgradeStrategy{
+							Type: v1alpha1.BlueGreenUpgradeStrategyType,
+						},
+					},
+				},
+			},
+		},
+	}
+	return infra
+}
+
+func TestExpectedRevision(t *testing.T) {
+	assert.Empty(t, expectedRevision(ir.NewInfra()))
+
+	revision := expectedRevision(blueGreenInfra("envoyproxy/envoy:v1"))
+	assert.Len(t, revision, 10)
+	assert.Equal(t, revision, expectedRevision(blueGreenInfra("envoyproxy/envoy:v1")))
+	assert.NotEqual(t, revision, expectedRevision(blueGreenInfra("envoyproxy/envoy:v2")))
+}
+
+func TestExpectedDeploymentBlueGreen(t *testing.T) {
+	kube := NewInfra(nil)
+	infra := blueGreenInfra("envoyproxy/envoy:v1")
+	revision := expectedRevision(infra)
+
+	deploy, err := kube.expectedDeployment(infra)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedDeploymentName(infra.Proxy.Name)+"-"+revision, deploy.Name)
+	assert.Equal(t, revision, deploy.Labels[gatewayapi.RevisionLabel])
+	assert.Equal(t, revision, deploy.Spec.Selector.MatchLabels[gatewayapi.RevisionLabel])
+	assert.Equal(t, revision, deploy.Spec.Template.Labels[gatewayapi.RevisionLabel])
+}
+
+func TestCreateOrUpdateServiceBlueGreen(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	kube := NewInfra(fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build())
+	oldInfra := blueGreenInfra("envoyproxy/envoy:v1")
+	newInfra := blueGreenInfra("envoyproxy/envoy:v2")
+	oldRevision, newRevision := expectedRevision(oldInfra), expectedRevision(newInfra)
+
+	// The traffic is served by the deployment of the old revision.
+	require.NoError(t, kube.createOrUpdateDeployment(ctx, oldInfra))
+	require.NoError(t, kube.createOrUpdateService(ctx, oldInfra))
+	assertSelectedRevision(t, kube, newInfra, oldRevision)
+
+	// The Service keeps selecting the old revision until the deployment of
+	// the new one is available.
+	require.NoError(t, kube.createOrUpdateDeployment(ctx, newInfra))
+	require.NoError(t, kube.createOrUpdateService(ctx, newInfra))
+	assertSelectedRevision(t, kube, newInfra, oldRevision)
+
+	deploy := &appsv1.Deployment{}
+	key := client.ObjectKey{Namespace: kube.Namespace, Name: expectedRevisionDeploymentName(newInfra.Proxy.Name, newRevision)}
+	require.NoError(t, kube.Client.Get(ctx, key, deploy))
+	deploy.Status = appsv1.DeploymentStatus{
+		ObservedGeneration: deploy.Generation,
+		UpdatedReplicas:    1,
+		AvailableReplicas:  1,
+	}
+	require.NoError(t, kube.Client.Update(ctx, deploy))
+
+	// The traffic switches to the new revision, and the old one is drained.
+	require.NoError(t, kube.createOrUpdateService(ctx, newInfra))
+	assertSelectedRevision(t, kube, newInfra, newRevision)
+
+	key.Name = expectedRevisionDeploymentName(oldInfra.Proxy.Name, oldRevision)
+	err := kube.Client.Get(ctx, key, &appsv1.Deployment{})
+	require.True(t, kerrors.IsNotFound(err))
+}
+
+func assertSelectedRevision(t *testing.T, kube *Infra, infra *ir.Infra, revision string) {
+	t.Helper()
+
+	svc := &corev1.Service{}
+	key := client.ObjectKey{Namespace: kube.Namespace, Name: expectedServiceName(infra.Proxy.Name)}
+	require.NoError(t, kube.Client.Get(context.Background(), key, svc))
+	assert.Equal(t, revision, svc.Spec.Selector[gatewayapi.RevisionLabel])
+}
@@ -27,6 +27,10 @@ type Manager interface {
 	CreateOrUpdateInfra(ctx context.Context, infra *ir.Infra) error
 	// DeleteInfra deletes infra
 	DeleteInfra(ctx context.Context, infra *ir.Infra) error
+	// CreateOrUpdateRateLimitInfra creates or updates the global rate limit service infra.
+	CreateOrUpdateRateLimitInfra(ctx context.Context, rateLimit *v1alpha1.RateLimit) error
+	// DeleteRateLimitInfra deletes the global rate limit service infra.
+	DeleteRateLimitInfra(ctx context.Context) error
 }
 
 // NewManager returns a new infrastructure Manager.
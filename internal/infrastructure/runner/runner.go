@@ -8,6 +8,7 @@ package runner
 import (
 	"context"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/infrastructure"
 	"github.com/envoyproxy/gateway/internal/ir"
@@ -22,6 +23,9 @@ type Config struct {
 type Runner struct {
 	Config
 	mgr infrastructure.Manager
+	// provisioned holds the keys of the infra IRs whose infra was created or
+	// updated successfully by the last update.
+	provisioned map[string]bool
 }
 
 func (r *Runner) Name() string {
@@ -35,30 +39,71 @@ func New(cfg *Config) *Runner {
 // Start starts the infrastructure runner
 func (r *Runner) Start(ctx context.Context) error {
 	var err error
-	r.Logger = r.Logger.WithValues("runner", r.Name())
+	r.Logger = r.ComponentLogger(v1alpha1.LogComponentInfra).WithValues("runner", r.Name())
 	r.mgr, err = infrastructure.NewManager(&r.Config.Server)
 	if err != nil {
 		r.Logger.Error(err, "failed to create new manager")
 	}
-	go r.subscribeAndTranslate(ctx)
+	go func() {
+		if !r.waitForElection(ctx) {
+			return
+		}
+		if r.mgr != nil {
+			if err := r.manageRateLimitInfra(ctx); err != nil {
+				r.Logger.Error(err, "failed to manage rate limit infra")
+			}
+		}
+		r.subscribeAndTranslate(ctx)
+	}()
 	r.Logger.Info("started")
 	return nil
 }
 
+// waitForElection waits until this replica is elected leader, since only the
+// leader manages the infrastructure. It returns false if ctx is done first.
+func (r *Runner) waitForElection(ctx context.Context) bool {
+	if r.Elected == nil {
+		return true
+	}
+	select {
+	case <-r.Elected:
+		r.Logger.Info("elected leader, managing infra")
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// manageRateLimitInfra creates or updates the global rate limit service infra
+// if global rate limiting is enabled, and deletes it otherwise.
+func (r *Runner) manageRateLimitInfra(ctx context.Context) error {
+	if r.EnvoyGateway.RateLimit == nil {
+		return r.mgr.DeleteRateLimitInfra(ctx)
+	}
+	return r.mgr.CreateOrUpdateRateLimitInfra(ctx, r.EnvoyGateway.RateLimit)
+}
+
 func (r *Runner) subscribeAndTranslate(ctx context.Context) {
-	// Subscribe to resources
-	message.HandleSubscription(r.InfraIR.Subscribe(ctx),
+	r.provisioned = make(map[string]bool)
+	// Subscribe to resources, managing the infra of the Gateways whose infra
+	// is missing or failed to be updated first.
+	message.HandleSubscriptionByPriority(r.InfraIR.Subscribe(ctx),
+		func(key string) bool { return !r.provisioned[key] },
 		func(update message.Update[string, *ir.Infra]) {
 			val := update.Value
 
 			if update.Delete {
+				delete(r.provisioned, update.Key)
 				if err := r.mgr.DeleteInfra(ctx, val); err != nil {
 					r.Logger.Error(err, "failed to delete infra")
 				}
 			} else {
 				// Manage the proxy infra.
 				if err := r.mgr.CreateOrUpdateInfra(ctx, val); err != nil {
+					r.provisioned[update.Key] = false
 					r.Logger.Error(err, "failed to create new infra")
+				} else {
+					r.provisioned[update.Key] = true
 				}
 			}
 		},
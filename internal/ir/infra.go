@@ -40,6 +40,18 @@ type ProxyInfra struct {
 	Image string
 	// Listeners define the listeners exposed by the proxy infrastructure.
 	Listeners []ProxyListener
+	// Addresses define the IP addresses requested for the proxy infrastructure,
+	// e.g. the static IP address of a pre-provisioned load balancer.
+	Addresses []string
+	// Hostnames are the listener hostnames the DNS records pointing at the
+	// proxy infrastructure are created for, if external DNS is enabled.
+	Hostnames []string
+	// SPIFFE defines the Workload API the proxy infrastructure sources its
+	// identity from. If unset, the xDS client certificate Secret is used.
+	SPIFFE *v1alpha1.SPIFFE
+	// TrafficStats enables the proxy infrastructure to push its stats to
+	// Envoy Gateway, aggregating the traffic statistics of the Gateway.
+	TrafficStats bool
 }
 
 // InfraMetadata defines metadata for the managed proxy infrastructure.
@@ -87,6 +99,12 @@ const (
 
 	// Accepts TLS sessions over TCP.
 	TLSProtocolType ProtocolType = "TLS"
+
+	// TCPProtocolType accepts cleartext TCP connections.
+	TCPProtocolType ProtocolType = "TCP"
+
+	// UDPProtocolType accepts UDP datagrams.
+	UDPProtocolType ProtocolType = "UDP"
 )
 
 // NewInfra returns a new Infra with default parameters.
@@ -8,8 +8,10 @@ package ir
 import (
 	"errors"
 	"net"
+	"net/url"
 
 	"github.com/tetratelabs/multierror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var (
@@ -33,6 +35,31 @@ var (
 	ErrAddHeaderEmptyName            = errors.New("header modifier filter cannot configure a header without a name to be added")
 	ErrAddHeaderDuplicate            = errors.New("header modifier filter attempts to add the same header more than once (case insensitive)")
 	ErrRemoveHeaderDuplicate         = errors.New("header modifier filter attempts to remove the same header more than once (case insensitive)")
+	ErrFilterPositionNameEmpty       = errors.New("field Name must be specified for a filter position")
+	ErrFilterPositionInvalid         = errors.New("exactly one of the Before or After fields must be specified for a filter position")
+	ErrRateLimitServiceHostEmpty     = errors.New("field Host must be specified for the rate limit service")
+	ErrRateLimitServicePortInvalid   = errors.New("field Port specified for the rate limit service is invalid")
+	ErrRateLimitServiceDomainEmpty   = errors.New("field Domain must be specified for the rate limit service")
+	ErrBackendTLSClientCertInvalid   = errors.New("fields ClientCertificate and PrivateKey must be specified together")
+	ErrTLSOCSPStapleRequired         = errors.New("field OCSPStaple must be specified when OCSPStaplePolicy is MustStaple")
+	ErrTLSOCSPStaplePolicyInvalid    = errors.New("field OCSPStaplePolicy must be LenientStapling, StrictStapling or MustStaple")
+	ErrJWTProvidersEmpty             = errors.New("field Providers must be specified with at least a single JWT provider")
+	ErrJWTProviderNameEmpty          = errors.New("field Name must be specified for a JWT provider")
+	ErrJWTProviderNameDuplicate      = errors.New("the names of the JWT providers must be unique")
+	ErrJWTProviderJWKSURIInvalid     = errors.New("field RemoteJWKS.URI must be an absolute HTTP or HTTPS URI")
+	ErrJWTClaimInvalid               = errors.New("fields Name and Values must be specified for a JWT claim")
+	ErrExtAuthServiceInvalid         = errors.New("exactly one of the GRPC or HTTP fields must be specified for the external authorization")
+	ErrExtAuthDestinationEmpty       = errors.New("field Destination must be specified for the external authorization")
+	ErrExtAuthBodyMaxBytesInvalid    = errors.New("field MaxRequestBytes must be greater than zero for the external authorization body")
+	ErrExtProcDestinationEmpty       = errors.New("field Destination must be specified for the external processing")
+	ErrHeaderLimitsKiBInvalid        = errors.New("field MaxRequestHeadersKiB must not be greater than 8192")
+	ErrSocketOptionsDSCPInvalid      = errors.New("field DSCP must not be greater than 63")
+	ErrAccessLogSampleRateInvalid    = errors.New("field AccessLogSampleRate must not be greater than 1000000")
+	ErrDynamicForwardProxyDests      = errors.New("field Destinations must be empty when DynamicForwardProxy is specified")
+	ErrInternalListenerDestAddress   = errors.New("field Host and Port must be empty when InternalListener is specified")
+	ErrUDPListenerHashHeader         = errors.New("field LoadBalancer.HashHeader must be empty for a UDP listener")
+	ErrDNSRecordDomainEmpty          = errors.New("field Domain must be specified for a DNS record")
+	ErrDNSRecordAddressInvalid       = errors.New("field Addresses must be specified with valid IP addresses for a DNS record")
 )
 
 // Xds holds the intermediate representation of a Gateway and is
@@ -45,6 +72,10 @@ type Xds struct {
 	TCP []*TCPListener
 	// UDP Listeners exposed by the gateway.
 	UDP []*UDPListener
+	// MaxStatsClusters is the maximum number of clusters reporting their own
+	// stats, the other clusters sharing the overflow stats. If zero, all the
+	// clusters report their own stats.
+	MaxStatsClusters uint32
 }
 
 // Validate the fields within the Xds structure.
@@ -102,6 +133,10 @@ func (x Xds) Printable() *Xds {
 		// Omit field
 		listener.TLS = nil
 	}
+	for _, listener := range out.TCP {
+		// Omit field
+		listener.TerminateTLS = nil
+	}
 	return out
 }
 
@@ -119,12 +154,50 @@ type HTTPListener struct {
 	// Refer to https://www.envoyproxy.io/docs/envoy/latest/api-v3/config/route/v3/route_components.proto#config-route-v3-virtualhost
 	// for more info.
 	Hostnames []string
+	// IsolatedHostnames are the hostnames of the more specific listeners
+	// sharing the port, matched by the Hostnames of this listener. Requests
+	// for these hostnames are never served by the routes of this listener.
+	IsolatedHostnames []string
 	// Tls certificate info. If omitted, the gateway will expose a plain text HTTP server.
 	TLS *TLSListenerConfig
 	// Routes associated with HTTP traffic to the service.
 	Routes []*HTTPRoute
 	// IsHTTP2 is set if the upstream client as well as the downstream server are configured to serve HTTP2 traffic.
 	IsHTTP2 bool
+	// FilterOrder moves HTTP filters relative to other HTTP filters, applied in
+	// order after the default ordering.
+	FilterOrder []*FilterPosition
+	// RateLimitService is the global rate limit service called by the rate
+	// limit filter of the listener. If unset, global rate limiting is disabled.
+	RateLimitService *RateLimitService
+	// IdleTimeout is the time after which downstream connections with no active
+	// requests are closed. If unset, the Envoy default applies.
+	IdleTimeout *metav1.Duration
+	// HeaderLimits limits the headers of the requests. Requests exceeding the
+	// limits are rejected with a 431 response. If unset, the Envoy defaults
+	// apply.
+	HeaderLimits *HeaderLimits
+	// Draining configures how the connections of the listener are drained
+	// when it is updated or removed. If unset, the Envoy defaults apply.
+	Draining *Draining
+	// Maintenance puts the listener into maintenance mode: the requests
+	// receive a 503 response instead of being routed. If unset, the requests
+	// are routed.
+	Maintenance *Maintenance
+	// SocketOptions configures the sockets of the listener. If unset, the
+	// Envoy defaults apply.
+	SocketOptions *SocketOptions
+	// HTTP1 configures the HTTP/1 connections of the listener. If unset, the
+	// Envoy defaults apply.
+	HTTP1 *HTTP1Settings
+	// HeaderHandling configures how the listener handles the casing, the
+	// duplicates and the hop-by-hop headers. If unset, the Envoy defaults
+	// apply.
+	HeaderHandling *HeaderHandling
+	// AccessLogSampleRate is the fraction of the requests written to the
+	// access log, in parts per million. If zero, all the requests are
+	// logged.
+	AccessLogSampleRate uint32
 }
 
 // Validate the fields within the HTTPListener structure
@@ -152,6 +225,187 @@ func (h HTTPListener) Validate() error {
 			errs = multierror.Append(errs, err)
 		}
 	}
+	for _, position := range h.FilterOrder {
+		if err := position.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.RateLimitService != nil {
+		if err := h.RateLimitService.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.HeaderLimits != nil {
+		if err := h.HeaderLimits.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.SocketOptions != nil {
+		if err := h.SocketOptions.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.AccessLogSampleRate > 1000000 {
+		errs = multierror.Append(errs, ErrAccessLogSampleRateInvalid)
+	}
+	return errs
+}
+
+// HeaderLimits holds the limits of the request headers of an HTTP listener.
+// +k8s:deepcopy-gen=true
+type HeaderLimits struct {
+	// MaxRequestHeadersKiB is the maximum total size of the request headers,
+	// in KiB. If zero, the Envoy default applies.
+	MaxRequestHeadersKiB uint32
+	// MaxRequestHeadersCount is the maximum number of request headers. If
+	// zero, the Envoy default applies.
+	MaxRequestHeadersCount uint32
+}
+
+// Validate the fields within the HeaderLimits structure
+func (h HeaderLimits) Validate() error {
+	var errs error
+	if h.MaxRequestHeadersKiB > 8192 {
+		errs = multierror.Append(errs, ErrHeaderLimitsKiBInvalid)
+	}
+	return errs
+}
+
+// Draining holds the connection draining configuration of an HTTP listener.
+// +k8s:deepcopy-gen=true
+type Draining struct {
+	// ModifyOnly drains the connections only when the listener is updated or
+	// removed, and not when the proxy is shutting down.
+	ModifyOnly bool
+	// Timeout is the time given to the draining connections to close
+	// gracefully before being closed forcibly. If unset, the Envoy default
+	// applies.
+	Timeout *metav1.Duration
+}
+
+// HTTP1Settings holds the settings of the HTTP/1 connections of an HTTP
+// listener.
+// +k8s:deepcopy-gen=true
+type HTTP1Settings struct {
+	// EnableHTTP10 accepts the HTTP/1.0 requests.
+	EnableHTTP10 bool
+	// HTTP10DefaultHost is the host of the HTTP/1.0 requests without a Host
+	// header. If empty, these requests are rejected.
+	HTTP10DefaultHost string
+}
+
+// DuplicateHeadersAction selects how the requests with duplicate headers are
+// handled.
+type DuplicateHeadersAction string
+
+const (
+	// ConcatenateDuplicateHeaders concatenates the values of the duplicate
+	// headers into a single header.
+	ConcatenateDuplicateHeaders DuplicateHeadersAction = "Concatenate"
+	// RejectDuplicateHeaders rejects the requests with duplicate headers.
+	RejectDuplicateHeaders DuplicateHeadersAction = "Reject"
+)
+
+// HeaderHandling holds how an HTTP listener handles the headers of the
+// requests and responses.
+// +k8s:deepcopy-gen=true
+type HeaderHandling struct {
+	// ProperCaseHeaders writes the header names of the HTTP/1 responses in
+	// proper case.
+	ProperCaseHeaders bool
+	// DuplicateHeaders selects how the requests with duplicate headers are
+	// handled. If empty, the duplicate headers are forwarded as is.
+	DuplicateHeaders DuplicateHeadersAction
+	// HopByHopHeaders are the names of the additional headers stripped from
+	// the requests and responses.
+	HopByHopHeaders []string
+}
+
+// SocketOptions holds the options of the sockets of an HTTP listener.
+// +k8s:deepcopy-gen=true
+type SocketOptions struct {
+	// ReusePort binds a listening socket per worker thread with SO_REUSEPORT.
+	// If unset, the Envoy default applies.
+	ReusePort *bool
+	// TCPFastOpenQueueLength enables TCP Fast Open with the provided queue
+	// length if non-zero.
+	TCPFastOpenQueueLength uint32
+	// Freebind binds the listening sockets with IP_FREEBIND.
+	Freebind bool
+	// DSCP marks the packets sent to the clients with the provided
+	// Differentiated Services Code Point. If unset, the packets are not
+	// marked.
+	DSCP *uint32
+}
+
+// Validate the fields within the SocketOptions structure
+func (s SocketOptions) Validate() error {
+	var errs error
+	if s.DSCP != nil && *s.DSCP > 63 {
+		errs = multierror.Append(errs, ErrSocketOptionsDSCPInvalid)
+	}
+	return errs
+}
+
+// Maintenance holds the responses of the requests to an HTTP listener in
+// maintenance mode.
+// +k8s:deepcopy-gen=true
+type Maintenance struct {
+	// Body of the 503 responses. If unset, the responses have no body.
+	Body *string
+	// ContentType of the body. If unset, defaults to text/plain.
+	ContentType string
+}
+
+// FilterPosition holds the position of an HTTP filter relative to another HTTP filter.
+// +k8s:deepcopy-gen=true
+type FilterPosition struct {
+	// Name of the HTTP filter to move.
+	Name string
+	// Before is the name of the HTTP filter to place the filter before.
+	Before *string
+	// After is the name of the HTTP filter to place the filter after.
+	After *string
+}
+
+// Validate the fields within the FilterPosition structure
+func (f FilterPosition) Validate() error {
+	var errs error
+	if f.Name == "" {
+		errs = multierror.Append(errs, ErrFilterPositionNameEmpty)
+	}
+	if (f.Before == nil) == (f.After == nil) {
+		errs = multierror.Append(errs, ErrFilterPositionInvalid)
+	}
+	return errs
+}
+
+// RateLimitService holds the address of the global rate limit service.
+// +k8s:deepcopy-gen=true
+type RateLimitService struct {
+	// Host of the rate limit service, either a hostname or an IP address.
+	Host string
+	// Port of the rate limit service gRPC server.
+	Port uint32
+	// Domain of the rate limit descriptors sent to the rate limit service.
+	Domain string
+	// FailClosed rejects requests when the rate limit service is unreachable
+	// or fails, instead of allowing them.
+	FailClosed bool
+}
+
+// Validate the fields within the RateLimitService structure
+func (r RateLimitService) Validate() error {
+	var errs error
+	if r.Host == "" {
+		errs = multierror.Append(errs, ErrRateLimitServiceHostEmpty)
+	}
+	if r.Port == 0 {
+		errs = multierror.Append(errs, ErrRateLimitServicePortInvalid)
+	}
+	if r.Domain == "" {
+		errs = multierror.Append(errs, ErrRateLimitServiceDomainEmpty)
+	}
 	return errs
 }
 
@@ -162,8 +416,31 @@ type TLSListenerConfig struct {
 	ServerCertificate []byte
 	// PrivateKey for the server.
 	PrivateKey []byte
+	// OCSPStaple is the DER encoded OCSP response stapled to the
+	// ServerCertificate during the handshake. If unset, no staple is sent.
+	OCSPStaple []byte
+	// OCSPStaplePolicy defines how the OCSPStaple is used. If unset, it
+	// defaults to LenientStapling.
+	OCSPStaplePolicy OCSPStaplePolicy
 }
 
+// OCSPStaplePolicy defines how the OCSP staple of a listener certificate is
+// used, following the Envoy OCSP staple policies.
+type OCSPStaplePolicy string
+
+const (
+	// OCSPStaplePolicyLenient staples the OCSP response if it is valid, and
+	// omits it otherwise, e.g. once it expired.
+	OCSPStaplePolicyLenient OCSPStaplePolicy = "LenientStapling"
+	// OCSPStaplePolicyStrict staples the OCSP response if it is valid, and
+	// fails the handshakes of certificates with the OCSP must-staple extension
+	// otherwise.
+	OCSPStaplePolicyStrict OCSPStaplePolicy = "StrictStapling"
+	// OCSPStaplePolicyMustStaple requires a valid OCSP response, failing the
+	// handshakes once it expired.
+	OCSPStaplePolicyMustStaple OCSPStaplePolicy = "MustStaple"
+)
+
 // Validate the fields within the TLSListenerConfig structure
 func (t TLSListenerConfig) Validate() error {
 	var errs error
@@ -173,6 +450,15 @@ func (t TLSListenerConfig) Validate() error {
 	if len(t.PrivateKey) == 0 {
 		errs = multierror.Append(errs, ErrTLSPrivateKey)
 	}
+	switch t.OCSPStaplePolicy {
+	case "", OCSPStaplePolicyLenient, OCSPStaplePolicyStrict:
+	case OCSPStaplePolicyMustStaple:
+		if len(t.OCSPStaple) == 0 {
+			errs = multierror.Append(errs, ErrTLSOCSPStapleRequired)
+		}
+	default:
+		errs = multierror.Append(errs, ErrTLSOCSPStaplePolicyInvalid)
+	}
 	return errs
 }
 
@@ -199,12 +485,56 @@ type HTTPRoute struct {
 	AddRequestHeaders []AddHeader
 	// RemoveRequestHeaders defines a list of headers to be removed from requests.
 	RemoveRequestHeaders []string
+	// AddResponseHeaders defines header/value sets to be added to the headers of responses.
+	AddResponseHeaders []AddHeader
 	// Direct responses to be returned for this route. Takes precedence over Destinations and Redirect.
 	DirectResponse *DirectResponse
 	// Redirections to be returned for this route. Takes precedence over Destinations.
 	Redirect *Redirect
 	// Destinations associated with this matched route.
 	Destinations []*RouteDestination
+	// Timeout is the timeout of the requests, from the end of the downstream
+	// request to the end of the upstream response. If unset, the Envoy default
+	// applies.
+	Timeout *metav1.Duration
+	// ConnectTimeout is the timeout of establishing connections to the
+	// destinations. If unset, defaults to 5s.
+	ConnectTimeout *metav1.Duration
+	// BackendTLS configures TLS on the connections to the destinations. If
+	// unset, the connections are not encrypted.
+	BackendTLS *BackendTLSConfig
+	// LoadBalancer configures the load balancing of the requests across the
+	// destinations. If unset, the requests are balanced round-robin.
+	LoadBalancer *LoadBalancer
+	// DynamicForwardProxy forwards the requests to the hosts they are
+	// addressed to instead of the destinations, which must be empty.
+	DynamicForwardProxy *DynamicForwardProxy
+	// JWT configures the authentication and authorization of the requests
+	// with JSON Web Tokens. If unset, the requests are not authenticated.
+	JWT *JWT
+	// ExtAuth configures the authorization of the requests by an external
+	// service. If unset, the requests are not authorized externally.
+	ExtAuth *ExtAuth
+	// CSRF enables the protection against cross-site request forgery. If
+	// unset, the requests are not checked.
+	CSRF *CSRF
+	// Transformation transforms the requests forwarded to the destinations
+	// and their responses. If unset, they are forwarded unchanged.
+	Transformation *Transformation
+	// Mirrors mirror the requests to other destinations, whose responses are
+	// discarded. If empty, the requests are not mirrored.
+	Mirrors []*Mirror
+	// BandwidthLimit caps the throughput of the requests and responses. If
+	// unset, the throughput is not limited.
+	BandwidthLimit *BandwidthLimit
+	// RuntimeWeightsKeyPrefix is the prefix of the runtime keys the weights
+	// of the destinations are read from, in which case the requests are split
+	// across a cluster per destination. If empty, the weights are not read
+	// from the runtime.
+	RuntimeWeightsKeyPrefix string
+	// ExtProc configures the processing of the requests and responses by an
+	// external service. If unset, they are not processed externally.
+	ExtProc *ExtProc
 }
 
 // Validate the fields within the HTTPRoute structure
@@ -236,6 +566,43 @@ func (h HTTPRoute) Validate() error {
 			errs = multierror.Append(errs, err)
 		}
 	}
+	if h.BackendTLS != nil {
+		if err := h.BackendTLS.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.DynamicForwardProxy != nil && len(h.Destinations) > 0 {
+		errs = multierror.Append(errs, ErrDynamicForwardProxyDests)
+	}
+	if h.JWT != nil {
+		if err := h.JWT.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.ExtAuth != nil {
+		if err := h.ExtAuth.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.ExtProc != nil {
+		if err := h.ExtProc.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	if h.CSRF != nil {
+		for _, origin := range h.CSRF.AdditionalOrigins {
+			if err := origin.Validate(); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+	}
+	for _, mirror := range h.Mirrors {
+		for _, dest := range mirror.Destinations {
+			if err := dest.Validate(); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+	}
 	if h.Redirect != nil {
 		if err := h.Redirect.Validate(); err != nil {
 			errs = multierror.Append(errs, err)
@@ -260,6 +627,20 @@ func (h HTTPRoute) Validate() error {
 			}
 		}
 	}
+	if len(h.AddResponseHeaders) > 0 {
+		occurred := map[string]bool{}
+		for _, header := range h.AddResponseHeaders {
+			if err := header.Validate(); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+			if !occurred[header.Name] {
+				occurred[header.Name] = true
+			} else {
+				errs = multierror.Append(errs, ErrAddHeaderDuplicate)
+				break
+			}
+		}
+	}
 	if len(h.RemoveRequestHeaders) > 0 {
 		occurred := map[string]bool{}
 		for _, header := range h.RemoveRequestHeaders {
@@ -275,6 +656,7 @@ func (h HTTPRoute) Validate() error {
 }
 
 // RouteDestination holds the destination details associated with the route
+// +k8s:deepcopy-gen=true
 type RouteDestination struct {
 	// Host refers to the FQDN or IP address of the backend service.
 	Host string
@@ -282,22 +664,426 @@ type RouteDestination struct {
 	Port uint32
 	// Weight associated with this destination.
 	Weight uint32
+	// Priority is the priority of the destination. Requests are only
+	// forwarded to the destinations of a priority once the ones of the lower
+	// priorities are unhealthy. Defaults to 0, the highest priority.
+	Priority uint32
+	// AddRequestHeaders defines header/value sets to be added to the headers of
+	// requests forwarded to this destination.
+	AddRequestHeaders []AddHeader
+	// RemoveRequestHeaders defines a list of headers to be removed from requests
+	// forwarded to this destination.
+	RemoveRequestHeaders []string
+	// HostRewrite is the value the Host header of the requests forwarded to this
+	// destination is rewritten to. If unset, the Host header is not rewritten.
+	HostRewrite string
+	// HashKey is the key the destination is hashed on by the consistent
+	// hashing load balancers. If unset, the destination is hashed on its
+	// address.
+	HashKey string
+	// InternalListener is the name of the HTTP listener the connections are
+	// forwarded to through an Envoy internal listener, instead of Host and
+	// Port, so that they are processed as HTTP requests by its routes once,
+	// e.g., TLS is terminated.
+	InternalListener string
+	// Draining is true if the destination is drained, in which case it
+	// receives no new requests while the requests in flight complete.
+	Draining bool
+}
+
+// LoadBalancerType is the type of a load balancer.
+type LoadBalancerType string
+
+const (
+	// LeastRequestLoadBalancer selects the destination with the fewest active
+	// requests out of two random ones.
+	LeastRequestLoadBalancer LoadBalancerType = "LeastRequest"
+	// RandomLoadBalancer selects a random destination.
+	RandomLoadBalancer LoadBalancerType = "Random"
+	// RingHashLoadBalancer consistently hashes the requests with a ring.
+	RingHashLoadBalancer LoadBalancerType = "RingHash"
+	// MaglevLoadBalancer consistently hashes the requests with a Maglev
+	// lookup table.
+	MaglevLoadBalancer LoadBalancerType = "Maglev"
+)
+
+// LoadBalancer holds the load balancing configuration of the destinations of
+// a route.
+// +k8s:deepcopy-gen=true
+type LoadBalancer struct {
+	// Type is the type of the load balancer. If empty, the requests are
+	// balanced round-robin.
+	Type LoadBalancerType
+	// HashHeader is the name of the request header hashed by the consistent
+	// hashing load balancers. If empty, the source IP is hashed.
+	HashHeader string
+	// TableSize is the size of the lookup table of the Maglev load balancer.
+	TableSize *uint64
+	// MinimumRingSize is the minimum size of the ring of the RingHash load
+	// balancer.
+	MinimumRingSize *uint64
+	// MaximumRingSize is the maximum size of the ring of the RingHash load
+	// balancer.
+	MaximumRingSize *uint64
+}
+
+// DynamicForwardProxy holds the configuration of a route forwarding the
+// requests to the hosts they are addressed to, resolved with DNS.
+// +k8s:deepcopy-gen=true
+type DynamicForwardProxy struct {
+	// HostHeader is the name of the request header holding the host the
+	// requests are forwarded to. If empty, the Host header is used.
+	HostHeader string
+	// DNSRefreshRate is the interval at which the cached hosts are resolved
+	// again. If unset, the Envoy default applies.
+	DNSRefreshRate *metav1.Duration
+	// DNSHostTTL is the time after which the unused hosts are removed from the
+	// DNS cache. If unset, the Envoy default applies.
+	DNSHostTTL *metav1.Duration
+	// DNSMaxHosts is the maximum number of hosts in the DNS cache. If zero,
+	// the Envoy default applies.
+	DNSMaxHosts uint32
+}
+
+// IsConsistentHash returns whether the load balancer consistently hashes the
+// requests to the destinations.
+func (l *LoadBalancer) IsConsistentHash() bool {
+	return l != nil && (l.Type == RingHashLoadBalancer || l.Type == MaglevLoadBalancer)
 }
 
 // Validate the fields within the RouteDestination structure
 func (r RouteDestination) Validate() error {
 	var errs error
-	// Only support IP hosts for now
-	if ip := net.ParseIP(r.Host); ip == nil {
-		errs = multierror.Append(errs, ErrRouteDestinationHostInvalid)
+	if r.InternalListener != "" {
+		if r.Host != "" || r.Port != 0 {
+			errs = multierror.Append(errs, ErrInternalListenerDestAddress)
+		}
+	} else {
+		// Only support IP hosts for now
+		if ip := net.ParseIP(r.Host); ip == nil {
+			errs = multierror.Append(errs, ErrRouteDestinationHostInvalid)
+		}
+		if r.Port == 0 {
+			errs = multierror.Append(errs, ErrRouteDestinationPortInvalid)
+		}
 	}
-	if r.Port == 0 {
-		errs = multierror.Append(errs, ErrRouteDestinationPortInvalid)
+	for _, header := range r.AddRequestHeaders {
+		if err := header.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
 	}
 
 	return errs
 }
 
+// BackendTLSConfig holds the configuration of the TLS connections to the
+// destinations of a route.
+// +k8s:deepcopy-gen=true
+type BackendTLSConfig struct {
+	// ClientCertificate presented to the destinations requiring client
+	// authentication. If unset, no client certificate is presented.
+	ClientCertificate []byte
+	// PrivateKey of the client certificate.
+	PrivateKey []byte
+	// CACertificate used to verify the certificates of the destinations. If
+	// unset, the certificates are not verified.
+	CACertificate []byte
+	// SNI is the server name sent to the destinations during the handshake.
+	SNI string
+	// SPIFFE presents the SVID of the proxy and verifies the destinations
+	// against the SPIFFE trust bundle, both fetched from the Workload API.
+	// ClientCertificate, PrivateKey and CACertificate are then ignored.
+	SPIFFE bool
+	// ALPNProtocols are the application protocols offered to the
+	// destinations during the handshake, in order of preference. If both h2
+	// and http/1.1 are offered, the requests are forwarded with the protocol
+	// negotiated with each destination.
+	ALPNProtocols []string
+}
+
+// AutoHTTPProtocol returns true if the protocol of the requests forwarded to
+// the destinations is negotiated with ALPN.
+func (b *BackendTLSConfig) AutoHTTPProtocol() bool {
+	if b == nil {
+		return false
+	}
+	var h2, http11 bool
+	for _, protocol := range b.ALPNProtocols {
+		switch protocol {
+		case "h2":
+			h2 = true
+		case "http/1.1":
+			http11 = true
+		}
+	}
+	return h2 && http11
+}
+
+// Validate the fields within the BackendTLSConfig structure
+func (b BackendTLSConfig) Validate() error {
+	var errs error
+	if (len(b.ClientCertificate) == 0) != (len(b.PrivateKey) == 0) {
+		errs = multierror.Append(errs, ErrBackendTLSClientCertInvalid)
+	}
+	return errs
+}
+
+// JWT holds the JWT providers the requests to a route are authenticated with,
+// and the claims the verified JWT must hold for the requests to be authorized.
+// +k8s:deepcopy-gen=true
+type JWT struct {
+	// Providers verifying the JWT of the requests. Requests without a JWT
+	// verified by any of them are rejected.
+	Providers []JWTProvider
+	// Authorization defines the scopes and claims the verified JWT must hold.
+	// If unset, all the authenticated requests are authorized.
+	Authorization *JWTAuthorization
+}
+
+// Validate the fields within the JWT structure
+func (j JWT) Validate() error {
+	var errs error
+	if len(j.Providers) == 0 {
+		errs = multierror.Append(errs, ErrJWTProvidersEmpty)
+	}
+	names := map[string]bool{}
+	for _, provider := range j.Providers {
+		if err := provider.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+		if names[provider.Name] {
+			errs = multierror.Append(errs, ErrJWTProviderNameDuplicate)
+		}
+		names[provider.Name] = true
+	}
+	if j.Authorization != nil {
+		for _, claim := range j.Authorization.Claims {
+			if claim.Name == "" || len(claim.Values) == 0 {
+				errs = multierror.Append(errs, ErrJWTClaimInvalid)
+			}
+		}
+	}
+	return errs
+}
+
+// JWTProvider defines how the JWTs of an issuer are verified.
+// +k8s:deepcopy-gen=true
+type JWTProvider struct {
+	// Name of the provider, unique within the route. The payload of the
+	// verified JWT is stored in the dynamic metadata under this name.
+	Name string
+	// Issuer is the expected iss claim. If unset, it is not verified.
+	Issuer string
+	// Audiences are the allowed aud claims. If unset, it is not verified.
+	Audiences []string
+	// JWKSURI is the HTTP or HTTPS URI the JSON Web Key Set is fetched from.
+	JWKSURI string
+}
+
+// Validate the fields within the JWTProvider structure
+func (p JWTProvider) Validate() error {
+	var errs error
+	if p.Name == "" {
+		errs = multierror.Append(errs, ErrJWTProviderNameEmpty)
+	}
+	if u, err := url.Parse(p.JWKSURI); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		errs = multierror.Append(errs, ErrJWTProviderJWKSURIInvalid)
+	}
+	return errs
+}
+
+// JWTAuthorization defines the scopes and claims a verified JWT must hold.
+// +k8s:deepcopy-gen=true
+type JWTAuthorization struct {
+	// Scopes the space-delimited scope claim must include.
+	Scopes []string
+	// Claims the JWT must hold.
+	Claims []JWTClaim
+}
+
+// JWTClaim defines the allowed values of a top-level JWT claim.
+// +k8s:deepcopy-gen=true
+type JWTClaim struct {
+	// Name of the claim.
+	Name string
+	// Values allowed for the claim, or included by a list claim.
+	Values []string
+}
+
+// ExtAuth holds the external authorization service consulted for the requests
+// to a route, and the context of the requests forwarded to it.
+// +k8s:deepcopy-gen=true
+type ExtAuth struct {
+	// Name identifies the configuration of the authorization service, e.g.
+	// the SecurityPolicy it is configured by, so that the routes with the
+	// same configuration share its filter. If unset, the configuration is
+	// only used by its route.
+	Name string
+	// Destination of the authorization service.
+	Destination *RouteDestination
+	// GRPC configures a service implementing the Envoy gRPC Authorization API.
+	GRPC *GRPCExtAuth
+	// HTTP configures a service receiving a HTTP request per authorized request.
+	HTTP *HTTPExtAuth
+	// FailOpen allows the requests when the service is unreachable or fails.
+	FailOpen bool
+	// Body configures the forwarding of the request body. If unset, the body
+	// is not forwarded.
+	Body *ExtAuthBody
+}
+
+// Validate the fields within the ExtAuth structure
+func (e ExtAuth) Validate() error {
+	var errs error
+	if (e.GRPC == nil) == (e.HTTP == nil) {
+		errs = multierror.Append(errs, ErrExtAuthServiceInvalid)
+	}
+	if e.Destination == nil {
+		errs = multierror.Append(errs, ErrExtAuthDestinationEmpty)
+	} else if err := e.Destination.Validate(); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	if e.Body != nil && e.Body.MaxRequestBytes == 0 {
+		errs = multierror.Append(errs, ErrExtAuthBodyMaxBytesInvalid)
+	}
+	return errs
+}
+
+// GRPCExtAuth holds the context forwarded to a gRPC authorization service.
+// +k8s:deepcopy-gen=true
+type GRPCExtAuth struct {
+	// MetadataNamespaces of the dynamic metadata forwarded to the service.
+	MetadataNamespaces []string
+}
+
+// HTTPExtAuth holds the context forwarded to a HTTP authorization service,
+// and the headers of its responses forwarded to the destinations.
+// +k8s:deepcopy-gen=true
+type HTTPExtAuth struct {
+	// Path prefix of the requests sent to the service.
+	Path string
+	// HeadersToExtAuth are the request headers forwarded to the service.
+	HeadersToExtAuth []string
+	// HeadersToBackend are the response headers forwarded to the destinations.
+	HeadersToBackend []string
+}
+
+// ExtAuthBody defines how the request body is forwarded to the authorization
+// service.
+// +k8s:deepcopy-gen=true
+type ExtAuthBody struct {
+	// MaxRequestBytes is the maximum size of the forwarded body.
+	MaxRequestBytes uint32
+	// AllowPartialMessage forwards the beginning of larger bodies instead of
+	// rejecting the requests.
+	AllowPartialMessage bool
+}
+
+// ExtProc holds the external processing service the headers of the requests
+// and responses of a route are sent to.
+// +k8s:deepcopy-gen=true
+type ExtProc struct {
+	// Destination of the processing service, implementing the Envoy gRPC
+	// External Processing API.
+	Destination *RouteDestination
+	// FailOpen forwards the requests and responses unprocessed when the
+	// service is unreachable or fails.
+	FailOpen bool
+}
+
+// Validate the fields within the ExtProc structure
+func (e ExtProc) Validate() error {
+	if e.Destination == nil {
+		return ErrExtProcDestinationEmpty
+	}
+	return e.Destination.Validate()
+}
+
+// CSRF holds the origins allowed to send requests with an unsafe method,
+// besides the destination of the requests.
+// +k8s:deepcopy-gen=true
+type CSRF struct {
+	// AdditionalOrigins matching the host of the Origin of the requests.
+	AdditionalOrigins []*StringMatch
+}
+
+// Transformation holds the transformations of the requests and responses of
+// a route, applied in the order of the fields.
+// +k8s:deepcopy-gen=true
+type Transformation struct {
+	// HeadersToQuery copies request headers into query parameters.
+	HeadersToQuery []HeaderQueryMapping
+	// QueryToHeaders copies query parameters into request headers.
+	QueryToHeaders []HeaderQueryMapping
+	// RequestBody is the template of the body of the requests. If unset,
+	// the body of the requests is unchanged.
+	RequestBody *BodyTemplate
+	// ResponseBody is the template of the body of the responses. If unset,
+	// the body of the responses is unchanged.
+	ResponseBody *BodyTemplate
+}
+
+// HeaderQueryMapping holds the copy of a request header into a query
+// parameter, or the other way around.
+// +k8s:deepcopy-gen=true
+type HeaderQueryMapping struct {
+	// Header is the name of the request header.
+	Header string
+	// QueryParam is the name of the query parameter.
+	QueryParam string
+	// Remove removes the source of the copy.
+	Remove bool
+}
+
+// BodyTemplate holds a body template, rendered by concatenating its parts.
+// +k8s:deepcopy-gen=true
+type BodyTemplate struct {
+	Parts []TemplatePart
+}
+
+// TemplatePart holds a part of a body template. Exactly one of the fields is
+// set, except for empty literals.
+// +k8s:deepcopy-gen=true
+type TemplatePart struct {
+	// Literal is rendered as is.
+	Literal string
+	// Body is substituted with the original body.
+	Body bool
+	// Header is the name of the header whose value is substituted.
+	Header string
+	// QueryParam is the name of the request query parameter whose value is
+	// substituted.
+	QueryParam string
+}
+
+// Mirror holds the destinations the requests of a route are mirrored to,
+// balanced across them.
+// +k8s:deepcopy-gen=true
+type Mirror struct {
+	// Destinations are the destinations the requests are mirrored to.
+	Destinations []*RouteDestination
+	// ShadowHeader is the name of the header added to the mirrored requests,
+	// whose value is the request ID of the original request. If set, the
+	// responses to the mirrored requests are also logged, otherwise the
+	// mirrored requests are neither tagged nor logged.
+	ShadowHeader string
+}
+
+// BandwidthLimit holds the throughput cap of the requests and responses of a
+// route, shared by all its requests.
+// +k8s:deepcopy-gen=true
+type BandwidthLimit struct {
+	// LimitKiBps is the maximum throughput, in KiB per second.
+	LimitKiBps uint64
+	// Request limits the throughput of the request bodies.
+	Request bool
+	// Response limits the throughput of the response bodies.
+	Response bool
+	// FillInterval is the interval at which the throughput budget is
+	// replenished. If unset, defaults to 50ms.
+	FillInterval *metav1.Duration
+}
+
 // Add header configures a headder to be added to a request.
 // +k8s:deepcopy-gen=true
 type AddHeader struct {
@@ -447,8 +1233,15 @@ type TCPListener struct {
 	// TLS information required for TLS Passthrough, If provided, incoming
 	// connections' server names are inspected and routed to backends accordingly.
 	TLS *TLSInspectorConfig
+	// TerminateTLS holds the certificate info used to terminate TLS connections.
+	// If provided, connections are decrypted before being forwarded to the backends.
+	TerminateTLS *TLSListenerConfig
 	// Destinations associated with TCP traffic to the service.
 	Destinations []*RouteDestination
+	// JSONAccessLog writes the access log entries of the connections as JSON
+	// objects, carrying the IDs of the connections, instead of the default
+	// text format of Envoy.
+	JSONAccessLog bool
 }
 
 // Validate the fields within the TCPListener structure
@@ -468,6 +1261,11 @@ func (h TCPListener) Validate() error {
 			errs = multierror.Append(errs, err)
 		}
 	}
+	if h.TerminateTLS != nil {
+		if err := h.TerminateTLS.Validate(); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
 	for _, route := range h.Destinations {
 		if err := route.Validate(); err != nil {
 			errs = multierror.Append(errs, err)
@@ -483,7 +1281,8 @@ type TLSInspectorConfig struct {
 	// Server names that are compared against the server names of a new connection.
 	// Wildcard hosts are supported in the prefix form. Partial wildcards are not
 	// supported, and values like *w.example.com are invalid.
-	// SNIs are used only in case of TLS Passthrough.
+	// SNIs are used in case of TLS Passthrough, or to select the listener
+	// terminating TLS connections.
 	SNIs []string
 }
 
@@ -506,6 +1305,57 @@ type UDPListener struct {
 	Port uint32
 	// Destinations associated with UDP traffic to the service.
 	Destinations []*RouteDestination
+	// LoadBalancer configures the load balancing of the sessions across the
+	// destinations. The consistent hashing load balancers hash the source IP
+	// of the sessions, so that the datagrams of a client stay pinned to a
+	// destination.
+	LoadBalancer *LoadBalancer
+	// IdleTimeout is the time after which a session with no datagrams is
+	// closed. If unset, the Envoy default of 60 seconds applies.
+	IdleTimeout *metav1.Duration
+	// DNS configures the listener to answer the DNS queries itself instead of
+	// proxying the datagrams. The queries of the domains without records are
+	// forwarded to the destinations, which are DNS resolvers.
+	DNS *DNSConfig
+	// JSONAccessLog writes the access log entries of the sessions as JSON
+	// objects instead of the default text format of Envoy.
+	JSONAccessLog bool
+}
+
+// DNSConfig holds the configuration of a UDP listener answering DNS queries.
+// +k8s:deepcopy-gen=true
+type DNSConfig struct {
+	// Records are the DNS records answered by the listener.
+	Records []DNSRecord
+	// TTL is the TTL of the answers. If unset, the Envoy default of 300
+	// seconds applies.
+	TTL *metav1.Duration
+}
+
+// DNSRecord holds the addresses a domain resolves to.
+// +k8s:deepcopy-gen=true
+type DNSRecord struct {
+	// Domain is the domain name of the record, e.g. "www.example.com".
+	Domain string
+	// Addresses are the IPv4 or IPv6 addresses the domain resolves to.
+	Addresses []string
+}
+
+// Validate the fields within the DNSRecord structure
+func (r DNSRecord) Validate() error {
+	var errs error
+	if r.Domain == "" {
+		errs = multierror.Append(errs, ErrDNSRecordDomainEmpty)
+	}
+	if len(r.Addresses) == 0 {
+		errs = multierror.Append(errs, ErrDNSRecordAddressInvalid)
+	}
+	for _, address := range r.Addresses {
+		if ip := net.ParseIP(address); ip == nil {
+			errs = multierror.Append(errs, ErrDNSRecordAddressInvalid)
+		}
+	}
+	return errs
 }
 
 // Validate the fields within the UDPListener structure
@@ -525,5 +1375,15 @@ func (h UDPListener) Validate() error {
 			errs = multierror.Append(errs, err)
 		}
 	}
+	if h.LoadBalancer != nil && h.LoadBalancer.HashHeader != "" {
+		errs = multierror.Append(errs, ErrUDPListenerHashHeader)
+	}
+	if h.DNS != nil {
+		for _, record := range h.DNS.Records {
+			if err := record.Validate(); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+	}
 	return errs
 }
@@ -88,6 +88,29 @@ var (
 		TLS:          &TLSInspectorConfig{SNIs: []string{}},
 		Destinations: []*RouteDestination{&happyRouteDestination},
 	}
+	happyTCPListener = TCPListener{
+		Name:         "happy",
+		Address:      "0.0.0.0",
+		Port:         80,
+		Destinations: []*RouteDestination{&happyRouteDestination},
+	}
+	happyTCPListenerTLSTerminate = TCPListener{
+		Name:    "happy",
+		Address: "0.0.0.0",
+		Port:    80,
+		TerminateTLS: &TLSListenerConfig{
+			ServerCertificate: []byte{1, 2, 3},
+			PrivateKey:        []byte{1, 2, 3},
+		},
+		Destinations: []*RouteDestination{&happyRouteDestination},
+	}
+	invalidCertTCPListenerTLSTerminate = TCPListener{
+		Name:         "invalid-cert",
+		Address:      "0.0.0.0",
+		Port:         80,
+		TerminateTLS: &TLSListenerConfig{},
+		Destinations: []*RouteDestination{&happyRouteDestination},
+	}
 
 	// UDPListener
 	happyUDPListener = UDPListener{
@@ -113,6 +136,22 @@ var (
 		Port:         0,
 		Destinations: []*RouteDestination{&happyRouteDestination},
 	}
+	hashHeaderUDPListener = UDPListener{
+		Name:         "hash-header",
+		Address:      "0.0.0.0",
+		Port:         80,
+		Destinations: []*RouteDestination{&happyRouteDestination},
+		LoadBalancer: &LoadBalancer{Type: RingHashLoadBalancer, HashHeader: "x-session"},
+	}
+	invalidDNSRecordUDPListener = UDPListener{
+		Name:         "invalid-dns-record",
+		Address:      "0.0.0.0",
+		Port:         53,
+		Destinations: []*RouteDestination{&happyRouteDestination},
+		DNS: &DNSConfig{
+			Records: []DNSRecord{{Addresses: []string{"www.example.com"}}},
+		},
+	}
 
 	// HTTPRoute
 	happyHTTPRoute = HTTPRoute{
@@ -397,6 +436,45 @@ func TestValidateHTTPListener(t *testing.T) {
 			input: invalidRouteMatchHTTPListener,
 			want:  []error{ErrHTTPRouteMatchEmpty},
 		},
+		{
+			name: "filter order",
+			input: HTTPListener{
+				Name:        "filter-order",
+				Address:     "0.0.0.0",
+				Port:        80,
+				Hostnames:   []string{"example.com"},
+				Routes:      []*HTTPRoute{&happyHTTPRoute},
+				FilterOrder: []*FilterPosition{{Name: "envoy.filters.http.lua", Before: ptrTo("envoy.filters.http.ext_authz")}},
+			},
+			want: nil,
+		},
+		{
+			name: "invalid filter order",
+			input: HTTPListener{
+				Name:      "invalid-filter-order",
+				Address:   "0.0.0.0",
+				Port:      80,
+				Hostnames: []string{"example.com"},
+				Routes:    []*HTTPRoute{&happyHTTPRoute},
+				FilterOrder: []*FilterPosition{
+					{Before: ptrTo("envoy.filters.http.ext_authz")},
+					{Name: "envoy.filters.http.lua"},
+				},
+			},
+			want: []error{ErrFilterPositionNameEmpty, ErrFilterPositionInvalid},
+		},
+		{
+			name: "invalid rate limit service",
+			input: HTTPListener{
+				Name:             "invalid-rate-limit-service",
+				Address:          "0.0.0.0",
+				Port:             80,
+				Hostnames:        []string{"example.com"},
+				Routes:           []*HTTPRoute{&happyHTTPRoute},
+				RateLimitService: &RateLimitService{},
+			},
+			want: []error{ErrRateLimitServiceHostEmpty, ErrRateLimitServicePortInvalid, ErrRateLimitServiceDomainEmpty},
+		},
 	}
 	for _, test := range tests {
 		test := test
@@ -439,6 +517,16 @@ func TestValidateTCPListener(t *testing.T) {
 			input: invalidSNITCPListenerTLSPassthrough,
 			want:  []error{ErrTCPListenesSNIsEmpty},
 		},
+		{
+			name:  "tls terminate happy",
+			input: happyTCPListenerTLSTerminate,
+			want:  nil,
+		},
+		{
+			name:  "tls terminate empty certificate",
+			input: invalidCertTCPListenerTLSTerminate,
+			want:  []error{ErrTLSServerCertEmpty, ErrTLSPrivateKey},
+		},
 	}
 	for _, test := range tests {
 		test := test
@@ -522,6 +610,16 @@ func TestValidateUDPListener(t *testing.T) {
 			input: invalidPortUDPListenerT,
 			want:  []error{ErrListenerPortInvalid},
 		},
+		{
+			name:  "udp hash header",
+			input: hashHeaderUDPListener,
+			want:  []error{ErrUDPListenerHashHeader},
+		},
+		{
+			name:  "udp invalid dns record",
+			input: invalidDNSRecordUDPListener,
+			want:  []error{ErrDNSRecordDomainEmpty, ErrDNSRecordAddressInvalid},
+		},
 	}
 	for _, test := range tests {
 		test := test
@@ -669,6 +767,23 @@ func TestValidateRouteDestination(t *testing.T) {
 			},
 			want: ErrRouteDestinationPortInvalid,
 		},
+		{
+			name: "internal listener",
+			input: RouteDestination{
+				InternalListener: "envoy-gateway-gateway-1-http",
+				Weight:           1,
+			},
+			want: nil,
+		},
+		{
+			name: "internal listener with address",
+			input: RouteDestination{
+				Host:             "10.11.12.13",
+				Port:             8080,
+				InternalListener: "envoy-gateway-gateway-1-http",
+			},
+			want: ErrInternalListenerDestAddress,
+		},
 	}
 	for _, test := range tests {
 		test := test
@@ -751,6 +866,15 @@ func TestPrintable(t *testing.T) {
 				HTTP: []*HTTPListener{&happyHTTPListener},
 			},
 		},
+		{
+			name: "tcp tls terminate",
+			input: Xds{
+				TCP: []*TCPListener{&happyTCPListenerTLSTerminate},
+			},
+			want: &Xds{
+				TCP: []*TCPListener{&happyTCPListener},
+			},
+		},
 	}
 	for _, test := range tests {
 		test := test
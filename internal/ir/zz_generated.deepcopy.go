@@ -12,6 +12,7 @@ package ir
 
 import (
 	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -29,6 +30,154 @@ func (in *AddHeader) DeepCopy() *AddHeader {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendTLSConfig) DeepCopyInto(out *BackendTLSConfig) {
+	*out = *in
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrivateKey != nil {
+		in, out := &in.PrivateKey, &out.PrivateKey
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CACertificate != nil {
+		in, out := &in.CACertificate, &out.CACertificate
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.ALPNProtocols != nil {
+		in, out := &in.ALPNProtocols, &out.ALPNProtocols
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendTLSConfig.
+func (in *BackendTLSConfig) DeepCopy() *BackendTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BandwidthLimit) DeepCopyInto(out *BandwidthLimit) {
+	*out = *in
+	if in.FillInterval != nil {
+		in, out := &in.FillInterval, &out.FillInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BandwidthLimit.
+func (in *BandwidthLimit) DeepCopy() *BandwidthLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(BandwidthLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BodyTemplate) DeepCopyInto(out *BodyTemplate) {
+	*out = *in
+	if in.Parts != nil {
+		in, out := &in.Parts, &out.Parts
+		*out = make([]TemplatePart, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BodyTemplate.
+func (in *BodyTemplate) DeepCopy() *BodyTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(BodyTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSRF) DeepCopyInto(out *CSRF) {
+	*out = *in
+	if in.AdditionalOrigins != nil {
+		in, out := &in.AdditionalOrigins, &out.AdditionalOrigins
+		*out = make([]*StringMatch, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(StringMatch)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSRF.
+func (in *CSRF) DeepCopy() *CSRF {
+	if in == nil {
+		return nil
+	}
+	out := new(CSRF)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSConfig) DeepCopyInto(out *DNSConfig) {
+	*out = *in
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = make([]DNSRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSConfig.
+func (in *DNSConfig) DeepCopy() *DNSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSRecord) DeepCopyInto(out *DNSRecord) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecord.
+func (in *DNSRecord) DeepCopy() *DNSRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DirectResponse) DeepCopyInto(out *DirectResponse) {
 	*out = *in
@@ -39,204 +188,751 @@ func (in *DirectResponse) DeepCopyInto(out *DirectResponse) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirectResponse.
-func (in *DirectResponse) DeepCopy() *DirectResponse {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DirectResponse.
+func (in *DirectResponse) DeepCopy() *DirectResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(DirectResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Draining) DeepCopyInto(out *Draining) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Draining.
+func (in *Draining) DeepCopy() *Draining {
+	if in == nil {
+		return nil
+	}
+	out := new(Draining)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynamicForwardProxy) DeepCopyInto(out *DynamicForwardProxy) {
+	*out = *in
+	if in.DNSRefreshRate != nil {
+		in, out := &in.DNSRefreshRate, &out.DNSRefreshRate
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DNSHostTTL != nil {
+		in, out := &in.DNSHostTTL, &out.DNSHostTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicForwardProxy.
+func (in *DynamicForwardProxy) DeepCopy() *DynamicForwardProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicForwardProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtAuth) DeepCopyInto(out *ExtAuth) {
+	*out = *in
+	if in.Destination != nil {
+		in, out := &in.Destination, &out.Destination
+		*out = new(RouteDestination)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(GRPCExtAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPExtAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Body != nil {
+		in, out := &in.Body, &out.Body
+		*out = new(ExtAuthBody)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtAuth.
+func (in *ExtAuth) DeepCopy() *ExtAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtAuthBody) DeepCopyInto(out *ExtAuthBody) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtAuthBody.
+func (in *ExtAuthBody) DeepCopy() *ExtAuthBody {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtAuthBody)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtProc) DeepCopyInto(out *ExtProc) {
+	*out = *in
+	if in.Destination != nil {
+		in, out := &in.Destination, &out.Destination
+		*out = new(RouteDestination)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtProc.
+func (in *ExtProc) DeepCopy() *ExtProc {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtProc)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterPosition) DeepCopyInto(out *FilterPosition) {
+	*out = *in
+	if in.Before != nil {
+		in, out := &in.Before, &out.Before
+		*out = new(string)
+		**out = **in
+	}
+	if in.After != nil {
+		in, out := &in.After, &out.After
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterPosition.
+func (in *FilterPosition) DeepCopy() *FilterPosition {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterPosition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCExtAuth) DeepCopyInto(out *GRPCExtAuth) {
+	*out = *in
+	if in.MetadataNamespaces != nil {
+		in, out := &in.MetadataNamespaces, &out.MetadataNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCExtAuth.
+func (in *GRPCExtAuth) DeepCopy() *GRPCExtAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCExtAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTP1Settings) DeepCopyInto(out *HTTP1Settings) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTP1Settings.
+func (in *HTTP1Settings) DeepCopy() *HTTP1Settings {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTP1Settings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPExtAuth) DeepCopyInto(out *HTTPExtAuth) {
+	*out = *in
+	if in.HeadersToExtAuth != nil {
+		in, out := &in.HeadersToExtAuth, &out.HeadersToExtAuth
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HeadersToBackend != nil {
+		in, out := &in.HeadersToBackend, &out.HeadersToBackend
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPExtAuth.
+func (in *HTTPExtAuth) DeepCopy() *HTTPExtAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPExtAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPListener) DeepCopyInto(out *HTTPListener) {
+	*out = *in
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IsolatedHostnames != nil {
+		in, out := &in.IsolatedHostnames, &out.IsolatedHostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSListenerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]*HTTPRoute, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(HTTPRoute)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.FilterOrder != nil {
+		in, out := &in.FilterOrder, &out.FilterOrder
+		*out = make([]*FilterPosition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(FilterPosition)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.RateLimitService != nil {
+		in, out := &in.RateLimitService, &out.RateLimitService
+		*out = new(RateLimitService)
+		**out = **in
+	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HeaderLimits != nil {
+		in, out := &in.HeaderLimits, &out.HeaderLimits
+		*out = new(HeaderLimits)
+		**out = **in
+	}
+	if in.Draining != nil {
+		in, out := &in.Draining, &out.Draining
+		*out = new(Draining)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(Maintenance)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SocketOptions != nil {
+		in, out := &in.SocketOptions, &out.SocketOptions
+		*out = new(SocketOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP1 != nil {
+		in, out := &in.HTTP1, &out.HTTP1
+		*out = new(HTTP1Settings)
+		**out = **in
+	}
+	if in.HeaderHandling != nil {
+		in, out := &in.HeaderHandling, &out.HeaderHandling
+		*out = new(HeaderHandling)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPListener.
+func (in *HTTPListener) DeepCopy() *HTTPListener {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPListener)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPPathModifier) DeepCopyInto(out *HTTPPathModifier) {
+	*out = *in
+	if in.FullReplace != nil {
+		in, out := &in.FullReplace, &out.FullReplace
+		*out = new(string)
+		**out = **in
+	}
+	if in.PrefixMatchReplace != nil {
+		in, out := &in.PrefixMatchReplace, &out.PrefixMatchReplace
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPPathModifier.
+func (in *HTTPPathModifier) DeepCopy() *HTTPPathModifier {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPPathModifier)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRoute) DeepCopyInto(out *HTTPRoute) {
+	*out = *in
+	if in.PathMatch != nil {
+		in, out := &in.PathMatch, &out.PathMatch
+		*out = new(StringMatch)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HeaderMatches != nil {
+		in, out := &in.HeaderMatches, &out.HeaderMatches
+		*out = make([]*StringMatch, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(StringMatch)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.QueryParamMatches != nil {
+		in, out := &in.QueryParamMatches, &out.QueryParamMatches
+		*out = make([]*StringMatch, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(StringMatch)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	out.BackendWeights = in.BackendWeights
+	if in.AddRequestHeaders != nil {
+		in, out := &in.AddRequestHeaders, &out.AddRequestHeaders
+		*out = make([]AddHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemoveRequestHeaders != nil {
+		in, out := &in.RemoveRequestHeaders, &out.RemoveRequestHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AddResponseHeaders != nil {
+		in, out := &in.AddResponseHeaders, &out.AddResponseHeaders
+		*out = make([]AddHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.DirectResponse != nil {
+		in, out := &in.DirectResponse, &out.DirectResponse
+		*out = new(DirectResponse)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Redirect != nil {
+		in, out := &in.Redirect, &out.Redirect
+		*out = new(Redirect)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]*RouteDestination, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RouteDestination)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ConnectTimeout != nil {
+		in, out := &in.ConnectTimeout, &out.ConnectTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.BackendTLS != nil {
+		in, out := &in.BackendTLS, &out.BackendTLS
+		*out = new(BackendTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LoadBalancer != nil {
+		in, out := &in.LoadBalancer, &out.LoadBalancer
+		*out = new(LoadBalancer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DynamicForwardProxy != nil {
+		in, out := &in.DynamicForwardProxy, &out.DynamicForwardProxy
+		*out = new(DynamicForwardProxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(JWT)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtAuth != nil {
+		in, out := &in.ExtAuth, &out.ExtAuth
+		*out = new(ExtAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CSRF != nil {
+		in, out := &in.CSRF, &out.CSRF
+		*out = new(CSRF)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Transformation != nil {
+		in, out := &in.Transformation, &out.Transformation
+		*out = new(Transformation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]*Mirror, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Mirror)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.BandwidthLimit != nil {
+		in, out := &in.BandwidthLimit, &out.BandwidthLimit
+		*out = new(BandwidthLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtProc != nil {
+		in, out := &in.ExtProc, &out.ExtProc
+		*out = new(ExtProc)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRoute.
+func (in *HTTPRoute) DeepCopy() *HTTPRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderHandling) DeepCopyInto(out *HeaderHandling) {
+	*out = *in
+	if in.HopByHopHeaders != nil {
+		in, out := &in.HopByHopHeaders, &out.HopByHopHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderHandling.
+func (in *HeaderHandling) DeepCopy() *HeaderHandling {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderHandling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderLimits) DeepCopyInto(out *HeaderLimits) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderLimits.
+func (in *HeaderLimits) DeepCopy() *HeaderLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderQueryMapping) DeepCopyInto(out *HeaderQueryMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderQueryMapping.
+func (in *HeaderQueryMapping) DeepCopy() *HeaderQueryMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderQueryMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Infra) DeepCopyInto(out *Infra) {
+	*out = *in
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyInfra)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Infra.
+func (in *Infra) DeepCopy() *Infra {
+	if in == nil {
+		return nil
+	}
+	out := new(Infra)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfraMetadata) DeepCopyInto(out *InfraMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraMetadata.
+func (in *InfraMetadata) DeepCopy() *InfraMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(InfraMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWT) DeepCopyInto(out *JWT) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]JWTProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = new(JWTAuthorization)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWT.
+func (in *JWT) DeepCopy() *JWT {
 	if in == nil {
 		return nil
 	}
-	out := new(DirectResponse)
+	out := new(JWT)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPListener) DeepCopyInto(out *HTTPListener) {
+func (in *JWTAuthorization) DeepCopyInto(out *JWTAuthorization) {
 	*out = *in
-	if in.Hostnames != nil {
-		in, out := &in.Hostnames, &out.Hostnames
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(TLSListenerConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Routes != nil {
-		in, out := &in.Routes, &out.Routes
-		*out = make([]*HTTPRoute, len(*in))
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make([]JWTClaim, len(*in))
 		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(HTTPRoute)
-				(*in).DeepCopyInto(*out)
-			}
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPListener.
-func (in *HTTPListener) DeepCopy() *HTTPListener {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTAuthorization.
+func (in *JWTAuthorization) DeepCopy() *JWTAuthorization {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPListener)
+	out := new(JWTAuthorization)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPPathModifier) DeepCopyInto(out *HTTPPathModifier) {
+func (in *JWTClaim) DeepCopyInto(out *JWTClaim) {
 	*out = *in
-	if in.FullReplace != nil {
-		in, out := &in.FullReplace, &out.FullReplace
-		*out = new(string)
-		**out = **in
-	}
-	if in.PrefixMatchReplace != nil {
-		in, out := &in.PrefixMatchReplace, &out.PrefixMatchReplace
-		*out = new(string)
-		**out = **in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPPathModifier.
-func (in *HTTPPathModifier) DeepCopy() *HTTPPathModifier {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTClaim.
+func (in *JWTClaim) DeepCopy() *JWTClaim {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPPathModifier)
+	out := new(JWTClaim)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPRoute) DeepCopyInto(out *HTTPRoute) {
+func (in *JWTProvider) DeepCopyInto(out *JWTProvider) {
 	*out = *in
-	if in.PathMatch != nil {
-		in, out := &in.PathMatch, &out.PathMatch
-		*out = new(StringMatch)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.HeaderMatches != nil {
-		in, out := &in.HeaderMatches, &out.HeaderMatches
-		*out = make([]*StringMatch, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(StringMatch)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
-	if in.QueryParamMatches != nil {
-		in, out := &in.QueryParamMatches, &out.QueryParamMatches
-		*out = make([]*StringMatch, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(StringMatch)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
-	out.BackendWeights = in.BackendWeights
-	if in.AddRequestHeaders != nil {
-		in, out := &in.AddRequestHeaders, &out.AddRequestHeaders
-		*out = make([]AddHeader, len(*in))
-		copy(*out, *in)
-	}
-	if in.RemoveRequestHeaders != nil {
-		in, out := &in.RemoveRequestHeaders, &out.RemoveRequestHeaders
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.DirectResponse != nil {
-		in, out := &in.DirectResponse, &out.DirectResponse
-		*out = new(DirectResponse)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Redirect != nil {
-		in, out := &in.Redirect, &out.Redirect
-		*out = new(Redirect)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Destinations != nil {
-		in, out := &in.Destinations, &out.Destinations
-		*out = make([]*RouteDestination, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(RouteDestination)
-				**out = **in
-			}
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTProvider.
+func (in *JWTProvider) DeepCopy() *JWTProvider {
+	if in == nil {
+		return nil
 	}
+	out := new(JWTProvider)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRoute.
-func (in *HTTPRoute) DeepCopy() *HTTPRoute {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenerPort) DeepCopyInto(out *ListenerPort) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerPort.
+func (in *ListenerPort) DeepCopy() *ListenerPort {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPRoute)
+	out := new(ListenerPort)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Infra) DeepCopyInto(out *Infra) {
+func (in *LoadBalancer) DeepCopyInto(out *LoadBalancer) {
 	*out = *in
-	if in.Proxy != nil {
-		in, out := &in.Proxy, &out.Proxy
-		*out = new(ProxyInfra)
-		(*in).DeepCopyInto(*out)
+	if in.TableSize != nil {
+		in, out := &in.TableSize, &out.TableSize
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.MinimumRingSize != nil {
+		in, out := &in.MinimumRingSize, &out.MinimumRingSize
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.MaximumRingSize != nil {
+		in, out := &in.MaximumRingSize, &out.MaximumRingSize
+		*out = new(uint64)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Infra.
-func (in *Infra) DeepCopy() *Infra {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancer.
+func (in *LoadBalancer) DeepCopy() *LoadBalancer {
 	if in == nil {
 		return nil
 	}
-	out := new(Infra)
+	out := new(LoadBalancer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InfraMetadata) DeepCopyInto(out *InfraMetadata) {
+func (in *Maintenance) DeepCopyInto(out *Maintenance) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Body != nil {
+		in, out := &in.Body, &out.Body
+		*out = new(string)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraMetadata.
-func (in *InfraMetadata) DeepCopy() *InfraMetadata {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Maintenance.
+func (in *Maintenance) DeepCopy() *Maintenance {
 	if in == nil {
 		return nil
 	}
-	out := new(InfraMetadata)
+	out := new(Maintenance)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ListenerPort) DeepCopyInto(out *ListenerPort) {
+func (in *Mirror) DeepCopyInto(out *Mirror) {
 	*out = *in
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]*RouteDestination, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RouteDestination)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerPort.
-func (in *ListenerPort) DeepCopy() *ListenerPort {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mirror.
+func (in *Mirror) DeepCopy() *Mirror {
 	if in == nil {
 		return nil
 	}
-	out := new(ListenerPort)
+	out := new(Mirror)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -261,6 +957,21 @@ func (in *ProxyInfra) DeepCopyInto(out *ProxyInfra) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SPIFFE != nil {
+		in, out := &in.SPIFFE, &out.SPIFFE
+		*out = new(v1alpha1.SPIFFE)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyInfra.
@@ -293,6 +1004,21 @@ func (in *ProxyListener) DeepCopy() *ProxyListener {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitService) DeepCopyInto(out *RateLimitService) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitService.
+func (in *RateLimitService) DeepCopy() *RateLimitService {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Redirect) DeepCopyInto(out *Redirect) {
 	*out = *in
@@ -333,6 +1059,56 @@ func (in *Redirect) DeepCopy() *Redirect {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteDestination) DeepCopyInto(out *RouteDestination) {
+	*out = *in
+	if in.AddRequestHeaders != nil {
+		in, out := &in.AddRequestHeaders, &out.AddRequestHeaders
+		*out = make([]AddHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemoveRequestHeaders != nil {
+		in, out := &in.RemoveRequestHeaders, &out.RemoveRequestHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteDestination.
+func (in *RouteDestination) DeepCopy() *RouteDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SocketOptions) DeepCopyInto(out *SocketOptions) {
+	*out = *in
+	if in.ReusePort != nil {
+		in, out := &in.ReusePort, &out.ReusePort
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DSCP != nil {
+		in, out := &in.DSCP, &out.DSCP
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SocketOptions.
+func (in *SocketOptions) DeepCopy() *SocketOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SocketOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StringMatch) DeepCopyInto(out *StringMatch) {
 	*out = *in
@@ -371,6 +1147,11 @@ func (in *TCPListener) DeepCopyInto(out *TCPListener) {
 		*out = new(TLSInspectorConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TerminateTLS != nil {
+		in, out := &in.TerminateTLS, &out.TerminateTLS
+		*out = new(TLSListenerConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Destinations != nil {
 		in, out := &in.Destinations, &out.Destinations
 		*out = make([]*RouteDestination, len(*in))
@@ -378,7 +1159,7 @@ func (in *TCPListener) DeepCopyInto(out *TCPListener) {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
 				*out = new(RouteDestination)
-				**out = **in
+				(*in).DeepCopyInto(*out)
 			}
 		}
 	}
@@ -427,6 +1208,11 @@ func (in *TLSListenerConfig) DeepCopyInto(out *TLSListenerConfig) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.OCSPStaple != nil {
+		in, out := &in.OCSPStaple, &out.OCSPStaple
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSListenerConfig.
@@ -439,6 +1225,56 @@ func (in *TLSListenerConfig) DeepCopy() *TLSListenerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplatePart) DeepCopyInto(out *TemplatePart) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplatePart.
+func (in *TemplatePart) DeepCopy() *TemplatePart {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplatePart)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Transformation) DeepCopyInto(out *Transformation) {
+	*out = *in
+	if in.HeadersToQuery != nil {
+		in, out := &in.HeadersToQuery, &out.HeadersToQuery
+		*out = make([]HeaderQueryMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.QueryToHeaders != nil {
+		in, out := &in.QueryToHeaders, &out.QueryToHeaders
+		*out = make([]HeaderQueryMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequestBody != nil {
+		in, out := &in.RequestBody, &out.RequestBody
+		*out = new(BodyTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseBody != nil {
+		in, out := &in.ResponseBody, &out.ResponseBody
+		*out = new(BodyTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Transformation.
+func (in *Transformation) DeepCopy() *Transformation {
+	if in == nil {
+		return nil
+	}
+	out := new(Transformation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UDPListener) DeepCopyInto(out *UDPListener) {
 	*out = *in
@@ -449,10 +1285,25 @@ func (in *UDPListener) DeepCopyInto(out *UDPListener) {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
 				*out = new(RouteDestination)
-				**out = **in
+				(*in).DeepCopyInto(*out)
 			}
 		}
 	}
+	if in.LoadBalancer != nil {
+		in, out := &in.LoadBalancer, &out.LoadBalancer
+		*out = new(LoadBalancer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(DNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UDPListener.
@@ -6,11 +6,34 @@
 package log
 
 import (
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+
 	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 )
 
+// debugLoggingPath is the path prefix of the debug endpoint of the log level
+// of each component, e.g. /debug/logging/xds.
+const debugLoggingPath = "/debug/logging/"
+
+// defaultComponent is the name of the level of the loggers of no component.
+const defaultComponent v1alpha1.LogComponent = "default"
+
+// components are the components of Envoy Gateway logging at their own level.
+var components = []v1alpha1.LogComponent{
+	v1alpha1.LogComponentProvider,
+	v1alpha1.LogComponentTranslator,
+	v1alpha1.LogComponentXds,
+	v1alpha1.LogComponentInfra,
+}
+
 func NewLogger() (logr.Logger, error) {
 	zap, err := zap.NewDevelopment()
 	if err != nil {
@@ -18,3 +41,85 @@ func NewLogger() (logr.Logger, error) {
 	}
 	return zapr.NewLogger(zap), nil
 }
+
+// Loggers are the structured loggers of the components of Envoy Gateway,
+// writing JSON lines to stderr. The level of each component can be adjusted
+// at runtime through the Handler.
+type Loggers struct {
+	encoder zapcore.Encoder
+	sink    zapcore.WriteSyncer
+	levels  map[v1alpha1.LogComponent]zap.AtomicLevel
+}
+
+// NewLoggers returns the loggers of the components at the levels of the
+// provided logging configuration, or at the default level if nil.
+func NewLoggers(logging *v1alpha1.EnvoyGatewayLogging) (*Loggers, error) {
+	l := &Loggers{
+		encoder: zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		sink:    zapcore.Lock(os.Stderr),
+		levels:  make(map[v1alpha1.LogComponent]zap.AtomicLevel),
+	}
+
+	level, err := atomicLevel(logging.GetLevel(defaultComponent))
+	if err != nil {
+		return nil, err
+	}
+	l.levels[defaultComponent] = level
+	for _, component := range components {
+		level, err := atomicLevel(logging.GetLevel(component))
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level of component %s: %w", component, err)
+		}
+		l.levels[component] = level
+	}
+	return l, nil
+}
+
+// atomicLevel returns an atomic level initialized to the provided level.
+func atomicLevel(level v1alpha1.LogLevel) (zap.AtomicLevel, error) {
+	atomic := zap.NewAtomicLevel()
+	if err := atomic.UnmarshalText([]byte(level)); err != nil {
+		return zap.AtomicLevel{}, err
+	}
+	return atomic, nil
+}
+
+// Logger returns the logger of the provided component, or of no component if
+// the component is unknown.
+func (l *Loggers) Logger(component v1alpha1.LogComponent) logr.Logger {
+	level, ok := l.levels[component]
+	if !ok {
+		component, level = defaultComponent, l.levels[defaultComponent]
+	}
+	core := zapcore.NewCore(l.encoder, l.sink, level)
+	logger := zap.New(core, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel))
+	if component != defaultComponent {
+		logger = logger.With(zap.String("component", string(component)))
+	}
+	return zapr.NewLogger(logger)
+}
+
+// Handler returns the HTTP handler of the debug endpoint of the log levels.
+// GET /debug/logging/ lists the components, and GET and PUT
+// /debug/logging/<component> get and set the level of a component, e.g.
+// with a {"level":"debug"} body.
+func (l *Loggers) Handler() http.Handler {
+	mux := http.NewServeMux()
+	names := make([]string, 0, len(l.levels))
+	for component, level := range l.levels {
+		names = append(names, string(component))
+		mux.Handle(debugLoggingPath+string(component), level)
+	}
+	sort.Strings(names)
+
+	mux.HandleFunc(debugLoggingPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != debugLoggingPath {
+			http.NotFound(w, r)
+			return
+		}
+		for _, name := range names {
+			fmt.Fprintf(w, "%s: %s\n", name, l.levels[v1alpha1.LogComponent(name)].Level())
+		}
+	})
+	return mux
+}
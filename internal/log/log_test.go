@@ -0,0 +1,60 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package log
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+func TestNewLoggers(t *testing.T) {
+	loggers, err := NewLoggers(&v1alpha1.EnvoyGatewayLogging{
+		Level: v1alpha1.LogLevelWarn,
+		Components: map[v1alpha1.LogComponent]v1alpha1.LogLevel{
+			v1alpha1.LogComponentXds: v1alpha1.LogLevelDebug,
+		},
+	})
+	require.NoError(t, err)
+
+	require.False(t, loggers.Logger("").Enabled())
+	require.False(t, loggers.Logger(v1alpha1.LogComponentProvider).Enabled())
+	require.True(t, loggers.Logger(v1alpha1.LogComponentXds).Enabled())
+	require.True(t, loggers.Logger(v1alpha1.LogComponentXds).V(1).Enabled())
+
+	_, err = NewLoggers(&v1alpha1.EnvoyGatewayLogging{Level: "verbose"})
+	require.Error(t, err)
+}
+
+func TestLoggersHandler(t *testing.T) {
+	loggers, err := NewLoggers(nil)
+	require.NoError(t, err)
+	handler := loggers.Handler()
+	logger := loggers.Logger(v1alpha1.LogComponentTranslator)
+	require.False(t, logger.V(1).Enabled())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/logging/", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "default: info\ninfra: info\nprovider: info\ntranslator: info\nxds: info\n", rec.Body.String())
+
+	// The level of the component is adjusted, including for the loggers
+	// already in use.
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/debug/logging/translator", strings.NewReader(`{"level":"debug"}`)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.True(t, logger.V(1).Enabled())
+	require.False(t, loggers.Logger(v1alpha1.LogComponentXds).V(1).Enabled())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/logging/unknown", nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
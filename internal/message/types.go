@@ -8,10 +8,12 @@ package message
 import (
 	"github.com/telepresenceio/watchable"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/types"
 	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/ir"
 	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
 )
@@ -22,15 +24,44 @@ type ProviderResources struct {
 	Gateways       watchable.Map[types.NamespacedName, *gwapiv1b1.Gateway]
 	HTTPRoutes     watchable.Map[types.NamespacedName, *gwapiv1b1.HTTPRoute]
 	TLSRoutes      watchable.Map[types.NamespacedName, *gwapiv1a2.TLSRoute]
+	TCPRoutes      watchable.Map[types.NamespacedName, *gwapiv1a2.TCPRoute]
+	UDPRoutes      watchable.Map[types.NamespacedName, *gwapiv1a2.UDPRoute]
 	Namespaces     watchable.Map[string, *corev1.Namespace]
 	Services       watchable.Map[types.NamespacedName, *corev1.Service]
+	// EndpointSlices holds the EndpointSlices of the Services, only watched
+	// when the backend readiness gating is enabled.
+	EndpointSlices watchable.Map[types.NamespacedName, *discoveryv1.EndpointSlice]
 	Secrets        watchable.Map[types.NamespacedName, *corev1.Secret]
 
 	ReferenceGrants watchable.Map[types.NamespacedName, *gwapiv1a2.ReferenceGrant]
 
+	// EnvoyProxies holds the EnvoyProxy referenced by the parametersRef
+	// of a GatewayClass, keyed by the GatewayClass name.
+	EnvoyProxies watchable.Map[string, *v1alpha1.EnvoyProxy]
+
+	BackendTrafficPolicies watchable.Map[types.NamespacedName, *v1alpha1.BackendTrafficPolicy]
+	ClientTrafficPolicies  watchable.Map[types.NamespacedName, *v1alpha1.ClientTrafficPolicy]
+	SecurityPolicies       watchable.Map[types.NamespacedName, *v1alpha1.SecurityPolicy]
+	TrafficShifts          watchable.Map[types.NamespacedName, *v1alpha1.TrafficShift]
+
 	GatewayStatuses   watchable.Map[types.NamespacedName, *gwapiv1b1.Gateway]
 	HTTPRouteStatuses watchable.Map[types.NamespacedName, *gwapiv1b1.HTTPRoute]
 	TLSRouteStatuses  watchable.Map[types.NamespacedName, *gwapiv1a2.TLSRoute]
+	TCPRouteStatuses  watchable.Map[types.NamespacedName, *gwapiv1a2.TCPRoute]
+	UDPRouteStatuses  watchable.Map[types.NamespacedName, *gwapiv1a2.UDPRoute]
+
+	BackendTrafficPolicyStatuses watchable.Map[types.NamespacedName, *v1alpha1.BackendTrafficPolicy]
+	ClientTrafficPolicyStatuses  watchable.Map[types.NamespacedName, *v1alpha1.ClientTrafficPolicy]
+	SecurityPolicyStatuses       watchable.Map[types.NamespacedName, *v1alpha1.SecurityPolicy]
+	TrafficShiftStatuses         watchable.Map[types.NamespacedName, *v1alpha1.TrafficShift]
+
+	// GatewayClassStatuses holds the ConfigurationValid condition of the
+	// GatewayClasses, summarizing the objects rejected by their translation.
+	GatewayClassStatuses watchable.Map[string, *gwapiv1b1.GatewayClass]
+
+	// GatewayTrafficStats holds the traffic statistics of the Gateways,
+	// aggregated from the stats pushed by their proxies.
+	GatewayTrafficStats watchable.Map[types.NamespacedName, *v1alpha1.GatewayTrafficStatsStatus]
 }
 
 func (p *ProviderResources) GetGatewayClasses() []*gwapiv1b1.GatewayClass {
@@ -78,6 +109,28 @@ func (p *ProviderResources) GetTLSRoutes() []*gwapiv1a2.TLSRoute {
 	return res
 }
 
+func (p *ProviderResources) GetTCPRoutes() []*gwapiv1a2.TCPRoute {
+	if p.TCPRoutes.Len() == 0 {
+		return nil
+	}
+	res := make([]*gwapiv1a2.TCPRoute, 0, p.TCPRoutes.Len())
+	for _, v := range p.TCPRoutes.LoadAll() {
+		res = append(res, v)
+	}
+	return res
+}
+
+func (p *ProviderResources) GetUDPRoutes() []*gwapiv1a2.UDPRoute {
+	if p.UDPRoutes.Len() == 0 {
+		return nil
+	}
+	res := make([]*gwapiv1a2.UDPRoute, 0, p.UDPRoutes.Len())
+	for _, v := range p.UDPRoutes.LoadAll() {
+		res = append(res, v)
+	}
+	return res
+}
+
 func (p *ProviderResources) GetNamespaces() []*corev1.Namespace {
 	if p.Namespaces.Len() == 0 {
 		return nil
@@ -101,6 +154,17 @@ func (p *ProviderResources) GetServices() []*corev1.Service {
 	return res
 }
 
+func (p *ProviderResources) GetEndpointSlices() []*discoveryv1.EndpointSlice {
+	if p.EndpointSlices.Len() == 0 {
+		return nil
+	}
+	res := make([]*discoveryv1.EndpointSlice, 0, p.EndpointSlices.Len())
+	for _, v := range p.EndpointSlices.LoadAll() {
+		res = append(res, v)
+	}
+	return res
+}
+
 func (p *ProviderResources) GetSecrets() []*corev1.Secret {
 	if p.Secrets.Len() == 0 {
 		return nil
@@ -123,6 +187,60 @@ func (p *ProviderResources) GetReferenceGrants() []*gwapiv1a2.ReferenceGrant {
 	return res
 }
 
+func (p *ProviderResources) GetBackendTrafficPolicies() []*v1alpha1.BackendTrafficPolicy {
+	if p.BackendTrafficPolicies.Len() == 0 {
+		return nil
+	}
+	res := make([]*v1alpha1.BackendTrafficPolicy, 0, p.BackendTrafficPolicies.Len())
+	for _, v := range p.BackendTrafficPolicies.LoadAll() {
+		res = append(res, v)
+	}
+	return res
+}
+
+func (p *ProviderResources) GetClientTrafficPolicies() []*v1alpha1.ClientTrafficPolicy {
+	if p.ClientTrafficPolicies.Len() == 0 {
+		return nil
+	}
+	res := make([]*v1alpha1.ClientTrafficPolicy, 0, p.ClientTrafficPolicies.Len())
+	for _, v := range p.ClientTrafficPolicies.LoadAll() {
+		res = append(res, v)
+	}
+	return res
+}
+
+func (p *ProviderResources) GetSecurityPolicies() []*v1alpha1.SecurityPolicy {
+	if p.SecurityPolicies.Len() == 0 {
+		return nil
+	}
+	res := make([]*v1alpha1.SecurityPolicy, 0, p.SecurityPolicies.Len())
+	for _, v := range p.SecurityPolicies.LoadAll() {
+		res = append(res, v)
+	}
+	return res
+}
+
+func (p *ProviderResources) GetTrafficShifts() []*v1alpha1.TrafficShift {
+	if p.TrafficShifts.Len() == 0 {
+		return nil
+	}
+	res := make([]*v1alpha1.TrafficShift, 0, p.TrafficShifts.Len())
+	for _, v := range p.TrafficShifts.LoadAll() {
+		res = append(res, v)
+	}
+	return res
+}
+
+// GetEnvoyProxy returns the EnvoyProxy referenced by the named GatewayClass,
+// or nil if the GatewayClass does not reference one.
+func (p *ProviderResources) GetEnvoyProxy(gatewayClassName string) *v1alpha1.EnvoyProxy {
+	ep, ok := p.EnvoyProxies.Load(gatewayClassName)
+	if !ok {
+		return nil
+	}
+	return ep
+}
+
 // XdsIR message
 type XdsIR struct {
 	watchable.Map[string, *ir.Xds]
@@ -137,3 +255,34 @@ type InfraIR struct {
 type Xds struct {
 	watchable.Map[string, *xdstypes.ResourceVersionTable]
 }
+
+// RejectedSnapshots message holds the rejections of the current snapshots of
+// the xDS IRs by one of their proxies, keyed by xDS IR, so that their updates
+// are handled first and the rejections are reported by the status of their
+// Gateways.
+type RejectedSnapshots struct {
+	watchable.Map[string, SnapshotRejection]
+}
+
+// SnapshotRejection describes the rejection of the current snapshot of an xDS
+// IR by one of its proxies.
+type SnapshotRejection struct {
+	// Node is the ID of the proxy.
+	Node string
+	// TypeURL is the type of the rejected resources.
+	TypeURL string
+	// Resource is the name of the rejected resource, or empty if unknown.
+	Resource string
+	// Message is the error message of the proxy.
+	Message string
+}
+
+// IsRejected returns whether the current snapshot of the xDS IR with the
+// provided key was rejected, false if m is nil.
+func (m *RejectedSnapshots) IsRejected(key string) bool {
+	if m == nil {
+		return false
+	}
+	_, ok := m.Load(key)
+	return ok
+}
@@ -22,18 +22,63 @@ type Update[K comparable, V any] watchable.Update[K, V]
 func HandleSubscription[K comparable, V any](
 	subscription <-chan watchable.Snapshot[K, V],
 	handle func(Update[K, V]),
+) {
+	HandleSubscriptionByPriority(subscription, nil, handle)
+}
+
+// HandleSubscriptionByPriority is like HandleSubscription, but calls the
+// given function for the updates of the keys for which prioritize returns
+// true before the other updates received at once, in their original order
+// otherwise. This way the keys whose data plane is broken recover first from
+// a burst of updates, e.g. when all the resources are resynced.
+func HandleSubscriptionByPriority[K comparable, V any](
+	subscription <-chan watchable.Snapshot[K, V],
+	prioritize func(K) bool,
+	handle func(Update[K, V]),
 ) {
 	if snapshot, ok := <-subscription; ok {
+		updates := make([]Update[K, V], 0, len(snapshot.State))
 		for k, v := range snapshot.State {
-			handle(Update[K, V]{
+			updates = append(updates, Update[K, V]{
 				Key:   k,
 				Value: v,
 			})
 		}
+		handleByPriority(updates, prioritize, handle)
 	}
 	for snapshot := range subscription {
+		updates := make([]Update[K, V], 0, len(snapshot.Updates))
 		for _, update := range snapshot.Updates {
-			handle(Update[K, V](update))
+			updates = append(updates, Update[K, V](update))
+		}
+		handleByPriority(updates, prioritize, handle)
+	}
+}
+
+// handleByPriority calls handle for the prioritized updates first, then for
+// the other ones.
+func handleByPriority[K comparable, V any](updates []Update[K, V], prioritize func(K) bool, handle func(Update[K, V])) {
+	if prioritize == nil {
+		for _, update := range updates {
+			handle(update)
+		}
+		return
+	}
+
+	// The priority of the keys is evaluated once, before handling any of
+	// the updates, since handling them may change it.
+	prioritized := make([]bool, len(updates))
+	for i, update := range updates {
+		prioritized[i] = prioritize(update.Key)
+	}
+	for i, update := range updates {
+		if prioritized[i] {
+			handle(update)
+		}
+	}
+	for i, update := range updates {
+		if !prioritized[i] {
+			handle(update)
 		}
 	}
 }
@@ -59,3 +59,25 @@ func TestHandleSubscriptionAlreadyInitialized(t *testing.T) {
 	assert.Equal(t, 2, storeCalls)
 	assert.Equal(t, 1, deleteCalls)
 }
+
+func TestHandleSubscriptionByPriority(t *testing.T) {
+	ch := make(chan watchable.Snapshot[string, any], 2)
+	ch <- watchable.Snapshot[string, any]{State: map[string]any{"foo": "bar"}}
+	ch <- watchable.Snapshot[string, any]{
+		Updates: []watchable.Update[string, any]{
+			{Key: "a", Value: "1"},
+			{Key: "b", Value: "2"},
+			{Key: "c", Value: "3"},
+			{Key: "d", Delete: true},
+		},
+	}
+	close(ch)
+
+	var keys []string
+	message.HandleSubscriptionByPriority[string, any](
+		ch,
+		func(key string) bool { return key == "c" || key == "d" },
+		func(update message.Update[string, any]) { keys = append(keys, update.Key) },
+	)
+	assert.Equal(t, []string{"foo", "c", "d", "a", "b"}, keys)
+}
@@ -0,0 +1,242 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/provider/utils"
+	"github.com/envoyproxy/gateway/internal/status"
+)
+
+type backendTrafficPolicyReconciler struct {
+	client        client.Client
+	log           logr.Logger
+	statusUpdater status.Updater
+
+	resources *message.ProviderResources
+}
+
+// newBackendTrafficPolicyController creates the backendtrafficpolicy controller from mgr.
+// The controller will be pre-configured to watch for BackendTrafficPolicy objects across
+// all namespaces.
+func newBackendTrafficPolicyController(mgr manager.Manager, cfg *config.Server, su status.Updater, resources *message.ProviderResources) error {
+	r := &backendTrafficPolicyReconciler{
+		client:        mgr.GetClient(),
+		log:           cfg.Logger,
+		statusUpdater: su,
+		resources:     resources,
+	}
+
+	c, err := newController("backendtrafficpolicy", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+	r.log.Info("created backendtrafficpolicy controller")
+
+	// Subscribe to status updates once they are written, i.e. once this
+	// replica is elected leader.
+	go func() {
+		<-su.Enabled()
+		r.subscribeAndUpdateStatus(context.Background())
+	}()
+
+	if err := c.Watch(&source.Kind{Type: &v1alpha1.BackendTrafficPolicy{}}, &handler.EnqueueRequestForObject{}, watchPredicates(cfg)...); err != nil {
+		return err
+	}
+
+	// Watch Secret CRUDs and reconcile the policies referencing them, so that
+	// rotated certificates are delivered to the proxies.
+	if err := c.Watch(
+		&source.Kind{Type: &corev1.Secret{}},
+		handler.EnqueueRequestsFromMapFunc(r.getBackendTrafficPoliciesForSecret),
+	); err != nil {
+		return err
+	}
+
+	// Watch Service CRUDs and reconcile the policies referencing them, so that
+	// the proxies follow the external processing services.
+	if err := c.Watch(
+		&source.Kind{Type: &corev1.Service{}},
+		handler.EnqueueRequestsFromMapFunc(r.getBackendTrafficPoliciesForService),
+	); err != nil {
+		return err
+	}
+
+	r.log.Info("watching backendtrafficpolicy objects")
+	return nil
+}
+
+// Reconcile stores the reconciled BackendTrafficPolicy in the resource map, or
+// removes it from the map if it no longer exists. The policies are resolved
+// against their target resources by the gateway-api translator.
+func (r *backendTrafficPolicyReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("namespace", request.Namespace, "name", request.Name)
+
+	log.Info("reconciling backendtrafficpolicy")
+
+	policy := new(v1alpha1.BackendTrafficPolicy)
+	if err := r.client.Get(ctx, request.NamespacedName, policy); err != nil {
+		if kerrors.IsNotFound(err) {
+			r.resources.BackendTrafficPolicies.Delete(request.NamespacedName)
+			r.resources.BackendTrafficPolicyStatuses.Delete(request.NamespacedName)
+			log.Info("deleted backendtrafficpolicy from resource map")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get backendtrafficpolicy %s: %w", request.NamespacedName, err)
+	}
+
+	r.resources.BackendTrafficPolicies.Store(request.NamespacedName, policy)
+	log.Info("added backendtrafficpolicy to resource map")
+
+	// Store the Secrets referenced by the policy. Missing Secrets are reported
+	// in the status of the targeted route by the gateway-api translator.
+	for _, key := range backendTrafficPolicySecrets(policy) {
+		secret := new(corev1.Secret)
+		if err := r.client.Get(ctx, key, secret); err != nil {
+			if kerrors.IsNotFound(err) {
+				log.Info("secret referenced by backendtrafficpolicy not found", "secret", key)
+				continue
+			}
+			return reconcile.Result{}, fmt.Errorf("failed to get secret %s: %w", key, err)
+		}
+		r.resources.Secrets.Store(key, secret)
+		log.Info("added secret to resource map", "secret", key)
+	}
+
+	// Store the external processing Service referenced by the policy. A
+	// missing Service is reported in the status of the targeted route by the
+	// gateway-api translator.
+	if key := backendTrafficPolicyService(policy); key != nil {
+		svc := new(corev1.Service)
+		if err := r.client.Get(ctx, *key, svc); err != nil {
+			if kerrors.IsNotFound(err) {
+				log.Info("service referenced by backendtrafficpolicy not found", "service", key)
+				return reconcile.Result{}, nil
+			}
+			return reconcile.Result{}, fmt.Errorf("failed to get service %s: %w", key, err)
+		}
+		r.resources.Services.Store(*key, svc)
+		log.Info("added service to resource map", "service", key)
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// getBackendTrafficPoliciesForSecret returns the reconcile requests of the
+// BackendTrafficPolicies referencing the provided Secret.
+func (r *backendTrafficPolicyReconciler) getBackendTrafficPoliciesForSecret(obj client.Object) []reconcile.Request {
+	policies := &v1alpha1.BackendTrafficPolicyList{}
+	if err := r.client.List(context.Background(), policies, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.log.Error(err, "failed to list backendtrafficpolicies")
+		return []reconcile.Request{}
+	}
+
+	secretKey := utils.NamespacedName(obj)
+	requests := []reconcile.Request{}
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		for _, key := range backendTrafficPolicySecrets(policy) {
+			if key == secretKey {
+				requests = append(requests, reconcile.Request{NamespacedName: utils.NamespacedName(policy)})
+				break
+			}
+		}
+	}
+
+	return requests
+}
+
+// getBackendTrafficPoliciesForService returns the reconcile requests of the
+// BackendTrafficPolicies referencing the provided Service.
+func (r *backendTrafficPolicyReconciler) getBackendTrafficPoliciesForService(obj client.Object) []reconcile.Request {
+	policies := &v1alpha1.BackendTrafficPolicyList{}
+	if err := r.client.List(context.Background(), policies, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.log.Error(err, "failed to list backendtrafficpolicies")
+		return []reconcile.Request{}
+	}
+
+	svcKey := utils.NamespacedName(obj)
+	requests := []reconcile.Request{}
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		if key := backendTrafficPolicyService(policy); key != nil && *key == svcKey {
+			requests = append(requests, reconcile.Request{NamespacedName: utils.NamespacedName(policy)})
+		}
+	}
+
+	return requests
+}
+
+// backendTrafficPolicyService returns the key of the external processing
+// Service referenced by the provided policy, or nil if it references none. The
+// Service must be in the namespace of the policy.
+func backendTrafficPolicyService(policy *v1alpha1.BackendTrafficPolicy) *types.NamespacedName {
+	if policy.Spec.ExtProc == nil {
+		return nil
+	}
+	return &types.NamespacedName{Namespace: policy.Namespace, Name: string(policy.Spec.ExtProc.BackendRef.Name)}
+}
+
+// backendTrafficPolicySecrets returns the keys of the Secrets referenced by the
+// TLS configuration of the provided policy. The Secrets must be in the
+// namespace of the policy.
+func backendTrafficPolicySecrets(policy *v1alpha1.BackendTrafficPolicy) []types.NamespacedName {
+	if policy.Spec.TLS == nil {
+		return nil
+	}
+	var keys []types.NamespacedName
+	if ref := policy.Spec.TLS.ClientCertificateRef; ref != nil {
+		keys = append(keys, types.NamespacedName{Namespace: policy.Namespace, Name: string(ref.Name)})
+	}
+	if ref := policy.Spec.TLS.CACertificateRef; ref != nil {
+		keys = append(keys, types.NamespacedName{Namespace: policy.Namespace, Name: string(ref.Name)})
+	}
+	return keys
+}
+
+// subscribeAndUpdateStatus subscribes to backendtrafficpolicy status updates and writes them
+// into the Kubernetes API Server.
+func (r *backendTrafficPolicyReconciler) subscribeAndUpdateStatus(ctx context.Context) {
+	message.HandleSubscription(r.resources.BackendTrafficPolicyStatuses.Subscribe(ctx),
+		func(update message.Update[types.NamespacedName, *v1alpha1.BackendTrafficPolicy]) {
+			// skip delete updates.
+			if update.Delete {
+				return
+			}
+			val := update.Value
+			r.statusUpdater.Send(status.Update{
+				NamespacedName: update.Key,
+				Resource:       new(v1alpha1.BackendTrafficPolicy),
+				Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+					policy, ok := obj.(*v1alpha1.BackendTrafficPolicy)
+					if !ok {
+						panic(fmt.Sprintf("unsupported object type %T", obj))
+					}
+					policyCopy := policy.DeepCopy()
+					policyCopy.Status = val.Status
+					return policyCopy
+				}),
+			})
+		},
+	)
+	r.log.Info("backendtrafficpolicy status subscriber shutting down")
+}
@@ -0,0 +1,74 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+)
+
+// cacheOptions returns the options of the cache of the manager, which strips
+// the fields never read by Envoy Gateway from the most numerous cached
+// objects, to reduce its memory usage in large clusters. These objects are
+// never updated from their cached copy: the proxy Secrets are patched, and the
+// route statuses are written through the status subresource. The other kinds,
+// e.g. the GatewayClasses whose finalizers are updated, are cached as is.
+func cacheOptions() cache.Options {
+	return cache.Options{
+		TransformByObject: cache.TransformByObject{
+			&corev1.Secret{}:             stripObjectMeta,
+			&corev1.Service{}:            stripService,
+			&discoveryv1.EndpointSlice{}: stripObjectMeta,
+			&gwapiv1b1.HTTPRoute{}:       stripObjectMeta,
+			&gwapiv1a2.TLSRoute{}:        stripObjectMeta,
+			&gwapiv1a2.TCPRoute{}:        stripObjectMeta,
+			&gwapiv1a2.UDPRoute{}:        stripObjectMeta,
+		},
+	}
+}
+
+// stripObjectMeta removes the managed fields and the last applied
+// configuration annotation of kubectl from the cached object, which together
+// often weigh more than the object itself. The status of the routes is kept,
+// since the status updater compares the computed status to it and carries
+// over the parent statuses of the other controllers.
+func stripObjectMeta(obj interface{}) (interface{}, error) {
+	accessor, ok := obj.(metav1.Object)
+	if !ok {
+		// The deleted objects whose final state is unknown are passed as is.
+		return obj, nil
+	}
+	accessor.SetManagedFields(nil)
+	if annotations := accessor.GetAnnotations(); annotations != nil {
+		delete(annotations, corev1.LastAppliedConfigAnnotation)
+		accessor.SetAnnotations(annotations)
+	}
+	return obj, nil
+}
+
+// stripService strips the object meta of the cached Service, and its status
+// unless it is the Service of an Envoy proxy fleet, whose load balancer
+// addresses are reported in the status of its Gateway.
+func stripService(obj interface{}) (interface{}, error) {
+	obj, err := stripObjectMeta(obj)
+	if err != nil {
+		return nil, err
+	}
+	svc, ok := obj.(*corev1.Service)
+	if !ok {
+		return obj, nil
+	}
+	if _, ok := svc.Labels[gatewayapi.OwningGatewayNameLabel]; !ok {
+		svc.Status = corev1.ServiceStatus{}
+	}
+	return svc, nil
+}
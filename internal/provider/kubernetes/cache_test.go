@@ -0,0 +1,97 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+)
+
+func TestStripObjectMeta(t *testing.T) {
+	route := &gwapiv1b1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "httproute-1",
+			Annotations: map[string]string{
+				corev1.LastAppliedConfigAnnotation:    `{"kind":"HTTPRoute"}`,
+				gatewayapi.BackendPortNamesAnnotation: "service-1=http",
+			},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		},
+		Status: gwapiv1b1.HTTPRouteStatus{
+			RouteStatus: gwapiv1b1.RouteStatus{
+				Parents: []gwapiv1b1.RouteParentStatus{{ControllerName: "gateway.envoyproxy.io/gatewayclass-controller"}},
+			},
+		},
+	}
+
+	obj, err := stripObjectMeta(route)
+	require.NoError(t, err)
+	stripped := obj.(*gwapiv1b1.HTTPRoute)
+	require.Nil(t, stripped.ManagedFields)
+	require.Equal(t, map[string]string{gatewayapi.BackendPortNamesAnnotation: "service-1=http"}, stripped.Annotations)
+	// The status of the routes is kept.
+	require.Len(t, stripped.Status.Parents, 1)
+
+	// The deleted objects whose final state is unknown are passed as is.
+	tombstone := toolscache.DeletedFinalStateUnknown{Key: "default/httproute-1"}
+	obj, err = stripObjectMeta(tombstone)
+	require.NoError(t, err)
+	require.Equal(t, tombstone, obj)
+}
+
+func TestStripService(t *testing.T) {
+	status := corev1.ServiceStatus{
+		LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{IP: "1.2.3.4"}}},
+	}
+
+	testCases := []struct {
+		name       string
+		labels     map[string]string
+		wantStatus bool
+	}{
+		{
+			name: "backend service",
+		},
+		{
+			name:       "envoy service",
+			labels:     map[string]string{gatewayapi.OwningGatewayNameLabel: "gateway-1"},
+			wantStatus: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:     "default",
+					Name:          "service-1",
+					Labels:        tc.labels,
+					ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+				},
+				Status: *status.DeepCopy(),
+			}
+
+			obj, err := stripService(svc)
+			require.NoError(t, err)
+			stripped := obj.(*corev1.Service)
+			require.Nil(t, stripped.ManagedFields)
+			if tc.wantStatus {
+				require.Equal(t, status, stripped.Status)
+			} else {
+				require.Equal(t, corev1.ServiceStatus{}, stripped.Status)
+			}
+		})
+	}
+}
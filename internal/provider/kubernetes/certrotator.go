@@ -0,0 +1,372 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/crypto"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+)
+
+const (
+	// envoyGatewaySecretName is the name of the Secret holding the Envoy Gateway
+	// xDS server certificate.
+	envoyGatewaySecretName = "envoy-gateway"
+
+	// envoySecretName is the name of the Secret holding the Envoy xDS client
+	// certificate.
+	envoySecretName = "envoy"
+
+	// nextCACertificateKey and nextCAPrivateKeyKey are the key names of the
+	// Envoy Gateway Secret holding the CA being rolled out, trusted but not yet
+	// issuing certificates.
+	nextCACertificateKey = "next-ca.crt"
+	nextCAPrivateKeyKey  = "next-ca.key"
+
+	// caRolloutAnnotation is the annotation of the Envoy Gateway Secret holding
+	// the time the current step of a CA rollout started at.
+	caRolloutAnnotation = "gateway.envoyproxy.io/ca-rollout-started"
+
+	// certRotationInterval is how often the control plane certificates are
+	// checked for expiry.
+	certRotationInterval = time.Hour
+
+	// caRolloutPeriod is the minimum duration of each step of a CA rollout,
+	// long enough for the kubelets to sync the updated Secrets mounted by the
+	// Envoy Gateway and Envoy pods.
+	caRolloutPeriod = time.Hour
+)
+
+// certRotator periodically checks the certificates used to secure the xDS
+// connection between Envoy Gateway and the managed Envoy fleet, and regenerates
+// the Envoy Gateway and Envoy certificates before they expire.
+// Envoy Gateway reloads its certificate on each new connection and Envoy watches
+// its SDS files, so updated Secrets are picked up without restarts.
+//
+// A CA close to expiry is replaced in three steps, each lasting at least
+// caRolloutPeriod, so that the peers always trust the certificates of each other:
+//  1. The new CA is added to the CA bundles, the certificates are unchanged.
+//  2. The certificates are reissued by the new CA, the old CA stays trusted
+//     for the certificates not reloaded yet.
+//  3. The old CA is removed from the CA bundles.
+type certRotator struct {
+	client       client.Client
+	log          logr.Logger
+	envoyGateway *v1alpha1.EnvoyGateway
+	namespace    string
+	interval     time.Duration
+	threshold    time.Duration
+	rollout      time.Duration
+}
+
+// newCertRotator returns a certRotator for the control plane certificates
+// stored in the provided namespace.
+func newCertRotator(cli client.Client, log logr.Logger, eg *v1alpha1.EnvoyGateway, namespace string) *certRotator {
+	return &certRotator{
+		client:       cli,
+		log:          log.WithName("cert-rotator"),
+		envoyGateway: eg,
+		namespace:    namespace,
+		interval:     certRotationInterval,
+		threshold:    crypto.DefaultRotationThreshold,
+		rollout:      caRolloutPeriod,
+	}
+}
+
+// Start implements manager.Runnable.
+func (r *certRotator) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.rotateIfNeeded(ctx, time.Now()); err != nil {
+			r.log.Error(err, "failed to rotate certificates")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// rotateIfNeeded regenerates the control plane certificates if the Envoy Gateway
+// certificate is missing, invalid or close to expiry, and advances the rollout
+// of a new CA.
+func (r *certRotator) rotateIfNeeded(ctx context.Context, now time.Time) error {
+	secret := new(corev1.Secret)
+	key := types.NamespacedName{Namespace: r.namespace, Name: envoyGatewaySecretName}
+	if err := r.client.Get(ctx, key, secret); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get secret %s: %w", key, err)
+		}
+		r.log.Info("certificate secret not found, generating certificates", "secret", key)
+		return r.generate(ctx, now)
+	}
+	cas, err := crypto.ParseCertificates(secret.Data[caCertificateKey])
+	if err != nil {
+		r.log.Info("invalid CA found, regenerating certificates", "secret", key, "reason", err.Error())
+		return r.generate(ctx, now)
+	}
+
+	// Advance the rollout of a new CA once its current step lasted long enough.
+	if len(secret.Data[nextCACertificateKey]) > 0 || len(cas) > 1 {
+		started, err := time.Parse(time.RFC3339, secret.Annotations[caRolloutAnnotation])
+		if err == nil && now.Before(started.Add(r.rollout)) {
+			return nil
+		}
+		if len(secret.Data[nextCACertificateKey]) > 0 {
+			return r.issueWithNextCA(ctx, secret, now)
+		}
+		return r.removePreviousCAs(ctx, secret)
+	}
+
+	// The Secrets generated before the certificates were rotated don't hold
+	// the private key of their CA, which is then replaced by a new CA rolled
+	// out in steps, as the peers only trust the current CA.
+	if len(secret.Data[caPrivateKeyKey]) == 0 {
+		r.log.Info("CA private key not found, rolling out a new CA", "secret", key)
+		return r.trustNextCA(ctx, secret, now)
+	}
+
+	rotate, err := crypto.NeedsRotation(secret.Data[corev1.TLSCertKey], now, r.threshold)
+	if err != nil {
+		r.log.Info("invalid certificate found, regenerating certificates", "secret", key, "reason", err.Error())
+		return r.generate(ctx, now)
+	}
+	if !rotate {
+		// The proxy certificates are issued when their Gateway is first
+		// reconciled, so they may expire before the control plane ones.
+		return r.updateProxySecrets(ctx, secret.Data[caCertificateKey], secret.Data[caPrivateKeyKey], true, now)
+	}
+	if now.Add(r.threshold).Before(cas[0].NotAfter) {
+		// The CA outlives the new certificates, so they are issued by the
+		// current CA and the CA bundles are unchanged.
+		certs, err := crypto.GenerateCertsWithCA(r.envoyGateway, secret.Data[caCertificateKey], secret.Data[caPrivateKeyKey])
+		if err != nil {
+			return fmt.Errorf("failed to generate certificates: %w", err)
+		}
+		if err := r.writeSecrets(ctx, certs, nil, time.Time{}); err != nil {
+			return err
+		}
+		if err := r.updateProxySecrets(ctx, certs.CACertificate, certs.CAPrivateKey, true, now); err != nil {
+			return err
+		}
+		r.log.Info("rotated control plane certificates")
+		return nil
+	}
+
+	return r.trustNextCA(ctx, secret, now)
+}
+
+// generate generates a new CA and the certificates it issues, replacing the
+// current ones. It is only used when no valid CA exists, so there are no
+// certificates to keep trusting.
+func (r *certRotator) generate(ctx context.Context, now time.Time) error {
+	certs, err := crypto.GenerateCerts(r.envoyGateway)
+	if err != nil {
+		return fmt.Errorf("failed to generate certificates: %w", err)
+	}
+	if err := r.writeSecrets(ctx, certs, nil, time.Time{}); err != nil {
+		return err
+	}
+	if err := r.updateProxySecrets(ctx, certs.CACertificate, certs.CAPrivateKey, true, now); err != nil {
+		return err
+	}
+	r.log.Info("generated control plane certificates")
+
+	return nil
+}
+
+// trustNextCA starts the rollout of a new CA by adding it to the CA bundles,
+// the current CA still issuing the certificates.
+func (r *certRotator) trustNextCA(ctx context.Context, secret *corev1.Secret, now time.Time) error {
+	nextCACert, nextCAKey, err := crypto.GenerateCA()
+	if err != nil {
+		return fmt.Errorf("failed to generate CA: %w", err)
+	}
+
+	certs, err := r.currentCerts(ctx, secret)
+	if err != nil {
+		return err
+	}
+	certs.CACertificate = append(append([]byte{}, certs.CACertificate...), nextCACert...)
+	if err := r.writeSecrets(ctx, certs, &crypto.Certificates{CACertificate: nextCACert, CAPrivateKey: nextCAKey}, now); err != nil {
+		return err
+	}
+	if err := r.updateProxySecrets(ctx, certs.CACertificate, certs.CAPrivateKey, false, time.Time{}); err != nil {
+		return err
+	}
+	r.log.Info("started the rollout of a new CA")
+
+	return nil
+}
+
+// issueWithNextCA reissues the certificates with the CA being rolled out,
+// keeping the previous CAs in the CA bundles for the certificates they issued.
+func (r *certRotator) issueWithNextCA(ctx context.Context, secret *corev1.Secret, now time.Time) error {
+	bundle := append(append([]byte{}, secret.Data[nextCACertificateKey]...), previousCAs(secret.Data[caCertificateKey], secret.Data[nextCACertificateKey])...)
+	certs, err := crypto.GenerateCertsWithCA(r.envoyGateway, bundle, secret.Data[nextCAPrivateKeyKey])
+	if err != nil {
+		return fmt.Errorf("failed to generate certificates: %w", err)
+	}
+	if err := r.writeSecrets(ctx, certs, nil, now); err != nil {
+		return err
+	}
+	if err := r.updateProxySecrets(ctx, certs.CACertificate, certs.CAPrivateKey, true, time.Time{}); err != nil {
+		return err
+	}
+	r.log.Info("issued the control plane certificates with the new CA")
+
+	return nil
+}
+
+// removePreviousCAs completes the rollout of a new CA by removing the previous
+// CAs from the CA bundles.
+func (r *certRotator) removePreviousCAs(ctx context.Context, secret *corev1.Secret) error {
+	certs, err := r.currentCerts(ctx, secret)
+	if err != nil {
+		return err
+	}
+	cas, err := crypto.ParseCertificates(certs.CACertificate)
+	if err != nil {
+		return err
+	}
+	certs.CACertificate = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cas[0].Raw})
+	if err := r.writeSecrets(ctx, certs, nil, time.Time{}); err != nil {
+		return err
+	}
+	if err := r.updateProxySecrets(ctx, certs.CACertificate, certs.CAPrivateKey, true, time.Time{}); err != nil {
+		return err
+	}
+	r.log.Info("completed the rollout of a new CA")
+
+	return nil
+}
+
+// writeSecrets writes the Envoy Gateway and Envoy Secrets holding certs. The CA
+// being rolled out, if any, and the time the current rollout step started at,
+// if set, are recorded in the Envoy Gateway Secret.
+func (r *certRotator) writeSecrets(ctx context.Context, certs, nextCA *crypto.Certificates, rolloutStarted time.Time) error {
+	secrets := CertsToSecret(r.namespace, certs)
+	for i := range secrets {
+		if secrets[i].Name != envoyGatewaySecretName {
+			continue
+		}
+		if nextCA != nil {
+			secrets[i].Data[nextCACertificateKey] = nextCA.CACertificate
+			secrets[i].Data[nextCAPrivateKeyKey] = nextCA.CAPrivateKey
+		}
+		if !rolloutStarted.IsZero() {
+			secrets[i].Annotations = map[string]string{caRolloutAnnotation: rolloutStarted.UTC().Format(time.RFC3339)}
+		}
+	}
+	if _, err := CreateOrUpdateSecrets(ctx, r.client, secrets); err != nil {
+		return fmt.Errorf("failed to create or update secrets: %w", err)
+	}
+
+	return nil
+}
+
+// updateProxySecrets sets the CA bundle of the Secrets holding the xDS client
+// certificates of the proxy fleets, which are otherwise only updated when their
+// Gateway is reconciled. If reissue is set, the certificates not issued by the
+// first CA of the bundle are reissued by it, along with the certificates close
+// to expiry at now if now is not zero.
+func (r *certRotator) updateProxySecrets(ctx context.Context, caBundle, caKey []byte, reissue bool, now time.Time) error {
+	secrets := new(corev1.SecretList)
+	if err := r.client.List(ctx, secrets, client.InNamespace(r.namespace), client.HasLabels{gatewayapi.OwningGatewayNameLabel}); err != nil {
+		return fmt.Errorf("failed to list proxy secrets: %w", err)
+	}
+
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		proxyName, err := crypto.ProxyName(secret.Data[corev1.TLSCertKey])
+		if err != nil || proxyName == "" {
+			continue
+		}
+
+		reissueCert := reissue && !crypto.IssuedBy(secret.Data[corev1.TLSCertKey], caBundle)
+		if reissue && !now.IsZero() {
+			expiring, err := crypto.NeedsRotation(secret.Data[corev1.TLSCertKey], now, r.threshold)
+			reissueCert = reissueCert || expiring || err != nil
+		}
+		if !reissueCert && bytes.Equal(secret.Data[caCertificateKey], caBundle) {
+			continue
+		}
+
+		patch := client.MergeFrom(secret.DeepCopy())
+		secret.Data[caCertificateKey] = caBundle
+		if reissueCert {
+			cert, key, err := crypto.GenerateProxyCert(caBundle, caKey, proxyName)
+			if err != nil {
+				return fmt.Errorf("failed to generate proxy certificate: %w", err)
+			}
+			secret.Data[corev1.TLSCertKey] = cert
+			secret.Data[corev1.TLSPrivateKeyKey] = key
+		}
+		if err := r.client.Patch(ctx, secret, patch); err != nil {
+			return fmt.Errorf("failed to update secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// currentCerts returns the CA and certificates held by the Envoy Gateway
+// Secret and the Envoy Secret.
+func (r *certRotator) currentCerts(ctx context.Context, secret *corev1.Secret) (*crypto.Certificates, error) {
+	envoySecret := new(corev1.Secret)
+	key := types.NamespacedName{Namespace: r.namespace, Name: envoySecretName}
+	if err := r.client.Get(ctx, key, envoySecret); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s: %w", key, err)
+	}
+
+	return &crypto.Certificates{
+		CACertificate:           secret.Data[caCertificateKey],
+		CAPrivateKey:            secret.Data[caPrivateKeyKey],
+		EnvoyGatewayCertificate: secret.Data[corev1.TLSCertKey],
+		EnvoyGatewayPrivateKey:  secret.Data[corev1.TLSPrivateKeyKey],
+		EnvoyCertificate:        envoySecret.Data[corev1.TLSCertKey],
+		EnvoyPrivateKey:         envoySecret.Data[corev1.TLSPrivateKeyKey],
+	}, nil
+}
+
+// previousCAs returns the PEM encoded CAs of caBundle other than ca.
+func previousCAs(caBundle, ca []byte) []byte {
+	var previous []byte
+	for rest := caBundle; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		encoded := pem.EncodeToMemory(block)
+		if !bytes.Contains(ca, encoded) {
+			previous = append(previous, encoded...)
+		}
+	}
+	return previous
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable so that only a
+// single replica rotates the certificates.
+func (r *certRotator) NeedLeaderElection() bool {
+	return true
+}
@@ -0,0 +1,235 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/envoyproxy/gateway/internal/crypto"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+)
+
+const certRotatorNamespace = "envoy-gateway-system"
+
+func getCertRotatorSecret(t *testing.T, cli client.Client, name string) *corev1.Secret {
+	secret := new(corev1.Secret)
+	require.NoError(t, cli.Get(context.Background(), types.NamespacedName{Namespace: certRotatorNamespace, Name: name}, secret))
+	return secret
+}
+
+// snapshotCertRotatorSecrets returns the Envoy Gateway, Envoy and proxy
+// Secrets.
+func snapshotCertRotatorSecrets(t *testing.T, cli client.Client) []*corev1.Secret {
+	return []*corev1.Secret{
+		getCertRotatorSecret(t, cli, envoyGatewaySecretName),
+		getCertRotatorSecret(t, cli, envoySecretName),
+		getCertRotatorSecret(t, cli, "envoy-proxy"),
+	}
+}
+
+// createProxySecret creates the Secret holding the xDS client certificate of
+// the proxy fleet of the eg Gateway.
+func createProxySecret(t *testing.T, cli client.Client, caBundle, cert, key []byte) {
+	require.NoError(t, cli.Create(context.Background(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: certRotatorNamespace,
+			Name:      "envoy-proxy",
+			Labels:    map[string]string{gatewayapi.OwningGatewayNameLabel: "eg"},
+		},
+		Data: map[string][]byte{
+			caCertificateKey:        caBundle,
+			corev1.TLSCertKey:       cert,
+			corev1.TLSPrivateKeyKey: key,
+		},
+	}))
+}
+
+// verifies returns true if the certificate of the secret leaf is trusted by
+// the CA bundle of the secret bundle.
+func verifies(t *testing.T, leaf, bundle *corev1.Secret) bool {
+	certs, err := crypto.ParseCertificates(leaf.Data[corev1.TLSCertKey])
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(bundle.Data[caCertificateKey]))
+	_, err = certs[0].Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}})
+	return err == nil
+}
+
+// requireOverlap requires the certificates of before and after to be trusted
+// by the CA bundles of both, since the peers don't reload their Secrets at the
+// same time.
+func requireOverlap(t *testing.T, before, after []*corev1.Secret) {
+	for _, leaf := range append(before, after...) {
+		for _, bundle := range append(before, after...) {
+			require.Truef(t, verifies(t, leaf, bundle), "certificate of %s not trusted by the CA bundle of %s", leaf.Name, bundle.Name)
+		}
+	}
+}
+
+func TestCertRotatorCARollout(t *testing.T) {
+	ctx := context.Background()
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build()
+	r := newCertRotator(cli, logr.Discard(), nil, certRotatorNamespace)
+	getSecret := func(t *testing.T, name string) *corev1.Secret {
+		return getCertRotatorSecret(t, cli, name)
+	}
+	snapshot := func(t *testing.T) []*corev1.Secret {
+		return snapshotCertRotatorSecrets(t, cli)
+	}
+
+	now := time.Now()
+	require.NoError(t, r.rotateIfNeeded(ctx, now))
+	eg := getSecret(t, envoyGatewaySecretName)
+	proxyCert, proxyKey, err := crypto.GenerateProxyCert(eg.Data[caCertificateKey], eg.Data[caPrivateKeyKey], "envoy-default-eg")
+	require.NoError(t, err)
+	createProxySecret(t, cli, eg.Data[caCertificateKey], proxyCert, proxyKey)
+	initial := snapshot(t)
+
+	// Nothing is rotated before the certificates are close to expiry.
+	require.NoError(t, r.rotateIfNeeded(ctx, now))
+	require.Equal(t, initial[0].Data, getSecret(t, envoyGatewaySecretName).Data)
+
+	// Step 1: the new CA is trusted, the certificates are unchanged.
+	later := now.Add(time.Duration(crypto.DefaultCertificateLifetime)*24*time.Hour - crypto.DefaultRotationThreshold)
+	require.NoError(t, r.rotateIfNeeded(ctx, later))
+	trusted := snapshot(t)
+	require.NotEmpty(t, trusted[0].Data[nextCACertificateKey])
+	for i := range trusted {
+		require.Equal(t, initial[i].Data[corev1.TLSCertKey], trusted[i].Data[corev1.TLSCertKey])
+		cas, err := crypto.ParseCertificates(trusted[i].Data[caCertificateKey])
+		require.NoError(t, err)
+		require.Len(t, cas, 2)
+	}
+	requireOverlap(t, initial, trusted)
+
+	// The rollout only advances once the step lasted long enough.
+	require.NoError(t, r.rotateIfNeeded(ctx, later.Add(time.Minute)))
+	require.Equal(t, trusted[0].Data, getSecret(t, envoyGatewaySecretName).Data)
+
+	// Step 2: the certificates are issued by the new CA, the old CA is still trusted.
+	later = later.Add(caRolloutPeriod)
+	require.NoError(t, r.rotateIfNeeded(ctx, later))
+	issued := snapshot(t)
+	require.Empty(t, issued[0].Data[nextCACertificateKey])
+	for i := range issued {
+		require.NotEqual(t, trusted[i].Data[corev1.TLSCertKey], issued[i].Data[corev1.TLSCertKey])
+		require.True(t, crypto.IssuedBy(issued[i].Data[corev1.TLSCertKey], trusted[0].Data[nextCACertificateKey]))
+	}
+	requireOverlap(t, trusted, issued)
+	name, err := crypto.ProxyName(issued[2].Data[corev1.TLSCertKey])
+	require.NoError(t, err)
+	require.Equal(t, "envoy-default-eg", name)
+
+	// Step 3: the old CA is no longer trusted.
+	later = later.Add(caRolloutPeriod)
+	require.NoError(t, r.rotateIfNeeded(ctx, later))
+	completed := snapshot(t)
+	for i := range completed {
+		require.Equal(t, issued[i].Data[corev1.TLSCertKey], completed[i].Data[corev1.TLSCertKey])
+		cas, err := crypto.ParseCertificates(completed[i].Data[caCertificateKey])
+		require.NoError(t, err)
+		require.Len(t, cas, 1)
+	}
+	requireOverlap(t, issued, completed)
+	require.False(t, verifies(t, initial[0], completed[1]))
+	require.Empty(t, completed[0].Annotations[caRolloutAnnotation])
+
+	// The rollout is completed, the new certificates are far from expiry.
+	require.NoError(t, r.rotateIfNeeded(ctx, time.Now()))
+	require.Equal(t, completed[0].Data, getSecret(t, envoyGatewaySecretName).Data)
+}
+
+func TestCertRotatorUpgrade(t *testing.T) {
+	ctx := context.Background()
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build()
+	r := newCertRotator(cli, logr.Discard(), nil, certRotatorNamespace)
+
+	// The Secrets generated before the certificates were rotated don't hold
+	// the private key of the CA.
+	certs, err := crypto.GenerateCerts(nil)
+	require.NoError(t, err)
+	proxyCert, proxyKey, err := crypto.GenerateProxyCert(certs.CACertificate, certs.CAPrivateKey, "envoy-default-eg")
+	require.NoError(t, err)
+	certs.CAPrivateKey = nil
+	_, err = CreateOrUpdateSecrets(ctx, cli, CertsToSecret(certRotatorNamespace, certs))
+	require.NoError(t, err)
+	createProxySecret(t, cli, certs.CACertificate, proxyCert, proxyKey)
+	initial := snapshotCertRotatorSecrets(t, cli)
+	require.Empty(t, initial[0].Data[caPrivateKeyKey])
+
+	// Step 1: a new CA is trusted, the certificates are unchanged.
+	now := time.Now()
+	require.NoError(t, r.rotateIfNeeded(ctx, now))
+	trusted := snapshotCertRotatorSecrets(t, cli)
+	require.NotEmpty(t, trusted[0].Data[nextCACertificateKey])
+	for i := range trusted {
+		require.Equal(t, initial[i].Data[corev1.TLSCertKey], trusted[i].Data[corev1.TLSCertKey])
+	}
+	requireOverlap(t, initial, trusted)
+
+	// Step 2: the certificates are issued by the new CA, whose private key is
+	// now held by the Secret.
+	now = now.Add(caRolloutPeriod)
+	require.NoError(t, r.rotateIfNeeded(ctx, now))
+	issued := snapshotCertRotatorSecrets(t, cli)
+	require.Equal(t, trusted[0].Data[nextCAPrivateKeyKey], issued[0].Data[caPrivateKeyKey])
+	for i := range issued {
+		require.True(t, crypto.IssuedBy(issued[i].Data[corev1.TLSCertKey], trusted[0].Data[nextCACertificateKey]))
+	}
+	requireOverlap(t, trusted, issued)
+
+	// Step 3: the CA without private key is no longer trusted.
+	now = now.Add(caRolloutPeriod)
+	require.NoError(t, r.rotateIfNeeded(ctx, now))
+	completed := snapshotCertRotatorSecrets(t, cli)
+	requireOverlap(t, issued, completed)
+	require.False(t, verifies(t, initial[0], completed[1]))
+
+	// The rollout is not started again.
+	require.NoError(t, r.rotateIfNeeded(ctx, now.Add(caRolloutPeriod)))
+	require.Equal(t, completed[0].Data, getCertRotatorSecret(t, cli, envoyGatewaySecretName).Data)
+}
+
+func TestCertRotatorProxyExpiry(t *testing.T) {
+	ctx := context.Background()
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build()
+	r := newCertRotator(cli, logr.Discard(), nil, certRotatorNamespace)
+
+	now := time.Now()
+	require.NoError(t, r.rotateIfNeeded(ctx, now))
+	eg := getCertRotatorSecret(t, cli, envoyGatewaySecretName)
+	proxyCert, proxyKey, err := crypto.GenerateProxyCert(eg.Data[caCertificateKey], eg.Data[caPrivateKeyKey], "envoy-default-eg")
+	require.NoError(t, err)
+	createProxySecret(t, cli, eg.Data[caCertificateKey], proxyCert, proxyKey)
+
+	// The proxy certificate is far from expiry.
+	require.NoError(t, r.rotateIfNeeded(ctx, now))
+	require.Equal(t, proxyCert, getCertRotatorSecret(t, cli, "envoy-proxy").Data[corev1.TLSCertKey])
+
+	// The proxy certificate is reissued by the current CA once close to
+	// expiry, even though the control plane certificates are not.
+	r.threshold = time.Duration(crypto.DefaultCertificateLifetime) * 24 * time.Hour
+	require.NoError(t, r.updateProxySecrets(ctx, eg.Data[caCertificateKey], eg.Data[caPrivateKeyKey], true, now))
+	proxy := getCertRotatorSecret(t, cli, "envoy-proxy")
+	require.NotEqual(t, proxyCert, proxy.Data[corev1.TLSCertKey])
+	require.True(t, crypto.IssuedBy(proxy.Data[corev1.TLSCertKey], eg.Data[caCertificateKey]))
+	name, err := crypto.ProxyName(proxy.Data[corev1.TLSCertKey])
+	require.NoError(t, err)
+	require.Equal(t, "envoy-default-eg", name)
+	require.Equal(t, eg.Data, getCertRotatorSecret(t, cli, envoyGatewaySecretName).Data)
+}
@@ -0,0 +1,119 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/status"
+)
+
+type clientTrafficPolicyReconciler struct {
+	client        client.Client
+	log           logr.Logger
+	statusUpdater status.Updater
+
+	resources *message.ProviderResources
+}
+
+// newClientTrafficPolicyController creates the clienttrafficpolicy controller from mgr.
+// The controller will be pre-configured to watch for ClientTrafficPolicy objects across
+// all namespaces.
+func newClientTrafficPolicyController(mgr manager.Manager, cfg *config.Server, su status.Updater, resources *message.ProviderResources) error {
+	r := &clientTrafficPolicyReconciler{
+		client:        mgr.GetClient(),
+		log:           cfg.Logger,
+		statusUpdater: su,
+		resources:     resources,
+	}
+
+	c, err := newController("clienttrafficpolicy", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+	r.log.Info("created clienttrafficpolicy controller")
+
+	// Subscribe to status updates once they are written, i.e. once this
+	// replica is elected leader.
+	go func() {
+		<-su.Enabled()
+		r.subscribeAndUpdateStatus(context.Background())
+	}()
+
+	if err := c.Watch(&source.Kind{Type: &v1alpha1.ClientTrafficPolicy{}}, &handler.EnqueueRequestForObject{}, watchPredicates(cfg)...); err != nil {
+		return err
+	}
+
+	r.log.Info("watching clienttrafficpolicy objects")
+	return nil
+}
+
+// Reconcile stores the reconciled ClientTrafficPolicy in the resource map, or
+// removes it from the map if it no longer exists. The policies are resolved
+// against their target resources by the gateway-api translator.
+func (r *clientTrafficPolicyReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("namespace", request.Namespace, "name", request.Name)
+
+	log.Info("reconciling clienttrafficpolicy")
+
+	policy := new(v1alpha1.ClientTrafficPolicy)
+	if err := r.client.Get(ctx, request.NamespacedName, policy); err != nil {
+		if kerrors.IsNotFound(err) {
+			r.resources.ClientTrafficPolicies.Delete(request.NamespacedName)
+			r.resources.ClientTrafficPolicyStatuses.Delete(request.NamespacedName)
+			log.Info("deleted clienttrafficpolicy from resource map")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get clienttrafficpolicy %s: %w", request.NamespacedName, err)
+	}
+
+	r.resources.ClientTrafficPolicies.Store(request.NamespacedName, policy)
+	log.Info("added clienttrafficpolicy to resource map")
+
+	return reconcile.Result{}, nil
+}
+
+// subscribeAndUpdateStatus subscribes to clienttrafficpolicy status updates and writes them
+// into the Kubernetes API Server.
+func (r *clientTrafficPolicyReconciler) subscribeAndUpdateStatus(ctx context.Context) {
+	message.HandleSubscription(r.resources.ClientTrafficPolicyStatuses.Subscribe(ctx),
+		func(update message.Update[types.NamespacedName, *v1alpha1.ClientTrafficPolicy]) {
+			// skip delete updates.
+			if update.Delete {
+				return
+			}
+			val := update.Value
+			r.statusUpdater.Send(status.Update{
+				NamespacedName: update.Key,
+				Resource:       new(v1alpha1.ClientTrafficPolicy),
+				Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+					policy, ok := obj.(*v1alpha1.ClientTrafficPolicy)
+					if !ok {
+						panic(fmt.Sprintf("unsupported object type %T", obj))
+					}
+					policyCopy := policy.DeepCopy()
+					policyCopy.Status = val.Status
+					return policyCopy
+				}),
+			})
+		},
+	)
+	r.log.Info("clienttrafficpolicy status subscriber shutting down")
+}
@@ -0,0 +1,178 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+const (
+	// ManagedByLabel is the label of the resources created and owned by
+	// Envoy Gateway, e.g. the default GatewayClass.
+	ManagedByLabel = "gateway.envoyproxy.io/managed-by"
+	// managedByValue is the value of the ManagedByLabel.
+	managedByValue = "envoy-gateway"
+
+	// defaultGatewayClassRetryInterval is the interval the provisioning of the
+	// default GatewayClass is retried at after a failure.
+	defaultGatewayClassRetryInterval = 10 * time.Second
+)
+
+// defaultGatewayClassProvisioner creates or updates the default GatewayClass,
+// and its EnvoyProxy, at startup.
+type defaultGatewayClassProvisioner struct {
+	client         client.Client
+	log            logr.Logger
+	controllerName string
+	defaultClass   *v1alpha1.DefaultGatewayClass
+	namespace      string
+	retryInterval  time.Duration
+}
+
+// newDefaultGatewayClassProvisioner returns a defaultGatewayClassProvisioner
+// of the provided default GatewayClass, whose EnvoyProxy is stored in the
+// provided namespace.
+func newDefaultGatewayClassProvisioner(cli client.Client, log logr.Logger, controllerName string,
+	defaultClass *v1alpha1.DefaultGatewayClass, namespace string) *defaultGatewayClassProvisioner {
+	return &defaultGatewayClassProvisioner{
+		client:         cli,
+		log:            log.WithName("default-gatewayclass-provisioner"),
+		controllerName: controllerName,
+		defaultClass:   defaultClass,
+		namespace:      namespace,
+		retryInterval:  defaultGatewayClassRetryInterval,
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable so that only a
+// single replica writes the default GatewayClass.
+func (p *defaultGatewayClassProvisioner) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements manager.Runnable. It retries until the default GatewayClass
+// is provisioned.
+func (p *defaultGatewayClassProvisioner) Start(ctx context.Context) error {
+	ticker := time.NewTicker(p.retryInterval)
+	defer ticker.Stop()
+
+	for {
+		err := p.provision(ctx)
+		if err == nil {
+			return nil
+		}
+		p.log.Error(err, "failed to provision the default gatewayclass")
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// name returns the name of the default GatewayClass and of its EnvoyProxy.
+func (p *defaultGatewayClassProvisioner) name() string {
+	if p.defaultClass.Name != "" {
+		return p.defaultClass.Name
+	}
+	return v1alpha1.DefaultGatewayClassName
+}
+
+// provision creates or updates the EnvoyProxy, if any, and then the
+// GatewayClass referencing it.
+func (p *defaultGatewayClassProvisioner) provision(ctx context.Context) error {
+	spec := gwapiv1b1.GatewayClassSpec{
+		ControllerName: gwapiv1b1.GatewayController(p.controllerName),
+	}
+
+	if p.defaultClass.EnvoyProxy != nil {
+		ep := &v1alpha1.EnvoyProxy{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1alpha1.GroupVersion.String(),
+				Kind:       v1alpha1.KindEnvoyProxy,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: p.namespace,
+				Name:      p.name(),
+			},
+			Spec: *p.defaultClass.EnvoyProxy.DeepCopy(),
+		}
+		if err := p.apply(ctx, ep, func(existing client.Object) {
+			existing.(*v1alpha1.EnvoyProxy).Spec = ep.Spec
+		}); err != nil {
+			return err
+		}
+
+		namespace := gwapiv1b1.Namespace(p.namespace)
+		spec.ParametersRef = &gwapiv1b1.ParametersReference{
+			Group:     gwapiv1b1.Group(v1alpha1.GroupVersion.Group),
+			Kind:      v1alpha1.KindEnvoyProxy,
+			Name:      p.name(),
+			Namespace: &namespace,
+		}
+	}
+
+	gc := &gwapiv1b1.GatewayClass{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gwapiv1b1.GroupVersion.String(),
+			Kind:       "GatewayClass",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: p.name(),
+		},
+		Spec: spec,
+	}
+	return p.apply(ctx, gc, func(existing client.Object) {
+		existing.(*gwapiv1b1.GatewayClass).Spec.ParametersRef = spec.ParametersRef
+	})
+}
+
+// apply creates obj, labeled with the ManagedByLabel, or updates the existing
+// object with update if it has the label. An existing object without the label
+// is left untouched. The controller name of a GatewayClass is immutable, so an
+// existing GatewayClass with another controller name is an error.
+func (p *defaultGatewayClassProvisioner) apply(ctx context.Context, obj client.Object, update func(existing client.Object)) error {
+	key := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	existing := obj.DeepCopyObject().(client.Object)
+	if err := p.client.Get(ctx, key, existing); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get %s %s: %w", kind, key, err)
+		}
+		obj.SetLabels(map[string]string{ManagedByLabel: managedByValue})
+		if err := p.client.Create(ctx, obj); err != nil {
+			return fmt.Errorf("failed to create %s %s: %w", kind, key, err)
+		}
+		p.log.Info("created default gatewayclass resource", "kind", kind, "name", key)
+		return nil
+	}
+
+	if existing.GetLabels()[ManagedByLabel] != managedByValue {
+		p.log.Info("skipping default gatewayclass resource not managed by envoy gateway", "kind", kind, "name", key)
+		return nil
+	}
+	if gc, ok := existing.(*gwapiv1b1.GatewayClass); ok && string(gc.Spec.ControllerName) != p.controllerName {
+		return fmt.Errorf("%s %s has controller name %s", kind, key.Name, gc.Spec.ControllerName)
+	}
+
+	update(existing)
+	if err := p.client.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update %s %s: %w", kind, key, err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/log"
+)
+
+func TestDefaultGatewayClassProvisioner(t *testing.T) {
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("creates and updates the gatewayclass and envoyproxy", func(t *testing.T) {
+		cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).Build()
+		defaultClass := &v1alpha1.DefaultGatewayClass{
+			EnvoyProxy: &v1alpha1.EnvoyProxySpec{},
+		}
+		p := newDefaultGatewayClassProvisioner(cli, logger, v1alpha1.GatewayControllerName, defaultClass, "envoy-gateway-system")
+		require.NoError(t, p.provision(ctx))
+
+		gc := new(gwapiv1b1.GatewayClass)
+		require.NoError(t, cli.Get(ctx, types.NamespacedName{Name: v1alpha1.DefaultGatewayClassName}, gc))
+		require.Equal(t, gwapiv1b1.GatewayController(v1alpha1.GatewayControllerName), gc.Spec.ControllerName)
+		require.Equal(t, managedByValue, gc.Labels[ManagedByLabel])
+		require.True(t, refsEnvoyProxy(gc.Spec.ParametersRef))
+		require.Equal(t, "envoy-gateway-system", string(*gc.Spec.ParametersRef.Namespace))
+
+		ep := new(v1alpha1.EnvoyProxy)
+		key := types.NamespacedName{Namespace: "envoy-gateway-system", Name: v1alpha1.DefaultGatewayClassName}
+		require.NoError(t, cli.Get(ctx, key, ep))
+		require.Equal(t, managedByValue, ep.Labels[ManagedByLabel])
+
+		// The parametersRef is removed along with the EnvoyProxy of the configuration.
+		defaultClass.EnvoyProxy = nil
+		require.NoError(t, p.provision(ctx))
+		require.NoError(t, cli.Get(ctx, types.NamespacedName{Name: v1alpha1.DefaultGatewayClassName}, gc))
+		require.Nil(t, gc.Spec.ParametersRef)
+	})
+
+	t.Run("leaves an unmanaged gatewayclass untouched", func(t *testing.T) {
+		existing := &gwapiv1b1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+			Spec: gwapiv1b1.GatewayClassSpec{
+				ControllerName: "example.com/other-controller",
+			},
+		}
+		cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects(existing).Build()
+		p := newDefaultGatewayClassProvisioner(cli, logger, v1alpha1.GatewayControllerName,
+			&v1alpha1.DefaultGatewayClass{Name: "custom"}, "envoy-gateway-system")
+		require.NoError(t, p.provision(ctx))
+
+		gc := new(gwapiv1b1.GatewayClass)
+		require.NoError(t, cli.Get(ctx, types.NamespacedName{Name: "custom"}, gc))
+		require.Equal(t, gwapiv1b1.GatewayController("example.com/other-controller"), gc.Spec.ControllerName)
+		require.Empty(t, gc.Labels)
+	})
+}
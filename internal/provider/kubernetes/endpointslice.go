@@ -0,0 +1,82 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+type endpointSliceReconciler struct {
+	client client.Client
+	log    logr.Logger
+
+	resources *message.ProviderResources
+}
+
+// newEndpointSliceController creates the endpointslice controller from mgr.
+// The controller will be pre-configured to watch for EndpointSlice objects
+// across all namespaces, so that the gateway-api translator reports whether
+// the backends of the routes have ready endpoints.
+func newEndpointSliceController(mgr manager.Manager, cfg *config.Server, resources *message.ProviderResources) error {
+	r := &endpointSliceReconciler{
+		client:    mgr.GetClient(),
+		log:       cfg.Logger,
+		resources: resources,
+	}
+
+	c, err := newController("endpointslice", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+	r.log.Info("created endpointslice controller")
+
+	if err := c.Watch(&source.Kind{Type: &discoveryv1.EndpointSlice{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	r.log.Info("watching endpointslice objects")
+	return nil
+}
+
+// Reconcile stores the reconciled EndpointSlice in the resource map, or
+// removes it from the map if it no longer exists.
+func (r *endpointSliceReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("namespace", request.Namespace, "name", request.Name)
+
+	slice := new(discoveryv1.EndpointSlice)
+	if err := r.client.Get(ctx, request.NamespacedName, slice); err != nil {
+		if kerrors.IsNotFound(err) {
+			r.resources.EndpointSlices.Delete(request.NamespacedName)
+			log.Info("deleted endpointslice from resource map")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get endpointslice %s: %w", request.NamespacedName, err)
+	}
+
+	// The EndpointSlices not managed for a Service are skipped.
+	if slice.Labels[discoveryv1.LabelServiceName] == "" {
+		return reconcile.Result{}, nil
+	}
+
+	r.resources.EndpointSlices.Store(request.NamespacedName, slice)
+	log.Info("added endpointslice to resource map")
+
+	return reconcile.Result{}, nil
+}
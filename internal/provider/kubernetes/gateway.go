@@ -15,6 +15,7 @@ package kubernetes
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
@@ -35,6 +36,7 @@ import (
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/provider/secretbackend"
 	"github.com/envoyproxy/gateway/internal/provider/utils"
 	"github.com/envoyproxy/gateway/internal/status"
 	"github.com/envoyproxy/gateway/internal/utils/slice"
@@ -48,6 +50,11 @@ type gatewayReconciler struct {
 	classController gwapiv1b1.GatewayController
 	statusUpdater   status.Updater
 	log             logr.Logger
+	// secrets retrieves the TLS certificates referenced by the Gateways.
+	secrets secretbackend.Backend
+	// secretRefresh is the interval the Gateways are reconciled at to refresh
+	// their certificates, if the secret backend isn't watched.
+	secretRefresh time.Duration
 
 	resources *message.ProviderResources
 }
@@ -56,28 +63,42 @@ type gatewayReconciler struct {
 // Gateway objects across all namespaces and reconcile those that match the configured
 // gatewayclass controller name.
 func newGatewayController(mgr manager.Manager, cfg *config.Server, su status.Updater, resources *message.ProviderResources) error {
+	secrets, err := secretbackend.New(cfg.EnvoyGateway.SecretBackend, mgr.GetClient())
+	if err != nil {
+		return fmt.Errorf("failed to create secret backend: %w", err)
+	}
+
 	r := &gatewayReconciler{
 		client:          mgr.GetClient(),
 		classController: gwapiv1b1.GatewayController(cfg.EnvoyGateway.Gateway.ControllerName),
 		statusUpdater:   su,
 		log:             cfg.Logger,
+		secrets:         secrets,
 		resources:       resources,
 	}
+	if !secrets.Watched() {
+		r.secretRefresh = cfg.EnvoyGateway.SecretBackend.GetRefreshInterval()
+	}
 
-	c, err := controller.New("gateway", mgr, controller.Options{Reconciler: r})
+	c, err := newController("gateway", mgr, controller.Options{Reconciler: r})
 	if err != nil {
 		return err
 	}
 	r.log.Info("created gateway controller")
 
-	// Subscribe to status updates
-	go r.subscribeAndUpdateStatus(context.Background())
+	// Subscribe to status updates once they are written, i.e. once this
+	// replica is elected leader, so that the new leader writes the status of
+	// all the resources when taking over.
+	go func() {
+		<-su.Enabled()
+		r.subscribeAndUpdateStatus(context.Background())
+	}()
 
 	// Only enqueue Gateway objects that match this Envoy Gateway's controller name.
 	if err := c.Watch(
 		&source.Kind{Type: &gwapiv1b1.Gateway{}},
 		&handler.EnqueueRequestForObject{},
-		predicate.NewPredicateFuncs(r.hasMatchingController),
+		append([]predicate.Predicate{predicate.NewPredicateFuncs(r.hasMatchingController)}, watchPredicates(cfg)...)...,
 	); err != nil {
 		return err
 	}
@@ -91,9 +112,12 @@ func newGatewayController(mgr manager.Manager, cfg *config.Server, su status.Upd
 		return err
 	}
 	// Trigger gateway reconciliation when a Secret that is referenced
-	// by a managed Gateway has changed.
-	if err := c.Watch(&source.Kind{Type: &corev1.Secret{}}, r.enqueueRequestForGatewaySecrets()); err != nil {
-		return err
+	// by a managed Gateway has changed. The certificates of the other
+	// secret backends are refreshed by requeuing the Gateways instead.
+	if secrets.Watched() {
+		if err := c.Watch(&source.Kind{Type: &corev1.Secret{}}, r.enqueueRequestForGatewaySecrets()); err != nil {
+			return err
+		}
 	}
 	// Trigger gateway reconciliation when a ReferenceGrant that refers
 	// to a managed Gateway has changed.
@@ -303,17 +327,17 @@ func (r *gatewayReconciler) Reconcile(ctx context.Context, request reconcile.Req
 	for i := range acceptedGateways {
 		gw := acceptedGateways[i]
 
-		// Get the status of the Gateway's associated Envoy Deployment.
-		deployment, err := r.envoyDeploymentForGateway(ctx, &gw)
+		// Get the status address of the Gateway's associated Envoy Service.
+		svc, err := r.envoyServiceForGateway(ctx, &gw)
 		if err != nil {
-			r.log.Info("failed to get deployment for gateway",
+			r.log.Info("failed to get service for gateway",
 				"namespace", gw.Namespace, "name", gw.Name)
 		}
 
-		// Get the status address of the Gateway's associated Envoy Service.
-		svc, err := r.envoyServiceForGateway(ctx, &gw)
+		// Get the status of the Gateway's associated Envoy Deployment.
+		deployment, err := r.envoyDeploymentForGateway(ctx, &gw, svc)
 		if err != nil {
-			r.log.Info("failed to get service for gateway",
+			r.log.Info("failed to get deployment for gateway",
 				"namespace", gw.Namespace, "name", gw.Name)
 		}
 
@@ -367,7 +391,8 @@ func (r *gatewayReconciler) Reconcile(ctx context.Context, request reconcile.Req
 					panic(fmt.Sprintf("unsupported object type %T", obj))
 				}
 				gCopy := g.DeepCopy()
-				gCopy.Status.Conditions = status.MergeConditions(gCopy.Status.Conditions, gw.Status.Conditions...)
+				conditions := status.MergeConditions(gCopy.Status.Conditions, gw.Status.Conditions...)
+				gCopy.Status.Conditions = status.CapConditions(status.DedupeConditions(conditions), status.MaxConditions)
 				gCopy.Status.Addresses = gw.Status.Addresses
 				return gCopy
 
@@ -418,6 +443,9 @@ func (r *gatewayReconciler) Reconcile(ctx context.Context, request reconcile.Req
 
 	r.log.WithName(request.Namespace).WithName(request.Name).Info("reconciled gateway")
 
+	if found && r.secretRefresh > 0 {
+		return reconcile.Result{RequeueAfter: r.secretRefresh}, nil
+	}
 	return reconcile.Result{}, nil
 }
 
@@ -552,8 +580,8 @@ func (r *gatewayReconciler) secretsAndRefGrantsForGateway(ctx context.Context, g
 									Namespace: string(*ref.Namespace),
 									Name:      string(ref.Name),
 								}
-								secret := new(corev1.Secret)
-								if err := r.client.Get(ctx, key, secret); err != nil {
+								secret, err := r.secrets.GetSecret(ctx, key)
+								if err != nil {
 									r.resources.Secrets.Delete(key)
 									return nil, nil, fmt.Errorf("failed to get secret: %v", err)
 								}
@@ -566,8 +594,8 @@ func (r *gatewayReconciler) secretsAndRefGrantsForGateway(ctx context.Context, g
 							Namespace: gateway.Namespace,
 							Name:      string(ref.Name),
 						}
-						secret := new(corev1.Secret)
-						if err := r.client.Get(ctx, key, secret); err != nil {
+						secret, err := r.secrets.GetSecret(ctx, key)
+						if err != nil {
 							r.resources.Secrets.Delete(key)
 							return nil, nil, fmt.Errorf("failed to get secret: %v", err)
 						}
@@ -624,11 +652,16 @@ func (r *gatewayReconciler) removeFinalizer(ctx context.Context, gc *gwapiv1b1.G
 }
 
 // envoyDeploymentForGateway returns the Envoy Deployment, returning nil if the Deployment doesn't exist.
-func (r *gatewayReconciler) envoyDeploymentForGateway(ctx context.Context, gateway *gwapiv1b1.Gateway) (*appsv1.Deployment, error) {
+// The Deployment of the revision selected by the provided Envoy Service is returned when the proxies
+// are upgraded with the BlueGreen strategy.
+func (r *gatewayReconciler) envoyDeploymentForGateway(ctx context.Context, gateway *gwapiv1b1.Gateway, svc *corev1.Service) (*appsv1.Deployment, error) {
 	key := types.NamespacedName{
 		Namespace: config.EnvoyGatewayNamespace,
 		Name:      infraDeploymentName(gateway),
 	}
+	if svc != nil && svc.Spec.Selector[gatewayapi.RevisionLabel] != "" {
+		key.Name = fmt.Sprintf("%s-%s", key.Name, svc.Spec.Selector[gatewayapi.RevisionLabel])
+	}
 	deployment := new(appsv1.Deployment)
 	if err := r.client.Get(ctx, key, deployment); err != nil {
 		if kerrors.IsNotFound(err) {
@@ -660,15 +693,52 @@ func (r *gatewayReconciler) subscribeAndUpdateStatus(ctx context.Context) {
 						panic(fmt.Sprintf("unsupported object type %T", obj))
 					}
 					gCopy := g.DeepCopy()
-					gCopy.Status.Listeners = val.Status.Listeners
+					status.SetListenerStatuses(gCopy, val.Status.Listeners)
+					status.UpdateGatewayStatusMaintenanceCondition(gCopy, val)
+					status.UpdateGatewayStatusConfigurationRejectedCondition(gCopy, val)
 					return gCopy
 				}),
 			})
+
+			if kinds, ok := val.Annotations[gatewayapi.AttachedRouteKindsAnnotation]; ok {
+				if err := r.updateAnnotation(ctx, key, gatewayapi.AttachedRouteKindsAnnotation, kinds); err != nil {
+					r.log.Error(err, "failed to update attached route kinds", "namespace", key.Namespace, "name", key.Name)
+				}
+			}
+			if listenerTLS, ok := val.Annotations[gatewayapi.ListenerTLSAnnotation]; ok {
+				if err := r.updateAnnotation(ctx, key, gatewayapi.ListenerTLSAnnotation, listenerTLS); err != nil {
+					r.log.Error(err, "failed to update listener tls", "namespace", key.Namespace, "name", key.Name)
+				}
+			}
 		},
 	)
 	r.log.Info("status subscriber shutting down")
 }
 
+// updateAnnotation sets the provided annotation computed by the translation,
+// e.g. the attached route kinds, of the Gateway identified by key, if changed.
+// Annotations can't be written through the status subresource, so the Gateway
+// is patched.
+func (r *gatewayReconciler) updateAnnotation(ctx context.Context, key types.NamespacedName, annotation, value string) error {
+	gw := new(gwapiv1b1.Gateway)
+	if err := r.client.Get(ctx, key, gw); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if gw.Annotations[annotation] == value {
+		return nil
+	}
+
+	patch := client.MergeFrom(gw.DeepCopy())
+	if gw.Annotations == nil {
+		gw.Annotations = make(map[string]string)
+	}
+	gw.Annotations[annotation] = value
+	return r.client.Patch(ctx, gw, patch)
+}
+
 func infraServiceName(gateway *gwapiv1b1.Gateway) string {
 	infraName := utils.GetHashedName(fmt.Sprintf("%s-%s", gateway.Namespace, gateway.Name))
 	return fmt.Sprintf("%s-%s", config.EnvoyPrefix, infraName)
@@ -23,6 +23,7 @@ import (
 	"github.com/envoyproxy/gateway/internal/envoygateway"
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/log"
+	"github.com/envoyproxy/gateway/internal/provider/secretbackend"
 )
 
 func TestGatewayHasMatchingController(t *testing.T) {
@@ -856,6 +857,7 @@ func TestSecretsAndRefGrantsForGateway(t *testing.T) {
 				objs = append(objs, &tc.refGrants[k])
 			}
 			r.client = fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects(objs...).Build()
+			r.secrets = secretbackend.NewKubernetes(r.client)
 			secrets, refGrants, err := r.secretsAndRefGrantsForGateway(ctx, tc.gw)
 			require.NoError(t, err)
 			require.Equal(t, tc.secrets, secrets)
@@ -13,6 +13,7 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -23,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/message"
 	"github.com/envoyproxy/gateway/internal/status"
@@ -50,25 +52,96 @@ func newGatewayClassController(mgr manager.Manager, cfg *config.Server, su statu
 		resources:     resources,
 	}
 
-	c, err := controller.New("gatewayclass", mgr, controller.Options{Reconciler: r})
+	c, err := newController("gatewayclass", mgr, controller.Options{Reconciler: r})
 	if err != nil {
 		return err
 	}
 	r.log.Info("created gatewayclass controller")
 
+	// Subscribe to status updates once they are written, i.e. once this
+	// replica is elected leader.
+	go func() {
+		<-su.Enabled()
+		r.subscribeAndUpdateStatus(context.Background())
+	}()
+
 	// Only enqueue GatewayClass objects that match this Envoy Gateway's controller name.
 	if err := c.Watch(
 		&source.Kind{Type: &gwapiv1b1.GatewayClass{}},
 		&handler.EnqueueRequestForObject{},
-		predicate.NewPredicateFuncs(r.hasMatchingController),
+		append([]predicate.Predicate{predicate.NewPredicateFuncs(r.hasMatchingController)}, watchPredicates(cfg)...)...,
 	); err != nil {
 		return err
 	}
 	r.log.Info("watching gatewayclass objects")
 
+	// Trigger gatewayclass reconciliation when a referenced EnvoyProxy changes.
+	if err := c.Watch(
+		&source.Kind{Type: &v1alpha1.EnvoyProxy{}},
+		r.enqueueRequestForReferencingClass(),
+	); err != nil {
+		return err
+	}
+	r.log.Info("watching envoyproxy objects")
+
 	return nil
 }
 
+// subscribeAndUpdateStatus subscribes to gatewayclass status updates, i.e. to
+// the ConfigurationValid condition computed by the translation, and merges them
+// into the status of the gatewayclass in the Kubernetes API Server.
+func (r *gatewayClassReconciler) subscribeAndUpdateStatus(ctx context.Context) {
+	message.HandleSubscription(r.resources.GatewayClassStatuses.Subscribe(ctx),
+		func(update message.Update[string, *gwapiv1b1.GatewayClass]) {
+			// skip delete updates.
+			if update.Delete {
+				return
+			}
+			val := update.Value
+			r.statusUpdater.Send(status.Update{
+				NamespacedName: types.NamespacedName{Name: update.Key},
+				Resource:       new(gwapiv1b1.GatewayClass),
+				Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+					gc, ok := obj.(*gwapiv1b1.GatewayClass)
+					if !ok {
+						panic(fmt.Sprintf("unsupported object type %T", obj))
+					}
+					gcCopy := gc.DeepCopy()
+					gcCopy.Status.Conditions = status.MergeConditions(gcCopy.Status.Conditions, val.Status.Conditions...)
+					return gcCopy
+				}),
+			})
+		},
+	)
+	r.log.Info("gatewayclass status subscriber shutting down")
+}
+
+// enqueueRequestForReferencingClass returns an event handler that maps events for
+// EnvoyProxy objects to reconcile requests for the managed GatewayClasses that
+// reference them.
+func (r *gatewayClassReconciler) enqueueRequestForReferencingClass() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
+		var gatewayClasses gwapiv1b1.GatewayClassList
+		if err := r.client.List(context.Background(), &gatewayClasses); err != nil {
+			return nil
+		}
+
+		var reqs []reconcile.Request
+		for i := range gatewayClasses.Items {
+			gc := &gatewayClasses.Items[i]
+			if gc.Spec.ControllerName != r.controller || !refsEnvoyProxy(gc.Spec.ParametersRef) {
+				continue
+			}
+			ref := gc.Spec.ParametersRef
+			if string(*ref.Namespace) == a.GetNamespace() && ref.Name == a.GetName() {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: gc.Name}})
+			}
+		}
+
+		return reqs
+	})
+}
+
 // hasMatchingController returns true if the provided object is a GatewayClass
 // with a Spec.Controller string matching this Envoy Gateway's controller string,
 // or false otherwise.
@@ -108,8 +181,10 @@ func (r *gatewayClassReconciler) Reconcile(ctx context.Context, request reconcil
 				!slice.ContainsString(gatewayClasses.Items[i].Finalizers, gatewayClassFinalizer) {
 				r.log.Info("gatewayclass marked for deletion")
 				cc.removeMatch(&gatewayClasses.Items[i])
-				// Delete the gatewayclass from the watchable map.
+				// Delete the gatewayclass and its parameters from the watchable maps.
 				r.resources.GatewayClasses.Delete(request.Name)
+				r.resources.EnvoyProxies.Delete(request.Name)
+				r.resources.GatewayClassStatuses.Delete(request.Name)
 				continue
 			}
 
@@ -124,7 +199,12 @@ func (r *gatewayClassReconciler) Reconcile(ctx context.Context, request reconcil
 		return reconcile.Result{}, nil
 	}
 
-	// Store the accepted gatewayclass in the resource map.
+	// Store the parameters and the accepted gatewayclass in the resource maps.
+	// The parameters are stored first so they are available when translating
+	// the Gateways of a newly accepted gatewayclass.
+	if err := r.processParametersRef(ctx, acceptedGC); err != nil {
+		return reconcile.Result{}, err
+	}
 	r.resources.GatewayClasses.Store(acceptedGC.GetName(), acceptedGC)
 
 	updater := func(gc *gwapiv1b1.GatewayClass, accepted bool) error {
@@ -168,6 +248,44 @@ func (r *gatewayClassReconciler) Reconcile(ctx context.Context, request reconcil
 	return reconcile.Result{}, nil
 }
 
+// processParametersRef stores the EnvoyProxy referenced by the parametersRef of
+// the provided gatewayclass in the resource map, or removes a stale entry if the
+// gatewayclass does not reference an existing EnvoyProxy.
+func (r *gatewayClassReconciler) processParametersRef(ctx context.Context, gc *gwapiv1b1.GatewayClass) error {
+	ref := gc.Spec.ParametersRef
+	if ref == nil {
+		r.resources.EnvoyProxies.Delete(gc.Name)
+		return nil
+	}
+	if !refsEnvoyProxy(ref) {
+		r.log.Info("ignoring unsupported gatewayclass parametersRef", "group", ref.Group, "kind", ref.Kind)
+		r.resources.EnvoyProxies.Delete(gc.Name)
+		return nil
+	}
+
+	ep := new(v1alpha1.EnvoyProxy)
+	key := types.NamespacedName{Namespace: string(*ref.Namespace), Name: ref.Name}
+	if err := r.client.Get(ctx, key, ep); err != nil {
+		if kerrors.IsNotFound(err) {
+			r.log.Info("envoyproxy referenced by gatewayclass not found", "namespace", key.Namespace, "name", key.Name)
+			r.resources.EnvoyProxies.Delete(gc.Name)
+			return nil
+		}
+		return fmt.Errorf("failed to get envoyproxy %s/%s: %w", key.Namespace, key.Name, err)
+	}
+	r.resources.EnvoyProxies.Store(gc.Name, ep)
+
+	return nil
+}
+
+// refsEnvoyProxy returns true if the provided parametersRef references an EnvoyProxy.
+func refsEnvoyProxy(ref *gwapiv1b1.ParametersReference) bool {
+	return ref != nil &&
+		string(ref.Group) == v1alpha1.GroupVersion.Group &&
+		string(ref.Kind) == v1alpha1.KindEnvoyProxy &&
+		ref.Namespace != nil
+}
+
 type controlledClasses struct {
 	// matchedClasses holds all GatewayClass objects with matching controllerName.
 	matchedClasses []*gwapiv1b1.GatewayClass
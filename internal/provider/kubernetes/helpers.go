@@ -13,11 +13,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
 )
 
 // validateParentRefs validates the provided routeParentReferences, returning the
 // referenced Gateways managed by Envoy Gateway. The only supported parentRef
-// is a Gateway.
+// is a Gateway, the Services of the mesh are skipped.
 func validateParentRefs(ctx context.Context, client client.Client, namespace string,
 	gatewayClassController gwapiv1b1.GatewayController,
 	routeParentReferences []gwapiv1b1.ParentReference) ([]gwapiv1b1.Gateway, error) {
@@ -25,6 +27,10 @@ func validateParentRefs(ctx context.Context, client client.Client, namespace str
 	var ret []gwapiv1b1.Gateway
 	for i := range routeParentReferences {
 		ref := routeParentReferences[i]
+		// The Services of the mesh are not validated here.
+		if gatewayapi.IsRefToService(ref) {
+			continue
+		}
 		if ref.Kind != nil && *ref.Kind != "Gateway" {
 			return nil, fmt.Errorf("invalid Kind %q", *ref.Kind)
 		}
@@ -61,20 +67,45 @@ func validateParentRefs(ctx context.Context, client client.Client, namespace str
 	return ret, nil
 }
 
-// isRoutePresentInNamespace checks if any kind of Routes - HTTPRoute, TLSRoute
-// exists in the namespace ns.
+// serviceParentRefs returns the Services referenced by the provided
+// routeParentReferences, i.e. the Services of the mesh the route attaches to.
+func serviceParentRefs(namespace string, routeParentReferences []gwapiv1b1.ParentReference) []types.NamespacedName {
+	var ret []types.NamespacedName
+	for _, ref := range routeParentReferences {
+		if gatewayapi.IsRefToService(ref) {
+			ret = append(ret, types.NamespacedName{
+				Namespace: gatewayapi.NamespaceDerefOr(ref.Namespace, namespace),
+				Name:      string(ref.Name),
+			})
+		}
+	}
+	return ret
+}
+
+// isRoutePresentInNamespace checks if any kind of Routes - HTTPRoute, TLSRoute,
+// TCPRoute, UDPRoute exists in the namespace ns.
 func isRoutePresentInNamespace(ctx context.Context, c client.Client, ns string) (bool, error) {
 	tlsRouteList := &gwapiv1a2.TLSRouteList{}
 	if err := c.List(ctx, tlsRouteList, &client.ListOptions{Namespace: ns}); err != nil {
 		return false, fmt.Errorf("error listing tlsroutes")
 	}
 
+	tcpRouteList := &gwapiv1a2.TCPRouteList{}
+	if err := c.List(ctx, tcpRouteList, &client.ListOptions{Namespace: ns}); err != nil {
+		return false, fmt.Errorf("error listing tcproutes")
+	}
+
+	udpRouteList := &gwapiv1a2.UDPRouteList{}
+	if err := c.List(ctx, udpRouteList, &client.ListOptions{Namespace: ns}); err != nil {
+		return false, fmt.Errorf("error listing udproutes")
+	}
+
 	httpRouteList := &gwapiv1b1.HTTPRouteList{}
 	if err := c.List(ctx, httpRouteList, &client.ListOptions{Namespace: ns}); err != nil {
 		return false, fmt.Errorf("error listing httproutes")
 	}
 
-	if len(tlsRouteList.Items)+len(httpRouteList.Items) > 0 {
+	if len(tlsRouteList.Items)+len(tcpRouteList.Items)+len(udpRouteList.Items)+len(httpRouteList.Items) > 0 {
 		return true, nil
 	}
 	return false, nil
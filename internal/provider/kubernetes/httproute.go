@@ -48,6 +48,8 @@ type httpRouteReconciler struct {
 	log             logr.Logger
 	statusUpdater   status.Updater
 	classController gwapiv1b1.GatewayController
+	// meshMode enables storing the routes attached to Services.
+	meshMode bool
 
 	resources      *message.ProviderResources
 	referenceStore *providerReferenceStore
@@ -60,30 +62,39 @@ func newHTTPRouteController(mgr manager.Manager, cfg *config.Server, su status.U
 		client:          mgr.GetClient(),
 		log:             cfg.Logger,
 		classController: gwapiv1b1.GatewayController(cfg.EnvoyGateway.Gateway.ControllerName),
+		meshMode:        cfg.EnvoyGateway.Mesh != nil && cfg.EnvoyGateway.Mesh.Enabled,
 		statusUpdater:   su,
 		resources:       resources,
 		referenceStore:  referenceStore,
 	}
 
-	c, err := controller.New("httproute", mgr, controller.Options{Reconciler: r})
+	c, err := newController("httproute", mgr, controller.Options{Reconciler: r})
 	if err != nil {
 		return err
 	}
 	r.log.Info("created httproute controller")
 
-	if err := c.Watch(&source.Kind{Type: &gwapiv1b1.HTTPRoute{}}, &handler.EnqueueRequestForObject{}); err != nil {
+	if err := c.Watch(&source.Kind{Type: &gwapiv1b1.HTTPRoute{}}, &handler.EnqueueRequestForObject{}, watchPredicates(cfg)...); err != nil {
 		return err
 	}
 
-	// Subscribe to status updates
-	go r.subscribeAndUpdateStatus(context.Background())
+	// Subscribe to status updates once they are written, i.e. once this
+	// replica is elected leader, so that the new leader writes the status of
+	// all the resources when taking over.
+	go func() {
+		<-su.Enabled()
+		r.subscribeAndUpdateStatus(context.Background())
+	}()
 
 	// Add indexing on HTTPRoute, for Service objects that are referenced in HTTPRoute objects
-	// via `.spec.rules.backendRefs`. This helps in querying for HTTPRoutes that are affected by
-	// a particular Service CRUD.
+	// via `.spec.rules.backendRefs` or `.spec.parentRefs` in mesh mode. This helps in querying
+	// for HTTPRoutes that are affected by a particular Service CRUD.
 	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &gwapiv1b1.HTTPRoute{}, serviceHTTPRouteIndex, func(rawObj client.Object) []string {
 		httpRoute := rawObj.(*gwapiv1b1.HTTPRoute)
 		var backendServices []string
+		for _, parent := range serviceParentRefs(httpRoute.Namespace, httpRoute.Spec.ParentRefs) {
+			backendServices = append(backendServices, parent.String())
+		}
 		for _, rule := range httpRoute.Spec.Rules {
 			for _, backend := range rule.BackendRefs {
 				if string(*backend.Kind) == gatewayapi.KindService {
@@ -218,7 +229,13 @@ func (r *httpRouteReconciler) Reconcile(ctx context.Context, request reconcile.R
 		}
 		log.Info("validated httproute parentRefs")
 
-		if len(gws) == 0 {
+		// In mesh mode, the route may attach to Services instead.
+		var parentServices []types.NamespacedName
+		if r.meshMode {
+			parentServices = serviceParentRefs(route.Namespace, route.Spec.ParentRefs)
+		}
+
+		if len(gws) == 0 && len(parentServices) == 0 {
 			// Remove the route from the watchable map since it doesn't reference
 			// a managed Gateway.
 			log.Info("httproute doesn't reference any managed gateways")
@@ -250,6 +267,34 @@ func (r *httpRouteReconciler) Reconcile(ctx context.Context, request reconcile.R
 		r.resources.Namespaces.Store(nsKey.Name, ns)
 		log.Info("added namespace to resource map")
 
+		// Get the Services the route attaches to from the cache. The missing
+		// ones are reported by the route status.
+		for _, svcKey := range parentServices {
+			svc := new(corev1.Service)
+			if err := r.client.Get(ctx, svcKey, svc); err != nil {
+				if !errors.IsNotFound(err) {
+					return reconcile.Result{}, fmt.Errorf("failed to get service %s/%s",
+						svcKey.Namespace, svcKey.Name)
+				}
+				if _, ok := r.resources.Services.Load(svcKey); ok {
+					r.resources.Services.Delete(svcKey)
+					r.referenceStore.removeRouteToServicesMapping(
+						ObjectKindNamespacedName{kindHTTPRoute, route.Namespace, route.Name},
+						svcKey,
+					)
+					log.Info("deleted service from resource map")
+				}
+				continue
+			}
+
+			r.resources.Services.Store(svcKey, svc)
+			r.referenceStore.updateRouteToServicesMapping(
+				ObjectKindNamespacedName{kindHTTPRoute, route.Namespace, route.Name},
+				svcKey,
+			)
+			log.Info("added service to resource map")
+		}
+
 		// Get the route's backendRefs from the cache. Note that a Service is the
 		// only supported kind.
 		for i := range route.Spec.Rules {
@@ -366,7 +411,7 @@ func (r *httpRouteReconciler) subscribeAndUpdateStatus(ctx context.Context) {
 						panic(fmt.Sprintf("unsupported object type %T", obj))
 					}
 					hCopy := h.DeepCopy()
-					hCopy.Status.Parents = val.Status.Parents
+					hCopy.Status.Parents = status.MergeRouteParentStatuses(hCopy.Status.Parents, val.Status.Parents, r.classController)
 					return hCopy
 				}),
 			})
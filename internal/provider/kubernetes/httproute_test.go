@@ -694,6 +694,69 @@ func TestValidateParentRefs(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "service parentRef is skipped",
+			route: &gwapiv1b1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "test",
+				},
+				Spec: gwapiv1b1.HTTPRouteSpec{
+					CommonRouteSpec: gwapiv1b1.CommonRouteSpec{
+						ParentRefs: []gwapiv1b1.ParentReference{
+							{
+								Group: gatewayapi.GroupPtr(gwapiv1b1.GroupName),
+								Kind:  gatewayapi.KindPtr("Gateway"),
+								Name:  "test",
+							},
+							{
+								Group: gatewayapi.GroupPtr(""),
+								Kind:  gatewayapi.KindPtr(gatewayapi.KindService),
+								Name:  "test",
+							},
+						},
+					},
+				},
+			},
+			gateways: []*gwapiv1b1.Gateway{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "test",
+						Name:      "test",
+					},
+					Spec: gwapiv1b1.GatewaySpec{
+						GatewayClassName: "gc1",
+					},
+				},
+			},
+			classes: []*gwapiv1b1.GatewayClass{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "gc1",
+					},
+					Spec: gwapiv1b1.GatewayClassSpec{
+						ControllerName: gwapiv1b1.GatewayController(v1alpha1.GatewayControllerName),
+					},
+				},
+			},
+			expect: []gwapiv1b1.Gateway{
+				{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "Gateway",
+						APIVersion: gwapiv1b1.GroupVersion.String(),
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:       "test",
+						Name:            "test",
+						ResourceVersion: "999",
+					},
+					Spec: gwapiv1b1.GatewaySpec{
+						GatewayClassName: "gc1",
+					},
+				},
+			},
+			expected: true,
+		},
 		{
 			name: "one of two valid parentRefs kind",
 			route: &gwapiv1b1.HTTPRoute{
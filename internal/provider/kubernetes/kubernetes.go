@@ -8,13 +8,17 @@ package kubernetes
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/message"
@@ -39,17 +43,59 @@ func New(cfg *rest.Config, svr *config.Server, resources *message.ProviderResour
 		HealthProbeBindAddress: ":8081",
 		LeaderElectionID:       "5b9825d2.gateway.envoyproxy.io",
 		MetricsBindAddress:     ":8080",
+		NewCache:               cache.BuilderWithOptions(cacheOptions()),
+	}
+	if kube := svr.EnvoyGateway.GetProvider().Kubernetes; kube != nil {
+		if kube.LeaderElection != nil {
+			setLeaderElectionOptions(&mgrOpts, kube.LeaderElection)
+		}
+		if kube.Watch != nil {
+			setWatchOptions(&mgrOpts, kube.Watch)
+		}
 	}
 	mgr, err := ctrl.NewManager(cfg, mgrOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create manager: %w", err)
 	}
 
-	updateHandler := status.NewUpdateHandler(mgr.GetLogger(), mgr.GetClient())
+	// Signal the other runners once this replica is elected leader. The
+	// runnable needs leader election, so it is started right away if leader
+	// election is disabled.
+	if svr.Elected != nil {
+		if err := mgr.Add(manager.RunnableFunc(func(context.Context) error {
+			svr.Logger.Info("elected leader")
+			close(svr.Elected)
+			return nil
+		})); err != nil {
+			return nil, fmt.Errorf("failed to add leader election signal %v", err)
+		}
+	}
+
+	updateHandler := status.NewUpdateHandler(mgr.GetLogger(), mgr.GetClient(), mgr.GetEventRecorderFor("envoy-gateway"))
 	if err := mgr.Add(updateHandler); err != nil {
 		return nil, fmt.Errorf("failed to add status update handler %v", err)
 	}
 
+	// Rotate the certificates used to secure the xDS connection to the Envoy fleet.
+	if err := mgr.Add(newCertRotator(mgr.GetClient(), svr.Logger, svr.EnvoyGateway, config.EnvoyGatewayNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to add certificate rotator %v", err)
+	}
+
+	// Create the default GatewayClass, if requested.
+	if gw := svr.EnvoyGateway.Gateway; gw != nil && gw.DefaultGatewayClass != nil {
+		if err := mgr.Add(newDefaultGatewayClassProvisioner(mgr.GetClient(), svr.Logger, gw.ControllerName,
+			gw.DefaultGatewayClass, config.EnvoyGatewayNamespace)); err != nil {
+			return nil, fmt.Errorf("failed to add default gatewayclass provisioner %v", err)
+		}
+	}
+
+	// Write the traffic statistics of the Gateways, if their aggregation is enabled.
+	if svr.EnvoyGateway.TrafficStats != nil {
+		if err := mgr.Add(newTrafficStatsWriter(mgr.GetClient(), svr.Logger, resources)); err != nil {
+			return nil, fmt.Errorf("failed to add traffic stats writer %v", err)
+		}
+	}
+
 	// Initialize kubernetes provider referenceStore to store additional object mappings.
 	referenceStore := newProviderReferenceStore()
 
@@ -67,6 +113,30 @@ func New(cfg *rest.Config, svr *config.Server, resources *message.ProviderResour
 	if err := newTLSRouteController(mgr, svr, updateHandler.Writer(), resources, referenceStore); err != nil {
 		return nil, fmt.Errorf("failed to create tlsroute controller: %w", err)
 	}
+	if err := newTCPRouteController(mgr, svr, updateHandler.Writer(), resources, referenceStore); err != nil {
+		return nil, fmt.Errorf("failed to create tcproute controller: %w", err)
+	}
+	if err := newUDPRouteController(mgr, svr, updateHandler.Writer(), resources, referenceStore); err != nil {
+		return nil, fmt.Errorf("failed to create udproute controller: %w", err)
+	}
+	if err := newBackendTrafficPolicyController(mgr, svr, updateHandler.Writer(), resources); err != nil {
+		return nil, fmt.Errorf("failed to create backendtrafficpolicy controller: %w", err)
+	}
+	if err := newClientTrafficPolicyController(mgr, svr, updateHandler.Writer(), resources); err != nil {
+		return nil, fmt.Errorf("failed to create clienttrafficpolicy controller: %w", err)
+	}
+	if err := newSecurityPolicyController(mgr, svr, updateHandler.Writer(), resources); err != nil {
+		return nil, fmt.Errorf("failed to create securitypolicy controller: %w", err)
+	}
+	if err := newTrafficShiftController(mgr, svr, updateHandler.Writer(), resources); err != nil {
+		return nil, fmt.Errorf("failed to create trafficshift controller: %w", err)
+	}
+	// Watch the EndpointSlices of the Services, if the backend readiness gating is enabled.
+	if gw := svr.EnvoyGateway.Gateway; gw != nil && gw.BackendReadinessGating {
+		if err := newEndpointSliceController(mgr, svr, resources); err != nil {
+			return nil, fmt.Errorf("failed to create endpointslice controller: %w", err)
+		}
+	}
 
 	// Add health check health probes.
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -84,6 +154,55 @@ func New(cfg *rest.Config, svr *config.Server, resources *message.ProviderResour
 	}, nil
 }
 
+// setLeaderElectionOptions enables the leader election of the manager, with
+// the Lease in the namespace of Envoy Gateway. The Lease is released when the
+// manager stops, so that a standby takes over without waiting for the Lease
+// to expire.
+func setLeaderElectionOptions(opts *manager.Options, le *v1alpha1.LeaderElection) {
+	opts.LeaderElection = true
+	opts.LeaderElectionNamespace = config.EnvoyGatewayNamespace
+	opts.LeaderElectionReleaseOnCancel = true
+	if le.LeaseDuration != nil {
+		opts.LeaseDuration = &le.LeaseDuration.Duration
+	}
+	if le.RenewDeadline != nil {
+		opts.RenewDeadline = &le.RenewDeadline.Duration
+	}
+	if le.RetryPeriod != nil {
+		opts.RetryPeriod = &le.RetryPeriod.Duration
+	}
+}
+
+// standbyController is a controller which also runs on the standby replicas,
+// keeping their caches and resource maps warm for a fast takeover.
+type standbyController struct {
+	controller.Controller
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (standbyController) NeedLeaderElection() bool {
+	return false
+}
+
+// newController creates a controller from mgr running on all the replicas.
+// Status updates sent by the controller are only written by the leader.
+func newController(name string, mgr manager.Manager, options controller.Options) (controller.Controller, error) {
+	c, err := controller.NewUnmanaged(name, mgr, options)
+	if err != nil {
+		return nil, err
+	}
+	if err := mgr.Add(standbyController{Controller: c}); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// AddMetricsExtraHandler serves the handler on the path of the metrics
+// server of the Provider. It must be called before the Provider starts.
+func (p *Provider) AddMetricsExtraHandler(path string, handler http.Handler) error {
+	return p.manager.AddMetricsExtraHandler(path, handler)
+}
+
 // Start starts the Provider synchronously until a message is received from ctx.
 func (p *Provider) Start(ctx context.Context) error {
 	errChan := make(chan error)
@@ -68,9 +68,12 @@ func TestProvider(t *testing.T) {
 	testcases := map[string]func(context.Context, *testing.T, *Provider, *message.ProviderResources){
 		"gatewayclass controller name":         testGatewayClassController,
 		"gatewayclass accepted status":         testGatewayClassAcceptedStatus,
+		"gatewayclass parameters":              testGatewayClassParameters,
 		"gateway scheduled status":             testGatewayScheduledStatus,
 		"httproute":                            testHTTPRoute,
 		"tlsroute":                             testTLSRoute,
+		"tcproute":                             testTCPRoute,
+		"udproute":                             testUDPRoute,
 		"stale service cleanup route deletion": testServiceCleanupForMultipleRoutes,
 	}
 	for name, tc := range testcases {
@@ -83,8 +86,9 @@ func TestProvider(t *testing.T) {
 func startEnv() (*envtest.Environment, *rest.Config, error) {
 	log.SetLogger(zap.New(zap.WriteTo(os.Stderr), zap.UseDevMode(true)))
 	crd := filepath.Join(".", "testdata", "in")
+	egCRD := filepath.Join(".", "config", "crd", "bases")
 	env := &envtest.Environment{
-		CRDDirectoryPaths: []string{crd},
+		CRDDirectoryPaths: []string{crd, egCRD},
 	}
 	cfg, err := env.Start()
 	if err != nil {
@@ -168,6 +172,51 @@ func testGatewayClassAcceptedStatus(ctx context.Context, t *testing.T, provider
 	assert.Equal(t, gc, gcs)
 }
 
+func testGatewayClassParameters(ctx context.Context, t *testing.T, provider *Provider, resources *message.ProviderResources) {
+	cli := provider.manager.GetClient()
+
+	lbClass := "example.com/static"
+	ep := &v1alpha1.EnvoyProxy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-gc-parameters",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.EnvoyProxySpec{
+			Provider: &v1alpha1.ResourceProvider{
+				Type: v1alpha1.ProviderTypeKubernetes,
+				Kubernetes: &v1alpha1.KubernetesResourceProvider{
+					EnvoyService: &v1alpha1.KubernetesServiceSpec{
+						LoadBalancerClass: &lbClass,
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, cli.Create(ctx, ep))
+
+	gc := getGatewayClass("test-gc-parameters")
+	ns := gwapiv1b1.Namespace(ep.Namespace)
+	gc.Spec.ParametersRef = &gwapiv1b1.ParametersReference{
+		Group:     gwapiv1b1.Group(v1alpha1.GroupVersion.Group),
+		Kind:      gwapiv1b1.Kind(v1alpha1.KindEnvoyProxy),
+		Name:      ep.Name,
+		Namespace: &ns,
+	}
+	require.NoError(t, cli.Create(ctx, gc))
+
+	defer func() {
+		require.NoError(t, cli.Delete(ctx, gc))
+		require.NoError(t, cli.Delete(ctx, ep))
+	}()
+
+	// Ensure the EnvoyProxy resource map contains the parameters of the gatewayclass under test.
+	require.Eventually(t, func() bool {
+		res := resources.GetEnvoyProxy(gc.Name)
+		return res != nil && res.GetEnvoyServiceSpec() != nil
+	}, defaultWait, defaultTick)
+	assert.Equal(t, lbClass, *resources.GetEnvoyProxy(gc.Name).GetEnvoyServiceSpec().LoadBalancerClass)
+}
+
 func testGatewayScheduledStatus(ctx context.Context, t *testing.T, provider *Provider, resources *message.ProviderResources) {
 	cli := provider.manager.GetClient()
 
@@ -801,6 +850,240 @@ func testTLSRoute(ctx context.Context, t *testing.T, provider *Provider, resourc
 	}
 }
 
+func testTCPRoute(ctx context.Context, t *testing.T, provider *Provider, resources *message.ProviderResources) {
+	cli := provider.manager.GetClient()
+
+	gc := getGatewayClass("tcproute-test")
+	require.NoError(t, cli.Create(ctx, gc))
+
+	defer func() {
+		require.NoError(t, cli.Delete(ctx, gc))
+	}()
+
+	// Create the namespace for the Gateway under test.
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tcproute-test"}}
+	require.NoError(t, cli.Create(ctx, ns))
+
+	gw := &gwapiv1b1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tcproute-test",
+			Namespace: ns.Name,
+		},
+		Spec: gwapiv1b1.GatewaySpec{
+			GatewayClassName: gwapiv1b1.ObjectName(gc.Name),
+			Listeners: []gwapiv1b1.Listener{
+				{
+					Name:     "test",
+					Port:     gwapiv1b1.PortNumber(int32(8080)),
+					Protocol: gwapiv1b1.TCPProtocolType,
+				},
+			},
+		},
+	}
+	require.NoError(t, cli.Create(ctx, gw))
+
+	defer func() {
+		require.NoError(t, cli.Delete(ctx, gw))
+	}()
+
+	svc := getService("test", ns.Name, map[string]int32{
+		"tcp": 90,
+	})
+	require.NoError(t, cli.Create(ctx, svc))
+	defer func() {
+		require.NoError(t, cli.Delete(ctx, svc))
+	}()
+
+	var testCases = []struct {
+		name  string
+		route gwapiv1a2.TCPRoute
+	}{
+		{
+			name: "tcproute",
+			route: gwapiv1a2.TCPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tcproute-test",
+					Namespace: ns.Name,
+				},
+				Spec: gwapiv1a2.TCPRouteSpec{
+					CommonRouteSpec: gwapiv1a2.CommonRouteSpec{
+						ParentRefs: []gwapiv1a2.ParentReference{
+							{
+								Name: gwapiv1a2.ObjectName(gw.Name),
+							},
+						},
+					},
+					Rules: []gwapiv1a2.TCPRouteRule{
+						{
+							BackendRefs: []gwapiv1a2.BackendRef{
+								{
+									BackendObjectReference: gwapiv1a2.BackendObjectReference{
+										Name: "test",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.NoError(t, cli.Create(ctx, &testCase.route))
+			defer func() {
+				require.NoError(t, cli.Delete(ctx, &testCase.route))
+			}()
+
+			require.Eventually(t, func() bool {
+				return resources.TCPRoutes.Len() == 1
+			}, defaultWait, defaultTick)
+
+			// Ensure the test TCPRoute in the TCPRoute resources is as expected.
+			key := types.NamespacedName{
+				Namespace: testCase.route.Namespace,
+				Name:      testCase.route.Name,
+			}
+			require.Eventually(t, func() bool {
+				return cli.Get(ctx, key, &testCase.route) == nil
+			}, defaultWait, defaultTick)
+			troutes, _ := resources.TCPRoutes.Load(key)
+			assert.Equal(t, &testCase.route, troutes)
+
+			// Ensure the TCPRoute Namespace is in the Namespace resource map.
+			require.Eventually(t, func() bool {
+				_, ok := resources.Namespaces.Load(testCase.route.Namespace)
+				return ok
+			}, defaultWait, defaultTick)
+
+			// Ensure the Service is in the resource map.
+			svcKey := utils.NamespacedName(svc)
+			require.Eventually(t, func() bool {
+				_, ok := resources.Services.Load(svcKey)
+				return ok
+			}, defaultWait, defaultTick)
+		})
+	}
+}
+
+func testUDPRoute(ctx context.Context, t *testing.T, provider *Provider, resources *message.ProviderResources) {
+	cli := provider.manager.GetClient()
+
+	gc := getGatewayClass("udproute-test")
+	require.NoError(t, cli.Create(ctx, gc))
+
+	defer func() {
+		require.NoError(t, cli.Delete(ctx, gc))
+	}()
+
+	// Create the namespace for the Gateway under test.
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "udproute-test"}}
+	require.NoError(t, cli.Create(ctx, ns))
+
+	gw := &gwapiv1b1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "udproute-test",
+			Namespace: ns.Name,
+		},
+		Spec: gwapiv1b1.GatewaySpec{
+			GatewayClassName: gwapiv1b1.ObjectName(gc.Name),
+			Listeners: []gwapiv1b1.Listener{
+				{
+					Name:     "test",
+					Port:     gwapiv1b1.PortNumber(int32(8080)),
+					Protocol: gwapiv1b1.UDPProtocolType,
+				},
+			},
+		},
+	}
+	require.NoError(t, cli.Create(ctx, gw))
+
+	defer func() {
+		require.NoError(t, cli.Delete(ctx, gw))
+	}()
+
+	svc := getService("test", ns.Name, map[string]int32{
+		"udp": 90,
+	})
+	require.NoError(t, cli.Create(ctx, svc))
+	defer func() {
+		require.NoError(t, cli.Delete(ctx, svc))
+	}()
+
+	var testCases = []struct {
+		name  string
+		route gwapiv1a2.UDPRoute
+	}{
+		{
+			name: "udproute",
+			route: gwapiv1a2.UDPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "udproute-test",
+					Namespace: ns.Name,
+				},
+				Spec: gwapiv1a2.UDPRouteSpec{
+					CommonRouteSpec: gwapiv1a2.CommonRouteSpec{
+						ParentRefs: []gwapiv1a2.ParentReference{
+							{
+								Name: gwapiv1a2.ObjectName(gw.Name),
+							},
+						},
+					},
+					Rules: []gwapiv1a2.UDPRouteRule{
+						{
+							BackendRefs: []gwapiv1a2.BackendRef{
+								{
+									BackendObjectReference: gwapiv1a2.BackendObjectReference{
+										Name: "test",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.NoError(t, cli.Create(ctx, &testCase.route))
+			defer func() {
+				require.NoError(t, cli.Delete(ctx, &testCase.route))
+			}()
+
+			require.Eventually(t, func() bool {
+				return resources.UDPRoutes.Len() == 1
+			}, defaultWait, defaultTick)
+
+			// Ensure the test UDPRoute in the UDPRoute resources is as expected.
+			key := types.NamespacedName{
+				Namespace: testCase.route.Namespace,
+				Name:      testCase.route.Name,
+			}
+			require.Eventually(t, func() bool {
+				return cli.Get(ctx, key, &testCase.route) == nil
+			}, defaultWait, defaultTick)
+			uroutes, _ := resources.UDPRoutes.Load(key)
+			assert.Equal(t, &testCase.route, uroutes)
+
+			// Ensure the UDPRoute Namespace is in the Namespace resource map.
+			require.Eventually(t, func() bool {
+				_, ok := resources.Namespaces.Load(testCase.route.Namespace)
+				return ok
+			}, defaultWait, defaultTick)
+
+			// Ensure the Service is in the resource map.
+			svcKey := utils.NamespacedName(svc)
+			require.Eventually(t, func() bool {
+				_, ok := resources.Services.Load(svcKey)
+				return ok
+			}, defaultWait, defaultTick)
+		})
+	}
+}
+
 // testServiceCleanupForMultipleRoutes creates multiple Routes pointing to the
 // same backend Service, and checks whether the Service is properly removed
 // from the resource map after Route deletion.
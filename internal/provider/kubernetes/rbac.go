@@ -5,9 +5,30 @@
 
 package kubernetes
 
-// +kubebuilder:rbac:groups="gateway.networking.k8s.io",resources=gatewayclasses;gateways;httproutes;tlsroutes;referencepolicies;referencegrants,verbs=get;list;watch;update
-// +kubebuilder:rbac:groups="gateway.networking.k8s.io",resources=gatewayclasses/status;gateways/status;httproutes/status;tlsroutes/status,verbs=update
+// +kubebuilder:rbac:groups="gateway.networking.k8s.io",resources=gatewayclasses;gateways;httproutes;tlsroutes;tcproutes;udproutes;referencepolicies;referencegrants,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups="gateway.networking.k8s.io",resources=gateways,verbs=patch
+// +kubebuilder:rbac:groups="gateway.networking.k8s.io",resources=gatewayclasses/status;gateways/status;httproutes/status;tlsroutes/status;tcproutes/status;udproutes/status,verbs=update
 
 // RBAC for watched resources of Gateway API controllers.
 // +kubebuilder:rbac:groups="",resources=secrets;services;namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch
+
+// RBAC for the EndpointSlices of the Services, watched for the backend readiness gating.
+// +kubebuilder:rbac:groups="discovery.k8s.io",resources=endpointslices,verbs=get;list;watch
+
+// RBAC for the Events of the resources rejected by the translation.
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// RBAC for the EnvoyProxy parameters of managed GatewayClasses.
+// +kubebuilder:rbac:groups="config.gateway.envoyproxy.io",resources=envoyproxies,verbs=get;list;watch
+
+// RBAC for the default GatewayClass and its EnvoyProxy.
+// +kubebuilder:rbac:groups="gateway.networking.k8s.io",resources=gatewayclasses,verbs=create
+// +kubebuilder:rbac:groups="config.gateway.envoyproxy.io",resources=envoyproxies,verbs=create;update
+
+// RBAC for the policies and traffic shifts attached to Gateway API resources.
+// +kubebuilder:rbac:groups="config.gateway.envoyproxy.io",resources=backendtrafficpolicies;clienttrafficpolicies;securitypolicies;trafficshifts,verbs=get;list;watch
+// +kubebuilder:rbac:groups="config.gateway.envoyproxy.io",resources=backendtrafficpolicies/status;clienttrafficpolicies/status;securitypolicies/status;trafficshifts/status,verbs=update
+
+// RBAC for the traffic statistics of the Gateways.
+// +kubebuilder:rbac:groups="config.gateway.envoyproxy.io",resources=gatewaytrafficstats,verbs=get;list;watch;create;update
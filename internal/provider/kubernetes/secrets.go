@@ -19,9 +19,15 @@ import (
 	"github.com/envoyproxy/gateway/internal/crypto"
 )
 
-// caCertificateKey is the key name for accessing TLS CA certificate bundles
-// in Kubernetes Secrets.
-const caCertificateKey = "ca.crt"
+const (
+	// caCertificateKey is the key name for accessing TLS CA certificate bundles
+	// in Kubernetes Secrets.
+	caCertificateKey = "ca.crt"
+	// caPrivateKeyKey is the key name for accessing the CA private key in
+	// Kubernetes Secrets. It is used by Envoy Gateway to issue per-proxy
+	// certificates.
+	caPrivateKeyKey = "ca.key"
+)
 
 func newSecret(secretType corev1.SecretType, name string, namespace string, data map[string][]byte) corev1.Secret {
 	return corev1.Secret{
@@ -50,6 +56,7 @@ func CertsToSecret(namespace string, certs *crypto.Certificates) []corev1.Secret
 			namespace,
 			map[string][]byte{
 				caCertificateKey:        certs.CACertificate,
+				caPrivateKeyKey:         certs.CAPrivateKey,
 				corev1.TLSCertKey:       certs.EnvoyGatewayCertificate,
 				corev1.TLSPrivateKeyKey: certs.EnvoyGatewayPrivateKey,
 			}),
@@ -0,0 +1,183 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/provider/utils"
+	"github.com/envoyproxy/gateway/internal/status"
+)
+
+type securityPolicyReconciler struct {
+	client        client.Client
+	log           logr.Logger
+	statusUpdater status.Updater
+
+	resources *message.ProviderResources
+}
+
+// newSecurityPolicyController creates the securitypolicy controller from mgr.
+// The controller will be pre-configured to watch for SecurityPolicy objects across
+// all namespaces.
+func newSecurityPolicyController(mgr manager.Manager, cfg *config.Server, su status.Updater, resources *message.ProviderResources) error {
+	r := &securityPolicyReconciler{
+		client:        mgr.GetClient(),
+		log:           cfg.Logger,
+		statusUpdater: su,
+		resources:     resources,
+	}
+
+	c, err := newController("securitypolicy", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+	r.log.Info("created securitypolicy controller")
+
+	// Subscribe to status updates once they are written, i.e. once this
+	// replica is elected leader.
+	go func() {
+		<-su.Enabled()
+		r.subscribeAndUpdateStatus(context.Background())
+	}()
+
+	if err := c.Watch(&source.Kind{Type: &v1alpha1.SecurityPolicy{}}, &handler.EnqueueRequestForObject{}, watchPredicates(cfg)...); err != nil {
+		return err
+	}
+
+	// Watch Service CRUDs and reconcile the policies referencing them, so that
+	// the proxies follow the external authorization services.
+	if err := c.Watch(
+		&source.Kind{Type: &corev1.Service{}},
+		handler.EnqueueRequestsFromMapFunc(r.getSecurityPoliciesForService),
+	); err != nil {
+		return err
+	}
+
+	r.log.Info("watching securitypolicy objects")
+	return nil
+}
+
+// Reconcile stores the reconciled SecurityPolicy in the resource map, or
+// removes it from the map if it no longer exists. The policies are resolved
+// against their target resources by the gateway-api translator.
+func (r *securityPolicyReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("namespace", request.Namespace, "name", request.Name)
+
+	log.Info("reconciling securitypolicy")
+
+	policy := new(v1alpha1.SecurityPolicy)
+	if err := r.client.Get(ctx, request.NamespacedName, policy); err != nil {
+		if kerrors.IsNotFound(err) {
+			r.resources.SecurityPolicies.Delete(request.NamespacedName)
+			r.resources.SecurityPolicyStatuses.Delete(request.NamespacedName)
+			log.Info("deleted securitypolicy from resource map")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get securitypolicy %s: %w", request.NamespacedName, err)
+	}
+
+	r.resources.SecurityPolicies.Store(request.NamespacedName, policy)
+	log.Info("added securitypolicy to resource map")
+
+	// Store the external authorization Service referenced by the policy. A
+	// missing Service is reported in the status of the targeted route by the
+	// gateway-api translator.
+	if key := securityPolicyService(policy); key != nil {
+		svc := new(corev1.Service)
+		if err := r.client.Get(ctx, *key, svc); err != nil {
+			if kerrors.IsNotFound(err) {
+				log.Info("service referenced by securitypolicy not found", "service", key)
+				return reconcile.Result{}, nil
+			}
+			return reconcile.Result{}, fmt.Errorf("failed to get service %s: %w", key, err)
+		}
+		r.resources.Services.Store(*key, svc)
+		log.Info("added service to resource map", "service", key)
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// getSecurityPoliciesForService returns the reconcile requests of the
+// SecurityPolicies referencing the provided Service.
+func (r *securityPolicyReconciler) getSecurityPoliciesForService(obj client.Object) []reconcile.Request {
+	policies := &v1alpha1.SecurityPolicyList{}
+	if err := r.client.List(context.Background(), policies, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.log.Error(err, "failed to list securitypolicies")
+		return []reconcile.Request{}
+	}
+
+	svcKey := utils.NamespacedName(obj)
+	requests := []reconcile.Request{}
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		if key := securityPolicyService(policy); key != nil && *key == svcKey {
+			requests = append(requests, reconcile.Request{NamespacedName: utils.NamespacedName(policy)})
+		}
+	}
+
+	return requests
+}
+
+// securityPolicyService returns the key of the external authorization Service
+// referenced by the provided policy, or nil if it references none. The Service
+// must be in the namespace of the policy.
+func securityPolicyService(policy *v1alpha1.SecurityPolicy) *types.NamespacedName {
+	extAuth := policy.Spec.ExtAuth
+	switch {
+	case extAuth == nil:
+		return nil
+	case extAuth.GRPC != nil:
+		return &types.NamespacedName{Namespace: policy.Namespace, Name: string(extAuth.GRPC.BackendRef.Name)}
+	case extAuth.HTTP != nil:
+		return &types.NamespacedName{Namespace: policy.Namespace, Name: string(extAuth.HTTP.BackendRef.Name)}
+	}
+	return nil
+}
+
+// subscribeAndUpdateStatus subscribes to securitypolicy status updates and writes them
+// into the Kubernetes API Server.
+func (r *securityPolicyReconciler) subscribeAndUpdateStatus(ctx context.Context) {
+	message.HandleSubscription(r.resources.SecurityPolicyStatuses.Subscribe(ctx),
+		func(update message.Update[types.NamespacedName, *v1alpha1.SecurityPolicy]) {
+			// skip delete updates.
+			if update.Delete {
+				return
+			}
+			val := update.Value
+			r.statusUpdater.Send(status.Update{
+				NamespacedName: update.Key,
+				Resource:       new(v1alpha1.SecurityPolicy),
+				Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+					policy, ok := obj.(*v1alpha1.SecurityPolicy)
+					if !ok {
+						panic(fmt.Sprintf("unsupported object type %T", obj))
+					}
+					policyCopy := policy.DeepCopy()
+					policyCopy.Status = val.Status
+					return policyCopy
+				}),
+			})
+		},
+	)
+	r.log.Info("securitypolicy status subscriber shutting down")
+}
@@ -66,7 +66,7 @@ func newTLSRouteController(mgr manager.Manager, cfg *config.Server, su status.Up
 		referenceStore:  referenceStore,
 	}
 
-	c, err := controller.New("tlsroute", mgr, controller.Options{Reconciler: r})
+	c, err := newController("tlsroute", mgr, controller.Options{Reconciler: r})
 	if err != nil {
 		return err
 	}
@@ -75,12 +75,18 @@ func newTLSRouteController(mgr manager.Manager, cfg *config.Server, su status.Up
 	if err := c.Watch(
 		&source.Kind{Type: &gwapiv1a2.TLSRoute{}},
 		&handler.EnqueueRequestForObject{},
+		watchPredicates(cfg)...,
 	); err != nil {
 		return err
 	}
 
-	// Subscribe to status updates
-	go r.subscribeAndUpdateStatus(context.Background())
+	// Subscribe to status updates once they are written, i.e. once this
+	// replica is elected leader, so that the new leader writes the status of
+	// all the resources when taking over.
+	go func() {
+		<-su.Enabled()
+		r.subscribeAndUpdateStatus(context.Background())
+	}()
 
 	// Add indexing on TLSRoute, for Service objects that are referenced in TLSRoute objects
 	// via `.spec.rules.backendRefs`. This helps in querying for TLSRoutes that are affected by
@@ -343,7 +349,9 @@ func (r *tlsRouteReconciler) subscribeAndUpdateStatus(ctx context.Context) {
 						panic(fmt.Sprintf("unsupported object type %T", obj))
 					}
 					tCopy := t.DeepCopy()
-					tCopy.Status.Parents = val.Status.Parents
+					parents := status.MergeRouteParentStatuses(gatewayapi.UpgradeRouteParentStatuses(tCopy.Status.Parents),
+						gatewayapi.UpgradeRouteParentStatuses(val.Status.Parents), r.classController)
+					tCopy.Status.Parents = gatewayapi.DowngradeRouteParentStatuses(parents)
 					return tCopy
 				}),
 			})
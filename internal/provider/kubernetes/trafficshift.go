@@ -0,0 +1,119 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/status"
+)
+
+type trafficShiftReconciler struct {
+	client        client.Client
+	log           logr.Logger
+	statusUpdater status.Updater
+
+	resources *message.ProviderResources
+}
+
+// newTrafficShiftController creates the trafficshift controller from mgr.
+// The controller will be pre-configured to watch for TrafficShift objects across
+// all namespaces.
+func newTrafficShiftController(mgr manager.Manager, cfg *config.Server, su status.Updater, resources *message.ProviderResources) error {
+	r := &trafficShiftReconciler{
+		client:        mgr.GetClient(),
+		log:           cfg.Logger,
+		statusUpdater: su,
+		resources:     resources,
+	}
+
+	c, err := newController("trafficshift", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+	r.log.Info("created trafficshift controller")
+
+	// Subscribe to status updates once they are written, i.e. once this
+	// replica is elected leader.
+	go func() {
+		<-su.Enabled()
+		r.subscribeAndUpdateStatus(context.Background())
+	}()
+
+	if err := c.Watch(&source.Kind{Type: &v1alpha1.TrafficShift{}}, &handler.EnqueueRequestForObject{}, watchPredicates(cfg)...); err != nil {
+		return err
+	}
+
+	r.log.Info("watching trafficshift objects")
+	return nil
+}
+
+// Reconcile stores the reconciled TrafficShift in the resource map, or removes
+// it from the map if it no longer exists. The traffic shifts are resolved
+// against their target routes by the gateway-api translator.
+func (r *trafficShiftReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("namespace", request.Namespace, "name", request.Name)
+
+	log.Info("reconciling trafficshift")
+
+	shift := new(v1alpha1.TrafficShift)
+	if err := r.client.Get(ctx, request.NamespacedName, shift); err != nil {
+		if kerrors.IsNotFound(err) {
+			r.resources.TrafficShifts.Delete(request.NamespacedName)
+			r.resources.TrafficShiftStatuses.Delete(request.NamespacedName)
+			log.Info("deleted trafficshift from resource map")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get trafficshift %s: %w", request.NamespacedName, err)
+	}
+
+	r.resources.TrafficShifts.Store(request.NamespacedName, shift)
+	log.Info("added trafficshift to resource map")
+
+	return reconcile.Result{}, nil
+}
+
+// subscribeAndUpdateStatus subscribes to trafficshift status updates and writes them
+// into the Kubernetes API Server.
+func (r *trafficShiftReconciler) subscribeAndUpdateStatus(ctx context.Context) {
+	message.HandleSubscription(r.resources.TrafficShiftStatuses.Subscribe(ctx),
+		func(update message.Update[types.NamespacedName, *v1alpha1.TrafficShift]) {
+			// skip delete updates.
+			if update.Delete {
+				return
+			}
+			val := update.Value
+			r.statusUpdater.Send(status.Update{
+				NamespacedName: update.Key,
+				Resource:       new(v1alpha1.TrafficShift),
+				Mutator: status.MutatorFunc(func(obj client.Object) client.Object {
+					shift, ok := obj.(*v1alpha1.TrafficShift)
+					if !ok {
+						panic(fmt.Sprintf("unsupported object type %T", obj))
+					}
+					shiftCopy := shift.DeepCopy()
+					shiftCopy.Status = val.Status
+					return shiftCopy
+				}),
+			})
+		},
+	)
+	r.log.Info("trafficshift status subscriber shutting down")
+}
@@ -0,0 +1,100 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+// trafficStatsWriter writes the traffic statistics of the Gateways published
+// by the traffic stats runner to GatewayTrafficStats resources, named after
+// and owned by their Gateway.
+type trafficStatsWriter struct {
+	client    client.Client
+	log       logr.Logger
+	resources *message.ProviderResources
+}
+
+// newTrafficStatsWriter returns a trafficStatsWriter of the traffic
+// statistics published to the provided resources.
+func newTrafficStatsWriter(cli client.Client, log logr.Logger, resources *message.ProviderResources) *trafficStatsWriter {
+	return &trafficStatsWriter{
+		client:    cli,
+		log:       log.WithName("traffic-stats-writer"),
+		resources: resources,
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (w *trafficStatsWriter) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements manager.Runnable.
+func (w *trafficStatsWriter) Start(ctx context.Context) error {
+	message.HandleSubscription(w.resources.GatewayTrafficStats.Subscribe(ctx),
+		func(update message.Update[types.NamespacedName, *v1alpha1.GatewayTrafficStatsStatus]) {
+			// The GatewayTrafficStats are garbage collected with their Gateway.
+			if update.Delete {
+				return
+			}
+			if err := w.write(ctx, update.Key, update.Value); err != nil {
+				w.log.Error(err, "failed to write traffic stats", "gateway", update.Key)
+			}
+		},
+	)
+	return nil
+}
+
+// write creates or updates the GatewayTrafficStats of the Gateway with the
+// provided key. Nothing is written if the Gateway no longer exists.
+func (w *trafficStatsWriter) write(ctx context.Context, key types.NamespacedName, stats *v1alpha1.GatewayTrafficStatsStatus) error {
+	gateway := new(gwapiv1b1.Gateway)
+	if err := w.client.Get(ctx, key, gateway); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get gateway: %w", err)
+	}
+
+	gwStats := new(v1alpha1.GatewayTrafficStats)
+	if err := w.client.Get(ctx, key, gwStats); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get gateway traffic stats: %w", err)
+		}
+		gwStats = &v1alpha1.GatewayTrafficStats{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1alpha1.GroupVersion.String(),
+				Kind:       v1alpha1.KindGatewayTrafficStats,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: key.Namespace,
+				Name:      key.Name,
+			},
+			Status: *stats,
+		}
+		if err := controllerutil.SetControllerReference(gateway, gwStats, envoygateway.GetScheme()); err != nil {
+			return fmt.Errorf("failed to set owner reference: %w", err)
+		}
+		return w.client.Create(ctx, gwStats)
+	}
+
+	gwStats.Status = *stats
+	return w.client.Update(ctx, gwStats)
+}
@@ -0,0 +1,54 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/log"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+func TestTrafficStatsWriter(t *testing.T) {
+	gateway := &gwapiv1b1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway-1", UID: "uid-1"},
+	}
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects(gateway).Build()
+	w := newTrafficStatsWriter(cli, logger, new(message.ProviderResources))
+	ctx := context.Background()
+	key := types.NamespacedName{Namespace: "default", Name: "gateway-1"}
+
+	// The GatewayTrafficStats is created, owned by the Gateway.
+	require.NoError(t, w.write(ctx, key, &v1alpha1.GatewayTrafficStatsStatus{Requests: 10, RequestsPerSecond: "0.17"}))
+	gwStats := new(v1alpha1.GatewayTrafficStats)
+	require.NoError(t, cli.Get(ctx, key, gwStats))
+	require.Equal(t, int64(10), gwStats.Status.Requests)
+	require.Len(t, gwStats.OwnerReferences, 1)
+	require.Equal(t, gateway.UID, gwStats.OwnerReferences[0].UID)
+
+	// The GatewayTrafficStats is updated.
+	require.NoError(t, w.write(ctx, key, &v1alpha1.GatewayTrafficStatsStatus{Requests: 20, Responses5xx: 1}))
+	require.NoError(t, cli.Get(ctx, key, gwStats))
+	require.Equal(t, int64(20), gwStats.Status.Requests)
+	require.Equal(t, int64(1), gwStats.Status.Responses5xx)
+
+	// Nothing is written for a missing Gateway.
+	missing := types.NamespacedName{Namespace: "default", Name: "gateway-2"}
+	require.NoError(t, w.write(ctx, missing, &v1alpha1.GatewayTrafficStatsStatus{Requests: 1}))
+	require.True(t, kerrors.IsNotFound(cli.Get(ctx, missing, new(v1alpha1.GatewayTrafficStats))))
+}
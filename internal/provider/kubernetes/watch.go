@@ -0,0 +1,42 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+)
+
+// setWatchOptions sets the resync period of the cache of the manager, if
+// configured.
+func setWatchOptions(opts *manager.Options, watch *v1alpha1.KubernetesWatch) {
+	if watch.ResyncPeriod != nil && watch.ResyncPeriod.Duration > 0 {
+		opts.SyncPeriod = &watch.ResyncPeriod.Duration
+	}
+}
+
+// watchPredicates returns the predicates of the watches of the GatewayClasses,
+// Gateways, routes and policies. If only the generation changes are watched,
+// the updates changing neither the generation, nor the labels or annotations
+// of the objects are skipped, which includes the status updates written by
+// Envoy Gateway and the periodic resyncs. The labels and annotations are
+// still watched since they drive the translation of some resources.
+func watchPredicates(cfg *config.Server) []predicate.Predicate {
+	kube := cfg.EnvoyGateway.GetProvider().Kubernetes
+	if kube == nil || kube.Watch == nil || !kube.Watch.GenerationChangedOnly {
+		return nil
+	}
+	return []predicate.Predicate{
+		predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.LabelChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+		),
+	}
+}
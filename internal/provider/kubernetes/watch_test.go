@@ -0,0 +1,64 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+)
+
+func TestSetWatchOptions(t *testing.T) {
+	opts := manager.Options{}
+	setWatchOptions(&opts, &v1alpha1.KubernetesWatch{})
+	require.Nil(t, opts.SyncPeriod)
+
+	setWatchOptions(&opts, &v1alpha1.KubernetesWatch{ResyncPeriod: &metav1.Duration{Duration: time.Hour}})
+	require.NotNil(t, opts.SyncPeriod)
+	require.Equal(t, time.Hour, *opts.SyncPeriod)
+}
+
+func TestWatchPredicates(t *testing.T) {
+	svr, err := config.NewDefaultServer()
+	require.NoError(t, err)
+	require.Empty(t, watchPredicates(svr))
+
+	svr.EnvoyGateway.Provider = &v1alpha1.Provider{
+		Type: v1alpha1.ProviderTypeKubernetes,
+		Kubernetes: &v1alpha1.KubernetesProvider{
+			Watch: &v1alpha1.KubernetesWatch{GenerationChangedOnly: true},
+		},
+	}
+	predicates := watchPredicates(svr)
+	require.Len(t, predicates, 1)
+
+	route := &gwapiv1b1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "httproute-1", Generation: 1},
+	}
+
+	// A status update is skipped.
+	updated := route.DeepCopy()
+	updated.Status.Parents = []gwapiv1b1.RouteParentStatus{{ControllerName: "gateway.envoyproxy.io/gatewayclass-controller"}}
+	require.False(t, predicates[0].Update(event.UpdateEvent{ObjectOld: route, ObjectNew: updated}))
+
+	// A spec update is not.
+	updated = route.DeepCopy()
+	updated.Generation = 2
+	require.True(t, predicates[0].Update(event.UpdateEvent{ObjectOld: route, ObjectNew: updated}))
+
+	// Neither is an annotation update.
+	updated = route.DeepCopy()
+	updated.Annotations = map[string]string{"foo": "bar"}
+	require.True(t, predicates[0].Update(event.UpdateEvent{ObjectOld: route, ObjectNew: updated}))
+}
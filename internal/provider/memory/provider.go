@@ -0,0 +1,176 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+// Package memory implements a resource provider keeping the resources in
+// memory, so that the translation of the resources can be tested end to end
+// without a Kubernetes API server.
+package memory
+
+import (
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/provider/utils"
+)
+
+// Provider publishes the resources applied to it to the provider resources,
+// like the Kubernetes provider publishes the resources of the API server. The
+// translator only translates the resources of the first GatewayClass, so a
+// single GatewayClass is expected to be applied.
+type Provider struct {
+	resources *message.ProviderResources
+
+	mu sync.Mutex
+	// gatewayClasses and envoyProxies are the applied GatewayClasses and
+	// EnvoyProxies, kept to publish the EnvoyProxy referenced by each
+	// GatewayClass regardless of the order they are applied in.
+	gatewayClasses map[string]*gwapiv1b1.GatewayClass
+	envoyProxies   map[types.NamespacedName]*v1alpha1.EnvoyProxy
+}
+
+// New returns a provider publishing the resources applied to it to the
+// provided provider resources.
+func New(resources *message.ProviderResources) *Provider {
+	return &Provider{
+		resources:      resources,
+		gatewayClasses: make(map[string]*gwapiv1b1.GatewayClass),
+		envoyProxies:   make(map[types.NamespacedName]*v1alpha1.EnvoyProxy),
+	}
+}
+
+// Apply creates or replaces the provided resources. An error is returned if
+// the kind of a resource is not supported, in which case the resources
+// preceding it are applied.
+func (p *Provider) Apply(objs ...client.Object) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, obj := range objs {
+		key := utils.NamespacedName(obj)
+		switch o := obj.(type) {
+		case *gwapiv1b1.GatewayClass:
+			p.gatewayClasses[o.Name] = o
+			p.resources.GatewayClasses.Store(o.Name, o)
+			p.publishEnvoyProxy(o)
+		case *gwapiv1b1.Gateway:
+			p.resources.Gateways.Store(key, o)
+		case *gwapiv1b1.HTTPRoute:
+			p.resources.HTTPRoutes.Store(key, o)
+		case *gwapiv1a2.TLSRoute:
+			p.resources.TLSRoutes.Store(key, o)
+		case *gwapiv1a2.TCPRoute:
+			p.resources.TCPRoutes.Store(key, o)
+		case *gwapiv1a2.UDPRoute:
+			p.resources.UDPRoutes.Store(key, o)
+		case *gwapiv1a2.ReferenceGrant:
+			p.resources.ReferenceGrants.Store(key, o)
+		case *corev1.Namespace:
+			p.resources.Namespaces.Store(o.Name, o)
+		case *corev1.Service:
+			p.resources.Services.Store(key, o)
+		case *discoveryv1.EndpointSlice:
+			p.resources.EndpointSlices.Store(key, o)
+		case *corev1.Secret:
+			p.resources.Secrets.Store(key, o)
+		case *v1alpha1.EnvoyProxy:
+			p.envoyProxies[key] = o
+			for _, gc := range p.gatewayClasses {
+				p.publishEnvoyProxy(gc)
+			}
+		case *v1alpha1.BackendTrafficPolicy:
+			p.resources.BackendTrafficPolicies.Store(key, o)
+		case *v1alpha1.ClientTrafficPolicy:
+			p.resources.ClientTrafficPolicies.Store(key, o)
+		case *v1alpha1.SecurityPolicy:
+			p.resources.SecurityPolicies.Store(key, o)
+		case *v1alpha1.TrafficShift:
+			p.resources.TrafficShifts.Store(key, o)
+		default:
+			return fmt.Errorf("unsupported resource %T %s", obj, key)
+		}
+	}
+	return nil
+}
+
+// Delete deletes the provided resources, of which only the kind, namespace
+// and name are used. An error is returned if the kind of a resource is not
+// supported, in which case the resources preceding it are deleted.
+func (p *Provider) Delete(objs ...client.Object) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, obj := range objs {
+		key := utils.NamespacedName(obj)
+		switch o := obj.(type) {
+		case *gwapiv1b1.GatewayClass:
+			delete(p.gatewayClasses, o.Name)
+			p.resources.GatewayClasses.Delete(o.Name)
+			p.resources.EnvoyProxies.Delete(o.Name)
+		case *gwapiv1b1.Gateway:
+			p.resources.Gateways.Delete(key)
+		case *gwapiv1b1.HTTPRoute:
+			p.resources.HTTPRoutes.Delete(key)
+		case *gwapiv1a2.TLSRoute:
+			p.resources.TLSRoutes.Delete(key)
+		case *gwapiv1a2.TCPRoute:
+			p.resources.TCPRoutes.Delete(key)
+		case *gwapiv1a2.UDPRoute:
+			p.resources.UDPRoutes.Delete(key)
+		case *gwapiv1a2.ReferenceGrant:
+			p.resources.ReferenceGrants.Delete(key)
+		case *corev1.Namespace:
+			p.resources.Namespaces.Delete(o.Name)
+		case *corev1.Service:
+			p.resources.Services.Delete(key)
+		case *discoveryv1.EndpointSlice:
+			p.resources.EndpointSlices.Delete(key)
+		case *corev1.Secret:
+			p.resources.Secrets.Delete(key)
+		case *v1alpha1.EnvoyProxy:
+			delete(p.envoyProxies, key)
+			for _, gc := range p.gatewayClasses {
+				p.publishEnvoyProxy(gc)
+			}
+		case *v1alpha1.BackendTrafficPolicy:
+			p.resources.BackendTrafficPolicies.Delete(key)
+		case *v1alpha1.ClientTrafficPolicy:
+			p.resources.ClientTrafficPolicies.Delete(key)
+		case *v1alpha1.SecurityPolicy:
+			p.resources.SecurityPolicies.Delete(key)
+		case *v1alpha1.TrafficShift:
+			p.resources.TrafficShifts.Delete(key)
+		default:
+			return fmt.Errorf("unsupported resource %T %s", obj, key)
+		}
+	}
+	return nil
+}
+
+// publishEnvoyProxy publishes the EnvoyProxy referenced by the parametersRef
+// of the GatewayClass, or removes it if the GatewayClass does not reference an
+// applied EnvoyProxy.
+func (p *Provider) publishEnvoyProxy(gc *gwapiv1b1.GatewayClass) {
+	ref := gc.Spec.ParametersRef
+	if ref == nil || string(ref.Group) != v1alpha1.GroupVersion.Group ||
+		string(ref.Kind) != v1alpha1.KindEnvoyProxy || ref.Namespace == nil {
+		p.resources.EnvoyProxies.Delete(gc.Name)
+		return
+	}
+	ep, ok := p.envoyProxies[types.NamespacedName{Namespace: string(*ref.Namespace), Name: ref.Name}]
+	if !ok {
+		p.resources.EnvoyProxies.Delete(gc.Name)
+		return
+	}
+	p.resources.EnvoyProxies.Store(gc.Name, ep)
+}
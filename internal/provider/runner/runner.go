@@ -13,13 +13,20 @@ import (
 
 	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/message"
 	"github.com/envoyproxy/gateway/internal/provider/kubernetes"
+	"github.com/envoyproxy/gateway/internal/xds/audit"
 )
 
 type Config struct {
 	config.Server
 	ProviderResources *message.ProviderResources
+	// AuditTrail is served next to the metrics of Envoy Gateway, if set.
+	AuditTrail *audit.Trail
+	// ReferenceGraphs is served next to the metrics of Envoy Gateway, if
+	// set.
+	ReferenceGraphs *gatewayapi.ReferenceGraphs
 }
 
 type Runner struct {
@@ -36,7 +43,7 @@ func (r *Runner) Name() string {
 
 // Start the provider runner
 func (r *Runner) Start(ctx context.Context) error {
-	r.Logger = r.Logger.WithValues("runner", r.Name())
+	r.Logger = r.ComponentLogger(v1alpha1.LogComponentProvider).WithValues("runner", r.Name())
 	if r.EnvoyGateway.Provider.Type == v1alpha1.ProviderTypeKubernetes {
 		r.Logger.Info("Using provider", "type", v1alpha1.ProviderTypeKubernetes)
 		cfg, err := ctrl.GetConfig()
@@ -47,6 +54,16 @@ func (r *Runner) Start(ctx context.Context) error {
 		if err != nil {
 			return fmt.Errorf("failed to create provider %s: %w", v1alpha1.ProviderTypeKubernetes, err)
 		}
+		if r.AuditTrail != nil {
+			if err := p.AddMetricsExtraHandler(audit.HandlerPath, r.AuditTrail); err != nil {
+				return fmt.Errorf("failed to serve audit trail: %w", err)
+			}
+		}
+		if r.ReferenceGraphs != nil {
+			if err := p.AddMetricsExtraHandler(gatewayapi.ReferenceGraphHandlerPath, r.ReferenceGraphs); err != nil {
+				return fmt.Errorf("failed to serve reference graph: %w", err)
+			}
+		}
 		go func() {
 			err := p.Start(ctx)
 			if err != nil {
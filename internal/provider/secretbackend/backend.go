@@ -0,0 +1,103 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package secretbackend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+// ErrNotFound is returned by the backends when the referenced certificate
+// doesn't exist.
+var ErrNotFound = errors.New("certificate not found")
+
+// Backend retrieves the TLS certificates referenced by the certificateRefs of
+// the Gateway listeners.
+type Backend interface {
+	// GetSecret returns the certificate referenced by the provided key, as a
+	// Secret of type kubernetes.io/tls named after the key.
+	GetSecret(ctx context.Context, key types.NamespacedName) (*corev1.Secret, error)
+	// Watched returns true if the changes of the certificates are watched
+	// through the Kubernetes Secrets, false if the certificates must be
+	// retrieved again periodically.
+	Watched() bool
+}
+
+// New returns the secret backend of the provided configuration. The
+// Kubernetes backend is returned if the configuration is nil.
+func New(cfg *v1alpha1.SecretBackend, reader client.Reader) (Backend, error) {
+	if cfg == nil {
+		return NewKubernetes(reader), nil
+	}
+
+	switch cfg.Type {
+	case v1alpha1.SecretBackendTypeKubernetes:
+		return NewKubernetes(reader), nil
+	case v1alpha1.SecretBackendTypeVault:
+		if cfg.Vault == nil {
+			return nil, fmt.Errorf("vault secret backend is unspecified")
+		}
+		return NewVault(cfg.Vault)
+	case v1alpha1.SecretBackendTypeCSI:
+		if cfg.CSI == nil {
+			return nil, fmt.Errorf("csi secret backend is unspecified")
+		}
+		return NewCSI(cfg.CSI)
+	default:
+		return nil, fmt.Errorf("unsupported secret backend type %q", cfg.Type)
+	}
+}
+
+// kubernetesBackend retrieves the certificates from Kubernetes Secrets.
+type kubernetesBackend struct {
+	reader client.Reader
+}
+
+// NewKubernetes returns the backend retrieving the certificates from the
+// Kubernetes Secrets read through the provided reader.
+func NewKubernetes(reader client.Reader) Backend {
+	return &kubernetesBackend{reader: reader}
+}
+
+func (b *kubernetesBackend) GetSecret(ctx context.Context, key types.NamespacedName) (*corev1.Secret, error) {
+	secret := new(corev1.Secret)
+	if err := b.reader.Get(ctx, key, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+func (b *kubernetesBackend) Watched() bool {
+	return true
+}
+
+// tlsSecret returns the Secret of type kubernetes.io/tls holding the provided
+// certificate and private key, named after the provided key.
+func tlsSecret(key types.NamespacedName, cert, privateKey []byte) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       cert,
+			corev1.TLSPrivateKeyKey: privateKey,
+		},
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package secretbackend
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+var testKey = types.NamespacedName{Namespace: "default", Name: "example-cert"}
+
+func TestNew(t *testing.T) {
+	b, err := New(nil, nil)
+	require.NoError(t, err)
+	require.True(t, b.Watched())
+
+	_, err = New(&v1alpha1.SecretBackend{Type: v1alpha1.SecretBackendTypeVault}, nil)
+	require.Error(t, err)
+
+	_, err = New(&v1alpha1.SecretBackend{
+		Type:  v1alpha1.SecretBackendTypeVault,
+		Vault: &v1alpha1.VaultSecretBackend{Address: "https://vault:8200"},
+	}, nil)
+	require.Error(t, err)
+
+	b, err = New(&v1alpha1.SecretBackend{
+		Type: v1alpha1.SecretBackendTypeCSI,
+		CSI:  &v1alpha1.CSISecretBackend{Path: "/mnt/secrets-store"},
+	}, nil)
+	require.NoError(t, err)
+	require.False(t, b.Watched())
+}
+
+func TestVaultGetSecret(t *testing.T) {
+	dir := t.TempDir()
+	jwtPath := filepath.Join(dir, "jwt")
+	require.NoError(t, os.WriteFile(jwtPath, []byte("service-account-token\n"), 0600))
+
+	logins := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/kubernetes/login":
+			var login map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&login))
+			require.Equal(t, "envoy-gateway", login["role"])
+			require.Equal(t, "service-account-token", login["jwt"])
+			logins++
+			_, _ = w.Write([]byte(`{"auth":{"client_token":"vault-token","lease_duration":3600}}`))
+		case "/v1/secret/data/envoy-gateway/default/example-cert":
+			require.Equal(t, "vault-token", r.Header.Get(vaultTokenHeader))
+			_, _ = w.Write([]byte(`{"data":{"data":{"tls.crt":"cert","tls.key":"key"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	b, err := NewVault(&v1alpha1.VaultSecretBackend{
+		Address:        server.URL,
+		PathPrefix:     "envoy-gateway",
+		KubernetesAuth: &v1alpha1.VaultKubernetesAuth{Role: "envoy-gateway"},
+	})
+	require.NoError(t, err)
+	b.(*vaultBackend).jwtPath = jwtPath
+
+	for i := 0; i < 2; i++ {
+		secret, err := b.GetSecret(context.Background(), testKey)
+		require.NoError(t, err)
+		require.Equal(t, testKey.Name, secret.Name)
+		require.Equal(t, testKey.Namespace, secret.Namespace)
+		require.Equal(t, corev1.SecretTypeTLS, secret.Type)
+		require.Equal(t, []byte("cert"), secret.Data[corev1.TLSCertKey])
+		require.Equal(t, []byte("key"), secret.Data[corev1.TLSPrivateKeyKey])
+	}
+	// The token is cached until half of its lease elapsed.
+	require.Equal(t, 1, logins)
+
+	_, err = b.GetSecret(context.Background(), types.NamespacedName{Namespace: "default", Name: "missing"})
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestVaultGetSecretTokenPath(t *testing.T) {
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte("vault-token"), 0600))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/kv/data/default/example-cert" || r.Header.Get(vaultTokenHeader) != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"data":{"tls.crt":"cert","tls.key":"key"}}}`))
+	}))
+	defer server.Close()
+
+	b, err := NewVault(&v1alpha1.VaultSecretBackend{
+		Address:   server.URL + "/",
+		Mount:     "kv",
+		TokenPath: &tokenPath,
+	})
+	require.NoError(t, err)
+
+	secret, err := b.GetSecret(context.Background(), testKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("cert"), secret.Data[corev1.TLSCertKey])
+
+	require.NoError(t, os.WriteFile(tokenPath, []byte("revoked-token"), 0600))
+	_, err = b.GetSecret(context.Background(), testKey)
+	require.Error(t, err)
+}
+
+func TestCSIGetSecret(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "default_example-cert.crt"), []byte("cert"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "default_example-cert.key"), []byte("key"), 0600))
+
+	b, err := NewCSI(&v1alpha1.CSISecretBackend{Path: dir})
+	require.NoError(t, err)
+
+	secret, err := b.GetSecret(context.Background(), testKey)
+	require.NoError(t, err)
+	require.Equal(t, corev1.SecretTypeTLS, secret.Type)
+	require.Equal(t, []byte("cert"), secret.Data[corev1.TLSCertKey])
+	require.Equal(t, []byte("key"), secret.Data[corev1.TLSPrivateKeyKey])
+
+	_, err = b.GetSecret(context.Background(), types.NamespacedName{Namespace: "other", Name: "example-cert"})
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,60 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package secretbackend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+// csiBackend reads the certificates from the volume mounted by the Secrets
+// Store CSI driver.
+type csiBackend struct {
+	path string
+}
+
+// NewCSI returns the backend reading the certificates from the provided CSI
+// volume.
+func NewCSI(cfg *v1alpha1.CSISecretBackend) (Backend, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("csi volume path is unspecified")
+	}
+	return &csiBackend{path: cfg.Path}, nil
+}
+
+func (b *csiBackend) GetSecret(_ context.Context, key types.NamespacedName) (*corev1.Secret, error) {
+	// Namespaces can't contain underscores, so the file names are unambiguous.
+	base := filepath.Join(b.path, key.Namespace+"_"+key.Name)
+	cert, err := os.ReadFile(base + ".crt")
+	if err != nil {
+		return nil, csiError(key, err)
+	}
+	privateKey, err := os.ReadFile(base + ".key")
+	if err != nil {
+		return nil, csiError(key, err)
+	}
+	return tlsSecret(key, cert, privateKey), nil
+}
+
+func (b *csiBackend) Watched() bool {
+	return false
+}
+
+func csiError(key types.NamespacedName, err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return fmt.Errorf("failed to read certificate %s: %w", key, err)
+}
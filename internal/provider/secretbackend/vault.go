@@ -0,0 +1,212 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package secretbackend
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+const (
+	// serviceAccountTokenPath is the path of the service account token of
+	// Envoy Gateway, presented to the Vault Kubernetes auth method.
+	serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	// vaultTokenHeader is the header holding the Vault token of the requests.
+	vaultTokenHeader = "X-Vault-Token"
+	// vaultRequestTimeout is the timeout of the requests to Vault.
+	vaultRequestTimeout = 10 * time.Second
+)
+
+// vaultBackend reads the certificates from a Vault KV version 2 secrets
+// engine.
+type vaultBackend struct {
+	cfg    *v1alpha1.VaultSecretBackend
+	client *http.Client
+	// jwtPath is the path of the token presented to the Kubernetes auth
+	// method.
+	jwtPath string
+
+	mu sync.Mutex
+	// token is the token obtained from the Kubernetes auth method, valid
+	// until expiry.
+	token  string
+	expiry time.Time
+}
+
+// NewVault returns the backend reading the certificates from the provided
+// Vault server.
+func NewVault(cfg *v1alpha1.VaultSecretBackend) (Backend, error) {
+	if cfg.Address == "" {
+		return nil, fmt.Errorf("vault address is unspecified")
+	}
+	if (cfg.KubernetesAuth == nil) == (cfg.TokenPath == nil) {
+		return nil, fmt.Errorf("exactly one of vault kubernetesAuth and tokenPath must be specified")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.CACertificatePath != nil {
+		ca, err := os.ReadFile(*cfg.CACertificatePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read vault ca certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("failed to parse vault ca certificate %s", *cfg.CACertificatePath)
+		}
+		transport.TLSClientConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			RootCAs:    pool,
+		}
+	}
+
+	return &vaultBackend{
+		cfg:     cfg,
+		client:  &http.Client{Transport: transport, Timeout: vaultRequestTimeout},
+		jwtPath: serviceAccountTokenPath,
+	}, nil
+}
+
+func (b *vaultBackend) GetSecret(ctx context.Context, key types.NamespacedName) (*corev1.Secret, error) {
+	token, err := b.getToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mount := b.cfg.Mount
+	if mount == "" {
+		mount = v1alpha1.DefaultVaultMount
+	}
+	url := b.url(mount, "data", b.cfg.PathPrefix, key.Namespace, key.Name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(vaultTokenHeader, token)
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate %s from vault: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	case http.StatusForbidden:
+		// The token may have been revoked, log in again next time.
+		b.resetToken()
+		return nil, fmt.Errorf("failed to read certificate %s from vault: permission denied", key)
+	default:
+		return nil, fmt.Errorf("failed to read certificate %s from vault: unexpected status %d", key, resp.StatusCode)
+	}
+
+	var secret struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return nil, fmt.Errorf("failed to decode certificate %s from vault: %w", key, err)
+	}
+	data := secret.Data.Data
+	return tlsSecret(key, []byte(data[corev1.TLSCertKey]), []byte(data[corev1.TLSPrivateKeyKey])), nil
+}
+
+func (b *vaultBackend) Watched() bool {
+	return false
+}
+
+// url returns the URL of the provided path of the Vault API.
+func (b *vaultBackend) url(elems ...string) string {
+	return strings.TrimSuffix(b.cfg.Address, "/") + path.Join(append([]string{"/v1"}, elems...)...)
+}
+
+// getToken returns the Vault token, read from the token file or obtained from
+// the Kubernetes auth method if the cached token expired.
+func (b *vaultBackend) getToken(ctx context.Context) (string, error) {
+	if b.cfg.TokenPath != nil {
+		token, err := os.ReadFile(*b.cfg.TokenPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read vault token: %w", err)
+		}
+		return strings.TrimSpace(string(token)), nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.token != "" && time.Now().Before(b.expiry) {
+		return b.token, nil
+	}
+
+	jwt, err := os.ReadFile(b.jwtPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read service account token: %w", err)
+	}
+	body, err := json.Marshal(map[string]string{
+		"role": b.cfg.KubernetesAuth.Role,
+		"jwt":  strings.TrimSpace(string(jwt)),
+	})
+	if err != nil {
+		return "", err
+	}
+	mount := b.cfg.KubernetesAuth.Mount
+	if mount == "" {
+		mount = v1alpha1.DefaultVaultKubernetesAuthMount
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.url("auth", mount, "login"), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to log in to vault: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to log in to vault: unexpected status %d", resp.StatusCode)
+	}
+
+	var login struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int64  `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&login); err != nil {
+		return "", fmt.Errorf("failed to decode vault login: %w", err)
+	}
+	if login.Auth.ClientToken == "" {
+		return "", fmt.Errorf("vault login returned no token")
+	}
+
+	// Log in again once half of the lease has elapsed, to not use a token
+	// about to expire.
+	b.token = login.Auth.ClientToken
+	b.expiry = time.Now().Add(time.Duration(login.Auth.LeaseDuration) * time.Second / 2)
+	return b.token, nil
+}
+
+func (b *vaultBackend) resetToken() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.token = ""
+}
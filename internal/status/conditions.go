@@ -15,10 +15,14 @@ package status
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"golang.org/x/exp/slices"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
@@ -71,6 +75,13 @@ func computeGatewayReadyCondition(gw *gwapiv1b1.Gateway, deployment *appsv1.Depl
 			"No addresses have been assigned to the Gateway", time.Now(), gw.Generation)
 	}
 
+	// Surface the addresses requested through the Gateway spec that
+	// could not be honored.
+	if msg := unassignedAddressesMessage(gw); len(msg) > 0 {
+		return newCondition(string(gwapiv1b1.GatewayConditionReady), metav1.ConditionFalse,
+			string(gwapiv1b1.GatewayReasonAddressNotAssigned), msg, time.Now(), gw.Generation)
+	}
+
 	// If there are no available replicas for the Envoy Deployment, don't
 	// mark the Gateway as ready yet.
 
@@ -86,33 +97,159 @@ func computeGatewayReadyCondition(gw *gwapiv1b1.Gateway, deployment *appsv1.Depl
 		string(gwapiv1b1.GatewayReasonReady), message, time.Now(), gw.Generation)
 }
 
+// unassignedAddressesMessage returns a message describing the addresses requested
+// through spec.addresses of the provided Gateway that have not been assigned, or
+// an empty string if all requested addresses have been assigned.
+func unassignedAddressesMessage(gw *gwapiv1b1.Gateway) string {
+	assigned := sets.NewString()
+	for _, addr := range gw.Status.Addresses {
+		assigned.Insert(addr.Value)
+	}
+
+	var unsupported, unassigned []string
+	for _, addr := range gw.Spec.Addresses {
+		switch {
+		case addr.Type != nil && *addr.Type != gwapiv1b1.IPAddressType:
+			unsupported = append(unsupported, addr.Value)
+		case !assigned.Has(addr.Value):
+			unassigned = append(unassigned, addr.Value)
+		}
+	}
+
+	var msgs []string
+	if len(unsupported) > 0 {
+		msgs = append(msgs, fmt.Sprintf("Only IPAddress type addresses are supported, unsupported addresses: %s",
+			strings.Join(unsupported, ", ")))
+	}
+	if len(unassigned) > 0 {
+		msgs = append(msgs, fmt.Sprintf("Requested addresses have not been assigned to the Gateway: %s",
+			strings.Join(unassigned, ", ")))
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
 // MergeConditions adds or updates matching conditions, and updates the transition
 // time if details of a condition have changed. Returns the updated condition array.
 func MergeConditions(conditions []metav1.Condition, updates ...metav1.Condition) []metav1.Condition {
-	var additions []metav1.Condition
-	for i, update := range updates {
+	// Only the existing conditions are matched, the additions are appended
+	// in place.
+	existing := len(conditions)
+	for i := range updates {
+		update := &updates[i]
 		add := true
-		for j, cond := range conditions {
+		for j := 0; j < existing; j++ {
+			cond := &conditions[j]
 			if cond.Type == update.Type {
 				add = false
-				if conditionChanged(cond, update) {
-					conditions[j].Status = update.Status
-					conditions[j].Reason = update.Reason
-					conditions[j].Message = update.Message
-					conditions[j].ObservedGeneration = update.ObservedGeneration
-					conditions[j].LastTransitionTime = update.LastTransitionTime
+				if conditionChanged(*cond, *update) {
+					cond.Status = update.Status
+					cond.Reason = update.Reason
+					cond.Message = update.Message
+					cond.ObservedGeneration = update.ObservedGeneration
+					cond.LastTransitionTime = update.LastTransitionTime
 					break
 				}
 			}
 		}
 		if add {
-			additions = append(additions, updates[i])
+			conditions = append(conditions, *update)
 		}
 	}
-	conditions = append(conditions, additions...)
 	return conditions
 }
 
+// MaxConditions is the maximum number of conditions of a Gateway API status
+// condition list, as enforced by the Gateway API CRDs.
+const MaxConditions = 8
+
+// DedupeConditions returns the conditions with a single condition per type,
+// keeping the most recently transitioned one at the position of the first
+// occurrence of the type.
+func DedupeConditions(conditions []metav1.Condition) []metav1.Condition {
+	if !hasDuplicateConditions(conditions) {
+		return conditions
+	}
+
+	// The condition lists are short, so the types are matched linearly.
+	deduped := make([]metav1.Condition, 0, len(conditions))
+	for i := range conditions {
+		cond := &conditions[i]
+		j := indexOfCondition(deduped, cond.Type)
+		if j < 0 {
+			deduped = append(deduped, *cond)
+			continue
+		}
+		if !cond.LastTransitionTime.Before(&deduped[j].LastTransitionTime) {
+			deduped[j] = *cond
+		}
+	}
+	return deduped
+}
+
+// hasDuplicateConditions returns whether several conditions have the same type.
+func hasDuplicateConditions(conditions []metav1.Condition) bool {
+	for i := 1; i < len(conditions); i++ {
+		if indexOfCondition(conditions[:i], conditions[i].Type) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// indexOfCondition returns the index of the condition of the provided type,
+// or -1 if there is none.
+func indexOfCondition(conditions []metav1.Condition, conditionType string) int {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return i
+		}
+	}
+	return -1
+}
+
+// PruneConditions drops the conditions of the owned types that are stale,
+// i.e. observed a generation older than the provided one, or whose status is
+// Unknown. Conditions of other types are kept since they may be owned by
+// other controllers.
+func PruneConditions(conditions []metav1.Condition, generation int64, ownedTypes ...string) []metav1.Condition {
+	pruned := make([]metav1.Condition, 0, len(conditions))
+	for i := range conditions {
+		cond := &conditions[i]
+		if slices.Contains(ownedTypes, cond.Type) &&
+			(cond.ObservedGeneration < generation || cond.Status == metav1.ConditionUnknown) {
+			continue
+		}
+		pruned = append(pruned, *cond)
+	}
+	return pruned
+}
+
+// CapConditions returns at most max conditions, dropping the least recently
+// transitioned ones. The order of the kept conditions is preserved.
+func CapConditions(conditions []metav1.Condition, max int) []metav1.Condition {
+	if max < 0 || len(conditions) <= max {
+		return conditions
+	}
+
+	idx := make([]int, len(conditions))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return conditions[idx[j]].LastTransitionTime.Before(&conditions[idx[i]].LastTransitionTime)
+	})
+	keep := sets.NewInt(idx[:max]...)
+
+	capped := make([]metav1.Condition, 0, max)
+	for i, cond := range conditions {
+		if keep.Has(i) {
+			capped = append(capped, cond)
+		}
+	}
+	return capped
+}
+
 func newCondition(t string, status metav1.ConditionStatus, reason, msg string, lt time.Time, og int64) metav1.Condition {
 	return metav1.Condition{
 		Type:               t,
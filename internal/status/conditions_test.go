@@ -19,6 +19,7 @@ import (
 
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilclock "k8s.io/utils/clock"
@@ -70,6 +71,30 @@ func TestComputeGatewayClassAcceptedCondition(t *testing.T) {
 	}
 }
 
+func TestSetGatewayClassAccepted(t *testing.T) {
+	gc := &gwapiv1b1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Generation: 7,
+		},
+	}
+
+	gc = SetGatewayClassAccepted(gc, true)
+	require.Len(t, gc.Status.Conditions, 2)
+	supported := gc.Status.Conditions[1]
+	assert.Equal(t, GatewayClassConditionSupportedFeatures, supported.Type)
+	assert.Equal(t, metav1.ConditionTrue, supported.Status)
+	assert.Equal(t, ReasonSupportedFeatures, supported.Reason)
+	assert.Equal(t, "Supported extended features: HTTPRouteQueryParamMatching, ReferenceGrant", supported.Message)
+	assert.Equal(t, gc.Generation, supported.ObservedGeneration)
+
+	// The features are not published on a GatewayClass which is no longer
+	// accepted.
+	gc = SetGatewayClassAccepted(gc, false)
+	require.Len(t, gc.Status.Conditions, 1)
+	assert.Equal(t, string(gwapiv1b1.GatewayClassConditionStatusAccepted), gc.Status.Conditions[0].Type)
+	assert.Equal(t, metav1.ConditionFalse, gc.Status.Conditions[0].Status)
+}
+
 func TestComputeGatewayScheduledCondition(t *testing.T) {
 	testCases := []struct {
 		name   string
@@ -249,6 +274,7 @@ func TestGatewayReadyCondition(t *testing.T) {
 	testCases := []struct {
 		name             string
 		serviceAddress   bool
+		specAddresses    []gwapiv1b1.GatewayAddress
 		deploymentStatus appsv1.DeploymentStatus
 		expect           metav1.Condition
 	}{
@@ -270,6 +296,46 @@ func TestGatewayReadyCondition(t *testing.T) {
 				Reason: string(gwapiv1b1.GatewayReasonAddressNotAssigned),
 			},
 		},
+		{
+			name:             "ready gateway with requested address",
+			serviceAddress:   true,
+			specAddresses:    []gwapiv1b1.GatewayAddress{{Value: "1.1.1.1"}},
+			deploymentStatus: appsv1.DeploymentStatus{AvailableReplicas: 1},
+			expect: metav1.Condition{
+				Status: metav1.ConditionTrue,
+				Reason: string(gwapiv1b1.GatewayReasonReady),
+			},
+		},
+		{
+			name:           "not ready gateway with unassigned requested address",
+			serviceAddress: true,
+			specAddresses: []gwapiv1b1.GatewayAddress{
+				{
+					Type:  gatewayapi.GatewayAddressTypePtr(gwapiv1b1.IPAddressType),
+					Value: "2.2.2.2",
+				},
+			},
+			deploymentStatus: appsv1.DeploymentStatus{AvailableReplicas: 1},
+			expect: metav1.Condition{
+				Status: metav1.ConditionFalse,
+				Reason: string(gwapiv1b1.GatewayReasonAddressNotAssigned),
+			},
+		},
+		{
+			name:           "not ready gateway with unsupported requested address type",
+			serviceAddress: true,
+			specAddresses: []gwapiv1b1.GatewayAddress{
+				{
+					Type:  gatewayapi.GatewayAddressTypePtr(gwapiv1b1.HostnameAddressType),
+					Value: "lb.example.com",
+				},
+			},
+			deploymentStatus: appsv1.DeploymentStatus{AvailableReplicas: 1},
+			expect: metav1.Condition{
+				Status: metav1.ConditionFalse,
+				Reason: string(gwapiv1b1.GatewayReasonAddressNotAssigned),
+			},
+		},
 		{
 			name:             "not ready gateway with address unavailable pods",
 			serviceAddress:   true,
@@ -286,6 +352,7 @@ func TestGatewayReadyCondition(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 			gtw := &gwapiv1b1.Gateway{}
+			gtw.Spec.Addresses = tc.specAddresses
 			if tc.serviceAddress {
 				gtw.Status = gwapiv1b1.GatewayStatus{
 					Addresses: []gwapiv1b1.GatewayAddress{
@@ -306,3 +373,86 @@ func TestGatewayReadyCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestDedupeConditions(t *testing.T) {
+	older := metav1.NewTime(time.Unix(100, 0))
+	newer := metav1.NewTime(time.Unix(200, 0))
+	conditions := []metav1.Condition{
+		{Type: "Ready", Status: metav1.ConditionTrue, LastTransitionTime: newer},
+		{Type: "Accepted", Status: metav1.ConditionTrue, LastTransitionTime: older},
+		{Type: "Ready", Status: metav1.ConditionFalse, LastTransitionTime: older},
+		{Type: "Accepted", Status: metav1.ConditionFalse, LastTransitionTime: newer},
+	}
+
+	got := DedupeConditions(conditions)
+	assert.Equal(t, []metav1.Condition{
+		{Type: "Ready", Status: metav1.ConditionTrue, LastTransitionTime: newer},
+		{Type: "Accepted", Status: metav1.ConditionFalse, LastTransitionTime: newer},
+	}, got)
+}
+
+func TestPruneConditions(t *testing.T) {
+	conditions := []metav1.Condition{
+		{Type: "Ready", Status: metav1.ConditionTrue, ObservedGeneration: 1},
+		{Type: "Accepted", Status: metav1.ConditionUnknown, ObservedGeneration: 2},
+		{Type: "ResolvedRefs", Status: metav1.ConditionTrue, ObservedGeneration: 2},
+		{Type: "Other", Status: metav1.ConditionUnknown, ObservedGeneration: 1},
+	}
+
+	got := PruneConditions(conditions, 2, "Ready", "Accepted", "ResolvedRefs")
+	assert.Equal(t, []metav1.Condition{
+		{Type: "ResolvedRefs", Status: metav1.ConditionTrue, ObservedGeneration: 2},
+		{Type: "Other", Status: metav1.ConditionUnknown, ObservedGeneration: 1},
+	}, got)
+}
+
+func TestCapConditions(t *testing.T) {
+	conditions := []metav1.Condition{
+		{Type: "A", LastTransitionTime: metav1.NewTime(time.Unix(300, 0))},
+		{Type: "B", LastTransitionTime: metav1.NewTime(time.Unix(100, 0))},
+		{Type: "C", LastTransitionTime: metav1.NewTime(time.Unix(200, 0))},
+	}
+
+	assert.Equal(t, conditions, CapConditions(conditions, 3))
+	got := CapConditions(conditions, 2)
+	assert.Len(t, got, 2)
+	assert.Equal(t, "A", got[0].Type)
+	assert.Equal(t, "C", got[1].Type)
+}
+
+// benchmarkConditions returns the conditions of the parents of n routes,
+// accepted and with resolved references.
+func benchmarkConditions(n int) [][]metav1.Condition {
+	now := time.Now()
+	conditions := make([][]metav1.Condition, n)
+	for i := range conditions {
+		conditions[i] = []metav1.Condition{
+			newCondition(string(gwapiv1b1.RouteConditionAccepted), metav1.ConditionTrue, "Accepted", "Route is accepted", now, 1),
+			newCondition(string(gwapiv1b1.RouteConditionResolvedRefs), metav1.ConditionTrue, "ResolvedRefs", "Resolved all the Object references for the Route", now, 1),
+		}
+	}
+	return conditions
+}
+
+func BenchmarkMergeConditions(b *testing.B) {
+	conditions := benchmarkConditions(1000)
+	update := newCondition(string(gwapiv1b1.RouteConditionAccepted), metav1.ConditionFalse, "NotAllowedByListeners", "Route is not allowed", time.Now(), 2)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, conds := range conditions {
+			MergeConditions(conds, update)
+		}
+	}
+}
+
+func BenchmarkDedupeConditions(b *testing.B) {
+	conditions := benchmarkConditions(1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, conds := range conditions {
+			CapConditions(DedupeConditions(conds), MaxConditions)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package status
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+// rejection is a condition of the status of an object reporting that the
+// object, one of its listeners or one of its parentRefs was rejected by the
+// translation.
+type rejection struct {
+	// subject is the listener or parentRef the condition applies to, empty
+	// if the condition applies to the object itself.
+	subject   string
+	condition metav1.Condition
+}
+
+// message returns the message of the Event of the rejection.
+func (r rejection) message() string {
+	if r.subject == "" {
+		return r.condition.Message
+	}
+	return fmt.Sprintf("%s: %s", r.subject, r.condition.Message)
+}
+
+// isRejection returns whether the condition reports a rejection, i.e. an
+// Accepted or ResolvedRefs condition set to False, or a Conflicted condition
+// set to True.
+func isRejection(cond metav1.Condition) bool {
+	switch cond.Type {
+	case string(gwapiv1b1.RouteConditionAccepted), string(gwapiv1b1.RouteConditionResolvedRefs):
+		return cond.Status == metav1.ConditionFalse
+	case string(gwapiv1b1.ListenerConditionConflicted):
+		return cond.Status == metav1.ConditionTrue
+	}
+	return false
+}
+
+// rejections returns the rejections reported by the status of the provided
+// Gateway or route. Only the route parent statuses written by Envoy Gateway
+// are considered.
+func rejections(obj client.Object) []rejection {
+	var rejections []rejection
+	add := func(subject string, conditions []metav1.Condition) {
+		for _, cond := range conditions {
+			if isRejection(cond) {
+				rejections = append(rejections, rejection{subject: subject, condition: cond})
+			}
+		}
+	}
+	addParent := func(controllerName string, ref string, conditions []metav1.Condition) {
+		if controllerName == v1alpha1.GatewayControllerName {
+			add(fmt.Sprintf("parentRef %s", ref), conditions)
+		}
+	}
+
+	switch o := obj.(type) {
+	case *gwapiv1b1.Gateway:
+		add("", o.Status.Conditions)
+		for _, listener := range o.Status.Listeners {
+			add(fmt.Sprintf("listener %s", listener.Name), listener.Conditions)
+		}
+	case *gwapiv1b1.HTTPRoute:
+		for _, parent := range o.Status.Parents {
+			addParent(string(parent.ControllerName),
+				parentRefString(string(parent.ParentRef.Name), parent.ParentRef.Namespace, parent.ParentRef.SectionName), parent.Conditions)
+		}
+	case *gwapiv1a2.TLSRoute:
+		for _, parent := range o.Status.Parents {
+			addParent(string(parent.ControllerName),
+				parentRefString(string(parent.ParentRef.Name), parent.ParentRef.Namespace, parent.ParentRef.SectionName), parent.Conditions)
+		}
+	case *gwapiv1a2.TCPRoute:
+		for _, parent := range o.Status.Parents {
+			addParent(string(parent.ControllerName),
+				parentRefString(string(parent.ParentRef.Name), parent.ParentRef.Namespace, parent.ParentRef.SectionName), parent.Conditions)
+		}
+	case *gwapiv1a2.UDPRoute:
+		for _, parent := range o.Status.Parents {
+			addParent(string(parent.ControllerName),
+				parentRefString(string(parent.ParentRef.Name), parent.ParentRef.Namespace, parent.ParentRef.SectionName), parent.Conditions)
+		}
+	}
+	return rejections
+}
+
+// newRejections returns the rejections of newObj which oldObj doesn't
+// report already with the same reason and message.
+func newRejections(oldObj, newObj client.Object) []rejection {
+	old := make(map[rejection]bool)
+	for _, r := range rejections(oldObj) {
+		old[rejectionKey(r)] = true
+	}
+
+	var added []rejection
+	for _, r := range rejections(newObj) {
+		if !old[rejectionKey(r)] {
+			added = append(added, r)
+		}
+	}
+	return added
+}
+
+// rejectionKey returns the rejection stripped of the condition fields which
+// change without the rejection changing.
+func rejectionKey(r rejection) rejection {
+	return rejection{
+		subject: r.subject,
+		condition: metav1.Condition{
+			Type:    r.condition.Type,
+			Status:  r.condition.Status,
+			Reason:  r.condition.Reason,
+			Message: r.condition.Message,
+		},
+	}
+}
+
+// recordRejections records a Warning Event on newObj for each rejection of
+// newObj that oldObj doesn't report, so that they are shown by kubectl
+// describe. Nothing is recorded without an event recorder.
+func (u *UpdateHandler) recordRejections(oldObj, newObj client.Object) {
+	if u.recorder == nil {
+		return
+	}
+	for _, r := range newRejections(oldObj, newObj) {
+		u.recorder.Event(newObj, corev1.EventTypeWarning, r.condition.Reason, r.message())
+	}
+}
+
+// parentRefString returns the namespace/name of a parentRef, and its
+// section name if set. The namespace and section name are the pointers of
+// any version of the Gateway API.
+func parentRefString[N, S ~string](name string, namespace *N, sectionName *S) string {
+	s := name
+	if namespace != nil {
+		s = fmt.Sprintf("%s/%s", string(*namespace), s)
+	}
+	if sectionName != nil {
+		s = fmt.Sprintf("%s/%s", s, string(*sectionName))
+	}
+	return s
+}
@@ -0,0 +1,111 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package status
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+func TestRecordRejections(t *testing.T) {
+	accepted := metav1.Condition{
+		Type:   string(gwapiv1b1.RouteConditionAccepted),
+		Status: metav1.ConditionTrue,
+		Reason: string(gwapiv1b1.RouteReasonAccepted),
+	}
+	unresolved := metav1.Condition{
+		Type:    string(gwapiv1b1.RouteConditionResolvedRefs),
+		Status:  metav1.ConditionFalse,
+		Reason:  string(gwapiv1b1.RouteReasonBackendNotFound),
+		Message: "Service default/service-1 not found",
+	}
+	route := func(controllerName string, conditions ...metav1.Condition) *gwapiv1b1.HTTPRoute {
+		return &gwapiv1b1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "httproute-1"},
+			Status: gwapiv1b1.HTTPRouteStatus{
+				RouteStatus: gwapiv1b1.RouteStatus{
+					Parents: []gwapiv1b1.RouteParentStatus{{
+						ParentRef:      gwapiv1b1.ParentReference{Name: "gateway-1"},
+						ControllerName: gwapiv1b1.GatewayController(controllerName),
+						Conditions:     conditions,
+					}},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		oldObj *gwapiv1b1.HTTPRoute
+		newObj *gwapiv1b1.HTTPRoute
+		events []string
+	}{
+		{
+			name:   "new rejection",
+			oldObj: route(v1alpha1.GatewayControllerName, accepted),
+			newObj: route(v1alpha1.GatewayControllerName, accepted, unresolved),
+			events: []string{"Warning BackendNotFound parentRef gateway-1: Service default/service-1 not found"},
+		},
+		{
+			name:   "unchanged rejection",
+			oldObj: route(v1alpha1.GatewayControllerName, accepted, unresolved),
+			newObj: route(v1alpha1.GatewayControllerName, accepted, unresolved),
+		},
+		{
+			name:   "accepted",
+			oldObj: route(v1alpha1.GatewayControllerName, unresolved),
+			newObj: route(v1alpha1.GatewayControllerName, accepted),
+		},
+		{
+			name:   "other controller",
+			oldObj: route("example.com/gateway-controller"),
+			newObj: route("example.com/gateway-controller", unresolved),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			u := NewUpdateHandler(logr.Discard(), nil, recorder)
+			u.recordRejections(tc.oldObj, tc.newObj)
+			close(recorder.Events)
+
+			var events []string
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			require.Equal(t, tc.events, events)
+		})
+	}
+}
+
+func TestGatewayRejections(t *testing.T) {
+	gw := &gwapiv1b1.Gateway{
+		Status: gwapiv1b1.GatewayStatus{
+			Listeners: []gwapiv1b1.ListenerStatus{{
+				Name: "http",
+				Conditions: []metav1.Condition{{
+					Type:    string(gwapiv1b1.ListenerConditionConflicted),
+					Status:  metav1.ConditionTrue,
+					Reason:  string(gwapiv1b1.ListenerReasonHostnameConflict),
+					Message: "All listeners for a given port must use a unique hostname",
+				}},
+			}},
+		},
+	}
+
+	got := rejections(gw)
+	require.Len(t, got, 1)
+	require.Equal(t, "listener http: All listeners for a given port must use a unique hostname", got[0].message())
+}
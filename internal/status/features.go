@@ -0,0 +1,49 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package status
+
+import (
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+const (
+	// GatewayClassConditionSupportedFeatures is the type of the GatewayClass
+	// condition listing the SupportedFeatures. GatewayClassStatus has no
+	// SupportedFeatures field in Gateway API v0.5.1, so the features are
+	// published in the message of this condition instead.
+	GatewayClassConditionSupportedFeatures = "SupportedFeatures"
+
+	// ReasonSupportedFeatures is the reason of the SupportedFeatures
+	// condition.
+	ReasonSupportedFeatures = "SupportedFeatures"
+
+	// supportedFeaturesPrefix prefixes the comma-separated features in the
+	// message of the SupportedFeatures condition.
+	supportedFeaturesPrefix = "Supported extended features: "
+)
+
+// SupportedFeatures holds the extended Gateway API features implemented by
+// Envoy Gateway, named after the conformance suite features. It must be kept
+// up to date on every release, since it is both used to run the conformance
+// tests and published on the status of the accepted GatewayClass for users to
+// introspect its capabilities.
+var SupportedFeatures = []string{
+	"HTTPRouteQueryParamMatching",
+	"ReferenceGrant",
+}
+
+// computeGatewayClassSupportedFeaturesCondition computes the GatewayClass
+// SupportedFeatures status condition, whose message lists the
+// SupportedFeatures.
+func computeGatewayClassSupportedFeaturesCondition(gatewayClass *gwapiv1b1.GatewayClass) metav1.Condition {
+	return newCondition(GatewayClassConditionSupportedFeatures, metav1.ConditionTrue,
+		ReasonSupportedFeatures, supportedFeaturesPrefix+strings.Join(SupportedFeatures, ", "),
+		time.Now(), gatewayClass.Generation)
+}
@@ -8,6 +8,7 @@ package status
 import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
@@ -62,3 +63,42 @@ func UpdateGatewayStatusReadyCondition(gw *gwapiv1b1.Gateway, svc *corev1.Servic
 	// Update the ready condition.
 	gw.Status.Conditions = MergeConditions(gw.Status.Conditions, computeGatewayReadyCondition(gw, deployment))
 }
+
+// UpdateGatewayStatusMaintenanceCondition sets the Maintenance condition of the
+// translated Gateway on the provided Gateway, or removes it if the translated
+// Gateway is not in maintenance mode.
+func UpdateGatewayStatusMaintenanceCondition(gw, translated *gwapiv1b1.Gateway) {
+	setTranslatedCondition(gw, translated, gatewayapi.GatewayConditionMaintenance)
+}
+
+// UpdateGatewayStatusConfigurationRejectedCondition sets the
+// ConfigurationRejected condition of the translated Gateway on the provided
+// Gateway, or removes it if no proxy of the translated Gateway rejected its
+// configuration.
+func UpdateGatewayStatusConfigurationRejectedCondition(gw, translated *gwapiv1b1.Gateway) {
+	setTranslatedCondition(gw, translated, gatewayapi.GatewayConditionConfigurationRejected)
+}
+
+// setTranslatedCondition sets the condition of the provided type of the
+// translated Gateway on the provided Gateway, or removes it if the translated
+// Gateway doesn't have it.
+func setTranslatedCondition(gw, translated *gwapiv1b1.Gateway, condType gwapiv1b1.GatewayConditionType) {
+	cond := meta.FindStatusCondition(translated.Status.Conditions, string(condType))
+	if cond == nil {
+		meta.RemoveStatusCondition(&gw.Status.Conditions, string(condType))
+		return
+	}
+	meta.SetStatusCondition(&gw.Status.Conditions, *cond)
+}
+
+// SetListenerStatuses sets the listener statuses of the provided Gateway to
+// the translated ones, with their conditions deduped and capped. The statuses
+// of the listeners removed from the Gateway are already pruned by the
+// translation.
+func SetListenerStatuses(gw *gwapiv1b1.Gateway, listeners []gwapiv1b1.ListenerStatus) {
+	gw.Status.Listeners = make([]gwapiv1b1.ListenerStatus, 0, len(listeners))
+	for _, l := range listeners {
+		l.Conditions = CapConditions(DedupeConditions(l.Conditions), MaxConditions)
+		gw.Status.Listeners = append(gw.Status.Listeners, l)
+	}
+}
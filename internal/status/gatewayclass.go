@@ -18,8 +18,14 @@ import (
 )
 
 // SetGatewayClassAccepted inserts or updates the Accepted condition
-// for the provided GatewayClass.
+// for the provided GatewayClass. The SupportedFeatures condition is set on
+// the accepted GatewayClass, and removed from the other ones.
 func SetGatewayClassAccepted(gc *gwapiv1b1.GatewayClass, accepted bool) *gwapiv1b1.GatewayClass {
 	gc.Status.Conditions = MergeConditions(gc.Status.Conditions, computeGatewayClassAcceptedCondition(gc, accepted))
+	if accepted {
+		gc.Status.Conditions = MergeConditions(gc.Status.Conditions, computeGatewayClassSupportedFeaturesCondition(gc))
+	} else if i := indexOfCondition(gc.Status.Conditions, GatewayClassConditionSupportedFeatures); i >= 0 {
+		gc.Status.Conditions = append(gc.Status.Conditions[:i], gc.Status.Conditions[i+1:]...)
+	}
 	return gc
 }
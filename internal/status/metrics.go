@@ -0,0 +1,45 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package status
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// statusUpdateConflicts counts the status writes rejected with a conflict.
+	statusUpdateConflicts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "envoy_gateway_status_update_conflicts_total",
+		Help: "Total number of status updates that failed with a conflict.",
+	}, []string{"kind"})
+
+	// statusUpdateRetries counts the status writes retried after a conflict.
+	statusUpdateRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "envoy_gateway_status_update_retries_total",
+		Help: "Total number of status updates retried after a conflict.",
+	}, []string{"kind"})
+
+	// statusUpdateFailures counts the status updates dropped after exhausting
+	// the retries or failing with a non-conflict error.
+	statusUpdateFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "envoy_gateway_status_update_failures_total",
+		Help: "Total number of status updates that could not be written.",
+	}, []string{"kind"})
+
+	// statusUpdatesThrottled counts the status updates delayed by the rate
+	// limiting of the status writes of each object.
+	statusUpdatesThrottled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "envoy_gateway_status_updates_throttled_total",
+		Help: "Total number of status updates delayed by the per-object rate limit.",
+	}, []string{"kind"})
+)
+
+func init() {
+	// Register with the controller-runtime registry so the counters are served
+	// by the manager metrics endpoint.
+	metrics.Registry.MustRegister(statusUpdateConflicts, statusUpdateRetries, statusUpdateFailures, statusUpdatesThrottled)
+}
@@ -0,0 +1,91 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package status
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// statusWriteBurst is the number of status writes of an object allowed
+	// in a row before the writes are throttled.
+	statusWriteBurst = 5
+	// statusWriteInterval is the interval at which an object regains a
+	// status write once throttled, i.e. 6 writes per minute.
+	statusWriteInterval = 10 * time.Second
+)
+
+// objectKey identifies the object a status update is written to.
+type objectKey struct {
+	kind string
+	types.NamespacedName
+}
+
+// tokenBucket holds the status writes available to an object.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// objectRateLimiter is a token bucket rate limiter of the status writes of
+// each object, so that an oscillating condition doesn't flood the API server
+// with status writes. It is not safe for concurrent use.
+type objectRateLimiter struct {
+	burst    int
+	interval time.Duration
+	buckets  map[objectKey]*tokenBucket
+	// lastPrune is when the buckets were last pruned.
+	lastPrune time.Time
+}
+
+func newObjectRateLimiter(burst int, interval time.Duration) *objectRateLimiter {
+	return &objectRateLimiter{
+		burst:    burst,
+		interval: interval,
+		buckets:  make(map[objectKey]*tokenBucket),
+	}
+}
+
+// reserve consumes a status write of the object if one is available at now,
+// and returns zero. Otherwise it returns how long to wait for the next one.
+func (l *objectRateLimiter) reserve(key objectKey, now time.Time) time.Duration {
+	l.prune(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: float64(l.burst), last: now}
+		l.buckets[key] = b
+	}
+	b.tokens += float64(now.Sub(b.last)) / float64(l.interval)
+	if b.tokens > float64(l.burst) {
+		b.tokens = float64(l.burst)
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) * float64(l.interval))
+}
+
+// prune removes the buckets of the objects which regained all their status
+// writes, since they behave as new buckets. The buckets are pruned at most
+// once per refill period.
+func (l *objectRateLimiter) prune(now time.Time) {
+	refill := time.Duration(l.burst) * l.interval
+	if now.Sub(l.lastPrune) < refill {
+		return
+	}
+	l.lastPrune = now
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= refill {
+			delete(l.buckets, key)
+		}
+	}
+}
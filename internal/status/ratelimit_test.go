@@ -0,0 +1,38 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package status
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestObjectRateLimiter(t *testing.T) {
+	l := newObjectRateLimiter(2, 10*time.Second)
+	gw := objectKey{kind: "Gateway", NamespacedName: types.NamespacedName{Namespace: "default", Name: "gateway-1"}}
+	route := objectKey{kind: "HTTPRoute", NamespacedName: gw.NamespacedName}
+	now := time.Unix(0, 0)
+
+	// The burst is allowed, then the writes are throttled.
+	require.Zero(t, l.reserve(gw, now))
+	require.Zero(t, l.reserve(gw, now))
+	require.Equal(t, 10*time.Second, l.reserve(gw, now))
+
+	// The objects are limited independently.
+	require.Zero(t, l.reserve(route, now))
+
+	// A write is regained after the interval.
+	require.Equal(t, 6*time.Second, l.reserve(gw, now.Add(4*time.Second)))
+	require.Zero(t, l.reserve(gw, now.Add(10*time.Second)))
+	require.Equal(t, 10*time.Second, l.reserve(gw, now.Add(10*time.Second)))
+
+	// The buckets refilled are pruned.
+	l.prune(now.Add(time.Minute))
+	require.Empty(t, l.buckets)
+}
@@ -0,0 +1,40 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package status
+
+import (
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// MaxRouteParents is the maximum number of parent statuses of a route, as
+// enforced by the Gateway API CRDs.
+const MaxRouteParents = 32
+
+// MergeRouteParentStatuses returns the parent statuses of current written by
+// other controllers, followed by the parent statuses of desired written by the
+// provided controller, with their conditions deduped and capped. The parents of
+// a route status are an atomic list, so the statuses of other controllers must
+// be carried over by each write. The parent statuses of the parentRefs removed
+// from the route are already pruned from desired by the translation.
+func MergeRouteParentStatuses(current, desired []gwapiv1b1.RouteParentStatus, controllerName gwapiv1b1.GatewayController) []gwapiv1b1.RouteParentStatus {
+	merged := make([]gwapiv1b1.RouteParentStatus, 0, len(current)+len(desired))
+	for _, parent := range current {
+		if parent.ControllerName != controllerName {
+			merged = append(merged, parent)
+		}
+	}
+	for _, parent := range desired {
+		if parent.ControllerName == controllerName {
+			parent.Conditions = CapConditions(DedupeConditions(parent.Conditions), MaxConditions)
+			merged = append(merged, parent)
+		}
+	}
+
+	if len(merged) > MaxRouteParents {
+		merged = merged[:MaxRouteParents]
+	}
+	return merged
+}
@@ -0,0 +1,89 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package status
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+)
+
+func TestMergeRouteParentStatuses(t *testing.T) {
+	controller := gwapiv1b1.GatewayController(v1alpha1.GatewayControllerName)
+	current := []gwapiv1b1.RouteParentStatus{
+		{ControllerName: controller, ParentRef: gwapiv1b1.ParentReference{Name: "gateway-1"}},
+		{ControllerName: "example.com/other-controller", ParentRef: gwapiv1b1.ParentReference{Name: "gateway-2"}},
+	}
+	desired := []gwapiv1b1.RouteParentStatus{
+		{
+			ControllerName: controller,
+			ParentRef:      gwapiv1b1.ParentReference{Name: "gateway-3"},
+			Conditions: []metav1.Condition{
+				{Type: "Accepted", Status: metav1.ConditionFalse},
+				{Type: "Accepted", Status: metav1.ConditionTrue},
+			},
+		},
+		// Stale status of the other controller, dropped in favor of the current one.
+		{ControllerName: "example.com/other-controller", ParentRef: gwapiv1b1.ParentReference{Name: "gateway-4"}},
+	}
+
+	got := MergeRouteParentStatuses(current, desired, controller)
+	assert.Len(t, got, 2)
+	assert.Equal(t, gwapiv1b1.ObjectName("gateway-2"), got[0].ParentRef.Name)
+	assert.Equal(t, gwapiv1b1.ObjectName("gateway-3"), got[1].ParentRef.Name)
+	assert.Len(t, got[1].Conditions, 1)
+	assert.Equal(t, metav1.ConditionTrue, got[1].Conditions[0].Status)
+}
+
+func TestSetListenerStatuses(t *testing.T) {
+	gw := &gwapiv1b1.Gateway{
+		Status: gwapiv1b1.GatewayStatus{
+			Listeners: []gwapiv1b1.ListenerStatus{{Name: "removed"}},
+		},
+	}
+	listeners := []gwapiv1b1.ListenerStatus{{
+		Name: "http",
+		Conditions: []metav1.Condition{
+			{Type: "Ready", Status: metav1.ConditionFalse},
+			{Type: "Ready", Status: metav1.ConditionTrue},
+		},
+	}}
+
+	SetListenerStatuses(gw, listeners)
+	assert.Len(t, gw.Status.Listeners, 1)
+	assert.Equal(t, gwapiv1b1.SectionName("http"), gw.Status.Listeners[0].Name)
+	assert.Len(t, gw.Status.Listeners[0].Conditions, 1)
+	assert.Equal(t, metav1.ConditionTrue, gw.Status.Listeners[0].Conditions[0].Status)
+	// The translated statuses are left as is.
+	assert.Len(t, listeners[0].Conditions, 2)
+}
+
+func TestUpdateGatewayStatusMaintenanceCondition(t *testing.T) {
+	ready := metav1.Condition{Type: "Ready", Status: metav1.ConditionTrue}
+	maintenance := metav1.Condition{
+		Type:   string(gatewayapi.GatewayConditionMaintenance),
+		Status: metav1.ConditionTrue,
+		Reason: string(gatewayapi.GatewayReasonMaintenanceEnabled),
+		// Conditions without a transition time are set at the current time.
+		LastTransitionTime: metav1.Unix(100, 0),
+	}
+	gw := &gwapiv1b1.Gateway{
+		Status: gwapiv1b1.GatewayStatus{Conditions: []metav1.Condition{ready}},
+	}
+
+	translated := gw.DeepCopy()
+	translated.Status.Conditions = []metav1.Condition{maintenance}
+	UpdateGatewayStatusMaintenanceCondition(gw, translated)
+	assert.Equal(t, []metav1.Condition{ready, maintenance}, gw.Status.Conditions)
+
+	UpdateGatewayStatusMaintenanceCondition(gw, &gwapiv1b1.Gateway{})
+	assert.Equal(t, []metav1.Condition{ready}, gw.Status.Conditions)
+}
@@ -15,16 +15,23 @@ package status
 
 import (
 	"context"
+	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 )
 
 // Update contains an all the information needed to update an object's status.
@@ -58,19 +65,62 @@ type UpdateHandler struct {
 	client        client.Client
 	sendUpdates   chan struct{}
 	updateChannel chan Update
+
+	// limiter throttles the status writes of each object. The updates of a
+	// throttled object are held in pending, only the latest one being
+	// written once the object is allowed a write, signaled on throttled.
+	limiter   *objectRateLimiter
+	pending   map[objectKey]Update
+	throttled chan objectKey
+
+	// recorder records the Events of the rejections reported by the
+	// written statuses, if set.
+	recorder record.EventRecorder
 }
 
-func NewUpdateHandler(log logr.Logger, client client.Client) *UpdateHandler {
+func NewUpdateHandler(log logr.Logger, client client.Client, recorder record.EventRecorder) *UpdateHandler {
 	return &UpdateHandler{
 		log:           log,
 		client:        client,
+		recorder:      recorder,
 		sendUpdates:   make(chan struct{}),
 		updateChannel: make(chan Update, 100),
+		limiter:       newObjectRateLimiter(statusWriteBurst, statusWriteInterval),
+		pending:       make(map[objectKey]Update),
+		throttled:     make(chan objectKey),
 	}
 }
 
+// statusUpdateBackoff bounds the retries of a status update failing with a
+// conflict, i.e. made from an object whose status or spec was written since it
+// was read. Each retry mutates the latest object. The jitter spreads the
+// retries of the updates conflicting with each other, which is common in large
+// clusters.
+var statusUpdateBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 20 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.5,
+	Cap:      time.Second,
+}
+
 func (u *UpdateHandler) apply(update Update) {
-	if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+	kind := resourceKind(update.Resource)
+	attempts := 0
+	isConflict := func(err error) bool {
+		if kerrors.IsConflict(err) {
+			statusUpdateConflicts.WithLabelValues(kind).Inc()
+			return true
+		}
+		return false
+	}
+
+	if err := retry.OnError(statusUpdateBackoff, isConflict, func() error {
+		if attempts > 0 {
+			statusUpdateRetries.WithLabelValues(kind).Inc()
+		}
+		attempts++
+
 		obj := update.Resource
 
 		// Get the resource.
@@ -87,13 +137,35 @@ func (u *UpdateHandler) apply(update Update) {
 			return nil
 		}
 
-		return u.client.Status().Update(context.Background(), newObj)
+		// The update is conditioned on the resource version of the object
+		// the status was mutated from, so that a status written meanwhile,
+		// e.g. the parent statuses of other controllers sharing a route, is
+		// never overwritten: the update fails with a conflict and is retried
+		// from the latest object instead.
+		if err := u.client.Status().Update(context.Background(), newObj); err != nil {
+			return err
+		}
+		u.recordRejections(obj, newObj)
+		return nil
 	}); err != nil {
+		statusUpdateFailures.WithLabelValues(kind).Inc()
 		u.log.Error(err, "unable to update status", "name", update.NamespacedName.Name,
-			"namespace", update.NamespacedName.Namespace)
+			"namespace", update.NamespacedName.Namespace, "attempts", attempts)
 	}
 }
 
+// resourceKind returns the kind of the provided object, used to label metrics.
+func resourceKind(obj client.Object) string {
+	t := reflect.TypeOf(obj)
+	if t == nil {
+		return ""
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
 func (u *UpdateHandler) NeedLeaderElection() bool {
 	return true
 }
@@ -114,11 +186,47 @@ func (u *UpdateHandler) Start(ctx context.Context) error {
 			u.log.Info("received a status update", "namespace", update.NamespacedName.Namespace,
 				"name", update.NamespacedName.Name)
 
-			u.apply(update)
+			u.write(ctx, update)
+		case key := <-u.throttled:
+			update, ok := u.pending[key]
+			if !ok {
+				continue
+			}
+			delete(u.pending, key)
+			u.write(ctx, update)
 		}
 	}
 }
 
+// write applies the update unless its object exceeded its rate of status
+// writes, in which case the update is held until the object is allowed a
+// write. An update held replaces the one previously held for the object.
+func (u *UpdateHandler) write(ctx context.Context, update Update) {
+	key := objectKey{kind: resourceKind(update.Resource), NamespacedName: update.NamespacedName}
+	if _, ok := u.pending[key]; ok {
+		u.pending[key] = update
+		statusUpdatesThrottled.WithLabelValues(key.kind).Inc()
+		return
+	}
+
+	delay := u.limiter.reserve(key, time.Now())
+	if delay == 0 {
+		u.apply(update)
+		return
+	}
+
+	u.log.Info("throttling status updates", "namespace", update.NamespacedName.Namespace,
+		"name", update.NamespacedName.Name, "delay", delay)
+	u.pending[key] = update
+	statusUpdatesThrottled.WithLabelValues(key.kind).Inc()
+	time.AfterFunc(delay, func() {
+		select {
+		case u.throttled <- key:
+		case <-ctx.Done():
+		}
+	})
+}
+
 // Writer retrieves the interface that should be used to write to the UpdateHandler.
 func (u *UpdateHandler) Writer() Updater {
 	return &UpdateWriter{
@@ -130,6 +238,9 @@ func (u *UpdateHandler) Writer() Updater {
 // Updater describes an interface to send status updates somewhere.
 type Updater interface {
 	Send(u Update)
+	// Enabled returns a channel closed once the updates are written, i.e.
+	// once this replica is elected leader. Updates sent before are dropped.
+	Enabled() <-chan struct{}
 }
 
 // UpdateWriter takes status updates and sends these to the UpdateHandler via a channel.
@@ -148,6 +259,11 @@ func (u *UpdateWriter) Send(update Update) {
 	}
 }
 
+// Enabled returns a channel closed once the UpdateHandler writes the updates.
+func (u *UpdateWriter) Enabled() <-chan struct{} {
+	return u.enabled
+}
+
 // isStatusEqual checks if two objects have equivalent status.
 //
 // Supported objects:
@@ -155,6 +271,12 @@ func (u *UpdateWriter) Send(update Update) {
 //  Gateway
 //  HTTPRoute
 //  TLSRoute
+//  TCPRoute
+//  UDPRoute
+//  BackendTrafficPolicy
+//  ClientTrafficPolicy
+//  SecurityPolicy
+//  TrafficShift
 func isStatusEqual(objA, objB interface{}) bool {
 	opts := cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime", "ObservedGeneration")
 	switch a := objA.(type) {
@@ -182,6 +304,42 @@ func isStatusEqual(objA, objB interface{}) bool {
 				return true
 			}
 		}
+	case *gwapiv1a2.TCPRoute:
+		if b, ok := objB.(*gwapiv1a2.TCPRoute); ok {
+			if cmp.Equal(a.Status, b.Status, opts) {
+				return true
+			}
+		}
+	case *gwapiv1a2.UDPRoute:
+		if b, ok := objB.(*gwapiv1a2.UDPRoute); ok {
+			if cmp.Equal(a.Status, b.Status, opts) {
+				return true
+			}
+		}
+	case *v1alpha1.BackendTrafficPolicy:
+		if b, ok := objB.(*v1alpha1.BackendTrafficPolicy); ok {
+			if cmp.Equal(a.Status, b.Status, opts) {
+				return true
+			}
+		}
+	case *v1alpha1.ClientTrafficPolicy:
+		if b, ok := objB.(*v1alpha1.ClientTrafficPolicy); ok {
+			if cmp.Equal(a.Status, b.Status, opts) {
+				return true
+			}
+		}
+	case *v1alpha1.SecurityPolicy:
+		if b, ok := objB.(*v1alpha1.SecurityPolicy); ok {
+			if cmp.Equal(a.Status, b.Status, opts) {
+				return true
+			}
+		}
+	case *v1alpha1.TrafficShift:
+		if b, ok := objB.(*v1alpha1.TrafficShift); ok {
+			if cmp.Equal(a.Status, b.Status, opts) {
+				return true
+			}
+		}
 	}
 	return false
 }
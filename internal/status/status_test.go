@@ -0,0 +1,141 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package status
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+)
+
+func TestApplyKeepsOtherControllerParents(t *testing.T) {
+	controller := gwapiv1b1.GatewayController(v1alpha1.GatewayControllerName)
+	otherParent := gwapiv1b1.RouteParentStatus{
+		ControllerName: "example.com/other-controller",
+		ParentRef:      gwapiv1b1.ParentReference{Name: "gateway-2"},
+		Conditions:     []metav1.Condition{{Type: "Accepted", Status: metav1.ConditionTrue, Reason: "Accepted"}},
+	}
+	route := &gwapiv1b1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "route-1"},
+		Spec: gwapiv1b1.HTTPRouteSpec{
+			CommonRouteSpec: gwapiv1b1.CommonRouteSpec{
+				ParentRefs: []gwapiv1b1.ParentReference{{Name: "gateway-1"}, {Name: "gateway-2"}},
+			},
+		},
+		// The parent status of the other controller is written after the
+		// route was translated, so it is not part of the translated status.
+		Status: gwapiv1b1.HTTPRouteStatus{
+			RouteStatus: gwapiv1b1.RouteStatus{Parents: []gwapiv1b1.RouteParentStatus{otherParent}},
+		},
+	}
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects(route).Build()
+	u := NewUpdateHandler(logr.Discard(), cli, nil)
+
+	parent := gwapiv1b1.RouteParentStatus{
+		ControllerName: controller,
+		ParentRef:      gwapiv1b1.ParentReference{Name: "gateway-1"},
+		Conditions:     []metav1.Condition{{Type: "Accepted", Status: metav1.ConditionTrue, Reason: "Accepted"}},
+	}
+	u.apply(Update{
+		NamespacedName: types.NamespacedName{Namespace: "default", Name: "route-1"},
+		Resource:       new(gwapiv1b1.HTTPRoute),
+		Mutator: MutatorFunc(func(obj client.Object) client.Object {
+			hCopy := obj.(*gwapiv1b1.HTTPRoute).DeepCopy()
+			hCopy.Status.Parents = MergeRouteParentStatuses(hCopy.Status.Parents, []gwapiv1b1.RouteParentStatus{parent}, controller)
+			return hCopy
+		}),
+	})
+
+	got := new(gwapiv1b1.HTTPRoute)
+	require.NoError(t, cli.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "route-1"}, got))
+	require.Len(t, got.Status.Parents, 2)
+	require.Equal(t, otherParent.ControllerName, got.Status.Parents[0].ControllerName)
+	require.Equal(t, gwapiv1b1.ObjectName("gateway-2"), got.Status.Parents[0].ParentRef.Name)
+	require.Equal(t, controller, got.Status.Parents[1].ControllerName)
+	require.Equal(t, gwapiv1b1.ObjectName("gateway-1"), got.Status.Parents[1].ParentRef.Name)
+}
+
+func TestResourceKind(t *testing.T) {
+	require.Equal(t, "Gateway", resourceKind(new(gwapiv1b1.Gateway)))
+	require.Equal(t, "HTTPRoute", resourceKind(new(gwapiv1b1.HTTPRoute)))
+	require.Equal(t, "", resourceKind(nil))
+}
+
+// conflictingClient writes the status of the updated object once before the
+// first status update, so that the update is made from a stale object.
+type conflictingClient struct {
+	client.Client
+	conflicted bool
+}
+
+func (c *conflictingClient) Status() client.StatusWriter {
+	return &conflictingStatusWriter{StatusWriter: c.Client.Status(), client: c}
+}
+
+type conflictingStatusWriter struct {
+	client.StatusWriter
+	client *conflictingClient
+}
+
+func (w *conflictingStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if !w.client.conflicted {
+		w.client.conflicted = true
+		latest := obj.DeepCopyObject().(client.Object)
+		if err := w.client.Get(ctx, client.ObjectKeyFromObject(obj), latest); err != nil {
+			return err
+		}
+		latest.SetAnnotations(map[string]string{"example.com/written": "true"})
+		if err := w.client.Update(ctx, latest); err != nil {
+			return err
+		}
+	}
+	return w.StatusWriter.Update(ctx, obj, opts...)
+}
+
+func TestApplyRetriesConflicts(t *testing.T) {
+	gw := &gwapiv1b1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "envoy-gateway", Name: "gateway-1"}}
+	cli := &conflictingClient{
+		Client: fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects(gw).Build(),
+	}
+	u := NewUpdateHandler(logr.Discard(), cli, nil)
+
+	conflicts := testutil.ToFloat64(statusUpdateConflicts.WithLabelValues("Gateway"))
+	retries := testutil.ToFloat64(statusUpdateRetries.WithLabelValues("Gateway"))
+	failures := testutil.ToFloat64(statusUpdateFailures.WithLabelValues("Gateway"))
+
+	u.apply(Update{
+		NamespacedName: types.NamespacedName{Namespace: "envoy-gateway", Name: "gateway-1"},
+		Resource:       new(gwapiv1b1.Gateway),
+		Mutator: MutatorFunc(func(obj client.Object) client.Object {
+			gwCopy := obj.(*gwapiv1b1.Gateway).DeepCopy()
+			gwCopy.Status.Conditions = []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Ready"}}
+			return gwCopy
+		}),
+	})
+
+	require.True(t, cli.conflicted)
+	require.Equal(t, conflicts+1, testutil.ToFloat64(statusUpdateConflicts.WithLabelValues("Gateway")))
+	require.Equal(t, retries+1, testutil.ToFloat64(statusUpdateRetries.WithLabelValues("Gateway")))
+	require.Equal(t, failures, testutil.ToFloat64(statusUpdateFailures.WithLabelValues("Gateway")))
+
+	// The retry is made from the latest object, keeping the change written
+	// meanwhile.
+	got := new(gwapiv1b1.Gateway)
+	require.NoError(t, cli.Get(context.Background(), types.NamespacedName{Namespace: "envoy-gateway", Name: "gateway-1"}, got))
+	require.Equal(t, "true", got.Annotations["example.com/written"])
+	require.Len(t, got.Status.Conditions, 1)
+}
@@ -0,0 +1,193 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package trafficstats
+
+import (
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	metricsv3 "github.com/envoyproxy/go-control-plane/envoy/service/metrics/v3"
+	prom "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+const (
+	// requestsStat is the counter of the requests received by the HTTP
+	// connection managers of the listeners.
+	requestsStat = "downstream_rq_total"
+	// responses4xxStat is the counter of the responses with a 4xx status code.
+	responses4xxStat = "downstream_rq_4xx"
+	// responses5xxStat is the counter of the responses with a 5xx status code.
+	responses5xxStat = "downstream_rq_5xx"
+	// latencyStat is the histogram of the request latency, in milliseconds.
+	latencyStat = "downstream_rq_time"
+)
+
+// httpStatPrefixes are the prefixes of the stats of the HTTP connection
+// managers of the HTTP and HTTPS listeners. The stats of the health listener
+// of the proxies are not aggregated.
+var httpStatPrefixes = []string{"http.http.", "http.https."}
+
+// sample holds the stats flushed by a proxy.
+type sample struct {
+	time         time.Time
+	node         string
+	requests     int64
+	responses4xx int64
+	responses5xx int64
+	// p95Latency is the 95th percentile of the request latency over the
+	// flush interval, or negative if no request was received.
+	p95Latency time.Duration
+}
+
+// Aggregator aggregates the stats pushed by the proxies over the Envoy
+// metrics service, by node cluster, i.e. by the IR key of their Gateway. The
+// proxies must report their counters as deltas.
+type Aggregator struct {
+	mu sync.Mutex
+	// samples holds the samples of the proxies, keyed by node cluster.
+	samples map[string][]sample
+}
+
+// NewAggregator returns an empty Aggregator.
+func NewAggregator() *Aggregator {
+	return &Aggregator{samples: make(map[string][]sample)}
+}
+
+// StreamMetrics implements the metrics service, receiving the stats flushed
+// by a proxy until it disconnects. The node of the proxy is only identified
+// by the first message of the stream.
+func (a *Aggregator) StreamMetrics(stream metricsv3.MetricsService_StreamMetricsServer) error {
+	var cluster, node string
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(&metricsv3.StreamMetricsResponse{})
+		}
+		if err != nil {
+			return err
+		}
+		if id := msg.GetIdentifier(); id != nil {
+			cluster, node = id.GetNode().GetCluster(), id.GetNode().GetId()
+		}
+		if cluster == "" {
+			continue
+		}
+		a.record(cluster, newSample(time.Now(), node, msg.GetEnvoyMetrics()))
+	}
+}
+
+// record adds the sample to the samples of the node cluster.
+func (a *Aggregator) record(cluster string, s sample) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.samples[cluster] = append(a.samples[cluster], s)
+}
+
+// newSample returns the sample of the stats flushed by the node at the
+// provided time.
+func newSample(now time.Time, node string, families []*prom.MetricFamily) sample {
+	s := sample{time: now, node: node, p95Latency: -1}
+	for _, family := range families {
+		stat, ok := httpStat(family.GetName())
+		if !ok {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			switch stat {
+			case requestsStat:
+				s.requests += int64(metric.GetCounter().GetValue())
+			case responses4xxStat:
+				s.responses4xx += int64(metric.GetCounter().GetValue())
+			case responses5xxStat:
+				s.responses5xx += int64(metric.GetCounter().GetValue())
+			case latencyStat:
+				// The histograms are flushed both as summaries of the
+				// flush interval and as cumulative histograms.
+				for _, q := range metric.GetSummary().GetQuantile() {
+					if q.GetQuantile() != 0.95 || math.IsNaN(q.GetValue()) {
+						continue
+					}
+					if latency := time.Duration(q.GetValue() * float64(time.Millisecond)); latency > s.p95Latency {
+						s.p95Latency = latency
+					}
+				}
+			}
+		}
+	}
+	return s
+}
+
+// httpStat returns the name of the stat of the HTTP connection managers of
+// the listeners, without its prefix, or false if it's another stat.
+func httpStat(name string) (string, bool) {
+	for _, prefix := range httpStatPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return strings.TrimPrefix(name, prefix), true
+		}
+	}
+	return "", false
+}
+
+// Stats returns the stats aggregated over the window ending at now, keyed
+// by node cluster, and drops the older samples. The node clusters whose
+// samples are all dropped are reported with empty stats one last time.
+func (a *Aggregator) Stats(now time.Time, window time.Duration) map[string]*v1alpha1.GatewayTrafficStatsStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	stats := make(map[string]*v1alpha1.GatewayTrafficStatsStatus, len(a.samples))
+	for cluster, samples := range a.samples {
+		var kept []sample
+		for _, s := range samples {
+			if now.Sub(s.time) < window {
+				kept = append(kept, s)
+			}
+		}
+		if len(kept) == 0 {
+			delete(a.samples, cluster)
+		} else {
+			a.samples[cluster] = kept
+		}
+		stats[cluster] = aggregate(now, window, kept)
+	}
+	return stats
+}
+
+// aggregate returns the stats of the provided samples of the window ending
+// at now.
+func aggregate(now time.Time, window time.Duration, samples []sample) *v1alpha1.GatewayTrafficStatsStatus {
+	status := &v1alpha1.GatewayTrafficStatsStatus{
+		LastUpdateTime: metav1.NewTime(now),
+		Window:         metav1.Duration{Duration: window},
+	}
+
+	nodes := make(map[string]bool)
+	p95Latency := time.Duration(-1)
+	for _, s := range samples {
+		nodes[s.node] = true
+		status.Requests += s.requests
+		status.Responses4xx += s.responses4xx
+		status.Responses5xx += s.responses5xx
+		if s.p95Latency > p95Latency {
+			p95Latency = s.p95Latency
+		}
+	}
+
+	status.Proxies = int32(len(nodes))
+	status.RequestsPerSecond = strconv.FormatFloat(float64(status.Requests)/window.Seconds(), 'f', 2, 64)
+	if p95Latency >= 0 {
+		status.P95Latency = &metav1.Duration{Duration: p95Latency}
+	}
+	return status
+}
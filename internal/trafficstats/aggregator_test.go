@@ -0,0 +1,95 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package trafficstats
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	prom "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+func counterFamily(name string, value float64) *prom.MetricFamily {
+	return &prom.MetricFamily{
+		Name:   proto.String(name),
+		Type:   prom.MetricType_COUNTER.Enum(),
+		Metric: []*prom.Metric{{Counter: &prom.Counter{Value: proto.Float64(value)}}},
+	}
+}
+
+func latencyFamily(name string, p95 float64) *prom.MetricFamily {
+	return &prom.MetricFamily{
+		Name: proto.String(name),
+		Type: prom.MetricType_SUMMARY.Enum(),
+		Metric: []*prom.Metric{{Summary: &prom.Summary{Quantile: []*prom.Quantile{
+			{Quantile: proto.Float64(0.5), Value: proto.Float64(p95 / 2)},
+			{Quantile: proto.Float64(0.95), Value: proto.Float64(p95)},
+		}}}},
+	}
+}
+
+func TestAggregatorStats(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := NewAggregator()
+
+	a.record("default-eg", newSample(start, "envoy-1", []*prom.MetricFamily{
+		counterFamily("http.http.downstream_rq_total", 100),
+		counterFamily("http.http.downstream_rq_4xx", 10),
+		counterFamily("http.http.downstream_rq_5xx", 1),
+		latencyFamily("http.http.downstream_rq_time", 12),
+		// The requests to the health listener are not aggregated.
+		counterFamily("http.eg-ready-http.downstream_rq_total", 1000),
+	}))
+	a.record("default-eg", newSample(start.Add(30*time.Second), "envoy-2", []*prom.MetricFamily{
+		counterFamily("http.https.downstream_rq_total", 50),
+		counterFamily("http.https.downstream_rq_5xx", 2),
+		latencyFamily("http.https.downstream_rq_time", 40),
+	}))
+	// No request was received within the flush interval.
+	a.record("default-eg", newSample(start.Add(45*time.Second), "envoy-1", []*prom.MetricFamily{
+		counterFamily("http.http.downstream_rq_total", 0),
+		latencyFamily("http.http.downstream_rq_time", math.NaN()),
+	}))
+	a.record("default-other", newSample(start, "envoy-3", nil))
+
+	now := start.Add(time.Minute - time.Second)
+	require.Equal(t, map[string]*v1alpha1.GatewayTrafficStatsStatus{
+		"default-eg": {
+			LastUpdateTime:    metav1.NewTime(now),
+			Window:            metav1.Duration{Duration: time.Minute},
+			Proxies:           2,
+			Requests:          150,
+			RequestsPerSecond: "2.50",
+			Responses4xx:      10,
+			Responses5xx:      3,
+			P95Latency:        &metav1.Duration{Duration: 40 * time.Millisecond},
+		},
+		"default-other": {
+			LastUpdateTime:    metav1.NewTime(now),
+			Window:            metav1.Duration{Duration: time.Minute},
+			Proxies:           1,
+			RequestsPerSecond: "0.00",
+		},
+	}, a.Stats(now, time.Minute))
+
+	// The samples older than the window are dropped, and the node clusters
+	// without samples are reported one last time.
+	now = start.Add(time.Minute + 40*time.Second)
+	stats := a.Stats(now, time.Minute)
+	require.Len(t, stats, 2)
+	require.Equal(t, int32(1), stats["default-eg"].Proxies)
+	require.Equal(t, int64(0), stats["default-eg"].Requests)
+	require.Nil(t, stats["default-eg"].P95Latency)
+	require.Equal(t, int32(0), stats["default-other"].Proxies)
+
+	require.Len(t, a.Stats(now, time.Minute), 1)
+}
@@ -0,0 +1,81 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/trafficstats"
+)
+
+// publishInterval is the interval at which the aggregated traffic statistics
+// are published.
+const publishInterval = 15 * time.Second
+
+type Config struct {
+	config.Server
+	ProviderResources *message.ProviderResources
+	// Aggregator aggregates the stats pushed by the proxies to the xDS server.
+	Aggregator *trafficstats.Aggregator
+}
+
+type Runner struct {
+	Config
+}
+
+func New(cfg *Config) *Runner {
+	return &Runner{Config: *cfg}
+}
+
+func (r *Runner) Name() string {
+	return "traffic-stats"
+}
+
+// Start starts the traffic stats runner
+func (r *Runner) Start(ctx context.Context) error {
+	r.Logger = r.Logger.WithValues("runner", r.Name())
+	go r.publishStats(ctx)
+	r.Logger.Info("started")
+	return nil
+}
+
+// publishStats periodically publishes the traffic statistics of the Gateways,
+// aggregated over the configured window.
+func (r *Runner) publishStats(ctx context.Context) {
+	window := r.EnvoyGateway.TrafficStats.GetWindow()
+	ticker := time.NewTicker(publishInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.Logger.Info("publisher shutting down")
+			return
+		case now := <-ticker.C:
+			r.publish(now, window)
+		}
+	}
+}
+
+// publish publishes the statistics of the Gateways whose proxies reported
+// stats over the window ending at now.
+func (r *Runner) publish(now time.Time, window time.Duration) {
+	stats := r.Aggregator.Stats(now, window)
+	for _, gateway := range r.ProviderResources.GetGateways() {
+		status, ok := stats[gatewayapi.IRKey(gateway.Namespace, gateway.Name)]
+		if !ok {
+			continue
+		}
+		key := types.NamespacedName{Namespace: gateway.Namespace, Name: gateway.Name}
+		r.ProviderResources.GatewayTrafficStats.Store(key, status)
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	envoy_cache_v3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"google.golang.org/protobuf/proto"
+
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// HandlerPath is the path the audit trail is served on, next to the metrics
+// of Envoy Gateway.
+const HandlerPath = "/debug/xds/audit"
+
+// Entry is a generation of the xDS snapshot of a key, i.e. of the proxies of a
+// Gateway, applied by the xDS server.
+type Entry struct {
+	// Generation is the generation of the snapshot, starting at 1 for the
+	// first snapshot of the key applied by this xDS server.
+	Generation int64 `json:"generation"`
+	// Hash is the hash of the resources of the snapshot, empty if the
+	// snapshot was cleared.
+	Hash string `json:"hash"`
+	// Time is the time the snapshot was applied.
+	Time time.Time `json:"time"`
+	// Added, Removed and Modified are the resources changed since the
+	// previous generation, as type/name.
+	Added    []string `json:"added,omitempty"`
+	Removed  []string `json:"removed,omitempty"`
+	Modified []string `json:"modified,omitempty"`
+}
+
+// Summary returns a summary of the resources changed by the generation.
+func (e *Entry) Summary() string {
+	var parts []string
+	if len(e.Added) > 0 {
+		parts = append(parts, fmt.Sprintf("added %s", strings.Join(e.Added, ",")))
+	}
+	if len(e.Removed) > 0 {
+		parts = append(parts, fmt.Sprintf("removed %s", strings.Join(e.Removed, ",")))
+	}
+	if len(e.Modified) > 0 {
+		parts = append(parts, fmt.Sprintf("modified %s", strings.Join(e.Modified, ",")))
+	}
+	if len(parts) == 0 {
+		return "unchanged"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// history is the audit trail of a key.
+type history struct {
+	// entries are the last generations, oldest first.
+	entries []Entry
+	// generation is the last generation.
+	generation int64
+	// hash is the hash of the last generation.
+	hash string
+	// resources are the hashes of the resources of the last generation, by
+	// type/name.
+	resources map[string]string
+}
+
+// Trail keeps a bounded history of the xDS snapshots applied to each key, so
+// that operators can find out what changed and when after an incident. It
+// serves the history as JSON over HTTP.
+type Trail struct {
+	mu sync.Mutex
+	// maxEntries is the number of generations kept per key.
+	maxEntries int
+	histories  map[string]*history
+}
+
+// NewTrail returns a Trail keeping the last maxEntries generations of each key.
+func NewTrail(maxEntries int) *Trail {
+	return &Trail{
+		maxEntries: maxEntries,
+		histories:  make(map[string]*history),
+	}
+}
+
+// Record records the snapshot of the key applied at now, clearing it if
+// resources is nil. The snapshots identical to the last generation of the
+// key are not recorded.
+func (t *Trail) Record(key string, resources xdstypes.XdsResources, now time.Time) {
+	hashes := resourceHashes(resources)
+	hash := snapshotHash(hashes)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h, ok := t.histories[key]
+	if !ok {
+		if resources == nil {
+			return
+		}
+		h = &history{}
+		t.histories[key] = h
+	}
+	if h.generation > 0 && h.hash == hash {
+		return
+	}
+
+	entry := Entry{
+		Generation: h.generation + 1,
+		Hash:       hash,
+		Time:       now,
+	}
+	for name, resourceHash := range hashes {
+		previous, ok := h.resources[name]
+		switch {
+		case !ok:
+			entry.Added = append(entry.Added, name)
+		case previous != resourceHash:
+			entry.Modified = append(entry.Modified, name)
+		}
+	}
+	for name := range h.resources {
+		if _, ok := hashes[name]; !ok {
+			entry.Removed = append(entry.Removed, name)
+		}
+	}
+	sort.Strings(entry.Added)
+	sort.Strings(entry.Removed)
+	sort.Strings(entry.Modified)
+
+	h.entries = append(h.entries, entry)
+	if len(h.entries) > t.maxEntries {
+		h.entries = h.entries[len(h.entries)-t.maxEntries:]
+	}
+	h.generation = entry.Generation
+	h.hash = hash
+	h.resources = hashes
+}
+
+// Entries returns the recorded generations of each key, oldest first.
+func (t *Trail) Entries() map[string][]Entry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entries := make(map[string][]Entry, len(t.histories))
+	for key, h := range t.histories {
+		entries[key] = append([]Entry(nil), h.entries...)
+	}
+	return entries
+}
+
+// ServeHTTP serves the recorded generations of each key as JSON, or of the
+// key of the "key" query parameter if set.
+func (t *Trail) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	entries := t.Entries()
+	if key := r.URL.Query().Get("key"); key != "" {
+		entries = map[string][]Entry{key: entries[key]}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(entries); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// resourceHashes returns the hashes of the resources, by type/name.
+func resourceHashes(resources xdstypes.XdsResources) map[string]string {
+	hashes := make(map[string]string)
+	for typeURL, typeResources := range resources {
+		typeName := typeURL[strings.LastIndex(typeURL, ".")+1:]
+		for _, resource := range typeResources {
+			name := fmt.Sprintf("%s/%s", typeName, envoy_cache_v3.GetResourceName(resource))
+			hashes[name] = resourceHash(resource)
+		}
+	}
+	return hashes
+}
+
+// resourceHash returns the hash of the deterministic encoding of the resource.
+func resourceHash(resource proto.Message) string {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(resource)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// snapshotHash returns the hash of a snapshot made of the resources with the
+// provided hashes, or an empty hash if there are none.
+func snapshotHash(hashes map[string]string) string {
+	if len(hashes) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(hashes))
+	for name := range hashes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sum := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(sum, "%s=%s\n", name, hashes[name])
+	}
+	return hex.EncodeToString(sum.Sum(nil))[:16]
+}
@@ -0,0 +1,94 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestTrailRecord(t *testing.T) {
+	now := time.Date(2022, 10, 1, 14, 32, 0, 0, time.UTC)
+	trail := NewTrail(2)
+	key := "default-eg"
+
+	listener := &listenerv3.Listener{Name: "http"}
+	cluster := &clusterv3.Cluster{Name: "backend"}
+	trail.Record(key, xdstypes.XdsResources{
+		resource.ListenerType: []types.Resource{listener},
+		resource.ClusterType:  []types.Resource{cluster},
+	}, now)
+
+	// An identical snapshot is not recorded.
+	trail.Record(key, xdstypes.XdsResources{
+		resource.ListenerType: []types.Resource{listener},
+		resource.ClusterType:  []types.Resource{cluster},
+	}, now.Add(time.Second))
+
+	trail.Record(key, xdstypes.XdsResources{
+		resource.ListenerType: []types.Resource{listener},
+		resource.ClusterType: []types.Resource{
+			&clusterv3.Cluster{Name: "backend", ConnectTimeout: durationpb.New(time.Second)},
+			&clusterv3.Cluster{Name: "backend-2"},
+		},
+	}, now.Add(time.Minute))
+
+	entries := trail.Entries()[key]
+	require.Len(t, entries, 2)
+	require.Equal(t, int64(1), entries[0].Generation)
+	require.Equal(t, []string{"Cluster/backend", "Listener/http"}, entries[0].Added)
+	require.Equal(t, int64(2), entries[1].Generation)
+	require.Equal(t, now.Add(time.Minute), entries[1].Time)
+	require.Equal(t, "added Cluster/backend-2; modified Cluster/backend", entries[1].Summary())
+	require.NotEqual(t, entries[0].Hash, entries[1].Hash)
+
+	// The snapshot is cleared, and the oldest generation is dropped.
+	trail.Record(key, nil, now.Add(time.Hour))
+	entries = trail.Entries()[key]
+	require.Len(t, entries, 2)
+	require.Equal(t, int64(3), entries[1].Generation)
+	require.Empty(t, entries[1].Hash)
+	require.Equal(t, []string{"Cluster/backend", "Cluster/backend-2", "Listener/http"}, entries[1].Removed)
+
+	// Clearing an unknown key is not recorded.
+	trail.Record("default-unknown", nil, now)
+	require.NotContains(t, trail.Entries(), "default-unknown")
+}
+
+func TestTrailServeHTTP(t *testing.T) {
+	trail := NewTrail(10)
+	trail.Record("default-eg", xdstypes.XdsResources{
+		resource.ListenerType: []types.Resource{&listenerv3.Listener{Name: "http"}},
+	}, time.Now())
+	trail.Record("default-other", xdstypes.XdsResources{
+		resource.ListenerType: []types.Resource{&listenerv3.Listener{Name: "http"}},
+	}, time.Now())
+
+	rec := httptest.NewRecorder()
+	trail.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, HandlerPath+"?key=default-eg", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	entries := make(map[string][]Entry)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &entries))
+	require.Len(t, entries, 1)
+	require.Len(t, entries["default-eg"], 1)
+
+	rec = httptest.NewRecorder()
+	trail.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, HandlerPath, nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,313 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package bootstrap
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	xdsrunner "github.com/envoyproxy/gateway/internal/xds/server/runner"
+)
+
+const (
+	// envoyCfgFileName is the name of the Envoy configuration file.
+	envoyCfgFileName = "bootstrap.yaml"
+	// envoyGatewayXdsServerHost is the DNS name of the Xds Server within Envoy Gateway.
+	// It defaults to the Envoy Gateway Kubernetes service.
+	envoyGatewayXdsServerHost = "envoy-gateway"
+	// envoyAdminAddress is the listening address of the envoy admin interface.
+	envoyAdminAddress = "127.0.0.1"
+	// envoyAdminPort is the port used to expose admin interface.
+	envoyAdminPort = 19000
+	// envoyAdminAccessLogPath is the path used to expose admin access log.
+	envoyAdminAccessLogPath = "/dev/null"
+	// envoyHealthAddress is the listening address of the envoy health listener.
+	envoyHealthAddress = "0.0.0.0"
+	// DefaultSdsDir is the directory containing the SDS resource files of
+	// Kubernetes managed proxies.
+	DefaultSdsDir = "/sds"
+	// SdsCAFilename is the name of the SDS resource file of the xDS trusted CA.
+	SdsCAFilename = "xds-trusted-ca.json"
+	// SdsCertFilename is the name of the SDS resource file of the xDS client certificate.
+	SdsCertFilename = "xds-certificate.json"
+	// SPIFFEClusterName is the name of the cluster of the SPIFFE Workload API,
+	// serving the SVID and trust bundle of the proxies over SDS.
+	SPIFFEClusterName = "spiffe_workload_api"
+	// SPIFFESVIDName is the name of the SDS secret of the default SVID of the
+	// proxies, as served by the SPIRE agent.
+	SPIFFESVIDName = "default"
+	// SPIFFEBundleName is the name of the SDS secret of the trust bundle of the
+	// trust domain of the proxies, as served by the SPIRE agent.
+	SPIFFEBundleName = "ROOTCA"
+)
+
+//go:embed bootstrap.yaml.tpl
+var bootstrapTmplStr string
+
+var bootstrapTmpl = template.Must(template.New(envoyCfgFileName).Parse(bootstrapTmplStr))
+
+// bootstrapConfig defines the envoy Bootstrap configuration.
+type bootstrapConfig struct {
+	// parameters defines configurable bootstrap configuration parameters.
+	parameters bootstrapParameters
+	// rendered is the rendered bootstrap configuration.
+	rendered string
+}
+
+// bootstrapParameters defines the envoy Bootstrap configuration parameters.
+type bootstrapParameters struct {
+	// XdsServer defines the configuration of the XDS server.
+	XdsServer xdsServerParameters
+	// AdminServer defines the configuration of the Envoy admin interface.
+	AdminServer adminServerParameters
+	// HealthServer defines the configuration of the Envoy health listener.
+	HealthServer healthServerParameters
+	// Node defines the identity of the Envoy node. If unset, it must be
+	// provided through the Envoy command line.
+	Node *nodeParameters
+	// SdsDir is the directory containing the SDS resource files of the
+	// xDS client certificate and trusted CA.
+	SdsDir string
+	// SPIFFE defines the Workload API the xDS client certificate and trusted
+	// CA are fetched from instead. If unset, the SdsDir files are used.
+	SPIFFE *spiffeParameters
+	// RuntimeFlags are the runtime flags set in the static runtime layer,
+	// overridden by the RTDS layer.
+	RuntimeFlags map[string]string
+	// OverloadManager defines the overload manager protecting the heap of
+	// the proxy. If unset, the proxy is not protected from overload.
+	OverloadManager *overloadManagerParameters
+	// StatsSink enables the proxy to push its stats to the metrics service
+	// of the xDS server.
+	StatsSink bool
+	// ExcludedStatsPrefixes are the prefixes of the names of the stats the
+	// proxy does not create.
+	ExcludedStatsPrefixes []string
+}
+
+type overloadManagerParameters struct {
+	// MaxHeapSizeBytes is the maximum size of the heap of the proxy.
+	MaxHeapSizeBytes uint64
+}
+
+type spiffeParameters struct {
+	// SocketPath is the path of the Workload API socket.
+	SocketPath string
+	// XdsServerID is the SPIFFE ID of the xDS server certificate.
+	XdsServerID string
+	// SVIDName is the SDS secret name of the SVID of the proxy.
+	SVIDName string
+	// BundleName is the SDS secret name of the trust bundle.
+	BundleName string
+	// ClusterName is the name of the Workload API cluster.
+	ClusterName string
+}
+
+type xdsServerParameters struct {
+	// Address is the address of the XDS Server that Envoy is managed by.
+	Address string
+	// Port is the port of the XDS Server that Envoy is managed by.
+	Port int32
+}
+
+type adminServerParameters struct {
+	// Address is the address of the Envoy admin interface.
+	Address string
+	// Port is the port of the Envoy admin interface.
+	Port int32
+	// AccessLogPath is the path of the Envoy admin access log.
+	AccessLogPath string
+}
+
+type healthServerParameters struct {
+	// Address is the address of the Envoy health listener.
+	Address string
+	// Port is the port of the Envoy health listener.
+	Port int32
+	// ReadinessPath is the path of the readiness endpoint.
+	ReadinessPath string
+}
+
+type nodeParameters struct {
+	// Cluster is the node cluster, i.e. the IR key of the owning Gateway.
+	Cluster string
+	// ID is the unique ID of the node.
+	ID string
+}
+
+// ExternalOptions defines the options used to render the bootstrap configuration
+// of an Envoy instance that is not managed by Envoy Gateway, e.g. running on a VM.
+type ExternalOptions struct {
+	// Cluster is the node cluster, which binds the node to the owning Gateway.
+	Cluster string
+	// NodeID is the unique ID of the node.
+	NodeID string
+	// XdsAddress is the address of the xDS server reachable from the node.
+	XdsAddress string
+	// XdsPort is the port of the xDS server reachable from the node.
+	XdsPort int32
+	// SdsDir is the directory the SDS resource files are installed into on the node.
+	SdsDir string
+}
+
+// render the stringified bootstrap config in yaml format.
+func (b *bootstrapConfig) render() error {
+	buf := new(strings.Builder)
+	if err := bootstrapTmpl.Execute(buf, b.parameters); err != nil {
+		return fmt.Errorf("failed to render bootstrap config: %v", err)
+	}
+	b.rendered = buf.String()
+
+	return nil
+}
+
+// newHealthServerParameters returns the health listener parameters of the
+// provided health configuration, or of the default one if nil.
+func newHealthServerParameters(health *v1alpha1.ProxyHealth) healthServerParameters {
+	if health == nil {
+		health = &v1alpha1.ProxyHealth{
+			Port: v1alpha1.DefaultProxyHealthPort,
+			Path: v1alpha1.DefaultProxyHealthPath,
+		}
+	}
+	return healthServerParameters{
+		Address:       envoyHealthAddress,
+		Port:          health.Port,
+		ReadinessPath: health.Path,
+	}
+}
+
+// newSPIFFEParameters returns the Workload API parameters of the provided
+// SPIFFE configuration, or nil if unset.
+func newSPIFFEParameters(spiffe *v1alpha1.SPIFFE) *spiffeParameters {
+	if spiffe == nil {
+		return nil
+	}
+	return &spiffeParameters{
+		SocketPath:  spiffe.WorkloadAPISocketPath,
+		XdsServerID: spiffe.XdsServerID,
+		SVIDName:    SPIFFESVIDName,
+		BundleName:  SPIFFEBundleName,
+		ClusterName: SPIFFEClusterName,
+	}
+}
+
+// newOverloadManagerParameters returns the overload manager parameters of the
+// provided maximum heap size, or nil if zero.
+func newOverloadManagerParameters(maxHeapSizeBytes uint64) *overloadManagerParameters {
+	if maxHeapSizeBytes == 0 {
+		return nil
+	}
+	return &overloadManagerParameters{MaxHeapSizeBytes: maxHeapSizeBytes}
+}
+
+// GetRenderedBootstrapConfig renders the bootstrap YAML string of the Envoy
+// proxies managed by Envoy Gateway, serving readiness on the health listener
+// defined by health, or the default one if nil. If spiffe is set, the xDS
+// client certificate and trusted CA are fetched from the SPIFFE Workload API.
+// The runtimeFlags are set in the static layer of the runtime. If
+// maxHeapSizeBytes is not zero, the proxy shrinks its heap and then stops
+// accepting requests as its heap approaches it. If statsSink is true, the
+// proxy pushes its stats to the metrics service of the xDS server. The stats
+// whose names start with one of the excludedStatsPrefixes are not created.
+func GetRenderedBootstrapConfig(health *v1alpha1.ProxyHealth, spiffe *v1alpha1.SPIFFE, runtimeFlags map[string]string, maxHeapSizeBytes uint64, statsSink bool, excludedStatsPrefixes []string) (string, error) {
+	cfg := &bootstrapConfig{
+		parameters: bootstrapParameters{
+			XdsServer: xdsServerParameters{
+				Address: envoyGatewayXdsServerHost,
+				Port:    xdsrunner.XdsServerPort,
+			},
+			AdminServer: adminServerParameters{
+				Address:       envoyAdminAddress,
+				Port:          envoyAdminPort,
+				AccessLogPath: envoyAdminAccessLogPath,
+			},
+			HealthServer:          newHealthServerParameters(health),
+			SdsDir:                DefaultSdsDir,
+			SPIFFE:                newSPIFFEParameters(spiffe),
+			RuntimeFlags:          runtimeFlags,
+			OverloadManager:       newOverloadManagerParameters(maxHeapSizeBytes),
+			StatsSink:             statsSink,
+			ExcludedStatsPrefixes: excludedStatsPrefixes,
+		},
+	}
+
+	if err := cfg.render(); err != nil {
+		return "", err
+	}
+
+	return cfg.rendered, nil
+}
+
+// GetRenderedExternalBootstrapConfig renders the bootstrap YAML string of an
+// externally managed Envoy instance.
+func GetRenderedExternalBootstrapConfig(opts *ExternalOptions) (string, error) {
+	if opts == nil {
+		return "", errors.New("external bootstrap options are nil")
+	}
+	if opts.Cluster == "" || opts.NodeID == "" {
+		return "", errors.New("node cluster and ID must be set")
+	}
+	if opts.XdsAddress == "" {
+		return "", errors.New("xds server address must be set")
+	}
+
+	port := opts.XdsPort
+	if port == 0 {
+		port = xdsrunner.XdsServerPort
+	}
+	sdsDir := opts.SdsDir
+	if sdsDir == "" {
+		sdsDir = DefaultSdsDir
+	}
+
+	cfg := &bootstrapConfig{
+		parameters: bootstrapParameters{
+			XdsServer: xdsServerParameters{
+				Address: opts.XdsAddress,
+				Port:    port,
+			},
+			AdminServer: adminServerParameters{
+				Address:       envoyAdminAddress,
+				Port:          envoyAdminPort,
+				AccessLogPath: envoyAdminAccessLogPath,
+			},
+			HealthServer: newHealthServerParameters(nil),
+			Node: &nodeParameters{
+				Cluster: opts.Cluster,
+				ID:      opts.NodeID,
+			},
+			SdsDir: filepath.Clean(sdsDir),
+		},
+	}
+
+	if err := cfg.render(); err != nil {
+		return "", err
+	}
+
+	return cfg.rendered, nil
+}
+
+// SdsCAConfig returns the SDS resource of the xDS trusted CA, read from caPath.
+// The xDS server certificate must be issued for the Envoy Gateway service.
+func SdsCAConfig(caPath string) string {
+	return fmt.Sprintf(`{"resources":[{"@type":"type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.Secret",`+
+		`"name":"xds_trusted_ca","validation_context":{"trusted_ca":{"filename":"%s"},`+
+		`"match_typed_subject_alt_names":[{"san_type":"DNS","matcher":{"exact":"envoy-gateway"}}]}}]}`, caPath)
+}
+
+// SdsCertificateConfig returns the SDS resource of the xDS client certificate,
+// read from certPath and keyPath.
+func SdsCertificateConfig(certPath, keyPath string) string {
+	return fmt.Sprintf(`{"resources":[{"@type":"type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.Secret",`+
+		`"name":"xds_certificate","tls_certificate":{"certificate_chain":{"filename":"%s"},`+
+		`"private_key":{"filename":"%s"}}}]}`, certPath, keyPath)
+}
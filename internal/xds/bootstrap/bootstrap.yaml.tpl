@@ -0,0 +1,198 @@
+admin:
+  access_log_path: {{ .AdminServer.AccessLogPath }}
+  address:
+    socket_address:
+      address: {{ .AdminServer.Address }}
+      port_value: {{ .AdminServer.Port }}
+bootstrap_extensions:
+- name: envoy.bootstrap.internal_listener
+  typed_config:
+    "@type": type.googleapis.com/envoy.extensions.bootstrap.internal_listener.v3.InternalListener
+dynamic_resources:
+  cds_config:
+    resource_api_version: V3
+    api_config_source:
+      api_type: DELTA_GRPC
+      transport_api_version: V3
+      grpc_services:
+      - envoy_grpc:
+          cluster_name: xds_cluster
+      set_node_on_first_message_only: true
+  lds_config:
+    resource_api_version: V3
+    api_config_source:
+      api_type: DELTA_GRPC
+      transport_api_version: V3
+      grpc_services:
+      - envoy_grpc:
+          cluster_name: xds_cluster
+      set_node_on_first_message_only: true
+{{- with .Node }}
+node:
+  cluster: {{ .Cluster }}
+  id: {{ .ID }}
+{{- end }}
+static_resources:
+  listeners:
+  - name: envoy-gateway-proxy-ready-{{ .HealthServer.Address }}-{{ .HealthServer.Port }}
+    address:
+      socket_address:
+        address: {{ .HealthServer.Address }}
+        port_value: {{ .HealthServer.Port }}
+        protocol: TCP
+    filter_chains:
+    - filters:
+      - name: envoy.filters.network.http_connection_manager
+        typed_config:
+          "@type": type.googleapis.com/envoy.extensions.filters.network.http_connection_manager.v3.HttpConnectionManager
+          stat_prefix: eg-ready-http
+          route_config:
+            name: local_route
+          http_filters:
+          - name: envoy.filters.http.health_check
+            typed_config:
+              "@type": type.googleapis.com/envoy.extensions.filters.http.health_check.v3.HealthCheck
+              pass_through_mode: false
+              headers:
+              - name: ":path"
+                string_match:
+                  exact: {{ .HealthServer.ReadinessPath }}
+          - name: envoy.filters.http.router
+            typed_config:
+              "@type": type.googleapis.com/envoy.extensions.filters.http.router.v3.Router
+  clusters:
+  - connect_timeout: 1s
+    load_assignment:
+      cluster_name: xds_cluster
+      endpoints:
+      - lb_endpoints:
+        - endpoint:
+            address:
+              socket_address:
+                address: {{ .XdsServer.Address }}
+                port_value: {{ .XdsServer.Port }}
+    http2_protocol_options: {}
+    name: xds_cluster
+    type: STRICT_DNS
+    transport_socket:
+      name: envoy.transport_sockets.tls
+      typed_config:
+        "@type": type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.UpstreamTlsContext
+        common_tls_context:
+          tls_params:
+            tls_maximum_protocol_version: TLSv1_3
+{{- with .SPIFFE }}
+          tls_certificate_sds_secret_configs:
+          - name: {{ .SVIDName }}
+            sds_config:
+              resource_api_version: V3
+              api_config_source:
+                api_type: GRPC
+                transport_api_version: V3
+                grpc_services:
+                - envoy_grpc:
+                    cluster_name: {{ .ClusterName }}
+          combined_validation_context:
+            default_validation_context:
+              match_typed_subject_alt_names:
+              - san_type: URI
+                matcher:
+                  exact: "{{ .XdsServerID }}"
+            validation_context_sds_secret_config:
+              name: {{ .BundleName }}
+              sds_config:
+                resource_api_version: V3
+                api_config_source:
+                  api_type: GRPC
+                  transport_api_version: V3
+                  grpc_services:
+                  - envoy_grpc:
+                      cluster_name: {{ .ClusterName }}
+  - name: {{ .ClusterName }}
+    connect_timeout: 1s
+    http2_protocol_options: {}
+    load_assignment:
+      cluster_name: {{ .ClusterName }}
+      endpoints:
+      - lb_endpoints:
+        - endpoint:
+            address:
+              pipe:
+                path: {{ .SocketPath }}
+{{- else }}
+          tls_certificate_sds_secret_configs:
+          - name: xds_certificate
+            sds_config:
+              path_config_source:
+                path: "{{ .SdsDir }}/xds-certificate.json"
+              resource_api_version: V3
+          validation_context_sds_secret_config:
+            name: xds_trusted_ca
+            sds_config:
+              path_config_source:
+                path: "{{ .SdsDir }}/xds-trusted-ca.json"
+              resource_api_version: V3
+{{- end }}
+{{- with .OverloadManager }}
+overload_manager:
+  refresh_interval: 0.25s
+  resource_monitors:
+  - name: envoy.resource_monitors.fixed_heap
+    typed_config:
+      "@type": type.googleapis.com/envoy.extensions.resource_monitors.fixed_heap.v3.FixedHeapConfig
+      max_heap_size_bytes: {{ .MaxHeapSizeBytes }}
+  actions:
+  - name: envoy.overload_actions.shrink_heap
+    triggers:
+    - name: envoy.resource_monitors.fixed_heap
+      threshold:
+        value: 0.95
+  - name: envoy.overload_actions.stop_accepting_requests
+    triggers:
+    - name: envoy.resource_monitors.fixed_heap
+      threshold:
+        value: 0.98
+{{- end }}
+{{- if .StatsSink }}
+stats_flush_interval: 10s
+stats_sinks:
+- name: envoy.stat_sinks.metrics_service
+  typed_config:
+    "@type": type.googleapis.com/envoy.config.metrics.v3.MetricsServiceConfig
+    transport_api_version: V3
+    report_counters_as_deltas: true
+    grpc_service:
+      envoy_grpc:
+        cluster_name: xds_cluster
+{{- end }}
+{{- with .ExcludedStatsPrefixes }}
+stats_config:
+  stats_matcher:
+    exclusion_list:
+      patterns:
+{{- range . }}
+      - prefix: {{ printf "%q" . }}
+{{- end }}
+{{- end }}
+layered_runtime:
+  layers:
+{{- with .RuntimeFlags }}
+    - name: static-0
+      static_layer:
+{{- range $name, $value := . }}
+        {{ printf "%q" $name }}: {{ printf "%q" $value }}
+{{- end }}
+{{- end }}
+    - name: runtime-0
+      rtds_layer:
+        rtds_config:
+          resource_api_version: V3
+          api_config_source:
+            transport_api_version: V3
+            api_type: DELTA_GRPC
+            grpc_services:
+              envoy_grpc:
+                cluster_name: xds_cluster
+        name: runtime-0
+    - name: admin-0
+      admin_layer: {}
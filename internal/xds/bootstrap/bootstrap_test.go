@@ -0,0 +1,239 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package bootstrap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+func TestGetRenderedBootstrapConfig(t *testing.T) {
+	got, err := GetRenderedBootstrapConfig(nil, nil, nil, 0, false, nil)
+	require.NoError(t, err)
+
+	// The node identity of managed proxies is provided through the command line.
+	assert.NotContains(t, got, "node:")
+	assert.Contains(t, got, "address: envoy-gateway")
+	assert.Contains(t, got, `path: "/sds/xds-certificate.json"`)
+	assert.Contains(t, got, "port_value: 19001")
+	assert.Contains(t, got, "exact: /ready")
+	// Internal listeners are enabled for the listeners chained to HTTP listeners.
+	assert.Contains(t, got, "name: envoy.bootstrap.internal_listener")
+
+	out := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(got), &out))
+}
+
+func TestGetRenderedBootstrapConfigHealth(t *testing.T) {
+	got, err := GetRenderedBootstrapConfig(&v1alpha1.ProxyHealth{Port: 8002, Path: "/healthz"}, nil, nil, 0, false, nil)
+	require.NoError(t, err)
+
+	assert.Contains(t, got, "name: envoy-gateway-proxy-ready-0.0.0.0-8002")
+	assert.Contains(t, got, "port_value: 8002")
+	assert.Contains(t, got, "exact: /healthz")
+
+	out := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(got), &out))
+}
+
+func TestGetRenderedBootstrapConfigSPIFFE(t *testing.T) {
+	got, err := GetRenderedBootstrapConfig(nil, &v1alpha1.SPIFFE{
+		WorkloadAPISocketPath: "/run/spire/sockets/agent.sock",
+		XdsServerID:           "spiffe://example.org/ns/envoy-gateway-system/sa/envoy-gateway",
+	}, nil, 0, false, nil)
+	require.NoError(t, err)
+
+	// The xDS client certificate and trusted CA are fetched from the Workload API.
+	assert.NotContains(t, got, "xds-certificate.json")
+	assert.NotContains(t, got, "xds-trusted-ca.json")
+	assert.Contains(t, got, "name: spiffe_workload_api")
+	assert.Contains(t, got, "path: /run/spire/sockets/agent.sock")
+	assert.Contains(t, got, "- name: default")
+	assert.Contains(t, got, "name: ROOTCA")
+	assert.Contains(t, got, `exact: "spiffe://example.org/ns/envoy-gateway-system/sa/envoy-gateway"`)
+
+	out := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(got), &out))
+}
+
+func TestGetRenderedBootstrapConfigRuntimeFlags(t *testing.T) {
+	got, err := GetRenderedBootstrapConfig(nil, nil, map[string]string{
+		"envoy.reloadable_features.http2_use_oghttp2": "false",
+		"overload.global_downstream_max_connections":  "50000",
+	}, 0, false, nil)
+	require.NoError(t, err)
+
+	out := struct {
+		LayeredRuntime struct {
+			Layers []struct {
+				Name        string            `json:"name"`
+				StaticLayer map[string]string `json:"static_layer"`
+			} `json:"layers"`
+		} `json:"layered_runtime"`
+	}{}
+	require.NoError(t, yaml.Unmarshal([]byte(got), &out))
+	// The static layer is overridden by the RTDS layer, itself overridden by
+	// the admin layer.
+	require.Len(t, out.LayeredRuntime.Layers, 3)
+	assert.Equal(t, "static-0", out.LayeredRuntime.Layers[0].Name)
+	assert.Equal(t, map[string]string{
+		"envoy.reloadable_features.http2_use_oghttp2": "false",
+		"overload.global_downstream_max_connections":  "50000",
+	}, out.LayeredRuntime.Layers[0].StaticLayer)
+	assert.Equal(t, "runtime-0", out.LayeredRuntime.Layers[1].Name)
+	assert.Equal(t, "admin-0", out.LayeredRuntime.Layers[2].Name)
+}
+
+func TestGetRenderedBootstrapConfigOverloadManager(t *testing.T) {
+	got, err := GetRenderedBootstrapConfig(nil, nil, nil, 0, false, nil)
+	require.NoError(t, err)
+	assert.NotContains(t, got, "overload_manager:")
+
+	got, err = GetRenderedBootstrapConfig(nil, nil, nil, 858993459, false, nil)
+	require.NoError(t, err)
+
+	out := struct {
+		OverloadManager struct {
+			ResourceMonitors []struct {
+				Name        string `json:"name"`
+				TypedConfig struct {
+					MaxHeapSizeBytes uint64 `json:"max_heap_size_bytes"`
+				} `json:"typed_config"`
+			} `json:"resource_monitors"`
+			Actions []struct {
+				Name string `json:"name"`
+			} `json:"actions"`
+		} `json:"overload_manager"`
+	}{}
+	require.NoError(t, yaml.Unmarshal([]byte(got), &out))
+	require.Len(t, out.OverloadManager.ResourceMonitors, 1)
+	assert.Equal(t, "envoy.resource_monitors.fixed_heap", out.OverloadManager.ResourceMonitors[0].Name)
+	assert.Equal(t, uint64(858993459), out.OverloadManager.ResourceMonitors[0].TypedConfig.MaxHeapSizeBytes)
+	require.Len(t, out.OverloadManager.Actions, 2)
+	assert.Equal(t, "envoy.overload_actions.shrink_heap", out.OverloadManager.Actions[0].Name)
+	assert.Equal(t, "envoy.overload_actions.stop_accepting_requests", out.OverloadManager.Actions[1].Name)
+}
+
+func TestGetRenderedBootstrapConfigStatsSink(t *testing.T) {
+	got, err := GetRenderedBootstrapConfig(nil, nil, nil, 0, false, nil)
+	require.NoError(t, err)
+	assert.NotContains(t, got, "stats_sinks:")
+
+	got, err = GetRenderedBootstrapConfig(nil, nil, nil, 0, true, nil)
+	require.NoError(t, err)
+
+	out := struct {
+		StatsSinks []struct {
+			Name        string `json:"name"`
+			TypedConfig struct {
+				GrpcService struct {
+					EnvoyGrpc struct {
+						ClusterName string `json:"cluster_name"`
+					} `json:"envoy_grpc"`
+				} `json:"grpc_service"`
+			} `json:"typed_config"`
+		} `json:"stats_sinks"`
+	}{}
+	require.NoError(t, yaml.Unmarshal([]byte(got), &out))
+	require.Len(t, out.StatsSinks, 1)
+	assert.Equal(t, "envoy.stat_sinks.metrics_service", out.StatsSinks[0].Name)
+	assert.Equal(t, "xds_cluster", out.StatsSinks[0].TypedConfig.GrpcService.EnvoyGrpc.ClusterName)
+}
+
+func TestGetRenderedExternalBootstrapConfig(t *testing.T) {
+	testCases := []struct {
+		name      string
+		opts      *ExternalOptions
+		expectErr bool
+	}{
+		{
+			name: "valid options",
+			opts: &ExternalOptions{
+				Cluster:    "default-eg",
+				NodeID:     "vm-1",
+				XdsAddress: "10.0.0.1",
+				XdsPort:    18001,
+				SdsDir:     "/etc/envoy/sds/",
+			},
+		},
+		{
+			name:      "nil options",
+			expectErr: true,
+		},
+		{
+			name: "missing node id",
+			opts: &ExternalOptions{
+				Cluster:    "default-eg",
+				XdsAddress: "10.0.0.1",
+			},
+			expectErr: true,
+		},
+		{
+			name: "missing xds address",
+			opts: &ExternalOptions{
+				Cluster: "default-eg",
+				NodeID:  "vm-1",
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := GetRenderedExternalBootstrapConfig(tc.opts)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			out := struct {
+				Node struct {
+					Cluster string `json:"cluster"`
+					ID      string `json:"id"`
+				} `json:"node"`
+			}{}
+			require.NoError(t, yaml.Unmarshal([]byte(got), &out))
+			assert.Equal(t, tc.opts.Cluster, out.Node.Cluster)
+			assert.Equal(t, tc.opts.NodeID, out.Node.ID)
+			assert.Contains(t, got, "address: 10.0.0.1")
+			assert.Contains(t, got, "port_value: 18001")
+			assert.Contains(t, got, `path: "/etc/envoy/sds/xds-trusted-ca.json"`)
+		})
+	}
+}
+
+func TestGetRenderedBootstrapConfigExcludedStats(t *testing.T) {
+	got, err := GetRenderedBootstrapConfig(nil, nil, nil, 0, false, nil)
+	require.NoError(t, err)
+	assert.NotContains(t, got, "stats_config:")
+
+	got, err = GetRenderedBootstrapConfig(nil, nil, nil, 0, false, []string{"vhost.", "cluster.stats_overflow."})
+	require.NoError(t, err)
+
+	out := struct {
+		StatsConfig struct {
+			StatsMatcher struct {
+				ExclusionList struct {
+					Patterns []struct {
+						Prefix string `json:"prefix"`
+					} `json:"patterns"`
+				} `json:"exclusion_list"`
+			} `json:"stats_matcher"`
+		} `json:"stats_config"`
+	}{}
+	require.NoError(t, yaml.Unmarshal([]byte(got), &out))
+	patterns := out.StatsConfig.StatsMatcher.ExclusionList.Patterns
+	require.Len(t, patterns, 2)
+	assert.Equal(t, "vhost.", patterns[0].Prefix)
+	assert.Equal(t, "cluster.stats_overflow.", patterns[1].Prefix)
+}
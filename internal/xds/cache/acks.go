@@ -0,0 +1,216 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_cache_v3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// snapshotAcks tracks the ACKs and NACKs of the current snapshot of a cluster
+// by its proxies.
+type snapshotAcks struct {
+	// types are the types of the resources of the current snapshot.
+	types []string
+	// pendingTypes are the types of the resources of the current snapshot
+	// not ACKed yet.
+	pendingTypes map[string]bool
+	// rejection is the first rejection of the current snapshot by a proxy,
+	// or nil if no proxy rejected it.
+	rejection *Rejection
+	// rolledBack holds the IDs of the proxies which rejected the current
+	// snapshot and were rolled back to lastAcked.
+	rolledBack map[string]bool
+	// lastAcked is the last snapshot ACKed for all its types of resources,
+	// which the proxies rejecting the current snapshot are rolled back to.
+	lastAcked *envoy_cache_v3.Snapshot
+	// nodeVersions holds the versions ACKed by each proxy of the cluster,
+	// keyed by node ID and type.
+	nodeVersions map[string]map[string]string
+}
+
+// trackSnapshot starts tracking the ACKs of the new snapshot of the cluster.
+// It must be called with the lock held.
+func (s *snapshotcache) trackSnapshot(cluster string, resources types.XdsResources) {
+	acks := s.acks[cluster]
+	if acks == nil {
+		acks = &snapshotAcks{nodeVersions: make(map[string]map[string]string)}
+		s.acks[cluster] = acks
+	}
+
+	acks.types = nil
+	acks.pendingTypes = make(map[string]bool, len(resources))
+	for typeURL, res := range resources {
+		if len(res) > 0 {
+			acks.types = append(acks.types, typeURL)
+			acks.pendingTypes[typeURL] = true
+		}
+	}
+	acks.rejection = nil
+	acks.rolledBack = make(map[string]bool)
+	s.setRejected(cluster, nil)
+	s.updateSyncStatus(cluster)
+}
+
+// OnRejection sets the handler called when the rejection of the current
+// snapshot of a cluster changes.
+func (s *snapshotcache) OnRejection(handler RejectionHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onRejection = handler
+}
+
+// setRejected reports the rejection of the current snapshot of the cluster,
+// or nil if it was not rejected. It must be called with the lock held.
+func (s *snapshotcache) setRejected(cluster string, rejection *Rejection) {
+	value := 0.0
+	if rejection != nil {
+		value = 1
+	}
+	snapshotRejected.WithLabelValues(cluster).Set(value)
+	if s.onRejection != nil {
+		s.onRejection(cluster, rejection)
+	}
+}
+
+// rejectedResource returns the name of the resource of the provided type of
+// the snapshot named by the error message of a NACK, or an empty string if the
+// message names none of them. The longest name is preferred, so that a
+// listener-10 is not mistaken for a listener-1.
+func rejectedResource(snapshot *envoy_cache_v3.Snapshot, typeURL, errorMessage string) string {
+	var names []string
+	for name := range snapshot.GetResources(typeURL) {
+		if strings.Contains(errorMessage, name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) > len(names[j])
+		}
+		return names[i] < names[j]
+	})
+	return names[0]
+}
+
+// handleAck records the ACK or NACK carried by a discovery request of a proxy
+// of the cluster. The version is the version ACKed by the proxy, or empty if
+// the request doesn't carry it, as for incremental xDS. On a NACK, the proxy
+// is rolled back to the last ACKed snapshot, rather than being sent the
+// rejected snapshot over and over, until a new snapshot is generated. The
+// other proxies of the cluster keep the current snapshot: a single proxy may
+// reject it for reasons of its own, e.g. an older version of Envoy. The first
+// rejection is reported with the rejected resource and the error message. It
+// must be called with the lock held.
+func (s *snapshotcache) handleAck(node *envoy_config_core_v3.Node, typeURL, version, nonce, errorMessage string, nacked bool) {
+	// The first request of a type doesn't respond to a snapshot.
+	if nonce == "" {
+		return
+	}
+
+	cluster := node.Cluster
+	current, acks := s.lastSnapshot[cluster], s.acks[cluster]
+	if current == nil || acks == nil {
+		return
+	}
+
+	if !nacked {
+		if version == "" {
+			version = current.GetVersion(typeURL)
+		}
+		if acks.nodeVersions[node.Id] == nil {
+			acks.nodeVersions[node.Id] = make(map[string]string)
+		}
+		acks.nodeVersions[node.Id][typeURL] = version
+		s.updateSyncStatus(cluster)
+
+		if version != current.GetVersion(typeURL) {
+			return
+		}
+		delete(acks.pendingTypes, typeURL)
+		if len(acks.pendingTypes) == 0 && acks.rejection == nil {
+			acks.lastAcked = current
+		}
+		return
+	}
+
+	snapshotNacks.WithLabelValues(cluster, typeURL).Inc()
+	s.log.Errorf("Node %s of cluster %s rejected the %s resources of version %s: %s",
+		node.Id, cluster, typeURL, current.GetVersion(typeURL), errorMessage)
+	if acks.rejection == nil {
+		acks.rejection = &Rejection{
+			Node:     node.Id,
+			TypeURL:  typeURL,
+			Resource: rejectedResource(current, typeURL, errorMessage),
+			Message:  errorMessage,
+		}
+		s.setRejected(cluster, acks.rejection)
+	}
+
+	if acks.lastAcked == nil || acks.lastAcked == current {
+		s.log.Errorf("No snapshot of cluster %s was accepted by its proxies, not rolling back node %s", cluster, node.Id)
+		return
+	}
+	if acks.rolledBack[node.Id] {
+		return
+	}
+
+	rollback := acks.lastAcked
+	s.log.Infof("Rolling back node %s of cluster %s to version %s", node.Id, cluster, rollback.GetVersion(typeURL))
+	snapshotRollbacks.WithLabelValues(cluster).Inc()
+	acks.rolledBack[node.Id] = true
+	if err := s.SetSnapshot(context.TODO(), s.hash.ID(node), rollback); err != nil {
+		s.log.Errorf("Failed to roll back node %s of cluster %s: %v", node.Id, cluster, err)
+	}
+}
+
+// updateSyncStatus updates the sync status of the proxies of the cluster. A
+// proxy is in sync once it ACKed the current snapshot for all its types of
+// resources. It must be called with the lock held.
+func (s *snapshotcache) updateSyncStatus(cluster string) {
+	current, acks := s.lastSnapshot[cluster], s.acks[cluster]
+	if current == nil || acks == nil {
+		return
+	}
+
+	for _, nodeID := range s.getNodeIDs(cluster) {
+		synced := 1.0
+		for _, typeURL := range acks.types {
+			if acks.nodeVersions[nodeID][typeURL] != current.GetVersion(typeURL) {
+				synced = 0
+				break
+			}
+		}
+		nodeSynced.WithLabelValues(cluster, nodeID).Set(synced)
+	}
+}
+
+// forgetNode stops tracking the sync status of the node, once it has no open
+// stream left. It must be called with the lock held.
+func (s *snapshotcache) forgetNode(node *envoy_config_core_v3.Node) {
+	if node == nil {
+		return
+	}
+	for _, other := range s.streamIDNodeInfo {
+		if other != nil && other.Id == node.Id && other.Cluster == node.Cluster {
+			return
+		}
+	}
+
+	if acks := s.acks[node.Cluster]; acks != nil {
+		delete(acks.nodeVersions, node.Id)
+	}
+	nodeSynced.DeleteLabelValues(node.Cluster, node.Id)
+}
@@ -0,0 +1,107 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"sync"
+	"time"
+
+	envoy_cache_v3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	envoy_stream_v3 "github.com/envoyproxy/go-control-plane/pkg/server/stream/v3"
+	"github.com/go-logr/logr"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+// faultyCache injects faults into the delta xDS responses of the wrapped
+// cache to the proxies matching the faults.
+type faultyCache struct {
+	SnapshotCacheWithCallbacks
+	// faults are the faults injected, by node ID.
+	faults map[string]*v1alpha1.XdsFault
+	log    logr.Logger
+}
+
+// WithFaults returns the cache injecting the provided faults into the delta
+// xDS responses of the wrapped cache, or the wrapped cache if there are no
+// faults.
+func WithFaults(cache SnapshotCacheWithCallbacks, faults []v1alpha1.XdsFault, log logr.Logger) SnapshotCacheWithCallbacks {
+	if len(faults) == 0 {
+		return cache
+	}
+
+	c := &faultyCache{
+		SnapshotCacheWithCallbacks: cache,
+		faults:                     make(map[string]*v1alpha1.XdsFault),
+		log:                        log,
+	}
+	for i := range faults {
+		for _, nodeID := range faults[i].NodeIDs {
+			if _, ok := c.faults[nodeID]; !ok {
+				c.faults[nodeID] = &faults[i]
+			}
+		}
+	}
+	log.Info("injecting faults into the xds responses", "nodes", len(c.faults))
+	return c
+}
+
+// CreateDeltaWatch creates a watch of the wrapped cache, whose responses are
+// delayed or dropped if a fault is injected for the node of the request. The
+// returned cancel func is never nil, even if the wrapped cache responded
+// without creating a watch.
+func (c *faultyCache) CreateDeltaWatch(req *envoy_cache_v3.DeltaRequest, state envoy_stream_v3.StreamState, out chan envoy_cache_v3.DeltaResponse) func() {
+	nodeID := req.GetNode().GetId()
+	fault, ok := c.faults[nodeID]
+	if !ok {
+		if cancelWatch := c.SnapshotCacheWithCallbacks.CreateDeltaWatch(req, state, out); cancelWatch != nil {
+			return cancelWatch
+		}
+		return func() {}
+	}
+
+	// The wrapped cache may respond before returning, so the channel is
+	// buffered like the ones of the server.
+	in := make(chan envoy_cache_v3.DeltaResponse, 1)
+	cancelWatch := c.SnapshotCacheWithCallbacks.CreateDeltaWatch(req, state, in)
+	done := make(chan struct{})
+	go func() {
+		var resp envoy_cache_v3.DeltaResponse
+		select {
+		case resp = <-in:
+		case <-done:
+			return
+		}
+
+		if fault.Drop {
+			c.log.Info("dropped xds response", "node", nodeID, "type", req.GetTypeUrl())
+			return
+		}
+		if fault.Delay != nil {
+			c.log.Info("delaying xds response", "node", nodeID, "type", req.GetTypeUrl(), "delay", fault.Delay.Duration)
+			select {
+			case <-time.After(fault.Delay.Duration):
+			case <-done:
+				return
+			}
+		}
+
+		select {
+		case out <- resp:
+		case <-done:
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			if cancelWatch != nil {
+				cancelWatch()
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	envoy_cache_types "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	envoy_cache_v3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	envoy_stream_v3 "github.com/envoyproxy/go-control-plane/pkg/server/stream/v3"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+)
+
+func TestWithFaults(t *testing.T) {
+	delay := 100 * time.Millisecond
+	c := WithFaults(NewSnapshotCache(false, false, logr.Discard()), []v1alpha1.XdsFault{
+		{NodeIDs: []string{"dropped"}, Drop: true},
+		{NodeIDs: []string{"delayed", "dropped"}, Delay: &metav1.Duration{Duration: delay}},
+	}, logr.Discard())
+
+	snapshot, err := envoy_cache_v3.NewSnapshot("1", map[resource.Type][]envoy_cache_types.Resource{
+		resource.ListenerType: {&envoy_config_listener_v3.Listener{Name: "http"}},
+	})
+	require.NoError(t, err)
+	for _, nodeID := range []string{"healthy", "delayed", "dropped"} {
+		require.NoError(t, c.SetSnapshot(context.Background(), nodeID, snapshot))
+	}
+
+	watch := func(nodeID string) (chan envoy_cache_v3.DeltaResponse, func()) {
+		out := make(chan envoy_cache_v3.DeltaResponse, 1)
+		cancel := c.CreateDeltaWatch(&envoy_service_discovery_v3.DeltaDiscoveryRequest{
+			Node:    &envoy_config_core_v3.Node{Id: nodeID},
+			TypeUrl: resource.ListenerType,
+		}, envoy_stream_v3.NewStreamState(true, nil), out)
+		return out, cancel
+	}
+
+	out, cancel := watch("healthy")
+	defer cancel()
+	select {
+	case <-out:
+	case <-time.After(time.Second):
+		t.Fatal("the response to the healthy node was not received")
+	}
+
+	start := time.Now()
+	out, cancel = watch("delayed")
+	defer cancel()
+	select {
+	case <-out:
+		require.GreaterOrEqual(t, time.Since(start), delay)
+	case <-time.After(time.Second):
+		t.Fatal("the response to the delayed node was not received")
+	}
+
+	out, cancel = watch("dropped")
+	defer cancel()
+	select {
+	case <-out:
+		t.Fatal("the response to the dropped node was received")
+	case <-time.After(2 * delay):
+	}
+}
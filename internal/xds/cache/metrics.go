@@ -0,0 +1,46 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// snapshotNacks counts the xDS responses rejected by the proxies.
+	snapshotNacks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "envoy_gateway_xds_snapshot_nacks_total",
+		Help: "Total number of xDS responses rejected by the proxies, by cluster and resource type.",
+	}, []string{"cluster", "type_url"})
+
+	// snapshotRollbacks counts the rollbacks of the proxies of a cluster to
+	// its last ACKed snapshot.
+	snapshotRollbacks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "envoy_gateway_xds_snapshot_rollbacks_total",
+		Help: "Total number of rollbacks of the proxies of a cluster to its last snapshot accepted by the proxies.",
+	}, []string{"cluster"})
+
+	// snapshotRejected reports whether the latest snapshot of a cluster was
+	// rejected by its proxies.
+	snapshotRejected = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "envoy_gateway_xds_snapshot_rejected",
+		Help: "Whether the latest snapshot of a cluster was rejected by its proxies (1) or not (0).",
+	}, []string{"cluster"})
+
+	// nodeSynced reports whether each proxy ACKed the latest snapshot of its
+	// cluster.
+	nodeSynced = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "envoy_gateway_xds_node_synced",
+		Help: "Whether a proxy accepted the latest snapshot of its cluster for all resource types (1) or not (0).",
+	}, []string{"cluster", "node"})
+)
+
+func init() {
+	// Register with the controller-runtime registry so the metrics are served
+	// by the manager metrics endpoint.
+	metrics.Registry.MustRegister(snapshotNacks, snapshotRollbacks, snapshotRejected, nodeSynced)
+}
@@ -0,0 +1,147 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// persistedSnapshotExt is the extension of the files holding the persisted
+// snapshots.
+const persistedSnapshotExt = ".json"
+
+// persistedSnapshot is the on-disk representation of the xDS resources of an
+// IR key. Each resource is stored as a binary encoded Any, so that the
+// extensions embedded in the resources do not need to be resolved.
+type persistedSnapshot struct {
+	Resources map[string][][]byte `json:"resources"`
+}
+
+// PersistSnapshot writes the xDS resources of the IR key to a file in dir,
+// replacing the previously persisted resources of the key.
+func PersistSnapshot(dir, irKey string, resources types.XdsResources) error {
+	snapshot := persistedSnapshot{Resources: make(map[string][][]byte, len(resources))}
+	for typeURL, rs := range resources {
+		encoded := make([][]byte, 0, len(rs))
+		for _, r := range rs {
+			a, err := anypb.New(r)
+			if err != nil {
+				return err
+			}
+			b, err := proto.Marshal(a)
+			if err != nil {
+				return err
+			}
+			encoded = append(encoded, b)
+		}
+		snapshot.Resources[typeURL] = encoded
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file renamed over the previous one, so that a
+	// crash never leaves a partially written snapshot behind.
+	tmp, err := os.CreateTemp(dir, ".snapshot-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), persistedSnapshotPath(dir, irKey))
+}
+
+// DeletePersistedSnapshot removes the persisted xDS resources of the IR key
+// from dir, if any.
+func DeletePersistedSnapshot(dir, irKey string) error {
+	if err := os.Remove(persistedSnapshotPath(dir, irKey)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// LoadPersistedSnapshots returns the xDS resources persisted in dir, keyed by
+// IR key.
+func LoadPersistedSnapshots(dir string) (map[string]types.XdsResources, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make(map[string]types.XdsResources)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, persistedSnapshotExt) {
+			continue
+		}
+		irKey, err := url.PathUnescape(strings.TrimSuffix(name, persistedSnapshotExt))
+		if err != nil {
+			return nil, fmt.Errorf("invalid snapshot file name %s: %w", name, err)
+		}
+		resources, err := loadPersistedSnapshot(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load snapshot %s: %w", name, err)
+		}
+		snapshots[irKey] = resources
+	}
+	return snapshots, nil
+}
+
+// loadPersistedSnapshot decodes the xDS resources persisted in the file.
+func loadPersistedSnapshot(path string) (types.XdsResources, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot persistedSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+
+	resources := make(types.XdsResources, len(snapshot.Resources))
+	for typeURL, encoded := range snapshot.Resources {
+		rs := make([]cachetypes.Resource, 0, len(encoded))
+		for _, b := range encoded {
+			a := new(anypb.Any)
+			if err := proto.Unmarshal(b, a); err != nil {
+				return nil, err
+			}
+			m, err := a.UnmarshalNew()
+			if err != nil {
+				return nil, err
+			}
+			rs = append(rs, m)
+		}
+		resources[typeURL] = rs
+	}
+	return resources, nil
+}
+
+// persistedSnapshotPath returns the path of the file holding the persisted
+// xDS resources of the IR key.
+func persistedSnapshotPath(dir, irKey string) string {
+	return filepath.Join(dir, url.PathEscape(irKey)+persistedSnapshotExt)
+}
@@ -0,0 +1,67 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestPersistedSnapshots(t *testing.T) {
+	dir := t.TempDir()
+
+	resources := types.XdsResources{
+		resource.ClusterType: []cachetypes.Resource{
+			&cluster.Cluster{Name: "first-route", ConnectTimeout: durationpb.New(5 * time.Second)},
+		},
+		resource.ListenerType: []cachetypes.Resource{
+			&listener.Listener{Name: "first-listener"},
+			&listener.Listener{Name: "second-listener"},
+		},
+	}
+	require.NoError(t, PersistSnapshot(dir, "default/gateway-1", resources))
+	require.NoError(t, PersistSnapshot(dir, "default/gateway-2", types.XdsResources{}))
+
+	// Persisting again replaces the previous resources.
+	updated := types.XdsResources{
+		resource.ClusterType: []cachetypes.Resource{&cluster.Cluster{Name: "second-route"}},
+	}
+	require.NoError(t, PersistSnapshot(dir, "default/gateway-2", updated))
+
+	snapshots, err := LoadPersistedSnapshots(dir)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	requireResourcesEqual(t, resources, snapshots["default/gateway-1"])
+	requireResourcesEqual(t, updated, snapshots["default/gateway-2"])
+
+	require.NoError(t, DeletePersistedSnapshot(dir, "default/gateway-1"))
+	require.NoError(t, DeletePersistedSnapshot(dir, "default/gateway-3"))
+	snapshots, err = LoadPersistedSnapshots(dir)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	require.Contains(t, snapshots, "default/gateway-2")
+}
+
+func requireResourcesEqual(t *testing.T, expected, actual types.XdsResources) {
+	t.Helper()
+	require.Len(t, actual, len(expected))
+	for typeURL, rs := range expected {
+		require.Len(t, actual[typeURL], len(rs))
+		for i := range rs {
+			require.True(t, proto.Equal(rs[i], actual[typeURL][i]), "%s resource %d differs", typeURL, i)
+		}
+	}
+}
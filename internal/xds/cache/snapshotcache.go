@@ -25,12 +25,27 @@ import (
 	envoy_cache_v3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
 	envoy_server_v3 "github.com/envoyproxy/go-control-plane/pkg/server/v3"
 	"github.com/go-logr/logr"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 
+	"github.com/envoyproxy/gateway/internal/crypto"
 	"github.com/envoyproxy/gateway/internal/xds/types"
 )
 
 var Hash = envoy_cache_v3.IDHash{}
 
+// clusterHash keys the snapshots of the nodes by their cluster and ID, so that
+// a node authorized for a cluster cannot be served the snapshot of another
+// cluster by spoofing the ID of one of its nodes.
+type clusterHash struct{}
+
+func (clusterHash) ID(node *envoy_config_core_v3.Node) string {
+	if node == nil {
+		return ""
+	}
+	return node.Cluster + "/" + node.Id
+}
+
 // SnapshotCacheWithCallbacks uses the go-control-plane SimpleCache to store snapshots of
 // Envoy resources, sliced by Node ID so that we can do incremental xDS properly.
 // It does this by also implementing callbacks to make sure that the cache is kept
@@ -44,19 +59,53 @@ type SnapshotCacheWithCallbacks interface {
 	envoy_cache_v3.SnapshotCache
 	envoy_server_v3.Callbacks
 	GenerateNewSnapshot(string, types.XdsResources) error
+	// OnRejection sets the handler called when the proxies of a cluster
+	// reject its current snapshot, and when a new snapshot is generated for
+	// a cluster. It must be set before the cache is used.
+	OnRejection(handler RejectionHandler)
+}
+
+// RejectionHandler is called with the rejection of the current snapshot of
+// the cluster by one of its proxies, or nil once a new snapshot is generated.
+type RejectionHandler func(cluster string, rejection *Rejection)
+
+// Rejection describes the rejection of a snapshot by a proxy, as reported by
+// the error detail of its NACK.
+type Rejection struct {
+	// Node is the ID of the proxy.
+	Node string
+	// TypeURL is the type of the rejected resources.
+	TypeURL string
+	// Resource is the name of the rejected resource, or empty if the error
+	// message names none of the resources of the snapshot.
+	Resource string
+	// Message is the error message of the proxy.
+	Message string
 }
 
 type snapshotMap map[string]*envoy_cache_v3.Snapshot
 
 type nodeInfoMap map[int64]*envoy_config_core_v3.Node
 
+type streamIdentityMap map[int64]string
+
 type snapshotcache struct {
 	envoy_cache_v3.SnapshotCache
 	streamIDNodeInfo nodeInfoMap
-	snapshotVersion  int64
-	lastSnapshot     snapshotMap
-	log              *LogrWrapper
-	mu               sync.Mutex
+	// streamIdentity holds the identity of the authenticated peer of each stream.
+	streamIdentity streamIdentityMap
+	authorizeNodes bool
+	// hash keys the snapshots of the nodes in the wrapped cache.
+	hash            envoy_cache_v3.NodeHash
+	snapshotVersion int64
+	lastSnapshot    snapshotMap
+	// acks tracks the ACKs of the last snapshot of each cluster.
+	acks map[string]*snapshotAcks
+	// onRejection is called when the rejection of the current snapshot of a
+	// cluster changes, if set.
+	onRejection RejectionHandler
+	log         *LogrWrapper
+	mu          sync.Mutex
 }
 
 // GenerateNewSnapshot takes a table of resources (the output from the IR->xDS
@@ -78,8 +127,9 @@ func (s *snapshotcache) GenerateNewSnapshot(irKey string, resources types.XdsRes
 	}
 
 	s.lastSnapshot[irKey] = snapshot
+	s.trackSnapshot(irKey, resources)
 
-	for _, node := range s.getNodeIDs(irKey) {
+	for _, node := range s.getSnapshotKeys(irKey) {
 		s.log.Debugf("Generating a snapshot with Node %s", node)
 		err := s.SetSnapshot(context.TODO(), node, snapshot)
 		if err != nil {
@@ -108,15 +158,87 @@ func (s *snapshotcache) newSnapshotVersion() string {
 // NewSnapshotCache gives you a fresh SnapshotCache.
 // It needs a logger that supports the go-control-plane
 // required interface (Debugf, Infof, Warnf, and Errorf).
-func NewSnapshotCache(ads bool, logger logr.Logger) SnapshotCacheWithCallbacks {
+// If authorizeNodes is true, a node is only served the snapshot of its
+// cluster if the client certificate of the stream was issued for that cluster,
+// and the snapshots are keyed by cluster and node ID instead of node ID.
+func NewSnapshotCache(ads bool, authorizeNodes bool, logger logr.Logger) SnapshotCacheWithCallbacks {
+	var hash envoy_cache_v3.NodeHash = &Hash
+	if authorizeNodes {
+		hash = clusterHash{}
+	}
+
 	// Set up the nasty wrapper hack.
 	wrappedLogger := NewLogrWrapper(logger)
 	return &snapshotcache{
-		SnapshotCache:    envoy_cache_v3.NewSnapshotCache(ads, &Hash, wrappedLogger),
+		SnapshotCache:    envoy_cache_v3.NewSnapshotCache(ads, hash, wrappedLogger),
+		hash:             hash,
 		log:              wrappedLogger,
 		lastSnapshot:     make(snapshotMap),
+		acks:             make(map[string]*snapshotAcks),
 		streamIDNodeInfo: make(nodeInfoMap),
+		streamIdentity:   make(streamIdentityMap),
+		authorizeNodes:   authorizeNodes,
+	}
+}
+
+// peerIdentity returns the proxy name of the URI subject alternative name of
+// the verified client certificate of the gRPC stream in ctx, or an empty
+// string if there isn't one.
+func peerIdentity(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+
+	return crypto.ProxyIdentity(tlsInfo.State.VerifiedChains[0][0])
+}
+
+// authorizeNode returns an error if the node connected on the stream is not
+// allowed to receive the configuration of its cluster. The node cluster is
+// the key of the IR of the owning Gateway, and must match the identity of the
+// client certificate so that a proxy cannot request the configuration and
+// secrets of other Gateways.
+func (s *snapshotcache) authorizeNode(streamID int64, node *envoy_config_core_v3.Node) error {
+	if err := s.authorizeIdentity(s.streamIdentity[streamID], node); err != nil {
+		return fmt.Errorf("%w on stream %d", err, streamID)
+	}
+	return nil
+}
+
+// authorizeIdentity returns an error if a peer with the provided identity is
+// not allowed to receive the configuration of the cluster of the node.
+func (s *snapshotcache) authorizeIdentity(identity string, node *envoy_config_core_v3.Node) error {
+	if !s.authorizeNodes {
+		return nil
 	}
+	if identity == "" {
+		return fmt.Errorf("node %s has no verified client certificate identifying a proxy", node.GetId())
+	}
+	if identity != node.GetCluster() {
+		return fmt.Errorf("node %s with identity %s is not authorized for cluster %s",
+			node.GetId(), identity, node.GetCluster())
+	}
+
+	return nil
+}
+
+// checkStreamNode returns an error if a request on the stream sets a node
+// other than the one of the first request. go-control-plane serves the node
+// of the latest request, so a proxy could otherwise be served the
+// configuration of another cluster after authenticating as its own.
+func checkStreamNode(streamID int64, stored, node *envoy_config_core_v3.Node) error {
+	if node == nil || (node.Id == "" && node.Cluster == "") {
+		return nil
+	}
+	if node.Id != stored.Id || node.Cluster != stored.Cluster {
+		return fmt.Errorf("node %s of cluster %s on stream %d of node %s of cluster %s is not allowed to change",
+			node.Id, node.Cluster, streamID, stored.Id, stored.Cluster)
+	}
+	return nil
 }
 
 // getNodeIDs retrieves the node ids from the node info map whose
@@ -124,7 +246,7 @@ func NewSnapshotCache(ads bool, logger logr.Logger) SnapshotCacheWithCallbacks {
 func (s *snapshotcache) getNodeIDs(irKey string) []string {
 	var nodeIDs []string
 	for _, node := range s.streamIDNodeInfo {
-		if node.Cluster == irKey {
+		if node != nil && node.Cluster == irKey {
 			nodeIDs = append(nodeIDs, node.Id)
 		}
 	}
@@ -133,6 +255,19 @@ func (s *snapshotcache) getNodeIDs(irKey string) []string {
 
 }
 
+// getSnapshotKeys retrieves the keys of the snapshots of the nodes from the
+// node info map whose cluster field matches the ir key
+func (s *snapshotcache) getSnapshotKeys(irKey string) []string {
+	var keys []string
+	for _, node := range s.streamIDNodeInfo {
+		if node != nil && node.Cluster == irKey {
+			keys = append(keys, s.hash.ID(node))
+		}
+	}
+
+	return keys
+}
+
 // OnStreamOpen and the other OnStream* functions implement the callbacks for the
 // state-of-the-world stream types.
 func (s *snapshotcache) OnStreamOpen(ctx context.Context, streamID int64, typeURL string) error {
@@ -141,17 +276,20 @@ func (s *snapshotcache) OnStreamOpen(ctx context.Context, streamID int64, typeUR
 	defer s.mu.Unlock()
 
 	s.streamIDNodeInfo[streamID] = nil
+	s.streamIdentity[streamID] = peerIdentity(ctx)
 
 	return nil
 }
 
 func (s *snapshotcache) OnStreamClosed(streamID int64, node *envoy_config_core_v3.Node) {
 
-	// TODO: something with the node?
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	node = s.streamIDNodeInfo[streamID]
 	delete(s.streamIDNodeInfo, streamID)
+	delete(s.streamIdentity, streamID)
+	s.forgetNode(node)
 
 }
 
@@ -169,8 +307,13 @@ func (s *snapshotcache) OnStreamRequest(streamID int64, req *envoy_service_disco
 		if req.Node.Id == "" {
 			return fmt.Errorf("couldn't get the node ID from the first discovery request on stream %d", streamID)
 		}
+		if err := s.authorizeNode(streamID, req.Node); err != nil {
+			return err
+		}
 		s.log.Debugf("First discovery request on stream %d, got nodeID %s", streamID, req.Node.Id)
 		s.streamIDNodeInfo[streamID] = req.Node
+	} else if err := checkStreamNode(streamID, s.streamIDNodeInfo[streamID], req.Node); err != nil {
+		return err
 	}
 	nodeID := s.streamIDNodeInfo[streamID].Id
 	cluster := s.streamIDNodeInfo[streamID].Cluster
@@ -186,9 +329,9 @@ func (s *snapshotcache) OnStreamRequest(streamID int64, req *envoy_service_disco
 		return nil
 	}
 
-	_, err := s.GetSnapshot(nodeID)
+	_, err := s.GetSnapshot(s.hash.ID(s.streamIDNodeInfo[streamID]))
 	if err != nil {
-		err = s.SetSnapshot(context.TODO(), nodeID, s.lastSnapshot[cluster])
+		err = s.SetSnapshot(context.TODO(), s.hash.ID(s.streamIDNodeInfo[streamID]), s.lastSnapshot[cluster])
 		if err != nil {
 			return err
 		}
@@ -204,10 +347,10 @@ func (s *snapshotcache) OnStreamRequest(streamID int64, req *envoy_service_disco
 
 	if status := req.ErrorDetail; status != nil {
 		// if Envoy rejected the last update log the details here.
-		// TODO(youngnick): Handle NACK properly
 		errorCode = status.Code
 		errorMessage = status.Message
 	}
+	s.handleAck(s.streamIDNodeInfo[streamID], req.GetTypeUrl(), req.VersionInfo, req.ResponseNonce, errorMessage, req.ErrorDetail != nil)
 
 	s.log.Debugf("handling v3 xDS resource request, version_info %s, response_nonce %s, nodeID %s, node_version %s, resource_names %v, type_url %s, errorCode %d, errorMessage %s",
 		req.VersionInfo, req.ResponseNonce,
@@ -238,17 +381,20 @@ func (s *snapshotcache) OnDeltaStreamOpen(ctx context.Context, streamID int64, t
 
 	// Ensure that we're adding the streamID to the Node ID list.
 	s.streamIDNodeInfo[streamID] = nil
+	s.streamIdentity[streamID] = peerIdentity(ctx)
 
 	return nil
 }
 
 func (s *snapshotcache) OnDeltaStreamClosed(streamID int64, node *envoy_config_core_v3.Node) {
 
-	// TODO: something with the node?
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	node = s.streamIDNodeInfo[streamID]
 	delete(s.streamIDNodeInfo, streamID)
+	delete(s.streamIdentity, streamID)
+	s.forgetNode(node)
 
 }
 
@@ -270,8 +416,13 @@ func (s *snapshotcache) OnStreamDeltaRequest(streamID int64, req *envoy_service_
 		if req.Node.Id == "" {
 			return fmt.Errorf("couldn't get the node ID from the first incremental discovery request on stream %d", streamID)
 		}
+		if err := s.authorizeNode(streamID, req.Node); err != nil {
+			return err
+		}
 		s.log.Debugf("First incremental discovery request on stream %d, got nodeID %s", streamID, req.Node.Id)
 		s.streamIDNodeInfo[streamID] = req.Node
+	} else if err := checkStreamNode(streamID, node, req.Node); err != nil {
+		return err
 	}
 	nodeID := s.streamIDNodeInfo[streamID].Id
 	cluster := s.streamIDNodeInfo[streamID].Cluster
@@ -283,9 +434,9 @@ func (s *snapshotcache) OnStreamDeltaRequest(streamID int64, req *envoy_service_
 		return nil
 	}
 
-	_, err := s.GetSnapshot(nodeID)
+	_, err := s.GetSnapshot(s.hash.ID(s.streamIDNodeInfo[streamID]))
 	if err != nil {
-		err = s.SetSnapshot(context.TODO(), nodeID, s.lastSnapshot[cluster])
+		err = s.SetSnapshot(context.TODO(), s.hash.ID(s.streamIDNodeInfo[streamID]), s.lastSnapshot[cluster])
 		if err != nil {
 			return err
 		}
@@ -301,10 +452,10 @@ func (s *snapshotcache) OnStreamDeltaRequest(streamID int64, req *envoy_service_
 		req.ResponseNonce, nodeID, nodeVersion)
 	if status := req.ErrorDetail; status != nil {
 		// if Envoy rejected the last update log the details here.
-		// TODO(youngnick): Handle NACK properly
 		errorCode = status.Code
 		errorMessage = status.Message
 	}
+	s.handleAck(s.streamIDNodeInfo[streamID], req.GetTypeUrl(), "", req.ResponseNonce, errorMessage, req.ErrorDetail != nil)
 	s.log.Debugf("handling v3 xDS resource request, response_nonce %s, nodeID %s, node_version %s, resource_names_subscribe %v, resource_names_unsubscribe %v, type_url %s, errorCode %d, errorMessage %s",
 		req.ResponseNonce,
 		nodeID, nodeVersion,
@@ -325,8 +476,11 @@ func (s *snapshotcache) OnStreamDeltaResponse(streamID int64, req *envoy_service
 	}
 }
 
+// OnFetchRequest authorizes the REST-like fetch requests, which are served the
+// snapshot of the cluster of their node, against the client certificate of
+// the peer.
 func (s *snapshotcache) OnFetchRequest(ctx context.Context, req *envoy_service_discovery_v3.DiscoveryRequest) error {
-	return nil
+	return s.authorizeIdentity(peerIdentity(ctx), req.Node)
 }
 
 func (s *snapshotcache) OnFetchResponse(req *envoy_service_discovery_v3.DiscoveryRequest, resp *envoy_service_discovery_v3.DiscoveryResponse) {
@@ -0,0 +1,400 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"testing"
+
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	envoy_config_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	envoy_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	envoy_cache_v3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	envoy_stream_v3 "github.com/envoyproxy/go-control-plane/pkg/server/stream/v3"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/envoyproxy/gateway/internal/crypto"
+	"github.com/envoyproxy/gateway/internal/log"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func peerContext(cert *x509.Certificate) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{cert}},
+			},
+		},
+	})
+}
+
+// proxyCert returns a client certificate identifying the proxy fleet
+// proxyName.
+func proxyCert(proxyName string) *x509.Certificate {
+	return &x509.Certificate{URIs: []*url.URL{crypto.ProxyIdentityURI(proxyName)}}
+}
+
+func TestNodeAuthorization(t *testing.T) {
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name           string
+		ctx            context.Context
+		authorizeNodes bool
+		cluster        string
+		expectErr      bool
+	}{
+		{
+			name:           "identity matches cluster",
+			ctx:            peerContext(proxyCert("default-eg")),
+			authorizeNodes: true,
+			cluster:        "default-eg",
+		},
+		{
+			name:           "identity does not match cluster",
+			ctx:            peerContext(proxyCert("default-eg")),
+			authorizeNodes: true,
+			cluster:        "other-eg",
+			expectErr:      true,
+		},
+		{
+			name:           "common name only",
+			ctx:            peerContext(&x509.Certificate{Subject: pkix.Name{CommonName: "default-eg"}}),
+			authorizeNodes: true,
+			cluster:        "default-eg",
+			expectErr:      true,
+		},
+		{
+			name:           "no client certificate",
+			ctx:            context.Background(),
+			authorizeNodes: true,
+			cluster:        "default-eg",
+			expectErr:      true,
+		},
+		{
+			name:    "authorization disabled",
+			ctx:     context.Background(),
+			cluster: "default-eg",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewSnapshotCache(false, tc.authorizeNodes, logger)
+			require.NoError(t, c.OnStreamOpen(tc.ctx, 1, ""))
+			err := c.OnStreamRequest(1, &envoy_service_discovery_v3.DiscoveryRequest{
+				Node: &envoy_config_core_v3.Node{Id: "envoy-pod", Cluster: tc.cluster},
+			})
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, c.OnDeltaStreamOpen(tc.ctx, 2, ""))
+			err = c.OnStreamDeltaRequest(2, &envoy_service_discovery_v3.DeltaDiscoveryRequest{
+				Node: &envoy_config_core_v3.Node{Id: "envoy-pod", Cluster: tc.cluster},
+			})
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNodeSwitchOnStream(t *testing.T) {
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name      string
+		node      *envoy_config_core_v3.Node
+		expectErr bool
+	}{
+		{
+			name: "same node",
+			node: &envoy_config_core_v3.Node{Id: "envoy-pod", Cluster: "default-eg"},
+		},
+		{
+			name: "node omitted",
+		},
+		{
+			name:      "other cluster",
+			node:      &envoy_config_core_v3.Node{Id: "envoy-pod", Cluster: "other-eg"},
+			expectErr: true,
+		},
+		{
+			name:      "other node ID",
+			node:      &envoy_config_core_v3.Node{Id: "other-pod", Cluster: "default-eg"},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			// The proxy authenticates as default-eg with its first request,
+			// then sets another node on the same stream.
+			c := NewSnapshotCache(false, true, logger)
+			ctx := peerContext(proxyCert("default-eg"))
+			first := &envoy_config_core_v3.Node{Id: "envoy-pod", Cluster: "default-eg"}
+
+			require.NoError(t, c.OnStreamOpen(ctx, 1, ""))
+			require.NoError(t, c.OnStreamRequest(1, &envoy_service_discovery_v3.DiscoveryRequest{Node: first}))
+			err := c.OnStreamRequest(1, &envoy_service_discovery_v3.DiscoveryRequest{Node: tc.node})
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, c.OnDeltaStreamOpen(ctx, 2, ""))
+			require.NoError(t, c.OnStreamDeltaRequest(2, &envoy_service_discovery_v3.DeltaDiscoveryRequest{Node: first}))
+			err = c.OnStreamDeltaRequest(2, &envoy_service_discovery_v3.DeltaDiscoveryRequest{Node: tc.node})
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFetchAuthorization(t *testing.T) {
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name           string
+		ctx            context.Context
+		authorizeNodes bool
+		node           *envoy_config_core_v3.Node
+		expectErr      bool
+	}{
+		{
+			name:           "identity matches cluster",
+			ctx:            peerContext(proxyCert("default-eg")),
+			authorizeNodes: true,
+			node:           &envoy_config_core_v3.Node{Id: "envoy-pod", Cluster: "default-eg"},
+		},
+		{
+			name:           "identity does not match cluster",
+			ctx:            peerContext(proxyCert("default-eg")),
+			authorizeNodes: true,
+			node:           &envoy_config_core_v3.Node{Id: "envoy-pod", Cluster: "other-eg"},
+			expectErr:      true,
+		},
+		{
+			name:           "no client certificate",
+			ctx:            context.Background(),
+			authorizeNodes: true,
+			node:           &envoy_config_core_v3.Node{Id: "envoy-pod", Cluster: "default-eg"},
+			expectErr:      true,
+		},
+		{
+			name:           "no node",
+			ctx:            peerContext(proxyCert("default-eg")),
+			authorizeNodes: true,
+			expectErr:      true,
+		},
+		{
+			name: "authorization disabled",
+			ctx:  context.Background(),
+			node: &envoy_config_core_v3.Node{Id: "envoy-pod", Cluster: "default-eg"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewSnapshotCache(false, tc.authorizeNodes, logger)
+			err := c.OnFetchRequest(tc.ctx, &envoy_service_discovery_v3.DiscoveryRequest{Node: tc.node})
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNodeIDSpoofing(t *testing.T) {
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+
+	c := NewSnapshotCache(false, true, logger)
+	listeners := func(name string) types.XdsResources {
+		return types.XdsResources{
+			resource.ListenerType: {&envoy_config_listener_v3.Listener{Name: name}},
+		}
+	}
+	require.NoError(t, c.GenerateNewSnapshot("default-eg", listeners("default-listener")))
+	require.NoError(t, c.GenerateNewSnapshot("other-eg", listeners("other-listener")))
+
+	// The node of the default-eg cluster reuses the ID of the node of the
+	// other-eg cluster.
+	otherNode := &envoy_config_core_v3.Node{Id: "envoy-pod", Cluster: "other-eg"}
+	spoofingNode := &envoy_config_core_v3.Node{Id: "envoy-pod", Cluster: "default-eg"}
+	require.NoError(t, c.OnStreamOpen(peerContext(proxyCert("other-eg")), 1, resource.ListenerType))
+	require.NoError(t, c.OnStreamRequest(1, &envoy_service_discovery_v3.DiscoveryRequest{
+		Node:    otherNode,
+		TypeUrl: resource.ListenerType,
+	}))
+	require.NoError(t, c.OnStreamOpen(peerContext(proxyCert("default-eg")), 2, resource.ListenerType))
+	require.NoError(t, c.OnStreamRequest(2, &envoy_service_discovery_v3.DiscoveryRequest{
+		Node:    spoofingNode,
+		TypeUrl: resource.ListenerType,
+	}))
+
+	listener := func(node *envoy_config_core_v3.Node) string {
+		out := make(chan envoy_cache_v3.Response, 1)
+		cancel := c.CreateWatch(&envoy_service_discovery_v3.DiscoveryRequest{
+			Node:    node,
+			TypeUrl: resource.ListenerType,
+		}, envoy_stream_v3.NewStreamState(false, nil), out)
+		if cancel != nil {
+			defer cancel()
+		}
+		resp := (<-out).(*envoy_cache_v3.RawResponse)
+		require.Len(t, resp.Resources, 1)
+		return envoy_cache_v3.GetResourceName(resp.Resources[0].Resource)
+	}
+
+	// Each node is only served the snapshot of its own cluster.
+	require.Equal(t, "default-listener", listener(spoofingNode))
+	require.Equal(t, "other-listener", listener(otherNode))
+
+	require.NoError(t, c.GenerateNewSnapshot("default-eg", listeners("new-default-listener")))
+	require.Equal(t, "new-default-listener", listener(spoofingNode))
+	require.Equal(t, "other-listener", listener(otherNode))
+}
+
+func TestSnapshotRollbackOnNack(t *testing.T) {
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+
+	c := NewSnapshotCache(false, false, logger)
+	rejections := make(map[string]*Rejection)
+	c.OnRejection(func(cluster string, rejection *Rejection) { rejections[cluster] = rejection })
+	listeners := func(name string) types.XdsResources {
+		return types.XdsResources{
+			resource.ListenerType: {
+				&envoy_config_listener_v3.Listener{Name: name},
+				&envoy_config_listener_v3.Listener{Name: name + "0"},
+			},
+		}
+	}
+	listenerVersion := func(node string) string {
+		snapshot, err := c.GetSnapshot(node)
+		require.NoError(t, err)
+		return snapshot.GetVersion(resource.ListenerType)
+	}
+
+	require.NoError(t, c.GenerateNewSnapshot("default-eg", listeners("listener-1")))
+	for i, node := range []string{"envoy-pod-1", "envoy-pod-2"} {
+		streamID := int64(i + 1)
+		require.NoError(t, c.OnStreamOpen(context.Background(), streamID, resource.ListenerType))
+		require.NoError(t, c.OnStreamRequest(streamID, &envoy_service_discovery_v3.DiscoveryRequest{
+			Node:    &envoy_config_core_v3.Node{Id: node, Cluster: "default-eg"},
+			TypeUrl: resource.ListenerType,
+		}))
+		require.Equal(t, "1", listenerVersion(node))
+
+		// The proxies ACK the first snapshot.
+		require.NoError(t, c.OnStreamRequest(streamID, &envoy_service_discovery_v3.DiscoveryRequest{
+			TypeUrl:       resource.ListenerType,
+			VersionInfo:   "1",
+			ResponseNonce: "1",
+		}))
+	}
+
+	// A proxy NACKs the second snapshot, it alone is rolled back to the
+	// first.
+	require.NoError(t, c.GenerateNewSnapshot("default-eg", listeners("listener-2")))
+	require.NoError(t, c.OnStreamRequest(1, &envoy_service_discovery_v3.DiscoveryRequest{
+		TypeUrl:       resource.ListenerType,
+		VersionInfo:   "1",
+		ResponseNonce: "2",
+		ErrorDetail:   &status.Status{Message: "Error adding/updating listener(s) listener-20: invalid filter"},
+	}))
+	require.Equal(t, "1", listenerVersion("envoy-pod-1"))
+	require.Equal(t, "2", listenerVersion("envoy-pod-2"))
+	require.Equal(t, &Rejection{
+		Node:     "envoy-pod-1",
+		TypeURL:  resource.ListenerType,
+		Resource: "listener-20",
+		Message:  "Error adding/updating listener(s) listener-20: invalid filter",
+	}, rejections["default-eg"])
+
+	// The other proxy is rolled back too once it NACKs the second snapshot,
+	// the first rejection is still reported.
+	require.NoError(t, c.OnStreamRequest(2, &envoy_service_discovery_v3.DiscoveryRequest{
+		TypeUrl:       resource.ListenerType,
+		VersionInfo:   "1",
+		ResponseNonce: "2",
+		ErrorDetail:   &status.Status{Message: "unknown error"},
+	}))
+	require.Equal(t, "1", listenerVersion("envoy-pod-2"))
+	require.Equal(t, "envoy-pod-1", rejections["default-eg"].Node)
+
+	// A new snapshot is served as soon as it is generated.
+	require.NoError(t, c.GenerateNewSnapshot("default-eg", listeners("listener-3")))
+	require.Equal(t, "3", listenerVersion("envoy-pod-1"))
+	require.Equal(t, "3", listenerVersion("envoy-pod-2"))
+	require.Nil(t, rejections["default-eg"])
+}
+
+func TestNodeSyncStatus(t *testing.T) {
+	logger, err := log.NewLogger()
+	require.NoError(t, err)
+
+	c := NewSnapshotCache(false, false, logger).(*snapshotcache)
+	listeners := types.XdsResources{
+		resource.ListenerType: {&envoy_config_listener_v3.Listener{Name: "listener"}},
+	}
+	synced := func(node string) float64 {
+		return testutil.ToFloat64(nodeSynced.WithLabelValues("default-sync", node))
+	}
+
+	require.NoError(t, c.GenerateNewSnapshot("default-sync", listeners))
+	for i, node := range []string{"envoy-pod-1", "envoy-pod-2"} {
+		streamID := int64(i + 1)
+		require.NoError(t, c.OnStreamOpen(context.Background(), streamID, resource.ListenerType))
+		require.NoError(t, c.OnStreamRequest(streamID, &envoy_service_discovery_v3.DiscoveryRequest{
+			Node:    &envoy_config_core_v3.Node{Id: node, Cluster: "default-sync"},
+			TypeUrl: resource.ListenerType,
+		}))
+	}
+
+	// Only the proxies which ACKed the latest snapshot are in sync.
+	require.NoError(t, c.OnStreamRequest(1, &envoy_service_discovery_v3.DiscoveryRequest{
+		TypeUrl:       resource.ListenerType,
+		VersionInfo:   "1",
+		ResponseNonce: "1",
+	}))
+	require.Equal(t, float64(1), synced("envoy-pod-1"))
+	require.Equal(t, float64(0), synced("envoy-pod-2"))
+
+	require.NoError(t, c.GenerateNewSnapshot("default-sync", listeners))
+	require.Equal(t, float64(0), synced("envoy-pod-1"))
+
+	// The sync status of a proxy is forgotten once its streams are closed.
+	c.OnStreamClosed(1, nil)
+	require.NotContains(t, c.acks["default-sync"].nodeVersions, "envoy-pod-1")
+}
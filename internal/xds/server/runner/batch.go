@@ -0,0 +1,68 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"sync"
+	"time"
+
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// snapshotBatcher coalesces the snapshot updates computed within a window,
+// flushing the latest resources of each key once the window ends.
+type snapshotBatcher struct {
+	window time.Duration
+	flush  func(key string, resources xdstypes.XdsResources)
+	// prioritize returns whether the key is flushed before the others, if
+	// set.
+	prioritize func(key string) bool
+
+	mu sync.Mutex
+	// pending holds the latest resources of each key updated within the
+	// current window, nil for deleted keys.
+	pending map[string]xdstypes.XdsResources
+}
+
+func newSnapshotBatcher(window time.Duration, flush func(string, xdstypes.XdsResources)) *snapshotBatcher {
+	return &snapshotBatcher{
+		window:  window,
+		flush:   flush,
+		pending: make(map[string]xdstypes.XdsResources),
+	}
+}
+
+// add records the resources of the key, replacing the ones recorded in the
+// current window. The first update of a window starts the window.
+func (b *snapshotBatcher) add(key string, resources xdstypes.XdsResources) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.pending) == 0 {
+		time.AfterFunc(b.window, b.flushPending)
+	}
+	b.pending[key] = resources
+}
+
+// flushPending flushes the updates recorded in the current window, the ones
+// of the prioritized keys first.
+func (b *snapshotBatcher) flushPending() {
+	b.mu.Lock()
+	pending := b.pending
+	b.pending = make(map[string]xdstypes.XdsResources)
+	b.mu.Unlock()
+
+	var deferred []string
+	for key, resources := range pending {
+		if b.prioritize != nil && !b.prioritize(key) {
+			deferred = append(deferred, key)
+			continue
+		}
+		b.flush(key, resources)
+	}
+	for _, key := range deferred {
+		b.flush(key, pending[key])
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestSnapshotBatcher(t *testing.T) {
+	var mu sync.Mutex
+	flushed := make(map[string]xdstypes.XdsResources)
+	flushes := 0
+	b := newSnapshotBatcher(50*time.Millisecond, func(key string, resources xdstypes.XdsResources) {
+		mu.Lock()
+		defer mu.Unlock()
+		flushed[key] = resources
+		flushes++
+	})
+
+	first := xdstypes.XdsResources{resource.ClusterType: []cachetypes.Resource{&cluster.Cluster{Name: "first"}}}
+	second := xdstypes.XdsResources{resource.ClusterType: []cachetypes.Resource{&cluster.Cluster{Name: "second"}}}
+	b.add("default/gateway-1", first)
+	b.add("default/gateway-1", second)
+	b.add("default/gateway-2", first)
+	b.add("default/gateway-2", nil)
+
+	// Nothing is flushed before the window ends.
+	mu.Lock()
+	require.Zero(t, flushes)
+	mu.Unlock()
+
+	// Only the latest update of each key is flushed.
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return flushes == 2
+	}, time.Second, 10*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, second, flushed["default/gateway-1"])
+	require.Contains(t, flushed, "default/gateway-2")
+	require.Nil(t, flushed["default/gateway-2"])
+}
@@ -0,0 +1,132 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// streamHandoff tracks the open xDS streams, so that they are closed
+// gradually when the xDS server shuts down. The Envoy proxies reconnect to the
+// other replicas through the Envoy Gateway Service once their stream is
+// closed, while keeping their current configuration.
+type streamHandoff struct {
+	mu sync.Mutex
+	// streams holds a channel per open stream, closed to hand it off.
+	streams map[chan struct{}]struct{}
+	// started is set once the hand-off started, after which new streams are
+	// rejected.
+	started bool
+}
+
+func newStreamHandoff() *streamHandoff {
+	return &streamHandoff{streams: make(map[chan struct{}]struct{})}
+}
+
+// streamInterceptor registers the xDS streams with the hand-off, and ends them
+// with an Unavailable status once they are handed off. The context of a handed
+// off stream is canceled, and the stream only ends once its handler returned,
+// so that the handler doesn't use the stream after it ended. The other
+// streams, e.g. of the metrics service, are not handed off.
+func (h *streamHandoff) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !isXdsMethod(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	handoff, ok := h.register()
+	if !ok {
+		return status.Error(codes.Unavailable, "xds server is shutting down")
+	}
+	defer h.unregister(handoff)
+
+	ctx, cancel := context.WithCancel(ss.Context())
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- handler(srv, &handoffStream{ServerStream: ss, ctx: ctx})
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-handoff:
+		cancel()
+		<-errCh
+		return status.Error(codes.Unavailable, "xds server is shutting down")
+	}
+}
+
+// isXdsMethod returns true if the gRPC method belongs to one of the xDS
+// services, e.g. "/envoy.service.discovery.v3.AggregatedDiscoveryService/StreamAggregatedResources".
+func isXdsMethod(fullMethod string) bool {
+	service, _, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return ok && strings.HasPrefix(service, "envoy.service.") && strings.HasSuffix(service, "DiscoveryService")
+}
+
+// handoffStream is a stream whose context is canceled when it is handed off.
+type handoffStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context implements grpc.ServerStream.
+func (s *handoffStream) Context() context.Context {
+	return s.ctx
+}
+
+// register returns the channel closed to hand off a new stream, or false if
+// the hand-off already started.
+func (h *streamHandoff) register() (chan struct{}, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.started {
+		return nil, false
+	}
+	handoff := make(chan struct{})
+	h.streams[handoff] = struct{}{}
+	return handoff, true
+}
+
+func (h *streamHandoff) unregister(handoff chan struct{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.streams, handoff)
+}
+
+// handOff closes the open streams one by one at regular intervals across the
+// period, and returns once they are all closed. The streams opened afterwards
+// are rejected.
+func (h *streamHandoff) handOff(period time.Duration) {
+	h.mu.Lock()
+	if h.started {
+		h.mu.Unlock()
+		return
+	}
+	h.started = true
+	streams := make([]chan struct{}, 0, len(h.streams))
+	for handoff := range h.streams {
+		streams = append(streams, handoff)
+	}
+	h.mu.Unlock()
+
+	if len(streams) == 0 {
+		return
+	}
+	interval := period / time.Duration(len(streams))
+	for i, handoff := range streams {
+		if i > 0 && interval > 0 {
+			time.Sleep(interval)
+		}
+		close(handoff)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const adsMethod = "/envoy.service.discovery.v3.AggregatedDiscoveryService/StreamAggregatedResources"
+
+// fakeServerStream is a grpc.ServerStream only providing its context.
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func newFakeServerStream() grpc.ServerStream {
+	return &fakeServerStream{ctx: context.Background()}
+}
+
+func TestStreamHandoff(t *testing.T) {
+	h := newStreamHandoff()
+
+	// The handlers of the streams block until their context is canceled, as
+	// the long-lived xDS streams.
+	var returned int32
+	handler := func(_ interface{}, ss grpc.ServerStream) error {
+		<-ss.Context().Done()
+		atomic.AddInt32(&returned, 1)
+		return ss.Context().Err()
+	}
+
+	const streams = 3
+	ended := make(chan error, streams)
+	for i := 0; i < streams; i++ {
+		go func() {
+			ended <- h.streamInterceptor(nil, newFakeServerStream(), &grpc.StreamServerInfo{FullMethod: adsMethod}, handler)
+		}()
+	}
+	require.Eventually(t, func() bool {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		return len(h.streams) == streams
+	}, time.Second, 10*time.Millisecond)
+
+	// The streams are closed one by one across the period.
+	period := 90 * time.Millisecond
+	start := time.Now()
+	h.handOff(period)
+	require.GreaterOrEqual(t, time.Since(start), period*(streams-1)/streams)
+
+	for i := 0; i < streams; i++ {
+		err := <-ended
+		require.Equal(t, codes.Unavailable, status.Code(err))
+	}
+	// The streams only end once their handler returned.
+	require.EqualValues(t, streams, atomic.LoadInt32(&returned))
+
+	// The streams opened after the hand-off are rejected.
+	err := h.streamInterceptor(nil, newFakeServerStream(), &grpc.StreamServerInfo{FullMethod: adsMethod}, handler)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestStreamHandoffEndedStream(t *testing.T) {
+	h := newStreamHandoff()
+
+	// A stream ended by its handler is not handed off.
+	err := h.streamInterceptor(nil, newFakeServerStream(), &grpc.StreamServerInfo{FullMethod: adsMethod},
+		func(interface{}, grpc.ServerStream) error {
+			return status.Error(codes.Canceled, "canceled")
+		})
+	require.Equal(t, codes.Canceled, status.Code(err))
+	require.Empty(t, h.streams)
+
+	h.handOff(time.Second)
+}
+
+func TestStreamHandoffMetricsStream(t *testing.T) {
+	h := newStreamHandoff()
+	h.handOff(time.Second)
+
+	// The metrics streams are neither handed off nor rejected.
+	ss := newFakeServerStream()
+	err := h.streamInterceptor(nil, ss, &grpc.StreamServerInfo{FullMethod: "/envoy.service.metrics.v3.MetricsService/StreamMetrics"},
+		func(_ interface{}, handled grpc.ServerStream) error {
+			require.Equal(t, ss, handled)
+			return nil
+		})
+	require.NoError(t, err)
+}
+
+func TestIsXdsMethod(t *testing.T) {
+	testCases := []struct {
+		method string
+		want   bool
+	}{
+		{method: adsMethod, want: true},
+		{method: "/envoy.service.secret.v3.SecretDiscoveryService/DeltaSecrets", want: true},
+		{method: "/envoy.service.metrics.v3.MetricsService/StreamMetrics", want: false},
+		{method: "/grpc.health.v1.Health/Watch", want: false},
+		{method: "", want: false},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.want, isXdsMethod(tc.method), tc.method)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/xds/cache"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// snapshotWarmup holds back the snapshots computed after a restart, while the
+// snapshots restored from disk are served. This keeps the Envoy proxies
+// which reconnect from receiving partial configuration before all the
+// resources have been reconciled and translated.
+type snapshotWarmup struct {
+	mu sync.Mutex
+	// done is set once the warmup period has elapsed.
+	done bool
+	// restored holds the keys of the snapshots restored from disk.
+	restored map[string]struct{}
+	// pending holds the latest resources computed for each key during the
+	// warmup period, nil for deleted keys.
+	pending map[string]xdstypes.XdsResources
+}
+
+// hold records the resources of the key if the warmup period is in progress,
+// and reports whether they were recorded.
+func (w *snapshotWarmup) hold(key string, resources xdstypes.XdsResources) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.done {
+		return false
+	}
+	w.pending[key] = resources
+	return true
+}
+
+// restoreSnapshots seeds the snapshot cache with the snapshots persisted in
+// the configured directory, and starts the warmup period during which they
+// are served.
+func (r *Runner) restoreSnapshots(ctx context.Context, persistence *v1alpha1.XdsSnapshotPersistence) {
+	r.snapshotDir = persistence.Path
+	if err := os.MkdirAll(r.snapshotDir, 0o750); err != nil {
+		r.Logger.Error(err, "failed to create snapshot directory", "path", r.snapshotDir)
+		return
+	}
+
+	snapshots, err := cache.LoadPersistedSnapshots(r.snapshotDir)
+	if err != nil {
+		r.Logger.Error(err, "failed to load persisted snapshots", "path", r.snapshotDir)
+		return
+	}
+	if len(snapshots) == 0 {
+		return
+	}
+
+	w := &snapshotWarmup{
+		restored: make(map[string]struct{}, len(snapshots)),
+		pending:  make(map[string]xdstypes.XdsResources),
+	}
+	for key, resources := range snapshots {
+		if err := r.cache.GenerateNewSnapshot(key, resources); err != nil {
+			r.Logger.Error(err, "failed to restore snapshot", "key", key)
+			continue
+		}
+		w.restored[key] = struct{}{}
+	}
+	r.Logger.Info("restored persisted snapshots", "count", len(w.restored))
+	r.warmup = w
+
+	period := v1alpha1.DefaultXdsSnapshotWarmupPeriod
+	if persistence.WarmupPeriod != nil {
+		period = persistence.WarmupPeriod.Duration
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-time.After(period):
+			r.endWarmup()
+		}
+	}()
+}
+
+// endWarmup applies the snapshots computed during the warmup period, and
+// removes the restored snapshots of the keys which no longer exist.
+func (r *Runner) endWarmup() {
+	w := r.warmup
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for key := range w.restored {
+		if _, ok := w.pending[key]; !ok {
+			w.pending[key] = nil
+		}
+	}
+	for key, resources := range w.pending {
+		r.updateSnapshot(key, resources)
+	}
+	w.done = true
+	w.pending = nil
+	r.Logger.Info("snapshot warmup completed")
+}
+
+// updateSnapshot generates a new snapshot for the key from the resources,
+// clearing it if resources is nil, and persists the resources if snapshot
+// persistence is enabled.
+func (r *Runner) updateSnapshot(key string, resources xdstypes.XdsResources) {
+	if err := r.cache.GenerateNewSnapshot(key, resources); err != nil {
+		r.Logger.Error(err, "failed to generate a snapshot")
+		return
+	}
+	if r.AuditTrail != nil {
+		r.AuditTrail.Record(key, resources, time.Now())
+	}
+	if r.snapshotDir == "" {
+		return
+	}
+
+	var err error
+	if resources == nil {
+		err = cache.DeletePersistedSnapshot(r.snapshotDir, key)
+	} else {
+		err = cache.PersistSnapshot(r.snapshotDir, key, resources)
+	}
+	if err != nil {
+		r.Logger.Error(err, "failed to persist snapshot", "key", key)
+	}
+}
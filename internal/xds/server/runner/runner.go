@@ -18,14 +18,17 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/xds/audit"
 	"github.com/envoyproxy/gateway/internal/xds/cache"
 	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
 	controlplane_service_cluster_v3 "github.com/envoyproxy/go-control-plane/envoy/service/cluster/v3"
 	controlplane_service_discovery_v3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	controlplane_service_endpoint_v3 "github.com/envoyproxy/go-control-plane/envoy/service/endpoint/v3"
 	controlplane_service_listener_v3 "github.com/envoyproxy/go-control-plane/envoy/service/listener/v3"
+	controlplane_service_metrics_v3 "github.com/envoyproxy/go-control-plane/envoy/service/metrics/v3"
 	controlplane_service_route_v3 "github.com/envoyproxy/go-control-plane/envoy/service/route/v3"
 	controlplane_service_runtime_v3 "github.com/envoyproxy/go-control-plane/envoy/service/runtime/v3"
 	controlplane_service_secret_v3 "github.com/envoyproxy/go-control-plane/envoy/service/secret/v3"
@@ -33,19 +36,10 @@ import (
 )
 
 const (
-	// XdsServerAddress is the listening address of the xds-server.
-	XdsServerAddress = "0.0.0.0"
-	// XdsServerPort is the listening port of the xds-server.
-	XdsServerPort = 18000
-	// xdsTLSCertFilename is the fully qualified path of the file containing the
-	// xDS server TLS certificate.
-	xdsTLSCertFilename = "/certs/tls.crt"
-	// xdsTLSKeyFilename is the fully qualified path of the file containing the
-	// xDS server TLS key.
-	xdsTLSKeyFilename = "/certs/tls.key"
-	// xdsTLSCaFilename is the fully qualified path of the file containing the
-	// xDS server trusted CA certificate.
-	xdsTLSCaFilename = "/certs/ca.crt"
+	// XdsServerAddress is the default listening address of the xds-server.
+	XdsServerAddress = v1alpha1.DefaultXdsServerAddress
+	// XdsServerPort is the default listening port of the xds-server.
+	XdsServerPort = v1alpha1.DefaultXdsServerPort
 )
 
 type Config struct {
@@ -53,23 +47,59 @@ type Config struct {
 	Xds   *message.Xds
 	grpc  *grpc.Server
 	cache cache.SnapshotCacheWithCallbacks
+	// snapshotDir is the directory the snapshots are persisted to, if any.
+	snapshotDir string
+	warmup      *snapshotWarmup
+	batcher     *snapshotBatcher
+	// MetricsService receives the stats pushed by the proxies, if the
+	// aggregation of their traffic statistics is enabled.
+	MetricsService controlplane_service_metrics_v3.MetricsServiceServer
+	// AuditTrail records the generations of the snapshots, if set.
+	AuditTrail *audit.Trail
+	// RejectedSnapshots receives the rejections of the current snapshots by
+	// their proxies, keyed by cluster, if set.
+	RejectedSnapshots *message.RejectedSnapshots
 }
 
 type Runner struct {
 	Config
+	handoff *streamHandoff
+	// stopped is closed once the xds-server stopped serving.
+	stopped chan struct{}
 }
 
 func New(cfg *Config) *Runner {
-	return &Runner{Config: *cfg}
+	return &Runner{
+		Config:  *cfg,
+		handoff: newStreamHandoff(),
+		stopped: make(chan struct{}),
+	}
 }
 
 func (r *Runner) Name() string {
 	return "xds-server"
 }
 
+// Stopped returns a channel closed once the xds-server stopped serving, after
+// handing off the xDS streams of the connected Envoy proxies.
+func (r *Runner) Stopped() <-chan struct{} {
+	return r.stopped
+}
+
 // Start starts the xds-server runner
 func (r *Runner) Start(ctx context.Context) error {
-	r.Logger = r.Logger.WithValues("runner", r.Name())
+	r.Logger = r.ComponentLogger(v1alpha1.LogComponentXds).WithValues("runner", r.Name())
+	r.cache = cache.WithFaults(cache.NewSnapshotCache(false, true, r.Logger), r.xdsServerConfig().Faults, r.Logger)
+	if r.RejectedSnapshots != nil {
+		r.cache.OnRejection(r.publishRejection)
+	}
+	if persistence := r.xdsServerConfig().SnapshotPersistence; persistence != nil {
+		r.restoreSnapshots(ctx, persistence)
+	}
+	if window := r.xdsServerConfig().BatchWindow; window != nil && window.Duration > 0 {
+		r.batcher = newSnapshotBatcher(window.Duration, r.updateSnapshot)
+		r.batcher.prioritize = r.RejectedSnapshots.IsRejected
+	}
 	go r.subscribeAndTranslate(ctx)
 	go r.setupXdsServer(ctx)
 	r.Logger.Info("started")
@@ -77,30 +107,57 @@ func (r *Runner) Start(ctx context.Context) error {
 }
 
 func (r *Runner) setupXdsServer(ctx context.Context) {
+	xds := r.xdsServerConfig()
+
 	// Set up the gRPC server and register the xDS handler.
-	cfg := r.tlsConfig(xdsTLSCertFilename, xdsTLSKeyFilename, xdsTLSCaFilename)
-	r.grpc = grpc.NewServer(grpc.Creds(credentials.NewTLS(cfg)))
+	cfg := r.tlsConfig(xds.TLS.CertificatePath, xds.TLS.PrivateKeyPath, xds.TLS.CACertificatePath)
+	r.grpc = grpc.NewServer(grpc.Creds(credentials.NewTLS(cfg)), grpc.StreamInterceptor(r.handoff.streamInterceptor))
+
+	// The xDS streams outlive ctx until they are handed off.
+	serverCtx, cancel := context.WithCancel(context.Background())
+	registerServer(controlplane_server_v3.NewServer(serverCtx, r.cache, r.cache), r.grpc)
+	if r.MetricsService != nil {
+		controlplane_service_metrics_v3.RegisterMetricsServiceServer(r.grpc, r.MetricsService)
+	}
 
-	r.cache = cache.NewSnapshotCache(false, r.Logger)
-	registerServer(controlplane_server_v3.NewServer(ctx, r.cache, r.cache), r.grpc)
+	// Every replica serves xDS from its own snapshots, including the standby
+	// replicas when leader election is enabled. On shutdown, the Envoy proxies
+	// are handed over to the other replicas, which they reconnect to while
+	// keeping their current configuration.
+	go func() {
+		defer close(r.stopped)
+		<-ctx.Done()
+		period := xds.GetHandoffPeriod()
+		r.Logger.Info("grpc server shutting down, handing off xds streams", "period", period)
+		// Stop accepting connections. GracefulStop doesn't return while
+		// envoy has long-lived hanging xDS requests, so the streams are
+		// closed by the hand-off and the TCP sessions are terminated
+		// afterwards.
+		go r.grpc.GracefulStop()
+		r.handoff.handOff(period)
+		cancel()
+		r.grpc.Stop()
+		r.Logger.Info("grpc server stopped")
+	}()
 
-	addr := net.JoinHostPort(XdsServerAddress, strconv.Itoa(XdsServerPort))
+	addr := net.JoinHostPort(xds.Address, strconv.Itoa(int(xds.Port)))
 	l, err := net.Listen("tcp", addr)
 	if err != nil {
 		r.Logger.Error(err, "failed to listen on address", addr)
+		return
 	}
-	err = r.grpc.Serve(l)
-	if err != nil {
+	if err := r.grpc.Serve(l); err != nil {
 		r.Logger.Error(err, "failed to start grpc based xds server")
 	}
+}
 
-	<-ctx.Done()
-	r.Logger.Info("grpc server shutting down")
-	// We don't use GracefulStop here because envoy
-	// has long-lived hanging xDS requests. There's no
-	// mechanism to make those pending requests fail,
-	// so we forcibly terminate the TCP sessions.
-	r.grpc.Stop()
+// xdsServerConfig returns the xDS server configuration of Envoy Gateway,
+// with defaults applied to unset fields.
+func (r *Runner) xdsServerConfig() *v1alpha1.XdsServer {
+	if r.EnvoyGateway == nil {
+		return v1alpha1.DefaultXdsServer()
+	}
+	return r.EnvoyGateway.GetXdsServer()
 }
 
 // registerServer registers the given xDS protocol Server with the gRPC
@@ -116,23 +173,40 @@ func registerServer(srv controlplane_server_v3.Server, g *grpc.Server) {
 	controlplane_service_runtime_v3.RegisterRuntimeDiscoveryServiceServer(g, srv)
 }
 
+// publishRejection publishes the rejection of the current snapshot of the
+// cluster by one of its proxies, or its removal if nil.
+func (r *Runner) publishRejection(cluster string, rejection *cache.Rejection) {
+	if rejection == nil {
+		r.RejectedSnapshots.Delete(cluster)
+		return
+	}
+	r.RejectedSnapshots.Store(cluster, message.SnapshotRejection{
+		Node:     rejection.Node,
+		TypeURL:  rejection.TypeURL,
+		Resource: rejection.Resource,
+		Message:  rejection.Message,
+	})
+}
+
 func (r *Runner) subscribeAndTranslate(ctx context.Context) {
-	// Subscribe to resources
-	message.HandleSubscription(r.Xds.Subscribe(ctx),
+	// Subscribe to resources, handling the updates of the clusters whose
+	// proxies rejected their current snapshot first.
+	message.HandleSubscriptionByPriority(r.Xds.Subscribe(ctx), r.RejectedSnapshots.IsRejected,
 		func(update message.Update[string, *xdstypes.ResourceVersionTable]) {
-			key := update.Key
-			val := update.Value
-
-			var err error
-			if update.Delete {
-				err = r.cache.GenerateNewSnapshot(key, nil)
-			} else {
-				// Update snapshot cache
-				err = r.cache.GenerateNewSnapshot(key, val.XdsResources)
+			var resources xdstypes.XdsResources
+			if !update.Delete {
+				resources = update.Value.XdsResources
+			}
+			// Hold back the snapshots computed while the persisted ones
+			// are served.
+			if r.warmup != nil && r.warmup.hold(update.Key, resources) {
+				return
 			}
-			if err != nil {
-				r.Logger.Error(err, "failed to generate a snapshot")
+			if r.batcher != nil {
+				r.batcher.add(update.Key, resources)
+				return
 			}
+			r.updateSnapshot(update.Key, resources)
 		},
 	)
 
@@ -7,7 +7,12 @@ package translator
 
 import (
 	accesslog "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	fileaccesslog "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/file/v3"
+	xdstype "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 var (
@@ -22,3 +27,94 @@ var (
 		},
 	}
 )
+
+// accessLogSampleRuntimeKey is the runtime key overriding the fraction of the
+// requests sampled by the access logs of the HTTP listeners.
+const accessLogSampleRuntimeKey = "envoy_gateway.access_log.sample_rate"
+
+// buildXdsAccessLogSampleFilter returns the filter of the access log of an
+// HTTP listener writing the provided fraction of the requests, in parts per
+// million. The requests are sampled by their request ID, so that the
+// requests traced are logged alike.
+func buildXdsAccessLogSampleFilter(rate uint32) *accesslog.AccessLogFilter {
+	return &accesslog.AccessLogFilter{
+		FilterSpecifier: &accesslog.AccessLogFilter_RuntimeFilter{
+			RuntimeFilter: &accesslog.RuntimeFilter{
+				RuntimeKey: accessLogSampleRuntimeKey,
+				PercentSampled: &xdstype.FractionalPercent{
+					Numerator:   rate,
+					Denominator: xdstype.FractionalPercent_MILLION,
+				},
+			},
+		},
+	}
+}
+
+// buildXdsL4AccessLog returns the access log of the TCP connections or UDP
+// sessions of the L4 listener with the provided name, in the default text
+// format of Envoy unless json is set. The JSON entries of the TCP connections
+// carry the ID Envoy assigns to each connection, so that they can be
+// correlated with the logs of the proxy. UDP sessions have no such ID, and are
+// identified by their downstream address instead.
+func buildXdsL4AccessLog(listenerName string, json, connectionID bool) ([]*accesslog.AccessLog, error) {
+	if !json {
+		accesslogAny, err := anypb.New(stdoutFileAccessLog)
+		if err != nil {
+			return nil, err
+		}
+		return []*accesslog.AccessLog{
+			{
+				Name:       wellknown.FileAccessLog,
+				ConfigType: &accesslog.AccessLog_TypedConfig{TypedConfig: accesslogAny},
+			},
+		}, nil
+	}
+
+	fields := map[string]interface{}{
+		"start_time":                "%START_TIME%",
+		"listener":                  listenerName,
+		"downstream_remote_address": "%DOWNSTREAM_REMOTE_ADDRESS%",
+		"downstream_local_address":  "%DOWNSTREAM_LOCAL_ADDRESS%",
+		"requested_server_name":     "%REQUESTED_SERVER_NAME%",
+		"upstream_cluster":          "%UPSTREAM_CLUSTER%",
+		"upstream_host":             "%UPSTREAM_HOST%",
+		"bytes_received":            "%BYTES_RECEIVED%",
+		"bytes_sent":                "%BYTES_SENT%",
+		"duration":                  "%DURATION%",
+		"response_flags":            "%RESPONSE_FLAGS%",
+	}
+	if connectionID {
+		fields["connection_id"] = "%CONNECTION_ID%"
+	}
+	return buildXdsJSONAccessLog(fields)
+}
+
+// buildXdsJSONAccessLog returns the access log writing entries with the
+// provided fields, formatted as JSON, to the standard output of the proxy.
+func buildXdsJSONAccessLog(fields map[string]interface{}) ([]*accesslog.AccessLog, error) {
+	jsonFormat, err := structpb.NewStruct(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	accesslogAny, err := anypb.New(&fileaccesslog.FileAccessLog{
+		Path: stdoutFileAccessLog.Path,
+		AccessLogFormat: &fileaccesslog.FileAccessLog_LogFormat{
+			LogFormat: &core.SubstitutionFormatString{
+				Format: &core.SubstitutionFormatString_JsonFormat{
+					JsonFormat: jsonFormat,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []*accesslog.AccessLog{
+		{
+			Name:       wellknown.FileAccessLog,
+			ConfigType: &accesslog.AccessLog_TypedConfig{TypedConfig: accesslogAny},
+		},
+	}, nil
+}
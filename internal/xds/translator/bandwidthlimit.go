@@ -0,0 +1,66 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	bandwidthlimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/bandwidth_limit/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const bandwidthLimitFilterName = "envoy.filters.http.bandwidth_limit"
+
+// addXdsBandwidthLimit adds the bandwidth limit filter to the filter chain of
+// the listener if missing. The filter is disabled by default, and enabled by
+// the routes whose throughput is limited.
+func addXdsBandwidthLimit(xdsListener *listener.Listener, httpListener *ir.HTTPListener, filterChainName string) error {
+	filterChain := findXdsHTTPFilterChain(xdsListener, httpListener, filterChainName)
+	return patchXdsHCM(filterChain, func(mgr *hcm.HttpConnectionManager) error {
+		if httpFilterIndex(mgr.HttpFilters, bandwidthLimitFilterName) >= 0 {
+			return nil
+		}
+		bandwidthLimitAny, err := anypb.New(&bandwidthlimitv3.BandwidthLimit{
+			StatPrefix: "bandwidth_limit",
+			EnableMode: bandwidthlimitv3.BandwidthLimit_DISABLED,
+		})
+		if err != nil {
+			return err
+		}
+		mgr.HttpFilters = append(mgr.HttpFilters, &hcm.HttpFilter{
+			Name:       bandwidthLimitFilterName,
+			ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: bandwidthLimitAny},
+		})
+		mgr.HttpFilters = sortHTTPFilters(mgr.HttpFilters, httpListener.FilterOrder)
+		return nil
+	})
+}
+
+// buildBandwidthLimitPerRouteConfig returns the per-route configuration
+// enabling the bandwidth limit filter with the limit of the route. The stats
+// of the filter are prefixed with the name of the route.
+func buildBandwidthLimitPerRouteConfig(routeName string, limit *ir.BandwidthLimit) (*anypb.Any, error) {
+	bl := &bandwidthlimitv3.BandwidthLimit{
+		StatPrefix: routeName,
+		LimitKbps:  wrapperspb.UInt64(limit.LimitKiBps),
+	}
+	switch {
+	case limit.Request && limit.Response:
+		bl.EnableMode = bandwidthlimitv3.BandwidthLimit_REQUEST_AND_RESPONSE
+	case limit.Request:
+		bl.EnableMode = bandwidthlimitv3.BandwidthLimit_REQUEST
+	case limit.Response:
+		bl.EnableMode = bandwidthlimitv3.BandwidthLimit_RESPONSE
+	}
+	if limit.FillInterval != nil {
+		bl.FillInterval = durationpb.New(limit.FillInterval.Duration)
+	}
+	return anypb.New(bl)
+}
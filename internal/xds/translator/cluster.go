@@ -6,28 +6,57 @@
 package translator
 
 import (
+	"fmt"
+	"sort"
 	"time"
 
 	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	httpupstream "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
+	xdstype "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
 )
 
+// httpProtocolOptionsName is the name of the extension configuring the
+// protocol of the requests forwarded to the endpoints of a cluster.
+const httpProtocolOptionsName = "envoy.extensions.upstreams.http.v3.HttpProtocolOptions"
+
 func buildXdsCluster(routeName string, destinations []*ir.RouteDestination, isHTTP2 bool) (*cluster.Cluster, error) {
-	localities := make([]*endpoint.LocalityLbEndpoints, 0, 1)
-	locality := &endpoint.LocalityLbEndpoints{
-		Locality:    &core.Locality{},
-		LbEndpoints: buildXdsEndpoints(destinations),
-		Priority:    0,
-		// Each locality gets the same weight 1. There is a single locality
-		// per priority, so the weight value does not really matter, but some
-		// load balancers need the value to be set.
-		LoadBalancingWeight: &wrapperspb.UInt32Value{Value: 1}}
-	localities = append(localities, locality)
+	// Envoy requires the priorities of the localities to be consecutive
+	// starting from 0, so the destinations of the lowest priority are
+	// assigned priority 0, and so on.
+	var priorities []uint32
+	byPriority := make(map[uint32][]*ir.RouteDestination)
+	for _, destination := range destinations {
+		if _, ok := byPriority[destination.Priority]; !ok {
+			priorities = append(priorities, destination.Priority)
+		}
+		byPriority[destination.Priority] = append(byPriority[destination.Priority], destination)
+	}
+	if len(priorities) == 0 {
+		priorities = []uint32{0}
+	}
+	sort.Slice(priorities, func(i, j int) bool { return priorities[i] < priorities[j] })
+
+	localities := make([]*endpoint.LocalityLbEndpoints, 0, len(priorities))
+	for i, priority := range priorities {
+		localities = append(localities, &endpoint.LocalityLbEndpoints{
+			Locality:    &core.Locality{},
+			LbEndpoints: buildXdsEndpoints(byPriority[priority]),
+			Priority:    uint32(i),
+			// Each locality gets the same weight 1. There is a single locality
+			// per priority, so the weight value does not really matter, but some
+			// load balancers need the value to be set.
+			LoadBalancingWeight: &wrapperspb.UInt32Value{Value: 1}})
+	}
 	clusterName := routeName
 	cluster := &cluster.Cluster{
 		Name:                 clusterName,
@@ -41,6 +70,12 @@ func buildXdsCluster(routeName string, destinations []*ir.RouteDestination, isHT
 				LocalityWeightedLbConfig: &cluster.Cluster_CommonLbConfig_LocalityWeightedLbConfig{}}},
 		OutlierDetection: &cluster.OutlierDetection{},
 	}
+	// The draining destinations are unhealthy, so the panic threshold is
+	// disabled for the requests to never be forwarded to them, even if most
+	// destinations are draining.
+	if anyDrainingDestination(destinations) {
+		cluster.CommonLbConfig.HealthyPanicThreshold = &xdstype.Percent{Value: 0}
+	}
 
 	if isHTTP2 {
 		cluster.Http2ProtocolOptions = &core.Http2ProtocolOptions{}
@@ -50,29 +85,317 @@ func buildXdsCluster(routeName string, destinations []*ir.RouteDestination, isHT
 
 }
 
+// weightedCluster is a cluster receiving a share of the connections
+// of a TCP listener.
+type weightedCluster struct {
+	cluster *cluster.Cluster
+	weight  uint32
+}
+
+// buildXdsTCPClusters builds the clusters of a TCP listener. A single cluster is
+// built if there is at most one destination, otherwise a cluster is built per
+// destination so that connections are split across them according to the
+// destination weights.
+func buildXdsTCPClusters(listenerName string, destinations []*ir.RouteDestination) ([]*weightedCluster, error) {
+	if len(destinations) <= 1 {
+		xdsCluster, err := buildXdsCluster(listenerName, destinations, false /*isHTTP2 */)
+		if err != nil {
+			return nil, err
+		}
+		return []*weightedCluster{{cluster: xdsCluster, weight: 1}}, nil
+	}
+
+	clusters := make([]*weightedCluster, 0, len(destinations))
+	for i, destination := range destinations {
+		xdsCluster, err := buildXdsCluster(destinationClusterName(listenerName, i), []*ir.RouteDestination{destination}, false /*isHTTP2 */)
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, &weightedCluster{cluster: xdsCluster, weight: destinationWeight(destination)})
+	}
+
+	return clusters, nil
+}
+
+// buildXdsHTTPClusters builds the clusters of a HTTP route. A single cluster is
+// built for all the destinations, unless the requests forwarded to each of them
+// are modified differently, in which case a cluster is built per destination so
+// that the requests are split across them by a weighted cluster.
+func buildXdsHTTPClusters(httpRoute *ir.HTTPRoute, isHTTP2 bool) ([]*cluster.Cluster, error) {
+	var clusters []*cluster.Cluster
+	if httpRoute.DynamicForwardProxy != nil {
+		xdsCluster, err := buildXdsDynamicForwardProxyCluster(httpRoute.Name, httpRoute.DynamicForwardProxy)
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, xdsCluster)
+	} else if !requiresClusterPerDestination(httpRoute) {
+		xdsCluster, err := buildXdsCluster(httpRoute.Name, httpRoute.Destinations, isHTTP2)
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, xdsCluster)
+	} else {
+		for i, destination := range httpRoute.Destinations {
+			xdsCluster, err := buildXdsCluster(destinationClusterName(httpRoute.Name, i), []*ir.RouteDestination{destination}, isHTTP2)
+			if err != nil {
+				return nil, err
+			}
+			clusters = append(clusters, xdsCluster)
+		}
+	}
+
+	if httpRoute.ConnectTimeout != nil {
+		for _, xdsCluster := range clusters {
+			xdsCluster.ConnectTimeout = durationpb.New(httpRoute.ConnectTimeout.Duration)
+		}
+	}
+
+	// The dynamic forward proxy clusters balance the requests themselves.
+	if httpRoute.DynamicForwardProxy == nil {
+		for _, xdsCluster := range clusters {
+			setXdsLoadBalancer(xdsCluster, httpRoute.LoadBalancer)
+		}
+	}
+
+	if httpRoute.BackendTLS != nil {
+		for _, xdsCluster := range clusters {
+			socket, err := buildXdsUpstreamTLSSocket(httpRoute.Name, httpRoute.BackendTLS)
+			if err != nil {
+				return nil, err
+			}
+			xdsCluster.TransportSocket = socket
+			if err := setXdsUpstreamHTTPProtocol(xdsCluster, httpRoute.BackendTLS); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return clusters, nil
+}
+
+// setXdsUpstreamHTTPProtocol sets the protocol of the requests forwarded to
+// the destinations of the cluster according to the application protocols
+// offered to them. The protocol is negotiated with each destination if both
+// HTTP/2 and HTTP/1.1 are offered, and the protocol of the listener is kept if
+// no protocol is offered.
+func setXdsUpstreamHTTPProtocol(xdsCluster *cluster.Cluster, backendTLS *ir.BackendTLSConfig) error {
+	switch {
+	case backendTLS.AutoHTTPProtocol():
+		optionsAny, err := anypb.New(&httpupstream.HttpProtocolOptions{
+			UpstreamProtocolOptions: &httpupstream.HttpProtocolOptions_AutoConfig{
+				AutoConfig: &httpupstream.HttpProtocolOptions_AutoHttpConfig{
+					HttpProtocolOptions:  &core.Http1ProtocolOptions{},
+					Http2ProtocolOptions: &core.Http2ProtocolOptions{},
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		// The protocol options of the cluster conflict with the ones of
+		// the extension.
+		xdsCluster.Http2ProtocolOptions = nil
+		xdsCluster.TypedExtensionProtocolOptions = map[string]*anypb.Any{
+			httpProtocolOptionsName: optionsAny,
+		}
+	case len(backendTLS.ALPNProtocols) == 1 && backendTLS.ALPNProtocols[0] == "h2":
+		xdsCluster.Http2ProtocolOptions = &core.Http2ProtocolOptions{}
+	case len(backendTLS.ALPNProtocols) == 1 && backendTLS.ALPNProtocols[0] == "http/1.1":
+		xdsCluster.Http2ProtocolOptions = nil
+	}
+	return nil
+}
+
+// upstreamClientCertSecretName returns the name of the SDS secret holding the
+// client certificate presented to the destinations of the route.
+func upstreamClientCertSecretName(routeName string) string {
+	return fmt.Sprintf("%s-client-certificate", routeName)
+}
+
+// upstreamCASecretName returns the name of the SDS secret holding the CA
+// certificates used to verify the destinations of the route.
+func upstreamCASecretName(routeName string) string {
+	return fmt.Sprintf("%s-ca-certificate", routeName)
+}
+
+// buildXdsUpstreamTLSSocket builds the transport socket originating TLS to the
+// destinations of the route. The client certificate and CA certificates are
+// delivered to Envoy via SDS, so that they are rotated without updating the
+// clusters.
+func buildXdsUpstreamTLSSocket(routeName string, backendTLS *ir.BackendTLSConfig) (*core.TransportSocket, error) {
+	tlsCtx := &tls.UpstreamTlsContext{
+		CommonTlsContext: &tls.CommonTlsContext{
+			AlpnProtocols: backendTLS.ALPNProtocols,
+		},
+		Sni: backendTLS.SNI,
+	}
+	if backendTLS.SPIFFE {
+		// The SVID and trust bundle are served by the Workload API through
+		// the cluster added to the bootstrap configuration.
+		tlsCtx.CommonTlsContext.TlsCertificateSdsSecretConfigs = []*tls.SdsSecretConfig{{
+			Name:      bootstrap.SPIFFESVIDName,
+			SdsConfig: makeSPIFFEConfigSource(),
+		}}
+		tlsCtx.CommonTlsContext.ValidationContextType = &tls.CommonTlsContext_ValidationContextSdsSecretConfig{
+			ValidationContextSdsSecretConfig: &tls.SdsSecretConfig{
+				Name:      bootstrap.SPIFFEBundleName,
+				SdsConfig: makeSPIFFEConfigSource(),
+			},
+		}
+	} else if len(backendTLS.ClientCertificate) > 0 {
+		tlsCtx.CommonTlsContext.TlsCertificateSdsSecretConfigs = []*tls.SdsSecretConfig{{
+			Name:      upstreamClientCertSecretName(routeName),
+			SdsConfig: makeConfigSource(),
+		}}
+	}
+	if !backendTLS.SPIFFE && len(backendTLS.CACertificate) > 0 {
+		tlsCtx.CommonTlsContext.ValidationContextType = &tls.CommonTlsContext_ValidationContextSdsSecretConfig{
+			ValidationContextSdsSecretConfig: &tls.SdsSecretConfig{
+				Name:      upstreamCASecretName(routeName),
+				SdsConfig: makeConfigSource(),
+			},
+		}
+	}
+
+	tlsCtxAny, err := anypb.New(tlsCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.TransportSocket{
+		Name: wellknown.TransportSocketTls,
+		ConfigType: &core.TransportSocket_TypedConfig{
+			TypedConfig: tlsCtxAny,
+		},
+	}, nil
+}
+
+// buildXdsUpstreamTLSSecrets builds the SDS secrets referenced by the transport
+// socket originating TLS to the destinations of the route.
+func buildXdsUpstreamTLSSecrets(routeName string, backendTLS *ir.BackendTLSConfig) []*tls.Secret {
+	var secrets []*tls.Secret
+	if backendTLS.SPIFFE {
+		return secrets
+	}
+	if len(backendTLS.ClientCertificate) > 0 {
+		secrets = append(secrets, &tls.Secret{
+			Name: upstreamClientCertSecretName(routeName),
+			Type: &tls.Secret_TlsCertificate{
+				TlsCertificate: &tls.TlsCertificate{
+					CertificateChain: &core.DataSource{
+						Specifier: &core.DataSource_InlineBytes{InlineBytes: backendTLS.ClientCertificate},
+					},
+					PrivateKey: &core.DataSource{
+						Specifier: &core.DataSource_InlineBytes{InlineBytes: backendTLS.PrivateKey},
+					},
+				},
+			},
+		})
+	}
+	if len(backendTLS.CACertificate) > 0 {
+		secrets = append(secrets, &tls.Secret{
+			Name: upstreamCASecretName(routeName),
+			Type: &tls.Secret_ValidationContext{
+				ValidationContext: &tls.CertificateValidationContext{
+					TrustedCa: &core.DataSource{
+						Specifier: &core.DataSource_InlineBytes{InlineBytes: backendTLS.CACertificate},
+					},
+				},
+			},
+		})
+	}
+	return secrets
+}
+
+// requiresClusterPerDestination returns true if any of the destinations modifies
+// the headers of the requests forwarded to it, if the destinations rewrite the
+// Host header to different values, or if their weights are read from the
+// runtime.
+func requiresClusterPerDestination(httpRoute *ir.HTTPRoute) bool {
+	destinations := httpRoute.Destinations
+	if httpRoute.RuntimeWeightsKeyPrefix != "" && len(destinations) > 0 {
+		return true
+	}
+	for _, destination := range destinations {
+		if len(destination.AddRequestHeaders) > 0 || len(destination.RemoveRequestHeaders) > 0 {
+			return true
+		}
+		if destination.HostRewrite != destinations[0].HostRewrite {
+			return true
+		}
+	}
+	return false
+}
+
+// destinationClusterName returns the name of the cluster of the destination at
+// the provided index, when a cluster is built per destination.
+func destinationClusterName(routeName string, idx int) string {
+	return fmt.Sprintf("%s-%d", routeName, idx)
+}
+
+// anyDrainingDestination returns true if any of the destinations is draining.
+func anyDrainingDestination(destinations []*ir.RouteDestination) bool {
+	for _, destination := range destinations {
+		if destination.Draining {
+			return true
+		}
+	}
+	return false
+}
+
+// allDrainingDestinations returns true if all the destinations are draining.
+func allDrainingDestinations(destinations []*ir.RouteDestination) bool {
+	for _, destination := range destinations {
+		if !destination.Draining {
+			return false
+		}
+	}
+	return len(destinations) > 0
+}
+
+// destinationWeight returns the weight of the destination. An unset weight
+// defaults to 1, consistent with the endpoint weights.
+func destinationWeight(destination *ir.RouteDestination) uint32 {
+	if destination.Weight == 0 {
+		return 1
+	}
+	return destination.Weight
+}
+
 func buildXdsEndpoints(destinations []*ir.RouteDestination) []*endpoint.LbEndpoint {
 	endpoints := make([]*endpoint.LbEndpoint, 0, len(destinations))
 	for _, destination := range destinations {
+		address := &core.Address{
+			Address: &core.Address_SocketAddress{
+				SocketAddress: &core.SocketAddress{
+					Protocol: core.SocketAddress_TCP,
+					Address:  destination.Host,
+					PortSpecifier: &core.SocketAddress_PortValue{
+						PortValue: destination.Port,
+					},
+				},
+			},
+		}
+		if destination.InternalListener != "" {
+			address = buildXdsInternalAddress(internalListenerName(destination.InternalListener))
+		}
 		lbEndpoint := &endpoint.LbEndpoint{
 			HostIdentifier: &endpoint.LbEndpoint_Endpoint{
 				Endpoint: &endpoint.Endpoint{
-					Address: &core.Address{
-						Address: &core.Address_SocketAddress{
-							SocketAddress: &core.SocketAddress{
-								Protocol: core.SocketAddress_TCP,
-								Address:  destination.Host,
-								PortSpecifier: &core.SocketAddress_PortValue{
-									PortValue: destination.Port,
-								},
-							},
-						},
-					},
+					Address: address,
 				},
 			},
 		}
 		if destination.Weight != 0 {
 			lbEndpoint.LoadBalancingWeight = &wrapperspb.UInt32Value{Value: destination.Weight}
 		}
+		if destination.Draining {
+			lbEndpoint.HealthStatus = core.HealthStatus_DRAINING
+		}
+		if destination.HashKey != "" {
+			lbEndpoint.Metadata = buildXdsEndpointHashKeyMetadata(destination.HashKey)
+		}
 		endpoints = append(endpoints, lbEndpoint)
 	}
 	return endpoints
@@ -0,0 +1,69 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	csrfv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/csrf/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	xdstype "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// csrfFilterName is the name of the CSRF HTTP filter.
+const csrfFilterName = "envoy.filters.http.csrf"
+
+// addXdsCSRF adds the CSRF filter to the filter chain of the listener if
+// missing. The filter is disabled by default, and enabled by the routes
+// requiring the protection.
+func addXdsCSRF(xdsListener *listener.Listener, httpListener *ir.HTTPListener, filterChainName string) error {
+	filterChain := findXdsHTTPFilterChain(xdsListener, httpListener, filterChainName)
+	return patchXdsHCM(filterChain, func(mgr *hcm.HttpConnectionManager) error {
+		if httpFilterIndex(mgr.HttpFilters, csrfFilterName) >= 0 {
+			return nil
+		}
+		csrfAny, err := anypb.New(&csrfv3.CsrfPolicy{
+			FilterEnabled: buildCSRFFilterEnabled(0),
+		})
+		if err != nil {
+			return err
+		}
+		mgr.HttpFilters = append(mgr.HttpFilters, &hcm.HttpFilter{
+			Name:       csrfFilterName,
+			ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: csrfAny},
+		})
+		mgr.HttpFilters = sortHTTPFilters(mgr.HttpFilters, httpListener.FilterOrder)
+		return nil
+	})
+}
+
+// buildCSRFPerRouteConfig returns the per-route configuration enabling the
+// CSRF filter with the additional origins of the route.
+func buildCSRFPerRouteConfig(csrf *ir.CSRF) (*anypb.Any, error) {
+	origins := make([]*matcher.StringMatcher, 0, len(csrf.AdditionalOrigins))
+	for _, origin := range csrf.AdditionalOrigins {
+		origins = append(origins, buildXdsStringMatcher(origin))
+	}
+	return anypb.New(&csrfv3.CsrfPolicy{
+		FilterEnabled:     buildCSRFFilterEnabled(100),
+		AdditionalOrigins: origins,
+	})
+}
+
+// buildCSRFFilterEnabled returns the percentage of the requests the CSRF
+// filter is enforced on.
+func buildCSRFFilterEnabled(percent uint32) *core.RuntimeFractionalPercent {
+	return &core.RuntimeFractionalPercent{
+		DefaultValue: &xdstype.FractionalPercent{
+			Numerator:   percent,
+			Denominator: xdstype.FractionalPercent_HUNDRED,
+		},
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	dns "github.com/envoyproxy/go-control-plane/envoy/data/dns/v3"
+	dnsfilter "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/udp/dns_filter/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const dnsFilterName = "envoy.filters.udp_listener.dns_filter"
+
+// buildXdsDNSFilter returns the filter of the UDP listener answering the DNS
+// queries of the domains of its records, and forwarding the other queries to
+// the destinations of the listener.
+func buildXdsDNSFilter(udpListener *ir.UDPListener) (*listener.Filter, error) {
+	table := &dns.DnsTable{}
+	for _, record := range udpListener.DNS.Records {
+		domain := &dns.DnsTable_DnsVirtualDomain{
+			Name: record.Domain,
+			Endpoint: &dns.DnsTable_DnsEndpoint{
+				EndpointConfig: &dns.DnsTable_DnsEndpoint_AddressList{
+					AddressList: &dns.DnsTable_AddressList{Address: record.Addresses},
+				},
+			},
+		}
+		if udpListener.DNS.TTL != nil {
+			domain.AnswerTtl = durationpb.New(udpListener.DNS.TTL.Duration)
+		}
+		table.VirtualDomains = append(table.VirtualDomains, domain)
+	}
+
+	config := &dnsfilter.DnsFilterConfig{
+		StatPrefix: udpListener.Name,
+		ServerConfig: &dnsfilter.DnsFilterConfig_ServerContextConfig{
+			ConfigSource: &dnsfilter.DnsFilterConfig_ServerContextConfig_InlineDnsTable{
+				InlineDnsTable: table,
+			},
+		},
+	}
+	if len(udpListener.Destinations) > 0 {
+		resolvers := make([]*core.Address, 0, len(udpListener.Destinations))
+		for _, destination := range udpListener.Destinations {
+			resolvers = append(resolvers, &core.Address{
+				Address: &core.Address_SocketAddress{
+					SocketAddress: &core.SocketAddress{
+						Protocol: core.SocketAddress_UDP,
+						Address:  destination.Host,
+						PortSpecifier: &core.SocketAddress_PortValue{
+							PortValue: destination.Port,
+						},
+					},
+				},
+			})
+		}
+		config.ClientConfig = &dnsfilter.DnsFilterConfig_ClientContextConfig{
+			DnsResolutionConfig: &core.DnsResolutionConfig{Resolvers: resolvers},
+		}
+	}
+	configAny, err := anypb.New(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &listener.Filter{
+		Name: dnsFilterName,
+		ConfigType: &listener.Filter_TypedConfig{
+			TypedConfig: configAny,
+		},
+	}, nil
+}
@@ -0,0 +1,113 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"time"
+
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	dfpcluster "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/dynamic_forward_proxy/v3"
+	dfpcommon "github.com/envoyproxy/go-control-plane/envoy/extensions/common/dynamic_forward_proxy/v3"
+	dfpfilter "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/dynamic_forward_proxy/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// dynamicForwardProxyFilterName is the name of the dynamic forward proxy
+	// HTTP filter.
+	dynamicForwardProxyFilterName = "envoy.filters.http.dynamic_forward_proxy"
+	// dynamicForwardProxyClusterType is the type of the dynamic forward proxy
+	// clusters.
+	dynamicForwardProxyClusterType = "envoy.clusters.dynamic_forward_proxy"
+	// dnsCacheName is the name of the DNS cache shared by the dynamic forward
+	// proxy filters and clusters. Envoy requires the DNS caches of the same
+	// name to be configured identically, which holds since their settings are
+	// the ones of the proxy.
+	dnsCacheName = "dynamic_forward_proxy"
+)
+
+// buildXdsDNSCacheConfig returns the configuration of the DNS cache resolving
+// the hosts of the dynamic forward proxy.
+func buildXdsDNSCacheConfig(dfp *ir.DynamicForwardProxy) *dfpcommon.DnsCacheConfig {
+	dnsCache := &dfpcommon.DnsCacheConfig{
+		Name:            dnsCacheName,
+		DnsLookupFamily: cluster.Cluster_V4_ONLY,
+	}
+	if dfp.DNSRefreshRate != nil {
+		dnsCache.DnsRefreshRate = durationpb.New(dfp.DNSRefreshRate.Duration)
+	}
+	if dfp.DNSHostTTL != nil {
+		dnsCache.HostTtl = durationpb.New(dfp.DNSHostTTL.Duration)
+	}
+	if dfp.DNSMaxHosts > 0 {
+		dnsCache.MaxHosts = wrapperspb.UInt32(dfp.DNSMaxHosts)
+	}
+	return dnsCache
+}
+
+// buildXdsDynamicForwardProxyCluster builds the cluster of a route forwarding
+// the requests to the hosts they are addressed to.
+func buildXdsDynamicForwardProxyCluster(routeName string, dfp *ir.DynamicForwardProxy) (*cluster.Cluster, error) {
+	clusterAny, err := anypb.New(&dfpcluster.ClusterConfig{
+		DnsCacheConfig: buildXdsDNSCacheConfig(dfp),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &cluster.Cluster{
+		Name:           routeName,
+		ConnectTimeout: durationpb.New(5 * time.Second),
+		ClusterDiscoveryType: &cluster.Cluster_ClusterType{
+			ClusterType: &cluster.Cluster_CustomClusterType{
+				Name:        dynamicForwardProxyClusterType,
+				TypedConfig: clusterAny,
+			},
+		},
+		LbPolicy: cluster.Cluster_CLUSTER_PROVIDED,
+	}, nil
+}
+
+// addXdsDynamicForwardProxy adds the dynamic forward proxy filter to the
+// filter chain of the listener if missing. The filter resolves the hosts of
+// the requests routed to dynamic forward proxy clusters only.
+func addXdsDynamicForwardProxy(xdsListener *listener.Listener, httpListener *ir.HTTPListener, filterChainName string, dfp *ir.DynamicForwardProxy) error {
+	filterChain := findXdsHTTPFilterChain(xdsListener, httpListener, filterChainName)
+	return patchXdsHCM(filterChain, func(mgr *hcm.HttpConnectionManager) error {
+		if httpFilterIndex(mgr.HttpFilters, dynamicForwardProxyFilterName) >= 0 {
+			return nil
+		}
+		filterAny, err := anypb.New(&dfpfilter.FilterConfig{
+			DnsCacheConfig: buildXdsDNSCacheConfig(dfp),
+		})
+		if err != nil {
+			return err
+		}
+		mgr.HttpFilters = append(mgr.HttpFilters, &hcm.HttpFilter{
+			Name:       dynamicForwardProxyFilterName,
+			ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: filterAny},
+		})
+		mgr.HttpFilters = sortHTTPFilters(mgr.HttpFilters, httpListener.FilterOrder)
+		return nil
+	})
+}
+
+// buildDynamicForwardProxyPerRouteConfig returns the per-route configuration
+// of the dynamic forward proxy filter resolving the host of the header of the
+// route.
+func buildDynamicForwardProxyPerRouteConfig(dfp *ir.DynamicForwardProxy) (*anypb.Any, error) {
+	return anypb.New(&dfpfilter.PerRouteConfig{
+		HostRewriteSpecifier: &dfpfilter.PerRouteConfig_HostRewriteHeader{
+			HostRewriteHeader: dfp.HostHeader,
+		},
+	})
+}
@@ -0,0 +1,183 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	extauthz "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// extAuthTimeout is the timeout of the requests to the external authorization
+// services.
+const extAuthTimeout = 10 * time.Second
+
+// extAuthName returns the name of the authorization configuration of the
+// route, or the name of the route if the configuration is not shared.
+func extAuthName(httpRoute *ir.HTTPRoute) string {
+	if httpRoute.ExtAuth.Name != "" {
+		return httpRoute.ExtAuth.Name
+	}
+	return httpRoute.Name
+}
+
+// extAuthFilterName returns the name of the external authorization filter of
+// the route. The routes with the same authorization configuration share their
+// filter, while the others are authorized by their own filter since the
+// service of the filter cannot be overridden per route.
+func extAuthFilterName(httpRoute *ir.HTTPRoute) string {
+	return fmt.Sprintf("%s/%s", wellknown.HTTPExternalAuthorization, extAuthName(httpRoute))
+}
+
+// extAuthClusterName returns the name of the cluster of the external
+// authorization service of the route.
+func extAuthClusterName(httpRoute *ir.HTTPRoute) string {
+	return fmt.Sprintf("ext-auth/%s", extAuthName(httpRoute))
+}
+
+// addXdsExtAuth adds the external authorization filter of the route to the
+// filter chain of the listener, if not added yet for another route with the
+// same configuration, along with the cluster of its service.
+func addXdsExtAuth(tCtx *types.ResourceVersionTable, xdsListener *listener.Listener, httpListener *ir.HTTPListener,
+	filterChainName string, httpRoute *ir.HTTPRoute) error {
+	filterChain := findXdsHTTPFilterChain(xdsListener, httpListener, filterChainName)
+	if err := patchXdsHCM(filterChain, func(mgr *hcm.HttpConnectionManager) error {
+		if httpFilterIndex(mgr.HttpFilters, extAuthFilterName(httpRoute)) >= 0 {
+			return nil
+		}
+		extAuthFilter, err := buildExtAuthFilter(httpRoute)
+		if err != nil {
+			return err
+		}
+		mgr.HttpFilters = sortHTTPFilters(append(mgr.HttpFilters, extAuthFilter), httpListener.FilterOrder)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if findXdsCluster(tCtx, extAuthClusterName(httpRoute)) == nil {
+		xdsCluster, err := buildXdsCluster(extAuthClusterName(httpRoute),
+			[]*ir.RouteDestination{httpRoute.ExtAuth.Destination}, httpRoute.ExtAuth.GRPC != nil)
+		if err != nil {
+			return err
+		}
+		tCtx.AddXdsResource(resource.ClusterType, xdsCluster)
+	}
+	return nil
+}
+
+// buildExtAuthFilter returns the external authorization filter of the route,
+// forwarding the configured context of the requests to its service.
+func buildExtAuthFilter(httpRoute *ir.HTTPRoute) (*hcm.HttpFilter, error) {
+	extAuth := httpRoute.ExtAuth
+	clusterName := extAuthClusterName(httpRoute)
+
+	extAuthz := &extauthz.ExtAuthz{
+		TransportApiVersion: core.ApiVersion_V3,
+		FailureModeAllow:    extAuth.FailOpen,
+	}
+	if extAuth.Body != nil {
+		extAuthz.WithRequestBody = &extauthz.BufferSettings{
+			MaxRequestBytes:     extAuth.Body.MaxRequestBytes,
+			AllowPartialMessage: extAuth.Body.AllowPartialMessage,
+		}
+	}
+
+	switch {
+	case extAuth.GRPC != nil:
+		extAuthz.Services = &extauthz.ExtAuthz_GrpcService{
+			GrpcService: &core.GrpcService{
+				TargetSpecifier: &core.GrpcService_EnvoyGrpc_{
+					EnvoyGrpc: &core.GrpcService_EnvoyGrpc{ClusterName: clusterName},
+				},
+				Timeout: durationpb.New(extAuthTimeout),
+			},
+		}
+		extAuthz.MetadataContextNamespaces = extAuth.GRPC.MetadataNamespaces
+	case extAuth.HTTP != nil:
+		httpService := &extauthz.HttpService{
+			ServerUri: &core.HttpUri{
+				Uri:              "http://" + net.JoinHostPort(extAuth.Destination.Host, strconv.Itoa(int(extAuth.Destination.Port))),
+				HttpUpstreamType: &core.HttpUri_Cluster{Cluster: clusterName},
+				Timeout:          durationpb.New(extAuthTimeout),
+			},
+			PathPrefix: extAuth.HTTP.Path,
+		}
+		if len(extAuth.HTTP.HeadersToExtAuth) > 0 {
+			httpService.AuthorizationRequest = &extauthz.AuthorizationRequest{
+				AllowedHeaders: buildExtAuthHeadersMatcher(extAuth.HTTP.HeadersToExtAuth),
+			}
+		}
+		if len(extAuth.HTTP.HeadersToBackend) > 0 {
+			httpService.AuthorizationResponse = &extauthz.AuthorizationResponse{
+				AllowedUpstreamHeaders: buildExtAuthHeadersMatcher(extAuth.HTTP.HeadersToBackend),
+			}
+		}
+		extAuthz.Services = &extauthz.ExtAuthz_HttpService{HttpService: httpService}
+	default:
+		return nil, errors.New("external authorization service is not specified")
+	}
+
+	extAuthzAny, err := anypb.New(extAuthz)
+	if err != nil {
+		return nil, err
+	}
+	return &hcm.HttpFilter{
+		Name:       extAuthFilterName(httpRoute),
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: extAuthzAny},
+	}, nil
+}
+
+// buildExtAuthHeadersMatcher returns the matcher of the headers with the
+// provided names, ignoring their case.
+func buildExtAuthHeadersMatcher(headers []string) *matcher.ListStringMatcher {
+	patterns := make([]*matcher.StringMatcher, 0, len(headers))
+	for _, header := range headers {
+		patterns = append(patterns, &matcher.StringMatcher{
+			MatchPattern: &matcher.StringMatcher_Exact{Exact: header},
+			IgnoreCase:   true,
+		})
+	}
+	return &matcher.ListStringMatcher{Patterns: patterns}
+}
+
+// buildExtAuthPerRouteConfig returns the per-route configuration enabling the
+// external authorization filter of the route.
+func buildExtAuthPerRouteConfig() (*anypb.Any, error) {
+	return anypb.New(&extauthz.ExtAuthzPerRoute{
+		Override: &extauthz.ExtAuthzPerRoute_CheckSettings{
+			CheckSettings: &extauthz.CheckSettings{},
+		},
+	})
+}
+
+// disableXdsExtAuthOnOtherRoutes disables the external authorization filters on
+// the routes sharing the HTTP connection manager with the routes they were
+// added for, since each filter must only authorize the requests to its routes.
+func disableXdsExtAuthOnOtherRoutes(tCtx *types.ResourceVersionTable) error {
+	disabledAny, err := anypb.New(&extauthz.ExtAuthzPerRoute{
+		Override: &extauthz.ExtAuthzPerRoute_Disabled{Disabled: true},
+	})
+	if err != nil {
+		return err
+	}
+	return disableXdsRouteFiltersOnOtherRoutes(tCtx, wellknown.HTTPExternalAuthorization, disabledAny)
+}
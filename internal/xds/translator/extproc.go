@@ -0,0 +1,137 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	extproc "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+const (
+	// extProcFilterType is the type of the external processing filters.
+	extProcFilterType = "envoy.filters.http.ext_proc"
+
+	// extProcTimeout is the timeout of the gRPC streams to the external
+	// processing services.
+	extProcTimeout = 10 * time.Second
+)
+
+// extProcFilterName returns the name of the external processing filter
+// shared by the routes of the listener with the provided failure mode. The
+// processing service of the filter is overridden per route, while its failure
+// mode cannot be.
+func extProcFilterName(failOpen bool) string {
+	if failOpen {
+		return extProcFilterType + "/fail-open"
+	}
+	return extProcFilterType
+}
+
+// extProcClusterName returns the name of the cluster of the provided external
+// processing service, shared by the routes it processes.
+func extProcClusterName(destination *ir.RouteDestination) string {
+	return fmt.Sprintf("ext-proc/%s", net.JoinHostPort(destination.Host, strconv.Itoa(int(destination.Port))))
+}
+
+// addXdsExtProc adds the external processing filter of the failure mode of
+// the route to the filter chain of the listener, if not added yet, along with
+// the cluster of its service.
+func addXdsExtProc(tCtx *types.ResourceVersionTable, xdsListener *listener.Listener, httpListener *ir.HTTPListener,
+	filterChainName string, httpRoute *ir.HTTPRoute) error {
+	filterChain := findXdsHTTPFilterChain(xdsListener, httpListener, filterChainName)
+	if err := patchXdsHCM(filterChain, func(mgr *hcm.HttpConnectionManager) error {
+		if httpFilterIndex(mgr.HttpFilters, extProcFilterName(httpRoute.ExtProc.FailOpen)) >= 0 {
+			return nil
+		}
+		extProcFilter, err := buildExtProcFilter(httpRoute.ExtProc)
+		if err != nil {
+			return err
+		}
+		mgr.HttpFilters = sortHTTPFilters(append(mgr.HttpFilters, extProcFilter), httpListener.FilterOrder)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	clusterName := extProcClusterName(httpRoute.ExtProc.Destination)
+	if findXdsCluster(tCtx, clusterName) == nil {
+		xdsCluster, err := buildXdsCluster(clusterName, []*ir.RouteDestination{httpRoute.ExtProc.Destination}, true)
+		if err != nil {
+			return err
+		}
+		tCtx.AddXdsResource(resource.ClusterType, xdsCluster)
+	}
+	return nil
+}
+
+// buildExtProcGrpcService returns the gRPC service of the provided external
+// processing service.
+func buildExtProcGrpcService(extProc *ir.ExtProc) *core.GrpcService {
+	return &core.GrpcService{
+		TargetSpecifier: &core.GrpcService_EnvoyGrpc_{
+			EnvoyGrpc: &core.GrpcService_EnvoyGrpc{ClusterName: extProcClusterName(extProc.Destination)},
+		},
+		Timeout: durationpb.New(extProcTimeout),
+	}
+}
+
+// buildExtProcFilter returns the external processing filter of the failure
+// mode of the provided service, sending the headers of the requests and
+// responses to the service of each route. The service of the filter itself
+// is always overridden by the routes.
+func buildExtProcFilter(extProc *ir.ExtProc) (*hcm.HttpFilter, error) {
+	extProcAny, err := anypb.New(&extproc.ExternalProcessor{
+		GrpcService:      buildExtProcGrpcService(extProc),
+		FailureModeAllow: extProc.FailOpen,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &hcm.HttpFilter{
+		Name:       extProcFilterName(extProc.FailOpen),
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: extProcAny},
+	}, nil
+}
+
+// buildExtProcPerRouteConfig returns the per-route configuration of the
+// external processing filter, sending the requests to the service of the
+// route.
+func buildExtProcPerRouteConfig(extProc *ir.ExtProc) (*anypb.Any, error) {
+	return anypb.New(&extproc.ExtProcPerRoute{
+		Override: &extproc.ExtProcPerRoute_Overrides{
+			Overrides: &extproc.ExtProcOverrides{
+				GrpcService: buildExtProcGrpcService(extProc),
+			},
+		},
+	})
+}
+
+// disableXdsExtProcOnOtherRoutes disables the external processing filters on
+// the routes sharing the HTTP connection manager with the routes they were
+// added for, since each filter must only process the requests to these
+// routes.
+func disableXdsExtProcOnOtherRoutes(tCtx *types.ResourceVersionTable) error {
+	disabledAny, err := anypb.New(&extproc.ExtProcPerRoute{
+		Override: &extproc.ExtProcPerRoute_Disabled{Disabled: true},
+	})
+	if err != nil {
+		return err
+	}
+	return disableXdsRouteFiltersOnOtherRoutes(tCtx, extProcFilterType, disabledAny)
+}
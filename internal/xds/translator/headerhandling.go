@@ -0,0 +1,111 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	luav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// duplicateHeadersFilterName is the name of the Lua filter handling the
+// duplicate request headers. It is distinct from the name of the Lua filter
+// running the transformations, so that its script is never overridden per
+// route.
+const duplicateHeadersFilterName = "envoy.filters.http.lua.duplicate_headers"
+
+// concatenateDuplicateHeadersScript concatenates the values of the duplicate
+// request headers into a single header. The Cookie headers, split by the
+// HTTP/2 clients, are concatenated with semicolons.
+const concatenateDuplicateHeadersScript = `function envoy_on_request(handle)
+  local headers = handle:headers()
+  local values = {}
+  for name, value in pairs(headers) do
+    if string.sub(name, 1, 1) ~= ":" then
+      values[name] = values[name] or {}
+      table.insert(values[name], value)
+    end
+  end
+  for name, list in pairs(values) do
+    if #list > 1 then
+      local separator = ","
+      if name == "cookie" then
+        separator = "; "
+      end
+      headers:remove(name)
+      headers:add(name, table.concat(list, separator))
+    end
+  end
+end
+`
+
+// rejectDuplicateHeadersScript rejects the requests with duplicate headers,
+// except the Cookie headers, split by the HTTP/2 clients.
+const rejectDuplicateHeadersScript = `function envoy_on_request(handle)
+  local seen = {}
+  for name, _ in pairs(handle:headers()) do
+    if string.sub(name, 1, 1) ~= ":" and name ~= "cookie" then
+      if seen[name] then
+        handle:respond({[":status"] = "400"}, "duplicate header")
+        return
+      end
+      seen[name] = true
+    end
+  end
+end
+`
+
+// buildXdsHeaderKeyFormat returns the format of the header names of the
+// HTTP/1 responses, or nil if they are written in lower case.
+func buildXdsHeaderKeyFormat(handling *ir.HeaderHandling) *core.Http1ProtocolOptions_HeaderKeyFormat {
+	if handling == nil || !handling.ProperCaseHeaders {
+		return nil
+	}
+	return &core.Http1ProtocolOptions_HeaderKeyFormat{
+		HeaderFormat: &core.Http1ProtocolOptions_HeaderKeyFormat_ProperCaseWords_{
+			ProperCaseWords: &core.Http1ProtocolOptions_HeaderKeyFormat_ProperCaseWords{},
+		},
+	}
+}
+
+// buildDuplicateHeadersFilter returns the Lua filter handling the duplicate
+// request headers, or nil if they are forwarded as is.
+func buildDuplicateHeadersFilter(handling *ir.HeaderHandling) (*hcm.HttpFilter, error) {
+	if handling == nil {
+		return nil, nil
+	}
+	var script string
+	switch handling.DuplicateHeaders {
+	case ir.ConcatenateDuplicateHeaders:
+		script = concatenateDuplicateHeadersScript
+	case ir.RejectDuplicateHeaders:
+		script = rejectDuplicateHeadersScript
+	default:
+		return nil, nil
+	}
+	luaAny, err := anypb.New(&luav3.Lua{InlineCode: script})
+	if err != nil {
+		return nil, err
+	}
+	return &hcm.HttpFilter{
+		Name:       duplicateHeadersFilterName,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: luaAny},
+	}, nil
+}
+
+// setXdsHopByHopHeaders strips the additional hop-by-hop headers of the
+// listener from the requests and responses of its virtual host.
+func setXdsHopByHopHeaders(vHost *route.VirtualHost, handling *ir.HeaderHandling) {
+	if handling == nil || len(handling.HopByHopHeaders) == 0 {
+		return
+	}
+	vHost.RequestHeadersToRemove = append(vHost.RequestHeadersToRemove, handling.HopByHopHeaders...)
+	vHost.ResponseHeadersToRemove = append(vHost.ResponseHeadersToRemove, handling.HopByHopHeaders...)
+}
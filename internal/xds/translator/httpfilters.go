@@ -0,0 +1,196 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+const (
+	// headerFilterRank is the rank of the HTTP filters normalizing the
+	// headers of requests.
+	headerFilterRank = iota
+	// authnFilterRank is the rank of the HTTP filters authenticating requests.
+	authnFilterRank
+	// authzFilterRank is the rank of the HTTP filters authorizing requests.
+	authzFilterRank
+	// rateLimitFilterRank is the rank of the HTTP filters rate limiting requests.
+	rateLimitFilterRank
+	// customFilterRank is the rank of any other HTTP filter.
+	customFilterRank
+)
+
+// httpFilterRanks holds the rank of the well known HTTP filters. Filters are
+// ordered header normalization, authentication, authorization, rate limiting
+// and then custom filters.
+var httpFilterRanks = map[string]int{
+	duplicateHeadersFilterName:           headerFilterRank,
+	jwtAuthnFilterName:                   authnFilterRank,
+	"envoy.filters.http.oauth2":          authnFilterRank,
+	wellknown.HTTPExternalAuthorization:  authzFilterRank,
+	wellknown.HTTPRoleBasedAccessControl: authzFilterRank,
+	csrfFilterName:                       authzFilterRank,
+	wellknown.HTTPRateLimit:              rateLimitFilterRank,
+	"envoy.filters.http.local_ratelimit": rateLimitFilterRank,
+	bandwidthLimitFilterName:             rateLimitFilterRank,
+}
+
+// httpFilterRank returns the rank of the HTTP filter with the provided name.
+// Filters added per route are named after the filter type followed by a slash
+// and the name of the route, and are ranked by their filter type.
+func httpFilterRank(name string) int {
+	if i := strings.Index(name, "/"); i >= 0 {
+		name = name[:i]
+	}
+	if rank, ok := httpFilterRanks[name]; ok {
+		return rank
+	}
+	return customFilterRank
+}
+
+// sortHTTPFilters returns the HTTP filters in their default order, with the
+// filter positions applied in order on top of it. Filters of the same rank keep
+// their relative order, and the router filter is always last since filters
+// after it are never invoked. Positions referencing filters that are not
+// configured are ignored.
+func sortHTTPFilters(filters []*hcm.HttpFilter, order []*ir.FilterPosition) []*hcm.HttpFilter {
+	var sorted []*hcm.HttpFilter
+	var routers []*hcm.HttpFilter
+	for _, filter := range filters {
+		if filter.Name == wellknown.Router {
+			routers = append(routers, filter)
+		} else {
+			sorted = append(sorted, filter)
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return httpFilterRank(sorted[i].Name) < httpFilterRank(sorted[j].Name)
+	})
+
+	for _, position := range order {
+		if position == nil {
+			continue
+		}
+		sorted = moveHTTPFilter(sorted, position)
+	}
+
+	return append(sorted, routers...)
+}
+
+// moveHTTPFilter moves the HTTP filter named by the position right before or
+// after its reference filter. The filters are returned unchanged if either of
+// them is not found.
+func moveHTTPFilter(filters []*hcm.HttpFilter, position *ir.FilterPosition) []*hcm.HttpFilter {
+	var reference string
+	switch {
+	case position.Before != nil:
+		reference = *position.Before
+	case position.After != nil:
+		reference = *position.After
+	default:
+		return filters
+	}
+	if reference == position.Name {
+		return filters
+	}
+
+	from := httpFilterIndex(filters, position.Name)
+	if from < 0 || httpFilterIndex(filters, reference) < 0 {
+		return filters
+	}
+
+	filter := filters[from]
+	moved := make([]*hcm.HttpFilter, 0, len(filters))
+	moved = append(moved, filters[:from]...)
+	moved = append(moved, filters[from+1:]...)
+
+	to := httpFilterIndex(moved, reference)
+	if position.After != nil {
+		to++
+	}
+	moved = append(moved[:to], append([]*hcm.HttpFilter{filter}, moved[to:]...)...)
+
+	return moved
+}
+
+// httpFilterIndex returns the index of the HTTP filter with the provided name,
+// or -1 if not found.
+func httpFilterIndex(filters []*hcm.HttpFilter, name string) int {
+	for i, filter := range filters {
+		if filter.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// disableXdsRouteFiltersOnOtherRoutes sets the provided per-route
+// configuration, disabling the filter, on the routes sharing the HTTP
+// connection manager with the filters of the provided filter type, named after
+// the filter type optionally followed by a slash and a suffix, except on the
+// routes configuring the filter themselves.
+func disableXdsRouteFiltersOnOtherRoutes(tCtx *types.ResourceVersionTable, filterType string, disabledAny *anypb.Any) error {
+	for _, r := range tCtx.XdsResources[resource.ListenerType] {
+		xdsListener := r.(*listener.Listener)
+		filterChains := xdsListener.FilterChains
+		if xdsListener.DefaultFilterChain != nil {
+			filterChains = append([]*listener.FilterChain{xdsListener.DefaultFilterChain}, filterChains...)
+		}
+
+		for _, filterChain := range filterChains {
+			for _, filter := range filterChain.Filters {
+				if filter.Name != wellknown.HTTPConnectionManager {
+					continue
+				}
+				mgr := new(hcm.HttpConnectionManager)
+				if err := filter.GetTypedConfig().UnmarshalTo(mgr); err != nil {
+					return err
+				}
+
+				var routeFilters []string
+				for _, httpFilter := range mgr.HttpFilters {
+					if httpFilter.Name == filterType || strings.HasPrefix(httpFilter.Name, filterType+"/") {
+						routeFilters = append(routeFilters, httpFilter.Name)
+					}
+				}
+				if len(routeFilters) == 0 {
+					continue
+				}
+
+				xdsRouteCfg := findXdsRouteConfig(tCtx, mgr.GetRds().GetRouteConfigName())
+				if xdsRouteCfg == nil {
+					return errors.New("unable to find xds route config")
+				}
+				for _, vHost := range xdsRouteCfg.VirtualHosts {
+					for _, xdsRoute := range vHost.Routes {
+						for _, name := range routeFilters {
+							if _, ok := xdsRoute.TypedPerFilterConfig[name]; ok {
+								continue
+							}
+							if xdsRoute.TypedPerFilterConfig == nil {
+								xdsRoute.TypedPerFilterConfig = map[string]*anypb.Any{}
+							}
+							xdsRoute.TypedPerFilterConfig[name] = disabledAny
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"testing"
+
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/stretchr/testify/require"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func TestSortHTTPFilters(t *testing.T) {
+	lua := "envoy.filters.http.lua"
+	jwt := "envoy.filters.http.jwt_authn"
+	extAuthz := wellknown.HTTPExternalAuthorization
+	ratelimit := wellknown.HTTPRateLimit
+	router := wellknown.Router
+	missing := "envoy.filters.http.missing"
+
+	testCases := []struct {
+		name    string
+		filters []string
+		order   []*ir.FilterPosition
+		want    []string
+	}{
+		{
+			name:    "default order",
+			filters: []string{router, lua, ratelimit, extAuthz, jwt},
+			want:    []string{jwt, extAuthz, ratelimit, lua, router},
+		},
+		{
+			name:    "move before",
+			filters: []string{jwt, extAuthz, lua, router},
+			order:   []*ir.FilterPosition{{Name: lua, Before: &extAuthz}},
+			want:    []string{jwt, lua, extAuthz, router},
+		},
+		{
+			name:    "move after",
+			filters: []string{jwt, extAuthz, lua, router},
+			order:   []*ir.FilterPosition{{Name: jwt, After: &extAuthz}},
+			want:    []string{extAuthz, jwt, lua, router},
+		},
+		{
+			name:    "positions applied in order",
+			filters: []string{jwt, extAuthz, lua, router},
+			order: []*ir.FilterPosition{
+				{Name: lua, Before: &jwt},
+				{Name: extAuthz, Before: &lua},
+			},
+			want: []string{extAuthz, lua, jwt, router},
+		},
+		{
+			name:    "router stays last",
+			filters: []string{lua, router},
+			order:   []*ir.FilterPosition{{Name: lua, After: &router}},
+			want:    []string{lua, router},
+		},
+		{
+			name:    "missing filters are ignored",
+			filters: []string{jwt, lua, router},
+			order: []*ir.FilterPosition{
+				{Name: missing, Before: &jwt},
+				{Name: lua, Before: &missing},
+			},
+			want: []string{jwt, lua, router},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			filters := make([]*hcm.HttpFilter, 0, len(tc.filters))
+			for _, name := range tc.filters {
+				filters = append(filters, &hcm.HttpFilter{Name: name})
+			}
+
+			var got []string
+			for _, filter := range sortHTTPFilters(filters, tc.order) {
+				got = append(got, filter.Name)
+			}
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
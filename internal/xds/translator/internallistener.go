@@ -0,0 +1,79 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"fmt"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// internalListenerName returns the name of the internal listener processing
+// the connections forwarded to the HTTP listener.
+func internalListenerName(httpListenerName string) string {
+	return httpListenerName + "-internal"
+}
+
+// buildXdsInternalAddress returns the address of the internal listener.
+func buildXdsInternalAddress(internalListenerName string) *core.Address {
+	return &core.Address{
+		Address: &core.Address_EnvoyInternalAddress{
+			EnvoyInternalAddress: &core.EnvoyInternalAddress{
+				AddressNameSpecifier: &core.EnvoyInternalAddress_ServerListenerName{
+					ServerListenerName: internalListenerName,
+				},
+			},
+		},
+	}
+}
+
+// addXdsInternalListener adds the internal listener processing the connections
+// forwarded to the HTTP listener, unless it already exists. The internal
+// listener shares the filter chain of the HTTP listener, and so its routes.
+func addXdsInternalListener(tCtx *types.ResourceVersionTable, httpListeners []*ir.HTTPListener, httpListenerName string) error {
+	name := internalListenerName(httpListenerName)
+	for _, r := range tCtx.XdsResources[resource.ListenerType] {
+		if r.(*listener.Listener).Name == name {
+			return nil
+		}
+	}
+
+	var httpListener *ir.HTTPListener
+	for _, l := range httpListeners {
+		if l.Name == httpListenerName {
+			httpListener = l
+			break
+		}
+	}
+	if httpListener == nil {
+		return fmt.Errorf("http listener %s not found", httpListenerName)
+	}
+	// The connections are forwarded once TLS is terminated, so only the
+	// plain text filter chain can process them.
+	if httpListener.TLS != nil {
+		return fmt.Errorf("http listener %s must not terminate tls", httpListenerName)
+	}
+
+	xdsListener := findXdsListener(tCtx, httpListener.Address, httpListener.Port, core.SocketAddress_TCP)
+	if xdsListener == nil || xdsListener.DefaultFilterChain == nil {
+		return fmt.Errorf("unable to find the filter chain of http listener %s", httpListenerName)
+	}
+
+	tCtx.AddXdsResource(resource.ListenerType, &listener.Listener{
+		Name: name,
+		ListenerSpecifier: &listener.Listener_InternalListener{
+			InternalListener: &listener.Listener_InternalListenerConfig{},
+		},
+		DefaultFilterChain: proto.Clone(xdsListener.DefaultFilterChain).(*listener.FilterChain),
+	})
+	return nil
+}
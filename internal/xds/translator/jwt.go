@@ -0,0 +1,371 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	rbacconfig "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	jwtauthn "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
+	rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+const (
+	// jwtAuthnFilterName is the name of the JWT authentication HTTP filter,
+	// which is also the namespace of the JWT payloads in the dynamic metadata.
+	jwtAuthnFilterName = "envoy.filters.http.jwt_authn"
+	// jwtAuthorizationPolicyName is the name of the RBAC policy authorizing
+	// the requests based on the claims of their JWT.
+	jwtAuthorizationPolicyName = "jwt-authorization"
+	// jwtScopeClaim is the space-delimited claim holding the scopes of a JWT.
+	jwtScopeClaim = "scope"
+	// envoyTrustBundle is the path of the system CA certificates of the
+	// Envoy image, used to verify the JWKS servers.
+	envoyTrustBundle = "/etc/ssl/certs/ca-certificates.crt"
+)
+
+// jwtProviderKey returns the key of the JWT provider of the route within the
+// JWT authentication filter, which is shared by all the routes of a listener.
+func jwtProviderKey(routeName, providerName string) string {
+	return fmt.Sprintf("%s/%s", routeName, providerName)
+}
+
+// jwksEndpoint holds the address of the server of a remote JWKS.
+type jwksEndpoint struct {
+	host string
+	port uint32
+	tls  bool
+}
+
+// parseJWKSURI returns the endpoint of the server of the remote JWKS URI,
+// defaulting to the well known port of its scheme.
+func parseJWKSURI(uri string) (*jwksEndpoint, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	ep := &jwksEndpoint{host: u.Hostname(), tls: u.Scheme == "https"}
+	if ep.host == "" {
+		return nil, fmt.Errorf("jwks uri %s has no host", uri)
+	}
+	switch {
+	case u.Port() != "":
+		port, err := strconv.ParseUint(u.Port(), 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("jwks uri %s has an invalid port: %w", uri, err)
+		}
+		ep.port = uint32(port)
+	case ep.tls:
+		ep.port = 443
+	default:
+		ep.port = 80
+	}
+	return ep, nil
+}
+
+// clusterName returns the name of the cluster of the JWKS server, shared by
+// all the providers fetching their JWKS from it.
+func (e *jwksEndpoint) clusterName() string {
+	return fmt.Sprintf("jwks_%s_%d", e.host, e.port)
+}
+
+// buildJWKSCluster returns the cluster of the JWKS server, resolving its host
+// through DNS and verifying it against the system CA certificates if it is
+// served over HTTPS.
+func buildJWKSCluster(ep *jwksEndpoint) (*cluster.Cluster, error) {
+	xdsCluster := &cluster.Cluster{
+		Name:                 ep.clusterName(),
+		ConnectTimeout:       durationpb.New(5 * time.Second),
+		ClusterDiscoveryType: &cluster.Cluster_Type{Type: cluster.Cluster_STRICT_DNS},
+		LbPolicy:             cluster.Cluster_ROUND_ROBIN,
+		DnsLookupFamily:      cluster.Cluster_V4_ONLY,
+		LoadAssignment: &endpoint.ClusterLoadAssignment{
+			ClusterName: ep.clusterName(),
+			Endpoints: []*endpoint.LocalityLbEndpoints{{
+				LbEndpoints: buildXdsEndpoints([]*ir.RouteDestination{{
+					Host: ep.host,
+					Port: ep.port,
+				}}),
+			}},
+		},
+	}
+
+	if ep.tls {
+		tlsCtxAny, err := anypb.New(&tls.UpstreamTlsContext{
+			Sni: ep.host,
+			CommonTlsContext: &tls.CommonTlsContext{
+				ValidationContextType: &tls.CommonTlsContext_ValidationContext{
+					ValidationContext: &tls.CertificateValidationContext{
+						TrustedCa: &core.DataSource{
+							Specifier: &core.DataSource_Filename{Filename: envoyTrustBundle},
+						},
+					},
+				},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		xdsCluster.TransportSocket = &core.TransportSocket{
+			Name:       wellknown.TransportSocketTls,
+			ConfigType: &core.TransportSocket_TypedConfig{TypedConfig: tlsCtxAny},
+		}
+	}
+
+	return xdsCluster, nil
+}
+
+// buildJWKSClusters returns the clusters of the JWKS servers of the route.
+func buildJWKSClusters(jwt *ir.JWT) ([]*cluster.Cluster, error) {
+	var clusters []*cluster.Cluster
+	for _, provider := range jwt.Providers {
+		ep, err := parseJWKSURI(provider.JWKSURI)
+		if err != nil {
+			return nil, err
+		}
+		xdsCluster, err := buildJWKSCluster(ep)
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, xdsCluster)
+	}
+	return clusters, nil
+}
+
+// addXdsJWTAuthn enables the JWT authentication of the route on the filter
+// chain of the listener, and adds the clusters of its JWKS servers if missing.
+func addXdsJWTAuthn(tCtx *types.ResourceVersionTable, xdsListener *listener.Listener, httpListener *ir.HTTPListener,
+	filterChainName string, httpRoute *ir.HTTPRoute) error {
+	filterChain := findXdsHTTPFilterChain(xdsListener, httpListener, filterChainName)
+	if err := patchXdsHCM(filterChain, func(mgr *hcm.HttpConnectionManager) error {
+		return patchJWTAuthn(mgr, httpRoute, httpListener.FilterOrder)
+	}); err != nil {
+		return err
+	}
+
+	clusters, err := buildJWKSClusters(httpRoute.JWT)
+	if err != nil {
+		return err
+	}
+	for _, xdsCluster := range clusters {
+		if findXdsCluster(tCtx, xdsCluster.Name) == nil {
+			tCtx.AddXdsResource(resource.ClusterType, xdsCluster)
+		}
+	}
+	return nil
+}
+
+// patchJWTAuthn adds the JWT providers of the route, and the requirement the
+// route references, to the JWT authentication filter of the HTTP connection
+// manager. The filter is added if missing, along with a RBAC filter enforcing
+// the per-route authorization rules if the route defines any.
+func patchJWTAuthn(mgr *hcm.HttpConnectionManager, httpRoute *ir.HTTPRoute, order []*ir.FilterPosition) error {
+	jwtAuthn := &jwtauthn.JwtAuthentication{
+		Providers:      map[string]*jwtauthn.JwtProvider{},
+		RequirementMap: map[string]*jwtauthn.JwtRequirement{},
+	}
+	jwtFilterIdx := httpFilterIndex(mgr.HttpFilters, jwtAuthnFilterName)
+	if jwtFilterIdx >= 0 {
+		if err := mgr.HttpFilters[jwtFilterIdx].GetTypedConfig().UnmarshalTo(jwtAuthn); err != nil {
+			return err
+		}
+	}
+
+	var requirements []*jwtauthn.JwtRequirement
+	for _, provider := range httpRoute.JWT.Providers {
+		ep, err := parseJWKSURI(provider.JWKSURI)
+		if err != nil {
+			return err
+		}
+		key := jwtProviderKey(httpRoute.Name, provider.Name)
+		jwtAuthn.Providers[key] = &jwtauthn.JwtProvider{
+			Issuer:    provider.Issuer,
+			Audiences: provider.Audiences,
+			JwksSourceSpecifier: &jwtauthn.JwtProvider_RemoteJwks{
+				RemoteJwks: &jwtauthn.RemoteJwks{
+					HttpUri: &core.HttpUri{
+						Uri:              provider.JWKSURI,
+						HttpUpstreamType: &core.HttpUri_Cluster{Cluster: ep.clusterName()},
+						Timeout:          durationpb.New(5 * time.Second),
+					},
+				},
+			},
+			PayloadInMetadata: provider.Name,
+		}
+		requirements = append(requirements, &jwtauthn.JwtRequirement{
+			RequiresType: &jwtauthn.JwtRequirement_ProviderName{ProviderName: key},
+		})
+	}
+	requirement := requirements[0]
+	if len(requirements) > 1 {
+		requirement = &jwtauthn.JwtRequirement{
+			RequiresType: &jwtauthn.JwtRequirement_RequiresAny{
+				RequiresAny: &jwtauthn.JwtRequirementOrList{Requirements: requirements},
+			},
+		}
+	}
+	jwtAuthn.RequirementMap[httpRoute.Name] = requirement
+
+	jwtAuthnAny, err := anypb.New(jwtAuthn)
+	if err != nil {
+		return err
+	}
+	jwtFilter := &hcm.HttpFilter{
+		Name:       jwtAuthnFilterName,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: jwtAuthnAny},
+	}
+	if jwtFilterIdx >= 0 {
+		mgr.HttpFilters[jwtFilterIdx] = jwtFilter
+	} else {
+		mgr.HttpFilters = append(mgr.HttpFilters, jwtFilter)
+	}
+
+	// The RBAC filter allows all the requests, unless the route overrides its
+	// rules.
+	if httpRoute.JWT.Authorization != nil && httpFilterIndex(mgr.HttpFilters, wellknown.HTTPRoleBasedAccessControl) < 0 {
+		rbacAny, err := anypb.New(&rbac.RBAC{})
+		if err != nil {
+			return err
+		}
+		mgr.HttpFilters = append(mgr.HttpFilters, &hcm.HttpFilter{
+			Name:       wellknown.HTTPRoleBasedAccessControl,
+			ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: rbacAny},
+		})
+	}
+
+	mgr.HttpFilters = sortHTTPFilters(mgr.HttpFilters, order)
+
+	return nil
+}
+
+// buildJWTPerFilterConfig returns the per-route configuration of the JWT
+// authentication filter, and of the RBAC filter if the route defines
+// authorization rules.
+func buildJWTPerFilterConfig(httpRoute *ir.HTTPRoute) (map[string]*anypb.Any, error) {
+	perFilterConfig := map[string]*anypb.Any{}
+
+	jwtAuthnAny, err := anypb.New(&jwtauthn.PerRouteConfig{
+		RequirementSpecifier: &jwtauthn.PerRouteConfig_RequirementName{RequirementName: httpRoute.Name},
+	})
+	if err != nil {
+		return nil, err
+	}
+	perFilterConfig[jwtAuthnFilterName] = jwtAuthnAny
+
+	if httpRoute.JWT.Authorization != nil {
+		rbacAny, err := anypb.New(&rbac.RBACPerRoute{
+			Rbac: &rbac.RBAC{
+				Rules: &rbacconfig.RBAC{
+					Action: rbacconfig.RBAC_ALLOW,
+					Policies: map[string]*rbacconfig.Policy{
+						jwtAuthorizationPolicyName: {
+							Permissions: []*rbacconfig.Permission{{
+								Rule: &rbacconfig.Permission_Any{Any: true},
+							}},
+							Principals: buildJWTPrincipals(httpRoute.JWT),
+						},
+					},
+				},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		perFilterConfig[wellknown.HTTPRoleBasedAccessControl] = rbacAny
+	}
+
+	return perFilterConfig, nil
+}
+
+// buildJWTPrincipals returns the principals authorized by the authorization
+// rules of the JWT, one per provider since the payload of the verified JWT is
+// stored under the name of its provider.
+func buildJWTPrincipals(jwt *ir.JWT) []*rbacconfig.Principal {
+	principals := make([]*rbacconfig.Principal, 0, len(jwt.Providers))
+	for _, provider := range jwt.Providers {
+		var ids []*rbacconfig.Principal
+		for _, scope := range jwt.Authorization.Scopes {
+			ids = append(ids, jwtClaimPrincipal(provider.Name, jwtScopeClaim, &matcher.ValueMatcher{
+				MatchPattern: &matcher.ValueMatcher_StringMatch{
+					StringMatch: &matcher.StringMatcher{
+						MatchPattern: &matcher.StringMatcher_SafeRegex{
+							SafeRegex: &matcher.RegexMatcher{
+								Regex: fmt.Sprintf(`(^|.* )%s( .*|$)`, regexp.QuoteMeta(scope)),
+								EngineType: &matcher.RegexMatcher_GoogleRe2{
+									GoogleRe2: &matcher.RegexMatcher_GoogleRE2{},
+								},
+							},
+						},
+					},
+				},
+			}))
+		}
+		for _, claim := range jwt.Authorization.Claims {
+			var values []*rbacconfig.Principal
+			for _, value := range claim.Values {
+				exact := &matcher.ValueMatcher{
+					MatchPattern: &matcher.ValueMatcher_StringMatch{
+						StringMatch: &matcher.StringMatcher{
+							MatchPattern: &matcher.StringMatcher_Exact{Exact: value},
+						},
+					},
+				}
+				values = append(values,
+					jwtClaimPrincipal(provider.Name, claim.Name, exact),
+					jwtClaimPrincipal(provider.Name, claim.Name, &matcher.ValueMatcher{
+						MatchPattern: &matcher.ValueMatcher_ListMatch{
+							ListMatch: &matcher.ListMatcher{
+								MatchPattern: &matcher.ListMatcher_OneOf{OneOf: exact},
+							},
+						},
+					}),
+				)
+			}
+			ids = append(ids, &rbacconfig.Principal{
+				Identifier: &rbacconfig.Principal_OrIds{OrIds: &rbacconfig.Principal_Set{Ids: values}},
+			})
+		}
+		principals = append(principals, &rbacconfig.Principal{
+			Identifier: &rbacconfig.Principal_AndIds{AndIds: &rbacconfig.Principal_Set{Ids: ids}},
+		})
+	}
+	return principals
+}
+
+// jwtClaimPrincipal returns the principal matching the claim of the payload
+// of the JWT verified by the provider against the value matcher.
+func jwtClaimPrincipal(provider, claim string, value *matcher.ValueMatcher) *rbacconfig.Principal {
+	return &rbacconfig.Principal{
+		Identifier: &rbacconfig.Principal_Metadata{
+			Metadata: &matcher.MetadataMatcher{
+				Filter: jwtAuthnFilterName,
+				Path: []*matcher.MetadataMatcher_PathSegment{
+					{Segment: &matcher.MetadataMatcher_PathSegment_Key{Key: provider}},
+					{Segment: &matcher.MetadataMatcher_PathSegment_Key{Key: claim}},
+				},
+				Value: value,
+			},
+		},
+	}
+}
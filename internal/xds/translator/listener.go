@@ -6,7 +6,9 @@
 package translator
 
 import (
+	"bytes"
 	"errors"
+	"net"
 
 	xdscore "github.com/cncf/xds/go/xds/core/v3"
 	matcher "github.com/cncf/xds/go/xds/type/matcher/v3"
@@ -21,10 +23,20 @@ import (
 	tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/envoyproxy/gateway/internal/ir"
 )
 
+// The levels and names of the socket options of Linux setting the DSCP.
+const (
+	ipprotoIP   = 0
+	ipTOS       = 1
+	ipprotoIPv6 = 41
+	ipv6TClass  = 67
+)
+
 func buildXdsTCPListener(name, address string, port uint32) *listener.Listener {
 	accesslogAny, _ := anypb.New(stdoutFileAccessLog)
 	return &listener.Listener{
@@ -44,13 +56,66 @@ func buildXdsTCPListener(name, address string, port uint32) *listener.Listener {
 					PortSpecifier: &core.SocketAddress_PortValue{
 						PortValue: port,
 					},
+					Ipv4Compat: isIPv6Any(address),
 				},
 			},
 		},
 	}
 }
 
-func addXdsHTTPFilterChain(xdsListener *listener.Listener, irListener *ir.HTTPListener) error {
+// isIPv6Any returns true if address is the IPv6 any address, in which case the
+// listener also accepts IPv4 connections to support dual-stack deployments.
+func isIPv6Any(address string) bool {
+	ip := net.ParseIP(address)
+	return ip != nil && ip.To4() == nil && ip.IsUnspecified()
+}
+
+// setXdsSocketOptions sets the options of the sockets of the listener. The
+// DSCP is set on the listening sockets, and inherited by the sockets of the
+// accepted connections.
+func setXdsSocketOptions(xdsListener *listener.Listener, options *ir.SocketOptions) {
+	if options.ReusePort != nil {
+		xdsListener.EnableReusePort = wrapperspb.Bool(*options.ReusePort)
+	}
+	if options.TCPFastOpenQueueLength > 0 {
+		xdsListener.TcpFastOpenQueueLength = wrapperspb.UInt32(options.TCPFastOpenQueueLength)
+	}
+	if options.Freebind {
+		xdsListener.Freebind = wrapperspb.Bool(true)
+	}
+	if options.DSCP == nil {
+		return
+	}
+
+	// The DSCP occupies the six most significant bits of the TOS byte, or
+	// of the traffic class of IPv6.
+	tos := int64(*options.DSCP) << 2
+	address := xdsListener.GetAddress().GetSocketAddress().GetAddress()
+	if ip := net.ParseIP(address); ip != nil && ip.To4() == nil {
+		xdsListener.SocketOptions = append(xdsListener.SocketOptions,
+			buildXdsListeningSocketOption("IPV6_TCLASS", ipprotoIPv6, ipv6TClass, tos))
+		// The dual-stack listeners also accept IPv4 connections.
+		if !isIPv6Any(address) {
+			return
+		}
+	}
+	xdsListener.SocketOptions = append(xdsListener.SocketOptions,
+		buildXdsListeningSocketOption("IP_TOS", ipprotoIP, ipTOS, tos))
+}
+
+// buildXdsListeningSocketOption returns the socket option with the provided
+// level, name and value, set on the listening sockets.
+func buildXdsListeningSocketOption(description string, level, name, value int64) *core.SocketOption {
+	return &core.SocketOption{
+		Description: description,
+		Level:       level,
+		Name:        name,
+		Value:       &core.SocketOption_IntValue{IntValue: value},
+		State:       core.SocketOption_STATE_LISTENING,
+	}
+}
+
+func addXdsHTTPFilterChain(xdsListener *listener.Listener, irListener *ir.HTTPListener, secretName string) error {
 	routerAny, err := anypb.New(&router.Router{})
 	if err != nil {
 		return err
@@ -84,13 +149,63 @@ func addXdsHTTPFilterChain(xdsListener *listener.Listener, irListener *ir.HTTPLi
 				RouteConfigName: irListener.Name,
 			},
 		},
-		// Use only router.
-		HttpFilters: []*hcm.HttpFilter{{
-			Name:       wellknown.Router,
-			ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: routerAny},
-		}},
 	}
 
+	if irListener.AccessLogSampleRate > 0 {
+		mgr.AccessLog[0].Filter = buildXdsAccessLogSampleFilter(irListener.AccessLogSampleRate)
+	}
+	if irListener.IdleTimeout != nil {
+		mgr.CommonHttpProtocolOptions = &core.HttpProtocolOptions{
+			IdleTimeout: durationpb.New(irListener.IdleTimeout.Duration),
+		}
+	}
+	if limits := irListener.HeaderLimits; limits != nil {
+		if limits.MaxRequestHeadersKiB > 0 {
+			mgr.MaxRequestHeadersKb = wrapperspb.UInt32(limits.MaxRequestHeadersKiB)
+		}
+		if limits.MaxRequestHeadersCount > 0 {
+			if mgr.CommonHttpProtocolOptions == nil {
+				mgr.CommonHttpProtocolOptions = new(core.HttpProtocolOptions)
+			}
+			mgr.CommonHttpProtocolOptions.MaxHeadersCount = wrapperspb.UInt32(limits.MaxRequestHeadersCount)
+		}
+	}
+	if draining := irListener.Draining; draining != nil && draining.Timeout != nil {
+		mgr.DrainTimeout = durationpb.New(draining.Timeout.Duration)
+	}
+	if http1 := irListener.HTTP1; http1 != nil {
+		mgr.HttpProtocolOptions = &core.Http1ProtocolOptions{
+			AcceptHttp_10:         http1.EnableHTTP10,
+			DefaultHostForHttp_10: http1.HTTP10DefaultHost,
+		}
+	}
+	if headerKeyFormat := buildXdsHeaderKeyFormat(irListener.HeaderHandling); headerKeyFormat != nil {
+		if mgr.HttpProtocolOptions == nil {
+			mgr.HttpProtocolOptions = new(core.Http1ProtocolOptions)
+		}
+		mgr.HttpProtocolOptions.HeaderKeyFormat = headerKeyFormat
+	}
+
+	httpFilters := []*hcm.HttpFilter{{
+		Name:       wellknown.Router,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: routerAny},
+	}}
+	if irListener.RateLimitService != nil {
+		rateLimitFilter, err := buildRateLimitFilter(irListener.RateLimitService)
+		if err != nil {
+			return err
+		}
+		httpFilters = append(httpFilters, rateLimitFilter)
+	}
+	duplicateHeadersFilter, err := buildDuplicateHeadersFilter(irListener.HeaderHandling)
+	if err != nil {
+		return err
+	}
+	if duplicateHeadersFilter != nil {
+		httpFilters = append(httpFilters, duplicateHeadersFilter)
+	}
+	mgr.HttpFilters = sortHTTPFilters(httpFilters, irListener.FilterOrder)
+
 	mgrAny, err := anypb.New(mgr)
 	if err != nil {
 		return err
@@ -106,10 +221,13 @@ func addXdsHTTPFilterChain(xdsListener *listener.Listener, irListener *ir.HTTPLi
 	}
 
 	if irListener.TLS != nil {
-		tSocket, err := buildXdsDownstreamTLSSocket(irListener.Name, irListener.TLS)
+		tSocket, err := buildXdsDownstreamTLSSocket(secretName, irListener.TLS)
 		if err != nil {
 			return err
 		}
+		// The filter chains terminating TLS are named after their listener,
+		// since their SDS secret may be shared with other listeners.
+		filterChain.Name = irListener.Name
 		filterChain.TransportSocket = tSocket
 		if err := addServerNamesMatch(xdsListener, filterChain, irListener.Hostnames); err != nil {
 			return err
@@ -143,6 +261,78 @@ func addServerNamesMatch(xdsListener *listener.Listener, filterChain *listener.F
 	return nil
 }
 
+// findSharedTLSHTTPListener returns the first of the listeners the HTTPS
+// listener can share its SDS secret with, or nil if there is none. Listeners
+// can share a secret if they use the same certificate, private key and OCSP
+// staple.
+func findSharedTLSHTTPListener(listeners []*ir.HTTPListener, httpListener *ir.HTTPListener) *ir.HTTPListener {
+	if httpListener.TLS == nil {
+		return nil
+	}
+
+	for _, l := range listeners {
+		if l.TLS == nil {
+			continue
+		}
+		if bytes.Equal(l.TLS.ServerCertificate, httpListener.TLS.ServerCertificate) &&
+			bytes.Equal(l.TLS.PrivateKey, httpListener.TLS.PrivateKey) &&
+			bytes.Equal(l.TLS.OCSPStaple, httpListener.TLS.OCSPStaple) {
+			return l
+		}
+	}
+
+	return nil
+}
+
+// findXdsHTTPSFilterChain finds the filter chain terminating TLS of the
+// listener with the provided name and returns nil if not found.
+func findXdsHTTPSFilterChain(xdsListener *listener.Listener, name string) *listener.FilterChain {
+	for _, filterChain := range xdsListener.FilterChains {
+		if filterChain.GetTransportSocket() != nil && filterChain.GetName() == name {
+			return filterChain
+		}
+	}
+	return nil
+}
+
+// findXdsHTTPFilterChain finds the filter chain serving the HTTP listener,
+// which is the default filter chain of the xDS listener for HTTP traffic, or
+// the filter chain terminating TLS of the listener with the provided name.
+func findXdsHTTPFilterChain(xdsListener *listener.Listener, httpListener *ir.HTTPListener, name string) *listener.FilterChain {
+	if httpListener.TLS == nil {
+		return xdsListener.DefaultFilterChain
+	}
+	return findXdsHTTPSFilterChain(xdsListener, name)
+}
+
+// patchXdsHCM applies the patch to the HTTP connection manager of the filter
+// chain.
+func patchXdsHCM(filterChain *listener.FilterChain, patch func(mgr *hcm.HttpConnectionManager) error) error {
+	if filterChain == nil {
+		return errors.New("filter chain is nil")
+	}
+
+	for _, filter := range filterChain.Filters {
+		if filter.Name != wellknown.HTTPConnectionManager {
+			continue
+		}
+		mgr := new(hcm.HttpConnectionManager)
+		if err := filter.GetTypedConfig().UnmarshalTo(mgr); err != nil {
+			return err
+		}
+		if err := patch(mgr); err != nil {
+			return err
+		}
+		mgrAny, err := anypb.New(mgr)
+		if err != nil {
+			return err
+		}
+		filter.ConfigType = &listener.Filter_TypedConfig{TypedConfig: mgrAny}
+		return nil
+	}
+	return errors.New("http connection manager not found")
+}
+
 // findXdsHTTPRouteConfigName finds the name of the route config associated with the
 // http connection manager within the default filter chain and returns an empty string if
 // not found.
@@ -167,32 +357,46 @@ func findXdsHTTPRouteConfigName(xdsListener *listener.Listener) string {
 	return ""
 }
 
-func addXdsTCPFilterChain(xdsListener *listener.Listener, irListener *ir.TCPListener, clusterName string) error {
+func addXdsTCPFilterChain(xdsListener *listener.Listener, irListener *ir.TCPListener, clusters []*weightedCluster) error {
 	if irListener == nil {
 		return errors.New("tcp listener is nil")
 	}
+	if len(clusters) == 0 {
+		return errors.New("tcp listener has no clusters")
+	}
 
 	statPrefix := "tcp"
-	if irListener.TLS != nil {
+	if irListener.TerminateTLS != nil {
+		statPrefix = "terminate"
+	} else if irListener.TLS != nil {
 		statPrefix = "passthrough"
 	}
 
-	accesslogAny, err := anypb.New(stdoutFileAccessLog)
+	accessLog, err := buildXdsL4AccessLog(irListener.Name, irListener.JSONAccessLog, true)
 	if err != nil {
 		return err
 	}
 
 	mgr := &tcp.TcpProxy{
-		AccessLog: []*accesslog.AccessLog{
-			{
-				Name:       wellknown.FileAccessLog,
-				ConfigType: &accesslog.AccessLog_TypedConfig{TypedConfig: accesslogAny},
-			},
-		},
+		AccessLog:  accessLog,
 		StatPrefix: statPrefix,
-		ClusterSpecifier: &tcp.TcpProxy_Cluster{
-			Cluster: clusterName,
-		},
+	}
+	if len(clusters) == 1 {
+		mgr.ClusterSpecifier = &tcp.TcpProxy_Cluster{
+			Cluster: clusters[0].cluster.Name,
+		}
+	} else {
+		// Split the connections across the clusters according to their weights.
+		weightedClusters := &tcp.TcpProxy_WeightedCluster{}
+		for _, c := range clusters {
+			weightedClusters.Clusters = append(weightedClusters.Clusters, &tcp.TcpProxy_WeightedCluster_ClusterWeight{
+				Name:   c.cluster.Name,
+				Weight: c.weight,
+			})
+		}
+		mgr.ClusterSpecifier = &tcp.TcpProxy_WeightedClusters{
+			WeightedClusters: weightedClusters,
+		}
 	}
 	mgrAny, err := anypb.New(mgr)
 	if err != nil {
@@ -208,7 +412,25 @@ func addXdsTCPFilterChain(xdsListener *listener.Listener, irListener *ir.TCPList
 		}},
 	}
 
+	if irListener.TerminateTLS != nil {
+		tSocket, err := buildXdsDownstreamTLSSocket(irListener.Name, irListener.TerminateTLS)
+		if err != nil {
+			return err
+		}
+		filterChain.TransportSocket = tSocket
+	}
+
 	if irListener.TLS != nil {
+		// Connections with a server name not matching any other filter chain
+		// are routed by the catch-all filter chain, if any.
+		if len(irListener.TLS.SNIs) > 0 && irListener.TLS.SNIs[0] == "*" {
+			if xdsListener.DefaultFilterChain != nil {
+				return errors.New("default filter chain already exists")
+			}
+			xdsListener.DefaultFilterChain = filterChain
+			return nil
+		}
+
 		if err := addServerNamesMatch(xdsListener, filterChain, irListener.TLS.SNIs); err != nil {
 			return err
 		}
@@ -257,6 +479,7 @@ func buildXdsDownstreamTLSSocket(listenerName string,
 				SdsConfig: makeConfigSource(),
 			}},
 		},
+		OcspStaplePolicy: buildXdsOCSPStaplePolicy(tlsConfig.OCSPStaplePolicy),
 	}
 
 	tlsCtxAny, err := anypb.New(tlsCtx)
@@ -272,20 +495,42 @@ func buildXdsDownstreamTLSSocket(listenerName string,
 	}, nil
 }
 
+// buildXdsOCSPStaplePolicy returns the Envoy OCSP staple policy of the
+// provided policy, defaulting to lenient stapling.
+func buildXdsOCSPStaplePolicy(policy ir.OCSPStaplePolicy) tls.DownstreamTlsContext_OcspStaplePolicy {
+	switch policy {
+	case ir.OCSPStaplePolicyStrict:
+		return tls.DownstreamTlsContext_STRICT_STAPLING
+	case ir.OCSPStaplePolicyMustStaple:
+		return tls.DownstreamTlsContext_MUST_STAPLE
+	default:
+		return tls.DownstreamTlsContext_LENIENT_STAPLING
+	}
+}
+
 func buildXdsDownstreamTLSSecret(listenerName string,
 	tlsConfig *ir.TLSListenerConfig) (*tls.Secret, error) {
+	tlsCert := &tls.TlsCertificate{
+		CertificateChain: &core.DataSource{
+			Specifier: &core.DataSource_InlineBytes{InlineBytes: tlsConfig.ServerCertificate},
+		},
+		PrivateKey: &core.DataSource{
+			Specifier: &core.DataSource_InlineBytes{InlineBytes: tlsConfig.PrivateKey},
+		},
+	}
+	// The staple is delivered along with the certificate, so that refreshing
+	// it in the Secret does not require updating the listener.
+	if len(tlsConfig.OCSPStaple) > 0 {
+		tlsCert.OcspStaple = &core.DataSource{
+			Specifier: &core.DataSource_InlineBytes{InlineBytes: tlsConfig.OCSPStaple},
+		}
+	}
+
 	// Build the tls secret
 	return &tls.Secret{
 		Name: listenerName,
 		Type: &tls.Secret_TlsCertificate{
-			TlsCertificate: &tls.TlsCertificate{
-				CertificateChain: &core.DataSource{
-					Specifier: &core.DataSource_InlineBytes{InlineBytes: tlsConfig.ServerCertificate},
-				},
-				PrivateKey: &core.DataSource{
-					Specifier: &core.DataSource_InlineBytes{InlineBytes: tlsConfig.PrivateKey},
-				},
-			},
+			TlsCertificate: tlsCert,
 		},
 	}, nil
 }
@@ -295,50 +540,21 @@ func buildXdsUDPListener(clusterName string, udpListener *ir.UDPListener) (*list
 		return nil, errors.New("udp listener is nil")
 	}
 
-	statPrefix := "service"
-
-	route := &udp.Route{
-		Cluster: clusterName,
-	}
-	routeAny, err := anypb.New(route)
-	if err != nil {
-		return nil, err
-	}
-	accesslogAny, _ := anypb.New(stdoutFileAccessLog)
-	udpProxy := &udp.UdpProxyConfig{
-		StatPrefix: statPrefix,
-		AccessLog: []*accesslog.AccessLog{
-			{
-				Name:       wellknown.FileAccessLog,
-				ConfigType: &accesslog.AccessLog_TypedConfig{TypedConfig: accesslogAny},
-			},
-		},
-		RouteSpecifier: &udp.UdpProxyConfig_Matcher{
-			Matcher: &matcher.Matcher{
-				OnNoMatch: &matcher.Matcher_OnMatch{
-					OnMatch: &matcher.Matcher_OnMatch_Action{
-						Action: &xdscore.TypedExtensionConfig{
-							TypedConfig: routeAny,
-						},
-					},
-				},
-			},
-		},
+	var filter *listener.Filter
+	var err error
+	if udpListener.DNS != nil {
+		filter, err = buildXdsDNSFilter(udpListener)
+	} else {
+		filter, err = buildXdsUDPProxyFilter(clusterName, udpListener)
 	}
-	udpProxyAny, err := anypb.New(udpProxy)
 	if err != nil {
 		return nil, err
 	}
-
 	filterChain := &listener.FilterChain{
-		Filters: []*listener.Filter{{
-			Name: "envoy.filters.udp_listener.udp_proxy",
-			ConfigType: &listener.Filter_TypedConfig{
-				TypedConfig: udpProxyAny,
-			},
-		}},
+		Filters: []*listener.Filter{filter},
 	}
 
+	accesslogAny, _ := anypb.New(stdoutFileAccessLog)
 	xdsListener := &listener.Listener{
 		Name: udpListener.Name,
 		AccessLog: []*accesslog.AccessLog{
@@ -355,6 +571,7 @@ func buildXdsUDPListener(clusterName string, udpListener *ir.UDPListener) (*list
 					PortSpecifier: &core.SocketAddress_PortValue{
 						PortValue: udpListener.Port,
 					},
+					Ipv4Compat: isIPv6Any(udpListener.Address),
 				},
 			},
 		},
@@ -363,3 +580,57 @@ func buildXdsUDPListener(clusterName string, udpListener *ir.UDPListener) (*list
 
 	return xdsListener, nil
 }
+
+// buildXdsUDPProxyFilter returns the filter of the UDP listener proxying the
+// datagrams to the cluster with the provided name.
+func buildXdsUDPProxyFilter(clusterName string, udpListener *ir.UDPListener) (*listener.Filter, error) {
+	statPrefix := "service"
+
+	route := &udp.Route{
+		Cluster: clusterName,
+	}
+	routeAny, err := anypb.New(route)
+	if err != nil {
+		return nil, err
+	}
+	accessLog, err := buildXdsL4AccessLog(udpListener.Name, udpListener.JSONAccessLog, false)
+	if err != nil {
+		return nil, err
+	}
+	udpProxy := &udp.UdpProxyConfig{
+		StatPrefix: statPrefix,
+		AccessLog:  accessLog,
+		RouteSpecifier: &udp.UdpProxyConfig_Matcher{
+			Matcher: &matcher.Matcher{
+				OnNoMatch: &matcher.Matcher_OnMatch{
+					OnMatch: &matcher.Matcher_OnMatch_Action{
+						Action: &xdscore.TypedExtensionConfig{
+							TypedConfig: routeAny,
+						},
+					},
+				},
+			},
+		},
+	}
+	// The sessions are pinned to a destination by hashing their source IP,
+	// e.g. for DTLS or game traffic.
+	if udpListener.LoadBalancer.IsConsistentHash() {
+		udpProxy.HashPolicies = []*udp.UdpProxyConfig_HashPolicy{{
+			PolicySpecifier: &udp.UdpProxyConfig_HashPolicy_SourceIp{SourceIp: true},
+		}}
+	}
+	if udpListener.IdleTimeout != nil {
+		udpProxy.IdleTimeout = durationpb.New(udpListener.IdleTimeout.Duration)
+	}
+	udpProxyAny, err := anypb.New(udpProxy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &listener.Filter{
+		Name: "envoy.filters.udp_listener.udp_proxy",
+		ConfigType: &listener.Filter_TypedConfig{
+			TypedConfig: udpProxyAny,
+		},
+	}, nil
+}
@@ -0,0 +1,85 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// lbMetadataNamespace is the metadata namespace of the endpoints read by the
+// load balancers of Envoy.
+const lbMetadataNamespace = "envoy.lb"
+
+// setXdsLoadBalancer sets the load balancing policy of the cluster.
+func setXdsLoadBalancer(xdsCluster *cluster.Cluster, lb *ir.LoadBalancer) {
+	if lb == nil {
+		return
+	}
+
+	switch lb.Type {
+	case ir.LeastRequestLoadBalancer:
+		xdsCluster.LbPolicy = cluster.Cluster_LEAST_REQUEST
+	case ir.RandomLoadBalancer:
+		xdsCluster.LbPolicy = cluster.Cluster_RANDOM
+	case ir.RingHashLoadBalancer:
+		xdsCluster.LbPolicy = cluster.Cluster_RING_HASH
+		if lb.MinimumRingSize != nil || lb.MaximumRingSize != nil {
+			ringHash := &cluster.Cluster_RingHashLbConfig{}
+			if lb.MinimumRingSize != nil {
+				ringHash.MinimumRingSize = wrapperspb.UInt64(*lb.MinimumRingSize)
+			}
+			if lb.MaximumRingSize != nil {
+				ringHash.MaximumRingSize = wrapperspb.UInt64(*lb.MaximumRingSize)
+			}
+			xdsCluster.LbConfig = &cluster.Cluster_RingHashLbConfig_{RingHashLbConfig: ringHash}
+		}
+	case ir.MaglevLoadBalancer:
+		xdsCluster.LbPolicy = cluster.Cluster_MAGLEV
+		if lb.TableSize != nil {
+			xdsCluster.LbConfig = &cluster.Cluster_MaglevLbConfig_{
+				MaglevLbConfig: &cluster.Cluster_MaglevLbConfig{TableSize: wrapperspb.UInt64(*lb.TableSize)},
+			}
+		}
+	}
+}
+
+// buildXdsHashPolicy returns the hash policy of the requests of a route
+// balanced by a consistent hashing load balancer, hashing the header of the
+// load balancer, or the source IP of the requests.
+func buildXdsHashPolicy(lb *ir.LoadBalancer) []*route.RouteAction_HashPolicy {
+	if lb.HashHeader != "" {
+		return []*route.RouteAction_HashPolicy{{
+			PolicySpecifier: &route.RouteAction_HashPolicy_Header_{
+				Header: &route.RouteAction_HashPolicy_Header{HeaderName: lb.HashHeader},
+			},
+		}}
+	}
+	return []*route.RouteAction_HashPolicy{{
+		PolicySpecifier: &route.RouteAction_HashPolicy_ConnectionProperties_{
+			ConnectionProperties: &route.RouteAction_HashPolicy_ConnectionProperties{SourceIp: true},
+		},
+	}}
+}
+
+// buildXdsEndpointHashKeyMetadata returns the metadata of an endpoint hashed
+// on the provided key by the consistent hashing load balancers.
+func buildXdsEndpointHashKeyMetadata(hashKey string) *core.Metadata {
+	return &core.Metadata{
+		FilterMetadata: map[string]*structpb.Struct{
+			lbMetadataNamespace: {
+				Fields: map[string]*structpb.Value{
+					"hash_key": structpb.NewStringValue(hashKey),
+				},
+			},
+		},
+	}
+}
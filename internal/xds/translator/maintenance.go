@@ -0,0 +1,37 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// maintenanceStatusCode is the status code of the responses to the requests
+// to the listeners in maintenance mode.
+const maintenanceStatusCode = 503
+
+// buildXdsMaintenanceRoute returns the route answering all the requests to the
+// listener in maintenance mode.
+func buildXdsMaintenanceRoute(httpListener *ir.HTTPListener) (*route.Route, error) {
+	prefix := "/"
+	maintenanceRoute := &ir.HTTPRoute{
+		Name:      httpListener.Name + "-maintenance",
+		PathMatch: &ir.StringMatch{Prefix: &prefix},
+		DirectResponse: &ir.DirectResponse{
+			StatusCode: maintenanceStatusCode,
+			Body:       httpListener.Maintenance.Body,
+		},
+	}
+	if httpListener.Maintenance.Body != nil && httpListener.Maintenance.ContentType != "" {
+		maintenanceRoute.AddResponseHeaders = []ir.AddHeader{{
+			Name:  "content-type",
+			Value: httpListener.Maintenance.ContentType,
+		}}
+	}
+	return buildXdsRoute(maintenanceRoute)
+}
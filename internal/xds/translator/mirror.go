@@ -0,0 +1,177 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"fmt"
+
+	accesslog "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	router "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/router/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// mirrorClusterName returns the name of the cluster of the mirror of the
+// route with the provided index.
+func mirrorClusterName(routeName string, idx int) string {
+	return fmt.Sprintf("%s-mirror-%d", routeName, idx)
+}
+
+// shadowClusterName returns the name of the cluster forwarding the requests
+// tagged by a mirror to the internal listener tagging and logging them.
+func shadowClusterName(mirrorClusterName string) string {
+	return mirrorClusterName + "-shadow"
+}
+
+// buildXdsRequestMirrorPolicies returns the policies mirroring the requests of
+// the route to the clusters of its mirrors. The requests of the mirrors
+// tagging them are mirrored to their shadow clusters instead.
+func buildXdsRequestMirrorPolicies(httpRoute *ir.HTTPRoute) []*route.RouteAction_RequestMirrorPolicy {
+	policies := make([]*route.RouteAction_RequestMirrorPolicy, 0, len(httpRoute.Mirrors))
+	for i, mirror := range httpRoute.Mirrors {
+		cluster := mirrorClusterName(httpRoute.Name, i)
+		if mirror.ShadowHeader != "" {
+			cluster = shadowClusterName(cluster)
+		}
+		policies = append(policies, &route.RouteAction_RequestMirrorPolicy{Cluster: cluster})
+	}
+	return policies
+}
+
+// addXdsMirrors adds the clusters of the mirrors of the route. The requests of
+// the mirrors tagging them are mirrored to a shadow cluster forwarding them to
+// an internal listener, which tags and logs them before forwarding them to the
+// mirror cluster, since the mirrored requests are not processed by the HTTP
+// filters and the access logs of the HTTP listener.
+func addXdsMirrors(tCtx *types.ResourceVersionTable, httpRoute *ir.HTTPRoute, isHTTP2 bool) error {
+	for i, mirror := range httpRoute.Mirrors {
+		clusterName := mirrorClusterName(httpRoute.Name, i)
+		xdsCluster, err := buildXdsCluster(clusterName, mirror.Destinations, isHTTP2)
+		if err != nil {
+			return err
+		}
+		tCtx.AddXdsResource(resource.ClusterType, xdsCluster)
+
+		if mirror.ShadowHeader == "" {
+			continue
+		}
+		// The shadow cluster forwards the requests to the internal listener
+		// named after it.
+		shadowName := shadowClusterName(clusterName)
+		shadowCluster, err := buildXdsCluster(shadowName, []*ir.RouteDestination{{InternalListener: shadowName}}, false /*isHTTP2 */)
+		if err != nil {
+			return err
+		}
+		tCtx.AddXdsResource(resource.ClusterType, shadowCluster)
+
+		shadowListener, err := buildXdsShadowListener(httpRoute.Name, clusterName, mirror.ShadowHeader)
+		if err != nil {
+			return err
+		}
+		tCtx.AddXdsResource(resource.ListenerType, shadowListener)
+	}
+	return nil
+}
+
+// buildXdsShadowListener returns the internal listener of the shadow cluster
+// of the mirror cluster with the provided name. It sets the shadow header of
+// the mirrored requests to their request ID, which is the one of the original
+// requests, and logs them before forwarding them to the mirror cluster.
+func buildXdsShadowListener(routeName, mirrorClusterName, shadowHeader string) (*listener.Listener, error) {
+	shadowName := shadowClusterName(mirrorClusterName)
+
+	routerAny, err := anypb.New(&router.Router{})
+	if err != nil {
+		return nil, err
+	}
+	accessLog, err := buildXdsShadowAccessLog(routeName)
+	if err != nil {
+		return nil, err
+	}
+
+	mgr := &hcm.HttpConnectionManager{
+		AccessLog:  accessLog,
+		CodecType:  hcm.HttpConnectionManager_AUTO,
+		StatPrefix: "shadow",
+		// The mirrored requests keep the request ID of the original requests.
+		PreserveExternalRequestId: true,
+		RouteSpecifier: &hcm.HttpConnectionManager_RouteConfig{
+			RouteConfig: &route.RouteConfiguration{
+				Name: shadowName,
+				VirtualHosts: []*route.VirtualHost{{
+					Name:    shadowName,
+					Domains: []string{"*"},
+					Routes: []*route.Route{{
+						Match: &route.RouteMatch{
+							PathSpecifier: &route.RouteMatch_Prefix{Prefix: "/"},
+						},
+						Action: &route.Route_Route{Route: buildXdsRouteAction(mirrorClusterName)},
+						RequestHeadersToAdd: []*core.HeaderValueOption{{
+							Header: &core.HeaderValue{
+								Key:   shadowHeader,
+								Value: "%REQ(X-REQUEST-ID)%",
+							},
+							Append: &wrapperspb.BoolValue{Value: false},
+						}},
+					}},
+				}},
+			},
+		},
+		HttpFilters: []*hcm.HttpFilter{{
+			Name:       wellknown.Router,
+			ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: routerAny},
+		}},
+	}
+	mgrAny, err := anypb.New(mgr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &listener.Listener{
+		Name: internalListenerName(shadowName),
+		ListenerSpecifier: &listener.Listener_InternalListener{
+			InternalListener: &listener.Listener_InternalListenerConfig{},
+		},
+		DefaultFilterChain: &listener.FilterChain{
+			Filters: []*listener.Filter{{
+				Name:       wellknown.HTTPConnectionManager,
+				ConfigType: &listener.Filter_TypedConfig{TypedConfig: mgrAny},
+			}},
+		},
+	}, nil
+}
+
+// buildXdsShadowAccessLog returns the JSON access log of the requests mirrored
+// by the route with the provided name. The entries carry the request ID of the
+// original requests, so that they can be paired with the entries of the
+// access log of the HTTP listener.
+func buildXdsShadowAccessLog(routeName string) ([]*accesslog.AccessLog, error) {
+	return buildXdsJSONAccessLog(map[string]interface{}{
+		"start_time":       "%START_TIME%",
+		"shadow":           true,
+		"route":            routeName,
+		"request_id":       "%REQ(X-REQUEST-ID)%",
+		"method":           "%REQ(:METHOD)%",
+		"path":             "%REQ(X-ENVOY-ORIGINAL-PATH?:PATH)%",
+		"authority":        "%REQ(:AUTHORITY)%",
+		"response_code":    "%RESPONSE_CODE%",
+		"response_flags":   "%RESPONSE_FLAGS%",
+		"bytes_received":   "%BYTES_RECEIVED%",
+		"bytes_sent":       "%BYTES_SENT%",
+		"duration":         "%DURATION%",
+		"upstream_cluster": "%UPSTREAM_CLUSTER%",
+		"upstream_host":    "%UPSTREAM_HOST%",
+	})
+}
@@ -0,0 +1,62 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"testing"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestAddXdsMirrors(t *testing.T) {
+	httpRoute := &ir.HTTPRoute{
+		Name: "mirror-route",
+		Mirrors: []*ir.Mirror{
+			{Destinations: []*ir.RouteDestination{{Host: "1.1.1.1", Port: 8080}}},
+			{Destinations: []*ir.RouteDestination{{Host: "2.2.2.2", Port: 8080}}, ShadowHeader: "x-shadow-of"},
+		},
+	}
+
+	policies := buildXdsRequestMirrorPolicies(httpRoute)
+	require.Len(t, policies, 2)
+	require.Equal(t, "mirror-route-mirror-0", policies[0].Cluster)
+	require.Equal(t, "mirror-route-mirror-1-shadow", policies[1].Cluster)
+
+	tCtx := new(types.ResourceVersionTable)
+	require.NoError(t, addXdsMirrors(tCtx, httpRoute, false))
+
+	var clusterNames []string
+	for _, r := range tCtx.XdsResources[resource.ClusterType] {
+		clusterNames = append(clusterNames, r.(*clusterv3.Cluster).Name)
+	}
+	require.Equal(t, []string{"mirror-route-mirror-0", "mirror-route-mirror-1", "mirror-route-mirror-1-shadow"}, clusterNames)
+
+	// The shadow cluster forwards the requests to the internal listener.
+	shadowCluster := tCtx.XdsResources[resource.ClusterType][2].(*clusterv3.Cluster)
+	address := shadowCluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().Address
+	require.Equal(t, "mirror-route-mirror-1-shadow-internal", address.GetEnvoyInternalAddress().GetServerListenerName())
+
+	require.Len(t, tCtx.XdsResources[resource.ListenerType], 1)
+	xdsListener := tCtx.XdsResources[resource.ListenerType][0].(*listenerv3.Listener)
+	require.Equal(t, "mirror-route-mirror-1-shadow-internal", xdsListener.Name)
+	require.NotNil(t, xdsListener.GetInternalListener())
+
+	mgr := &hcm.HttpConnectionManager{}
+	require.NoError(t, xdsListener.DefaultFilterChain.Filters[0].GetTypedConfig().UnmarshalTo(mgr))
+	require.True(t, mgr.PreserveExternalRequestId)
+	require.Len(t, mgr.AccessLog, 1)
+	xdsRoute := mgr.GetRouteConfig().VirtualHosts[0].Routes[0]
+	require.Equal(t, "mirror-route-mirror-1", xdsRoute.GetRoute().GetCluster())
+	require.Equal(t, "x-shadow-of", xdsRoute.RequestHeadersToAdd[0].Header.Key)
+	require.Equal(t, "%REQ(X-REQUEST-ID)%", xdsRoute.RequestHeadersToAdd[0].Header.Value)
+}
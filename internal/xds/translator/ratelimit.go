@@ -0,0 +1,74 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"time"
+
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	ratelimitconfig "github.com/envoyproxy/go-control-plane/envoy/config/ratelimit/v3"
+	ratelimitfilter "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ratelimit/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// rateLimitClusterName is the name of the cluster of the global rate limit service.
+const rateLimitClusterName = "ratelimit_cluster"
+
+// buildRateLimitFilter returns the HTTP filter calling the global rate limit
+// service through the rate limit cluster.
+func buildRateLimitFilter(service *ir.RateLimitService) (*hcm.HttpFilter, error) {
+	rateLimitAny, err := anypb.New(&ratelimitfilter.RateLimit{
+		Domain:          service.Domain,
+		FailureModeDeny: service.FailClosed,
+		RateLimitService: &ratelimitconfig.RateLimitServiceConfig{
+			GrpcService: &core.GrpcService{
+				TargetSpecifier: &core.GrpcService_EnvoyGrpc_{
+					EnvoyGrpc: &core.GrpcService_EnvoyGrpc{
+						ClusterName: rateLimitClusterName,
+					},
+				},
+			},
+			TransportApiVersion: core.ApiVersion_V3,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcm.HttpFilter{
+		Name:       wellknown.HTTPRateLimit,
+		ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: rateLimitAny},
+	}, nil
+}
+
+// buildRateLimitCluster returns the cluster of the global rate limit service,
+// resolving its host through DNS since the service is addressed by name.
+func buildRateLimitCluster(service *ir.RateLimitService) *cluster.Cluster {
+	return &cluster.Cluster{
+		Name:                 rateLimitClusterName,
+		ConnectTimeout:       durationpb.New(5 * time.Second),
+		ClusterDiscoveryType: &cluster.Cluster_Type{Type: cluster.Cluster_STRICT_DNS},
+		LbPolicy:             cluster.Cluster_ROUND_ROBIN,
+		DnsLookupFamily:      cluster.Cluster_V4_ONLY,
+		LoadAssignment: &endpoint.ClusterLoadAssignment{
+			ClusterName: rateLimitClusterName,
+			Endpoints: []*endpoint.LocalityLbEndpoints{{
+				LbEndpoints: buildXdsEndpoints([]*ir.RouteDestination{{
+					Host: service.Host,
+					Port: service.Port,
+				}}),
+			}},
+		},
+		Http2ProtocolOptions: &core.Http2ProtocolOptions{},
+	}
+}
@@ -9,6 +9,9 @@ import (
 	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/envoyproxy/gateway/internal/ir"
@@ -20,11 +23,14 @@ func buildXdsRoute(httpRoute *ir.HTTPRoute) (*route.Route, error) {
 	}
 
 	if len(httpRoute.AddRequestHeaders) > 0 {
-		ret.RequestHeadersToAdd = buildXdsAddedRequestHeaders(httpRoute.AddRequestHeaders)
+		ret.RequestHeadersToAdd = buildXdsAddedHeaders(httpRoute.AddRequestHeaders)
 	}
 	if len(httpRoute.RemoveRequestHeaders) > 0 {
 		ret.RequestHeadersToRemove = httpRoute.RemoveRequestHeaders
 	}
+	if len(httpRoute.AddResponseHeaders) > 0 {
+		ret.ResponseHeadersToAdd = buildXdsAddedHeaders(httpRoute.AddResponseHeaders)
+	}
 
 	switch {
 	case httpRoute.DirectResponse != nil:
@@ -32,12 +38,103 @@ func buildXdsRoute(httpRoute *ir.HTTPRoute) (*route.Route, error) {
 	case httpRoute.Redirect != nil:
 		ret.Action = &route.Route_Redirect{Redirect: buildXdsRedirectAction(httpRoute.Redirect)}
 	default:
-		if httpRoute.BackendWeights.Invalid != 0 {
-			// If there are invalid backends then a weighted cluster is required for the route
-			ret.Action = &route.Route_Route{Route: buildXdsWeightedRouteAction(httpRoute)}
+		var routeAction *route.RouteAction
+		if requiresClusterPerDestination(httpRoute) {
+			// If the destinations modify the requests differently then a weighted
+			// cluster per destination is required for the route
+			routeAction = buildXdsDestinationsRouteAction(httpRoute)
 		} else {
-			ret.Action = &route.Route_Route{Route: buildXdsRouteAction(httpRoute.Name)}
+			if httpRoute.BackendWeights.Invalid != 0 {
+				// If there are invalid backends then a weighted cluster is required for the route
+				routeAction = buildXdsWeightedRouteAction(httpRoute)
+			} else {
+				routeAction = buildXdsRouteAction(httpRoute.Name)
+			}
+			// All the destinations rewrite the Host header to the same value.
+			if len(httpRoute.Destinations) > 0 && httpRoute.Destinations[0].HostRewrite != "" {
+				routeAction.HostRewriteSpecifier = &route.RouteAction_HostRewriteLiteral{
+					HostRewriteLiteral: httpRoute.Destinations[0].HostRewrite,
+				}
+			}
+		}
+		if httpRoute.Timeout != nil {
+			routeAction.Timeout = durationpb.New(httpRoute.Timeout.Duration)
+		}
+		if httpRoute.LoadBalancer.IsConsistentHash() {
+			routeAction.HashPolicy = buildXdsHashPolicy(httpRoute.LoadBalancer)
+		}
+		if len(httpRoute.Mirrors) > 0 {
+			routeAction.RequestMirrorPolicies = buildXdsRequestMirrorPolicies(httpRoute)
+		}
+		ret.Action = &route.Route_Route{Route: routeAction}
+	}
+
+	if httpRoute.JWT != nil {
+		perFilterConfig, err := buildJWTPerFilterConfig(httpRoute)
+		if err != nil {
+			return nil, err
+		}
+		ret.TypedPerFilterConfig = perFilterConfig
+	}
+	if httpRoute.ExtAuth != nil {
+		extAuthAny, err := buildExtAuthPerRouteConfig()
+		if err != nil {
+			return nil, err
+		}
+		if ret.TypedPerFilterConfig == nil {
+			ret.TypedPerFilterConfig = map[string]*anypb.Any{}
+		}
+		ret.TypedPerFilterConfig[extAuthFilterName(httpRoute)] = extAuthAny
+	}
+	if httpRoute.ExtProc != nil {
+		extProcAny, err := buildExtProcPerRouteConfig(httpRoute.ExtProc)
+		if err != nil {
+			return nil, err
+		}
+		if ret.TypedPerFilterConfig == nil {
+			ret.TypedPerFilterConfig = map[string]*anypb.Any{}
+		}
+		ret.TypedPerFilterConfig[extProcFilterName(httpRoute.ExtProc.FailOpen)] = extProcAny
+	}
+	if httpRoute.CSRF != nil {
+		csrfAny, err := buildCSRFPerRouteConfig(httpRoute.CSRF)
+		if err != nil {
+			return nil, err
+		}
+		if ret.TypedPerFilterConfig == nil {
+			ret.TypedPerFilterConfig = map[string]*anypb.Any{}
+		}
+		ret.TypedPerFilterConfig[csrfFilterName] = csrfAny
+	}
+	if httpRoute.Transformation != nil {
+		transformationAny, err := buildTransformationPerRouteConfig(httpRoute.Transformation)
+		if err != nil {
+			return nil, err
+		}
+		if ret.TypedPerFilterConfig == nil {
+			ret.TypedPerFilterConfig = map[string]*anypb.Any{}
+		}
+		ret.TypedPerFilterConfig[wellknown.Lua] = transformationAny
+	}
+	if httpRoute.BandwidthLimit != nil {
+		bandwidthLimitAny, err := buildBandwidthLimitPerRouteConfig(httpRoute.Name, httpRoute.BandwidthLimit)
+		if err != nil {
+			return nil, err
+		}
+		if ret.TypedPerFilterConfig == nil {
+			ret.TypedPerFilterConfig = map[string]*anypb.Any{}
 		}
+		ret.TypedPerFilterConfig[bandwidthLimitFilterName] = bandwidthLimitAny
+	}
+	if httpRoute.DynamicForwardProxy != nil && httpRoute.DynamicForwardProxy.HostHeader != "" {
+		dfpAny, err := buildDynamicForwardProxyPerRouteConfig(httpRoute.DynamicForwardProxy)
+		if err != nil {
+			return nil, err
+		}
+		if ret.TypedPerFilterConfig == nil {
+			ret.TypedPerFilterConfig = map[string]*anypb.Any{}
+		}
+		ret.TypedPerFilterConfig[dynamicForwardProxyFilterName] = dfpAny
 	}
 
 	return ret, nil
@@ -167,6 +264,59 @@ func buildXdsWeightedRouteAction(httpRoute *ir.HTTPRoute) *route.RouteAction {
 	}
 }
 
+// buildXdsDestinationsRouteAction builds a route action splitting the requests
+// across a weighted cluster per destination, each modifying the requests
+// forwarded to it. Requests for invalid backends are routed to a
+// non-existent cluster, in the same proportion as the invalid backends weights.
+// The weights are overridden by the runtime keys made of the runtime weights
+// key prefix of the route and the names of the clusters, when set.
+func buildXdsDestinationsRouteAction(httpRoute *ir.HTTPRoute) *route.RouteAction {
+	var clusters []*route.WeightedCluster_ClusterWeight
+	if httpRoute.BackendWeights.Invalid != 0 {
+		clusters = append(clusters, &route.WeightedCluster_ClusterWeight{
+			Name:   "invalid-backend-cluster",
+			Weight: &wrapperspb.UInt32Value{Value: httpRoute.BackendWeights.Invalid},
+		})
+	}
+	// The draining destinations receive no new requests, unless all the
+	// destinations are draining, in which case the clusters of the draining
+	// destinations reject the requests themselves.
+	allDraining := allDrainingDestinations(httpRoute.Destinations)
+	for i, destination := range httpRoute.Destinations {
+		weight := destinationWeight(destination)
+		if destination.Draining && !allDraining {
+			weight = 0
+		}
+		clusterWeight := &route.WeightedCluster_ClusterWeight{
+			Name:   destinationClusterName(httpRoute.Name, i),
+			Weight: &wrapperspb.UInt32Value{Value: weight},
+		}
+		if len(destination.AddRequestHeaders) > 0 {
+			clusterWeight.RequestHeadersToAdd = buildXdsAddedHeaders(destination.AddRequestHeaders)
+		}
+		if len(destination.RemoveRequestHeaders) > 0 {
+			clusterWeight.RequestHeadersToRemove = destination.RemoveRequestHeaders
+		}
+		if destination.HostRewrite != "" {
+			clusterWeight.HostRewriteSpecifier = &route.WeightedCluster_ClusterWeight_HostRewriteLiteral{
+				HostRewriteLiteral: destination.HostRewrite,
+			}
+		}
+		clusters = append(clusters, clusterWeight)
+	}
+
+	return &route.RouteAction{
+		// Intentionally route to a non-existent cluster and return a 500 error when it is not found
+		ClusterNotFoundResponseCode: route.RouteAction_INTERNAL_SERVER_ERROR,
+		ClusterSpecifier: &route.RouteAction_WeightedClusters{
+			WeightedClusters: &route.WeightedCluster{
+				Clusters:         clusters,
+				RuntimeKeyPrefix: httpRoute.RuntimeWeightsKeyPrefix,
+			},
+		},
+	}
+}
+
 func buildXdsRedirectAction(redirection *ir.Redirect) *route.RedirectAction {
 	ret := &route.RedirectAction{}
 
@@ -215,7 +365,7 @@ func buildXdsDirectResponseAction(res *ir.DirectResponse) *route.DirectResponseA
 	return ret
 }
 
-func buildXdsAddedRequestHeaders(headersToAdd []ir.AddHeader) []*core.HeaderValueOption {
+func buildXdsAddedHeaders(headersToAdd []ir.AddHeader) []*core.HeaderValueOption {
 	ret := make([]*core.HeaderValueOption, len(headersToAdd))
 
 	for i, header := range headersToAdd {
@@ -8,6 +8,7 @@ package runner
 import (
 	"context"
 
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/message"
@@ -18,6 +19,9 @@ type Config struct {
 	config.Server
 	XdsIR *message.XdsIR
 	Xds   *message.Xds
+	// RejectedSnapshots holds the keys whose current snapshot was rejected
+	// by their proxies, whose updates are translated first, if set.
+	RejectedSnapshots *message.RejectedSnapshots
 }
 
 type Runner struct {
@@ -34,15 +38,16 @@ func (r *Runner) Name() string {
 
 // Start starts the xds-translator runner
 func (r *Runner) Start(ctx context.Context) error {
-	r.Logger = r.Logger.WithValues("runner", r.Name())
+	r.Logger = r.ComponentLogger(v1alpha1.LogComponentXds).WithValues("runner", r.Name())
 	go r.subscribeAndTranslate(ctx)
 	r.Logger.Info("started")
 	return nil
 }
 
 func (r *Runner) subscribeAndTranslate(ctx context.Context) {
-	// Subscribe to resources
-	message.HandleSubscription(r.XdsIR.Subscribe(ctx),
+	// Subscribe to resources, translating the updates of the IRs whose
+	// proxies rejected their current snapshot first.
+	message.HandleSubscriptionByPriority(r.XdsIR.Subscribe(ctx), r.RejectedSnapshots.IsRejected,
 		func(update message.Update[string, *ir.Xds]) {
 			r.Logger.Info("received an update")
 			key := update.Key
@@ -0,0 +1,56 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"sort"
+	"strings"
+
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// namedResource is implemented by all the xDS resources.
+type namedResource interface {
+	GetName() string
+}
+
+// sortXdsResources sorts the xDS resources of each type by name, the filter
+// chains of the listeners by their server names, and the virtual hosts of the
+// route configurations by name, so that the same IR is always translated into
+// the same output, and config dumps and snapshot diffs are stable. Envoy does
+// not depend on the order of these resources, unlike the order of the HTTP
+// filters or of the routes of a virtual host, which are kept as is.
+func sortXdsResources(tCtx *types.ResourceVersionTable) {
+	for _, resources := range tCtx.XdsResources {
+		sort.SliceStable(resources, func(i, j int) bool {
+			return resources[i].(namedResource).GetName() < resources[j].(namedResource).GetName()
+		})
+	}
+
+	for _, r := range tCtx.XdsResources[resource.ListenerType] {
+		filterChains := r.(*listener.Listener).FilterChains
+		sort.SliceStable(filterChains, func(i, j int) bool {
+			return filterChainKey(filterChains[i]) < filterChainKey(filterChains[j])
+		})
+	}
+
+	for _, r := range tCtx.XdsResources[resource.RouteType] {
+		vHosts := r.(*route.RouteConfiguration).VirtualHosts
+		sort.SliceStable(vHosts, func(i, j int) bool {
+			return vHosts[i].Name < vHosts[j].Name
+		})
+	}
+}
+
+// filterChainKey returns the key the filter chains of a listener are sorted by:
+// the server names they match on, which are unique within a listener.
+func filterChainKey(filterChain *listener.FilterChain) string {
+	return strings.Join(filterChain.GetFilterChainMatch().GetServerNames(), ",")
+}
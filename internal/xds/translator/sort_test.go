@@ -0,0 +1,52 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"testing"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestSortXdsResources(t *testing.T) {
+	tCtx := new(types.ResourceVersionTable)
+	tCtx.AddXdsResource(resource.ClusterType, &clusterv3.Cluster{Name: "second-route"})
+	tCtx.AddXdsResource(resource.ClusterType, &clusterv3.Cluster{Name: "first-route"})
+	tCtx.AddXdsResource(resource.ListenerType, &listenerv3.Listener{
+		Name: "first-listener",
+		FilterChains: []*listenerv3.FilterChain{
+			{FilterChainMatch: &listenerv3.FilterChainMatch{ServerNames: []string{"foo.com"}}},
+			{FilterChainMatch: &listenerv3.FilterChainMatch{ServerNames: []string{"bar.com", "baz.com"}}},
+		},
+	})
+	tCtx.AddXdsResource(resource.RouteType, &routev3.RouteConfiguration{
+		Name: "first-listener",
+		VirtualHosts: []*routev3.VirtualHost{
+			{Name: "second-listener"},
+			{Name: "first-listener"},
+		},
+	})
+
+	sortXdsResources(tCtx)
+
+	clusters := tCtx.XdsResources[resource.ClusterType]
+	require.Equal(t, "first-route", clusters[0].(*clusterv3.Cluster).Name)
+	require.Equal(t, "second-route", clusters[1].(*clusterv3.Cluster).Name)
+
+	filterChains := tCtx.XdsResources[resource.ListenerType][0].(*listenerv3.Listener).FilterChains
+	require.Equal(t, []string{"bar.com", "baz.com"}, filterChains[0].FilterChainMatch.ServerNames)
+	require.Equal(t, []string{"foo.com"}, filterChains[1].FilterChainMatch.ServerNames)
+
+	vHosts := tCtx.XdsResources[resource.RouteType][0].(*routev3.RouteConfiguration).VirtualHosts
+	require.Equal(t, "first-listener", vHosts[0].Name)
+	require.Equal(t, "second-listener", vHosts[1].Name)
+}
@@ -0,0 +1,40 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"sort"
+
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// overflowStatsName is the stat name shared by the clusters over the stats
+// budget of a Gateway.
+const overflowStatsName = "stats_overflow"
+
+// limitXdsClusterStats makes the clusters over the provided maximum, in the
+// order of their names, report their stats under the shared overflow stat
+// name, bounding the cardinality of the stats of the proxies. If max is zero,
+// all the clusters report their own stats.
+func limitXdsClusterStats(tCtx *types.ResourceVersionTable, max uint32) {
+	if max == 0 || uint32(len(tCtx.XdsResources[resource.ClusterType])) <= max {
+		return
+	}
+
+	clusters := make([]*cluster.Cluster, 0, len(tCtx.XdsResources[resource.ClusterType]))
+	for _, r := range tCtx.XdsResources[resource.ClusterType] {
+		clusters = append(clusters, r.(*cluster.Cluster))
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Name < clusters[j].Name
+	})
+	for _, c := range clusters[max:] {
+		c.AltStatName = overflowStatsName
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"testing"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestLimitXdsClusterStats(t *testing.T) {
+	newTable := func() *types.ResourceVersionTable {
+		tCtx := new(types.ResourceVersionTable)
+		for _, name := range []string{"route-c", "route-a", "route-b"} {
+			tCtx.AddXdsResource(resource.ClusterType, &clusterv3.Cluster{Name: name})
+		}
+		return tCtx
+	}
+	altStatNames := func(tCtx *types.ResourceVersionTable) map[string]string {
+		names := make(map[string]string)
+		for _, r := range tCtx.XdsResources[resource.ClusterType] {
+			c := r.(*clusterv3.Cluster)
+			names[c.Name] = c.AltStatName
+		}
+		return names
+	}
+
+	// The clusters are not limited without a budget, or within it.
+	for _, max := range []uint32{0, 3} {
+		tCtx := newTable()
+		limitXdsClusterStats(tCtx, max)
+		require.Equal(t, map[string]string{"route-a": "", "route-b": "", "route-c": ""}, altStatNames(tCtx))
+	}
+
+	// The clusters over the budget share the overflow stats.
+	tCtx := newTable()
+	limitXdsClusterStats(tCtx, 1)
+	require.Equal(t, map[string]string{"route-a": "", "route-b": overflowStatsName, "route-c": overflowStatsName}, altStatNames(tCtx))
+}
@@ -0,0 +1,221 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"fmt"
+	"strings"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	luav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// transformationHelpers are the Lua functions used by the transformation
+// scripts to manipulate the query parameters and bodies.
+const transformationHelpers = `local function url_encode(s)
+  return (string.gsub(s, "[^%w%-%._~]", function(c) return string.format("%%%02X", string.byte(c)) end))
+end
+
+local function url_decode(s)
+  s = string.gsub(s, "+", " ")
+  return (string.gsub(s, "%%(%x%x)", function(h) return string.char(tonumber(h, 16)) end))
+end
+
+local function split_path(path)
+  local i = string.find(path, "?", 1, true)
+  if i == nil then
+    return path, {}
+  end
+  local params = {}
+  for pair in string.gmatch(string.sub(path, i + 1), "[^&]+") do
+    table.insert(params, pair)
+  end
+  return string.sub(path, 1, i - 1), params
+end
+
+local function join_path(path, params)
+  if #params == 0 then
+    return path
+  end
+  return path .. "?" .. table.concat(params, "&")
+end
+
+local function split_param(pair)
+  local i = string.find(pair, "=", 1, true)
+  if i == nil then
+    return url_decode(pair), ""
+  end
+  return url_decode(string.sub(pair, 1, i - 1)), string.sub(pair, i + 1)
+end
+
+local function get_param(params, name)
+  for _, pair in ipairs(params) do
+    local key, value = split_param(pair)
+    if key == name then
+      return url_decode(value)
+    end
+  end
+  return nil
+end
+
+local function remove_param(params, name)
+  local kept = {}
+  for _, pair in ipairs(params) do
+    if split_param(pair) ~= name then
+      table.insert(kept, pair)
+    end
+  end
+  return kept
+end
+
+local function set_param(params, name, value)
+  params = remove_param(params, name)
+  table.insert(params, url_encode(name) .. "=" .. url_encode(value))
+  return params
+end
+
+local function set_body(handle, headers, render)
+  local body = handle:body()
+  if body == nil then
+    return
+  end
+  local rendered = render(body:getBytes(0, body:length()))
+  body:setBytes(rendered)
+  if headers:get("content-length") ~= nil then
+    headers:replace("content-length", tostring(#rendered))
+  end
+end
+`
+
+// addXdsTransformation adds the Lua filter running the transformation
+// scripts to the filter chain of the listener if missing. The script of the
+// filter does nothing, and is replaced by the script of the routes
+// transforming their requests or responses.
+func addXdsTransformation(xdsListener *listener.Listener, httpListener *ir.HTTPListener, filterChainName string) error {
+	filterChain := findXdsHTTPFilterChain(xdsListener, httpListener, filterChainName)
+	return patchXdsHCM(filterChain, func(mgr *hcm.HttpConnectionManager) error {
+		if httpFilterIndex(mgr.HttpFilters, wellknown.Lua) >= 0 {
+			return nil
+		}
+		luaAny, err := anypb.New(&luav3.Lua{
+			InlineCode: "-- The transformations are configured per route.\n",
+		})
+		if err != nil {
+			return err
+		}
+		mgr.HttpFilters = append(mgr.HttpFilters, &hcm.HttpFilter{
+			Name:       wellknown.Lua,
+			ConfigType: &hcm.HttpFilter_TypedConfig{TypedConfig: luaAny},
+		})
+		mgr.HttpFilters = sortHTTPFilters(mgr.HttpFilters, httpListener.FilterOrder)
+		return nil
+	})
+}
+
+// buildTransformationPerRouteConfig returns the per-route configuration of the
+// Lua filter running the transformation script of the route.
+func buildTransformationPerRouteConfig(transformation *ir.Transformation) (*anypb.Any, error) {
+	return anypb.New(&luav3.LuaPerRoute{
+		Override: &luav3.LuaPerRoute_SourceCode{
+			SourceCode: &core.DataSource{
+				Specifier: &core.DataSource_InlineString{
+					InlineString: buildTransformationScript(transformation),
+				},
+			},
+		},
+	})
+}
+
+// buildTransformationScript returns the Lua script applying the provided
+// transformation.
+func buildTransformationScript(transformation *ir.Transformation) string {
+	var b strings.Builder
+	b.WriteString(transformationHelpers)
+
+	b.WriteString("\nfunction envoy_on_request(handle)\n")
+	b.WriteString("  local headers = handle:headers()\n")
+	if len(transformation.HeadersToQuery) > 0 || len(transformation.QueryToHeaders) > 0 || transformation.RequestBody != nil {
+		b.WriteString("  local path, params = split_path(headers:get(\":path\"))\n")
+	}
+	for _, mapping := range transformation.HeadersToQuery {
+		fmt.Fprintf(&b, "  do\n    local value = headers:get(%s)\n    if value ~= nil then\n", luaQuote(mapping.Header))
+		fmt.Fprintf(&b, "      params = set_param(params, %s, value)\n", luaQuote(mapping.QueryParam))
+		if mapping.Remove {
+			fmt.Fprintf(&b, "      headers:remove(%s)\n", luaQuote(mapping.Header))
+		}
+		b.WriteString("    end\n  end\n")
+	}
+	for _, mapping := range transformation.QueryToHeaders {
+		fmt.Fprintf(&b, "  do\n    local value = get_param(params, %s)\n    if value ~= nil then\n", luaQuote(mapping.QueryParam))
+		fmt.Fprintf(&b, "      headers:replace(%s, value)\n", luaQuote(mapping.Header))
+		if mapping.Remove {
+			fmt.Fprintf(&b, "      params = remove_param(params, %s)\n", luaQuote(mapping.QueryParam))
+		}
+		b.WriteString("    end\n  end\n")
+	}
+	if len(transformation.HeadersToQuery) > 0 || len(transformation.QueryToHeaders) > 0 {
+		b.WriteString("  headers:replace(\":path\", join_path(path, params))\n")
+	}
+	if transformation.RequestBody != nil {
+		fmt.Fprintf(&b, "  set_body(handle, headers, function(body)\n    return %s\n  end)\n", luaTemplate(transformation.RequestBody))
+	}
+	b.WriteString("end\n")
+
+	if transformation.ResponseBody != nil {
+		b.WriteString("\nfunction envoy_on_response(handle)\n")
+		b.WriteString("  local headers = handle:headers()\n")
+		fmt.Fprintf(&b, "  set_body(handle, headers, function(body)\n    return %s\n  end)\n", luaTemplate(transformation.ResponseBody))
+		b.WriteString("end\n")
+	}
+
+	return b.String()
+}
+
+// luaTemplate returns the Lua expression rendering the provided body template,
+// in a scope holding the original body, the headers and the query parameters.
+func luaTemplate(tmpl *ir.BodyTemplate) string {
+	parts := make([]string, 0, len(tmpl.Parts))
+	for _, part := range tmpl.Parts {
+		switch {
+		case part.Body:
+			parts = append(parts, "body")
+		case part.Header != "":
+			parts = append(parts, fmt.Sprintf("(headers:get(%s) or \"\")", luaQuote(part.Header)))
+		case part.QueryParam != "":
+			parts = append(parts, fmt.Sprintf("(get_param(params, %s) or \"\")", luaQuote(part.QueryParam)))
+		default:
+			parts = append(parts, luaQuote(part.Literal))
+		}
+	}
+	return "table.concat({" + strings.Join(parts, ", ") + "})"
+}
+
+// luaQuote returns the Lua string literal of the provided string. The bytes
+// outside of the printable ASCII range are escaped with their decimal value.
+func luaQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20 || c > 0x7e:
+			fmt.Fprintf(&b, "\\%03d", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
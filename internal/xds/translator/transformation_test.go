@@ -0,0 +1,49 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func TestBuildTransformationScript(t *testing.T) {
+	script := buildTransformationScript(&ir.Transformation{
+		HeadersToQuery: []ir.HeaderQueryMapping{{Header: "x-api-key", QueryParam: "api_key", Remove: true}},
+		QueryToHeaders: []ir.HeaderQueryMapping{{Header: "x-version", QueryParam: "v"}},
+		RequestBody: &ir.BodyTemplate{Parts: []ir.TemplatePart{
+			{Literal: `{"page": "`},
+			{QueryParam: "page"},
+			{Literal: `", "data": `},
+			{Body: true},
+			{Literal: "}"},
+		}},
+	})
+
+	require.Contains(t, script, `params = set_param(params, "api_key", value)`)
+	require.Contains(t, script, `headers:remove("x-api-key")`)
+	require.Contains(t, script, `headers:replace("x-version", value)`)
+	require.NotContains(t, script, `params = remove_param(params, "v")`)
+	require.Contains(t, script, `headers:replace(":path", join_path(path, params))`)
+	require.Contains(t, script, `return table.concat({"{\"page\": \"", (get_param(params, "page") or ""), "\", \"data\": ", body, "}"})`)
+	require.NotContains(t, script, "envoy_on_response")
+
+	script = buildTransformationScript(&ir.Transformation{
+		ResponseBody: &ir.BodyTemplate{Parts: []ir.TemplatePart{{Header: "x-request-id"}}},
+	})
+	require.NotContains(t, script, "split_path(headers")
+	require.Contains(t, script, "function envoy_on_response(handle)")
+	require.Contains(t, script, `return table.concat({(headers:get("x-request-id") or "")})`)
+}
+
+func TestLuaQuote(t *testing.T) {
+	require.Equal(t, `"plain"`, luaQuote("plain"))
+	require.Equal(t, `"\"quoted\" \\ back"`, luaQuote(`"quoted" \ back`))
+	require.Equal(t, `"line\010caf\195\169"`, luaQuote("line\ncafé"))
+}
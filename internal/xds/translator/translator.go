@@ -8,6 +8,7 @@ package translator
 import (
 	"errors"
 
+	cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
@@ -15,6 +16,7 @@ import (
 	"github.com/tetratelabs/multierror"
 
 	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
 	"github.com/envoyproxy/gateway/internal/xds/types"
 )
 
@@ -26,7 +28,9 @@ func Translate(ir *ir.Xds) (*types.ResourceVersionTable, error) {
 
 	tCtx := new(types.ResourceVersionTable)
 
-	for _, httpListener := range ir.HTTP {
+	// routeCfgs holds the route configuration of each HTTP listener.
+	routeCfgs := make(map[string]*route.RouteConfiguration, len(ir.HTTP))
+	for i, httpListener := range ir.HTTP {
 		addFilterChain := true
 		var xdsRouteCfg *route.RouteConfiguration
 
@@ -34,6 +38,12 @@ func Translate(ir *ir.Xds) (*types.ResourceVersionTable, error) {
 		xdsListener := findXdsListener(tCtx, httpListener.Address, httpListener.Port, core.SocketAddress_TCP)
 		if xdsListener == nil {
 			xdsListener = buildXdsTCPListener(httpListener.Name, httpListener.Address, httpListener.Port)
+			if httpListener.Draining != nil && httpListener.Draining.ModifyOnly {
+				xdsListener.DrainType = listener.Listener_MODIFY_ONLY
+			}
+			if httpListener.SocketOptions != nil {
+				setXdsSocketOptions(xdsListener, httpListener.SocketOptions)
+			}
 			tCtx.AddXdsResource(resource.ListenerType, xdsListener)
 		} else if httpListener.TLS == nil {
 			// Find the route config associated with this listener that
@@ -51,12 +61,26 @@ func Translate(ir *ir.Xds) (*types.ResourceVersionTable, error) {
 			}
 		}
 
+		// HTTPS listeners using the same certificate, e.g. a wildcard
+		// certificate, share the SDS secret of the first of them. Each keeps its
+		// own filter chain and RouteConfiguration, so that a request can only
+		// be routed to the virtual hosts of the listener matching its SNI.
+		secretName := httpListener.Name
+		if shared := findSharedTLSHTTPListener(ir.HTTP[:i], httpListener); shared != nil {
+			secretName = shared.Name
+		}
+
 		if addFilterChain {
-			if err := addXdsHTTPFilterChain(xdsListener, httpListener); err != nil {
+			if err := addXdsHTTPFilterChain(xdsListener, httpListener, secretName); err != nil {
 				return nil, err
 			}
 		}
 
+		// The rate limit cluster is shared by all the listeners.
+		if httpListener.RateLimitService != nil && findXdsCluster(tCtx, rateLimitClusterName) == nil {
+			tCtx.AddXdsResource(resource.ClusterType, buildRateLimitCluster(httpListener.RateLimitService))
+		}
+
 		// Create a route config if we have not found one yet
 		if xdsRouteCfg == nil {
 			xdsRouteCfg = &route.RouteConfiguration{
@@ -65,8 +89,9 @@ func Translate(ir *ir.Xds) (*types.ResourceVersionTable, error) {
 			tCtx.AddXdsResource(resource.RouteType, xdsRouteCfg)
 		}
 
-		// 1:1 between IR TLSListenerConfig and xDS Secret
-		if httpListener.TLS != nil {
+		// 1:1 between IR TLSListenerConfig and xDS Secret, unless the secret
+		// is shared with another listener.
+		if httpListener.TLS != nil && secretName == httpListener.Name {
 			secret, err := buildXdsDownstreamTLSSecret(httpListener.Name, httpListener.TLS)
 			if err != nil {
 				return nil, multierror.Append(err, errors.New("error building xds listener tls secret"))
@@ -80,8 +105,22 @@ func Translate(ir *ir.Xds) (*types.ResourceVersionTable, error) {
 			Name:    httpListener.Name,
 			Domains: httpListener.Hostnames,
 		}
+		setXdsHopByHopHeaders(vHost, httpListener.HeaderHandling)
+
+		// The requests to a listener in maintenance mode are answered by a
+		// single route, so the routes of the listener and their clusters are
+		// not built.
+		httpRoutes := httpListener.Routes
+		if httpListener.Maintenance != nil {
+			xdsRoute, err := buildXdsMaintenanceRoute(httpListener)
+			if err != nil {
+				return nil, multierror.Append(err, errors.New("error building xds maintenance route"))
+			}
+			vHost.Routes = append(vHost.Routes, xdsRoute)
+			httpRoutes = nil
+		}
 
-		for _, httpRoute := range httpListener.Routes {
+		for _, httpRoute := range httpRoutes {
 			// 1:1 between IR HTTPRoute and xDS config.route.v3.Route
 			xdsRoute, err := buildXdsRoute(httpRoute)
 			if err != nil {
@@ -89,28 +128,104 @@ func Translate(ir *ir.Xds) (*types.ResourceVersionTable, error) {
 			}
 			vHost.Routes = append(vHost.Routes, xdsRoute)
 
+			if httpRoute.JWT != nil {
+				if err := addXdsJWTAuthn(tCtx, xdsListener, httpListener, httpListener.Name, httpRoute); err != nil {
+					return nil, multierror.Append(err, errors.New("error building xds jwt authentication"))
+				}
+			}
+			if httpRoute.ExtAuth != nil {
+				if err := addXdsExtAuth(tCtx, xdsListener, httpListener, httpListener.Name, httpRoute); err != nil {
+					return nil, multierror.Append(err, errors.New("error building xds external authorization"))
+				}
+			}
+			if httpRoute.ExtProc != nil {
+				if err := addXdsExtProc(tCtx, xdsListener, httpListener, httpListener.Name, httpRoute); err != nil {
+					return nil, multierror.Append(err, errors.New("error building xds external processing"))
+				}
+			}
+			if httpRoute.CSRF != nil {
+				if err := addXdsCSRF(xdsListener, httpListener, httpListener.Name); err != nil {
+					return nil, multierror.Append(err, errors.New("error building xds csrf"))
+				}
+			}
+			if httpRoute.Transformation != nil {
+				if err := addXdsTransformation(xdsListener, httpListener, httpListener.Name); err != nil {
+					return nil, multierror.Append(err, errors.New("error building xds transformation"))
+				}
+			}
+			if httpRoute.BandwidthLimit != nil {
+				if err := addXdsBandwidthLimit(xdsListener, httpListener, httpListener.Name); err != nil {
+					return nil, multierror.Append(err, errors.New("error building xds bandwidth limit"))
+				}
+			}
+			if httpRoute.DynamicForwardProxy != nil {
+				if err := addXdsDynamicForwardProxy(xdsListener, httpListener, httpListener.Name, httpRoute.DynamicForwardProxy); err != nil {
+					return nil, multierror.Append(err, errors.New("error building xds dynamic forward proxy"))
+				}
+			}
+
 			// Skip trying to build an IR cluster if the httpRoute only has invalid backends
 			if len(httpRoute.Destinations) == 0 && httpRoute.BackendWeights.Invalid > 0 {
 				continue
 			}
-			xdsCluster, err := buildXdsCluster(httpRoute.Name, httpRoute.Destinations, httpListener.IsHTTP2)
+			xdsClusters, err := buildXdsHTTPClusters(httpRoute, httpListener.IsHTTP2)
 			if err != nil {
 				return nil, multierror.Append(err, errors.New("error building xds cluster"))
 			}
-			tCtx.AddXdsResource(resource.ClusterType, xdsCluster)
-
+			for _, xdsCluster := range xdsClusters {
+				tCtx.AddXdsResource(resource.ClusterType, xdsCluster)
+			}
+			// The requests are only mirrored by the routes forwarding them.
+			if len(httpRoute.Mirrors) > 0 && httpRoute.DirectResponse == nil && httpRoute.Redirect == nil {
+				if err := addXdsMirrors(tCtx, httpRoute, httpListener.IsHTTP2); err != nil {
+					return nil, multierror.Append(err, errors.New("error building xds mirror"))
+				}
+			}
+			if httpRoute.BackendTLS != nil {
+				for _, secret := range buildXdsUpstreamTLSSecrets(httpRoute.Name, httpRoute.BackendTLS) {
+					tCtx.AddXdsResource(resource.SecretType, secret)
+				}
+			}
 		}
 
 		xdsRouteCfg.VirtualHosts = append(xdsRouteCfg.VirtualHosts, vHost)
+		routeCfgs[httpListener.Name] = xdsRouteCfg
+	}
+
+	for _, httpListener := range ir.HTTP {
+		addXdsIsolationVirtualHost(routeCfgs[httpListener.Name], httpListener)
+	}
+
+	// The external authorization and processing filters are added per route,
+	// so they are disabled on the other routes once all the routes are built.
+	if err := disableXdsExtAuthOnOtherRoutes(tCtx); err != nil {
+		return nil, multierror.Append(err, errors.New("error building xds external authorization"))
+	}
+	if err := disableXdsExtProcOnOtherRoutes(tCtx); err != nil {
+		return nil, multierror.Append(err, errors.New("error building xds external processing"))
 	}
 
 	for _, tcpListener := range ir.TCP {
-		// 1:1 between IR TCPListener and xDS Cluster
-		xdsCluster, err := buildXdsCluster(tcpListener.Name, tcpListener.Destinations, false /*isHTTP2 */)
+		// 1:1 between IR TCPListener destinations and xDS Clusters if the
+		// connections are split across multiple destinations.
+		xdsClusters, err := buildXdsTCPClusters(tcpListener.Name, tcpListener.Destinations)
 		if err != nil {
 			return nil, multierror.Append(err, errors.New("error building xds cluster"))
 		}
-		tCtx.AddXdsResource(resource.ClusterType, xdsCluster)
+		for _, xdsCluster := range xdsClusters {
+			tCtx.AddXdsResource(resource.ClusterType, xdsCluster.cluster)
+		}
+
+		// The connections forwarded to HTTP listeners are processed by their
+		// internal listeners.
+		for _, destination := range tcpListener.Destinations {
+			if destination.InternalListener == "" {
+				continue
+			}
+			if err := addXdsInternalListener(tCtx, ir.HTTP, destination.InternalListener); err != nil {
+				return nil, multierror.Append(err, errors.New("error building xds internal listener"))
+			}
+		}
 
 		// Search for an existing listener, if it does not exist, create one.
 		xdsListener := findXdsListener(tCtx, tcpListener.Address, tcpListener.Port, core.SocketAddress_TCP)
@@ -119,27 +234,45 @@ func Translate(ir *ir.Xds) (*types.ResourceVersionTable, error) {
 			tCtx.AddXdsResource(resource.ListenerType, xdsListener)
 		}
 
-		if err := addXdsTCPFilterChain(xdsListener, tcpListener, xdsCluster.Name); err != nil {
+		if err := addXdsTCPFilterChain(xdsListener, tcpListener, xdsClusters); err != nil {
 			return nil, err
 		}
+
+		// 1:1 between IR TLSListenerConfig and xDS Secret
+		if tcpListener.TerminateTLS != nil {
+			secret, err := buildXdsDownstreamTLSSecret(tcpListener.Name, tcpListener.TerminateTLS)
+			if err != nil {
+				return nil, multierror.Append(err, errors.New("error building xds listener tls secret"))
+			}
+			tCtx.AddXdsResource(resource.SecretType, secret)
+		}
 	}
 
 	for _, udpListener := range ir.UDP {
-		// 1:1 between IR UDPListener and xDS Cluster
-		xdsCluster, err := buildXdsCluster(udpListener.Name, udpListener.Destinations, false /*isHTTP2 */)
-		if err != nil {
-			return nil, multierror.Append(err, errors.New("error building xds cluster"))
+		// 1:1 between IR UDPListener and xDS Cluster, except for the listeners
+		// answering DNS queries, which forward them to their destinations
+		// directly.
+		if udpListener.DNS == nil {
+			xdsCluster, err := buildXdsCluster(udpListener.Name, udpListener.Destinations, false /*isHTTP2 */)
+			if err != nil {
+				return nil, multierror.Append(err, errors.New("error building xds cluster"))
+			}
+			setXdsLoadBalancer(xdsCluster, udpListener.LoadBalancer)
+			tCtx.AddXdsResource(resource.ClusterType, xdsCluster)
 		}
-		tCtx.AddXdsResource(resource.ClusterType, xdsCluster)
 
 		// There won't be multiple UDP listeners on the same port since it's already been checked at the gateway api
 		// translator
-		xdsListener, err := buildXdsUDPListener(xdsCluster.Name, udpListener)
+		xdsListener, err := buildXdsUDPListener(udpListener.Name, udpListener)
 		if err != nil {
 			return nil, multierror.Append(err, errors.New("error building xds cluster"))
 		}
 		tCtx.AddXdsResource(resource.ListenerType, xdsListener)
 	}
+
+	limitXdsClusterStats(tCtx, ir.MaxStatsClusters)
+	sortXdsResources(tCtx)
+
 	return tCtx, nil
 }
 
@@ -153,8 +286,8 @@ func findXdsListener(tCtx *types.ResourceVersionTable, address string, port uint
 	for _, r := range tCtx.XdsResources[resource.ListenerType] {
 		listener := r.(*listener.Listener)
 		addr := listener.GetAddress()
-		if addr.GetSocketAddress().GetPortValue() == port && addr.GetSocketAddress().Address == address && addr.
-			GetSocketAddress().Protocol == protocol {
+		if addr.GetSocketAddress().GetPortValue() == port && addr.GetSocketAddress().GetAddress() == address && addr.
+			GetSocketAddress().GetProtocol() == protocol {
 			return listener
 		}
 	}
@@ -162,6 +295,22 @@ func findXdsListener(tCtx *types.ResourceVersionTable, address string, port uint
 	return nil
 }
 
+// findXdsCluster finds a xds cluster with the name and returns nil if there is no match.
+func findXdsCluster(tCtx *types.ResourceVersionTable, name string) *cluster.Cluster {
+	if tCtx == nil || tCtx.XdsResources == nil || tCtx.XdsResources[resource.ClusterType] == nil {
+		return nil
+	}
+
+	for _, r := range tCtx.XdsResources[resource.ClusterType] {
+		cluster := r.(*cluster.Cluster)
+		if cluster.Name == name {
+			return cluster
+		}
+	}
+
+	return nil
+}
+
 // findXdsRouteConfig finds an xds route with the name and returns nil if there is no match.
 func findXdsRouteConfig(tCtx *types.ResourceVersionTable, name string) *route.RouteConfiguration {
 	if tCtx == nil || tCtx.XdsResources == nil || tCtx.XdsResources[resource.RouteType] == nil {
@@ -196,3 +345,65 @@ func makeConfigSource() *core.ConfigSource {
 	}
 	return source
 }
+
+// makeSPIFFEConfigSource returns the SDS config source of the SPIFFE Workload
+// API, served by the SPIFFE cluster of the bootstrap configuration.
+func makeSPIFFEConfigSource() *core.ConfigSource {
+	return &core.ConfigSource{
+		ResourceApiVersion: resource.DefaultAPIVersion,
+		ConfigSourceSpecifier: &core.ConfigSource_ApiConfigSource{
+			ApiConfigSource: &core.ApiConfigSource{
+				TransportApiVersion: resource.DefaultAPIVersion,
+				ApiType:             core.ApiConfigSource_GRPC,
+				GrpcServices: []*core.GrpcService{{
+					TargetSpecifier: &core.GrpcService_EnvoyGrpc_{
+						EnvoyGrpc: &core.GrpcService_EnvoyGrpc{ClusterName: bootstrap.SPIFFEClusterName},
+					},
+				}},
+			},
+		},
+	}
+}
+
+// addXdsIsolationVirtualHost adds a virtual host rejecting the requests for the
+// isolated hostnames of the listener to its route configuration, unless they
+// are served by the virtual hosts of the configuration, i.e. if the more
+// specific listeners are served by another filter chain. Otherwise, requests
+// whose Host header differs from their SNI would be routed by the virtual
+// host of the less specific listener.
+func addXdsIsolationVirtualHost(routeCfg *route.RouteConfiguration, httpListener *ir.HTTPListener) {
+	if routeCfg == nil || len(httpListener.IsolatedHostnames) == 0 {
+		return
+	}
+
+	var domains []string
+	for _, hostname := range httpListener.IsolatedHostnames {
+		served := false
+		for _, vHost := range routeCfg.VirtualHosts {
+			for _, domain := range vHost.Domains {
+				if domain == hostname {
+					served = true
+				}
+			}
+		}
+		if !served {
+			domains = append(domains, hostname)
+		}
+	}
+	if len(domains) == 0 {
+		return
+	}
+
+	routeCfg.VirtualHosts = append(routeCfg.VirtualHosts, &route.VirtualHost{
+		Name:    httpListener.Name + "-isolation",
+		Domains: domains,
+		Routes: []*route.Route{{
+			Match: &route.RouteMatch{
+				PathSpecifier: &route.RouteMatch_Prefix{Prefix: "/"},
+			},
+			Action: &route.Route_DirectResponse{
+				DirectResponse: &route.DirectResponseAction{Status: 404},
+			},
+		}},
+	})
+}
@@ -6,27 +6,20 @@
 package translator
 
 import (
-	"bytes"
 	"embed"
 	"path/filepath"
 	"testing"
 
-	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	"github.com/stretchr/testify/require"
-	"google.golang.org/protobuf/encoding/protojson"
-	"google.golang.org/protobuf/proto"
 	"sigs.k8s.io/yaml"
 
+	"github.com/envoyproxy/gateway/internal/golden"
 	"github.com/envoyproxy/gateway/internal/ir"
 )
 
-var (
-	//go:embed testdata/out/*
-	outFiles embed.FS
-	//go:embed testdata/in/*
-	inFiles embed.FS
-)
+//go:embed testdata/in/*
+var inFiles embed.FS
 
 func TestTranslate(t *testing.T) {
 	testCases := []struct {
@@ -39,6 +32,9 @@ func TestTranslate(t *testing.T) {
 		{
 			name: "http-route",
 		},
+		{
+			name: "http-route-dual-stack",
+		},
 		{
 			name: "http-route-redirect",
 		},
@@ -51,13 +47,50 @@ func TestTranslate(t *testing.T) {
 		{
 			name: "http-route-weighted-invalid-backend",
 		},
+		{
+			name: "http-route-backend-request-headers",
+		},
+		{
+			name: "http-route-host-rewrite",
+		},
+		{
+			name: "http-route-timeouts",
+		},
+		{
+			name:           "http-route-backend-tls",
+			requireSecrets: true,
+		},
+		{
+			name: "http-route-backend-tls-spiffe",
+		},
+		{
+			name: "http-route-backend-tls-alpn",
+		},
 		{
 			name:           "simple-tls",
 			requireSecrets: true,
 		},
+		{
+			name:           "simple-tls-ocsp-staple",
+			requireSecrets: true,
+		},
 		{
 			name: "tls-route-passthrough",
 		},
+		{
+			name: "tls-route-passthrough-default",
+		},
+		{
+			name:           "tcp-route-tls-terminate",
+			requireSecrets: true,
+		},
+		{
+			name:           "tcp-route-internal-listener",
+			requireSecrets: true,
+		},
+		{
+			name: "tcp-route-weighted-backend",
+		},
 		{
 			name:           "multiple-listeners-same-port",
 			requireSecrets: true,
@@ -65,9 +98,78 @@ func TestTranslate(t *testing.T) {
 		{
 			name: "udp-route",
 		},
+		{
+			name: "udp-route-session-affinity",
+		},
+		{
+			name: "udp-route-dns",
+		},
+		{
+			name: "l4-json-access-log",
+		},
 		{
 			name: "http2-route",
 		},
+		{
+			name: "http-route-ratelimit",
+		},
+		{
+			name: "http-route-jwt",
+		},
+		{
+			name: "http-route-ext-auth",
+		},
+		{
+			name: "http-route-ext-proc",
+		},
+		{
+			name: "http-route-csrf",
+		},
+		{
+			name: "http-route-bandwidth-limit",
+		},
+		{
+			name: "http-route-runtime-weights",
+		},
+		{
+			name: "http-route-response-headers",
+		},
+		{
+			name: "http-route-header-limits",
+		},
+		{
+			name: "http-route-http1",
+		},
+		{
+			name: "http-route-header-handling",
+		},
+		{
+			name: "http-route-access-log-sampling",
+		},
+		{
+			name: "http-route-listener-isolation",
+		},
+		{
+			name: "http-route-load-balancer",
+		},
+		{
+			name: "http-route-draining",
+		},
+		{
+			name: "http-route-draining-destinations",
+		},
+		{
+			name: "http-route-socket-options",
+		},
+		{
+			name: "http-route-dynamic-forward-proxy",
+		},
+		{
+			name: "http-route-priorities",
+		},
+		{
+			name: "http-route-maintenance",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -79,12 +181,12 @@ func TestTranslate(t *testing.T) {
 			listeners := tCtx.XdsResources[resource.ListenerType]
 			routes := tCtx.XdsResources[resource.RouteType]
 			clusters := tCtx.XdsResources[resource.ClusterType]
-			require.Equal(t, requireTestDataOutFile(t, "xds-ir", tc.name+".listeners.yaml"), requireResourcesToYAMLString(t, listeners))
-			require.Equal(t, requireTestDataOutFile(t, "xds-ir", tc.name+".routes.yaml"), requireResourcesToYAMLString(t, routes))
-			require.Equal(t, requireTestDataOutFile(t, "xds-ir", tc.name+".clusters.yaml"), requireResourcesToYAMLString(t, clusters))
+			golden.AssertXdsResources(t, testDataOutFile("xds-ir", tc.name+".listeners.yaml"), listeners)
+			golden.AssertXdsResources(t, testDataOutFile("xds-ir", tc.name+".routes.yaml"), routes)
+			golden.AssertXdsResources(t, testDataOutFile("xds-ir", tc.name+".clusters.yaml"), clusters)
 			if tc.requireSecrets {
 				secrets := tCtx.XdsResources[resource.SecretType]
-				require.Equal(t, requireTestDataOutFile(t, "xds-ir", tc.name+".secrets.yaml"), requireResourcesToYAMLString(t, secrets))
+				golden.AssertXdsResources(t, testDataOutFile("xds-ir", tc.name+".secrets.yaml"), secrets)
 			}
 		})
 	}
@@ -101,39 +203,7 @@ func requireXdsIRFromInputTestData(t *testing.T, name ...string) *ir.Xds {
 	return ir
 }
 
-func requireTestDataOutFile(t *testing.T, name ...string) string {
-	t.Helper()
-	elems := append([]string{"testdata", "out"}, name...)
-	content, err := outFiles.ReadFile(filepath.Join(elems...))
-	require.NoError(t, err)
-	return string(content)
-}
-
-func requireResourcesToYAMLString(t *testing.T, resources []types.Resource) string {
-	jsonBytes, err := marshalResourcesToJSON(resources)
-	require.NoError(t, err)
-	data, err := yaml.JSONToYAML(jsonBytes)
-	require.NoError(t, err)
-	return string(data)
-}
-
-func marshalResourcesToJSON(resources []types.Resource) ([]byte, error) {
-	msgs := make([]proto.Message, 0)
-	for _, resource := range resources {
-		msgs = append(msgs, resource.(proto.Message))
-	}
-	var buffer bytes.Buffer
-	buffer.WriteByte('[')
-	for idx, msg := range msgs {
-		if idx != 0 {
-			buffer.WriteByte(',')
-		}
-		b, err := protojson.Marshal(msg)
-		if err != nil {
-			return nil, err
-		}
-		buffer.Write(b)
-	}
-	buffer.WriteByte(']')
-	return buffer.Bytes(), nil
+// testDataOutFile returns the path of the golden file of the provided name.
+func testDataOutFile(name ...string) string {
+	return filepath.Join(append([]string{"testdata", "out"}, name...)...)
 }
@@ -0,0 +1,336 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+// Package translator provides the Envoy Gateway translation of Gateway API
+// resources into the IRs and xDS resources configuring Envoy, along with the
+// status conditions of the translated resources, so that it can be embedded by
+// other tools. Its API follows the Envoy Gateway API compatibility guarantees,
+// while the internal packages it is built upon may change at any time.
+package translator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/envoyproxy/gateway/api/config/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/ir"
+	xdstranslator "github.com/envoyproxy/gateway/internal/xds/translator"
+)
+
+// Resources holds the Gateway API and related resources to translate.
+type Resources struct {
+	Gateways        []*v1beta1.Gateway
+	HTTPRoutes      []*v1beta1.HTTPRoute
+	TLSRoutes       []*v1alpha2.TLSRoute
+	TCPRoutes       []*v1alpha2.TCPRoute
+	UDPRoutes       []*v1alpha2.UDPRoute
+	ReferenceGrants []*v1alpha2.ReferenceGrant
+	Namespaces      []*corev1.Namespace
+	Services        []*corev1.Service
+	Secrets         []*corev1.Secret
+	// EnvoyProxy is the optional EnvoyProxy referenced by the
+	// parametersRef of the GatewayClass.
+	EnvoyProxy             *v1alpha1.EnvoyProxy
+	BackendTrafficPolicies []*v1alpha1.BackendTrafficPolicy
+	ClientTrafficPolicies  []*v1alpha1.ClientTrafficPolicy
+	SecurityPolicies       []*v1alpha1.SecurityPolicy
+	TrafficShifts          []*v1alpha1.TrafficShift
+}
+
+// Xds summarizes the xDS IR of a Gateway, i.e. the listeners and routes
+// programmed on its proxies.
+type Xds struct {
+	HTTPListeners []HTTPListener
+	TCPListeners  []TCPListener
+	UDPListeners  []UDPListener
+}
+
+// HTTPListener is an HTTP listener of the xDS IR.
+type HTTPListener struct {
+	Name      string
+	Address   string
+	Port      uint32
+	Hostnames []string
+	// TLS is set if the listener terminates TLS connections.
+	TLS    bool
+	Routes []HTTPRoute
+}
+
+// HTTPRoute is a route of an HTTP listener of the xDS IR.
+type HTTPRoute struct {
+	Name         string
+	Destinations []Destination
+	// DirectResponseStatusCode is the status code of the response sent
+	// to the requests matching the route instead of forwarding them, or
+	// zero if they are forwarded to the destinations.
+	DirectResponseStatusCode uint32
+}
+
+// TCPListener is a TCP listener of the xDS IR.
+type TCPListener struct {
+	Name         string
+	Address      string
+	Port         uint32
+	Destinations []Destination
+}
+
+// UDPListener is a UDP listener of the xDS IR.
+type UDPListener struct {
+	Name         string
+	Address      string
+	Port         uint32
+	Destinations []Destination
+}
+
+// Destination is a backend the traffic of a route or listener is
+// forwarded to.
+type Destination struct {
+	Host   string
+	Port   uint32
+	Weight uint32
+}
+
+// Infra summarizes the infrastructure IR of a Gateway, i.e. the proxies
+// deployed for it.
+type Infra struct {
+	Name  string
+	Image string
+	Ports []InfraPort
+}
+
+// InfraPort is a port exposed by the proxies of a Gateway.
+type InfraPort struct {
+	Name          string
+	Protocol      string
+	ServicePort   int32
+	ContainerPort int32
+}
+
+// XdsResources holds xDS resources keyed by type URL.
+type XdsResources map[string][]types.Resource
+
+// Options holds the options of the Translator.
+type Options struct {
+	// GatewayClassName is the name of the GatewayClass to translate
+	// Gateways for. Required.
+	GatewayClassName string
+
+	// ProxyImage is the optional proxy image to use in the
+	// Infra IR. If unspecified, the default proxy image is used.
+	ProxyImage string
+}
+
+// Result holds the output of a translation. The IRs and xDS resources are
+// keyed by Gateway, in the "<namespace>/<name>" format.
+type Result struct {
+	// Gateways are the translated Gateways, with their status conditions set.
+	Gateways []*v1beta1.Gateway
+	// HTTPRoutes are the translated HTTPRoutes, with their status conditions set.
+	HTTPRoutes []*v1beta1.HTTPRoute
+	// TLSRoutes are the translated TLSRoutes, with their status conditions set.
+	TLSRoutes []*v1alpha2.TLSRoute
+	// TCPRoutes are the translated TCPRoutes, with their status conditions set.
+	TCPRoutes []*v1alpha2.TCPRoute
+	// UDPRoutes are the translated UDPRoutes, with their status conditions set.
+	UDPRoutes []*v1alpha2.UDPRoute
+	// BackendTrafficPolicies are the translated BackendTrafficPolicies, with
+	// their status for the resources they apply to set.
+	BackendTrafficPolicies []*v1alpha1.BackendTrafficPolicy
+	// ClientTrafficPolicies are the translated ClientTrafficPolicies, with
+	// their status for the resources they apply to set.
+	ClientTrafficPolicies []*v1alpha1.ClientTrafficPolicy
+	// SecurityPolicies are the translated SecurityPolicies, with their status
+	// for the resources they apply to set.
+	SecurityPolicies []*v1alpha1.SecurityPolicy
+	// TrafficShifts are the translated TrafficShifts, with their status for
+	// the route they apply to set.
+	TrafficShifts []*v1alpha1.TrafficShift
+	// XdsIR holds the xDS IR of each Gateway.
+	XdsIR map[string]*Xds
+	// InfraIR holds the infrastructure IR of each Gateway.
+	InfraIR map[string]*Infra
+	// Xds holds the xDS resources of each Gateway.
+	Xds map[string]XdsResources
+}
+
+// Translator translates Gateway API resources into IRs and xDS resources.
+type Translator struct {
+	opts Options
+}
+
+// New returns a Translator with the provided options.
+func New(opts Options) (*Translator, error) {
+	if opts.GatewayClassName == "" {
+		return nil, errors.New("gateway class name is required")
+	}
+	return &Translator{opts: opts}, nil
+}
+
+// Translate translates the provided resources. An error is returned if an IR
+// fails to validate or to translate to xDS, which is a translator bug rather
+// than an invalid input: invalid resources are reported through their status
+// conditions instead.
+func (t *Translator) Translate(resources *Resources) (*Result, error) {
+	if resources == nil {
+		resources = new(Resources)
+	}
+
+	gwTranslator := &gatewayapi.Translator{
+		GatewayClassName: v1beta1.ObjectName(t.opts.GatewayClassName),
+		ProxyImage:       t.opts.ProxyImage,
+	}
+	translated := gwTranslator.Translate(resources.toGatewayAPI())
+
+	result := &Result{
+		Gateways:               translated.Gateways,
+		HTTPRoutes:             translated.HTTPRoutes,
+		TLSRoutes:              translated.TLSRoutes,
+		TCPRoutes:              translated.TCPRoutes,
+		UDPRoutes:              translated.UDPRoutes,
+		BackendTrafficPolicies: translated.BackendTrafficPolicies,
+		ClientTrafficPolicies:  translated.ClientTrafficPolicies,
+		SecurityPolicies:       translated.SecurityPolicies,
+		TrafficShifts:          translated.TrafficShifts,
+		XdsIR:                  make(map[string]*Xds, len(translated.XdsIR)),
+		InfraIR:                make(map[string]*Infra, len(translated.InfraIR)),
+		Xds:                    make(map[string]XdsResources, len(translated.XdsIR)),
+	}
+
+	// The IRs are keyed by IR key, which is ambiguous, so they are keyed by
+	// the namespace and name of their Gateway instead.
+	gatewayKeys := make(map[string]string, len(translated.Gateways))
+	for _, gateway := range translated.Gateways {
+		gatewayKeys[gatewayapi.IRKey(gateway.Namespace, gateway.Name)] = gateway.Namespace + "/" + gateway.Name
+	}
+	gatewayKey := func(irKey string) string {
+		if key, ok := gatewayKeys[irKey]; ok {
+			return key
+		}
+		return irKey
+	}
+
+	for key, infraIR := range translated.InfraIR {
+		if err := infraIR.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid infra ir %s: %w", key, err)
+		}
+		result.InfraIR[gatewayKey(key)] = infraFromIR(infraIR)
+	}
+	for key, xdsIR := range translated.XdsIR {
+		if err := xdsIR.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid xds ir %s: %w", key, err)
+		}
+		tCtx, err := xdstranslator.Translate(xdsIR)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate xds ir %s: %w", key, err)
+		}
+		result.XdsIR[gatewayKey(key)] = xdsFromIR(xdsIR)
+		result.Xds[gatewayKey(key)] = XdsResources(tCtx.GetXdsResources())
+	}
+
+	return result, nil
+}
+
+// toGatewayAPI converts the resources to the resources of the Gateway API
+// translator.
+func (r *Resources) toGatewayAPI() *gatewayapi.Resources {
+	return &gatewayapi.Resources{
+		Gateways:               r.Gateways,
+		HTTPRoutes:             r.HTTPRoutes,
+		TLSRoutes:              r.TLSRoutes,
+		TCPRoutes:              r.TCPRoutes,
+		UDPRoutes:              r.UDPRoutes,
+		ReferenceGrants:        r.ReferenceGrants,
+		Namespaces:             r.Namespaces,
+		Services:               r.Services,
+		Secrets:                r.Secrets,
+		EnvoyProxy:             r.EnvoyProxy,
+		BackendTrafficPolicies: r.BackendTrafficPolicies,
+		ClientTrafficPolicies:  r.ClientTrafficPolicies,
+		SecurityPolicies:       r.SecurityPolicies,
+		TrafficShifts:          r.TrafficShifts,
+	}
+}
+
+// xdsFromIR converts an xDS IR to its summary.
+func xdsFromIR(xdsIR *ir.Xds) *Xds {
+	xds := new(Xds)
+	for _, listener := range xdsIR.HTTP {
+		httpListener := HTTPListener{
+			Name:      listener.Name,
+			Address:   listener.Address,
+			Port:      listener.Port,
+			Hostnames: listener.Hostnames,
+			TLS:       listener.TLS != nil,
+		}
+		for _, route := range listener.Routes {
+			httpRoute := HTTPRoute{
+				Name:         route.Name,
+				Destinations: destinationsFromIR(route.Destinations),
+			}
+			if route.DirectResponse != nil {
+				httpRoute.DirectResponseStatusCode = route.DirectResponse.StatusCode
+			}
+			httpListener.Routes = append(httpListener.Routes, httpRoute)
+		}
+		xds.HTTPListeners = append(xds.HTTPListeners, httpListener)
+	}
+	for _, listener := range xdsIR.TCP {
+		xds.TCPListeners = append(xds.TCPListeners, TCPListener{
+			Name:         listener.Name,
+			Address:      listener.Address,
+			Port:         listener.Port,
+			Destinations: destinationsFromIR(listener.Destinations),
+		})
+	}
+	for _, listener := range xdsIR.UDP {
+		xds.UDPListeners = append(xds.UDPListeners, UDPListener{
+			Name:         listener.Name,
+			Address:      listener.Address,
+			Port:         listener.Port,
+			Destinations: destinationsFromIR(listener.Destinations),
+		})
+	}
+	return xds
+}
+
+// destinationsFromIR converts the destinations of an xDS IR.
+func destinationsFromIR(irDestinations []*ir.RouteDestination) []Destination {
+	var destinations []Destination
+	for _, destination := range irDestinations {
+		destinations = append(destinations, Destination{
+			Host:   destination.Host,
+			Port:   destination.Port,
+			Weight: destination.Weight,
+		})
+	}
+	return destinations
+}
+
+// infraFromIR converts an infrastructure IR to its summary.
+func infraFromIR(infraIR *ir.Infra) *Infra {
+	infra := new(Infra)
+	if infraIR.Proxy == nil {
+		return infra
+	}
+	infra.Name = infraIR.Proxy.Name
+	infra.Image = infraIR.Proxy.Image
+	for _, listener := range infraIR.Proxy.Listeners {
+		for _, port := range listener.Ports {
+			infra.Ports = append(infra.Ports, InfraPort{
+				Name:          port.Name,
+				Protocol:      string(port.Protocol),
+				ServicePort:   port.ServicePort,
+				ContainerPort: port.ContainerPort,
+			})
+		}
+	}
+	return infra
+}
@@ -0,0 +1,126 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"testing"
+
+	resource "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New(Options{})
+	require.Error(t, err)
+
+	tr, err := New(Options{GatewayClassName: "envoy-gateway-class"})
+	require.NoError(t, err)
+	require.NotNil(t, tr)
+}
+
+func TestTranslate(t *testing.T) {
+	tr, err := New(Options{
+		GatewayClassName: "envoy-gateway-class",
+		ProxyImage:       "envoyproxy/envoy:translator-tests",
+	})
+	require.NoError(t, err)
+
+	port := v1beta1.PortNumber(8080)
+	pathPrefix := v1beta1.PathMatchPathPrefix
+	path := "/"
+	namespace := v1beta1.Namespace("envoy-gateway")
+	resources := &Resources{
+		Gateways: []*v1beta1.Gateway{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "envoy-gateway", Name: "gateway-1"},
+			Spec: v1beta1.GatewaySpec{
+				GatewayClassName: "envoy-gateway-class",
+				Listeners: []v1beta1.Listener{{
+					Name:     "http",
+					Protocol: v1beta1.HTTPProtocolType,
+					Port:     80,
+					AllowedRoutes: &v1beta1.AllowedRoutes{
+						Namespaces: &v1beta1.RouteNamespaces{From: fromPtr(v1beta1.NamespacesFromAll)},
+					},
+				}},
+			},
+		}},
+		HTTPRoutes: []*v1beta1.HTTPRoute{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "httproute-1"},
+			Spec: v1beta1.HTTPRouteSpec{
+				CommonRouteSpec: v1beta1.CommonRouteSpec{
+					ParentRefs: []v1beta1.ParentReference{{Namespace: &namespace, Name: "gateway-1"}},
+				},
+				Rules: []v1beta1.HTTPRouteRule{{
+					Matches: []v1beta1.HTTPRouteMatch{{
+						Path: &v1beta1.HTTPPathMatch{Type: &pathPrefix, Value: &path},
+					}},
+					BackendRefs: []v1beta1.HTTPBackendRef{{
+						BackendRef: v1beta1.BackendRef{
+							BackendObjectReference: v1beta1.BackendObjectReference{Name: "service-1", Port: &port},
+						},
+					}},
+				}},
+			},
+		}},
+		Namespaces: []*corev1.Namespace{
+			{ObjectMeta: metav1.ObjectMeta{Name: "envoy-gateway"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		},
+		Services: []*corev1.Service{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "service-1"},
+			Spec: corev1.ServiceSpec{
+				ClusterIP: "7.7.7.7",
+				Ports:     []corev1.ServicePort{{Port: 8080}},
+			},
+		}},
+	}
+
+	result, err := tr.Translate(resources)
+	require.NoError(t, err)
+
+	require.Len(t, result.Gateways, 1)
+	require.Len(t, result.HTTPRoutes, 1)
+	require.Len(t, result.HTTPRoutes[0].Status.Parents, 1)
+	require.Equal(t, &Xds{
+		HTTPListeners: []HTTPListener{{
+			Name:      "envoy-gateway-gateway-1-http",
+			Address:   "0.0.0.0",
+			Port:      10080,
+			Hostnames: []string{"*"},
+			Routes: []HTTPRoute{{
+				Name:         "default-httproute-1-rule-0-match-0-*",
+				Destinations: []Destination{{Host: "7.7.7.7", Port: 8080, Weight: 1}},
+			}},
+		}},
+	}, result.XdsIR["envoy-gateway/gateway-1"])
+	require.Equal(t, &Infra{
+		Name:  "envoy-gateway-gateway-1",
+		Image: "envoyproxy/envoy:translator-tests",
+		Ports: []InfraPort{{Name: "http", Protocol: "HTTP", ServicePort: 80, ContainerPort: 10080}},
+	}, result.InfraIR["envoy-gateway/gateway-1"])
+
+	xds := result.Xds["envoy-gateway/gateway-1"]
+	require.Len(t, xds[resource.ListenerType], 1)
+	require.Len(t, xds[resource.RouteType], 1)
+	require.Len(t, xds[resource.ClusterType], 1)
+}
+
+func TestTranslateNoResources(t *testing.T) {
+	tr, err := New(Options{GatewayClassName: "envoy-gateway-class"})
+	require.NoError(t, err)
+
+	result, err := tr.Translate(nil)
+	require.NoError(t, err)
+	require.Empty(t, result.XdsIR)
+	require.Empty(t, result.Xds)
+}
+
+func fromPtr(from v1beta1.FromNamespaces) *v1beta1.FromNamespaces {
+	return &from
+}
@@ -19,6 +19,8 @@ import (
 	"sigs.k8s.io/gateway-api/conformance/tests"
 	"sigs.k8s.io/gateway-api/conformance/utils/flags"
 	"sigs.k8s.io/gateway-api/conformance/utils/suite"
+
+	"github.com/envoyproxy/gateway/internal/status"
 )
 
 var useUniquePorts = flag.Bool("use-unique-ports", true, "whether to use unique ports")
@@ -45,13 +47,18 @@ func TestGatewayAPIConformance(t *testing.T) {
 		validUniqueListenerPorts = []v1alpha2.PortNumber{}
 	}
 
+	supportedFeatures := make([]suite.SupportedFeature, 0, len(status.SupportedFeatures))
+	for _, feature := range status.SupportedFeatures {
+		supportedFeatures = append(supportedFeatures, suite.SupportedFeature(feature))
+	}
+
 	cSuite := suite.New(suite.Options{
 		Client:                   client,
 		GatewayClassName:         *flags.GatewayClassName,
 		Debug:                    *flags.ShowDebug,
 		CleanupBaseResources:     *flags.CleanupBaseResources,
 		ValidUniqueListenerPorts: validUniqueListenerPorts,
-		SupportedFeatures:        []suite.SupportedFeature{suite.SupportHTTPRouteQueryParamMatching, suite.SupportReferenceGrant},
+		SupportedFeatures:        supportedFeatures,
 	})
 	cSuite.Setup(t)
 	egTests := []suite.ConformanceTest{
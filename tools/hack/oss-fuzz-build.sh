@@ -0,0 +1,13 @@
+#!/usr/bin/env bash
+
+# Builds the go fuzz tests as OSS-Fuzz fuzzers. It is run by the build.sh
+# script of the envoy-gateway project of OSS-Fuzz, which provides the
+# compile_native_go_fuzzer command.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+for fuzz in FuzzIntersectHostnames FuzzParentRefMatchesListener FuzzRoutePrecedenceLess; do
+  compile_native_go_fuzzer github.com/envoyproxy/gateway/internal/gatewayapi "${fuzz}" "${fuzz}"
+done
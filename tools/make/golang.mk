@@ -42,6 +42,18 @@ go.build.multiarch: $(foreach p,$(PLATFORMS),$(addprefix go.build., $(addprefix
 go.test.unit: ## Run go unit tests
 	go test ./...
 
+.PHONY: go.test.benchmark
+go.test.benchmark: ## Run go benchmarks of the translators
+	go test ./internal/benchmark/... -run '^$$' -bench . -benchmem
+
+FUZZ_TIME ?= 30s
+
+.PHONY: go.test.fuzz
+go.test.fuzz: ## Run each go fuzz test of the Gateway API translator for FUZZ_TIME
+	@for fuzz in $$(go test ./internal/gatewayapi -list '^Fuzz' | grep '^Fuzz'); do \
+		go test ./internal/gatewayapi -run '^$$' -fuzz "^$$fuzz$$" -fuzztime $(FUZZ_TIME) || exit 1; \
+	done
+
 .PHONY: go.test.coverage
 go.test.coverage: $(tools/setup-envtest) ## Run go unit and integration tests in GitHub Actions
 	KUBEBUILDER_ASSETS="$(shell $(tools/setup-envtest) use $(ENVTEST_K8S_VERSION) -p path)" go test ./... --tags=integration -race -coverprofile=coverage.xml -covermode=atomic